@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+// runCheck reports books with missing files and FB2 documents with errors,
+// it exits with 1 if anything is found.
+func runCheck(args []string) {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	cfgFlags := registerConfigFlags(flags)
+	flags.String("files", "", "directory for book files and covers, overrides storage.files")
+	warnings := false
+	flags.BoolVar(&warnings, "warnings", warnings, "report FB2 warnings too")
+	_ = flags.Parse(args)
+	cfg := cfgFlags.load(flags)
+
+	failed, err := checkBooks(context.Background(), cfg, warnings)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// checkBooks prints problems of every book and returns their number.
+func checkBooks(ctx context.Context, cfg *config.Config, warnings bool) (int, error) {
+	st, errStorage := openStorage(ctx, cfg)
+	if errStorage != nil {
+		return 0, errStorage
+	}
+	defer func() { _ = st.Close() }()
+
+	books, errBooks := st.client.Book.Query().Order(ent.Asc(book.FieldID)).All(ctx)
+	if errBooks != nil {
+		return 0, fmt.Errorf("db: %w", errBooks)
+	}
+
+	failed := 0
+	report := func(found *ent.Book, format string, args ...any) {
+		failed++
+		fmt.Printf("#%d %q: %s\n", found.ID, found.Title, fmt.Sprintf(format, args...))
+	}
+
+	for _, found := range books {
+		for _, problem := range checkBook(ctx, st.library, found, warnings) {
+			report(found, "%s", problem)
+		}
+	}
+
+	fmt.Printf("checked %d books, %d problems\n", len(books), failed)
+	return failed, nil
+}
+
+// checkBook returns problems with stored files of the book,
+// FB2 warnings are returned only with warnings set.
+// Problems of FB2 files are kept for the validation report.
+func checkBook(ctx context.Context, lib *library.Library, found *ent.Book, warnings bool) []string {
+	var problems []string
+	for _, id := range []string{found.FileID, found.CoverID} {
+		if id == "" {
+			continue
+		}
+		if errStat := statStored(lib.Files, id); errStat != nil {
+			problems = append(problems, errStat.Error())
+		}
+	}
+
+	if files.Ext(found.FileID) != "fb2" {
+		return problems
+	}
+	validated, errValidate := lib.ValidateFile(ctx, found.FileID)
+	switch {
+	case errors.Is(errValidate, fs.ErrNotExist):
+		// reported as a missing file
+	case errValidate != nil:
+		problems = append(problems, fmt.Sprintf("validate: %v", errValidate))
+	}
+	for _, problem := range validated {
+		if problem.Severity == bookinfo.SeverityError || warnings {
+			problems = append(problems, problem.String())
+		}
+	}
+	return problems
+}
+
+func statStored(store *files.Store, id string) error {
+	file, errOpen := store.Open(id)
+	if errOpen != nil {
+		return errOpen
+	}
+	return file.Close()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+)
+
+// testConfig returns defaults with a migrated SQLite database
+// and the file store in a temporary directory.
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	cfg := config.Default()
+	cfg.Database.DSN = "file:" + filepath.Join(dir, "bib.sqlite") + "?_pragma=foreign_keys(1)"
+	cfg.Database.AutoMigrate = true
+	cfg.Storage.Files = filepath.Join(dir, "files")
+	return cfg
+}
+
+// newStorage opens the storage of the config.
+func newStorage(t *testing.T, cfg *config.Config) *storage {
+	t.Helper()
+	st, err := openStorage(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = st.Close() })
+	return st
+}
+
+func TestCheckBook(t *testing.T) {
+	ctx := context.Background()
+	st := newStorage(t, testConfig(t))
+	lib, store := st.library, st.library.Files
+
+	malformed, err := store.Put(".fb2", strings.NewReader("<FictionBook><body>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a directory is opened, but can't be read
+	unreadable, err := store.Put(".fb2", strings.NewReader("unreadable"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(store.Dir, unreadable)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	missing, err := store.Put(".fb2", strings.NewReader("missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove(missing); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		fileID string
+		want   string
+	}{
+		{"malformed", malformed, "malformed XML"},
+		{"unreadable", unreadable, "validate: read:"},
+		{"missing", missing, "no such file"},
+	}
+	for _, tc := range tests {
+		problems := checkBook(ctx, lib, &ent.Book{FileID: tc.fileID}, false)
+		if len(problems) != 1 || !strings.Contains(problems[0], tc.want) {
+			t.Errorf("%s: got %q, want one problem with %q", tc.name, problems, tc.want)
+		}
+	}
+
+	// the validation report shows what the check found
+	kept, err := lib.FileProblems(ctx, []string{malformed, unreadable})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kept) != 1 || len(kept[malformed]) != 1 {
+		t.Errorf("got kept problems %v, want the malformed file's", kept)
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/ninedraft/bibliotheca/internal/config"
+)
+
+type configFlags struct {
+	path string
+}
+
+// registerConfigFlags adds flags shared by all commands.
+// Flags take precedence over the environment and the config file.
+func registerConfigFlags(flags *flag.FlagSet) *configFlags {
+	cfgFlags := &configFlags{}
+	flags.StringVar(&cfgFlags.path, "config", os.Getenv(config.EnvPrefix+"CONFIG"),
+		"TOML or YAML config file, "+config.EnvPrefix+"CONFIG by default")
+	flags.String("db-driver", "", "database driver: sqlite or postgres, overrides database.driver")
+	flags.String("db-dsn", "", "database DSN, overrides database.dsn")
+	return cfgFlags
+}
+
+// load reads the config and applies flags set on the command line.
+// Invalid configs are reported and the program exits.
+func (cfgFlags *configFlags) load(flags *flag.FlagSet) *config.Config {
+	cfg, errLoad := config.Load(cfgFlags.path)
+	if errLoad != nil {
+		fmt.Fprintln(os.Stderr, errLoad)
+		os.Exit(2)
+	}
+
+	var errFlag error
+	flags.Visit(func(f *flag.Flag) {
+		value := f.Value.String()
+		switch f.Name {
+		case "addr":
+			cfg.Server.Addr = value
+		case "files":
+			cfg.Storage.Files = value
+		case "db-driver":
+			cfg.Database.Driver = value
+		case "db-dsn":
+			cfg.Database.DSN = value
+		case "auto-migrate":
+			cfg.Database.AutoMigrate, errFlag = strconv.ParseBool(value)
+		}
+	})
+
+	if errValidate := cfg.Validate(); errValidate != nil || errFlag != nil {
+		fmt.Fprintln(os.Stderr, "invalid config:")
+		for _, err := range []error{errValidate, errFlag} {
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+		os.Exit(2)
+	}
+	return cfg
+}
+
+func runConfig(args []string) {
+	flags := flag.NewFlagSet("config", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "usage: bibliotheca config [flags] print")
+		flags.PrintDefaults()
+	}
+	cfgFlags := registerConfigFlags(flags)
+	format := "toml"
+	flags.StringVar(&format, "format", format, "output format: toml or yaml")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 || flags.Arg(0) != "print" {
+		flags.Usage()
+		os.Exit(2)
+	}
+	cfg := cfgFlags.load(flags).Redacted()
+
+	var (
+		data      []byte
+		errFormat error
+	)
+	switch format {
+	case "toml":
+		data, errFormat = cfg.TOML()
+	case "yaml":
+		data, errFormat = cfg.YAML()
+	default:
+		log.Fatalf("unknown format %q", format)
+	}
+	if errFormat != nil {
+		log.Fatal(errFormat)
+	}
+	_, _ = os.Stdout.Write(data)
+}
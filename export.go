@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+type exportedBook struct {
+	ID        int64    `json:"id"`
+	Title     string   `json:"title"`
+	WrittenAt string   `json:"written_at,omitempty"`
+	Authors   []string `json:"authors"`
+	// File is a name in the export directory or a file ID if files are not exported.
+	File  string `json:"file,omitempty"`
+	Cover string `json:"cover,omitempty"`
+}
+
+func runExport(args []string) {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "usage: bibliotheca export [flags]")
+		flags.PrintDefaults()
+	}
+	cfgFlags := registerConfigFlags(flags)
+	flags.String("files", "", "directory for book files and covers, overrides storage.files")
+	output := ""
+	flags.StringVar(&output, "o", output, "write JSON to the file instead of stdout")
+	dir := ""
+	flags.StringVar(&dir, "dir", dir, "copy book files into the directory")
+	_ = flags.Parse(args)
+	cfg := cfgFlags.load(flags)
+
+	if err := exportBooks(context.Background(), cfg, output, dir); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// exportBooks writes the catalog as JSON to the output file or to stdout
+// if it's empty, book files are copied into the directory if it's set.
+func exportBooks(ctx context.Context, cfg *config.Config, output, dir string) error {
+	st, errStorage := openStorage(ctx, cfg)
+	if errStorage != nil {
+		return errStorage
+	}
+	defer func() { _ = st.Close() }()
+
+	books, errBooks := st.client.Book.Query().
+		WithAuthors().
+		Order(ent.Asc(book.FieldID)).
+		All(ctx)
+	if errBooks != nil {
+		return fmt.Errorf("db: %w", errBooks)
+	}
+
+	if dir != "" {
+		if errDir := os.MkdirAll(dir, 0o755); errDir != nil {
+			return errDir
+		}
+	}
+
+	exported := make([]exportedBook, 0, len(books))
+	for _, found := range books {
+		item := exportedBook{
+			ID:      found.ID,
+			Title:   found.Title,
+			File:    found.FileID,
+			Cover:   found.CoverID,
+			Authors: []string{},
+		}
+		if found.WrittenAt != 0 {
+			item.WrittenAt = time.Unix(found.WrittenAt, 0).UTC().Format(time.DateOnly)
+		}
+		for _, author := range found.Edges.Authors {
+			item.Authors = append(item.Authors, author.Name)
+		}
+
+		if dir != "" && found.FileID != "" {
+			name := exportName(found)
+			if errCopy := copyStored(st.library.Files, found.FileID, filepath.Join(dir, name)); errCopy != nil {
+				return fmt.Errorf("book %d: %w", found.ID, errCopy)
+			}
+			item.File = name
+		}
+
+		exported = append(exported, item)
+	}
+
+	if output == "" {
+		return writeJSON(os.Stdout, exported)
+	}
+	out, errCreate := os.Create(output)
+	if errCreate != nil {
+		return errCreate
+	}
+	if errWrite := writeJSON(out, exported); errWrite != nil {
+		_ = out.Close()
+		return errWrite
+	}
+	return out.Close()
+}
+
+func writeJSON(out io.Writer, value any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// exportName is a file name safe for common file systems, like "12 War and Peace.fb2".
+func exportName(found *ent.Book) string {
+	title := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, found.Title)
+	if len(title) > 100 {
+		title = strings.ToValidUTF8(title[:100], "")
+	}
+	return fmt.Sprintf("%d %s.%s", found.ID, strings.TrimSpace(title), files.Ext(found.FileID))
+}
+
+func copyStored(store *files.Store, id, dst string) error {
+	src, errOpen := store.Open(id)
+	if errOpen != nil {
+		return errOpen
+	}
+	defer func() { _ = src.Close() }()
+
+	out, errCreate := os.Create(dst)
+	if errCreate != nil {
+		return errCreate
+	}
+	if _, errCopy := io.Copy(out, src); errCopy != nil {
+		_ = out.Close()
+		return errCopy
+	}
+	return out.Close()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExportBooks(t *testing.T) {
+	ctx := context.Background()
+	cfg := testConfig(t)
+	example, err := os.ReadFile("internal/bookinfo/testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := newStorage(t, cfg)
+	created, _, err := st.library.Import(ctx, "example.fb2", bytes.NewReader(example))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.client.Book.Create().SetTitle("No File").Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		file string
+	}{
+		{name: "catalog", file: created.FileID},
+		{name: "files", dir: t.TempDir(), file: "1 Fiction Book.fb2"},
+	}
+	for _, tc := range tests {
+		output := filepath.Join(t.TempDir(), "books.json")
+		if err := exportBooks(ctx, cfg, output, tc.dir); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var exported []exportedBook
+		if err := json.Unmarshal(data, &exported); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		if len(exported) != 2 {
+			t.Fatalf("%s: got %+v, want 2 books", tc.name, exported)
+		}
+		got := exported[0]
+		if got.ID != created.ID || got.Title != "Fiction Book" || !slices.Equal(got.Authors, []string{"John Doe"}) || got.File != tc.file {
+			t.Errorf("%s: got %+v", tc.name, got)
+		}
+		if noFile := exported[1]; noFile.File != "" || noFile.Authors == nil {
+			t.Errorf("%s: got %+v, want no file and an empty list of authors", tc.name, noFile)
+		}
+		if tc.dir == "" {
+			continue
+		}
+		copied, err := os.ReadFile(filepath.Join(tc.dir, tc.file))
+		if err != nil || !bytes.Equal(copied, example) {
+			t.Errorf("%s: copied file differs: %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/internal/library"
+)
+
+func runImport(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "usage: bibliotheca import [flags] <path>...")
+		_, _ = fmt.Fprintln(flags.Output(), "directories are imported recursively, files of unknown formats are skipped")
+		flags.PrintDefaults()
+	}
+	cfgFlags := registerConfigFlags(flags)
+	flags.String("files", "", "directory for book files and covers, overrides storage.files")
+	_ = flags.Parse(args)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	cfg := cfgFlags.load(flags)
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	failed, err := importPaths(ctx, cfg, flags.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if failed > 0 || ctx.Err() != nil {
+		os.Exit(1)
+	}
+}
+
+// importPaths imports books from the paths and prints a summary,
+// it returns the number of files which failed to import.
+func importPaths(ctx context.Context, cfg *config.Config, roots []string) (int, error) {
+	st, errStorage := openStorage(ctx, cfg)
+	if errStorage != nil {
+		return 0, errStorage
+	}
+	defer func() { _ = st.Close() }()
+
+	var imported, skipped, failed int
+	for _, root := range roots {
+		errWalk := filepath.WalkDir(root, func(name string, entry fs.DirEntry, errEntry error) error {
+			if errEntry != nil {
+				return errEntry
+			}
+			if entry.IsDir() || !slices.Contains(bookinfo.Formats, library.Format(name)) {
+				return nil
+			}
+			// stop between files, imported ones are skipped by the next run
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			errImport := importFile(context.WithoutCancel(ctx), st.library, name)
+			switch {
+			case errors.Is(errImport, library.ErrExistsInTrash):
+				skipped++
+				fmt.Printf("skip %s: already in the trash\n", name)
+			case errors.Is(errImport, library.ErrExists):
+				skipped++
+				fmt.Printf("skip %s: already in the catalog\n", name)
+			case errImport != nil:
+				failed++
+				fmt.Printf("fail %s: %v\n", name, errImport)
+			default:
+				imported++
+			}
+			return nil
+		})
+		if errors.Is(errWalk, context.Canceled) {
+			fmt.Println("interrupted, run import again to continue")
+			break
+		}
+		if errWalk != nil {
+			failed++
+			fmt.Printf("fail %s: %v\n", root, errWalk)
+		}
+	}
+
+	fmt.Printf("imported %d, skipped %d, failed %d\n", imported, skipped, failed)
+	return failed, nil
+}
+
+func importFile(ctx context.Context, lib *library.Library, name string) error {
+	file, errOpen := os.Open(name)
+	if errOpen != nil {
+		return errOpen
+	}
+	defer func() { _ = file.Close() }()
+
+	created, problems, errImport := lib.Import(ctx, name, file)
+	if errImport != nil {
+		return errImport
+	}
+
+	fmt.Printf("add  %s: #%d %q\n", name, created.ID, created.Title)
+	for _, problem := range problems {
+		fmt.Printf("     %s\n", problem)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFiles writes the files by their names relative to the directory.
+func writeFiles(t *testing.T, dir string, contents map[string][]byte) {
+	t.Helper()
+	for name, content := range contents {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestImportPaths(t *testing.T) {
+	ctx := context.Background()
+	cfg := testConfig(t)
+	example, err := os.ReadFile("internal/bookinfo/testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string][]byte{
+		"example.fb2": example,
+		// the same file is imported once
+		"nested/copy.fb2": example,
+		"malformed.fb2":   []byte("<FictionBook><body>"),
+		"notes.txt":       []byte("not a book"),
+	})
+
+	failed, err := importPaths(ctx, cfg, []string{dir, filepath.Join(dir, "missing")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed != 2 {
+		t.Errorf("got %d failed, want the malformed file and the missing path", failed)
+	}
+
+	// imported books are skipped by the next run
+	if failed, err := importPaths(ctx, cfg, []string{filepath.Join(dir, "example.fb2")}); err != nil || failed != 0 {
+		t.Errorf("second run: got %d failed, %v", failed, err)
+	}
+
+	st := newStorage(t, cfg)
+	books, err := st.client.Book.Query().WithAuthors().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(books) != 1 || books[0].Title != "Fiction Book" || len(books[0].Edges.Authors) != 1 {
+		t.Fatalf("got books %v, want the example", books)
+	}
+	if err := statStored(st.library.Files, books[0].FileID); err != nil {
+		t.Errorf("file: %v", err)
+	}
+}
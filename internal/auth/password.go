@@ -0,0 +1,81 @@
+// Package auth hashes passwords and session tokens, signs values
+// kept by clients and maps user roles to permissions.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var (
+	ErrPasswordMismatch = errors.New("password mismatch")
+	errHashFormat       = errors.New("unknown password hash format")
+)
+
+// argon2id parameters recommended by RFC 9106 for memory-constrained environments.
+const (
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+	argonKeyLen  = 32
+	argonSaltLen = 16
+)
+
+// MinPasswordLen is the shortest accepted password.
+const MinPasswordLen = 8
+
+// HashPassword returns the argon2id hash of the password in the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=4$salt$hash
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argonMemory, argonTime, argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPassword returns ErrPasswordMismatch if the password doesn't match the hash.
+func CheckPassword(hash, password string) error {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return errHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return errHashFormat
+	}
+
+	var (
+		memory, iterations uint32
+		threads            uint8
+	)
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return errHashFormat
+	}
+
+	salt, errSalt := base64.RawStdEncoding.DecodeString(parts[4])
+	if errSalt != nil {
+		return errHashFormat
+	}
+	key, errKey := base64.RawStdEncoding.DecodeString(parts[5])
+	if errKey != nil {
+		return errHashFormat
+	}
+
+	got := argon2.IDKey([]byte(password), salt, iterations, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(got, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
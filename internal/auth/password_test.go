@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=4$") {
+		t.Errorf("got hash %q", hash)
+	}
+	if err := CheckPassword(hash, "correct horse"); err != nil {
+		t.Errorf("right password: got %v", err)
+	}
+	if err := CheckPassword(hash, "correct horse "); !errors.Is(err, ErrPasswordMismatch) {
+		t.Errorf("wrong password: got %v, want %v", err, ErrPasswordMismatch)
+	}
+
+	// hashes are salted
+	again, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again == hash {
+		t.Error("got the same hash twice")
+	}
+
+	parts := strings.Split(hash, "$")
+	malformed := map[string]string{
+		"empty":     "",
+		"bcrypt":    "$2a$10$abcdefghijklmnopqrstuv",
+		"argon2i":   strings.Replace(hash, "argon2id", "argon2i", 1),
+		"version":   strings.Replace(hash, "v=19", "v=16", 1),
+		"params":    strings.Replace(hash, "m=65536,t=3,p=4", "m=65536", 1),
+		"salt":      strings.Replace(hash, parts[4], "!!", 1),
+		"no digest": strings.Join(parts[:5], "$"),
+	}
+	for name, bad := range malformed {
+		if err := CheckPassword(bad, "correct horse"); !errors.Is(err, errHashFormat) {
+			t.Errorf("%s: got %v, want %v", name, err, errHashFormat)
+		}
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	token, err := NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == other || len(token) != 43 {
+		t.Errorf("got tokens %q and %q", token, other)
+	}
+	if HashToken(token) != HashToken(token) || HashToken(token) == HashToken(other) {
+		t.Error("hashes of tokens don't match the tokens")
+	}
+	if HashToken(token) == token {
+		t.Error("the hash is the token")
+	}
+}
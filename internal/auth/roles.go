@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"slices"
+
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Permission is an action a handler requires.
+type Permission string
+
+const (
+	ReadCatalog  Permission = "read_catalog"
+	Download     Permission = "download"
+	Upload       Permission = "upload"
+	EditMetadata Permission = "edit_metadata"
+	Delete       Permission = "delete"
+	ManageUsers  Permission = "manage_users"
+	// Circulate is lending and taking back paper copies.
+	Circulate Permission = "circulate"
+	// Reserve is placing holds on paper copies.
+	Reserve Permission = "reserve"
+	// Review is rating books and writing reviews.
+	Review Permission = "review"
+	// Moderate is hiding reviews of other users.
+	Moderate Permission = "moderate"
+)
+
+// Permissions lists all permissions.
+var Permissions = []Permission{ReadCatalog, Download, Reserve, Review, Upload, EditMetadata, Delete, Circulate, Moderate, ManageUsers}
+
+// Roles lists roles from the least to the most privileged.
+var Roles = []user.Role{user.RoleReader, user.RoleLibrarian, user.RoleAdmin}
+
+var rolePermissions = map[user.Role][]Permission{
+	user.RoleReader:    {ReadCatalog, Download, Reserve, Review},
+	user.RoleLibrarian: {ReadCatalog, Download, Reserve, Review, Upload, EditMetadata, Delete, Circulate, Moderate},
+	user.RoleAdmin:     {ReadCatalog, Download, Reserve, Review, Upload, EditMetadata, Delete, Circulate, Moderate, ManageUsers},
+}
+
+// AnonymousPermissions are granted to visitors without an account
+// when browsing is public.
+var AnonymousPermissions = []Permission{ReadCatalog, Download}
+
+// Allowed reports whether the role grants the permission.
+func Allowed(role user.Role, perm Permission) bool {
+	return slices.Contains(rolePermissions[role], perm)
+}
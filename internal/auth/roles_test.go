@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+func TestAllowed(t *testing.T) {
+	// rows are permissions, columns are reader, librarian and admin
+	matrix := map[Permission][3]bool{
+		ReadCatalog:  {true, true, true},
+		Download:     {true, true, true},
+		Reserve:      {true, true, true},
+		Review:       {true, true, true},
+		Upload:       {false, true, true},
+		EditMetadata: {false, true, true},
+		Delete:       {false, true, true},
+		Circulate:    {false, true, true},
+		Moderate:     {false, true, true},
+		ManageUsers:  {false, false, true},
+	}
+	if len(matrix) != len(Permissions) {
+		t.Fatalf("the matrix has %d permissions, want all %d", len(matrix), len(Permissions))
+	}
+
+	for _, perm := range Permissions {
+		want, ok := matrix[perm]
+		if !ok {
+			t.Errorf("%s is missing from the matrix", perm)
+			continue
+		}
+		for i, role := range Roles {
+			if got := Allowed(role, perm); got != want[i] {
+				t.Errorf("%s %s: got %v, want %v", role, perm, got, want[i])
+			}
+		}
+	}
+
+	if Allowed(user.Role("guest"), ReadCatalog) {
+		t.Error("unknown role is allowed to read the catalog")
+	}
+}
+
+func TestRolesAreOrdered(t *testing.T) {
+	// each role has all permissions of the less privileged ones
+	for i := 1; i < len(Roles); i++ {
+		for _, perm := range Permissions {
+			if Allowed(Roles[i-1], perm) && !Allowed(Roles[i], perm) {
+				t.Errorf("%s has %s, %s doesn't", Roles[i-1], perm, Roles[i])
+			}
+		}
+	}
+}
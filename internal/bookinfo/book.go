@@ -1,12 +1,54 @@
 package bookinfo
 
-import "time"
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
 
 type Book struct {
-	Title      string
-	WrittenAt  time.Time
-	Authors    []string
-	Genres     []string
-	Language   string
-	Annotation string
+	Title        string
+	Series       string
+	SeriesNumber string
+	WrittenAt    time.Time
+	Authors      []string
+	Illustrators []string
+	Genres       []string
+	Keywords     []string
+	Publisher    string
+	ISBN         string
+	Language     string
+	Annotation   string
+	// Pages is 0 if the format has no fixed pagination.
+	Pages int
+	// Cover is nil if the book has no cover image or it can't be extracted.
+	Cover *Image
+}
+
+type Image struct {
+	ContentType string
+	Data        []byte
+}
+
+var ErrUnknownFormat = errors.New("unknown book format")
+
+// Formats lists file extensions supported by Parse.
+var Formats = []string{"fb2", "pdf", "cbz", "mobi", "azw3"}
+
+// Parse reads book metadata in the format given by the file extension
+// without the leading dot.
+func Parse(format string, input io.Reader) (*Book, error) {
+	switch strings.ToLower(format) {
+	case "fb2":
+		return ParseFB2(input)
+	case "pdf":
+		return ParsePDF(input)
+	case "cbz":
+		return ParseCBZ(input)
+	case "mobi", "azw3":
+		return ParseMOBI(input)
+	default:
+		return nil, ErrUnknownFormat
+	}
 }
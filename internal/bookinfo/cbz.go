@@ -0,0 +1,210 @@
+package bookinfo
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+)
+
+// ParseCBZ reads a comic book archive. Metadata is taken from ComicInfo.xml
+// if present, the cover is the first image in natural sort order.
+// A malformed ComicInfo.xml is logged and ignored, so the book is imported
+// under the archive name with pages counted from the images.
+func ParseCBZ(input io.Reader) (*Book, error) {
+	data, errRead := io.ReadAll(input)
+	if errRead != nil {
+		return nil, fmt.Errorf("read: %w", errRead)
+	}
+
+	archive, errZip := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if errZip != nil {
+		return nil, fmt.Errorf("zip: %w", errZip)
+	}
+
+	book := &Book{}
+	var pages []*zip.File
+	for _, file := range archive.File {
+		name := file.Name
+		switch {
+		case file.FileInfo().IsDir(), isHiddenPath(name):
+			continue
+		case strings.EqualFold(path.Base(name), "ComicInfo.xml"):
+			info := &Book{}
+			if errInfo := readComicInfo(info, file); errInfo != nil {
+				log.Printf("ERROR: %s: %v", name, errInfo)
+				continue
+			}
+			book = info
+		case isComicPage(name):
+			pages = append(pages, file)
+		}
+	}
+
+	if book.Pages == 0 {
+		book.Pages = len(pages)
+	}
+
+	sort.Slice(pages, func(i, j int) bool {
+		return naturalLess(pages[i].Name, pages[j].Name)
+	})
+
+	if len(pages) > 0 {
+		cover, errCover := readZipFile(pages[0])
+		if errCover != nil {
+			return nil, fmt.Errorf("cover %s: %w", pages[0].Name, errCover)
+		}
+		book.Cover = &Image{
+			ContentType: mime.TypeByExtension(strings.ToLower(path.Ext(pages[0].Name))),
+			Data:        cover,
+		}
+	}
+
+	return book, nil
+}
+
+func readComicInfo(book *Book, file *zip.File) error {
+	data, errRead := readZipFile(file)
+	if errRead != nil {
+		return errRead
+	}
+
+	doc, errParse := xmlquery.Parse(bytes.NewReader(data))
+	if errParse != nil {
+		return fmt.Errorf("xml parse: %w", errParse)
+	}
+
+	text := func(name string) string {
+		node := xmlquery.FindOne(doc, "/ComicInfo/"+name)
+		if node == nil {
+			return ""
+		}
+		return strings.TrimSpace(node.InnerText())
+	}
+
+	book.Title = text("Title")
+	book.Series = text("Series")
+	book.SeriesNumber = text("Number")
+	book.Authors = splitNames(text("Writer"))
+	book.Illustrators = splitNames(text("Penciller"))
+	book.Genres = splitNames(text("Genre"))
+	book.Keywords = splitNames(text("Tags"))
+	book.Annotation = text("Summary")
+	book.Language = text("LanguageISO")
+	book.Pages, _ = strconv.Atoi(text("PageCount"))
+
+	if book.Title == "" && book.Series != "" {
+		book.Title = strings.TrimSpace(book.Series + " #" + book.SeriesNumber)
+	}
+
+	if year, err := strconv.Atoi(text("Year")); err == nil {
+		month, errMonth := strconv.Atoi(text("Month"))
+		if errMonth != nil || month < 1 || month > 12 {
+			month = 1
+		}
+		day, errDay := strconv.Atoi(text("Day"))
+		if errDay != nil || day < 1 || day > 31 {
+			day = 1
+		}
+		book.WrittenAt = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+
+	return nil
+}
+
+// maxCBZFileSize limits files read from comic archives, page scans
+// are a few MB, larger files are zip bombs.
+const maxCBZFileSize = 32 << 20
+
+var errCBZFileSize = fmt.Errorf("file is larger than %d bytes", maxCBZFileSize)
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	if file.UncompressedSize64 > maxCBZFileSize {
+		return nil, errCBZFileSize
+	}
+	rc, errOpen := file.Open()
+	if errOpen != nil {
+		return nil, errOpen
+	}
+	defer func() { _ = rc.Close() }()
+
+	// the size in the header comes from the file
+	data, errRead := io.ReadAll(io.LimitReader(rc, maxCBZFileSize+1))
+	switch {
+	case errRead != nil:
+		return nil, errRead
+	case len(data) > maxCBZFileSize:
+		return nil, errCBZFileSize
+	}
+	return data, nil
+}
+
+// splitNames splits ComicInfo lists, which are comma separated.
+func splitNames(names string) []string {
+	var result []string
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func isHiddenPath(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+func isComicPage(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp", ".bmp":
+		return true
+	}
+	return false
+}
+
+// naturalLess compares strings treating digit runs as numbers,
+// so "page2.jpg" goes before "page10.jpg".
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		aDigits, bDigits := leadingDigits(a), leadingDigits(b)
+		if aDigits != "" && bDigits != "" {
+			aNum, bNum := strings.TrimLeft(aDigits, "0"), strings.TrimLeft(bDigits, "0")
+			if len(aNum) != len(bNum) {
+				return len(aNum) < len(bNum)
+			}
+			if aNum != bNum {
+				return aNum < bNum
+			}
+			a, b = a[len(aDigits):], b[len(bDigits):]
+			continue
+		}
+
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
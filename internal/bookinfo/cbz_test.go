@@ -0,0 +1,207 @@
+package bookinfo
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+// buildCBZ packs files into an archive in the given order,
+// names ending with a slash are directories.
+func buildCBZ(t *testing.T, files ...string) []byte {
+	t.Helper()
+	out := &bytes.Buffer{}
+	zw := zip.NewWriter(out)
+	for i := 0; i < len(files); i += 2 {
+		w, err := zw.Create(files[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(files[i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+const testComicInfo = `<?xml version="1.0"?>
+<ComicInfo xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <Series>Saga</Series>
+  <Number>3</Number>
+  <Summary>Space opera.</Summary>
+  <Year>2012</Year>
+  <Month>13</Month>
+  <Day>5</Day>
+  <Writer>Brian K. Vaughan</Writer>
+  <Penciller>Fiona Staples, Ann Lee</Penciller>
+  <Genre>Science Fiction, Fantasy</Genre>
+  <Tags>space,,war</Tags>
+  <LanguageISO>en</LanguageISO>
+  <PageCount>24</PageCount>
+</ComicInfo>`
+
+func TestParseCBZ(t *testing.T) {
+	data := buildCBZ(t,
+		"saga/page10.png", "p10",
+		"saga/page2.jpg", "p2",
+		"saga/.thumb.jpg", "hidden",
+		"__MACOSX/saga/._page1.jpg", "resource fork",
+		"saga/notes.txt", "not a page",
+		"saga/ComicInfo.xml", testComicInfo,
+		"saga/", "",
+	)
+
+	got, err := ParseCBZ(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Book{
+		Title:        "Saga #3",
+		Series:       "Saga",
+		SeriesNumber: "3",
+		// a month out of range is replaced with January
+		WrittenAt:    time.Date(2012, time.January, 5, 0, 0, 0, 0, time.UTC),
+		Authors:      []string{"Brian K. Vaughan"},
+		Illustrators: []string{"Fiona Staples", "Ann Lee"},
+		Genres:       []string{"Science Fiction", "Fantasy"},
+		Keywords:     []string{"space", "war"},
+		Language:     "en",
+		Annotation:   "Space opera.",
+		Pages:        24,
+	}
+	if got.Title != want.Title || got.Series != want.Series || got.SeriesNumber != want.SeriesNumber ||
+		!got.WrittenAt.Equal(want.WrittenAt) || got.Language != want.Language ||
+		got.Annotation != want.Annotation || got.Pages != want.Pages {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	for _, list := range []struct{ got, want []string }{
+		{got.Authors, want.Authors},
+		{got.Illustrators, want.Illustrators},
+		{got.Genres, want.Genres},
+		{got.Keywords, want.Keywords},
+	} {
+		if !slices.Equal(list.got, list.want) {
+			t.Errorf("got %q, want %q", list.got, list.want)
+		}
+	}
+
+	// the cover is the first page in natural order, not in the archive order
+	if got.Cover == nil || string(got.Cover.Data) != "p2" || got.Cover.ContentType != "image/jpeg" {
+		t.Errorf("got cover %+v, want page2.jpg", got.Cover)
+	}
+}
+
+func TestParseCBZWithoutComicInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		pages int
+		cover string
+	}{
+		{
+			name:  "no ComicInfo",
+			files: []string{"b/01.png", "1", "a/10.png", "10", "a/9.png", "9"},
+			pages: 3,
+			cover: "9",
+		},
+		{
+			name:  "malformed ComicInfo",
+			files: []string{"ComicInfo.xml", "<ComicInfo><Title>Broken</ComicInfo>", "2.gif", "2", "1.gif", "1"},
+			pages: 2,
+			cover: "1",
+		},
+		{
+			name:  "no page count",
+			files: []string{"ComicInfo.xml", "<ComicInfo><Title>Short</Title></ComicInfo>", "1.webp", "1"},
+			pages: 1,
+			cover: "1",
+		},
+		{
+			name:  "no pages",
+			files: []string{"readme.txt", "text"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseCBZ(bytes.NewReader(buildCBZ(t, tc.files...)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Pages != tc.pages {
+				t.Errorf("got %d pages, want %d", got.Pages, tc.pages)
+			}
+			if tc.cover == "" && got.Cover != nil || tc.cover != "" && (got.Cover == nil || string(got.Cover.Data) != tc.cover) {
+				t.Errorf("got cover %+v, want %q", got.Cover, tc.cover)
+			}
+			// the title is left to the archive name
+			if got.Title == "Broken" {
+				t.Errorf("got title %q from a malformed ComicInfo", got.Title)
+			}
+		})
+	}
+}
+
+func TestParseCBZTooLarge(t *testing.T) {
+	huge := string(make([]byte, maxCBZFileSize+1))
+
+	// the cover can't be skipped
+	_, err := ParseCBZ(bytes.NewReader(buildCBZ(t, "1.png", huge, "2.png", "2")))
+	if !errors.Is(err, errCBZFileSize) {
+		t.Errorf("huge cover: got %v, want %v", err, errCBZFileSize)
+	}
+
+	// ComicInfo.xml is ignored like a malformed one
+	got, err := ParseCBZ(bytes.NewReader(buildCBZ(t, "ComicInfo.xml", huge, "1.png", "1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Pages != 1 || got.Cover == nil || string(got.Cover.Data) != "1" {
+		t.Errorf("huge ComicInfo: got %d pages, cover %+v", got.Pages, got.Cover)
+	}
+}
+
+func TestParseCBZNotZip(t *testing.T) {
+	if _, err := ParseCBZ(bytes.NewReader([]byte("not a zip"))); err == nil {
+		t.Error("got no error")
+	}
+}
+
+func TestNaturalLess(t *testing.T) {
+	names := []string{
+		"page10.jpg",
+		"page2.jpg",
+		"page002b.jpg",
+		"page1.jpg",
+		"Page3.jpg",
+		"page",
+		"page02.jpg",
+		"cover.jpg",
+		"ch2/page1.jpg",
+		"ch10/page1.jpg",
+	}
+	want := []string{
+		"Page3.jpg",
+		"ch2/page1.jpg",
+		"ch10/page1.jpg",
+		"cover.jpg",
+		"page",
+		"page1.jpg",
+		"page2.jpg",
+		"page02.jpg",
+		"page002b.jpg",
+		"page10.jpg",
+	}
+	sort.SliceStable(names, func(i, j int) bool { return naturalLess(names[i], names[j]) })
+	if !slices.Equal(names, want) {
+		t.Errorf("got %q, want %q", names, want)
+	}
+}
@@ -1,47 +1,181 @@
 package bookinfo
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/antchfx/xmlquery"
 )
 
+var errFB2NoTitleInfo = errors.New("fb2: description/title-info not found")
+
+const fb2Namespace = "http://www.gribuser.ru/xml/fictionbook/2.0"
+
 func ParseFB2(input io.Reader) (*Book, error) {
-	doc, errParse := xmlquery.Parse(input)
+	doc, errParse := parseFB2XML(input)
 	if errParse != nil {
 		return nil, fmt.Errorf("xml parse: %w", errParse)
 	}
 
+	info := xmlquery.FindOne(doc, "//description/title-info")
+	if info == nil {
+		return nil, errFB2NoTitleInfo
+	}
+
 	book := &Book{
-		Title:    xmlquery.FindOne(doc, "//title").InnerText(),
-		Language: xmlquery.FindOne(doc, "//lang").InnerText(),
+		Title:    childText(info, "book-title"),
+		Language: childText(info, "lang"),
+		Keywords: splitKeywords(childText(info, "keywords")),
+	}
+
+	for _, author := range xmlquery.Find(info, "author") {
+		book.Authors = append(book.Authors, fb2AuthorName(author))
 	}
 
-	for _, author := range xmlquery.Find(doc, "//author") {
-		firstname := xmlquery.FindOne(author, "//first-name")
-		lastname := xmlquery.FindOne(author, "//last-name")
-		name := concatXMLTexts(" ", firstname, lastname)
-		book.Authors = append(book.Authors, name)
+	if annotation := xmlquery.FindOne(info, "annotation"); annotation != nil {
+		book.Annotation = fb2Paragraphs(annotation)
 	}
 
-	if annotation := xmlquery.FindOne(doc, "//annotation"); annotation != nil {
-		book.Annotation = annotation.InnerText()
+	for _, genre := range xmlquery.Find(info, "genre") {
+		book.Genres = append(book.Genres, strings.TrimSpace(genre.InnerText()))
 	}
 
-	for _, genre := range xmlquery.Find(doc, "//genre") {
-		book.Genres = append(book.Genres, genre.InnerText())
+	if date := xmlquery.FindOne(info, "date"); date != nil {
+		book.WrittenAt = parseFB2Date(date)
+	}
+
+	if sequence := xmlquery.FindOne(info, "sequence"); sequence != nil {
+		book.Series = sequence.SelectAttr("name")
+		book.SeriesNumber = sequence.SelectAttr("number")
+	}
+
+	if image := xmlquery.FindOne(info, "coverpage/image"); image != nil {
+		book.Cover = fb2Binary(doc, strings.TrimPrefix(AttrLocal(image, "href"), "#"))
 	}
 
 	return book, nil
 }
 
+// parseFB2XML parses the document with namespace prefixes of FB2 elements
+// dropped, so queries by plain names find <fb:body> as well as <body>.
+func parseFB2XML(input io.Reader) (*xmlquery.Node, error) {
+	doc, err := xmlquery.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	dropFB2Prefixes(doc)
+	return doc, nil
+}
+
+func dropFB2Prefixes(node *xmlquery.Node) {
+	if node.Type == xmlquery.ElementNode && node.NamespaceURI == fb2Namespace {
+		node.Prefix = ""
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		dropFB2Prefixes(child)
+	}
+}
+
+func childText(node *xmlquery.Node, name string) string {
+	child := xmlquery.FindOne(node, name)
+	if child == nil {
+		return ""
+	}
+	return strings.TrimSpace(child.InnerText())
+}
+
+// fb2AuthorName joins name parts of the author element,
+// the nickname is used only if there are no other parts.
+func fb2AuthorName(author *xmlquery.Node) string {
+	name := concatXMLTexts(" ",
+		xmlquery.FindOne(author, "first-name"),
+		xmlquery.FindOne(author, "middle-name"),
+		xmlquery.FindOne(author, "last-name"))
+	if name == "" {
+		name = childText(author, "nickname")
+	}
+	return name
+}
+
+// fb2Paragraphs returns text of the element with paragraphs separated by newlines.
+func fb2Paragraphs(node *xmlquery.Node) string {
+	paragraphs := xmlquery.Find(node, "p")
+	if len(paragraphs) == 0 {
+		return strings.TrimSpace(node.InnerText())
+	}
+	var texts []string
+	for _, p := range paragraphs {
+		if text := strings.TrimSpace(p.InnerText()); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func parseFB2Date(date *xmlquery.Node) time.Time {
+	for _, value := range []string{date.SelectAttr("value"), strings.TrimSpace(date.InnerText())} {
+		for _, layout := range []string{time.DateOnly, "2006-01", "2006", "02.01.2006"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func fb2Binary(doc *xmlquery.Node, id string) *Image {
+	if id == "" {
+		return nil
+	}
+	for _, binary := range xmlquery.Find(doc, "//binary") {
+		if binary.SelectAttr("id") != id {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(stripSpaces(binary.InnerText()))
+		if err != nil {
+			return nil
+		}
+		return &Image{
+			ContentType: binary.SelectAttr("content-type"),
+			Data:        data,
+		}
+	}
+	return nil
+}
+
+// AttrLocal returns value of the attribute ignoring its namespace,
+// FB2 files use both "l:" and "xlink:" prefixes for links.
+func AttrLocal(node *xmlquery.Node, local string) string {
+	for _, attr := range node.Attr {
+		if attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func stripSpaces(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', '\r':
+			return -1
+		}
+		return r
+	}, s)
+}
+
 func concatXMLTexts(sep string, nodes ...*xmlquery.Node) string {
 	var texts []string
 	for _, node := range nodes {
-		if node != nil {
-			texts = append(texts, node.InnerText())
+		if node == nil {
+			continue
+		}
+		if text := strings.TrimSpace(node.InnerText()); text != "" {
+			texts = append(texts, text)
 		}
 	}
 	return strings.Join(texts, sep)
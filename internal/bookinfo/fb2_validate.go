@@ -0,0 +1,263 @@
+package bookinfo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+)
+
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (severity Severity) String() string {
+	switch severity {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(severity))
+	}
+}
+
+// Problem describes a single violation of the FB2 schema or a broken reference.
+type Problem struct {
+	Severity Severity
+	// Location is an XPath of the offending node.
+	Location string
+	Message  string
+}
+
+func (problem Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", problem.Severity, problem.Location, problem.Message)
+}
+
+// ValidateFB2 checks the document for problems which break readers or
+// hide the book from the catalog: missing required metadata, unknown genres,
+// dangling image references and undecodable binaries.
+// Malformed XML is reported as a problem, the error is returned only
+// if the input can't be read.
+func ValidateFB2(input io.Reader) ([]Problem, error) {
+	data, errRead := io.ReadAll(input)
+	if errRead != nil {
+		return nil, fmt.Errorf("read: %w", errRead)
+	}
+
+	v := &fb2Validator{}
+	doc, errParse := parseFB2XML(bytes.NewReader(data))
+	if errParse != nil {
+		v.report(SeverityError, "/", "malformed XML: %v", errParse)
+		return v.problems, nil
+	}
+
+	root := xmlquery.FindOne(doc, "/FictionBook")
+	if root == nil {
+		v.report(SeverityError, "/", "root element FictionBook is missing")
+		return v.problems, nil
+	}
+	if root.NamespaceURI != fb2Namespace {
+		v.report(SeverityWarning, "/FictionBook", "unexpected namespace %q", root.NamespaceURI)
+	}
+
+	v.titleInfo(root)
+	v.documentInfo(root)
+
+	if xmlquery.FindOne(root, "body") == nil {
+		v.report(SeverityError, "/FictionBook", "body is missing")
+	}
+
+	v.binaries(root)
+	v.images(root)
+
+	return v.problems, nil
+}
+
+type fb2Validator struct {
+	problems  []Problem
+	binaryIDs map[string]bool
+}
+
+func (v *fb2Validator) report(severity Severity, location, format string, args ...any) {
+	v.problems = append(v.problems, Problem{
+		Severity: severity,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *fb2Validator) titleInfo(root *xmlquery.Node) {
+	info := xmlquery.FindOne(root, "description/title-info")
+	if info == nil {
+		v.report(SeverityError, "/FictionBook/description", "title-info is missing")
+		return
+	}
+	location := xpathOf(info)
+
+	genres := xmlquery.Find(info, "genre")
+	if len(genres) == 0 {
+		v.report(SeverityError, location, "no genres")
+	}
+	for _, genre := range genres {
+		code := strings.TrimSpace(genre.InnerText())
+		if !fb2Genres[code] {
+			v.report(SeverityWarning, xpathOf(genre), "unknown genre code %q", code)
+		}
+	}
+
+	authors := xmlquery.Find(info, "author")
+	if len(authors) == 0 {
+		v.report(SeverityError, location, "no authors")
+	}
+	for _, author := range authors {
+		v.author(author)
+	}
+
+	if childText(info, "book-title") == "" {
+		v.report(SeverityError, location, "book-title is missing or empty")
+	}
+
+	if childText(info, "lang") == "" {
+		v.report(SeverityError, location, "lang is missing or empty")
+	}
+
+	if date := xmlquery.FindOne(info, "date"); date != nil {
+		v.date(date)
+	}
+
+	for _, sequence := range xmlquery.Find(info, "sequence") {
+		if sequence.SelectAttr("name") == "" {
+			v.report(SeverityWarning, xpathOf(sequence), "sequence without name")
+		}
+	}
+}
+
+func (v *fb2Validator) author(author *xmlquery.Node) {
+	hasNames := childText(author, "first-name") != "" && childText(author, "last-name") != ""
+	if !hasNames && childText(author, "nickname") == "" {
+		v.report(SeverityWarning, xpathOf(author), "author must have first and last names or a nickname")
+	}
+}
+
+func (v *fb2Validator) date(date *xmlquery.Node) {
+	value := date.SelectAttr("value")
+	if value == "" {
+		return
+	}
+	for _, layout := range []string{time.DateOnly, "2006-01", "2006"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return
+		}
+	}
+	v.report(SeverityWarning, xpathOf(date), "date value %q is not an ISO date", value)
+}
+
+func (v *fb2Validator) documentInfo(root *xmlquery.Node) {
+	info := xmlquery.FindOne(root, "description/document-info")
+	if info == nil {
+		v.report(SeverityWarning, "/FictionBook/description", "document-info is missing")
+		return
+	}
+	if childText(info, "id") == "" {
+		v.report(SeverityWarning, xpathOf(info), "document id is missing")
+	}
+}
+
+func (v *fb2Validator) binaries(root *xmlquery.Node) {
+	v.binaryIDs = map[string]bool{}
+	for _, binary := range xmlquery.Find(root, "binary") {
+		location := xpathOf(binary)
+		id := binary.SelectAttr("id")
+		switch {
+		case id == "":
+			v.report(SeverityError, location, "binary without id")
+		case v.binaryIDs[id]:
+			v.report(SeverityError, location, "duplicate binary id %q", id)
+		}
+		v.binaryIDs[id] = true
+
+		if binary.SelectAttr("content-type") == "" {
+			v.report(SeverityWarning, location, "binary without content-type")
+		}
+		if _, err := base64.StdEncoding.DecodeString(stripSpaces(binary.InnerText())); err != nil {
+			v.report(SeverityError, location, "invalid base64: %v", err)
+		}
+	}
+}
+
+func (v *fb2Validator) images(root *xmlquery.Node) {
+	for _, image := range xmlquery.Find(root, "//image") {
+		href := AttrLocal(image, "href")
+		switch {
+		case href == "":
+			v.report(SeverityError, xpathOf(image), "image without href")
+		case !strings.HasPrefix(href, "#"):
+			v.report(SeverityWarning, xpathOf(image), "external image %q", href)
+		case !v.binaryIDs[strings.TrimPrefix(href, "#")]:
+			v.report(SeverityError, xpathOf(image), "dangling image reference %q", href)
+		}
+	}
+}
+
+// xpathOf returns an absolute XPath of the element with positions
+// among siblings of the same name, like /FictionBook/body/section[2].
+func xpathOf(node *xmlquery.Node) string {
+	var steps []string
+	for ; node != nil && node.Type == xmlquery.ElementNode; node = node.Parent {
+		position, total := 0, 0
+		for sibling := node.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+			if sibling.Type == xmlquery.ElementNode && sibling.Data == node.Data {
+				total++
+				if sibling == node {
+					position = total
+				}
+			}
+		}
+		step := node.Data
+		if total > 1 {
+			step = fmt.Sprintf("%s[%d]", step, position)
+		}
+		steps = append([]string{step}, steps...)
+	}
+	return "/" + strings.Join(steps, "/")
+}
+
+// fb2Genres lists genre codes defined by the FB2 2.1 genre list.
+var fb2Genres = map[string]bool{}
+
+func init() {
+	codes := `
+		sf_history sf_action sf_epic sf_heroic sf_detective sf_cyberpunk sf_space
+		sf_social sf_horror sf_humor sf_fantasy sf sf_etc
+		det_classic det_police det_action det_irony det_history det_espionage
+		det_crime det_political det_maniac det_hard thriller detective
+		prose_classic prose_history prose_contemporary prose_counter
+		prose_rus_classic prose_su_classics
+		love_contemporary love_history love_detective love_short love_erotica
+		adv_western adv_history adv_indian adv_maritime adv_geo adv_animal adventure
+		child_tale child_verse child_prose child_sf child_det child_adv
+		child_education children
+		poetry dramaturgy
+		antique_ant antique_european antique_russian antique_east antique_myths antique
+		sci_history sci_psychology sci_culture sci_religion sci_philosophy
+		sci_politics sci_business sci_juris sci_linguistic sci_medicine sci_phys
+		sci_math sci_chem sci_biology sci_tech science
+		comp_www comp_programming comp_hard comp_soft comp_db comp_osnet computers
+		ref_encyc ref_dict ref_ref ref_guide reference
+		nonf_biography nonf_publicism nonf_criticism design nonfiction
+		religion_rel religion_esoterics religion_self religion
+		humor_anecdote humor_prose humor_verse humor
+		home_cooking home_pets home_crafts home_entertain home_health home_garden
+		home_diy home_sport home_sex home`
+	for _, code := range strings.Fields(codes) {
+		fb2Genres[code] = true
+	}
+}
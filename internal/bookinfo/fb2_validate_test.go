@@ -0,0 +1,236 @@
+package bookinfo
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const (
+	validTitleInfo = `<genre>sf</genre><author><first-name>Ann</first-name><last-name>Lee</last-name></author>` +
+		`<book-title>Title</book-title><lang>en</lang>`
+	validDocumentInfo = `<document-info><id>doc-1</id></document-info>`
+	validBody         = `<body><section><p>text</p></section></body>`
+	coverBinary       = `<binary id="cover.png" content-type="image/png">iVBORw0KGgo=</binary>`
+)
+
+// fb2Doc builds a document from the parts of the description and the rest.
+func fb2Doc(titleInfo, documentInfo, rest string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0" xmlns:l="http://www.w3.org/1999/xlink">` +
+		`<description><title-info>` + titleInfo + `</title-info>` + documentInfo + `</description>` +
+		rest + `</FictionBook>`
+}
+
+func TestValidateFB2(t *testing.T) {
+	const titleInfo = "/FictionBook/description/title-info"
+
+	tests := []struct {
+		name string
+		doc  string
+		// messages are compared by prefix
+		want []Problem
+	}{
+		{
+			name: "valid",
+			doc:  fb2Doc(validTitleInfo, validDocumentInfo, validBody),
+		},
+		{
+			name: "valid with a cover",
+			doc: fb2Doc(validTitleInfo+`<coverpage><image l:href="#cover.png"/></coverpage>`,
+				validDocumentInfo, validBody+coverBinary),
+		},
+		{
+			name: "valid with a prefixed namespace",
+			doc: `<?xml version="1.0"?><fb:FictionBook xmlns:fb="http://www.gribuser.ru/xml/fictionbook/2.0">` +
+				`<fb:description><fb:title-info><fb:genre>sf</fb:genre><fb:author><fb:nickname>ann</fb:nickname></fb:author>` +
+				`<fb:book-title>Title</fb:book-title><fb:lang>en</fb:lang></fb:title-info>` +
+				`<fb:document-info><fb:id>1</fb:id></fb:document-info></fb:description>` +
+				`<fb:body><fb:p>text</fb:p></fb:body></fb:FictionBook>`,
+		},
+		{
+			name: "malformed XML",
+			doc:  `<FictionBook><description></FictionBook>`,
+			want: []Problem{{SeverityError, "/", "malformed XML"}},
+		},
+		{
+			name: "not FB2",
+			doc:  `<?xml version="1.0"?><html><body/></html>`,
+			want: []Problem{{SeverityError, "/", "root element FictionBook is missing"}},
+		},
+		{
+			name: "unexpected namespace",
+			doc: strings.Replace(fb2Doc(validTitleInfo, validDocumentInfo, validBody),
+				"http://www.gribuser.ru/xml/fictionbook/2.0", "http://example.com/fb", 1),
+			want: []Problem{{SeverityWarning, "/FictionBook", `unexpected namespace "http://example.com/fb"`}},
+		},
+		{
+			name: "no title-info",
+			doc: `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0"><description>` +
+				validDocumentInfo + `</description>` + validBody + `</FictionBook>`,
+			want: []Problem{{SeverityError, "/FictionBook/description", "title-info is missing"}},
+		},
+		{
+			name: "no genres",
+			doc:  fb2Doc(strings.Replace(validTitleInfo, "<genre>sf</genre>", "", 1), validDocumentInfo, validBody),
+			want: []Problem{{SeverityError, titleInfo, "no genres"}},
+		},
+		{
+			name: "unknown genre",
+			doc:  fb2Doc(`<genre>sf</genre><genre>cyberpunk</genre>`+validTitleInfo[len("<genre>sf</genre>"):], validDocumentInfo, validBody),
+			want: []Problem{{SeverityWarning, titleInfo + "/genre[2]", `unknown genre code "cyberpunk"`}},
+		},
+		{
+			name: "no authors",
+			doc: fb2Doc(`<genre>sf</genre><book-title>Title</book-title><lang>en</lang>`,
+				validDocumentInfo, validBody),
+			want: []Problem{{SeverityError, titleInfo, "no authors"}},
+		},
+		{
+			name: "author without a last name",
+			doc: fb2Doc(validTitleInfo+`<author><first-name>Bob</first-name></author>`,
+				validDocumentInfo, validBody),
+			want: []Problem{{SeverityWarning, titleInfo + "/author[2]", "author must have first and last names or a nickname"}},
+		},
+		{
+			name: "empty book-title",
+			doc:  fb2Doc(strings.Replace(validTitleInfo, "Title", " ", 1), validDocumentInfo, validBody),
+			want: []Problem{{SeverityError, titleInfo, "book-title is missing or empty"}},
+		},
+		{
+			name: "no lang",
+			doc:  fb2Doc(strings.Replace(validTitleInfo, "<lang>en</lang>", "", 1), validDocumentInfo, validBody),
+			want: []Problem{{SeverityError, titleInfo, "lang is missing or empty"}},
+		},
+		{
+			name: "date is not ISO",
+			doc:  fb2Doc(validTitleInfo+`<date value="18.07.2011">18.07.2011</date>`, validDocumentInfo, validBody),
+			want: []Problem{{SeverityWarning, titleInfo + "/date", `date value "18.07.2011" is not an ISO date`}},
+		},
+		{
+			name: "partial dates",
+			doc:  fb2Doc(validTitleInfo+`<date value="2011-07">July 2011</date>`, validDocumentInfo, validBody),
+		},
+		{
+			name: "sequence without name",
+			doc:  fb2Doc(validTitleInfo+`<sequence number="1"/>`, validDocumentInfo, validBody),
+			want: []Problem{{SeverityWarning, titleInfo + "/sequence", "sequence without name"}},
+		},
+		{
+			name: "no document-info",
+			doc:  fb2Doc(validTitleInfo, "", validBody),
+			want: []Problem{{SeverityWarning, "/FictionBook/description", "document-info is missing"}},
+		},
+		{
+			name: "no document id",
+			doc:  fb2Doc(validTitleInfo, `<document-info><id></id></document-info>`, validBody),
+			want: []Problem{{SeverityWarning, "/FictionBook/description/document-info", "document id is missing"}},
+		},
+		{
+			name: "no body",
+			doc:  fb2Doc(validTitleInfo, validDocumentInfo, ""),
+			want: []Problem{{SeverityError, "/FictionBook", "body is missing"}},
+		},
+		{
+			name: "binaries",
+			doc: fb2Doc(validTitleInfo, validDocumentInfo, validBody+
+				`<binary content-type="image/png">iVBORw0KGgo=</binary>`+
+				`<binary id="a.png" content-type="image/png">iVBORw0KGgo=</binary>`+
+				`<binary id="a.png" content-type="image/png">iVBORw0KGgo=</binary>`+
+				`<binary id="b.png">iVBORw0KGgo=</binary>`+
+				`<binary id="c.png" content-type="image/png">not base64!</binary>`),
+			want: []Problem{
+				{SeverityError, "/FictionBook/binary[1]", "binary without id"},
+				{SeverityError, "/FictionBook/binary[3]", `duplicate binary id "a.png"`},
+				{SeverityWarning, "/FictionBook/binary[4]", "binary without content-type"},
+				{SeverityError, "/FictionBook/binary[5]", "invalid base64"},
+			},
+		},
+		{
+			name: "images",
+			doc: fb2Doc(validTitleInfo, validDocumentInfo,
+				`<body><section><p>text</p></section><section><image l:href="#cover.png"/>`+
+					`<image/><image l:href="http://example.com/a.png"/><image xlink:href="#missing.png" xmlns:xlink="http://www.w3.org/1999/xlink"/>`+
+					`</section></body>`+coverBinary),
+			want: []Problem{
+				{SeverityError, "/FictionBook/body/section[2]/image[2]", "image without href"},
+				{SeverityWarning, "/FictionBook/body/section[2]/image[3]", `external image "http://example.com/a.png"`},
+				{SeverityError, "/FictionBook/body/section[2]/image[4]", `dangling image reference "#missing.png"`},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ValidateFB2(strings.NewReader(tc.doc))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d problems, want %d: %v", len(got), len(tc.want), got)
+			}
+			for i, want := range tc.want {
+				if got[i].Severity != want.Severity || got[i].Location != want.Location ||
+					!strings.HasPrefix(got[i].Message, want.Message) {
+					t.Errorf("got %s, want %s", got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFB2Example(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems, err := ValidateFB2(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the example has no errors, but a genre out of the list and an empty id
+	for _, problem := range problems {
+		if problem.Severity != SeverityWarning {
+			t.Errorf("got %s", problem)
+		}
+	}
+	if len(problems) != 2 {
+		t.Errorf("got %v, want 2 warnings", problems)
+	}
+}
+
+func TestValidateFB2Written(t *testing.T) {
+	src, err := os.ReadFile("testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := ParseFB2(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	book.Title = "Another Book"
+	book.Authors = []string{"Ann Lee"}
+	before, err := ValidateFB2(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// downloads with edited metadata are as valid as the upload
+	after, err := ValidateFB2(bytes.NewReader(writeFB2(t, src, book)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(after) != len(before) {
+		t.Errorf("got %v, want %v", after, before)
+	}
+}
+
+func TestValidateFB2ReadError(t *testing.T) {
+	errRead := errors.New("disk failure")
+	if _, err := ValidateFB2(iotest.ErrReader(errRead)); !errors.Is(err, errRead) {
+		t.Errorf("got %v, want %v", err, errRead)
+	}
+}
@@ -0,0 +1,482 @@
+package bookinfo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+)
+
+const fb2HistoryNote = "Metadata updated by bibliotheca"
+
+// WriteFB2Metadata copies the FB2 document from src to dst, replacing
+// description/title-info with the book metadata and bumping the
+// document-info version with a history note.
+// Everything else, including the body and binaries, is copied byte for byte.
+// Elements which are not described by Book, like coverpage or translator,
+// are preserved, as well as authors whose names are not changed.
+func WriteFB2Metadata(dst io.Writer, src io.Reader, book *Book) error {
+	data, errRead := io.ReadAll(src)
+	if errRead != nil {
+		return fmt.Errorf("read: %w", errRead)
+	}
+
+	layout, errScan := scanFB2(data)
+	if errScan != nil {
+		return errScan
+	}
+	if layout.titleInfo == nil {
+		return errFB2NoTitleInfo
+	}
+
+	current, errParse := ParseFB2(bytes.NewReader(data))
+	if errParse != nil {
+		return errParse
+	}
+
+	w := &fb2Writer{data: data, layout: layout}
+	if errEnc := w.setEncoding(layout.encoding); errEnc != nil {
+		return errEnc
+	}
+
+	edits := []fb2Edit{w.titleInfo(book, current)}
+	if layout.documentInfo != nil {
+		edits = append(edits, w.documentInfo()...)
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	offset := 0
+	for _, edit := range edits {
+		if _, err := dst.Write(data[offset:edit.start]); err != nil {
+			return err
+		}
+		if _, err := dst.Write(edit.text); err != nil {
+			return err
+		}
+		offset = edit.end
+	}
+	_, errWrite := dst.Write(data[offset:])
+	return errWrite
+}
+
+// fb2Element holds byte offsets of an element in the source document.
+type fb2Element struct {
+	name       string
+	prefix     string
+	start, end int
+	// inner content boundaries
+	innerStart, innerEnd int
+	children             []*fb2Element
+}
+
+type fb2Layout struct {
+	encoding     string
+	titleInfo    *fb2Element
+	documentInfo *fb2Element
+}
+
+// scanFB2 finds offsets of title-info and document-info elements.
+// Input is decoded as is, with non-ASCII bytes masked, so the offsets
+// match the source document in any ASCII-compatible encoding.
+func scanFB2(data []byte) (*fb2Layout, error) {
+	layout := &fb2Layout{encoding: "utf-8"}
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		layout.encoding = label
+		return asciiMasker{input}, nil
+	}
+
+	var stack []*fb2Element
+	for {
+		start := int(dec.InputOffset())
+		token, errToken := dec.RawToken()
+		if errors.Is(errToken, io.EOF) {
+			break
+		}
+		if errToken != nil {
+			return nil, fmt.Errorf("xml parse: %w", errToken)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			elem := &fb2Element{
+				name:       token.Name.Local,
+				prefix:     token.Name.Space,
+				start:      start,
+				innerStart: int(dec.InputOffset()),
+			}
+			if n := len(stack); n > 0 {
+				parent := stack[n-1]
+				parent.children = append(parent.children, elem)
+			}
+			stack = append(stack, elem)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("xml parse: unexpected end element %s", token.Name.Local)
+			}
+			elem := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			elem.innerEnd, elem.end = start, int(dec.InputOffset())
+			// self-closing elements produce no bytes for the end token
+			if elem.innerEnd < elem.innerStart {
+				elem.innerEnd = elem.innerStart
+			}
+
+			if len(stack) == 2 && stack[1].name == "description" {
+				switch elem.name {
+				case "title-info":
+					layout.titleInfo = elem
+				case "document-info":
+					layout.documentInfo = elem
+				}
+			}
+			if len(stack) == 1 && stack[0].name == "FictionBook" && elem.name == "description" {
+				return layout, nil
+			}
+		}
+	}
+
+	return layout, nil
+}
+
+type asciiMasker struct{ r io.Reader }
+
+func (m asciiMasker) Read(p []byte) (int, error) {
+	n, err := m.r.Read(p)
+	for i, c := range p[:n] {
+		if c >= 0x80 {
+			p[i] = '?'
+		}
+	}
+	return n, err
+}
+
+type fb2Edit struct {
+	start, end int
+	text       []byte
+}
+
+type fb2Writer struct {
+	data    []byte
+	layout  *fb2Layout
+	encoder *encoding.Encoder
+}
+
+func (w *fb2Writer) setEncoding(label string) error {
+	if strings.EqualFold(label, "utf-8") || strings.EqualFold(label, "utf8") {
+		return nil
+	}
+	enc, name := charset.Lookup(label)
+	switch {
+	case enc == nil:
+		return fmt.Errorf("fb2: unknown encoding %q", label)
+	case name == "utf-8":
+		return nil
+	case strings.HasPrefix(name, "utf-16"):
+		return fmt.Errorf("fb2: encoding %q is not supported", label)
+	}
+	w.encoder = enc.NewEncoder()
+	return nil
+}
+
+func (w *fb2Writer) raw(elem *fb2Element) []byte {
+	return w.data[elem.start:elem.end]
+}
+
+// indents returns whitespace before the first child and before the end tag.
+func (w *fb2Writer) indents(elem *fb2Element) (child, closing string) {
+	child, closing = "\n", "\n"
+	if len(elem.children) > 0 {
+		first, last := elem.children[0], elem.children[len(elem.children)-1]
+		if ws := w.data[elem.innerStart:first.start]; len(bytes.TrimSpace(ws)) == 0 {
+			child = string(ws)
+		}
+		if ws := w.data[last.end:elem.innerEnd]; len(bytes.TrimSpace(ws)) == 0 {
+			closing = string(ws)
+		}
+	}
+	return child, closing
+}
+
+// text escapes and encodes the string to the document encoding,
+// runes which can't be encoded are written as character references.
+func (w *fb2Writer) text(s string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(s))
+	if w.encoder == nil {
+		return escaped.String()
+	}
+
+	var encoded strings.Builder
+	for _, r := range escaped.String() {
+		if chunk, err := w.encoder.String(string(r)); err == nil {
+			encoded.WriteString(chunk)
+		} else {
+			fmt.Fprintf(&encoded, "&#%d;", r)
+		}
+	}
+	return encoded.String()
+}
+
+func (w *fb2Writer) tag(prefix, name string) string {
+	if prefix != "" {
+		return prefix + ":" + name
+	}
+	return name
+}
+
+// fb2TitleInfoOrder lists title-info children in schema order.
+var fb2TitleInfoOrder = []string{
+	"genre", "author", "book-title", "annotation", "keywords", "date",
+	"coverpage", "lang", "src-lang", "translator", "sequence",
+}
+
+// titleInfo rebuilds title-info, original elements are kept as is
+// if the corresponding value is not changed.
+func (w *fb2Writer) titleInfo(book, current *Book) fb2Edit {
+	info := w.layout.titleInfo
+	indent, closing := w.indents(info)
+	p := info.prefix
+
+	original := map[string][]*fb2Element{}
+	for _, child := range info.children {
+		original[child.name] = append(original[child.name], child)
+	}
+
+	var children []string
+	simple := func(name, value string) {
+		if value != "" {
+			children = append(children, fmt.Sprintf("<%[1]s>%[2]s</%[1]s>", w.tag(p, name), w.text(value)))
+		}
+	}
+	keep := func(name string) {
+		for _, elem := range original[name] {
+			children = append(children, string(w.raw(elem)))
+		}
+	}
+
+	for _, name := range fb2TitleInfoOrder {
+		switch name {
+		case "genre":
+			if len(book.Genres) == 0 || slices.Equal(book.Genres, current.Genres) {
+				keep(name)
+				continue
+			}
+			for _, genre := range book.Genres {
+				simple(name, genre)
+			}
+		case "author":
+			if len(book.Authors) == 0 {
+				keep(name)
+				continue
+			}
+			for _, author := range book.Authors {
+				children = append(children, w.author(p, author, current.Authors, original[name]))
+			}
+		case "book-title":
+			if book.Title == current.Title {
+				keep(name)
+				continue
+			}
+			simple(name, book.Title)
+		case "annotation":
+			if book.Annotation == current.Annotation {
+				keep(name)
+				continue
+			}
+			var paragraphs []string
+			for _, line := range strings.Split(book.Annotation, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					paragraphs = append(paragraphs, fmt.Sprintf("<%[1]s>%[2]s</%[1]s>", w.tag(p, "p"), w.text(line)))
+				}
+			}
+			if len(paragraphs) > 0 {
+				children = append(children, fmt.Sprintf("<%[1]s>%[2]s</%[1]s>", w.tag(p, name), strings.Join(paragraphs, "")))
+			}
+		case "keywords":
+			if slices.Equal(book.Keywords, current.Keywords) {
+				keep(name)
+				continue
+			}
+			simple(name, strings.Join(book.Keywords, ", "))
+		case "date":
+			if book.WrittenAt.Format(time.DateOnly) == current.WrittenAt.Format(time.DateOnly) {
+				keep(name)
+				continue
+			}
+			if !book.WrittenAt.IsZero() {
+				children = append(children, fmt.Sprintf(`<%[1]s value="%[2]s">%[3]d</%[1]s>`,
+					w.tag(p, name), book.WrittenAt.Format(time.DateOnly), book.WrittenAt.Year()))
+			}
+		case "lang":
+			if book.Language == "" || book.Language == current.Language {
+				keep(name)
+				continue
+			}
+			simple(name, book.Language)
+		case "sequence":
+			if book.Series == current.Series && book.SeriesNumber == current.SeriesNumber {
+				keep(name)
+				continue
+			}
+			if book.Series == "" {
+				continue
+			}
+			number := ""
+			if _, err := strconv.Atoi(book.SeriesNumber); err == nil {
+				number = fmt.Sprintf(` number="%s"`, book.SeriesNumber)
+			}
+			children = append(children, fmt.Sprintf(`<%s name="%s"%s/>`, w.tag(p, name), w.text(book.Series), number))
+		default:
+			keep(name)
+		}
+	}
+
+	// elements unknown to the schema go last
+	for _, child := range info.children {
+		if !slices.Contains(fb2TitleInfoOrder, child.name) {
+			children = append(children, string(w.raw(child)))
+		}
+	}
+
+	var text strings.Builder
+	text.Write(w.data[info.start:info.innerStart])
+	for _, child := range children {
+		text.WriteString(indent)
+		text.WriteString(child)
+	}
+	text.WriteString(closing)
+	text.Write(w.data[info.innerEnd:info.end])
+
+	return fb2Edit{start: info.start, end: info.end, text: []byte(text.String())}
+}
+
+// author reuses the original element if the name is unchanged,
+// so nicknames, emails and ids are preserved.
+// Names of the original elements are listed in the same order.
+func (w *fb2Writer) author(prefix, name string, names []string, original []*fb2Element) string {
+	if i := slices.Index(names, name); i >= 0 && i < len(original) {
+		return string(w.raw(original[i]))
+	}
+
+	parts := strings.Fields(name)
+	var elems [][2]string
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		elems = [][2]string{{"nickname", parts[0]}}
+	case 2:
+		elems = [][2]string{{"first-name", parts[0]}, {"last-name", parts[1]}}
+	default:
+		elems = [][2]string{
+			{"first-name", parts[0]},
+			{"middle-name", strings.Join(parts[1:len(parts)-1], " ")},
+			{"last-name", parts[len(parts)-1]},
+		}
+	}
+
+	var author strings.Builder
+	fmt.Fprintf(&author, "<%s>", w.tag(prefix, "author"))
+	for _, elem := range elems {
+		fmt.Fprintf(&author, "<%[1]s>%[2]s</%[1]s>", w.tag(prefix, elem[0]), w.text(elem[1]))
+	}
+	fmt.Fprintf(&author, "</%s>", w.tag(prefix, "author"))
+	return author.String()
+}
+
+// documentInfo bumps the version and adds the note to the history,
+// missing ones are added in schema order: after id, before publishers.
+func (w *fb2Writer) documentInfo() []fb2Edit {
+	info := w.layout.documentInfo
+	indent, _ := w.indents(info)
+	p := info.prefix
+
+	var version, history, publisher *fb2Element
+	for _, child := range info.children {
+		switch child.name {
+		case "version":
+			version = child
+		case "history":
+			history = child
+		case "publisher":
+			if publisher == nil {
+				publisher = child
+			}
+		}
+	}
+
+	note := fmt.Sprintf("<%[1]s>%[2]s</%[1]s>", w.tag(p, "p"), w.text(fb2HistoryNote))
+	newVersion := fmt.Sprintf("<%[1]s>1.0</%[1]s>", w.tag(p, "version"))
+	newHistory := fmt.Sprintf("<%[1]s>%[2]s</%[1]s>", w.tag(p, "history"), note)
+
+	// insert places the element before the next sibling, or last if there is none
+	insert := func(next *fb2Element, elem string) fb2Edit {
+		if next != nil {
+			return fb2Edit{start: next.start, end: next.start, text: []byte(elem + indent)}
+		}
+		return fb2Edit{start: info.innerEnd, end: info.innerEnd, text: []byte(indent + elem)}
+	}
+
+	var edits []fb2Edit
+	switch {
+	case version != nil:
+		current := strings.TrimSpace(string(w.data[version.innerStart:version.innerEnd]))
+		edits = append(edits, fb2Edit{
+			start: version.innerStart,
+			end:   version.innerEnd,
+			text:  []byte(bumpFB2Version(current)),
+		})
+	case history != nil:
+		edits = append(edits, insert(history, newVersion))
+	default:
+		edits = append(edits, insert(publisher, newVersion+indent+newHistory))
+	}
+
+	switch {
+	case history != nil:
+		historyIndent, _ := w.indents(history)
+		at := history.innerEnd
+		if n := len(history.children); n > 0 {
+			at = history.children[n-1].end
+		}
+		edits = append(edits, fb2Edit{start: at, end: at, text: []byte(historyIndent + note)})
+	case version != nil:
+		edits = append(edits, fb2Edit{
+			start: version.end,
+			end:   version.end,
+			text:  []byte(indent + newHistory),
+		})
+	}
+
+	return edits
+}
+
+// bumpFB2Version increments the version by one in its last decimal place,
+// versions are floats according to the FB2 schema.
+func bumpFB2Version(version string) string {
+	value, err := strconv.ParseFloat(version, 64)
+	if err != nil {
+		return "1.0"
+	}
+	decimals := 1
+	if dot := strings.IndexByte(version, '.'); dot >= 0 && len(version)-dot-1 > decimals {
+		decimals = len(version) - dot - 1
+	}
+	step := 1.0
+	for i := 0; i < decimals; i++ {
+		step /= 10
+	}
+	return strconv.FormatFloat(value+step, 'f', decimals, 64)
+}
@@ -0,0 +1,290 @@
+package bookinfo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testFB2 is a document with a prefixed namespace, a cover binary
+// and a body with markup the writer must not touch.
+const testFB2 = `<?xml version="1.0" encoding="UTF-8"?>
+<fb:FictionBook xmlns:fb="http://www.gribuser.ru/xml/fictionbook/2.0" xmlns:l="http://www.w3.org/1999/xlink">
+ <fb:description>
+  <fb:title-info>
+   <fb:genre>sf</fb:genre>
+   <fb:author><fb:first-name>Stanisław</fb:first-name><fb:last-name>Lem</fb:last-name><fb:id>42</fb:id></fb:author>
+   <fb:book-title>Solaris</fb:book-title>
+   <fb:date value="1961-01-01">1961</fb:date>
+   <fb:coverpage><fb:image l:href="#cover.png"/></fb:coverpage>
+   <fb:lang>pl</fb:lang>
+  </fb:title-info>
+  <fb:document-info>
+   <fb:author><fb:nickname>scanner</fb:nickname></fb:author>
+   <fb:date>2001</fb:date>
+   <fb:id>solaris-1</fb:id>
+   <fb:version>2.15</fb:version>
+   <fb:history><fb:p>scanned</fb:p></fb:history>
+  </fb:document-info>
+ </fb:description>
+ <fb:body>
+  <fb:section><fb:p>Kelvin  arrived   at the <fb:emphasis>station</fb:emphasis> &amp; waited.</fb:p><fb:empty-line/></fb:section>
+ </fb:body>
+ <fb:binary id="cover.png" content-type="image/png">iVBORw0KGgo=
+ </fb:binary>
+</fb:FictionBook>
+`
+
+func writeFB2(t *testing.T, src []byte, book *Book) []byte {
+	t.Helper()
+	out := &bytes.Buffer{}
+	if err := WriteFB2Metadata(out, bytes.NewReader(src), book); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+// splitFB2 cuts the document around title-info and document-info.
+func splitFB2(t *testing.T, data []byte) (head, between, tail []byte) {
+	t.Helper()
+	titleStart := bytes.Index(data, []byte("<fb:title-info>"))
+	titleEnd := bytes.Index(data, []byte("</fb:title-info>"))
+	docStart := bytes.Index(data, []byte("<fb:document-info>"))
+	docEnd := bytes.Index(data, []byte("</fb:document-info>"))
+	if titleStart < 0 || titleEnd < 0 || docStart < 0 || docEnd < 0 {
+		t.Fatalf("no title-info or document-info in:\n%s", data)
+	}
+	return data[:titleStart], data[titleEnd:docStart], data[docEnd:]
+}
+
+func TestWriteFB2Metadata(t *testing.T) {
+	src := []byte(testFB2)
+	book := &Book{
+		Title:     "Solaris & Eden",
+		Authors:   []string{"Stanisław Lem", "Anna Maria Nowak"},
+		WrittenAt: time.Date(1959, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	out := writeFB2(t, src, book)
+
+	// everything outside of the metadata is copied as is
+	srcHead, srcBetween, srcTail := splitFB2(t, src)
+	outHead, outBetween, outTail := splitFB2(t, out)
+	if !bytes.Equal(srcHead, outHead) || !bytes.Equal(srcBetween, outBetween) || !bytes.Equal(srcTail, outTail) {
+		t.Errorf("content outside of the metadata is changed:\n%s", out)
+	}
+
+	got, err := ParseFB2(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != book.Title || !slices.Equal(got.Authors, book.Authors) || !got.WrittenAt.Equal(book.WrittenAt) {
+		t.Errorf("got %q by %q written at %v", got.Title, got.Authors, got.WrittenAt)
+	}
+	// untouched elements are kept
+	if got.Language != "pl" || len(got.Genres) != 1 || got.Cover == nil {
+		t.Errorf("lost metadata: lang %q, genres %q, cover %v", got.Language, got.Genres, got.Cover)
+	}
+
+	for _, want := range []string{
+		// the unchanged author keeps the id
+		"<fb:author><fb:first-name>Stanisław</fb:first-name><fb:last-name>Lem</fb:last-name><fb:id>42</fb:id></fb:author>",
+		"<fb:author><fb:first-name>Anna</fb:first-name><fb:middle-name>Maria</fb:middle-name><fb:last-name>Nowak</fb:last-name></fb:author>",
+		"<fb:book-title>Solaris &amp; Eden</fb:book-title>",
+		`<fb:date value="1959-05-01">1959</fb:date>`,
+		"<fb:version>2.16</fb:version>",
+		"<fb:history><fb:p>scanned</fb:p><fb:p>" + fb2HistoryNote + "</fb:p></fb:history>",
+	} {
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("no %s in:\n%s", want, out)
+		}
+	}
+	if bytes.Contains(out, []byte("<book-title>")) || bytes.Contains(out, []byte("<p>")) {
+		t.Errorf("elements without the namespace prefix:\n%s", out)
+	}
+}
+
+func TestWriteFB2MetadataExample(t *testing.T) {
+	src, err := os.ReadFile("testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	book, err := ParseFB2(bytes.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	book.Title = "Another Book"
+	out := writeFB2(t, src, book)
+
+	// the body is copied byte for byte
+	body := src[bytes.Index(src, []byte("</description>")):]
+	if !bytes.HasSuffix(out, body) {
+		t.Errorf("the body is changed:\n%s", out)
+	}
+	got, err := ParseFB2(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Another Book" || !slices.Equal(got.Authors, book.Authors) || got.Annotation != "Hello" || len(got.Keywords) != 3 {
+		t.Errorf("got %+v", got)
+	}
+}
+
+// documentInfoChildren returns names of document-info children
+// and the text of its version.
+func documentInfoChildren(t *testing.T, data []byte) (names []string, version string) {
+	t.Helper()
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	depth, inside := 0, false
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return names, version
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			depth++
+			if token.Name.Local == "document-info" {
+				inside = true
+				depth = 0
+			}
+			if inside && depth == 1 {
+				names = append(names, token.Name.Local)
+			}
+			if inside && token.Name.Local == "version" {
+				var text string
+				if err := dec.DecodeElement(&text, &token); err != nil {
+					t.Fatal(err)
+				}
+				version = text
+				depth--
+			}
+		case xml.EndElement:
+			depth--
+			if token.Name.Local == "document-info" {
+				inside = false
+			}
+		}
+	}
+}
+
+func TestWriteFB2MetadataDocumentInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    string
+		want    []string
+		version string
+		notes   int
+	}{
+		{
+			name:    "version and history",
+			info:    "<id>1</id><version>1.9</version><history><p>made</p></history><publisher><nickname>p</nickname></publisher>",
+			want:    []string{"id", "version", "history", "publisher"},
+			version: "2.0",
+			notes:   2,
+		},
+		{
+			name:    "no history",
+			info:    "<id>1</id><version>1.0</version><publisher><nickname>p</nickname></publisher>",
+			want:    []string{"id", "version", "history", "publisher"},
+			version: "1.1",
+			notes:   1,
+		},
+		{
+			name:    "no version, history",
+			info:    "<id>1</id><history><p>made</p></history>",
+			want:    []string{"id", "version", "history"},
+			version: "1.0",
+			notes:   2,
+		},
+		{
+			name:    "no version and history, publishers",
+			info:    "<id>1</id><publisher><nickname>p</nickname></publisher><publisher><nickname>q</nickname></publisher>",
+			want:    []string{"id", "version", "history", "publisher", "publisher"},
+			version: "1.0",
+			notes:   1,
+		},
+		{
+			name:    "no version and history",
+			info:    "<id>1</id>",
+			want:    []string{"id", "version", "history"},
+			version: "1.0",
+			notes:   1,
+		},
+		{
+			name:    "unparsable version",
+			info:    "<id>1</id><version>draft</version>",
+			want:    []string{"id", "version", "history"},
+			version: "1.0",
+			notes:   1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			src := `<?xml version="1.0"?><FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0"><description>` +
+				`<title-info><book-title>Old</book-title></title-info><document-info>` + tc.info + `</document-info>` +
+				`</description><body><p>text</p></body></FictionBook>`
+			out := writeFB2(t, []byte(src), &Book{Title: "New"})
+
+			names, version := documentInfoChildren(t, out)
+			if !slices.Equal(names, tc.want) {
+				t.Errorf("children: got %v, want %v", names, tc.want)
+			}
+			if version != tc.version {
+				t.Errorf("version: got %q, want %q", version, tc.version)
+			}
+			if got := strings.Count(string(out), "<p>"); got != tc.notes+1 {
+				t.Errorf("got %d history notes, want %d:\n%s", got-1, tc.notes, out)
+			}
+		})
+	}
+}
+
+func TestWriteFB2MetadataEncoding(t *testing.T) {
+	// "Книга" in windows-1251
+	src := []byte("<?xml version=\"1.0\" encoding=\"windows-1251\"?>\n" +
+		"<FictionBook xmlns=\"http://www.gribuser.ru/xml/fictionbook/2.0\"><description><title-info>" +
+		"<book-title>\xca\xed\xe8\xe3\xe0</book-title></title-info></description>" +
+		"<body><p>\xca\xed\xe8\xe3\xe0</p></body></FictionBook>")
+	out := writeFB2(t, src, &Book{Title: "Новая книга ☃"})
+
+	if !bytes.Contains(out, []byte("<body><p>\xca\xed\xe8\xe3\xe0</p></body>")) {
+		t.Errorf("the body is changed:\n%q", out)
+	}
+	// runes out of the encoding are written as character references
+	if !bytes.Contains(out, []byte("<book-title>\xcd\xee\xe2\xe0\xff \xea\xed\xe8\xe3\xe0 &#9731;</book-title>")) {
+		t.Errorf("the title is not encoded:\n%q", out)
+	}
+	got, err := ParseFB2(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Новая книга ☃" {
+		t.Errorf("got %q", got.Title)
+	}
+}
+
+func TestBumpFB2Version(t *testing.T) {
+	tests := map[string]string{
+		"1.0":  "1.1",
+		"1.9":  "2.0",
+		"2.15": "2.16",
+		"3":    "3.1",
+		"":     "1.0",
+		"x":    "1.0",
+	}
+	for version, want := range tests {
+		if got := bumpFB2Version(version); got != want {
+			t.Errorf("%q: got %q, want %q", version, got, want)
+		}
+	}
+}
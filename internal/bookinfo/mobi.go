@@ -0,0 +1,224 @@
+package bookinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+var errMOBIFormat = errors.New("mobi: bad format")
+
+// ParseMOBI reads metadata of MOBI and AZW3 (KF8) books from the PalmDB
+// container: the MOBI header of the first record and its EXTH records.
+// Book text is not decoded, so DRM-protected files are supported too.
+func ParseMOBI(input io.Reader) (*Book, error) {
+	data, errRead := io.ReadAll(input)
+	if errRead != nil {
+		return nil, fmt.Errorf("read: %w", errRead)
+	}
+
+	records, errRecords := palmDBRecords(data)
+	if errRecords != nil {
+		return nil, errRecords
+	}
+
+	header := records[0]
+	// PalmDOC header takes 16 bytes, the MOBI header follows
+	if len(header) < 16+116 || string(header[16:20]) != "MOBI" {
+		return nil, fmt.Errorf("%w: MOBI header not found", errMOBIFormat)
+	}
+	headerLen := int(binary.BigEndian.Uint32(header[20:]))
+	encoding := binary.BigEndian.Uint32(header[28:])
+	nameOffset := int(binary.BigEndian.Uint32(header[84:]))
+	nameLen := int(binary.BigEndian.Uint32(header[88:]))
+	locale := binary.BigEndian.Uint32(header[92:])
+	firstImage := int(binary.BigEndian.Uint32(header[108:]))
+	exthFlags := binary.BigEndian.Uint32(header[128:])
+
+	text := func(data []byte) string {
+		if encoding == mobiEncodingCP1252 {
+			decoded, err := charmap.Windows1252.NewDecoder().Bytes(data)
+			if err == nil {
+				data = decoded
+			}
+		}
+		return strings.TrimSpace(string(bytes.TrimRight(data, "\x00")))
+	}
+
+	book := &Book{
+		Language: mobiLanguages[locale&0xFF],
+	}
+	if nameOffset+nameLen <= len(header) {
+		book.Title = text(header[nameOffset : nameOffset+nameLen])
+	}
+
+	if exthFlags&0x40 == 0 {
+		return book, nil
+	}
+
+	exth, errEXTH := parseEXTH(header[min(16+headerLen, len(header)):])
+	if errEXTH != nil {
+		return nil, errEXTH
+	}
+
+	// 0xFFFFFFFF marks an absent value
+	coverOffset := uint32(0xFFFFFFFF)
+	for _, record := range exth {
+		value := text(record.data)
+		switch record.kind {
+		case exthAuthor:
+			book.Authors = append(book.Authors, value)
+		case exthPublisher:
+			book.Publisher = value
+		case exthDescription:
+			book.Annotation = value
+		case exthISBN:
+			book.ISBN = value
+		case exthSubject:
+			book.Genres = append(book.Genres, value)
+		case exthPublishingDate:
+			if date, err := parseMOBIDate(value); err == nil {
+				book.WrittenAt = date
+			}
+		case exthCoverOffset:
+			if len(record.data) == 4 {
+				coverOffset = binary.BigEndian.Uint32(record.data)
+			}
+		case exthUpdatedTitle:
+			book.Title = value
+		case exthLanguage:
+			book.Language = value
+		}
+	}
+
+	if coverOffset != 0xFFFFFFFF && firstImage+int(coverOffset) < len(records) {
+		cover := records[firstImage+int(coverOffset)]
+		if contentType := http.DetectContentType(cover); strings.HasPrefix(contentType, "image/") {
+			book.Cover = &Image{
+				ContentType: contentType,
+				Data:        bytes.Clone(cover),
+			}
+		}
+	}
+
+	return book, nil
+}
+
+const mobiEncodingCP1252 = 1252
+
+// palmDBRecords splits a PalmDB file into records, see
+// https://wiki.mobileread.com/wiki/PDB#Palm_Database_Format
+func palmDBRecords(data []byte) ([][]byte, error) {
+	const headerLen = 78
+	if len(data) < headerLen {
+		return nil, fmt.Errorf("%w: short PalmDB header", errMOBIFormat)
+	}
+
+	switch kind := string(data[60:68]); kind {
+	case "BOOKMOBI", "TEXtREAd":
+	default:
+		return nil, fmt.Errorf("%w: unknown PalmDB type %q", errMOBIFormat, kind)
+	}
+
+	n := int(binary.BigEndian.Uint16(data[76:]))
+	if n == 0 || len(data) < headerLen+8*n {
+		return nil, fmt.Errorf("%w: bad record list", errMOBIFormat)
+	}
+
+	offsets := make([]int, n+1)
+	for i := 0; i < n; i++ {
+		offsets[i] = int(binary.BigEndian.Uint32(data[headerLen+8*i:]))
+	}
+	offsets[n] = len(data)
+
+	records := make([][]byte, n)
+	for i := range records {
+		start, end := offsets[i], offsets[i+1]
+		if start > end || end > len(data) {
+			return nil, fmt.Errorf("%w: record %d is out of bounds", errMOBIFormat, i)
+		}
+		records[i] = data[start:end]
+	}
+
+	return records, nil
+}
+
+// EXTH record types, see https://wiki.mobileread.com/wiki/MOBI#EXTH_Header
+const (
+	exthAuthor         = 100
+	exthPublisher      = 101
+	exthDescription    = 103
+	exthISBN           = 104
+	exthSubject        = 105
+	exthPublishingDate = 106
+	exthCoverOffset    = 201
+	exthUpdatedTitle   = 503
+	exthLanguage       = 524
+)
+
+type exthRecord struct {
+	kind uint32
+	data []byte
+}
+
+func parseEXTH(data []byte) ([]exthRecord, error) {
+	if len(data) < 12 || string(data[:4]) != "EXTH" {
+		return nil, fmt.Errorf("%w: EXTH header not found", errMOBIFormat)
+	}
+
+	count := int(binary.BigEndian.Uint32(data[8:]))
+	data = data[12:]
+	// every record takes 8 bytes at least, so the count is checked
+	// before it's trusted with an allocation
+	if count > len(data)/8 {
+		return nil, fmt.Errorf("%w: %d EXTH records don't fit in %d bytes", errMOBIFormat, count, len(data))
+	}
+
+	records := make([]exthRecord, 0, count)
+	for i := 0; i < count; i++ {
+		if len(data) < 8 {
+			return nil, fmt.Errorf("%w: EXTH record %d is truncated", errMOBIFormat, i)
+		}
+		kind := binary.BigEndian.Uint32(data)
+		size := int(binary.BigEndian.Uint32(data[4:]))
+		if size < 8 || size > len(data) {
+			return nil, fmt.Errorf("%w: EXTH record %d has bad length", errMOBIFormat, i)
+		}
+		records = append(records, exthRecord{kind: kind, data: data[8:size]})
+		data = data[size:]
+	}
+
+	return records, nil
+}
+
+func parseMOBIDate(date string) (time.Time, error) {
+	layouts := []string{time.RFC3339, "2006-01-02T15:04:05", time.DateOnly, "2006-01", "2006"}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("mobi date %q: bad format", date)
+}
+
+// mobiLanguages maps primary language identifiers of Windows LCIDs,
+// which are used by the locale field of the MOBI header.
+var mobiLanguages = map[uint32]string{
+	0x01: "ar", 0x02: "bg", 0x03: "ca", 0x04: "zh", 0x05: "cs",
+	0x06: "da", 0x07: "de", 0x08: "el", 0x09: "en", 0x0A: "es",
+	0x0B: "fi", 0x0C: "fr", 0x0D: "he", 0x0E: "hu", 0x0F: "is",
+	0x10: "it", 0x11: "ja", 0x12: "ko", 0x13: "nl", 0x14: "no",
+	0x15: "pl", 0x16: "pt", 0x17: "rm", 0x18: "ro", 0x19: "ru",
+	0x1A: "hr", 0x1B: "sk", 0x1C: "sq", 0x1D: "sv", 0x1E: "th",
+	0x1F: "tr", 0x20: "ur", 0x21: "id", 0x22: "uk", 0x23: "be",
+	0x24: "sl", 0x25: "et", 0x26: "lv", 0x27: "lt", 0x29: "fa",
+	0x2A: "vi", 0x2B: "hy", 0x2D: "eu", 0x2F: "mk", 0x36: "af",
+	0x37: "ka", 0x38: "fo", 0x39: "hi", 0x3E: "ms", 0x3F: "kk",
+}
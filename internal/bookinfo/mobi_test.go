@@ -0,0 +1,363 @@
+package bookinfo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+type testEXTH struct {
+	kind uint32
+	data []byte
+}
+
+// testMOBI describes a book made by its build method.
+type testMOBI struct {
+	title    string
+	encoding uint32
+	locale   uint32
+	exth     []testEXTH
+	// noEXTH clears the EXTH flag of the MOBI header.
+	noEXTH     bool
+	firstImage uint32
+	// images are records after the header record.
+	images [][]byte
+}
+
+const testMOBIHeaderLen = 232
+
+func buildEXTH(records []testEXTH) []byte {
+	body := &bytes.Buffer{}
+	for _, record := range records {
+		_ = binary.Write(body, binary.BigEndian, record.kind)
+		_ = binary.Write(body, binary.BigEndian, uint32(8+len(record.data)))
+		body.Write(record.data)
+	}
+	exth := &bytes.Buffer{}
+	exth.WriteString("EXTH")
+	_ = binary.Write(exth, binary.BigEndian, uint32(12+body.Len()))
+	_ = binary.Write(exth, binary.BigEndian, uint32(len(records)))
+	exth.Write(body.Bytes())
+	return exth.Bytes()
+}
+
+// buildHeaderRecord makes the first record: PalmDOC header, MOBI header,
+// EXTH records and the full name.
+func (book *testMOBI) buildHeaderRecord() []byte {
+	record := make([]byte, 16+testMOBIHeaderLen)
+	copy(record[16:], "MOBI")
+	binary.BigEndian.PutUint32(record[20:], testMOBIHeaderLen)
+	encoding := book.encoding
+	if encoding == 0 {
+		encoding = 65001
+	}
+	binary.BigEndian.PutUint32(record[28:], encoding)
+	binary.BigEndian.PutUint32(record[92:], book.locale)
+	binary.BigEndian.PutUint32(record[108:], book.firstImage)
+	if !book.noEXTH {
+		binary.BigEndian.PutUint32(record[128:], 0x40)
+		record = append(record, buildEXTH(book.exth)...)
+	}
+	binary.BigEndian.PutUint32(record[84:], uint32(len(record)))
+	binary.BigEndian.PutUint32(record[88:], uint32(len(book.title)))
+	record = append(record, book.title...)
+	return append(record, 0, 0)
+}
+
+// buildPalmDB joins records into a PalmDB file of the BOOKMOBI type.
+func buildPalmDB(records ...[]byte) []byte {
+	const headerLen = 78
+	header := make([]byte, headerLen)
+	copy(header, "test book")
+	copy(header[60:], "BOOKMOBI")
+	binary.BigEndian.PutUint16(header[76:], uint16(len(records)))
+
+	offset := headerLen + 8*len(records) + 2
+	list := &bytes.Buffer{}
+	for i, record := range records {
+		_ = binary.Write(list, binary.BigEndian, uint32(offset))
+		_ = binary.Write(list, binary.BigEndian, uint32(i))
+		offset += len(record)
+	}
+
+	data := append(header, list.Bytes()...)
+	data = append(data, 0, 0)
+	for _, record := range records {
+		data = append(data, record...)
+	}
+	return data
+}
+
+func (book *testMOBI) build() []byte {
+	records := [][]byte{book.buildHeaderRecord()}
+	return buildPalmDB(append(records, book.images...)...)
+}
+
+func uint32Bytes(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
+
+var testJPEG = []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 0x10, 'J', 'F', 'I', 'F', 0}
+
+func TestParseMOBI(t *testing.T) {
+	tests := []struct {
+		name string
+		book testMOBI
+		want Book
+		// cover is the expected cover data, nil for no cover.
+		cover []byte
+	}{
+		{
+			name: "header only",
+			book: testMOBI{title: "Full Name", locale: 0x0409, noEXTH: true},
+			want: Book{Title: "Full Name", Language: "en"},
+		},
+		{
+			name: "exth records",
+			book: testMOBI{
+				title:  "Full Name",
+				locale: 0x19,
+				exth: []testEXTH{
+					{kind: exthAuthor, data: []byte("Ann")},
+					{kind: exthAuthor, data: []byte("Bob")},
+					{kind: exthPublisher, data: []byte("Press")},
+					{kind: exthDescription, data: []byte("About")},
+					{kind: exthISBN, data: []byte("978-3-16-148410-0")},
+					{kind: exthSubject, data: []byte("Fiction")},
+					{kind: exthPublishingDate, data: []byte("2001-02-03")},
+					{kind: exthUpdatedTitle, data: []byte("Updated Title")},
+					{kind: exthLanguage, data: []byte("de")},
+					{kind: 999, data: []byte("unknown")},
+				},
+			},
+			want: Book{
+				Title:      "Updated Title",
+				Authors:    []string{"Ann", "Bob"},
+				Publisher:  "Press",
+				Annotation: "About",
+				ISBN:       "978-3-16-148410-0",
+				Genres:     []string{"Fiction"},
+				WrittenAt:  time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC),
+				Language:   "de",
+			},
+		},
+		{
+			name: "cp1252",
+			book: testMOBI{
+				title:    "Caf\xe9",
+				encoding: mobiEncodingCP1252,
+				exth:     []testEXTH{{kind: exthAuthor, data: []byte("Ren\xe9")}},
+			},
+			want: Book{Title: "Café", Authors: []string{"René"}},
+		},
+		{
+			name: "cover index",
+			book: testMOBI{
+				title:      "Covered",
+				firstImage: 1,
+				exth:       []testEXTH{{kind: exthCoverOffset, data: uint32Bytes(1)}},
+				images:     [][]byte{[]byte("not an image"), testJPEG},
+			},
+			want:  Book{Title: "Covered"},
+			cover: testJPEG,
+		},
+		{
+			name: "cover index out of records",
+			book: testMOBI{
+				title:      "Covered",
+				firstImage: 1,
+				exth:       []testEXTH{{kind: exthCoverOffset, data: uint32Bytes(5)}},
+				images:     [][]byte{testJPEG},
+			},
+			want: Book{Title: "Covered"},
+		},
+		{
+			name: "absent first image",
+			book: testMOBI{
+				title:      "Covered",
+				firstImage: 0xFFFFFFFF,
+				exth:       []testEXTH{{kind: exthCoverOffset, data: uint32Bytes(0)}},
+				images:     [][]byte{testJPEG},
+			},
+			want: Book{Title: "Covered"},
+		},
+		{
+			name: "cover is not an image",
+			book: testMOBI{
+				title:      "Covered",
+				firstImage: 1,
+				exth:       []testEXTH{{kind: exthCoverOffset, data: uint32Bytes(0)}},
+				images:     [][]byte{[]byte("plain text")},
+			},
+			want: Book{Title: "Covered"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			book, err := ParseMOBI(bytes.NewReader(tc.book.build()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			cover := book.Cover
+			book.Cover = nil
+			if !equalBooks(book, &tc.want) {
+				t.Errorf("got %+v, want %+v", book, tc.want)
+			}
+
+			switch {
+			case tc.cover == nil && cover != nil:
+				t.Errorf("unexpected cover %q", cover.ContentType)
+			case tc.cover != nil && cover == nil:
+				t.Error("cover not found")
+			case tc.cover != nil && !bytes.Equal(cover.Data, tc.cover):
+				t.Errorf("cover: got %x, want %x", cover.Data, tc.cover)
+			}
+		})
+	}
+}
+
+func TestParseMOBIMalformed(t *testing.T) {
+	valid := (&testMOBI{title: "Title", exth: []testEXTH{{kind: exthAuthor, data: []byte("Ann")}}}).build()
+
+	tests := []struct {
+		name string
+		data func() []byte
+	}{
+		{name: "empty", data: func() []byte { return nil }},
+		{name: "short palmdb header", data: func() []byte { return valid[:50] }},
+		{
+			name: "unknown type",
+			data: func() []byte {
+				data := bytes.Clone(valid)
+				copy(data[60:], "TEXTtest")
+				return data
+			},
+		},
+		{
+			name: "no records",
+			data: func() []byte {
+				data := bytes.Clone(valid)
+				binary.BigEndian.PutUint16(data[76:], 0)
+				return data
+			},
+		},
+		{
+			name: "record list past the file",
+			data: func() []byte {
+				data := bytes.Clone(valid)
+				binary.BigEndian.PutUint16(data[76:], 0xFFFF)
+				return data
+			},
+		},
+		{
+			name: "record offset past the file",
+			data: func() []byte {
+				data := bytes.Clone(valid)
+				binary.BigEndian.PutUint32(data[78:], uint32(len(data)+10))
+				return data
+			},
+		},
+		{
+			name: "truncated header record",
+			data: func() []byte {
+				return buildPalmDB([]byte("0123456789abcdefMOBI"))
+			},
+		},
+		{
+			name: "missing MOBI magic",
+			data: func() []byte {
+				record := (&testMOBI{title: "Title"}).buildHeaderRecord()
+				copy(record[16:], "XXXX")
+				return buildPalmDB(record)
+			},
+		},
+		{
+			name: "missing EXTH header",
+			data: func() []byte {
+				record := (&testMOBI{title: "Title", noEXTH: true}).buildHeaderRecord()
+				binary.BigEndian.PutUint32(record[128:], 0x40)
+				return buildPalmDB(record)
+			},
+		},
+		{
+			name: "truncated EXTH records",
+			data: func() []byte {
+				record := (&testMOBI{title: "Title", noEXTH: true}).buildHeaderRecord()
+				binary.BigEndian.PutUint32(record[128:], 0x40)
+				exth := buildEXTH([]testEXTH{{kind: exthAuthor, data: []byte("Ann")}})
+				// the count claims more records than there are
+				binary.BigEndian.PutUint32(exth[8:], 3)
+				return buildPalmDB(append(record[:16+testMOBIHeaderLen], exth...))
+			},
+		},
+		{
+			name: "huge EXTH record count",
+			data: func() []byte {
+				record := (&testMOBI{title: "Title", noEXTH: true}).buildHeaderRecord()
+				binary.BigEndian.PutUint32(record[128:], 0x40)
+				exth := buildEXTH([]testEXTH{{kind: exthAuthor, data: []byte("Ann")}})
+				// used to preallocate records for the count and run out of memory
+				binary.BigEndian.PutUint32(exth[8:], 0xFFFFFFFF)
+				return buildPalmDB(append(record[:16+testMOBIHeaderLen], exth...))
+			},
+		},
+		{
+			name: "EXTH record longer than data",
+			data: func() []byte {
+				record := (&testMOBI{title: "Title", noEXTH: true}).buildHeaderRecord()
+				binary.BigEndian.PutUint32(record[128:], 0x40)
+				exth := buildEXTH([]testEXTH{{kind: exthAuthor, data: []byte("Ann")}})
+				binary.BigEndian.PutUint32(exth[16:], 1000)
+				return buildPalmDB(append(record[:16+testMOBIHeaderLen], exth...))
+			},
+		},
+		{
+			name: "EXTH record shorter than its header",
+			data: func() []byte {
+				record := (&testMOBI{title: "Title", noEXTH: true}).buildHeaderRecord()
+				binary.BigEndian.PutUint32(record[128:], 0x40)
+				exth := buildEXTH([]testEXTH{{kind: exthAuthor, data: []byte("Ann")}})
+				binary.BigEndian.PutUint32(exth[16:], 4)
+				return buildPalmDB(append(record[:16+testMOBIHeaderLen], exth...))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			book, err := ParseMOBI(bytes.NewReader(tc.data()))
+			if !errors.Is(err, errMOBIFormat) {
+				t.Fatalf("got %+v, %v, want %v", book, err, errMOBIFormat)
+			}
+		})
+	}
+}
+
+func TestParseMOBIName(t *testing.T) {
+	// the full name pointing out of the record is skipped
+	record := (&testMOBI{title: "Title", noEXTH: true}).buildHeaderRecord()
+	binary.BigEndian.PutUint32(record[88:], 1<<20)
+
+	book, err := ParseMOBI(bytes.NewReader(buildPalmDB(record)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Title != "" {
+		t.Errorf("title: got %q, want none", book.Title)
+	}
+}
+
+func equalBooks(a, b *Book) bool {
+	return a.Title == b.Title &&
+		a.Language == b.Language &&
+		a.Publisher == b.Publisher &&
+		a.Annotation == b.Annotation &&
+		a.ISBN == b.ISBN &&
+		a.WrittenAt.Equal(b.WrittenAt) &&
+		slices.Equal(a.Authors, b.Authors) &&
+		slices.Equal(a.Genres, b.Genres)
+}
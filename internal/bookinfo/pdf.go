@@ -0,0 +1,404 @@
+package bookinfo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/antchfx/xmlquery"
+)
+
+var ErrPDFEncrypted = errors.New("pdf: encrypted documents are not supported")
+
+// ParsePDF extracts metadata from the document information dictionary and
+// the XMP metadata stream. XMP values take precedence over the Info ones,
+// malformed XMP is skipped. The cover is extracted only if the first page
+// contains a single JPEG image.
+func ParsePDF(input io.Reader) (*Book, error) {
+	data, errRead := io.ReadAll(input)
+	if errRead != nil {
+		return nil, fmt.Errorf("read: %w", errRead)
+	}
+
+	doc, errDoc := parsePDFDoc(data)
+	if errDoc != nil {
+		return nil, errDoc
+	}
+
+	if doc.trailer["Encrypt"] != nil {
+		return nil, ErrPDFEncrypted
+	}
+
+	book := &Book{}
+	if info, ok := doc.resolve(doc.trailer["Info"]).(pdfDict); ok {
+		doc.readInfo(book, info)
+	}
+
+	catalog, _ := doc.resolve(doc.trailer["Root"]).(pdfDict)
+	if lang, ok := doc.resolve(catalog["Lang"]).(pdfString); ok {
+		book.Language = lang.text()
+	}
+
+	if metadata, ok := doc.resolve(catalog["Metadata"]).(*pdfStream); ok {
+		// the Info dictionary is enough if XMP is broken
+		if xmp, errXMP := metadata.decode(); errXMP == nil {
+			_ = readXMP(book, xmp)
+		}
+	}
+
+	if page := doc.firstPage(catalog); page != nil {
+		book.Cover = doc.pageCover(page)
+	}
+
+	return book, nil
+}
+
+type pdfDoc struct {
+	objects map[int64]any
+	trailer pdfDict
+}
+
+var pdfObjHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// parsePDFDoc loads all indirect objects by scanning the file instead of
+// following the cross-reference table, so files with broken offsets
+// are still readable. Objects defined later override earlier ones,
+// which matches the incremental update semantics.
+func parsePDFDoc(data []byte) (*pdfDoc, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\n\f\r "), []byte("%PDF-")) {
+		return nil, fmt.Errorf("%w: missing header", errPDFSyntax)
+	}
+
+	doc := &pdfDoc{objects: map[int64]any{}}
+	var xrefStream pdfDict
+	var objStreams []*pdfStream
+
+	for pos := 0; pos < len(data); {
+		loc := pdfObjHeader.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.ParseInt(string(data[pos+loc[2]:pos+loc[3]]), 10, 64)
+
+		lx := &pdfLexer{data: data, pos: pos + loc[1]}
+		obj, errObj := lx.readIndirect()
+		if errObj != nil {
+			pos += loc[1]
+			continue
+		}
+		pos = lx.pos
+
+		doc.objects[num] = obj
+		if stream, ok := obj.(*pdfStream); ok {
+			switch stream.dict["Type"] {
+			case pdfName("XRef"):
+				xrefStream = stream.dict
+			case pdfName("ObjStm"):
+				objStreams = append(objStreams, stream)
+			}
+		}
+	}
+
+	for _, stream := range objStreams {
+		doc.loadObjStream(stream)
+	}
+
+	doc.trailer = findPDFTrailer(data)
+	if doc.trailer == nil {
+		doc.trailer = xrefStream
+	}
+	if doc.trailer == nil {
+		return nil, fmt.Errorf("%w: trailer not found", errPDFSyntax)
+	}
+
+	return doc, nil
+}
+
+func findPDFTrailer(data []byte) pdfDict {
+	idx := bytes.LastIndex(data, []byte("trailer"))
+	if idx < 0 {
+		return nil
+	}
+	lx := &pdfLexer{data: data, pos: idx + len("trailer")}
+	obj, _ := lx.readObject()
+	dict, _ := obj.(pdfDict)
+	return dict
+}
+
+// loadObjStream registers objects from a compressed object stream,
+// see ISO 32000-1, section 7.5.7. Direct objects are not overridden.
+// Like objects of the file, broken parts are skipped: streams which can't
+// be decoded, a header out of the stream data and objects out of it.
+func (doc *pdfDoc) loadObjStream(stream *pdfStream) {
+	data, errDecode := stream.decode()
+	if errDecode != nil {
+		return
+	}
+	n, _ := stream.dict["N"].(int64)
+	first, _ := stream.dict["First"].(int64)
+	if first < 0 || first >= int64(len(data)) {
+		return
+	}
+
+	header := &pdfLexer{data: data[:first]}
+	for i := int64(0); i < n; i++ {
+		num, _ := header.readObject()
+		offset, _ := header.readObject()
+		objNum, okNum := num.(int64)
+		objOffset, okOffset := offset.(int64)
+		if !okNum || !okOffset {
+			return
+		}
+		if objOffset < 0 || objOffset >= int64(len(data))-first {
+			continue
+		}
+		if _, exists := doc.objects[objNum]; exists {
+			continue
+		}
+		lx := &pdfLexer{data: data, pos: int(first + objOffset)}
+		if obj, err := lx.readObject(); err == nil {
+			doc.objects[objNum] = obj
+		}
+	}
+}
+
+func (doc *pdfDoc) resolve(obj any) any {
+	for depth := 0; depth < 32; depth++ {
+		ref, ok := obj.(pdfRef)
+		if !ok {
+			return obj
+		}
+		obj = doc.objects[ref.num]
+	}
+	return nil
+}
+
+func (doc *pdfDoc) readInfo(book *Book, info pdfDict) {
+	text := func(key pdfName) string {
+		str, _ := doc.resolve(info[key]).(pdfString)
+		return strings.TrimSpace(str.text())
+	}
+
+	book.Title = text("Title")
+	book.Annotation = text("Subject")
+	book.Keywords = splitKeywords(text("Keywords"))
+	for _, author := range strings.Split(text("Author"), ";") {
+		if author = strings.TrimSpace(author); author != "" {
+			book.Authors = append(book.Authors, author)
+		}
+	}
+	if date, err := parsePDFDate(text("CreationDate")); err == nil {
+		book.WrittenAt = date
+	}
+}
+
+func splitKeywords(keywords string) []string {
+	var result []string
+	for _, keyword := range strings.FieldsFunc(keywords, func(r rune) bool { return r == ',' || r == ';' }) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			result = append(result, keyword)
+		}
+	}
+	return result
+}
+
+const pdfDateLayout = "20060102150405"
+
+// parsePDFDate parses dates in "D:YYYYMMDDHHmmSSOHH'mm'" format,
+// where every component after the year is optional.
+func parsePDFDate(date string) (time.Time, error) {
+	date = strings.TrimPrefix(date, "D:")
+	date = strings.ReplaceAll(date, "'", "")
+
+	digits := len(date)
+	for i, c := range date {
+		if c < '0' || c > '9' {
+			digits = i
+			break
+		}
+	}
+	if digits < 4 || digits > len(pdfDateLayout) || digits%2 != 0 {
+		return time.Time{}, fmt.Errorf("pdf date %q: bad format", date)
+	}
+
+	layout := pdfDateLayout[:digits]
+	zone := date[digits:]
+	switch {
+	case zone == "" || zone == "Z" || zone == "Z00" || zone == "Z0000":
+		return time.Parse(layout, date[:digits])
+	case len(zone) == 3:
+		return time.Parse(layout+"-07", date)
+	default:
+		return time.Parse(layout+"-0700", date)
+	}
+}
+
+// firstPage walks the page tree down to the first leaf page.
+// Resources are inheritable, so they are copied from the ancestors.
+func (doc *pdfDoc) firstPage(catalog pdfDict) pdfDict {
+	node, _ := doc.resolve(catalog["Pages"]).(pdfDict)
+	var resources any
+	for depth := 0; node != nil && depth < 64; depth++ {
+		if node["Resources"] != nil {
+			resources = node["Resources"]
+		}
+		kids, ok := doc.resolve(node["Kids"]).(pdfArray)
+		if !ok || node["Type"] == pdfName("Page") {
+			page := pdfDict{}
+			for key, value := range node {
+				page[key] = value
+			}
+			page["Resources"] = resources
+			return page
+		}
+		if len(kids) == 0 {
+			return nil
+		}
+		node, _ = doc.resolve(kids[0]).(pdfDict)
+	}
+	return nil
+}
+
+func (doc *pdfDoc) pageCover(page pdfDict) *Image {
+	resources, _ := doc.resolve(page["Resources"]).(pdfDict)
+	xobjects, _ := doc.resolve(resources["XObject"]).(pdfDict)
+
+	var images []*pdfStream
+	for _, xobject := range xobjects {
+		stream, ok := doc.resolve(xobject).(*pdfStream)
+		if ok && stream.dict["Subtype"] == pdfName("Image") {
+			images = append(images, stream)
+		}
+	}
+	if len(images) != 1 {
+		return nil
+	}
+
+	image := images[0]
+	filter := doc.resolve(image.dict["Filter"])
+	if array, ok := filter.(pdfArray); ok && len(array) == 1 {
+		filter = doc.resolve(array[0])
+	}
+	if filter != pdfName("DCTDecode") || !bytes.HasPrefix(image.raw, []byte{0xFF, 0xD8}) {
+		return nil
+	}
+
+	return &Image{
+		ContentType: "image/jpeg",
+		Data:        bytes.Clone(image.raw),
+	}
+}
+
+const (
+	nsDC  = "http://purl.org/dc/elements/1.1/"
+	nsXMP = "http://ns.adobe.com/xap/1.0/"
+	nsPDF = "http://ns.adobe.com/pdf/1.3/"
+	nsRDF = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+)
+
+func readXMP(book *Book, xmp []byte) error {
+	doc, errParse := xmlquery.Parse(bytes.NewReader(xmp))
+	if errParse != nil {
+		return fmt.Errorf("xml parse: %w", errParse)
+	}
+
+	if titles := xmpValues(doc, nsDC, "title"); len(titles) > 0 {
+		book.Title = titles[0]
+	}
+	if creators := xmpValues(doc, nsDC, "creator"); len(creators) > 0 {
+		book.Authors = creators
+	}
+	if descriptions := xmpValues(doc, nsDC, "description"); len(descriptions) > 0 {
+		book.Annotation = descriptions[0]
+	}
+	if subjects := xmpValues(doc, nsDC, "subject"); len(subjects) > 0 {
+		book.Keywords = subjects
+	} else if keywords := xmpValues(doc, nsPDF, "Keywords"); len(keywords) > 0 {
+		book.Keywords = splitKeywords(keywords[0])
+	}
+	if languages := xmpValues(doc, nsDC, "language"); len(languages) > 0 {
+		book.Language = languages[0]
+	}
+	if dates := xmpValues(doc, nsXMP, "CreateDate"); len(dates) > 0 {
+		if date, err := parseXMPDate(dates[0]); err == nil {
+			book.WrittenAt = date
+		}
+	}
+
+	return nil
+}
+
+// xmpValues returns values of the property, which can be written either as an
+// element with an optional rdf container, or as an attribute of rdf:Description.
+// The x-default item of language alternatives goes first.
+func xmpValues(doc *xmlquery.Node, ns, name string) []string {
+	var values []string
+	var walk func(node *xmlquery.Node)
+	walk = func(node *xmlquery.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != xmlquery.ElementNode {
+				continue
+			}
+
+			if child.NamespaceURI == nsRDF && child.Data == "Description" {
+				for _, attr := range child.Attr {
+					if attr.NamespaceURI == ns && attr.Name.Local == name {
+						values = append(values, strings.TrimSpace(attr.Value))
+					}
+				}
+			}
+
+			if child.NamespaceURI != ns || child.Data != name {
+				walk(child)
+				continue
+			}
+
+			items := xmlquery.Find(child, "./*/*[local-name()='li']")
+			if len(items) == 0 {
+				values = append(values, strings.TrimSpace(child.InnerText()))
+				continue
+			}
+			for _, item := range items {
+				value := strings.TrimSpace(item.InnerText())
+				if isXDefault(item) {
+					values = append([]string{value}, values...)
+				} else {
+					values = append(values, value)
+				}
+			}
+		}
+	}
+	walk(doc)
+
+	nonEmpty := values[:0]
+	for _, value := range values {
+		if value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	return nonEmpty
+}
+
+func isXDefault(node *xmlquery.Node) bool {
+	for _, attr := range node.Attr {
+		if attr.Name.Local == "lang" && attr.Value == "x-default" {
+			return true
+		}
+	}
+	return false
+}
+
+func parseXMPDate(date string) (time.Time, error) {
+	layouts := []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05", time.DateOnly, "2006-01", "2006"}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("xmp date %q: bad format", date)
+}
@@ -0,0 +1,526 @@
+package bookinfo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf16"
+)
+
+// PDF object model, see ISO 32000-1, section 7.3.
+// Integers are int64, reals are float64, booleans are bool and null is nil.
+type (
+	pdfName    string
+	pdfString  []byte
+	pdfArray   []any
+	pdfDict    map[pdfName]any
+	pdfKeyword string
+	pdfRef     struct{ num, gen int64 }
+)
+
+type pdfStream struct {
+	dict pdfDict
+	// raw holds the encoded stream data.
+	raw []byte
+}
+
+var errPDFSyntax = errors.New("pdf: syntax error")
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isPDFDelim(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (lx *pdfLexer) skipSpace() {
+	for lx.pos < len(lx.data) {
+		c := lx.data[lx.pos]
+		switch {
+		case isPDFSpace(c):
+			lx.pos++
+		case c == '%':
+			for lx.pos < len(lx.data) && lx.data[lx.pos] != '\n' && lx.data[lx.pos] != '\r' {
+				lx.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (lx *pdfLexer) regular() []byte {
+	start := lx.pos
+	for lx.pos < len(lx.data) {
+		c := lx.data[lx.pos]
+		if isPDFSpace(c) || isPDFDelim(c) {
+			break
+		}
+		lx.pos++
+	}
+	return lx.data[start:lx.pos]
+}
+
+// readObject reads a direct object or an indirect reference.
+// Keywords such as "obj" or "stream" are returned as pdfKeyword.
+func (lx *pdfLexer) readObject() (any, error) {
+	lx.skipSpace()
+	if lx.pos >= len(lx.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	switch c := lx.data[lx.pos]; c {
+	case '/':
+		lx.pos++
+		return pdfName(decodeNameEscapes(lx.regular())), nil
+	case '(':
+		lx.pos++
+		return lx.literalString()
+	case '<':
+		if lx.pos+1 < len(lx.data) && lx.data[lx.pos+1] == '<' {
+			lx.pos += 2
+			return lx.dict()
+		}
+		lx.pos++
+		return lx.hexString()
+	case '[':
+		lx.pos++
+		return lx.array()
+	case ']', '>', ')', '{', '}':
+		lx.pos++
+		return pdfKeyword(c), nil
+	}
+
+	token := lx.regular()
+	if len(token) == 0 {
+		return nil, fmt.Errorf("%w: unexpected byte %q at %d", errPDFSyntax, lx.data[lx.pos], lx.pos)
+	}
+
+	switch string(token) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+
+	if n, err := strconv.ParseInt(string(token), 10, 64); err == nil {
+		return lx.maybeRef(n), nil
+	}
+	if f, err := strconv.ParseFloat(string(token), 64); err == nil {
+		return f, nil
+	}
+
+	return pdfKeyword(token), nil
+}
+
+// maybeRef checks if the integer n is followed by "<gen> R".
+func (lx *pdfLexer) maybeRef(n int64) any {
+	save := lx.pos
+	lx.skipSpace()
+	gen, err := strconv.ParseInt(string(lx.regular()), 10, 64)
+	if err == nil {
+		lx.skipSpace()
+		if string(lx.regular()) == "R" {
+			return pdfRef{num: n, gen: gen}
+		}
+	}
+	lx.pos = save
+	return n
+}
+
+func (lx *pdfLexer) dict() (pdfDict, error) {
+	dict := pdfDict{}
+	for {
+		key, err := lx.readObject()
+		if err != nil {
+			return nil, err
+		}
+		if key == pdfKeyword('>') {
+			if lx.pos < len(lx.data) && lx.data[lx.pos] == '>' {
+				lx.pos++
+				return dict, nil
+			}
+			return nil, fmt.Errorf("%w: unterminated dictionary at %d", errPDFSyntax, lx.pos)
+		}
+		name, ok := key.(pdfName)
+		if !ok {
+			return nil, fmt.Errorf("%w: dictionary key is %T at %d", errPDFSyntax, key, lx.pos)
+		}
+		value, err := lx.readObject()
+		if err != nil {
+			return nil, err
+		}
+		dict[name] = value
+	}
+}
+
+func (lx *pdfLexer) array() (pdfArray, error) {
+	var array pdfArray
+	for {
+		item, err := lx.readObject()
+		if err != nil {
+			return nil, err
+		}
+		if item == pdfKeyword(']') {
+			return array, nil
+		}
+		array = append(array, item)
+	}
+}
+
+func (lx *pdfLexer) literalString() (pdfString, error) {
+	var str []byte
+	depth := 1
+	for lx.pos < len(lx.data) {
+		c := lx.data[lx.pos]
+		lx.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return str, nil
+			}
+		case '\\':
+			if lx.pos >= len(lx.data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			c = lx.data[lx.pos]
+			lx.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				// line continuation
+				if lx.pos < len(lx.data) && lx.data[lx.pos] == '\n' {
+					lx.pos++
+				}
+				continue
+			case '\n':
+				continue
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				code := int(c - '0')
+				for i := 0; i < 2 && lx.pos < len(lx.data); i++ {
+					d := lx.data[lx.pos]
+					if d < '0' || d > '7' {
+						break
+					}
+					code = code*8 + int(d-'0')
+					lx.pos++
+				}
+				c = byte(code)
+			}
+		}
+		str = append(str, c)
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (lx *pdfLexer) hexString() (pdfString, error) {
+	end := bytes.IndexByte(lx.data[lx.pos:], '>')
+	if end < 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	digits := make([]byte, 0, end+1)
+	for _, c := range lx.data[lx.pos : lx.pos+end] {
+		if !isPDFSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	lx.pos += end + 1
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	str := make([]byte, hex.DecodedLen(len(digits)))
+	if _, err := hex.Decode(str, digits); err != nil {
+		return nil, fmt.Errorf("%w: hex string: %w", errPDFSyntax, err)
+	}
+	return str, nil
+}
+
+func decodeNameEscapes(name []byte) string {
+	if bytes.IndexByte(name, '#') < 0 {
+		return string(name)
+	}
+	decoded := make([]byte, 0, len(name))
+	for i := 0; i < len(name); i++ {
+		if name[i] == '#' && i+2 < len(name) {
+			var b [1]byte
+			if _, err := hex.Decode(b[:], name[i+1:i+3]); err == nil {
+				decoded = append(decoded, b[0])
+				i += 2
+				continue
+			}
+		}
+		decoded = append(decoded, name[i])
+	}
+	return string(decoded)
+}
+
+// readIndirect reads the body of an indirect object, which starts right after
+// the "<num> <gen> obj" header. Stream data is located using the /Length entry
+// if it's a direct integer, otherwise by searching for the "endstream" keyword.
+func (lx *pdfLexer) readIndirect() (any, error) {
+	obj, err := lx.readObject()
+	if err != nil {
+		return nil, err
+	}
+
+	save := lx.pos
+	next, err := lx.readObject()
+	if err != nil || next != pdfKeyword("stream") {
+		lx.pos = save
+		return obj, nil
+	}
+
+	dict, ok := obj.(pdfDict)
+	if !ok {
+		return nil, fmt.Errorf("%w: stream without dictionary at %d", errPDFSyntax, lx.pos)
+	}
+
+	// the keyword is followed by CRLF or LF
+	if lx.pos < len(lx.data) && lx.data[lx.pos] == '\r' {
+		lx.pos++
+	}
+	if lx.pos < len(lx.data) && lx.data[lx.pos] == '\n' {
+		lx.pos++
+	}
+
+	start := lx.pos
+	if length, ok := dict["Length"].(int64); ok && length >= 0 && length <= int64(len(lx.data)-start) {
+		end := start + int(length)
+		tail := lx.data[end:min(end+32, len(lx.data))]
+		if bytes.HasPrefix(bytes.TrimLeft(tail, "\r\n \t"), []byte("endstream")) {
+			lx.pos = end + bytes.Index(tail, []byte("endstream")) + len("endstream")
+			return &pdfStream{dict: dict, raw: lx.data[start:end]}, nil
+		}
+	}
+
+	end := bytes.Index(lx.data[start:], []byte("endstream"))
+	if end < 0 {
+		return nil, fmt.Errorf("%w: unterminated stream at %d", errPDFSyntax, start)
+	}
+	raw := bytes.TrimSuffix(lx.data[start:start+end], []byte("\n"))
+	raw = bytes.TrimSuffix(raw, []byte("\r"))
+	lx.pos = start + end + len("endstream")
+
+	return &pdfStream{dict: dict, raw: raw}, nil
+}
+
+func (stream *pdfStream) decode() ([]byte, error) {
+	var filters []any
+	switch filter := stream.dict["Filter"].(type) {
+	case nil:
+		return stream.raw, nil
+	case pdfName:
+		filters = pdfArray{filter}
+	case pdfArray:
+		filters = filter
+	default:
+		return nil, fmt.Errorf("%w: filter is %T", errPDFSyntax, filter)
+	}
+
+	var params []any
+	switch parms := stream.dict["DecodeParms"].(type) {
+	case pdfDict:
+		params = pdfArray{parms}
+	case pdfArray:
+		params = parms
+	}
+
+	data := stream.raw
+	for i, filter := range filters {
+		var parms pdfDict
+		if i < len(params) {
+			parms, _ = params[i].(pdfDict)
+		}
+
+		var err error
+		switch filter {
+		case pdfName("FlateDecode"):
+			data, err = flateDecode(data, parms)
+		case pdfName("ASCIIHexDecode"):
+			lx := &pdfLexer{data: data}
+			data, err = lx.hexString()
+		default:
+			err = fmt.Errorf("unsupported filter %v", filter)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pdf: stream: %w", err)
+		}
+	}
+
+	return data, nil
+}
+
+func flateDecode(data []byte, parms pdfDict) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+
+	// damaged streams are common, keep whatever was inflated
+	decoded, err := io.ReadAll(io.LimitReader(zr, maxStreamSize+1))
+	switch {
+	case len(decoded) > maxStreamSize:
+		return nil, fmt.Errorf("%w: stream inflates to more than %d bytes", errPDFSyntax, maxStreamSize)
+	case err != nil && len(decoded) == 0:
+		return nil, err
+	}
+
+	predictor, _ := parms["Predictor"].(int64)
+	if predictor < 10 {
+		return decoded, nil
+	}
+
+	columns, colors, bpc := int64(1), int64(1), int64(8)
+	if v, ok := parms["Columns"].(int64); ok {
+		columns = v
+	}
+	if v, ok := parms["Colors"].(int64); ok {
+		colors = v
+	}
+	if v, ok := parms["BitsPerComponent"].(int64); ok {
+		bpc = v
+	}
+	// the parameters come from the file, the row buffer is allocated by them
+	switch {
+	case columns < 1 || columns > maxPredictorColumns:
+		return nil, fmt.Errorf("%w: predictor columns %d", errPDFSyntax, columns)
+	case colors < 1 || colors > maxPredictorColors:
+		return nil, fmt.Errorf("%w: predictor colors %d", errPDFSyntax, colors)
+	case bpc != 1 && bpc != 2 && bpc != 4 && bpc != 8 && bpc != 16:
+		return nil, fmt.Errorf("%w: predictor bits per component %d", errPDFSyntax, bpc)
+	}
+
+	return pngUnpredict(decoded, int((colors*bpc+7)/8), int((columns*colors*bpc+7)/8))
+}
+
+// Limits of predictor parameters, see ISO 32000-1, table 8.
+const (
+	maxPredictorColumns = 1 << 20
+	maxPredictorColors  = 32
+)
+
+// maxStreamSize limits inflated streams, metadata and object streams
+// are far smaller, larger ones are flate bombs.
+const maxStreamSize = 8 << 20
+
+// pngUnpredict reverts PNG row filters, see RFC 2083, section 6.
+func pngUnpredict(data []byte, bpp, rowSize int) ([]byte, error) {
+	if rowSize <= 0 || bpp <= 0 || rowSize > len(data) {
+		return nil, fmt.Errorf("%w: bad predictor parameters", errPDFSyntax)
+	}
+
+	prev := make([]byte, rowSize)
+	result := make([]byte, 0, len(data))
+	for len(data) > rowSize {
+		filter, row := data[0], data[1:rowSize+1]
+		data = data[rowSize+1:]
+
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch filter {
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			}
+		}
+
+		result = append(result, row...)
+		prev = row
+	}
+
+	return result, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	default:
+		return c
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// text decodes a PDF text string, which is either UTF-16BE with BOM,
+// UTF-8 with BOM or PDFDocEncoding.
+func (str pdfString) text() string {
+	switch {
+	case bytes.HasPrefix(str, []byte{0xFE, 0xFF}):
+		units := make([]uint16, 0, len(str)/2)
+		for i := 2; i+1 < len(str); i += 2 {
+			units = append(units, uint16(str[i])<<8|uint16(str[i+1]))
+		}
+		return string(utf16.Decode(units))
+	case bytes.HasPrefix(str, []byte{0xEF, 0xBB, 0xBF}):
+		return string(str[3:])
+	}
+
+	runes := make([]rune, 0, len(str))
+	for _, c := range str {
+		r := rune(c)
+		if c >= 0x80 && c < 0xA0 {
+			r = pdfDocEncoding[c-0x80]
+		}
+		runes = append(runes, r)
+	}
+	return string(runes)
+}
+
+// pdfDocEncoding maps the 0x80-0x9F range of PDFDocEncoding,
+// the rest of the table matches Latin-1.
+var pdfDocEncoding = [32]rune{
+	'•', '†', '‡', '…', '—', '–', 'ƒ', '⁄', '‹', '›', '−', '‰', '„', '“', '”', '‘',
+	'’', '‚', '™', 'ﬁ', 'ﬂ', 'Ł', 'Œ', 'Š', 'Ÿ', 'Ž', 'ı', 'ł', 'œ', 'š', 'ž', '�',
+}
@@ -0,0 +1,447 @@
+package bookinfo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// buildPDF joins indirect objects into a document, objects are numbered
+// from 1. The parser scans for objects, so no cross-reference table is written.
+func buildPDF(trailer string, objects ...string) []byte {
+	out := &bytes.Buffer{}
+	out.WriteString("%PDF-1.7\n")
+	for i, obj := range objects {
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	fmt.Fprintf(out, "trailer\n%s\n%%%%EOF\n", trailer)
+	return out.Bytes()
+}
+
+func pdfStreamObject(dict string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func deflate(t *testing.T, data []byte) []byte {
+	t.Helper()
+	out := &bytes.Buffer{}
+	zw := zlib.NewWriter(out)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+const testXMP = `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:title><rdf:Alt><rdf:li xml:lang="x-default">XMP Title</rdf:li></rdf:Alt></dc:title>
+</rdf:Description>
+</rdf:RDF>
+</x:xmpmeta>`
+
+func TestParsePDF(t *testing.T) {
+	const info = "<< /Title (Info Title) /Author (Ann; Bob) /CreationDate (D:20200102) >>"
+	const catalog = "<< /Type /Catalog /Metadata 3 0 R >>"
+	const trailer = "<< /Root 2 0 R /Info 1 0 R >>"
+
+	tests := []struct {
+		name    string
+		pdf     func(t *testing.T) []byte
+		title   string
+		written time.Time
+		err     bool
+	}{
+		{
+			name: "info",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, "<< /Type /Catalog >>")
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "xmp takes precedence",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, catalog, pdfStreamObject("/Type /Metadata", []byte(testXMP)))
+			},
+			title:   "XMP Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "long creation date",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer,
+					"<< /Title (Info Title) /CreationDate (D:202001021200000000000000) >>",
+					"<< /Type /Catalog >>")
+			},
+			title: "Info Title",
+		},
+		{
+			name: "malformed xmp",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, catalog, pdfStreamObject("/Type /Metadata", []byte("<x:xmpmeta><rdf:RDF")))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "undecodable xmp",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, catalog, pdfStreamObject("/Type /Metadata /Filter /FlateDecode", []byte("not deflated")))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "huge predictor columns",
+			pdf: func(t *testing.T) []byte {
+				xmp := deflate(t, []byte(testXMP))
+				return buildPDF(trailer, info, catalog, pdfStreamObject(
+					"/Type /Metadata /Filter /FlateDecode /DecodeParms << /Predictor 12 /Columns 4000000000 >>", xmp))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "predictor row longer than data",
+			pdf: func(t *testing.T) []byte {
+				xmp := deflate(t, []byte(testXMP))
+				return buildPDF(trailer, info, catalog, pdfStreamObject(
+					"/Type /Metadata /Filter /FlateDecode /DecodeParms << /Predictor 12 /Columns 100000 >>", xmp))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "predictor bad bits per component",
+			pdf: func(t *testing.T) []byte {
+				xmp := deflate(t, []byte(testXMP))
+				return buildPDF(trailer, info, catalog, pdfStreamObject(
+					"/Type /Metadata /Filter /FlateDecode /DecodeParms << /Predictor 12 /Colors 3 /BitsPerComponent 1000000 >>", xmp))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "object stream",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF("<< /Root 2 0 R /Info 4 0 R >>", info, "<< /Type /Catalog >>",
+					pdfStreamObject("/Type /ObjStm /N 1 /First 5", []byte("4 0  << /Title (Packed) >>")))
+			},
+			title: "Packed",
+		},
+		{
+			name: "object stream with a broken object",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF("<< /Root 2 0 R /Info 5 0 R >>", info, "<< /Type /Catalog >>",
+					pdfStreamObject("/Type /ObjStm /N 2 /First 11", []byte("4 1000 5 0 << /Title (Packed) >>")))
+			},
+			title: "Packed",
+		},
+		{
+			name: "object stream negative offset",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, "<< /Type /Catalog >>",
+					pdfStreamObject("/Type /ObjStm /N 1 /First 6", []byte("4 -20 << /Title (Packed) >>")))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "object stream offset past data",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, "<< /Type /Catalog >>",
+					pdfStreamObject("/Type /ObjStm /N 1 /First 7", []byte("4 1000 << /Title (Packed) >>")))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "object stream first past data",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, "<< /Type /Catalog >>",
+					pdfStreamObject("/Type /ObjStm /N 1 /First 1000", []byte("4 0 << /Title (Packed) >>")))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "object stream negative first",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, "<< /Type /Catalog >>",
+					pdfStreamObject("/Type /ObjStm /N 1 /First -5", []byte("4 0 << /Title (Packed) >>")))
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "huge stream length",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF(trailer, info, "<< /Type /Catalog >>",
+					"<< /Length 9223372036854775807 >>\nstream\ndata\nendstream")
+			},
+			title:   "Info Title",
+			written: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "encrypted",
+			pdf: func(t *testing.T) []byte {
+				return buildPDF("<< /Root 2 0 R /Info 1 0 R /Encrypt 3 0 R >>", info, "<< /Type /Catalog >>", "<< /Filter /Standard >>")
+			},
+			err: true,
+		},
+		{
+			name: "not a pdf",
+			pdf: func(t *testing.T) []byte {
+				return []byte("hello")
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			book, err := ParsePDF(bytes.NewReader(tc.pdf(t)))
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", book)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if book.Title != tc.title {
+				t.Errorf("title: got %q, want %q", book.Title, tc.title)
+			}
+			if !book.WrittenAt.Equal(tc.written) {
+				t.Errorf("written at: got %v, want %v", book.WrittenAt, tc.written)
+			}
+		})
+	}
+}
+
+func TestParsePDFEncrypted(t *testing.T) {
+	pdf := buildPDF("<< /Root 1 0 R /Encrypt 2 0 R >>", "<< /Type /Catalog >>", "<< /Filter /Standard >>")
+	if _, err := ParsePDF(bytes.NewReader(pdf)); !errors.Is(err, ErrPDFEncrypted) {
+		t.Fatalf("got %v, want %v", err, ErrPDFEncrypted)
+	}
+}
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		date string
+		want time.Time
+		err  bool
+	}{
+		{date: "D:2020", want: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{date: "D:20200102", want: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{date: "D:20200102030405Z", want: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{date: "D:20200102030405+03'00'", want: time.Date(2020, 1, 2, 0, 4, 5, 0, time.UTC)},
+		{date: "D:20200102030405-05", want: time.Date(2020, 1, 2, 8, 4, 5, 0, time.UTC)},
+		{date: "D:202001020304050", err: true},
+		{date: "D:2020010203040500", err: true},
+		{date: "D:" + strings.Repeat("1", 64), err: true},
+		{date: "D:202", err: true},
+		{date: "D:20201", err: true},
+		{date: "", err: true},
+		{date: "yesterday", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.date, func(t *testing.T) {
+			got, err := parsePDFDate(tc.date)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPNGUnpredict(t *testing.T) {
+	// two rows of two bytes: no filter, then the up filter
+	data := []byte{0, 1, 2, 2, 1, 1}
+	got, err := pngUnpredict(data, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{1, 2, 2, 3}; !bytes.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, rowSize := range []int{0, -1, len(data) + 1, 1 << 40} {
+		if _, err := pngUnpredict(data, 1, rowSize); err == nil {
+			t.Errorf("row size %d: expected an error", rowSize)
+		}
+	}
+}
+
+func TestFlateDecodeLimit(t *testing.T) {
+	data := deflate(t, make([]byte, maxStreamSize))
+	if got, err := flateDecode(data, nil); err != nil || len(got) != maxStreamSize {
+		t.Fatalf("at the limit: got %d bytes, %v", len(got), err)
+	}
+
+	// a few KB inflate past the limit
+	bomb := deflate(t, make([]byte, maxStreamSize+1))
+	if _, err := flateDecode(bomb, nil); !errors.Is(err, errPDFSyntax) {
+		t.Errorf("got %v, want %v", err, errPDFSyntax)
+	}
+}
+
+func TestParsePDFKeywordsLanguage(t *testing.T) {
+	const trailer = "<< /Root 2 0 R /Info 1 0 R >>"
+	const info = "<< /Title (Info Title) /Keywords (space; war, , opera) >>"
+	const xmpSubjects = `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description xmlns:dc="http://purl.org/dc/elements/1.1/">
+<dc:subject><rdf:Bag><rdf:li>xmp</rdf:li><rdf:li>tags</rdf:li></rdf:Bag></dc:subject>
+<dc:language><rdf:Bag><rdf:li>de</rdf:li></rdf:Bag></dc:language>
+</rdf:Description></rdf:RDF></x:xmpmeta>`
+	const xmpKeywords = `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+<rdf:Description xmlns:pdf="http://ns.adobe.com/pdf/1.3/" pdf:Keywords="one, two"/>
+</rdf:RDF></x:xmpmeta>`
+
+	tests := []struct {
+		name     string
+		pdf      []byte
+		keywords []string
+		language string
+	}{
+		{
+			name:     "info and catalog",
+			pdf:      buildPDF(trailer, info, "<< /Type /Catalog /Lang (pl-PL) >>"),
+			keywords: []string{"space", "war", "opera"},
+			language: "pl-PL",
+		},
+		{
+			name:     "referenced language",
+			pdf:      buildPDF(trailer, info, "<< /Type /Catalog /Lang 3 0 R >>", "(fr)"),
+			keywords: []string{"space", "war", "opera"},
+			language: "fr",
+		},
+		{
+			name: "xmp subjects and language",
+			pdf: buildPDF(trailer, info, "<< /Type /Catalog /Lang (pl) /Metadata 3 0 R >>",
+				pdfStreamObject("/Type /Metadata", []byte(xmpSubjects))),
+			keywords: []string{"xmp", "tags"},
+			language: "de",
+		},
+		{
+			name: "xmp keywords",
+			pdf: buildPDF(trailer, info, "<< /Type /Catalog /Metadata 3 0 R >>",
+				pdfStreamObject("/Type /Metadata", []byte(xmpKeywords))),
+			keywords: []string{"one", "two"},
+		},
+		{
+			name: "none",
+			pdf:  buildPDF(trailer, "<< /Title (Info Title) >>", "<< /Type /Catalog >>"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			book, err := ParsePDF(bytes.NewReader(tc.pdf))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(book.Keywords, tc.keywords) {
+				t.Errorf("keywords: got %q, want %q", book.Keywords, tc.keywords)
+			}
+			if book.Language != tc.language {
+				t.Errorf("language: got %q, want %q", book.Language, tc.language)
+			}
+		})
+	}
+}
+
+func TestParsePDFCover(t *testing.T) {
+	const trailer = "<< /Root 1 0 R >>"
+	const catalog = "<< /Type /Catalog /Pages 2 0 R >>"
+	jpeg := []byte("\xff\xd8\xff\xe0 jpeg data")
+	image := pdfStreamObject("/Type /XObject /Subtype /Image /Filter /DCTDecode", jpeg)
+
+	tests := []struct {
+		name  string
+		pdf   []byte
+		cover bool
+	}{
+		{
+			name: "single jpeg",
+			pdf: buildPDF(trailer, catalog,
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Resources << /XObject << /Im0 4 0 R >> >> >>",
+				image),
+			cover: true,
+		},
+		{
+			name: "filter array and inherited resources",
+			pdf: buildPDF(trailer, catalog,
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 /Resources << /XObject << /Im0 5 0 R >> >> >>",
+				"<< /Type /Pages /Kids [4 0 R] /Count 1 >>",
+				"<< /Type /Page >>",
+				pdfStreamObject("/Subtype /Image /Filter [/DCTDecode]", jpeg)),
+			cover: true,
+		},
+		{
+			name: "two images",
+			pdf: buildPDF(trailer, catalog,
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Resources << /XObject << /Im0 4 0 R /Im1 4 0 R >> >> >>",
+				image),
+		},
+		{
+			name: "not a jpeg",
+			pdf: buildPDF(trailer, catalog,
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Resources << /XObject << /Im0 4 0 R >> >> >>",
+				pdfStreamObject("/Subtype /Image /Filter /FlateDecode", deflate(t, []byte("pixels")))),
+		},
+		{
+			name: "form on the page",
+			pdf: buildPDF(trailer, catalog,
+				"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+				"<< /Type /Page /Resources << /XObject << /Fm0 4 0 R >> >> >>",
+				pdfStreamObject("/Subtype /Form /Filter /DCTDecode", jpeg)),
+		},
+		{
+			name: "no pages",
+			pdf:  buildPDF(trailer, catalog, "<< /Type /Pages /Kids [] /Count 0 >>"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			book, err := ParsePDF(bytes.NewReader(tc.pdf))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.cover {
+				if book.Cover != nil {
+					t.Errorf("got a cover of %d bytes, want none", len(book.Cover.Data))
+				}
+				return
+			}
+			if book.Cover == nil || book.Cover.ContentType != "image/jpeg" || !bytes.Equal(book.Cover.Data, jpeg) {
+				t.Errorf("got cover %+v, want the jpeg", book.Cover)
+			}
+		})
+	}
+}
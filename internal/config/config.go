@@ -0,0 +1,334 @@
+// Package config loads settings from a TOML or YAML file
+// with BIBLIOTHECA_* environment overrides.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ninedraft/bibliotheca/storage/database"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server      Server      `toml:"server" yaml:"server"`
+	Database    Database    `toml:"database" yaml:"database"`
+	Storage     Storage     `toml:"storage" yaml:"storage"`
+	Log         Log         `toml:"log" yaml:"log"`
+	Auth        Auth        `toml:"auth" yaml:"auth"`
+	Circulation Circulation `toml:"circulation" yaml:"circulation"`
+	Mail        Mail        `toml:"mail" yaml:"mail"`
+	Devices     Devices     `toml:"devices" yaml:"devices"`
+	Features    Features    `toml:"features" yaml:"features"`
+}
+
+type Server struct {
+	Addr              string        `toml:"addr" yaml:"addr"`
+	ReadHeaderTimeout time.Duration `toml:"read_header_timeout" yaml:"read_header_timeout"`
+	// ShutdownTimeout limits waiting for running requests and, separately,
+	// for background jobs after a termination signal, they are aborted after it.
+	ShutdownTimeout time.Duration `toml:"shutdown_timeout" yaml:"shutdown_timeout"`
+}
+
+type Database struct {
+	// Driver is sqlite or postgres.
+	Driver string `toml:"driver" yaml:"driver"`
+	// DSN is bib.sqlite in the working directory by default for sqlite.
+	DSN string `toml:"dsn" yaml:"dsn"`
+	// AutoMigrate applies pending migrations at startup.
+	AutoMigrate bool `toml:"auto_migrate" yaml:"auto_migrate"`
+}
+
+type Storage struct {
+	// Files is a directory for book files and covers.
+	Files string `toml:"files" yaml:"files"`
+	// Templates and Static override embedded assets if set.
+	Templates string `toml:"templates" yaml:"templates"`
+	Static    string `toml:"static" yaml:"static"`
+	// TrashRetention is how long deleted books are kept in the trash,
+	// zero keeps them until purged by hand.
+	TrashRetention time.Duration `toml:"trash_retention" yaml:"trash_retention"`
+}
+
+type Log struct {
+	// File is appended to, stderr is used if empty.
+	File     string `toml:"file" yaml:"file"`
+	Requests bool   `toml:"requests" yaml:"requests"`
+	SQL      bool   `toml:"sql" yaml:"sql"`
+}
+
+type Auth struct {
+	// PublicBrowsing lets anonymous users list and download books,
+	// adding books and authors always requires login.
+	PublicBrowsing bool          `toml:"public_browsing" yaml:"public_browsing"`
+	SessionTTL     time.Duration `toml:"session_ttl" yaml:"session_ttl"`
+	// SecureCookie sends the session cookie over HTTPS only.
+	SecureCookie bool `toml:"secure_cookie" yaml:"secure_cookie"`
+	// Secret signs form tokens and flash messages and keys hashes of
+	// KOReader sync passwords. A random one is used if empty, so open forms
+	// and sync passwords stop working after a restart.
+	Secret string `toml:"secret" yaml:"secret"`
+}
+
+type Circulation struct {
+	// PickupWindow is how long a returned copy is set aside
+	// for the next user in the holds queue.
+	PickupWindow time.Duration `toml:"pickup_window" yaml:"pickup_window"`
+}
+
+type Mail struct {
+	// SMTPAddr is host:port of the mail server, notifications are off if empty.
+	SMTPAddr string `toml:"smtp_addr" yaml:"smtp_addr"`
+	From     string `toml:"from" yaml:"from"`
+	Username string `toml:"username" yaml:"username"`
+	Password string `toml:"password" yaml:"password"`
+	// ImplicitTLS connects with TLS, usually to port 465,
+	// otherwise STARTTLS is used if the server offers it.
+	ImplicitTLS bool `toml:"implicit_tls" yaml:"implicit_tls"`
+	// AllowInsecureAuth sends the username and password to servers
+	// without STARTTLS, sending fails for them otherwise.
+	AllowInsecureAuth bool `toml:"allow_insecure_auth" yaml:"allow_insecure_auth"`
+	// BaseURL is the public address of the library for links in messages.
+	BaseURL string `toml:"base_url" yaml:"base_url"`
+	// DueReminder is how long before the due date borrowers are reminded.
+	DueReminder time.Duration `toml:"due_reminder" yaml:"due_reminder"`
+	// MaxAttempts limits delivery retries of a message.
+	MaxAttempts int `toml:"max_attempts" yaml:"max_attempts"`
+}
+
+type Devices struct {
+	// ConvertCommand is an external converter like calibre's ebook-convert,
+	// called with the input and output file paths. FB2 is converted
+	// to EPUB without it.
+	ConvertCommand string        `toml:"convert_command" yaml:"convert_command"`
+	ConvertTimeout time.Duration `toml:"convert_timeout" yaml:"convert_timeout"`
+	// MaxFileSize in bytes of books sent to devices,
+	// many mail servers reject larger messages.
+	MaxFileSize int64 `toml:"max_file_size" yaml:"max_file_size"`
+}
+
+type Features struct {
+	// Search enables the full-text index, substring search is used without it.
+	Search bool `toml:"search" yaml:"search"`
+	// EmbedMetadata writes edited metadata into downloaded FB2 files.
+	EmbedMetadata bool `toml:"embed_metadata" yaml:"embed_metadata"`
+	// ValidationReport enables the /admin/validation page.
+	ValidationReport bool `toml:"validation_report" yaml:"validation_report"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			Addr:              "localhost:8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ShutdownTimeout:   5 * time.Second,
+		},
+		Database: Database{
+			Driver: database.SQLite,
+		},
+		Storage: Storage{
+			Files:          "files",
+			TrashRetention: 30 * 24 * time.Hour,
+		},
+		Log: Log{
+			Requests: true,
+		},
+		Auth: Auth{
+			PublicBrowsing: true,
+			SessionTTL:     30 * 24 * time.Hour,
+		},
+		Circulation: Circulation{
+			PickupWindow: 72 * time.Hour,
+		},
+		Mail: Mail{
+			DueReminder: 48 * time.Hour,
+			MaxAttempts: 10,
+		},
+		Devices: Devices{
+			ConvertTimeout: 5 * time.Minute,
+			MaxFileSize:    25 << 20,
+		},
+		Features: Features{
+			Search:           true,
+			EmbedMetadata:    true,
+			ValidationReport: true,
+		},
+	}
+}
+
+// Load reads the config file over defaults and applies environment overrides.
+// An empty path means defaults only. The result is not validated.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, errRead := os.ReadFile(path)
+		if errRead != nil {
+			return nil, fmt.Errorf("config: %w", errRead)
+		}
+
+		var errDecode error
+		switch ext := strings.ToLower(filepath.Ext(path)); ext {
+		case ".toml":
+			var meta toml.MetaData
+			meta, errDecode = toml.Decode(string(data), cfg)
+			if undecoded := meta.Undecoded(); errDecode == nil && len(undecoded) > 0 {
+				errDecode = fmt.Errorf("unknown key %q", undecoded[0].String())
+			}
+		case ".yaml", ".yml":
+			decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+			decoder.KnownFields(true)
+			errDecode = decoder.Decode(cfg)
+		default:
+			errDecode = fmt.Errorf("unknown format %q, expected .toml, .yaml or .yml", ext)
+		}
+		if errDecode != nil {
+			return nil, fmt.Errorf("config %s: %w", path, errDecode)
+		}
+	}
+
+	if errEnv := applyEnv(cfg, os.Environ()); errEnv != nil {
+		return nil, fmt.Errorf("config: %w", errEnv)
+	}
+
+	return cfg, nil
+}
+
+// Validate returns all problems of the config joined.
+func (cfg *Config) Validate() error {
+	var errs []error
+	invalid := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]any{key}, args...)...))
+	}
+
+	if cfg.Server.Addr == "" {
+		invalid("server.addr", "must not be empty")
+	}
+	if cfg.Server.ReadHeaderTimeout <= 0 {
+		invalid("server.read_header_timeout", "must be positive")
+	}
+	if cfg.Server.ShutdownTimeout < 0 {
+		invalid("server.shutdown_timeout", "must not be negative")
+	}
+
+	switch cfg.Database.Driver {
+	case database.SQLite:
+	case database.Postgres:
+		if cfg.Database.DSN == "" {
+			invalid("database.dsn", "is required for postgres")
+		}
+	default:
+		invalid("database.driver", "unknown driver %q, expected %s or %s",
+			cfg.Database.Driver, database.SQLite, database.Postgres)
+	}
+
+	if cfg.Storage.Files == "" {
+		invalid("storage.files", "must not be empty")
+	}
+	for key, dir := range map[string]string{
+		"storage.templates": cfg.Storage.Templates,
+		"storage.static":    cfg.Storage.Static,
+	} {
+		if dir == "" {
+			continue
+		}
+		if info, err := os.Stat(dir); err != nil {
+			invalid(key, "%v", err)
+		} else if !info.IsDir() {
+			invalid(key, "%s is not a directory", dir)
+		}
+	}
+	if cfg.Storage.TrashRetention < 0 {
+		invalid("storage.trash_retention", "must not be negative")
+	}
+
+	if cfg.Auth.SessionTTL <= 0 {
+		invalid("auth.session_ttl", "must be positive")
+	}
+	if cfg.Auth.Secret != "" && len(cfg.Auth.Secret) < minSecretLen {
+		invalid("auth.secret", "must be at least %d characters long", minSecretLen)
+	}
+
+	if cfg.Circulation.PickupWindow <= 0 {
+		invalid("circulation.pickup_window", "must be positive")
+	}
+
+	if cfg.Mail.SMTPAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.Mail.SMTPAddr); err != nil {
+			invalid("mail.smtp_addr", "%v", err)
+		}
+		if _, err := mail.ParseAddress(cfg.Mail.From); err != nil {
+			invalid("mail.from", "%v", err)
+		}
+	}
+	if cfg.Mail.BaseURL != "" {
+		if u, err := url.Parse(cfg.Mail.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			invalid("mail.base_url", "must be an absolute URL like https://library.example.com")
+		}
+	}
+	if cfg.Mail.DueReminder <= 0 {
+		invalid("mail.due_reminder", "must be positive")
+	}
+	if cfg.Mail.MaxAttempts <= 0 {
+		invalid("mail.max_attempts", "must be positive")
+	}
+
+	if cfg.Devices.ConvertCommand != "" {
+		if _, err := exec.LookPath(cfg.Devices.ConvertCommand); err != nil {
+			invalid("devices.convert_command", "%v", err)
+		}
+	}
+	if cfg.Devices.ConvertTimeout <= 0 {
+		invalid("devices.convert_timeout", "must be positive")
+	}
+	if cfg.Devices.MaxFileSize <= 0 {
+		invalid("devices.max_file_size", "must be positive")
+	}
+
+	return errors.Join(errs...)
+}
+
+const minSecretLen = 32
+
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// Redacted returns a copy of the config with the database password,
+// the auth secret and the mail password masked.
+func (cfg *Config) Redacted() *Config {
+	redacted := *cfg
+	if u, err := url.Parse(cfg.Database.DSN); err == nil && u.User != nil {
+		redacted.Database.DSN = u.Redacted()
+	}
+	redacted.Database.DSN = dsnPassword.ReplaceAllString(redacted.Database.DSN, "${1}xxxxx")
+	if redacted.Auth.Secret != "" {
+		redacted.Auth.Secret = "xxxxx"
+	}
+	if redacted.Mail.Password != "" {
+		redacted.Mail.Password = "xxxxx"
+	}
+	return &redacted
+}
+
+// TOML returns the config in the TOML format.
+func (cfg *Config) TOML() ([]byte, error) {
+	var buf strings.Builder
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
+
+// YAML returns the config in the YAML format.
+func (cfg *Config) YAML() ([]byte, error) {
+	return yaml.Marshal(cfg)
+}
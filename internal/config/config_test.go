@@ -0,0 +1,334 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes the config file into a temporary directory.
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("got %+v, want defaults", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("defaults are invalid: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	want := Default()
+	want.Server.Addr = "0.0.0.0:9000"
+	want.Server.ShutdownTimeout = 30 * time.Second
+	want.Database.Driver = "postgres"
+	want.Database.DSN = "postgres://localhost/bib"
+	want.Log.Requests = false
+	want.Features.Search = false
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		err     string
+	}{
+		{
+			name: "toml",
+			file: "bib.toml",
+			content: `
+[server]
+addr = "0.0.0.0:9000"
+shutdown_timeout = "30s"
+
+[database]
+driver = "postgres"
+dsn = "postgres://localhost/bib"
+
+[log]
+requests = false
+
+[features]
+search = false
+`,
+		},
+		{
+			name: "yaml",
+			file: "bib.yaml",
+			content: `
+server:
+  addr: 0.0.0.0:9000
+  shutdown_timeout: 30s
+database:
+  driver: postgres
+  dsn: postgres://localhost/bib
+log:
+  requests: false
+features:
+  search: false
+`,
+		},
+		{
+			name: "yml",
+			file: "bib.yml",
+			content: `
+server: {addr: "0.0.0.0:9000", shutdown_timeout: 30s}
+database: {driver: postgres, dsn: "postgres://localhost/bib"}
+log: {requests: false}
+features: {search: false}
+`,
+		},
+		{
+			name:    "unknown toml key",
+			file:    "bib.toml",
+			content: "[server]\nadress = \"0.0.0.0:9000\"\n",
+			err:     `unknown key "server.adress"`,
+		},
+		{
+			name:    "unknown yaml key",
+			file:    "bib.yaml",
+			content: "server:\n  adress: 0.0.0.0:9000\n",
+			err:     "field adress not found",
+		},
+		{
+			name:    "malformed toml",
+			file:    "bib.toml",
+			content: "[server\n",
+			err:     "bib.toml",
+		},
+		{
+			name:    "bad duration",
+			file:    "bib.yaml",
+			content: "server:\n  shutdown_timeout: soon\n",
+			err:     "bib.yaml",
+		},
+		{
+			name:    "unknown format",
+			file:    "bib.json",
+			content: "{}",
+			err:     `unknown format ".json"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tc.file, tc.content))
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("got %v, want an error with %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cfg, want) {
+				t.Errorf("got %+v, want %+v", cfg, want)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("missing file: got no error")
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+	path := writeConfig(t, "bib.toml", `
+[server]
+addr = "0.0.0.0:9000"
+read_header_timeout = "10s"
+
+[database]
+dsn = "file.sqlite"
+`)
+	// the file path itself is not a config key
+	t.Setenv(EnvPrefix+"CONFIG", path)
+	t.Setenv(EnvPrefix+"SERVER_ADDR", "127.0.0.1:8000")
+	t.Setenv(EnvPrefix+"SERVER_SHUTDOWN_TIMEOUT", "1m")
+	t.Setenv(EnvPrefix+"DATABASE_AUTO_MIGRATE", "true")
+	t.Setenv(EnvPrefix+"LOG_SQL", "1")
+	t.Setenv("OTHER_SERVER_ADDR", "ignored")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Default()
+	// environment overrides take precedence over the file
+	want.Server.Addr = "127.0.0.1:8000"
+	want.Server.ReadHeaderTimeout = 10 * time.Second
+	want.Server.ShutdownTimeout = time.Minute
+	want.Database.DSN = "file.sqlite"
+	want.Database.AutoMigrate = true
+	want.Log.SQL = true
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("got %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadEnvErrors(t *testing.T) {
+	tests := []struct {
+		name, value string
+		err         string
+	}{
+		{"SERVER_ADRESS", "127.0.0.1:8000", "unknown variable BIBLIOTHECA_SERVER_ADRESS"},
+		{"SERVER_SHUTDOWN_TIMEOUT", "5", "BIBLIOTHECA_SERVER_SHUTDOWN_TIMEOUT"},
+		{"DATABASE_AUTO_MIGRATE", "sure", "BIBLIOTHECA_DATABASE_AUTO_MIGRATE"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(EnvPrefix+tc.name, tc.value)
+			if _, err := Load(""); err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("got %v, want an error with %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	file := writeConfig(t, "file", "")
+
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errs   []string
+	}{
+		{
+			name: "valid",
+			modify: func(cfg *Config) {
+				cfg.Database.Driver = "postgres"
+				cfg.Database.DSN = "postgres://localhost/bib"
+				cfg.Storage.Templates = t.TempDir()
+			},
+		},
+		{
+			name: "server",
+			modify: func(cfg *Config) {
+				cfg.Server.Addr = ""
+				cfg.Server.ReadHeaderTimeout = 0
+				cfg.Server.ShutdownTimeout = -time.Second
+			},
+			errs: []string{
+				"server.addr: must not be empty",
+				"server.read_header_timeout: must be positive",
+				"server.shutdown_timeout: must not be negative",
+			},
+		},
+		{
+			name:   "unknown driver",
+			modify: func(cfg *Config) { cfg.Database.Driver = "mysql" },
+			errs:   []string{`database.driver: unknown driver "mysql"`},
+		},
+		{
+			name:   "postgres without dsn",
+			modify: func(cfg *Config) { cfg.Database.Driver = "postgres" },
+			errs:   []string{"database.dsn: is required for postgres"},
+		},
+		{
+			name: "storage",
+			modify: func(cfg *Config) {
+				cfg.Storage.Files = ""
+				cfg.Storage.Templates = filepath.Join(t.TempDir(), "missing")
+				cfg.Storage.Static = file
+			},
+			errs: []string{
+				"storage.files: must not be empty",
+				"storage.templates:",
+				"storage.static: " + file + " is not a directory",
+			},
+		},
+		{
+			name: "auth",
+			modify: func(cfg *Config) {
+				cfg.Auth.SessionTTL = 0
+				cfg.Auth.Secret = "short"
+				cfg.Storage.TrashRetention = -time.Hour
+			},
+			errs: []string{
+				"auth.session_ttl: must be positive",
+				"auth.secret: must be at least 32 characters long",
+				"storage.trash_retention: must not be negative",
+			},
+		},
+		{
+			name: "mail",
+			modify: func(cfg *Config) {
+				cfg.Circulation.PickupWindow = 0
+				cfg.Mail.SMTPAddr = "mail.example.com"
+				cfg.Mail.From = "library"
+				cfg.Mail.BaseURL = "library.example.com"
+				cfg.Mail.DueReminder = 0
+				cfg.Mail.MaxAttempts = 0
+			},
+			errs: []string{
+				"circulation.pickup_window: must be positive",
+				"mail.smtp_addr:",
+				"mail.from:",
+				"mail.base_url: must be an absolute URL",
+				"mail.due_reminder: must be positive",
+				"mail.max_attempts: must be positive",
+			},
+		},
+		{
+			name: "devices",
+			modify: func(cfg *Config) {
+				cfg.Devices.ConvertCommand = "no-such-converter"
+				cfg.Devices.ConvertTimeout = 0
+				cfg.Devices.MaxFileSize = 0
+			},
+			errs: []string{
+				"devices.convert_command:",
+				"devices.convert_timeout: must be positive",
+				"devices.max_file_size: must be positive",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Default()
+			tc.modify(cfg)
+			err := cfg.Validate()
+			if len(tc.errs) == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			// all problems are reported at once, one per line
+			lines := strings.Split(err.Error(), "\n")
+			if len(lines) != len(tc.errs) {
+				t.Fatalf("got %q, want %d errors", lines, len(tc.errs))
+			}
+			for _, want := range tc.errs {
+				found := false
+				for _, line := range lines {
+					found = found || strings.HasPrefix(line, want)
+				}
+				if !found {
+					t.Errorf("no %q in %q", want, lines)
+				}
+			}
+		})
+	}
+}
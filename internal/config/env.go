@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix starts names of environment overrides,
+// like BIBLIOTHECA_SERVER_ADDR for server.addr.
+const EnvPrefix = "BIBLIOTHECA_"
+
+// applyEnv sets config fields from variables named after the section
+// and key of the field. Unknown BIBLIOTHECA_* variables are errors,
+// so typos don't go unnoticed.
+func applyEnv(cfg *Config, environ []string) error {
+	fields := envFields(reflect.ValueOf(cfg).Elem())
+
+	for _, variable := range environ {
+		name, value, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(name, EnvPrefix) || name == EnvPrefix+"CONFIG" {
+			continue
+		}
+		field, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("unknown variable %s", name)
+		}
+		if errSet := setField(field, value); errSet != nil {
+			return fmt.Errorf("%s: %w", name, errSet)
+		}
+	}
+	return nil
+}
+
+func envFields(root reflect.Value) map[string]reflect.Value {
+	fields := map[string]reflect.Value{}
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Field(i)
+		sectionKey := root.Type().Field(i).Tag.Get("toml")
+		for j := 0; j < section.NumField(); j++ {
+			key := section.Type().Field(j).Tag.Get("toml")
+			name := EnvPrefix + strings.ToUpper(sectionKey+"_"+key)
+			fields[name] = section.Field(j)
+		}
+	}
+	return fields
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setField(field reflect.Value, value string) error {
+	switch {
+	case field.Type() == durationType:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(duration))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Int || field.Kind() == reflect.Int64:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(number)
+	case field.Kind() == reflect.Bool:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(flag)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
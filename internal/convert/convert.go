@@ -0,0 +1,100 @@
+// Package convert turns stored book files into formats accepted
+// by e-readers. FB2 is converted to EPUB without external tools,
+// other conversions need an external converter like calibre's ebook-convert.
+package convert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+)
+
+var ErrUnsupported = errors.New("conversion is not supported")
+
+type Converter struct {
+	// Command is an external converter called with paths of the input
+	// and the output files, their extensions tell the formats.
+	// Only built in conversions are done if it's empty.
+	Command string
+	Timeout time.Duration
+}
+
+const defaultTimeout = 5 * time.Minute
+
+// Convert writes the book in the format to dst. Format names are file
+// extensions without the leading dot. Metadata of the book overrides
+// the one in the file if it's not nil.
+func (converter *Converter) Convert(ctx context.Context, dst io.Writer, src io.Reader, from, to string, book *bookinfo.Book) error {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	switch {
+	case from == to:
+		_, err := io.Copy(dst, src)
+		return err
+	case converter.Command != "":
+		return converter.external(ctx, dst, src, from, to)
+	case from == "fb2" && to == "epub":
+		return FB2ToEPUB(dst, src, book)
+	default:
+		return fmt.Errorf("%s to %s: %w", from, to, ErrUnsupported)
+	}
+}
+
+func (converter *Converter) external(ctx context.Context, dst io.Writer, src io.Reader, from, to string) error {
+	dir, errDir := os.MkdirTemp("", "bibliotheca-convert-*")
+	if errDir != nil {
+		return fmt.Errorf("convert: %w", errDir)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	input := filepath.Join(dir, "book."+from)
+	output := filepath.Join(dir, "converted."+to)
+	if err := writeFile(input, src); err != nil {
+		return fmt.Errorf("convert: %w", err)
+	}
+
+	timeout := converter.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, converter.Command, input, output)
+	cmd.Dir = dir
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("convert: %s: %w: %s", converter.Command, err, lastLine(stderr.String()))
+	}
+
+	result, errOpen := os.Open(output)
+	if errOpen != nil {
+		return fmt.Errorf("convert: %w", errOpen)
+	}
+	defer func() { _ = result.Close() }()
+	_, errCopy := io.Copy(dst, result)
+	return errCopy
+}
+
+func writeFile(name string, src io.Reader) error {
+	file, errCreate := os.Create(name)
+	if errCreate != nil {
+		return errCreate
+	}
+	_, errCopy := io.Copy(file, src)
+	return errors.Join(errCopy, file.Close())
+}
+
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,32 @@
+package convert
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	converter := &Converter{}
+	ctx := context.Background()
+	src := exampleFB2(t)
+
+	same := &bytes.Buffer{}
+	if err := converter.Convert(ctx, same, strings.NewReader(src), "FB2", "fb2", nil); err != nil || same.String() != src {
+		t.Errorf("same format: got %d bytes, %v, want a copy", same.Len(), err)
+	}
+
+	converted := &bytes.Buffer{}
+	if err := converter.Convert(ctx, converted, strings.NewReader(src), "fb2", "epub", nil); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := readEPUB(t, converted.Bytes()); files[0].Name != "mimetype" {
+		t.Errorf("got %s first, want an EPUB", files[0].Name)
+	}
+
+	if err := converter.Convert(ctx, &bytes.Buffer{}, strings.NewReader(src), "fb2", "mobi", nil); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("fb2 to mobi: got %v, want %v", err, ErrUnsupported)
+	}
+}
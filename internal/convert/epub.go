@@ -0,0 +1,539 @@
+package convert
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+)
+
+var errNoFictionBook = errors.New("fb2: root element FictionBook is missing")
+
+// FB2ToEPUB converts the FB2 document to EPUB 3. Top level sections
+// of the main body become chapters, other bodies like notes are
+// chapters of their own. Images without a known type are dropped.
+// The output depends only on the input, so converted files of
+// the same book are stored once.
+func FB2ToEPUB(dst io.Writer, src io.Reader, book *bookinfo.Book) error {
+	data, info, root, errParse := parseFB2(src)
+	if errParse != nil {
+		return errParse
+	}
+	if book != nil {
+		if book.Title != "" {
+			info.Title = book.Title
+		}
+		if len(book.Authors) > 0 {
+			info.Authors = book.Authors
+		}
+		if !book.WrittenAt.IsZero() {
+			info.WrittenAt = book.WrittenAt
+		}
+	}
+
+	e := &epub{info: info, anchors: map[string]string{}, images: map[string]*epubImage{}}
+	e.identifier = childText(root, "description/document-info/id")
+	if e.identifier == "" {
+		sum := sha256.Sum256(data)
+		e.identifier = "urn:sha256:" + hex.EncodeToString(sum[:])
+	}
+	e.collectImages(root)
+	if cover := xmlquery.FindOne(root, "description/title-info/coverpage/image"); cover != nil {
+		e.cover = e.images[strings.TrimPrefix(bookinfo.AttrLocal(cover, "href"), "#")]
+	}
+	e.collectChapters(root)
+
+	return e.write(dst)
+}
+
+// parseFB2 returns the document with its metadata and the root element.
+func parseFB2(src io.Reader) ([]byte, *bookinfo.Book, *xmlquery.Node, error) {
+	data, errRead := io.ReadAll(src)
+	if errRead != nil {
+		return nil, nil, nil, fmt.Errorf("read: %w", errRead)
+	}
+
+	info, errInfo := bookinfo.ParseFB2(bytes.NewReader(data))
+	if errInfo != nil {
+		return nil, nil, nil, errInfo
+	}
+
+	doc, errParse := xmlquery.Parse(bytes.NewReader(data))
+	if errParse != nil {
+		return nil, nil, nil, fmt.Errorf("xml parse: %w", errParse)
+	}
+	root := xmlquery.FindOne(doc, "/FictionBook")
+	if root == nil {
+		return nil, nil, nil, errNoFictionBook
+	}
+	return data, info, root, nil
+}
+
+type epub struct {
+	info       *bookinfo.Book
+	identifier string
+	chapters   []*epubChapter
+	// anchors map ids of FB2 elements to chapter files.
+	anchors map[string]string
+	images  map[string]*epubImage
+	// imageOrder keeps the manifest stable.
+	imageOrder []*epubImage
+	cover      *epubImage
+	// web renders chapters for the browser reader, see FB2ToHTML.
+	web   bool
+	paths map[*xmlquery.Node]string
+}
+
+type epubChapter struct {
+	file  string
+	title string
+	nodes []*xmlquery.Node
+}
+
+type epubFile struct {
+	name string
+	data []byte
+}
+
+type epubImage struct {
+	id          string
+	href        string
+	contentType string
+	data        []byte
+}
+
+var imageExts = map[string]string{
+	"image/jpeg": "jpg",
+	"image/jpg":  "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+func (e *epub) collectImages(root *xmlquery.Node) {
+	for _, binary := range xmlquery.Find(root, "binary") {
+		contentType := strings.ToLower(binary.SelectAttr("content-type"))
+		ext, ok := imageExts[contentType]
+		id := binary.SelectAttr("id")
+		if !ok || id == "" || e.images[id] != nil {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(stripSpaces(binary.InnerText()))
+		if err != nil {
+			continue
+		}
+		n := len(e.imageOrder) + 1
+		image := &epubImage{
+			id:          "image" + strconv.Itoa(n),
+			href:        "images/" + strconv.Itoa(n) + "." + ext,
+			contentType: contentType,
+			data:        data,
+		}
+		e.images[id] = image
+		e.imageOrder = append(e.imageOrder, image)
+	}
+}
+
+func (e *epub) collectChapters(root *xmlquery.Node) {
+	for _, body := range xmlquery.Find(root, "body") {
+		if name := body.SelectAttr("name"); name != "" {
+			title := titleText(body)
+			if title == "" {
+				first, size := utf8.DecodeRuneInString(name)
+				title = string(unicode.ToUpper(first)) + name[size:]
+			}
+			e.addChapter(title, elements(body))
+			continue
+		}
+
+		var intro []*xmlquery.Node
+		var sections []*xmlquery.Node
+		for _, child := range elements(body) {
+			if child.Data == "section" {
+				sections = append(sections, child)
+			} else if sections == nil {
+				intro = append(intro, child)
+			}
+		}
+		if len(intro) > 0 {
+			e.addChapter(e.info.Title, intro)
+		}
+		for _, section := range sections {
+			e.addChapter(titleText(section), []*xmlquery.Node{section})
+		}
+	}
+}
+
+func (e *epub) addChapter(title string, nodes []*xmlquery.Node) {
+	file := "chapter" + strconv.Itoa(len(e.chapters)+1) + ".xhtml"
+	if e.web {
+		file = "?chapter=" + strconv.Itoa(len(e.chapters)+1)
+	}
+	chapter := &epubChapter{
+		file:  file,
+		title: title,
+		nodes: nodes,
+	}
+	if chapter.title == "" {
+		chapter.title = "Chapter " + strconv.Itoa(len(e.chapters)+1)
+	}
+	for _, node := range nodes {
+		for _, anchor := range xmlquery.Find(node, "descendant-or-self::*[@id]") {
+			e.anchors[anchor.SelectAttr("id")] = chapter.file
+		}
+	}
+	e.chapters = append(e.chapters, chapter)
+}
+
+// epochModified is the fixed modification date of the package.
+const epochModified = "1970-01-01T00:00:00Z"
+
+func (e *epub) write(dst io.Writer) error {
+	archive := zip.NewWriter(dst)
+
+	// mimetype must be the first file and stored uncompressed
+	mimetype, errMimetype := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if errMimetype != nil {
+		return errMimetype
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	files := []epubFile{
+		{"META-INF/container.xml", []byte(epubContainer)},
+		{"OEBPS/content.opf", e.opf()},
+		{"OEBPS/nav.xhtml", e.nav()},
+		{"OEBPS/style.css", []byte(epubStyle)},
+	}
+	if e.cover != nil {
+		files = append(files, epubFile{"OEBPS/cover.xhtml", e.coverPage()})
+	}
+	for _, chapter := range e.chapters {
+		files = append(files, epubFile{"OEBPS/" + chapter.file, e.chapter(chapter)})
+	}
+	for _, image := range e.imageOrder {
+		files = append(files, epubFile{"OEBPS/" + image.href, image.data})
+	}
+
+	for _, file := range files {
+		w, errCreate := archive.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate})
+		if errCreate != nil {
+			return errCreate
+		}
+		if _, err := w.Write(file.data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+const epubContainer = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const epubStyle = `h1, h2, h3, h4, h5, h6 { text-align: center; }
+p { margin: 0; text-indent: 1.5em; }
+p.subtitle, p.text-author { text-align: center; text-indent: 0; }
+p.text-author { font-style: italic; }
+blockquote.epigraph { margin-left: 40%; font-size: 90%; }
+div.poem { margin: 1em 2em; }
+div.stanza { margin-bottom: 1em; }
+div.stanza p { text-indent: 0; }
+div.image { text-align: center; }
+img { max-width: 100%; }
+`
+
+func (e *epub) opf() []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">` + "\n")
+	buf.WriteString(`<metadata xmlns:dc="http://purl.org/dc/elements/1.1/">` + "\n")
+	fmt.Fprintf(buf, "<dc:identifier id=\"book-id\">%s</dc:identifier>\n", esc(e.identifier))
+	fmt.Fprintf(buf, "<dc:title>%s</dc:title>\n", esc(e.info.Title))
+	language := e.info.Language
+	if language == "" {
+		language = "und"
+	}
+	fmt.Fprintf(buf, "<dc:language>%s</dc:language>\n", esc(language))
+	for _, author := range e.info.Authors {
+		fmt.Fprintf(buf, "<dc:creator>%s</dc:creator>\n", esc(author))
+	}
+	if !e.info.WrittenAt.IsZero() {
+		fmt.Fprintf(buf, "<dc:date>%s</dc:date>\n", e.info.WrittenAt.Format(time.DateOnly))
+	}
+	if e.info.Annotation != "" {
+		fmt.Fprintf(buf, "<dc:description>%s</dc:description>\n", esc(e.info.Annotation))
+	}
+	fmt.Fprintf(buf, "<meta property=\"dcterms:modified\">%s</meta>\n", epochModified)
+	if e.cover != nil {
+		// Kindle looks for the EPUB 2 cover meta
+		fmt.Fprintf(buf, "<meta name=\"cover\" content=\"%s\"/>\n", e.cover.id)
+	}
+	buf.WriteString("</metadata>\n<manifest>\n")
+	buf.WriteString(`<item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	buf.WriteString(`<item id="style" href="style.css" media-type="text/css"/>` + "\n")
+	if e.cover != nil {
+		buf.WriteString(`<item id="cover" href="cover.xhtml" media-type="application/xhtml+xml"/>` + "\n")
+	}
+	for i, chapter := range e.chapters {
+		fmt.Fprintf(buf, "<item id=\"chapter%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, chapter.file)
+	}
+	for _, image := range e.imageOrder {
+		properties := ""
+		if image == e.cover {
+			properties = ` properties="cover-image"`
+		}
+		fmt.Fprintf(buf, "<item id=\"%s\" href=\"%s\" media-type=\"%s\"%s/>\n", image.id, image.href, image.contentType, properties)
+	}
+	buf.WriteString("</manifest>\n<spine>\n")
+	if e.cover != nil {
+		buf.WriteString(`<itemref idref="cover"/>` + "\n")
+	}
+	for i := range e.chapters {
+		fmt.Fprintf(buf, "<itemref idref=\"chapter%d\"/>\n", i+1)
+	}
+	buf.WriteString("</spine>\n</package>\n")
+	return buf.Bytes()
+}
+
+func (e *epub) nav() []byte {
+	body := &strings.Builder{}
+	body.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<h1>Contents</h1>\n<ol>\n")
+	for _, chapter := range e.chapters {
+		fmt.Fprintf(body, "<li><a href=\"%s\">%s</a></li>\n", chapter.file, esc(chapter.title))
+	}
+	body.WriteString("</ol>\n</nav>\n")
+	return xhtmlPage("Contents", body.String())
+}
+
+func (e *epub) coverPage() []byte {
+	body := fmt.Sprintf("<div class=\"image\"><img src=\"%s\" alt=\"%s\"/></div>\n", e.cover.href, esc(e.info.Title))
+	return xhtmlPage(e.info.Title, body)
+}
+
+func (e *epub) chapter(chapter *epubChapter) []byte {
+	w := &strings.Builder{}
+	for _, node := range chapter.nodes {
+		e.block(w, node, 0)
+	}
+	return xhtmlPage(chapter.title, w.String())
+}
+
+func xhtmlPage(title, body string) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">` + "\n")
+	fmt.Fprintf(buf, "<head>\n<title>%s</title>\n<link rel=\"stylesheet\" type=\"text/css\" href=\"style.css\"/>\n</head>\n", esc(title))
+	buf.WriteString("<body>\n")
+	buf.WriteString(body)
+	buf.WriteString("</body>\n</html>\n")
+	return buf.Bytes()
+}
+
+// blockTags map FB2 block elements to XHTML ones with a class.
+var blockTags = map[string][2]string{
+	"p":           {"p", ""},
+	"subtitle":    {"p", "subtitle"},
+	"text-author": {"p", "text-author"},
+	"v":           {"p", "v"},
+	"date":        {"p", "date"},
+	"cite":        {"blockquote", ""},
+	"epigraph":    {"blockquote", "epigraph"},
+	"annotation":  {"div", "annotation"},
+	"poem":        {"div", "poem"},
+	"stanza":      {"div", "stanza"},
+	"table":       {"table", ""},
+	"tr":          {"tr", ""},
+}
+
+// block writes the FB2 block element, depth is the nesting of sections.
+// Titles of top level sections are h2, h1 is left for the book title.
+func (e *epub) block(w *strings.Builder, node *xmlquery.Node, depth int) {
+	switch node.Data {
+	case "section":
+		fmt.Fprintf(w, "<section%s>\n", idAttr(node))
+		for _, child := range elements(node) {
+			e.block(w, child, depth+1)
+		}
+		w.WriteString("</section>\n")
+	case "title":
+		level := min(depth+1, 6)
+		fmt.Fprintf(w, "<h%d%s>", level, idAttr(node))
+		first := true
+		for _, p := range elements(node) {
+			if p.Data != "p" {
+				continue
+			}
+			if !first {
+				w.WriteString("<br/>")
+			}
+			first = false
+			e.inline(w, p)
+		}
+		fmt.Fprintf(w, "</h%d>\n", level)
+	case "empty-line":
+		w.WriteString("<p>&#160;</p>\n")
+	case "image":
+		if src := e.imageHref(node); src != "" {
+			fmt.Fprintf(w, "<div class=\"image\"%s><img src=\"%s\" alt=\"%s\"/></div>\n",
+				idAttr(node), src, esc(node.SelectAttr("alt")))
+		}
+	case "th", "td":
+		fmt.Fprintf(w, "<%s%s%s%s>", node.Data, spanAttr(node, "colspan"), spanAttr(node, "rowspan"), e.anchorAttr(node))
+		e.inline(w, node)
+		fmt.Fprintf(w, "</%s>\n", node.Data)
+	default:
+		tag, ok := blockTags[node.Data]
+		if !ok {
+			return
+		}
+		class := ""
+		if tag[1] != "" {
+			class = ` class="` + tag[1] + `"`
+		}
+		switch node.Data {
+		case "p", "subtitle", "text-author", "v", "date":
+			fmt.Fprintf(w, "<%s%s%s%s>", tag[0], class, idAttr(node), e.anchorAttr(node))
+			e.inline(w, node)
+		default:
+			fmt.Fprintf(w, "<%s%s%s>\n", tag[0], class, idAttr(node))
+			for _, child := range elements(node) {
+				e.block(w, child, depth)
+			}
+		}
+		fmt.Fprintf(w, "</%s>\n", tag[0])
+	}
+}
+
+var inlineTags = map[string]string{
+	"emphasis":      "em",
+	"strong":        "strong",
+	"strikethrough": "del",
+	"sub":           "sub",
+	"sup":           "sup",
+	"code":          "code",
+	"style":         "span",
+}
+
+// inline writes the text content of the element with inline markup.
+func (e *epub) inline(w *strings.Builder, node *xmlquery.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		switch child.Type {
+		case xmlquery.TextNode, xmlquery.CharDataNode:
+			w.WriteString(esc(child.Data))
+		case xmlquery.ElementNode:
+			switch child.Data {
+			case "a":
+				fmt.Fprintf(w, "<a href=\"%s\">", esc(e.linkHref(bookinfo.AttrLocal(child, "href"))))
+				e.inline(w, child)
+				w.WriteString("</a>")
+			case "image":
+				if src := e.imageHref(child); src != "" {
+					fmt.Fprintf(w, "<img src=\"%s\" alt=\"\"/>", src)
+				}
+			default:
+				tag, ok := inlineTags[child.Data]
+				if !ok {
+					e.inline(w, child)
+					continue
+				}
+				fmt.Fprintf(w, "<%s>", tag)
+				e.inline(w, child)
+				fmt.Fprintf(w, "</%s>", tag)
+			}
+		}
+	}
+}
+
+func (e *epub) linkHref(href string) string {
+	id, internal := strings.CutPrefix(href, "#")
+	if !internal {
+		return href
+	}
+	return e.anchors[id] + "#" + id
+}
+
+func (e *epub) imageHref(node *xmlquery.Node) string {
+	image := e.images[strings.TrimPrefix(bookinfo.AttrLocal(node, "href"), "#")]
+	switch {
+	case image == nil:
+		return ""
+	case e.web:
+		return "data:" + image.contentType + ";base64," + base64.StdEncoding.EncodeToString(image.data)
+	}
+	return image.href
+}
+
+func idAttr(node *xmlquery.Node) string {
+	if id := node.SelectAttr("id"); id != "" {
+		return ` id="` + esc(id) + `"`
+	}
+	return ""
+}
+
+func spanAttr(node *xmlquery.Node, name string) string {
+	if n, err := strconv.Atoi(node.SelectAttr(name)); err == nil && n > 1 {
+		return fmt.Sprintf(` %s="%d"`, name, n)
+	}
+	return ""
+}
+
+func elements(node *xmlquery.Node) []*xmlquery.Node {
+	var children []*xmlquery.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == xmlquery.ElementNode {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// titleText returns paragraphs of the element title joined by spaces.
+func titleText(node *xmlquery.Node) string {
+	var texts []string
+	for _, p := range xmlquery.Find(node, "title/p") {
+		if text := strings.Join(strings.Fields(p.InnerText()), " "); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
+
+func childText(node *xmlquery.Node, path string) string {
+	child := xmlquery.FindOne(node, path)
+	if child == nil {
+		return ""
+	}
+	return strings.TrimSpace(child.InnerText())
+}
+
+func stripSpaces(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\n', '\r':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func esc(s string) string {
+	return html.EscapeString(s)
+}
@@ -0,0 +1,185 @@
+package convert
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+)
+
+// pixel is a 1x1 PNG.
+const pixel = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg=="
+
+// exampleFB2 returns the example document with a cover and notes.
+func exampleFB2(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile("../bookinfo/testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := strings.Replace(string(data), "<coverpage></coverpage>",
+		`<coverpage><image l:href="#cover.png"/></coverpage>`, 1)
+	doc = strings.Replace(doc, "</FictionBook>", `<body name="примечания">
+    <section id="n1"><p>A note</p></section>
+  </body>
+  <binary id="cover.png" content-type="image/png">`+pixel+`</binary>
+</FictionBook>`, 1)
+	return doc
+}
+
+// readEPUB returns files of the archive in their order.
+func readEPUB(t *testing.T, data []byte) ([]*zip.File, map[string]string) {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents := map[string]string{}
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[file.Name] = string(content)
+	}
+	return archive.File, contents
+}
+
+// opfPackage is the part of content.opf the tests check.
+type opfPackage struct {
+	Title    string   `xml:"metadata>title"`
+	Creators []string `xml:"metadata>creator"`
+	Manifest []struct {
+		ID         string `xml:"id,attr"`
+		Href       string `xml:"href,attr"`
+		MediaType  string `xml:"media-type,attr"`
+		Properties string `xml:"properties,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+func TestFB2ToEPUB(t *testing.T) {
+	out := &bytes.Buffer{}
+	if err := FB2ToEPUB(out, strings.NewReader(exampleFB2(t)), nil); err != nil {
+		t.Fatal(err)
+	}
+	files, contents := readEPUB(t, out.Bytes())
+
+	// readers find the type at a fixed offset
+	if files[0].Name != "mimetype" || files[0].Method != zip.Store || contents["mimetype"] != "application/epub+zip" {
+		t.Errorf("first file: %s stored with method %d: %q", files[0].Name, files[0].Method, contents["mimetype"])
+	}
+	if !strings.Contains(contents["META-INF/container.xml"], `full-path="OEBPS/content.opf"`) {
+		t.Errorf("container doesn't point to the package:\n%s", contents["META-INF/container.xml"])
+	}
+
+	var opf opfPackage
+	if err := xml.Unmarshal([]byte(contents["OEBPS/content.opf"]), &opf); err != nil {
+		t.Fatal(err)
+	}
+	if opf.Title != "Fiction Book" || !slices.Equal(opf.Creators, []string{"John Doe"}) {
+		t.Errorf("got %q by %q", opf.Title, opf.Creators)
+	}
+
+	// the intro before sections, two sections and the notes body
+	chapters := []string{"chapter1.xhtml", "chapter2.xhtml", "chapter3.xhtml", "chapter4.xhtml"}
+	hrefs := map[string]string{}
+	var cover string
+	for _, item := range opf.Manifest {
+		hrefs[item.ID] = item.Href
+		if _, ok := contents["OEBPS/"+item.Href]; !ok {
+			t.Errorf("manifest item %s: no file %s", item.ID, item.Href)
+		}
+		if item.Properties == "cover-image" {
+			cover = item.Href
+		}
+	}
+	var spine []string
+	for _, ref := range opf.Spine {
+		spine = append(spine, hrefs[ref.IDRef])
+	}
+	if want := append([]string{"cover.xhtml"}, chapters...); !slices.Equal(spine, want) {
+		t.Errorf("spine: got %q, want %q", spine, want)
+	}
+
+	pngData := contents["OEBPS/"+cover]
+	if cover == "" || !strings.HasPrefix(pngData, "\x89PNG") {
+		t.Errorf("got cover %q", cover)
+	}
+	if !strings.Contains(contents["OEBPS/cover.xhtml"], `src="`+cover+`"`) {
+		t.Errorf("cover page doesn't show the cover:\n%s", contents["OEBPS/cover.xhtml"])
+	}
+
+	nav := contents["OEBPS/nav.xhtml"]
+	for i, title := range []string{"Fiction Book", "Chapter 1", "Chapter 2", "Примечания"} {
+		if !strings.Contains(nav, `<a href="`+chapters[i]+`">`+title+`</a>`) {
+			t.Errorf("nav has no %s:\n%s", title, nav)
+		}
+	}
+	if !strings.Contains(contents["OEBPS/chapter2.xhtml"], "<p>Line one of the first chapter</p>") {
+		t.Errorf("chapter 1:\n%s", contents["OEBPS/chapter2.xhtml"])
+	}
+
+	for name, content := range contents {
+		if !utf8.ValidString(content) && !strings.HasPrefix(name, "OEBPS/images/") {
+			t.Errorf("%s is not valid UTF-8", name)
+		}
+		if strings.HasSuffix(name, ".xhtml") || strings.HasSuffix(name, ".opf") {
+			if err := xml.Unmarshal([]byte(content), new(struct{})); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+	}
+}
+
+func TestFB2ToEPUBEscaping(t *testing.T) {
+	book := &bookinfo.Book{Title: `Tom & Jerry <"3">`, Authors: []string{"Ann & Bob"}}
+	out := &bytes.Buffer{}
+	if err := FB2ToEPUB(out, strings.NewReader(exampleFB2(t)), book); err != nil {
+		t.Fatal(err)
+	}
+	_, contents := readEPUB(t, out.Bytes())
+
+	var opf opfPackage
+	if err := xml.Unmarshal([]byte(contents["OEBPS/content.opf"]), &opf); err != nil {
+		t.Fatal(err)
+	}
+	if opf.Title != book.Title || !slices.Equal(opf.Creators, book.Authors) {
+		t.Errorf("got %q by %q, want %q by %q", opf.Title, opf.Creators, book.Title, book.Authors)
+	}
+	// the intro chapter is named after the book
+	for _, name := range []string{"OEBPS/cover.xhtml", "OEBPS/chapter1.xhtml"} {
+		var page struct {
+			Title string `xml:"head>title"`
+		}
+		if err := xml.Unmarshal([]byte(contents[name]), &page); err != nil || page.Title != book.Title {
+			t.Errorf("%s: got title %q, %v", name, page.Title, err)
+		}
+	}
+	var nav struct {
+		Links []string `xml:"body>nav>ol>li>a"`
+	}
+	if err := xml.Unmarshal([]byte(contents["OEBPS/nav.xhtml"]), &nav); err != nil || len(nav.Links) == 0 || nav.Links[0] != book.Title {
+		t.Errorf("nav: got %q, %v", nav.Links, err)
+	}
+}
+
+func TestFB2ToEPUBNotFB2(t *testing.T) {
+	if err := FB2ToEPUB(io.Discard, strings.NewReader("<html/>"), nil); err == nil {
+		t.Error("got no error")
+	}
+}
@@ -0,0 +1,123 @@
+package convert
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/antchfx/xmlquery"
+)
+
+var ErrBadAnchor = errors.New("fb2: no text at the position")
+
+// Chapter is a part of the book rendered for reading in the browser.
+type Chapter struct {
+	Title string
+	// HTML is the content. Blocks of text carry the data-anchor attribute,
+	// the path of the FB2 element like body[1]/section[2]/p[3].
+	HTML string
+}
+
+// FB2ToHTML splits the FB2 document into chapters the same way as FB2ToEPUB.
+// Links between chapters point to ?chapter=N, images are inlined.
+func FB2ToHTML(src io.Reader) ([]Chapter, error) {
+	_, info, root, errParse := parseFB2(src)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	e := &epub{
+		info:    info,
+		anchors: map[string]string{},
+		images:  map[string]*epubImage{},
+		web:     true,
+		paths:   map[*xmlquery.Node]string{},
+	}
+	e.collectPaths(root, "")
+	e.collectImages(root)
+	e.collectChapters(root)
+
+	chapters := make([]Chapter, 0, len(e.chapters))
+	for _, chapter := range e.chapters {
+		w := &strings.Builder{}
+		for _, node := range chapter.nodes {
+			e.block(w, node, 0)
+		}
+		chapters = append(chapters, Chapter{Title: chapter.title, HTML: w.String()})
+	}
+	return chapters, nil
+}
+
+// textBlocks are elements which get anchors, their content is inline.
+var textBlocks = map[string]bool{
+	"p": true, "subtitle": true, "text-author": true, "v": true, "date": true,
+	"th": true, "td": true,
+}
+
+// collectPaths remembers paths of text blocks under the bodies.
+func (e *epub) collectPaths(node *xmlquery.Node, path string) {
+	counts := map[string]int{}
+	for _, child := range elements(node) {
+		if path == "" && child.Data != "body" {
+			continue
+		}
+		counts[child.Data]++
+		childPath := child.Data + "[" + strconv.Itoa(counts[child.Data]) + "]"
+		if path != "" {
+			childPath = path + "/" + childPath
+		}
+		if textBlocks[child.Data] {
+			e.paths[child] = childPath
+			continue
+		}
+		e.collectPaths(child, childPath)
+	}
+}
+
+func (e *epub) anchorAttr(node *xmlquery.Node) string {
+	if path, ok := e.paths[node]; ok {
+		return ` data-anchor="` + path + `"`
+	}
+	return ""
+}
+
+// Passage is a piece of the text found by Locate.
+type Passage struct {
+	Text string
+	// Chapter is the title of the nearest section.
+	Chapter string
+}
+
+var anchorPath = regexp.MustCompile(`^body\[[1-9][0-9]*\](/[a-z-]+\[[1-9][0-9]*\])+$`)
+
+// Locate returns the text of the block with the anchor between the offsets.
+// Offsets count UTF-16 code units as browsers do.
+func Locate(src io.Reader, anchor string, start, end int) (*Passage, error) {
+	if !anchorPath.MatchString(anchor) {
+		return nil, ErrBadAnchor
+	}
+	_, _, root, errParse := parseFB2(src)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	node := xmlquery.FindOne(root, anchor)
+	if node == nil || !textBlocks[node.Data] {
+		return nil, ErrBadAnchor
+	}
+	text := utf16.Encode([]rune(node.InnerText()))
+	if start < 0 || start >= end || end > len(text) {
+		return nil, ErrBadAnchor
+	}
+
+	passage := &Passage{Text: string(utf16.Decode(text[start:end]))}
+	for parent := node.Parent; parent != nil && passage.Chapter == ""; parent = parent.Parent {
+		if parent.Data == "section" || parent.Data == "body" {
+			passage.Chapter = titleText(parent)
+		}
+	}
+	return passage, nil
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/checkpoint"
+)
+
+// Checkpoint persists the position of a long operation under its name,
+// an interrupted operation continues from it on the next run.
+type Checkpoint struct {
+	Storage *ent.Client
+	Name    string
+}
+
+// Load returns the saved position or an empty string.
+func (c *Checkpoint) Load(ctx context.Context) (string, error) {
+	found, err := c.Storage.Checkpoint.Query().
+		Where(checkpoint.Name(c.Name)).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("checkpoint %s: %w", c.Name, err)
+	}
+	return found.Value, nil
+}
+
+func (c *Checkpoint) Save(ctx context.Context, value string) error {
+	updated, errUpdate := c.Storage.Checkpoint.Update().
+		Where(checkpoint.Name(c.Name)).
+		SetValue(value).
+		Save(ctx)
+	if errUpdate == nil && updated == 0 {
+		errUpdate = c.Storage.Checkpoint.Create().
+			SetName(c.Name).
+			SetValue(value).
+			Exec(ctx)
+	}
+	if errUpdate != nil {
+		return fmt.Errorf("checkpoint %s: %w", c.Name, errUpdate)
+	}
+	return nil
+}
+
+// Clear removes the checkpoint of a finished operation.
+func (c *Checkpoint) Clear(ctx context.Context) error {
+	_, errDelete := c.Storage.Checkpoint.Delete().
+		Where(checkpoint.Name(c.Name)).
+		Exec(ctx)
+	if errDelete != nil {
+		return fmt.Errorf("checkpoint %s: %w", c.Name, errDelete)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package jobs runs periodic background jobs of the server
+// and keeps progress of interruptible commands like reindex.
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Run must return soon after the context is canceled. Jobs pick due
+	// items by their state in the database and change the state of each
+	// item as it's done, so they finish the current item and the next run
+	// picks the rest without a Checkpoint.
+	Run func(ctx context.Context) error
+}
+
+// Runner starts every job in its own goroutine and runs it each interval.
+type Runner struct {
+	Jobs []Job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (runner *Runner) Start(ctx context.Context) {
+	ctx, runner.cancel = context.WithCancel(ctx)
+	for _, job := range runner.Jobs {
+		runner.wg.Add(1)
+		go func(job Job) {
+			defer runner.wg.Done()
+			runner.loop(ctx, job)
+		}(job)
+	}
+}
+
+func (runner *Runner) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("ERROR: job %s: %v", job.Name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+// until the context is done.
+func (runner *Runner) Stop(ctx context.Context) error {
+	if runner.cancel == nil {
+		return nil
+	}
+	runner.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		runner.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunnerStop(t *testing.T) {
+	var runs, returned atomic.Int32
+	started := make(chan struct{}, 1)
+	runner := &Runner{Jobs: []Job{{
+		Name:     "slow",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			started <- struct{}{}
+			<-ctx.Done()
+			// saving progress takes a while
+			time.Sleep(20 * time.Millisecond)
+			returned.Add(1)
+			return ctx.Err()
+		},
+	}}}
+	runner.Start(context.Background())
+	<-started
+
+	if err := runner.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if returned.Load() != 1 {
+		t.Error("Stop returned before the job")
+	}
+	if runs.Load() != 1 {
+		t.Errorf("got %d runs, want 1", runs.Load())
+	}
+}
+
+func TestRunnerStopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{}, 1)
+	runner := &Runner{Jobs: []Job{{
+		Name:     "stuck",
+		Interval: time.Hour,
+		Run: func(context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+	}}}
+	runner.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := runner.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRunnerStopNotStarted(t *testing.T) {
+	if err := (&Runner{}).Stop(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
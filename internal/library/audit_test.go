@@ -0,0 +1,103 @@
+package library
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/auditentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/hook"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+)
+
+// auditLog returns entries of the entity, oldest first.
+func auditLog(t *testing.T, lib *Library, entity string, id int64) []*ent.AuditEntry {
+	t.Helper()
+	entries, err := lib.Storage.AuditEntry.Query().
+		Where(auditentry.Entity(entity), auditentry.EntityID(id)).
+		Order(ent.Asc(auditentry.FieldID)).
+		All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func findChange(changes []schema.FieldChange, field string) (schema.FieldChange, bool) {
+	i := slices.IndexFunc(changes, func(change schema.FieldChange) bool { return change.Field == field })
+	if i < 0 {
+		return schema.FieldChange{}, false
+	}
+	return changes[i], true
+}
+
+func TestAudit(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		librarian := newReaders(t, lib, "lib")[0]
+		actorCtx := hook.WithActor(ctx, hook.Actor{ID: librarian.ID, Login: librarian.Login})
+
+		authors, err := lib.EnsureAuthors(ctx, []string{"Ann", "Bob"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, err := lib.Storage.Book.Create().SetTitle("Draft").AddAuthorIDs(authors[0]).Save(actorCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.UpdateBook(actorCtx, created.ID, &BookMetadata{Title: "Final", Authors: authors[1:]}); err != nil {
+			t.Fatal(err)
+		}
+		// saving the same values is not a change
+		if _, err := lib.Storage.Book.UpdateOneID(created.ID).SetTitle("Final").Save(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		entries := auditLog(t, lib, ent.TypeBook, created.ID)
+		if len(entries) != 2 {
+			t.Fatalf("got %d entries, want 2", len(entries))
+		}
+		for _, entry := range entries {
+			actor, err := entry.QueryActor().OnlyID(ctx)
+			if entry.ActorLogin != "lib" || err != nil || actor != librarian.ID {
+				t.Errorf("%s: got actor %q %d, %v", entry.Op, entry.ActorLogin, actor, err)
+			}
+		}
+
+		createEntry, updateEntry := entries[0], entries[1]
+		if createEntry.Op != auditentry.OpCreate {
+			t.Errorf("got %s, want %s", createEntry.Op, auditentry.OpCreate)
+		}
+		if change, ok := findChange(createEntry.Changes, "title"); !ok || change.Old != nil || change.New != "Draft" {
+			t.Errorf("create title: got %+v", change)
+		}
+		if change, ok := findChange(createEntry.Changes, "authors"); !ok || !slices.Equal(change.Added, authors[:1]) {
+			t.Errorf("create authors: got %+v", change)
+		}
+
+		if updateEntry.Op != auditentry.OpUpdate {
+			t.Errorf("got %s, want %s", updateEntry.Op, auditentry.OpUpdate)
+		}
+		if change, ok := findChange(updateEntry.Changes, "title"); !ok || change.Old != "Draft" || change.New != "Final" {
+			t.Errorf("update title: got %+v", change)
+		}
+		change, ok := findChange(updateEntry.Changes, "authors")
+		if !ok || !slices.Equal(change.Added, authors[1:]) || !slices.Equal(change.Removed, authors[:1]) {
+			t.Errorf("update authors: got %+v", change)
+		}
+
+		// changes made without an actor are recorded too
+		if err := lib.Storage.Author.DeleteOneID(authors[0]).Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+		entries = auditLog(t, lib, ent.TypeAuthor, authors[0])
+		last := entries[len(entries)-1]
+		if last.Op != auditentry.OpDelete || last.ActorLogin != "" {
+			t.Errorf("got %s by %q, want a delete without an actor", last.Op, last.ActorLogin)
+		}
+		if change, ok := findChange(last.Changes, "name"); !ok || change.Old != "Ann" || change.New != nil {
+			t.Errorf("deleted name: got %+v", change)
+		}
+	})
+}
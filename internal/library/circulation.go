@@ -0,0 +1,191 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+var (
+	ErrCopyNotFound     = errors.New("no copy with this barcode")
+	ErrBorrowerNotFound = errors.New("no user with this login")
+	ErrOnLoan           = errors.New("copy is already on loan")
+	ErrNotOnLoan        = errors.New("copy is not on loan")
+	ErrDueInPast        = errors.New("due date is in the past")
+)
+
+// CheckOut lends the copy to the user until the due time.
+func (lib *Library) CheckOut(ctx context.Context, barcode, borrower string, due time.Time) (*ent.Loan, error) {
+	if due.Before(time.Now()) {
+		return nil, ErrDueInPast
+	}
+
+	// copies of books in the trash are not lent, so the books can be purged
+	found, errCopy := lib.Storage.BookCopy.Query().
+		Where(
+			bookcopy.Barcode(barcode),
+			bookcopy.HasBookWith(book.DeletedAtIsNil()),
+		).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(errCopy):
+		return nil, ErrCopyNotFound
+	case errCopy != nil:
+		return nil, fmt.Errorf("db: %w", errCopy)
+	}
+
+	borrowerID, errUser := lib.Storage.User.Query().
+		Where(user.Login(borrower)).
+		OnlyID(ctx)
+	switch {
+	case ent.IsNotFound(errUser):
+		return nil, ErrBorrowerNotFound
+	case errUser != nil:
+		return nil, fmt.Errorf("db: %w", errUser)
+	}
+
+	// the loan is kept only together with the fulfilled hold,
+	// otherwise the copy would be lent and still set aside
+	var created *ent.Loan
+	errTx := lib.withTx(ctx, func(client *ent.Client) error {
+		reserved, errHold := client.BookCopy.QueryHolds(found).
+			Where(hold.StatusEQ(hold.StatusReady)).
+			WithUser().
+			Only(ctx)
+		switch {
+		case ent.IsNotFound(errHold):
+			reserved = nil
+		case errHold != nil:
+			return fmt.Errorf("db: %w", errHold)
+		case reserved.Edges.User.ID != borrowerID:
+			return ErrReserved
+		}
+
+		// the partial unique index on open loans rejects a concurrent check-out
+		var errLoan error
+		created, errLoan = client.Loan.Create().
+			SetCopy(found).
+			SetBorrowerID(borrowerID).
+			SetDueAt(due.Unix()).
+			Save(ctx)
+		switch {
+		case ent.IsConstraintError(errLoan):
+			return ErrOnLoan
+		case errLoan != nil:
+			return fmt.Errorf("db: %w", errLoan)
+		}
+
+		if reserved != nil {
+			errFulfill := client.Hold.UpdateOne(reserved).
+				SetStatus(hold.StatusFulfilled).
+				Exec(ctx)
+			if errFulfill != nil {
+				return fmt.Errorf("db: %w", errFulfill)
+			}
+		}
+		return nil
+	})
+	if errTx != nil {
+		return nil, errTx
+	}
+	return created, nil
+}
+
+// CheckIn closes the open loan of the copy, the copy is set aside
+// for the next user waiting for the book.
+func (lib *Library) CheckIn(ctx context.Context, barcode string) error {
+	bookID, errCopy := lib.Storage.BookCopy.Query().
+		Where(bookcopy.Barcode(barcode)).
+		QueryBook().
+		OnlyID(intercept.SkipSoftDelete(ctx))
+	switch {
+	case ent.IsNotFound(errCopy):
+		return ErrCopyNotFound
+	case errCopy != nil:
+		return fmt.Errorf("db: %w", errCopy)
+	}
+
+	n, errUpdate := lib.Storage.Loan.Update().
+		Where(
+			loan.ReturnedAtIsNil(),
+			loan.HasCopyWith(bookcopy.Barcode(barcode)),
+		).
+		SetReturnedAt(time.Now().Unix()).
+		Save(ctx)
+	switch {
+	case errUpdate != nil:
+		return fmt.Errorf("db: %w", errUpdate)
+	case n == 0:
+		return ErrNotOnLoan
+	}
+	return lib.FillHolds(ctx, bookID)
+}
+
+// Overdue returns open loans past the due time with copies,
+// their books and borrowers, the longest overdue first.
+// Copies of deleted books are still on loan, so they are listed too.
+func (lib *Library) Overdue(ctx context.Context, now time.Time) ([]*ent.Loan, error) {
+	loans, err := lib.Storage.Loan.Query().
+		Where(
+			loan.ReturnedAtIsNil(),
+			loan.DueAtLT(now.Unix()),
+		).
+		WithCopy(func(query *ent.BookCopyQuery) { query.WithBook() }).
+		WithBorrower().
+		Order(ent.Asc(loan.FieldDueAt)).
+		All(intercept.SkipSoftDelete(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	return loans, nil
+}
+
+// RemindDue notifies borrowers of loans due within the lead time,
+// each loan is reminded once. It returns the number of reminders.
+func (lib *Library) RemindDue(ctx context.Context, now time.Time, lead time.Duration) (int, error) {
+	loans, errQuery := lib.Storage.Loan.Query().
+		Where(
+			loan.ReturnedAtIsNil(),
+			loan.RemindedAtIsNil(),
+			loan.DueAtGT(now.Unix()),
+			loan.DueAtLTE(now.Add(lead).Unix()),
+		).
+		WithCopy(func(query *ent.BookCopyQuery) { query.WithBook() }).
+		WithBorrower().
+		All(intercept.SkipSoftDelete(ctx))
+	if errQuery != nil {
+		return 0, fmt.Errorf("db: %w", errQuery)
+	}
+
+	for i, item := range loans {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		// a loan marked reminded gets its reminder even if the call is canceled,
+		// other loans are reminded by the next call
+		itemCtx := context.WithoutCancel(ctx)
+
+		errUpdate := lib.Storage.Loan.UpdateOne(item).
+			SetRemindedAt(now.Unix()).
+			Exec(itemCtx)
+		if errUpdate != nil {
+			return i, fmt.Errorf("db: %w", errUpdate)
+		}
+		lib.notify(itemCtx, item.Edges.Borrower, notify.KindDueReminder, &notify.DueReminder{
+			Book:    item.Edges.Copy.Edges.Book,
+			Barcode: item.Edges.Copy.Barcode,
+			Due:     time.Unix(item.DueAt, 0),
+		})
+	}
+	return len(loans), nil
+}
@@ -0,0 +1,257 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+)
+
+// newCopies creates a book with paper copies with the barcodes.
+func newCopies(t *testing.T, lib *Library, barcodes ...string) *ent.Book {
+	t.Helper()
+	ctx := context.Background()
+	created, err := lib.Storage.Book.Create().SetTitle("Paper " + barcodes[0]).Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, barcode := range barcodes {
+		if _, err := lib.Storage.BookCopy.Create().SetBarcode(barcode).SetBook(created).Save(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return created
+}
+
+// newReaders creates users with the logins.
+func newReaders(t *testing.T, lib *Library, logins ...string) []*ent.User {
+	t.Helper()
+	var created []*ent.User
+	for _, login := range logins {
+		reader, err := lib.Storage.User.Create().SetLogin(login).SetPasswordHash("-").Save(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, reader)
+	}
+	return created
+}
+
+func TestCheckOut(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		newCopies(t, lib, "001")
+		newReaders(t, lib, "ann", "bob")
+		due := time.Now().Add(time.Hour)
+
+		lent, err := lib.CheckOut(ctx, "001", "ann", due)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lent.DueAt != due.Unix() || lent.ReturnedAt != nil {
+			t.Errorf("got loan due at %d, returned at %v", lent.DueAt, lent.ReturnedAt)
+		}
+
+		tests := []struct {
+			name     string
+			barcode  string
+			borrower string
+			due      time.Time
+			err      error
+		}{
+			{"on loan", "001", "bob", due, ErrOnLoan},
+			{"unknown copy", "002", "bob", due, ErrCopyNotFound},
+			{"unknown borrower", "001", "eve", due, ErrBorrowerNotFound},
+			{"due in the past", "001", "bob", time.Now().Add(-time.Minute), ErrDueInPast},
+		}
+		for _, tc := range tests {
+			if _, err := lib.CheckOut(ctx, tc.barcode, tc.borrower, tc.due); !errors.Is(err, tc.err) {
+				t.Errorf("%s: got %v, want %v", tc.name, err, tc.err)
+			}
+		}
+
+		if err := lib.CheckIn(ctx, "001"); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.CheckIn(ctx, "001"); !errors.Is(err, ErrNotOnLoan) {
+			t.Errorf("checked in twice: got %v, want %v", err, ErrNotOnLoan)
+		}
+		if err := lib.CheckIn(ctx, "002"); !errors.Is(err, ErrCopyNotFound) {
+			t.Errorf("unknown copy: got %v, want %v", err, ErrCopyNotFound)
+		}
+		if _, err := lib.CheckOut(ctx, "001", "bob", due); err != nil {
+			t.Errorf("returned copy: %v", err)
+		}
+	})
+}
+
+func TestCheckOutConcurrent(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		newCopies(t, lib, "001")
+		const clerks = 8
+		var logins []string
+		for i := 0; i < clerks; i++ {
+			logins = append(logins, fmt.Sprintf("reader%d", i))
+		}
+		newReaders(t, lib, logins...)
+
+		// every call returns either success or a definite error,
+		// the copy is lent once and returned once
+		run := func(call func(i int) error, want error) {
+			t.Helper()
+			errs := make([]error, clerks)
+			var wg sync.WaitGroup
+			for i := 0; i < clerks; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					errs[i] = call(i)
+				}(i)
+			}
+			wg.Wait()
+
+			succeeded := 0
+			for _, err := range errs {
+				switch {
+				case err == nil:
+					succeeded++
+				case !errors.Is(err, want):
+					t.Errorf("got %v, want %v", err, want)
+				}
+			}
+			if succeeded != 1 {
+				t.Errorf("%d calls succeeded, want 1", succeeded)
+			}
+		}
+
+		run(func(i int) error {
+			_, err := lib.CheckOut(ctx, "001", logins[i], time.Now().Add(time.Hour))
+			return err
+		}, ErrOnLoan)
+		if n, _ := lib.Storage.Loan.Query().Count(ctx); n != 1 {
+			t.Errorf("got %d loans, want 1", n)
+		}
+
+		run(func(int) error { return lib.CheckIn(ctx, "001") }, ErrNotOnLoan)
+	})
+}
+
+func TestOverdue(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		newCopies(t, lib, "001", "002", "003")
+		newReaders(t, lib, "ann", "bob", "eve")
+
+		now := time.Now()
+		for i, login := range []string{"ann", "bob", "eve"} {
+			if _, err := lib.CheckOut(ctx, fmt.Sprintf("%03d", i+1), login, now.Add(time.Duration(i+1)*24*time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// the longest overdue loan goes first
+		overdue, err := lib.Overdue(ctx, now.Add(50*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(overdue) != 2 || overdue[0].Edges.Borrower.Login != "ann" || overdue[1].Edges.Copy.Barcode != "002" {
+			t.Errorf("got %d overdue loans", len(overdue))
+		}
+		if overdue[0].Edges.Copy.Edges.Book == nil {
+			t.Error("the book is not loaded")
+		}
+
+		if err := lib.CheckIn(ctx, "001"); err != nil {
+			t.Fatal(err)
+		}
+		if overdue, _ := lib.Overdue(ctx, now.Add(50*time.Hour)); len(overdue) != 1 {
+			t.Errorf("got %d overdue loans after the check-in, want 1", len(overdue))
+		}
+	})
+}
+
+func TestRemindDue(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		sent := &notifications{}
+		lib.Notifier = sent
+		newCopies(t, lib, "001", "002", "003")
+		newReaders(t, lib, "ann", "bob", "eve")
+
+		now := time.Now()
+		for i, login := range []string{"ann", "bob", "eve"} {
+			if _, err := lib.CheckOut(ctx, fmt.Sprintf("%03d", i+1), login, now.Add(time.Duration(i+1)*24*time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// ann's loan is due in a day, bob's in two
+		reminded, err := lib.RemindDue(ctx, now, 36*time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sent.take(); reminded != 1 || len(got) != 1 || got[0] != "ann due_reminder" {
+			t.Errorf("got %d reminders: %q", reminded, got)
+		}
+		// a loan is reminded once
+		reminded, err = lib.RemindDue(ctx, now.Add(12*time.Hour), 36*time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sent.take(); reminded != 1 || len(got) != 1 || got[0] != "bob due_reminder" {
+			t.Errorf("got %d reminders: %q", reminded, got)
+		}
+
+		// a canceled call leaves the rest to the next one
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if reminded, err := lib.RemindDue(canceled, now.Add(36*time.Hour), 36*time.Hour); !errors.Is(err, context.Canceled) || reminded != 0 {
+			t.Errorf("canceled: got %d reminders, %v", reminded, err)
+		}
+		if reminded, err := lib.RemindDue(ctx, now.Add(36*time.Hour), 36*time.Hour); err != nil || reminded != 1 {
+			t.Errorf("after cancel: got %d reminders, %v, want 1", reminded, err)
+		}
+		if got := sent.take(); len(got) != 1 || got[0] != "eve due_reminder" {
+			t.Errorf("got %q", got)
+		}
+	})
+}
+
+var errStoreHold = errors.New("holds are read-only")
+
+func TestCheckOutRollback(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		paper := newCopies(t, lib, "001")
+		ann := newReaders(t, lib, "ann")[0]
+
+		// the copy is free, so it's set aside for ann at once
+		placed, err := lib.PlaceHold(ctx, paper.ID, ann.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the hold is fulfilled after the loan is stored, its failure undoes the loan
+		lib.Storage.Hold.Use(func(ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) {
+				return nil, errStoreHold
+			})
+		})
+		if _, err := lib.CheckOut(ctx, "001", "ann", time.Now().Add(time.Hour)); !errors.Is(err, errStoreHold) {
+			t.Fatalf("got %v, want %v", err, errStoreHold)
+		}
+
+		if n, _ := lib.Storage.Loan.Query().Count(ctx); n != 0 {
+			t.Errorf("got %d loans, want the failed one undone", n)
+		}
+		if found, _ := lib.Storage.Hold.Get(ctx, placed.ID); found.Status != hold.StatusReady {
+			t.Errorf("got hold %s, want ready", found.Status)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/internal/convert"
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+var (
+	ErrMailDisabled   = errors.New("the library doesn't send mail")
+	ErrDeviceNotFound = errors.New("no such device")
+	ErrBookNotFound   = errors.New("no such book")
+	ErrNoFile         = errors.New("the book has no file")
+	ErrFileTooLarge   = errors.New("the file is too large to send by mail")
+)
+
+// DefaultMaxFileSize is used if the library has no MaxFileSize.
+const DefaultMaxFileSize = 25 << 20
+
+// Mailer queues stored files to devices.
+type Mailer interface {
+	SendFile(ctx context.Context, to *ent.User, data *notify.BookFile, fileID, name string) (*ent.OutboxMessage, error)
+}
+
+// deviceFormats lists formats accepted by kinds of devices, the first one
+// is the target of conversions. Devices missing here accept any format.
+var deviceFormats = map[device.Kind][]string{
+	device.KindKindle: {"epub", "pdf"},
+}
+
+// SendToDevice mails the book file to the device of the user, converting it
+// first if the device doesn't read the format. A failed conversion is
+// recorded in the returned delivery along with the error.
+func (lib *Library) SendToDevice(ctx context.Context, userID, deviceID, bookID int64) (*ent.Delivery, error) {
+	if lib.Mailer == nil {
+		return nil, ErrMailDisabled
+	}
+
+	target, errDevice := lib.Storage.Device.Query().
+		Where(device.ID(deviceID), device.HasUserWith(user.ID(userID))).
+		WithUser().
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(errDevice):
+		return nil, ErrDeviceNotFound
+	case errDevice != nil:
+		return nil, fmt.Errorf("db: %w", errDevice)
+	}
+
+	found, errBook := lib.Storage.Book.Query().
+		Where(book.ID(bookID)).
+		WithAuthors().
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(errBook):
+		return nil, ErrBookNotFound
+	case errBook != nil:
+		return nil, fmt.Errorf("db: %w", errBook)
+	case found.FileID == "":
+		return nil, ErrNoFile
+	}
+
+	format := files.Ext(found.FileID)
+	if accepted, ok := deviceFormats[target.Kind]; ok && !slices.Contains(accepted, format) {
+		format = accepted[0]
+	}
+
+	delivery := lib.Storage.Delivery.Create().
+		SetTo(target.Email).
+		SetFormat(format).
+		SetUserID(userID).
+		SetBookID(bookID).
+		SetDevice(target)
+
+	fileID, errFile := lib.deviceFile(ctx, found, format)
+	if errFile != nil {
+		created, errCreate := delivery.SetError(errFile.Error()).Save(ctx)
+		if errCreate != nil {
+			return nil, fmt.Errorf("db: %w", errCreate)
+		}
+		return created, errFile
+	}
+	// a reading app on the device identifies the converted file by its hash
+	if err := lib.rememberFile(ctx, bookID, fileID); err != nil {
+		return nil, err
+	}
+
+	msg, errSend := lib.Mailer.SendFile(ctx, target.Edges.User, &notify.BookFile{Book: found, Device: target},
+		fileID, attachmentName(found.Title, format))
+	if errSend != nil {
+		return nil, errSend
+	}
+
+	created, errCreate := delivery.SetMessage(msg).Save(ctx)
+	if errCreate != nil {
+		return nil, fmt.Errorf("db: %w", errCreate)
+	}
+	return created, nil
+}
+
+// deviceFile returns the id of the book file in the format,
+// converted files are kept in the store.
+func (lib *Library) deviceFile(ctx context.Context, found *ent.Book, format string) (string, error) {
+	fileID := found.FileID
+	if files.Ext(fileID) != format {
+		src, errOpen := lib.Files.Open(found.FileID)
+		if errOpen != nil {
+			return "", fmt.Errorf("files: %w", errOpen)
+		}
+		defer func() { _ = src.Close() }()
+
+		info := &bookinfo.Book{Title: found.Title}
+		if found.WrittenAt != 0 {
+			info.WrittenAt = time.Unix(found.WrittenAt, 0).UTC()
+		}
+		for _, author := range found.Edges.Authors {
+			info.Authors = append(info.Authors, author.Name)
+		}
+
+		converted := &bytes.Buffer{}
+		errConvert := lib.converter().Convert(ctx, converted, src, files.Ext(found.FileID), format, info)
+		if errConvert != nil {
+			return "", errConvert
+		}
+
+		var errPut error
+		fileID, errPut = lib.Files.Put("."+format, converted)
+		if errPut != nil {
+			return "", errPut
+		}
+	}
+
+	stored, errOpen := lib.Files.Open(fileID)
+	if errOpen != nil {
+		return "", fmt.Errorf("files: %w", errOpen)
+	}
+	defer func() { _ = stored.Close() }()
+	stat, errStat := stored.Stat()
+	if errStat != nil {
+		return "", fmt.Errorf("files: %w", errStat)
+	}
+	if stat.Size() > lib.maxFileSize() {
+		return "", fmt.Errorf("%w: %d MB", ErrFileTooLarge, stat.Size()>>20)
+	}
+	return fileID, nil
+}
+
+func (lib *Library) maxFileSize() int64 {
+	if lib.MaxFileSize > 0 {
+		return lib.MaxFileSize
+	}
+	return DefaultMaxFileSize
+}
+
+// attachmentName makes a file name of the book title,
+// characters which are not allowed in file names are replaced.
+func attachmentName(title, format string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = "book"
+	}
+	return name + "." + format
+}
+
+func (lib *Library) converter() *convert.Converter {
+	if lib.Converter != nil {
+		return lib.Converter
+	}
+	return &convert.Converter{}
+}
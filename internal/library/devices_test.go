@@ -0,0 +1,132 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+// newDevice adds a device of the kind to the user.
+func newDevice(t *testing.T, lib *Library, owner *ent.User, kind device.Kind) *ent.Device {
+	t.Helper()
+	created, err := lib.Storage.Device.Create().
+		SetName(string(kind)).
+		SetEmail(owner.Login + "-" + string(kind) + "@example.com").
+		SetKind(kind).
+		SetUser(owner).
+		Save(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created
+}
+
+func TestSendToDevice(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		lib.Mailer = &notify.Outbox{Storage: lib.Storage, Files: lib.Files}
+		fb2, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+		readers := newReaders(t, lib, "ann", "bob")
+		ann := readers[0]
+		kindle, other := newDevice(t, lib, ann, device.KindKindle), newDevice(t, lib, ann, device.KindOther)
+
+		tests := []struct {
+			name   string
+			device *ent.Device
+			format string
+		}{
+			// kindles don't read FB2, the book is converted
+			{"kindle", kindle, "epub"},
+			{"other", other, "fb2"},
+		}
+		for _, tc := range tests {
+			delivery, err := lib.SendToDevice(ctx, ann.ID, tc.device.ID, fb2.ID)
+			if err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if delivery.Format != tc.format || delivery.To != tc.device.Email || delivery.Error != "" {
+				t.Errorf("%s: got %+v", tc.name, delivery)
+			}
+			msg, err := delivery.QueryMessage().Only(ctx)
+			if err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if msg.To != tc.device.Email || files.Ext(msg.Attachment) != tc.format || msg.AttachmentName != fb2.Title+"."+tc.format {
+				t.Errorf("%s: got message to %s with %s named %q", tc.name, msg.To, msg.Attachment, msg.AttachmentName)
+			}
+		}
+
+	})
+}
+
+func TestSendToDeviceErrors(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		fb2, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+		readers := newReaders(t, lib, "ann", "bob")
+		ann, bob := readers[0], readers[1]
+		other := newDevice(t, lib, ann, device.KindOther)
+		paper := newCopies(t, lib, "001")
+
+		if _, err := lib.SendToDevice(ctx, ann.ID, other.ID, fb2.ID); !errors.Is(err, ErrMailDisabled) {
+			t.Errorf("no mail: got %v, want %v", err, ErrMailDisabled)
+		}
+		lib.Mailer = &notify.Outbox{Storage: lib.Storage, Files: lib.Files}
+
+		tests := []struct {
+			name   string
+			userID int64
+			bookID int64
+			err    error
+		}{
+			{"device of another user", bob.ID, fb2.ID, ErrDeviceNotFound},
+			{"unknown book", ann.ID, paper.ID + 1, ErrBookNotFound},
+			{"paper book", ann.ID, paper.ID, ErrNoFile},
+		}
+		for _, tc := range tests {
+			if _, err := lib.SendToDevice(ctx, tc.userID, other.ID, tc.bookID); !errors.Is(err, tc.err) {
+				t.Errorf("%s: got %v, want %v", tc.name, err, tc.err)
+			}
+		}
+
+		// a failed delivery is recorded for the user to see
+		lib.MaxFileSize = 10
+		delivery, err := lib.SendToDevice(ctx, ann.ID, other.ID, fb2.ID)
+		if !errors.Is(err, ErrFileTooLarge) {
+			t.Fatalf("got %v, want %v", err, ErrFileTooLarge)
+		}
+		if delivery == nil || delivery.Error == "" {
+			t.Errorf("got delivery %+v, want the error recorded", delivery)
+		}
+		if n, _ := lib.Storage.OutboxMessage.Query().Count(ctx); n != 0 {
+			t.Errorf("got %d messages, want none", n)
+		}
+	})
+}
+
+func TestAttachmentName(t *testing.T) {
+	tests := map[string]string{
+		"Solaris":           "Solaris.epub",
+		" Dune: Messiah ":   "Dune_ Messiah.epub",
+		`a/b\c*d?"e"<f>|g`:  "a_b_c_d__e__f__g.epub",
+		"tab\there":         "tab_here.epub",
+		"   ":               "book.epub",
+		"Пикник на обочине": "Пикник на обочине.epub",
+	}
+	for title, want := range tests {
+		if got := attachmentName(title, "epub"); got != want {
+			t.Errorf("%q: got %q, want %q", title, got, want)
+		}
+	}
+}
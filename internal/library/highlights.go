@@ -0,0 +1,210 @@
+package library
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ninedraft/bibliotheca/internal/convert"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+var (
+	ErrHighlightNotFound = errors.New("no such highlight")
+	ErrNotFB2            = errors.New("only FB2 books can be read in the browser")
+)
+
+// NewHighlight is a highlight made in the web reader, the text
+// is taken from the book file at the anchor.
+type NewHighlight struct {
+	Anchor string
+	Start  int
+	End    int
+	Note   string
+	Color  highlight.Color
+}
+
+// AddHighlight saves the highlight of the user in the book.
+func (lib *Library) AddHighlight(ctx context.Context, userID, bookID int64, form *NewHighlight) (*ent.Highlight, error) {
+	found, errBook := lib.Storage.Book.Get(ctx, bookID)
+	switch {
+	case ent.IsNotFound(errBook):
+		return nil, ErrBookNotFound
+	case errBook != nil:
+		return nil, fmt.Errorf("db: %w", errBook)
+	case files.Ext(found.FileID) != "fb2":
+		return nil, ErrNotFB2
+	}
+
+	file, errOpen := lib.Files.Open(found.FileID)
+	if errOpen != nil {
+		return nil, fmt.Errorf("files: %w", errOpen)
+	}
+	defer func() { _ = file.Close() }()
+
+	passage, errLocate := convert.Locate(file, form.Anchor, form.Start, form.End)
+	if errLocate != nil {
+		return nil, errLocate
+	}
+
+	create := lib.Storage.Highlight.Create().
+		SetAnchor(form.Anchor).
+		SetStart(form.Start).
+		SetEnd(form.End).
+		SetText(passage.Text).
+		SetChapter(passage.Chapter).
+		SetNote(form.Note).
+		SetBookID(bookID).
+		SetUserID(userID)
+	if form.Color != "" {
+		create.SetColor(form.Color)
+	}
+
+	created, errCreate := create.Save(ctx)
+	switch {
+	case ent.IsValidationError(errCreate):
+		return nil, errCreate
+	case errCreate != nil:
+		return nil, fmt.Errorf("db: %w", errCreate)
+	}
+	return created, nil
+}
+
+// UpdateHighlight changes the note and the color of the highlight.
+func (lib *Library) UpdateHighlight(ctx context.Context, userID, id int64, note string, color highlight.Color) (*ent.Highlight, error) {
+	n, errUpdate := lib.Storage.Highlight.Update().
+		Where(highlight.ID(id), highlight.HasUserWith(user.ID(userID))).
+		SetNote(note).
+		SetColor(color).
+		Save(ctx)
+	switch {
+	case ent.IsValidationError(errUpdate):
+		return nil, errUpdate
+	case errUpdate != nil:
+		return nil, fmt.Errorf("db: %w", errUpdate)
+	case n == 0:
+		return nil, ErrHighlightNotFound
+	}
+
+	updated, errGet := lib.Storage.Highlight.Get(ctx, id)
+	if errGet != nil {
+		return nil, fmt.Errorf("db: %w", errGet)
+	}
+	return updated, nil
+}
+
+func (lib *Library) DeleteHighlight(ctx context.Context, userID, id int64) error {
+	n, err := lib.Storage.Highlight.Delete().
+		Where(highlight.ID(id), highlight.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	switch {
+	case err != nil:
+		return fmt.Errorf("db: %w", err)
+	case n == 0:
+		return ErrHighlightNotFound
+	}
+	return nil
+}
+
+// Highlights returns highlights of the user with their books in reading order,
+// of all books if bookID is 0. Highlights of deleted books are skipped.
+func (lib *Library) Highlights(ctx context.Context, userID, bookID int64) ([]*ent.Highlight, error) {
+	query := lib.Storage.Highlight.Query().
+		Where(
+			highlight.HasUserWith(user.ID(userID)),
+			highlight.HasBookWith(book.DeletedAtIsNil()),
+		)
+	if bookID != 0 {
+		query = query.Where(highlight.HasBookWith(book.ID(bookID)))
+	}
+
+	found, err := query.
+		WithBook(func(query *ent.BookQuery) { query.WithAuthors() }).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	slices.SortFunc(found, func(a, b *ent.Highlight) int {
+		if c := cmp.Compare(strings.ToLower(a.Edges.Book.Title), strings.ToLower(b.Edges.Book.Title)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Edges.Book.ID, b.Edges.Book.ID); c != 0 {
+			return c
+		}
+		if c := slices.Compare(anchorKey(a.Anchor), anchorKey(b.Anchor)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Start, b.Start)
+	})
+	return found, nil
+}
+
+var anchorIndex = regexp.MustCompile(`\[(\d+)\]`)
+
+// anchorKey returns element indexes of the anchor, so p[10] goes after p[9].
+// Elements of different kinds at the same level are ordered by their index,
+// which is close enough for sections with mixed content.
+func anchorKey(anchor string) []int {
+	var key []int
+	for _, match := range anchorIndex.FindAllStringSubmatch(anchor, -1) {
+		n, _ := strconv.Atoi(match[1])
+		key = append(key, n)
+	}
+	return key
+}
+
+// ExportHighlights writes highlights in Markdown, grouped by books
+// and chapters. Highlighted text is quoted, notes follow it as they are.
+func ExportHighlights(w io.Writer, highlights []*ent.Highlight) error {
+	out := &strings.Builder{}
+	out.WriteString("# Highlights\n")
+
+	var bookID int64
+	chapter := ""
+	for _, item := range highlights {
+		if found := item.Edges.Book; found.ID != bookID {
+			bookID, chapter = found.ID, ""
+			out.WriteString("\n## " + markdownLine(found.Title))
+			var authors []string
+			for _, author := range found.Edges.Authors {
+				authors = append(authors, author.Name)
+			}
+			if len(authors) > 0 {
+				out.WriteString(" — " + markdownLine(strings.Join(authors, ", ")))
+			}
+			out.WriteString("\n")
+		}
+		if item.Chapter != "" && item.Chapter != chapter {
+			chapter = item.Chapter
+			out.WriteString("\n### " + markdownLine(chapter) + "\n")
+		}
+
+		out.WriteString("\n")
+		for _, line := range strings.Split(strings.TrimSpace(item.Text), "\n") {
+			out.WriteString("> " + strings.TrimSpace(line) + "\n")
+		}
+		if note := strings.TrimSpace(item.Note); note != "" {
+			out.WriteString("\n" + note + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// markdownLine makes the text safe for a heading.
+func markdownLine(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.NewReplacer(`\`, `\\`, "#", `\#`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`).Replace(text)
+}
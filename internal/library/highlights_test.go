@@ -0,0 +1,168 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/internal/convert"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+)
+
+func TestAddHighlight(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		ann := newReaders(t, lib, "ann")[0]
+		example, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		added, err := lib.AddHighlight(ctx, ann.ID, example.ID, &NewHighlight{
+			Anchor: "body[1]/section[1]/p[2]",
+			Start:  5,
+			End:    8,
+			Note:   "a note",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the text is taken from the book, not from the browser
+		if added.Text != "two" || added.Chapter != "Chapter 1" || added.Color != highlight.DefaultColor {
+			t.Errorf("got %+v", added)
+		}
+
+		invalid := []struct {
+			name string
+			form NewHighlight
+		}{
+			{"bad anchor", NewHighlight{Anchor: "body[1]/../p[1]", Start: 0, End: 4}},
+			{"no such block", NewHighlight{Anchor: "body[1]/section[3]/p[1]", Start: 0, End: 4}},
+			{"not a text block", NewHighlight{Anchor: "body[1]/section[1]", Start: 0, End: 4}},
+			{"empty range", NewHighlight{Anchor: "body[1]/section[1]/p[1]", Start: 4, End: 4}},
+			{"past the end", NewHighlight{Anchor: "body[1]/section[1]/p[1]", Start: 0, End: 100}},
+		}
+		for _, tc := range invalid {
+			if _, err := lib.AddHighlight(ctx, ann.ID, example.ID, &tc.form); !errors.Is(err, convert.ErrBadAnchor) {
+				t.Errorf("%s: got %v, want %v", tc.name, err, convert.ErrBadAnchor)
+			}
+		}
+
+		form := &NewHighlight{Anchor: "body[1]/section[1]/p[1]", Start: 0, End: 4, Color: "purple"}
+		if _, err := lib.AddHighlight(ctx, ann.ID, example.ID, form); !ent.IsValidationError(err) {
+			t.Errorf("unknown color: got %v, want a validation error", err)
+		}
+		form.Color = ""
+		if _, err := lib.AddHighlight(ctx, ann.ID, example.ID+1, form); !errors.Is(err, ErrBookNotFound) {
+			t.Errorf("unknown book: got %v, want %v", err, ErrBookNotFound)
+		}
+	})
+}
+
+func TestUpdateHighlight(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		readers := newReaders(t, lib, "ann", "bob")
+		ann, bob := readers[0], readers[1]
+		example, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+		added, err := lib.AddHighlight(ctx, ann.ID, example.ID, &NewHighlight{Anchor: "body[1]/section[1]/p[1]", Start: 0, End: 4})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := lib.UpdateHighlight(ctx, ann.ID, added.ID, "new note", highlight.ColorBlue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Note != "new note" || updated.Color != highlight.ColorBlue || updated.Text != added.Text {
+			t.Errorf("got %+v", updated)
+		}
+
+		// highlights of other readers are invisible
+		if _, err := lib.UpdateHighlight(ctx, bob.ID, added.ID, "", highlight.ColorPink); !errors.Is(err, ErrHighlightNotFound) {
+			t.Errorf("update by another reader: got %v, want %v", err, ErrHighlightNotFound)
+		}
+		if err := lib.DeleteHighlight(ctx, bob.ID, added.ID); !errors.Is(err, ErrHighlightNotFound) {
+			t.Errorf("delete by another reader: got %v, want %v", err, ErrHighlightNotFound)
+		}
+
+		if err := lib.DeleteHighlight(ctx, ann.ID, added.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteHighlight(ctx, ann.ID, added.ID); !errors.Is(err, ErrHighlightNotFound) {
+			t.Errorf("second delete: got %v, want %v", err, ErrHighlightNotFound)
+		}
+	})
+}
+
+func TestExportHighlights(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		readers := newReaders(t, lib, "ann", "bob")
+		ann, bob := readers[0], readers[1]
+		example, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// added out of the reading order
+		forms := []struct {
+			userID int64
+			form   NewHighlight
+		}{
+			{ann.ID, NewHighlight{Anchor: "body[1]/section[2]/p[1]", Start: 0, End: 8, Note: "*second*"}},
+			{ann.ID, NewHighlight{Anchor: "body[1]/section[1]/p[3]", Start: 0, End: 10}},
+			{ann.ID, NewHighlight{Anchor: "body[1]/section[1]/p[1]", Start: 5, End: 8}},
+			{bob.ID, NewHighlight{Anchor: "body[1]/section[1]/p[2]", Start: 0, End: 8}},
+		}
+		for _, item := range forms {
+			if _, err := lib.AddHighlight(ctx, item.userID, example.ID, &item.form); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		found, err := lib.Highlights(ctx, ann.ID, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := &strings.Builder{}
+		if err := ExportHighlights(out, found); err != nil {
+			t.Fatal(err)
+		}
+		want := "# Highlights\n" +
+			"\n## Fiction Book — John Doe\n" +
+			"\n### Chapter 1\n" +
+			"\n> one\n" +
+			"\n> Line three\n" +
+			"\n### Chapter 2\n" +
+			"\n> Line one\n" +
+			"\n*second*\n"
+		if out.String() != want {
+			t.Errorf("got\n%s\nwant\n%s", out, want)
+		}
+
+		// highlights of deleted books are skipped
+		if err := lib.DeleteBook(ctx, example.ID); err != nil {
+			t.Fatal(err)
+		}
+		if found, err := lib.Highlights(ctx, ann.ID, example.ID); err != nil || len(found) != 0 {
+			t.Errorf("got %d highlights of a deleted book, %v", len(found), err)
+		}
+	})
+}
+
+func TestAnchorKey(t *testing.T) {
+	anchors := []string{"body[1]/section[1]/p[9]", "body[1]/section[1]/p[10]", "body[1]/section[2]/p[1]", "body[2]/section[1]/p[1]"}
+	for i := 1; i < len(anchors); i++ {
+		a, b := anchorKey(anchors[i-1]), anchorKey(anchors[i])
+		if !(slices.Compare(a, b) < 0) {
+			t.Errorf("%s goes after %s", anchors[i-1], anchors[i])
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+var (
+	ErrNoCopies      = errors.New("the library has no paper copies of the book")
+	ErrHoldExists    = errors.New("you already have a hold on the book")
+	ErrHoldNotActive = errors.New("hold is not active")
+	ErrReserved      = errors.New("copy is set aside for another user")
+)
+
+// DefaultPickupWindow is used if the library has no PickupWindow.
+const DefaultPickupWindow = 72 * time.Hour
+
+// Notifier delivers messages to users.
+type Notifier interface {
+	Notify(ctx context.Context, to *ent.User, kind notify.Kind, data any) error
+}
+
+// notify logs failures, notifications don't stop the library work.
+func (lib *Library) notify(ctx context.Context, to *ent.User, kind notify.Kind, data any) {
+	if lib.Notifier == nil {
+		log.Printf("notify %s: %s", to.Login, kind)
+		return
+	}
+	if err := lib.Notifier.Notify(ctx, to, kind, data); err != nil {
+		log.Printf("ERROR: notify %s: %s: %v", to.Login, kind, err)
+	}
+}
+
+func (lib *Library) pickupWindow() time.Duration {
+	if lib.PickupWindow > 0 {
+		return lib.PickupWindow
+	}
+	return DefaultPickupWindow
+}
+
+// PlaceHold puts the user into the queue for the book. The hold is ready
+// at once if a copy is on the shelf.
+func (lib *Library) PlaceHold(ctx context.Context, bookID, userID int64) (*ent.Hold, error) {
+	// copies of books in the trash are not lent, holds on them would never be filled
+	live, errBook := lib.Storage.Book.Query().
+		Where(book.ID(bookID), book.DeletedAtIsNil()).
+		Exist(ctx)
+	switch {
+	case errBook != nil:
+		return nil, fmt.Errorf("db: %w", errBook)
+	case !live:
+		return nil, ErrBookNotFound
+	}
+
+	hasCopies, errCopies := lib.Storage.BookCopy.Query().
+		Where(bookcopy.HasBookWith(book.ID(bookID))).
+		Exist(ctx)
+	switch {
+	case errCopies != nil:
+		return nil, fmt.Errorf("db: %w", errCopies)
+	case !hasCopies:
+		return nil, ErrNoCopies
+	}
+
+	created, errCreate := lib.Storage.Hold.Create().
+		SetBookID(bookID).
+		SetUserID(userID).
+		Save(ctx)
+	switch {
+	case ent.IsConstraintError(errCreate):
+		return nil, ErrHoldExists
+	case errCreate != nil:
+		return nil, fmt.Errorf("db: %w", errCreate)
+	}
+
+	if err := lib.FillHolds(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return lib.Storage.Hold.Get(ctx, created.ID)
+}
+
+// CancelHold removes the user from the queue, a copy set aside
+// for the user goes to the next one.
+func (lib *Library) CancelHold(ctx context.Context, holdID, userID int64) error {
+	found, errHold := lib.Storage.Hold.Query().
+		Where(
+			hold.ID(holdID),
+			hold.HasUserWith(user.ID(userID)),
+			hold.StatusIn(hold.StatusWaiting, hold.StatusReady),
+		).
+		QueryBook().
+		OnlyID(intercept.SkipSoftDelete(ctx))
+	switch {
+	case ent.IsNotFound(errHold):
+		return ErrHoldNotActive
+	case errHold != nil:
+		return fmt.Errorf("db: %w", errHold)
+	}
+
+	n, errUpdate := lib.Storage.Hold.Update().
+		Where(
+			hold.ID(holdID),
+			hold.StatusIn(hold.StatusWaiting, hold.StatusReady),
+		).
+		SetStatus(hold.StatusCanceled).
+		Save(ctx)
+	switch {
+	case errUpdate != nil:
+		return fmt.Errorf("db: %w", errUpdate)
+	case n == 0:
+		return ErrHoldNotActive
+	}
+
+	return lib.FillHolds(ctx, found)
+}
+
+// QueuePosition returns the place of the waiting hold in the queue, starting from 1.
+func (lib *Library) QueuePosition(ctx context.Context, waiting *ent.Hold) (int, error) {
+	bookID, errBook := waiting.QueryBook().OnlyID(ctx)
+	if errBook != nil {
+		return 0, fmt.Errorf("db: %w", errBook)
+	}
+	ahead, err := lib.Storage.Hold.Query().
+		Where(
+			hold.HasBookWith(book.ID(bookID)),
+			hold.StatusEQ(hold.StatusWaiting),
+			hold.IDLT(waiting.ID),
+		).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+	return ahead + 1, nil
+}
+
+// FillHolds sets copies on the shelf aside for the first users in the queue
+// and notifies them. The unique indexes on holds make a concurrent call
+// fail to take the same copy, the loser tries the next one.
+// Paper copies of deleted books still circulate.
+func (lib *Library) FillHolds(ctx context.Context, bookID int64) error {
+	for {
+		next, errNext := lib.Storage.Hold.Query().
+			Where(
+				hold.HasBookWith(book.ID(bookID)),
+				hold.StatusEQ(hold.StatusWaiting),
+			).
+			Order(ent.Asc(hold.FieldID)).
+			WithUser().
+			WithBook().
+			First(intercept.SkipSoftDelete(ctx))
+		switch {
+		case ent.IsNotFound(errNext):
+			return nil
+		case errNext != nil:
+			return fmt.Errorf("db: %w", errNext)
+		}
+
+		available, errCopy := lib.Storage.BookCopy.Query().
+			Where(
+				bookcopy.HasBookWith(book.ID(bookID)),
+				bookcopy.Not(bookcopy.HasLoansWith(loan.ReturnedAtIsNil())),
+				bookcopy.Not(bookcopy.HasHoldsWith(hold.StatusEQ(hold.StatusReady))),
+			).
+			Order(ent.Asc(bookcopy.FieldID)).
+			First(ctx)
+		switch {
+		case ent.IsNotFound(errCopy):
+			return nil
+		case errCopy != nil:
+			return fmt.Errorf("db: %w", errCopy)
+		}
+
+		now := time.Now()
+		expires := now.Add(lib.pickupWindow())
+		n, errUpdate := lib.Storage.Hold.Update().
+			Where(
+				hold.ID(next.ID),
+				hold.StatusEQ(hold.StatusWaiting),
+			).
+			SetStatus(hold.StatusReady).
+			SetCopy(available).
+			SetReadyAt(now.Unix()).
+			SetExpiresAt(expires.Unix()).
+			Save(ctx)
+		switch {
+		case ent.IsConstraintError(errUpdate), errUpdate == nil && n == 0:
+			continue
+		case errUpdate != nil:
+			return fmt.Errorf("db: %w", errUpdate)
+		}
+
+		lib.notify(ctx, next.Edges.User, notify.KindHoldReady, &notify.HoldReady{
+			Book:    next.Edges.Book,
+			Barcode: available.Barcode,
+			Expires: expires,
+		})
+	}
+}
+
+// ExpireHolds ends ready holds not picked up in time and passes
+// their copies to the next users in the queues.
+func (lib *Library) ExpireHolds(ctx context.Context, now time.Time) (int, error) {
+	expired, errQuery := lib.Storage.Hold.Query().
+		Where(
+			hold.StatusEQ(hold.StatusReady),
+			hold.ExpiresAtLT(now.Unix()),
+		).
+		WithBook().
+		WithUser().
+		All(intercept.SkipSoftDelete(ctx))
+	if errQuery != nil {
+		return 0, fmt.Errorf("db: %w", errQuery)
+	}
+
+	count := 0
+	for _, item := range expired {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		// a canceled call passes on the copy of the expired hold before it returns,
+		// other holds are expired by the next call
+		itemCtx := context.WithoutCancel(ctx)
+
+		n, errUpdate := lib.Storage.Hold.Update().
+			Where(
+				hold.ID(item.ID),
+				hold.StatusEQ(hold.StatusReady),
+			).
+			SetStatus(hold.StatusExpired).
+			Save(itemCtx)
+		if errUpdate != nil {
+			return count, fmt.Errorf("db: %w", errUpdate)
+		}
+		if n == 0 {
+			continue
+		}
+		count++
+
+		lib.notify(itemCtx, item.Edges.User, notify.KindHoldExpired, &notify.HoldExpired{
+			Book: item.Edges.Book,
+		})
+
+		if err := lib.FillHolds(itemCtx, item.Edges.Book.ID); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,188 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+)
+
+// notifications records notifications instead of sending them.
+type notifications struct {
+	mu   sync.Mutex
+	sent []string
+}
+
+func (n *notifications) Notify(_ context.Context, to *ent.User, kind notify.Kind, _ any) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, to.Login+" "+string(kind))
+	return nil
+}
+
+// take returns notifications sent since the last call.
+func (n *notifications) take() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	sent := n.sent
+	n.sent = nil
+	return sent
+}
+
+func TestHoldQueue(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		sent := &notifications{}
+		lib.Notifier = sent
+		lib.PickupWindow = time.Hour
+		paper := newCopies(t, lib, "001")
+		readers := newReaders(t, lib, "lender", "ann", "bob", "eve")
+		ann, bob, eve := readers[1], readers[2], readers[3]
+
+		if _, err := lib.CheckOut(ctx, "001", "lender", time.Now().Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+
+		// the only copy is on loan, so everyone waits in the order of holds
+		var holds []*ent.Hold
+		for i, reader := range []*ent.User{ann, bob, eve} {
+			placed, err := lib.PlaceHold(ctx, paper.ID, reader.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if placed.Status != hold.StatusWaiting {
+				t.Errorf("%s: got %s, want waiting", reader.Login, placed.Status)
+			}
+			if position, err := lib.QueuePosition(ctx, placed); err != nil || position != i+1 {
+				t.Errorf("%s: got position %d, %v, want %d", reader.Login, position, err, i+1)
+			}
+			holds = append(holds, placed)
+		}
+		if _, err := lib.PlaceHold(ctx, paper.ID, ann.ID); !errors.Is(err, ErrHoldExists) {
+			t.Errorf("second hold: got %v, want %v", err, ErrHoldExists)
+		}
+
+		status := func(placed *ent.Hold) hold.Status {
+			t.Helper()
+			found, err := lib.Storage.Hold.Get(ctx, placed.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return found.Status
+		}
+		expectSent := func(want ...string) {
+			t.Helper()
+			if got := sent.take(); !slices.Equal(got, want) {
+				t.Errorf("got notifications %q, want %q", got, want)
+			}
+		}
+
+		// the returned copy is set aside for the first in the queue
+		if err := lib.CheckIn(ctx, "001"); err != nil {
+			t.Fatal(err)
+		}
+		expectSent("ann hold_ready")
+		ready, err := lib.Storage.Hold.Get(ctx, holds[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ready.Status != hold.StatusReady || ready.ExpiresAt == nil || *ready.ExpiresAt-*ready.ReadyAt != int64(time.Hour/time.Second) {
+			t.Errorf("got %+v, want ready for the pickup window", ready)
+		}
+		if position, _ := lib.QueuePosition(ctx, holds[1]); position != 1 {
+			t.Errorf("bob: got position %d, want 1", position)
+		}
+		if _, err := lib.CheckOut(ctx, "001", "bob", time.Now().Add(time.Hour)); !errors.Is(err, ErrReserved) {
+			t.Errorf("check out to bob: got %v, want %v", err, ErrReserved)
+		}
+
+		// the hold is fulfilled by the check-out, the next return goes to bob
+		if _, err := lib.CheckOut(ctx, "001", "ann", time.Now().Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if got := status(holds[0]); got != hold.StatusFulfilled {
+			t.Errorf("ann: got %s, want fulfilled", got)
+		}
+		if err := lib.CheckIn(ctx, "001"); err != nil {
+			t.Fatal(err)
+		}
+		expectSent("bob hold_ready")
+
+		// a canceled ready hold passes the copy on
+		if err := lib.CancelHold(ctx, holds[1].ID, eve.ID); !errors.Is(err, ErrHoldNotActive) {
+			t.Errorf("cancel a hold of another user: got %v, want %v", err, ErrHoldNotActive)
+		}
+		if err := lib.CancelHold(ctx, holds[1].ID, bob.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.CancelHold(ctx, holds[1].ID, bob.ID); !errors.Is(err, ErrHoldNotActive) {
+			t.Errorf("cancel twice: got %v, want %v", err, ErrHoldNotActive)
+		}
+		expectSent("eve hold_ready")
+
+		// a hold not picked up in time expires
+		if n, err := lib.ExpireHolds(ctx, time.Now()); err != nil || n != 0 {
+			t.Errorf("before the deadline: got %d expired, %v", n, err)
+		}
+		n, err := lib.ExpireHolds(ctx, time.Now().Add(2*time.Hour))
+		if err != nil || n != 1 {
+			t.Fatalf("got %d expired, %v, want 1", n, err)
+		}
+		if got := status(holds[2]); got != hold.StatusExpired {
+			t.Errorf("eve: got %s, want expired", got)
+		}
+		expectSent("eve hold_expired")
+
+		// the queue is empty, the copy is on the shelf for the next hold
+		placed, err := lib.PlaceHold(ctx, paper.ID, bob.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if placed.Status != hold.StatusReady {
+			t.Errorf("got %s, want ready at once", placed.Status)
+		}
+		expectSent("bob hold_ready")
+	})
+}
+
+func TestHoldQueueCopies(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		lib.Notifier = &notifications{}
+		paper := newCopies(t, lib, "001", "002")
+		readers := newReaders(t, lib, "ann", "bob", "eve")
+
+		digital, err := lib.Storage.Book.Create().SetTitle("Digital").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.PlaceHold(ctx, digital.ID, readers[0].ID); !errors.Is(err, ErrNoCopies) {
+			t.Errorf("no copies: got %v, want %v", err, ErrNoCopies)
+		}
+
+		// each copy on the shelf goes to one hold
+		var copies []int64
+		for _, reader := range readers {
+			placed, err := lib.PlaceHold(ctx, paper.ID, reader.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if placed.Status == hold.StatusReady {
+				copyID, err := placed.QueryCopy().OnlyID(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				copies = append(copies, copyID)
+			}
+		}
+		if len(copies) != 2 || copies[0] == copies[1] {
+			t.Errorf("got ready holds on copies %v, want two different ones", copies)
+		}
+	})
+}
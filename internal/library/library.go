@@ -0,0 +1,384 @@
+// Package library implements catalog operations shared
+// by the web service and the command line.
+package library
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/internal/convert"
+	"github.com/ninedraft/bibliotheca/internal/jobs"
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/author"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/files"
+	"github.com/ninedraft/bibliotheca/storage/search"
+)
+
+type Library struct {
+	Storage *ent.Client
+	Files   *files.Store
+	// Search is the full-text index, nil disables it.
+	Search *search.Index
+	// Notifier tells users about their loans, holds and new books,
+	// messages are logged if nil.
+	Notifier Notifier
+	// PickupWindow is how long a copy is set aside for a hold,
+	// DefaultPickupWindow if zero.
+	PickupWindow time.Duration
+	// Mailer sends books to devices, nil if mail is not configured.
+	Mailer Mailer
+	// MailAttempts is the number of delivery attempts after which
+	// messages are left unsent, their attachments are removed as garbage.
+	// Attachments of all unsent messages are kept if it's zero.
+	MailAttempts int
+	// Converter turns books into formats of devices,
+	// only built in conversions are done if nil.
+	Converter *convert.Converter
+	// MaxFileSize of files sent to devices, DefaultMaxFileSize if zero.
+	MaxFileSize int64
+	// Signer keys hashes of sync passwords of reading apps.
+	Signer *auth.Signer
+}
+
+// ErrExists is returned by Import if a book with the same file is in the catalog.
+var ErrExists = errors.New("book already exists")
+
+// ErrExistsInTrash is returned by Import if a book with the same file
+// is in the trash, it is to be restored instead. It wraps ErrExists.
+var ErrExistsInTrash = fmt.Errorf("%w in the trash", ErrExists)
+
+type StoredFile struct {
+	FileID  string
+	CoverID string
+	// Document is the PartialMD5 hash reading apps identify the file by.
+	Document string
+	Info     *bookinfo.Book
+	Problems []bookinfo.Problem
+}
+
+// StoreFile saves the book file and its cover, the format is chosen by the file name.
+// FB2 files are validated, the problems are kept for the validation report.
+func (lib *Library) StoreFile(ctx context.Context, name string, file io.ReadSeeker) (*StoredFile, error) {
+	format := Format(name)
+	info, errParse := bookinfo.Parse(format, file)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var problems []bookinfo.Problem
+	if format == "fb2" {
+		var errValidate error
+		problems, errValidate = bookinfo.ValidateFB2(file)
+		if errValidate != nil {
+			return nil, errValidate
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	document, errHash := PartialMD5(file)
+	if errHash != nil {
+		return nil, errHash
+	}
+
+	fileID, errPut := lib.Files.Put("."+format, file)
+	if errPut != nil {
+		return nil, errPut
+	}
+
+	if format == "fb2" {
+		if err := lib.saveValidation(ctx, fileID, problems); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &StoredFile{FileID: fileID, Document: document, Info: info, Problems: problems}
+	if info.Cover != nil {
+		coverID, errCover := lib.Files.Put(imageExt(info.Cover.ContentType), bytes.NewReader(info.Cover.Data))
+		if errCover != nil {
+			return nil, errCover
+		}
+		result.CoverID = coverID
+	}
+
+	return result, nil
+}
+
+// Format returns the book format by the file name extension.
+func Format(name string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+}
+
+func imageExt(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".bin"
+	}
+}
+
+// Import stores the file and adds a book described by its metadata.
+// The file name is used as a title if the metadata has none.
+func (lib *Library) Import(ctx context.Context, name string, file io.ReadSeeker) (*ent.Book, []bookinfo.Problem, error) {
+	stored, errStore := lib.StoreFile(ctx, name, file)
+	if errStore != nil {
+		return nil, nil, errStore
+	}
+
+	// deleted books keep their files until they are purged
+	existing, errExisting := lib.Storage.Book.Query().
+		Where(book.FileID(stored.FileID)).
+		First(intercept.SkipSoftDelete(ctx))
+	switch {
+	case ent.IsNotFound(errExisting):
+	case errExisting != nil:
+		return nil, nil, fmt.Errorf("db: %w", errExisting)
+	case existing.DeletedAt != nil:
+		return nil, nil, ErrExistsInTrash
+	default:
+		return nil, nil, ErrExists
+	}
+
+	authors, errAuthors := lib.EnsureAuthors(ctx, stored.Info.Authors)
+	if errAuthors != nil {
+		return nil, nil, fmt.Errorf("db: %w", errAuthors)
+	}
+
+	title := stored.Info.Title
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	}
+
+	creation := lib.Storage.Book.Create().
+		SetTitle(title).
+		SetFileID(stored.FileID).
+		AddAuthorIDs(authors...)
+	if stored.CoverID != "" {
+		creation.SetCoverID(stored.CoverID)
+	}
+	if !stored.Info.WrittenAt.IsZero() {
+		creation.SetWrittenAt(stored.Info.WrittenAt.Unix())
+	}
+	if stored.Info.Pages > 0 {
+		creation.SetPages(int64(stored.Info.Pages))
+	}
+
+	created, errCreate := creation.Save(ctx)
+	if errCreate != nil {
+		return nil, nil, fmt.Errorf("db: %w", errCreate)
+	}
+
+	if errLink := lib.LinkDocument(ctx, created.ID, stored.Document); errLink != nil {
+		return nil, nil, errLink
+	}
+
+	if errIndex := lib.IndexBook(ctx, created); errIndex != nil {
+		return nil, nil, errIndex
+	}
+
+	if errAnnounce := lib.AnnounceBook(ctx, created); errAnnounce != nil {
+		return nil, nil, errAnnounce
+	}
+
+	return created, stored.Problems, nil
+}
+
+// AnnounceBook notifies followers of the book authors about the new book.
+func (lib *Library) AnnounceBook(ctx context.Context, b *ent.Book) error {
+	authors, errAuthors := b.QueryAuthors().
+		WithFollowers().
+		All(ctx)
+	if errAuthors != nil {
+		return fmt.Errorf("db: %w", errAuthors)
+	}
+
+	var names []string
+	followers := map[int64]*ent.User{}
+	for _, item := range authors {
+		names = append(names, item.Name)
+		for _, follower := range item.Edges.Followers {
+			followers[follower.ID] = follower
+		}
+	}
+
+	for _, follower := range followers {
+		lib.notify(ctx, follower, notify.KindNewBook, &notify.NewBook{
+			Book:    b,
+			Authors: names,
+		})
+	}
+	return nil
+}
+
+// EnsureAuthors returns IDs of authors with the names, missing ones are created.
+func (lib *Library) EnsureAuthors(ctx context.Context, names []string) ([]int64, error) {
+	var ids []int64
+	for _, name := range names {
+		id, errQuery := lib.Storage.Author.Query().
+			Where(author.Name(name)).
+			FirstID(ctx)
+		if ent.IsNotFound(errQuery) {
+			created, errCreate := lib.Storage.Author.Create().
+				SetName(name).
+				Save(ctx)
+			if errCreate != nil {
+				return nil, errCreate
+			}
+			id, errQuery = created.ID, nil
+		}
+		if errQuery != nil {
+			return nil, errQuery
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// IndexBook updates the full-text search entry of the book.
+func (lib *Library) IndexBook(ctx context.Context, b *ent.Book) error {
+	if lib.Search == nil {
+		return nil
+	}
+	names, errAuthors := b.QueryAuthors().Select(author.FieldName).Strings(ctx)
+	if errAuthors != nil {
+		return fmt.Errorf("db: %w", errAuthors)
+	}
+	return lib.Search.Put(ctx, b.ID, b.Title, names)
+}
+
+const reindexBatch = 100
+
+// DeleteBook moves the book to the trash and removes it from the search index,
+// see RestoreBook and PurgeBook. Books with copies on loan are refused
+// with ErrBookOnLoan.
+func (lib *Library) DeleteBook(ctx context.Context, id int64) error {
+	errTx := lib.withTx(ctx, func(client *ent.Client) error {
+		if err := checkNoLoans(ctx, client, id); err != nil {
+			return err
+		}
+
+		errDelete := client.Book.UpdateOneID(id).
+			Where(book.DeletedAtIsNil()).
+			SetDeletedAt(time.Now().Unix()).
+			Exec(ctx)
+		switch {
+		case ent.IsNotFound(errDelete):
+			return ErrBookNotFound
+		case errDelete != nil:
+			return fmt.Errorf("db: %w", errDelete)
+		}
+		return nil
+	})
+	if errTx != nil {
+		return errTx
+	}
+	if lib.Search == nil {
+		return nil
+	}
+	return lib.Search.Delete(ctx, id)
+}
+
+// DeleteAuthor removes the author, books of the author are kept
+// and reindexed without the name.
+func (lib *Library) DeleteAuthor(ctx context.Context, id int64) error {
+	books, errBooks := lib.Storage.Author.Query().
+		Where(author.ID(id)).
+		QueryBooks().
+		All(ctx)
+	if errBooks != nil {
+		return fmt.Errorf("db: %w", errBooks)
+	}
+
+	if err := lib.Storage.Author.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+
+	for _, b := range books {
+		if err := lib.IndexBook(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reindex rebuilds the full-text index and returns the number of books
+// indexed by this call. Progress is saved after each batch of books,
+// so a canceled reindex continues from the checkpoint.
+func (lib *Library) Reindex(ctx context.Context, progress *jobs.Checkpoint) (int, error) {
+	if lib.Search == nil {
+		return 0, nil
+	}
+
+	saved, errLoad := progress.Load(ctx)
+	if errLoad != nil {
+		return 0, errLoad
+	}
+	var after int64
+	if saved != "" {
+		after, _ = strconv.ParseInt(saved, 10, 64)
+	} else if errReset := lib.Search.Reset(ctx); errReset != nil {
+		return 0, errReset
+	}
+
+	indexed := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return indexed, err
+		}
+
+		books, errBooks := lib.Storage.Book.Query().
+			Where(book.IDGT(after)).
+			Order(ent.Asc(book.FieldID)).
+			Limit(reindexBatch).
+			WithAuthors().
+			All(ctx)
+		if errBooks != nil {
+			return indexed, fmt.Errorf("db: %w", errBooks)
+		}
+		if len(books) == 0 {
+			break
+		}
+
+		for _, b := range books {
+			var names []string
+			for _, a := range b.Edges.Authors {
+				names = append(names, a.Name)
+			}
+			if errPut := lib.Search.Put(ctx, b.ID, b.Title, names); errPut != nil {
+				return indexed, errPut
+			}
+		}
+		indexed += len(books)
+		after = books[len(books)-1].ID
+
+		if errSave := progress.Save(ctx, strconv.FormatInt(after, 10)); errSave != nil {
+			return indexed, errSave
+		}
+	}
+
+	return indexed, progress.Clear(ctx)
+}
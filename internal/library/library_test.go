@@ -0,0 +1,90 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/storage/database/dbtest"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/hook"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/files"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+	"github.com/ninedraft/bibliotheca/storage/search"
+)
+
+// newLibrary returns a library on an empty database of the driver
+// with hooks and interceptors of the server.
+func newLibrary(t *testing.T, driver string) *Library {
+	t.Helper()
+	db, dbDialect := dbtest.Open(t, driver)
+	if err := (&migrations.Migrator{DB: db, Dialect: dbDialect}).Up(context.Background(), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dbDialect, db)))
+	client.Book.Use(hook.Audit(), hook.Revisions())
+	client.Author.Use(hook.Audit(), hook.Revisions())
+	client.Book.Intercept(intercept.SoftDelete())
+
+	return &Library{
+		Storage: client,
+		Files:   &files.Store{Dir: t.TempDir()},
+		Search:  &search.Index{DB: db, Dialect: dbDialect},
+		Signer:  &auth.Signer{Key: []byte("0123456789abcdef0123456789abcdef")},
+	}
+}
+
+// forEachLibrary runs the test as a subtest for every driver
+// of dbtest.Drivers with a library on an empty database.
+func forEachLibrary(t *testing.T, test func(t *testing.T, lib *Library)) {
+	for _, driver := range dbtest.Drivers {
+		t.Run(driver, func(t *testing.T) {
+			test(t, newLibrary(t, driver))
+		})
+	}
+}
+
+// importExample imports the example FB2 book.
+func importExample(t *testing.T, lib *Library) (*ent.Book, error) {
+	t.Helper()
+	file, err := os.Open("../bookinfo/testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	created, _, errImport := lib.Import(context.Background(), "example.fb2", file)
+	return created, errImport
+}
+
+func TestImport(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		created, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.FileID == "" || created.Title == "" {
+			t.Errorf("got %q with file %q", created.Title, created.FileID)
+		}
+
+		// reading apps find the book by the hash of the imported file
+		linked, err := lib.Storage.Document.Query().QueryBook().OnlyID(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if linked != created.ID {
+			t.Errorf("document of book %d, want %d", linked, created.ID)
+		}
+
+		if _, err := importExample(t, lib); !errors.Is(err, ErrExists) {
+			t.Errorf("second import: got %v, want %v", err, ErrExists)
+		}
+	})
+}
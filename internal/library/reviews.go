@@ -0,0 +1,128 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+var ErrReviewNotFound = errors.New("no such review")
+
+// Rating is the average of visible reviews of a book.
+type Rating struct {
+	Average float64
+	Count   int
+}
+
+// ReviewBook saves the rating and the review text of the user, replacing
+// the previous review of the book. Edits don't reveal hidden reviews.
+func (lib *Library) ReviewBook(ctx context.Context, userID, bookID int64, rating int, text string) (*ent.Review, error) {
+	exists, errBook := lib.Storage.Book.Query().Where(book.ID(bookID)).Exist(ctx)
+	switch {
+	case errBook != nil:
+		return nil, fmt.Errorf("db: %w", errBook)
+	case !exists:
+		return nil, ErrBookNotFound
+	}
+
+	saved, errSave := lib.updateReview(ctx, userID, bookID, rating, text)
+	if ent.IsNotFound(errSave) {
+		saved, errSave = lib.Storage.Review.Create().
+			SetRating(rating).
+			SetText(text).
+			SetBookID(bookID).
+			SetUserID(userID).
+			Save(ctx)
+		if ent.IsConstraintError(errSave) {
+			// created by a concurrent request
+			saved, errSave = lib.updateReview(ctx, userID, bookID, rating, text)
+		}
+	}
+	switch {
+	case ent.IsValidationError(errSave):
+		return nil, errSave
+	case errSave != nil:
+		return nil, fmt.Errorf("db: %w", errSave)
+	}
+	return saved, nil
+}
+
+func (lib *Library) updateReview(ctx context.Context, userID, bookID int64, rating int, text string) (*ent.Review, error) {
+	found, errQuery := lib.Storage.Review.Query().
+		Where(review.HasBookWith(book.ID(bookID)), review.HasUserWith(user.ID(userID))).
+		Only(ctx)
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	return found.Update().
+		SetRating(rating).
+		SetText(text).
+		Save(ctx)
+}
+
+// DeleteReview removes the review of the book written by the user.
+func (lib *Library) DeleteReview(ctx context.Context, userID, bookID int64) error {
+	_, err := lib.Storage.Review.Delete().
+		Where(review.HasBookWith(book.ID(bookID)), review.HasUserWith(user.ID(userID))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	return nil
+}
+
+// HideReview hides the review from readers and the rating,
+// or shows it again. The moderator is remembered.
+func (lib *Library) HideReview(ctx context.Context, moderatorID, reviewID int64, hidden bool) (*ent.Review, error) {
+	found, errQuery := lib.Storage.Review.Get(ctx, reviewID)
+	switch {
+	case ent.IsNotFound(errQuery):
+		return nil, ErrReviewNotFound
+	case errQuery != nil:
+		return nil, fmt.Errorf("db: %w", errQuery)
+	}
+
+	// moderation is not an edit of the text
+	update := found.Update().SetHidden(hidden).SetUpdatedAt(found.UpdatedAt)
+	if hidden {
+		update.SetHiddenAt(time.Now().Unix()).SetHiddenByID(moderatorID)
+	} else {
+		update.ClearHiddenAt().ClearHiddenBy()
+	}
+
+	updated, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	return updated, nil
+}
+
+// Ratings returns ratings of the books, books without
+// visible reviews are missing.
+func (lib *Library) Ratings(ctx context.Context, bookIDs ...int64) (map[int64]Rating, error) {
+	var rows []struct {
+		BookID  int64   `json:"review_book"`
+		Count   int     `json:"count"`
+		Average float64 `json:"mean"`
+	}
+	err := lib.Storage.Review.Query().
+		Where(review.Hidden(false), review.HasBookWith(book.IDIn(bookIDs...))).
+		GroupBy(review.BookColumn).
+		Aggregate(ent.As(ent.Count(), "count"), ent.As(ent.Mean(review.FieldRating), "mean")).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	ratings := make(map[int64]Rating, len(rows))
+	for _, row := range rows {
+		ratings[row.BookID] = Rating{Average: row.Average, Count: row.Count}
+	}
+	return ratings, nil
+}
@@ -0,0 +1,104 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+)
+
+func TestReviewBook(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		readers := newReaders(t, lib, "ann", "bob", "mod")
+		ann, bob, moderator := readers[0], readers[1], readers[2]
+		dune, err := lib.Storage.Book.Create().SetTitle("Dune").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first, err := lib.ReviewBook(ctx, ann.ID, dune.ID, 3, "fine")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a second review replaces the first one
+		edited, err := lib.ReviewBook(ctx, ann.ID, dune.ID, 5, "great")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if edited.ID != first.ID || edited.Rating != 5 || edited.Text != "great" {
+			t.Errorf("got %+v, want the first review edited", edited)
+		}
+		if _, err := lib.ReviewBook(ctx, bob.ID, dune.ID, 2, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		invalid := []struct {
+			name   string
+			bookID int64
+			rating int
+			text   string
+		}{
+			{"no rating", dune.ID, 0, "text"},
+			{"rating over 5", dune.ID, 6, "text"},
+			{"long text", dune.ID, 4, strings.Repeat("a", 10001)},
+		}
+		for _, tc := range invalid {
+			if _, err := lib.ReviewBook(ctx, ann.ID, tc.bookID, tc.rating, tc.text); !ent.IsValidationError(err) {
+				t.Errorf("%s: got %v, want a validation error", tc.name, err)
+			}
+		}
+		if _, err := lib.ReviewBook(ctx, ann.ID, dune.ID+1, 4, ""); !errors.Is(err, ErrBookNotFound) {
+			t.Errorf("unknown book: got %v, want %v", err, ErrBookNotFound)
+		}
+
+		expectRating := func(want Rating) {
+			t.Helper()
+			ratings, err := lib.Ratings(ctx, dune.ID, dune.ID+1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ratings[dune.ID]; got != want || len(ratings) > 1 {
+				t.Errorf("got %v, want %v", ratings, want)
+			}
+		}
+		expectRating(Rating{Average: 3.5, Count: 2})
+
+		// hidden reviews don't count, moderation is not an edit
+		hidden, err := lib.HideReview(ctx, moderator.ID, edited.ID, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hidden.Hidden || hidden.HiddenAt == nil || hidden.UpdatedAt != edited.UpdatedAt {
+			t.Errorf("got %+v, want hidden without an edit", hidden)
+		}
+		if by, err := hidden.QueryHiddenBy().OnlyID(ctx); err != nil || by != moderator.ID {
+			t.Errorf("hidden by %d, %v, want %d", by, err, moderator.ID)
+		}
+		expectRating(Rating{Average: 2, Count: 1})
+
+		// the author can't reveal the review by editing it
+		if edited, err = lib.ReviewBook(ctx, ann.ID, dune.ID, 4, "still great"); err != nil || !edited.Hidden {
+			t.Errorf("edited hidden review: got %+v, %v", edited, err)
+		}
+
+		shown, err := lib.HideReview(ctx, moderator.ID, edited.ID, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shown.Hidden || shown.HiddenAt != nil {
+			t.Errorf("got %+v, want shown", shown)
+		}
+		expectRating(Rating{Average: 3, Count: 2})
+		if _, err := lib.HideReview(ctx, moderator.ID, edited.ID+100, true); !errors.Is(err, ErrReviewNotFound) {
+			t.Errorf("unknown review: got %v, want %v", err, ErrReviewNotFound)
+		}
+
+		if err := lib.DeleteReview(ctx, bob.ID, dune.ID); err != nil {
+			t.Fatal(err)
+		}
+		expectRating(Rating{Average: 4, Count: 1})
+	})
+}
@@ -0,0 +1,235 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/author"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("no such revision")
+	ErrAuthorNotFound   = errors.New("no such author")
+)
+
+// BookMetadata is the metadata of a book as revisions store it.
+type BookMetadata struct {
+	Title     string  `json:"title"`
+	WrittenAt int64   `json:"written_at"`
+	CoverID   string  `json:"cover_id"`
+	FileID    string  `json:"file_id"`
+	Pages     int64   `json:"pages"`
+	Authors   []int64 `json:"authors"`
+}
+
+// AuthorMetadata is the metadata of an author as revisions store it.
+type AuthorMetadata struct {
+	Name string `json:"name"`
+	Bio  string `json:"bio"`
+}
+
+// UpdateBook replaces metadata of the book in a transaction with its audit
+// and revision records, then updates the search index.
+// Authors missing from the catalog are skipped.
+func (lib *Library) UpdateBook(ctx context.Context, id int64, meta *BookMetadata) (*ent.Book, error) {
+	var updated *ent.Book
+	errTx := lib.withTx(ctx, func(client *ent.Client) error {
+		var err error
+		updated, err = updateBook(ctx, client, id, meta)
+		return err
+	})
+	if errTx != nil {
+		return nil, errTx
+	}
+
+	updated = updated.Unwrap()
+	if err := lib.IndexBook(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func updateBook(ctx context.Context, client *ent.Client, id int64, meta *BookMetadata) (*ent.Book, error) {
+	current, errCurrent := client.Book.Query().
+		Where(book.ID(id)).
+		QueryAuthors().
+		IDs(ctx)
+	if errCurrent != nil {
+		return nil, fmt.Errorf("db: %w", errCurrent)
+	}
+	authors, errAuthors := client.Author.Query().
+		Where(author.IDIn(meta.Authors...)).
+		IDs(ctx)
+	if errAuthors != nil {
+		return nil, fmt.Errorf("db: %w", errAuthors)
+	}
+
+	update := client.Book.UpdateOneID(id).
+		SetTitle(meta.Title).
+		SetWrittenAt(meta.WrittenAt)
+	for _, authorID := range authors {
+		if !slices.Contains(current, authorID) {
+			update.AddAuthorIDs(authorID)
+		}
+	}
+	for _, authorID := range current {
+		if !slices.Contains(authors, authorID) {
+			update.RemoveAuthorIDs(authorID)
+		}
+	}
+	if meta.CoverID == "" {
+		update.ClearCoverID()
+	} else {
+		update.SetCoverID(meta.CoverID)
+	}
+	if meta.FileID == "" {
+		update.ClearFileID()
+	} else {
+		update.SetFileID(meta.FileID)
+	}
+	// pages are counted in the file, so they change with it
+	if meta.Pages == 0 {
+		update.ClearPages()
+	} else {
+		update.SetPages(meta.Pages)
+	}
+
+	updated, errUpdate := update.Save(ctx)
+	switch {
+	case ent.IsNotFound(errUpdate):
+		return nil, ErrBookNotFound
+	case ent.IsValidationError(errUpdate):
+		return nil, errUpdate
+	case errUpdate != nil:
+		return nil, fmt.Errorf("db: %w", errUpdate)
+	}
+	return updated, nil
+}
+
+// UpdateAuthor replaces metadata of the author in a transaction
+// like UpdateBook and reindexes books of the author.
+func (lib *Library) UpdateAuthor(ctx context.Context, id int64, meta *AuthorMetadata) (*ent.Author, error) {
+	var updated *ent.Author
+	errTx := lib.withTx(ctx, func(client *ent.Client) error {
+		var err error
+		updated, err = updateAuthor(ctx, client, id, meta)
+		return err
+	})
+	if errTx != nil {
+		return nil, errTx
+	}
+
+	updated = updated.Unwrap()
+	if err := lib.indexAuthorBooks(ctx, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func updateAuthor(ctx context.Context, client *ent.Client, id int64, meta *AuthorMetadata) (*ent.Author, error) {
+	updated, errUpdate := client.Author.UpdateOneID(id).
+		SetName(meta.Name).
+		SetBio(meta.Bio).
+		Save(ctx)
+	switch {
+	case ent.IsNotFound(errUpdate):
+		return nil, ErrAuthorNotFound
+	case errUpdate != nil:
+		return nil, fmt.Errorf("db: %w", errUpdate)
+	}
+	return updated, nil
+}
+
+func (lib *Library) indexAuthorBooks(ctx context.Context, a *ent.Author) error {
+	books, errBooks := a.QueryBooks().All(ctx)
+	if errBooks != nil {
+		return fmt.Errorf("db: %w", errBooks)
+	}
+	for _, b := range books {
+		if err := lib.IndexBook(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withTx runs fn in a transaction, which is rolled back if fn fails.
+// Hooks recording audit and revisions write within the transaction too.
+func (lib *Library) withTx(ctx context.Context, fn func(client *ent.Client) error) error {
+	tx, errTx := lib.Storage.Tx(ctx)
+	if errTx != nil {
+		return fmt.Errorf("db: %w", errTx)
+	}
+	if err := fn(tx.Client()); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	return nil
+}
+
+// RestoreRevision applies metadata of the revision to its book or author
+// the same way as an edit, so the restore is a new revision itself.
+func (lib *Library) RestoreRevision(ctx context.Context, id int64) (*ent.Revision, error) {
+	var (
+		found         *ent.Revision
+		updatedBook   *ent.Book
+		updatedAuthor *ent.Author
+	)
+	errTx := lib.withTx(ctx, func(client *ent.Client) error {
+		var errQuery error
+		found, errQuery = client.Revision.Get(ctx, id)
+		switch {
+		case ent.IsNotFound(errQuery):
+			return ErrRevisionNotFound
+		case errQuery != nil:
+			return fmt.Errorf("db: %w", errQuery)
+		}
+
+		data, errData := json.Marshal(found.Data)
+		if errData != nil {
+			return fmt.Errorf("revision %d: %w", id, errData)
+		}
+
+		var errUpdate error
+		switch found.Entity {
+		case ent.TypeBook:
+			var meta BookMetadata
+			if err := json.Unmarshal(data, &meta); err != nil {
+				return fmt.Errorf("revision %d: %w", id, err)
+			}
+			updatedBook, errUpdate = updateBook(ctx, client, found.EntityID, &meta)
+		case ent.TypeAuthor:
+			var meta AuthorMetadata
+			if err := json.Unmarshal(data, &meta); err != nil {
+				return fmt.Errorf("revision %d: %w", id, err)
+			}
+			updatedAuthor, errUpdate = updateAuthor(ctx, client, found.EntityID, &meta)
+		default:
+			errUpdate = fmt.Errorf("revision %d: can't restore %s", id, found.Entity)
+		}
+		return errUpdate
+	})
+	if errTx != nil {
+		return nil, errTx
+	}
+
+	// the search index is not a part of the transaction
+	var errIndex error
+	if updatedBook != nil {
+		errIndex = lib.IndexBook(ctx, updatedBook.Unwrap())
+	} else {
+		errIndex = lib.indexAuthorBooks(ctx, updatedAuthor.Unwrap())
+	}
+	if errIndex != nil {
+		return nil, errIndex
+	}
+	return found.Unwrap(), nil
+}
@@ -0,0 +1,224 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+)
+
+func revisionCount(t *testing.T, lib *Library, entity string, id int64) int {
+	t.Helper()
+	n, err := lib.Storage.Revision.Query().
+		Where(revision.Entity(entity), revision.EntityID(id)).
+		Count(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestUpdateBook(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		authors, err := lib.EnsureAuthors(ctx, []string{"Ann", "Bob"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, err := lib.Storage.Book.Create().SetTitle("Draft").AddAuthorIDs(authors[0]).Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := lib.UpdateBook(ctx, created.ID, &BookMetadata{Title: "Final", WrittenAt: 100, Authors: []int64{authors[1], -1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Title != "Final" || updated.WrittenAt != 100 {
+			t.Errorf("got %q written at %d", updated.Title, updated.WrittenAt)
+		}
+		// the returned book is usable after the transaction
+		got, err := updated.QueryAuthors().IDs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, authors[1:]) {
+			t.Errorf("authors: got %v, want %v", got, authors[1:])
+		}
+		if ids, _ := lib.Search.Search(ctx, "final bob", 10); !slices.Equal(ids, []int64{created.ID}) {
+			t.Errorf("search: got %v, want the book", ids)
+		}
+		if n := revisionCount(t, lib, ent.TypeBook, created.ID); n != 2 {
+			t.Errorf("got %d revisions, want 2", n)
+		}
+
+		if _, err := lib.UpdateBook(ctx, created.ID+1, &BookMetadata{Title: "Missing"}); !errors.Is(err, ErrBookNotFound) {
+			t.Errorf("missing book: got %v, want %v", err, ErrBookNotFound)
+		}
+	})
+}
+
+var errStoreRevision = errors.New("revisions are read-only")
+
+func TestUpdateBookRollback(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		authors, err := lib.EnsureAuthors(ctx, []string{"Ann", "Bob"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, err := lib.Storage.Book.Create().SetTitle("Draft").AddAuthorIDs(authors[0]).Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the revision is stored after the book is changed, its failure undoes the change
+		lib.Storage.Revision.Use(func(ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) {
+				return nil, errStoreRevision
+			})
+		})
+		_, errUpdate := lib.UpdateBook(ctx, created.ID, &BookMetadata{Title: "Final", Authors: authors[1:]})
+		if !errors.Is(errUpdate, errStoreRevision) {
+			t.Fatalf("got %v, want %v", errUpdate, errStoreRevision)
+		}
+
+		found, err := lib.Storage.Book.Query().WithAuthors().Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Title != "Draft" || len(found.Edges.Authors) != 1 || found.Edges.Authors[0].ID != authors[0] {
+			t.Errorf("the failed update is kept: %q by %v", found.Title, found.Edges.Authors)
+		}
+	})
+}
+
+func TestRestoreRevision(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		authors, err := lib.EnsureAuthors(ctx, []string{"Ann"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, err := lib.Storage.Book.Create().SetTitle("First").AddAuthorIDs(authors...).Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, err := lib.Storage.Revision.Query().Where(revision.Entity(ent.TypeBook), revision.EntityID(created.ID)).Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.UpdateBook(ctx, created.ID, &BookMetadata{Title: "Second"}); err != nil {
+			t.Fatal(err)
+		}
+
+		restored, err := lib.RestoreRevision(ctx, first.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.ID != first.ID {
+			t.Errorf("got revision %d, want %d", restored.ID, first.ID)
+		}
+		found, err := lib.Storage.Book.Query().WithAuthors().Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Title != "First" || len(found.Edges.Authors) != 1 {
+			t.Errorf("got %q by %v", found.Title, found.Edges.Authors)
+		}
+		// the restore is a revision itself
+		if n := revisionCount(t, lib, ent.TypeBook, created.ID); n != 3 {
+			t.Errorf("got %d revisions, want 3", n)
+		}
+		if ids, _ := lib.Search.Search(ctx, "first", 10); !slices.Equal(ids, []int64{created.ID}) {
+			t.Errorf("search: got %v, want the book", ids)
+		}
+
+		if _, err := lib.RestoreRevision(ctx, first.ID+100); !errors.Is(err, ErrRevisionNotFound) {
+			t.Errorf("missing revision: got %v, want %v", err, ErrRevisionNotFound)
+		}
+	})
+}
+
+func TestRestoreRevisionFile(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		created, err := lib.Storage.Book.Create().SetTitle("Atlas").SetFileID("first.pdf").SetPages(10).Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, err := lib.Storage.Revision.Query().Where(revision.Entity(ent.TypeBook), revision.EntityID(created.ID)).Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the file is replaced by one of another length
+		if err := lib.Storage.Book.UpdateOne(created).SetFileID("second.pdf").SetPages(20).Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+		second, err := lib.Storage.Revision.Query().Where(revision.Entity(ent.TypeBook), revision.EntityID(created.ID)).Order(ent.Desc(revision.FieldID)).First(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name     string
+			revision int64
+			fileID   string
+			pages    int64
+		}{
+			{name: "first", revision: first.ID, fileID: "first.pdf", pages: 10},
+			{name: "second", revision: second.ID, fileID: "second.pdf", pages: 20},
+		}
+		for _, tc := range tests {
+			if _, err := lib.RestoreRevision(ctx, tc.revision); err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			found, err := lib.Storage.Book.Get(ctx, created.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if found.FileID != tc.fileID || found.Pages != tc.pages {
+				t.Errorf("%s: got %d pages of %q, want %d of %q", tc.name, found.Pages, found.FileID, tc.pages, tc.fileID)
+			}
+		}
+	})
+}
+
+func TestRestoreAuthorRevision(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		created, err := lib.Storage.Author.Create().SetName("Anne").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.Storage.Book.Create().SetTitle("Poems").AddAuthors(created).Save(ctx); err != nil {
+			t.Fatal(err)
+		}
+		first, err := lib.Storage.Revision.Query().Where(revision.Entity(ent.TypeAuthor)).Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.UpdateAuthor(ctx, created.ID, &AuthorMetadata{Name: "Ann"}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := lib.RestoreRevision(ctx, first.ID); err != nil {
+			t.Fatal(err)
+		}
+		found, err := lib.Storage.Author.Get(ctx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Name != "Anne" {
+			t.Errorf("got %q, want %q", found.Name, "Anne")
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+var (
+	ErrShelfNotFound = errors.New("no such shelf")
+	ErrShelfExists   = errors.New("you already have a shelf with this name")
+	ErrStatusShelf   = errors.New("reading status shelves can't be deleted")
+)
+
+// StatusShelves are kinds of shelves holding the reading status,
+// a book is on one of them at most.
+var StatusShelves = []shelf.Kind{shelf.KindWantToRead, shelf.KindReading, shelf.KindFinished}
+
+// StatusNames are names of status shelves.
+var StatusNames = map[shelf.Kind]string{
+	shelf.KindWantToRead: "Want to read",
+	shelf.KindReading:    "Reading",
+	shelf.KindFinished:   "Finished",
+}
+
+// SetReadingStatus moves the book to the status shelf of the user,
+// an empty status takes the book off all status shelves.
+func (lib *Library) SetReadingStatus(ctx context.Context, userID, bookID int64, status shelf.Kind) error {
+	if _, ok := StatusNames[status]; !ok && status != "" {
+		return fmt.Errorf("%w: %s", ErrShelfNotFound, status)
+	}
+
+	others := StatusShelves
+	if status != "" {
+		target, errShelf := lib.statusShelf(ctx, userID, status)
+		if errShelf != nil {
+			return errShelf
+		}
+		if err := lib.shelve(ctx, target.ID, bookID); err != nil {
+			return err
+		}
+		others = nil
+		for _, kind := range StatusShelves {
+			if kind != status {
+				others = append(others, kind)
+			}
+		}
+	}
+
+	_, errDelete := lib.Storage.ShelfEntry.Delete().
+		Where(
+			shelfentry.HasBookWith(book.ID(bookID)),
+			shelfentry.HasShelfWith(shelf.HasUserWith(user.ID(userID)), shelf.KindIn(others...)),
+		).
+		Exec(ctx)
+	if errDelete != nil {
+		return fmt.Errorf("db: %w", errDelete)
+	}
+	return nil
+}
+
+// statusShelf returns the status shelf of the user, creating it on first use.
+func (lib *Library) statusShelf(ctx context.Context, userID int64, kind shelf.Kind) (*ent.Shelf, error) {
+	query := lib.Storage.Shelf.Query().
+		Where(shelf.HasUserWith(user.ID(userID)), shelf.KindEQ(kind))
+	found, errQuery := query.Only(ctx)
+	if errQuery == nil {
+		return found, nil
+	}
+	if !ent.IsNotFound(errQuery) {
+		return nil, fmt.Errorf("db: %w", errQuery)
+	}
+
+	created, errCreate := lib.Storage.Shelf.Create().
+		SetName(StatusNames[kind]).
+		SetKind(kind).
+		SetUserID(userID).
+		Save(ctx)
+	if ent.IsConstraintError(errCreate) {
+		// created by a concurrent request
+		created, errCreate = query.Only(ctx)
+	}
+	if errCreate != nil {
+		return nil, fmt.Errorf("db: %w", errCreate)
+	}
+	return created, nil
+}
+
+// shelve puts the book on the shelf, a book already on it stays.
+func (lib *Library) shelve(ctx context.Context, shelfID, bookID int64) error {
+	errCreate := lib.Storage.ShelfEntry.Create().
+		SetShelfID(shelfID).
+		SetBookID(bookID).
+		Exec(ctx)
+	if errCreate != nil && !ent.IsConstraintError(errCreate) {
+		return fmt.Errorf("db: %w", errCreate)
+	}
+	return nil
+}
+
+// AddToShelf puts the book on the shelf of the user, putting it on a status
+// shelf is the same as setting the reading status.
+func (lib *Library) AddToShelf(ctx context.Context, userID, shelfID, bookID int64) error {
+	found, errShelf := lib.userShelf(ctx, userID, shelfID)
+	if errShelf != nil {
+		return errShelf
+	}
+	if found.Kind != shelf.KindCustom {
+		return lib.SetReadingStatus(ctx, userID, bookID, found.Kind)
+	}
+	return lib.shelve(ctx, found.ID, bookID)
+}
+
+func (lib *Library) RemoveFromShelf(ctx context.Context, userID, shelfID, bookID int64) error {
+	found, errShelf := lib.userShelf(ctx, userID, shelfID)
+	if errShelf != nil {
+		return errShelf
+	}
+
+	_, errDelete := lib.Storage.ShelfEntry.Delete().
+		Where(shelfentry.HasShelfWith(shelf.ID(found.ID)), shelfentry.HasBookWith(book.ID(bookID))).
+		Exec(ctx)
+	if errDelete != nil {
+		return fmt.Errorf("db: %w", errDelete)
+	}
+	return nil
+}
+
+// CreateShelf adds a custom shelf.
+func (lib *Library) CreateShelf(ctx context.Context, userID int64, name string) (*ent.Shelf, error) {
+	for _, statusName := range StatusNames {
+		if strings.EqualFold(name, statusName) {
+			return nil, ErrShelfExists
+		}
+	}
+
+	created, errCreate := lib.Storage.Shelf.Create().
+		SetName(name).
+		SetUserID(userID).
+		Save(ctx)
+	switch {
+	case ent.IsConstraintError(errCreate):
+		return nil, ErrShelfExists
+	case ent.IsValidationError(errCreate):
+		return nil, errCreate
+	case errCreate != nil:
+		return nil, fmt.Errorf("db: %w", errCreate)
+	}
+	return created, nil
+}
+
+// DeleteShelf removes the custom shelf, books stay in the catalog.
+func (lib *Library) DeleteShelf(ctx context.Context, userID, shelfID int64) error {
+	found, errShelf := lib.userShelf(ctx, userID, shelfID)
+	if errShelf != nil {
+		return errShelf
+	}
+	if found.Kind != shelf.KindCustom {
+		return ErrStatusShelf
+	}
+
+	if err := lib.Storage.Shelf.DeleteOne(found).Exec(ctx); err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	return nil
+}
+
+func (lib *Library) userShelf(ctx context.Context, userID, shelfID int64) (*ent.Shelf, error) {
+	found, err := lib.Storage.Shelf.Query().
+		Where(shelf.ID(shelfID), shelf.HasUserWith(user.ID(userID))).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, ErrShelfNotFound
+	case err != nil:
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	return found, nil
+}
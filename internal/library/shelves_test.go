@@ -0,0 +1,179 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// shelfContents returns titles of books on shelves of the user
+// by shelf names.
+func shelfContents(t *testing.T, lib *Library, owner *ent.User) map[string][]string {
+	t.Helper()
+	entries, err := lib.Storage.ShelfEntry.Query().
+		Where(shelfentry.HasShelfWith(shelf.HasUserWith(user.ID(owner.ID)))).
+		WithShelf().
+		WithBook().
+		All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents := map[string][]string{}
+	for _, entry := range entries {
+		name := entry.Edges.Shelf.Name
+		contents[name] = append(contents[name], entry.Edges.Book.Title)
+	}
+	for _, titles := range contents {
+		slices.Sort(titles)
+	}
+	return contents
+}
+
+func equalContents(a, b map[string][]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, titles := range a {
+		if !slices.Equal(titles, b[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSetReadingStatus(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		readers := newReaders(t, lib, "ann", "bob")
+		ann, bob := readers[0], readers[1]
+		dune, err := lib.Storage.Book.Create().SetTitle("Dune").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		solaris, err := lib.Storage.Book.Create().SetTitle("Solaris").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		steps := []struct {
+			book   *ent.Book
+			status shelf.Kind
+			want   map[string][]string
+		}{
+			{dune, shelf.KindWantToRead, map[string][]string{"Want to read": {"Dune"}}},
+			{solaris, shelf.KindWantToRead, map[string][]string{"Want to read": {"Dune", "Solaris"}}},
+			// a book has one status at most
+			{dune, shelf.KindReading, map[string][]string{"Want to read": {"Solaris"}, "Reading": {"Dune"}}},
+			{dune, shelf.KindReading, map[string][]string{"Want to read": {"Solaris"}, "Reading": {"Dune"}}},
+			{dune, shelf.KindFinished, map[string][]string{"Want to read": {"Solaris"}, "Finished": {"Dune"}}},
+			{solaris, "", map[string][]string{"Finished": {"Dune"}}},
+		}
+		for _, step := range steps {
+			if err := lib.SetReadingStatus(ctx, ann.ID, step.book.ID, step.status); err != nil {
+				t.Fatal(err)
+			}
+			if got := shelfContents(t, lib, ann); !equalContents(got, step.want) {
+				t.Errorf("%s is %q: got %v, want %v", step.book.Title, step.status, got, step.want)
+			}
+		}
+
+		// status shelves are created once per user
+		if n, _ := lib.Storage.Shelf.Query().Where(shelf.HasUserWith(user.ID(ann.ID))).Count(ctx); n != 3 {
+			t.Errorf("got %d shelves, want 3", n)
+		}
+		if got := shelfContents(t, lib, bob); len(got) != 0 {
+			t.Errorf("bob: got %v, want empty shelves", got)
+		}
+		if err := lib.SetReadingStatus(ctx, ann.ID, dune.ID, shelf.KindCustom); !errors.Is(err, ErrShelfNotFound) {
+			t.Errorf("custom status: got %v, want %v", err, ErrShelfNotFound)
+		}
+	})
+}
+
+func TestCustomShelves(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		readers := newReaders(t, lib, "ann", "bob")
+		ann, bob := readers[0], readers[1]
+		dune, err := lib.Storage.Book.Create().SetTitle("Dune").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		favourites, err := lib.CreateShelf(ctx, ann.ID, "Favourites")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.CreateShelf(ctx, bob.ID, "Favourites"); err != nil {
+			t.Errorf("same name for another user: %v", err)
+		}
+		for _, name := range []string{"Favourites", "reading", "Want To Read"} {
+			if _, err := lib.CreateShelf(ctx, ann.ID, name); !errors.Is(err, ErrShelfExists) {
+				t.Errorf("%q: got %v, want %v", name, err, ErrShelfExists)
+			}
+		}
+		if _, err := lib.CreateShelf(ctx, ann.ID, ""); err == nil {
+			t.Error("empty name: got no error")
+		}
+
+		// a book is put on a shelf once
+		for i := 0; i < 2; i++ {
+			if err := lib.AddToShelf(ctx, ann.ID, favourites.ID, dune.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := lib.AddToShelf(ctx, bob.ID, favourites.ID, dune.ID); !errors.Is(err, ErrShelfNotFound) {
+			t.Errorf("shelf of another user: got %v, want %v", err, ErrShelfNotFound)
+		}
+		// custom shelves don't affect the reading status
+		if err := lib.SetReadingStatus(ctx, ann.ID, dune.ID, shelf.KindReading); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string][]string{"Favourites": {"Dune"}, "Reading": {"Dune"}}
+		if got := shelfContents(t, lib, ann); !equalContents(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+
+		// adding to a status shelf sets the status
+		finished, err := lib.statusShelf(ctx, ann.ID, shelf.KindFinished)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.AddToShelf(ctx, ann.ID, finished.ID, dune.ID); err != nil {
+			t.Fatal(err)
+		}
+		want = map[string][]string{"Favourites": {"Dune"}, "Finished": {"Dune"}}
+		if got := shelfContents(t, lib, ann); !equalContents(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+
+		if err := lib.RemoveFromShelf(ctx, ann.ID, favourites.ID, dune.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteShelf(ctx, ann.ID, finished.ID); !errors.Is(err, ErrStatusShelf) {
+			t.Errorf("delete a status shelf: got %v, want %v", err, ErrStatusShelf)
+		}
+		if err := lib.DeleteShelf(ctx, bob.ID, favourites.ID); !errors.Is(err, ErrShelfNotFound) {
+			t.Errorf("delete a shelf of another user: got %v, want %v", err, ErrShelfNotFound)
+		}
+		if err := lib.AddToShelf(ctx, ann.ID, favourites.ID, dune.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteShelf(ctx, ann.ID, favourites.ID); err != nil {
+			t.Fatal(err)
+		}
+		want = map[string][]string{"Finished": {"Dune"}}
+		if got := shelfContents(t, lib, ann); !equalContents(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if _, err := lib.Storage.Book.Get(ctx, dune.ID); err != nil {
+			t.Errorf("the book is removed with the shelf: %v", err)
+		}
+	})
+}
@@ -0,0 +1,234 @@
+package library
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/document"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+var ErrSyncUnauthorized = errors.New("wrong login or sync password")
+
+// PartialMD5 returns the hash KOReader identifies documents by: MD5 of 1 KB
+// samples at offsets 0, 1 KB, 4 KB, 16 KB and so on up to 1 GB, the samples
+// end at the end of the file. The file is rewound afterwards.
+func PartialMD5(file io.ReadSeeker) (string, error) {
+	const step, size = 1024, 1024
+
+	hash := md5.New()
+	sample := make([]byte, size)
+	for i := -1; i <= 10; i++ {
+		offset := int64(0)
+		if i >= 0 {
+			offset = step << (2 * i)
+		}
+		if _, err := file.Seek(offset, io.SeekStart); err != nil {
+			return "", err
+		}
+		n, errRead := io.ReadFull(file, sample)
+		hash.Write(sample[:n])
+		if errors.Is(errRead, io.EOF) || errors.Is(errRead, io.ErrUnexpectedEOF) {
+			break
+		}
+		if errRead != nil {
+			return "", errRead
+		}
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// RememberDocument links the file with the book, so progress of reading apps
+// is shown on the book page. The file is rewound afterwards.
+func (lib *Library) RememberDocument(ctx context.Context, bookID int64, file io.ReadSeeker) error {
+	hash, errHash := PartialMD5(file)
+	if errHash != nil {
+		return fmt.Errorf("files: %w", errHash)
+	}
+	return lib.LinkDocument(ctx, bookID, hash)
+}
+
+// LinkDocument links the document with the PartialMD5 hash with the book.
+func (lib *Library) LinkDocument(ctx context.Context, bookID int64, hash string) error {
+	exists, errExists := lib.Storage.Document.Query().Where(document.Hash(hash)).Exist(ctx)
+	if errExists != nil {
+		return fmt.Errorf("db: %w", errExists)
+	}
+	if exists {
+		return nil
+	}
+
+	errCreate := lib.Storage.Document.Create().
+		SetHash(hash).
+		SetBookID(bookID).
+		Exec(ctx)
+	// a constraint error means the document is known already
+	if errCreate != nil && !ent.IsConstraintError(errCreate) {
+		return fmt.Errorf("db: %w", errCreate)
+	}
+	return nil
+}
+
+// rememberFile links the stored file with the book.
+func (lib *Library) rememberFile(ctx context.Context, bookID int64, fileID string) error {
+	if fileID == "" {
+		return nil
+	}
+	file, errOpen := lib.Files.Open(fileID)
+	if errOpen != nil {
+		return fmt.Errorf("files: %w", errOpen)
+	}
+	defer func() { _ = file.Close() }()
+	return lib.RememberDocument(ctx, bookID, file)
+}
+
+// SetSyncPassword sets the password reading apps use to sync progress,
+// an empty one turns sync off for the user.
+func (lib *Library) SetSyncPassword(ctx context.Context, userID int64, password string) error {
+	update := lib.Storage.User.UpdateOneID(userID)
+	if password == "" {
+		update.ClearSyncKeyHash()
+	} else {
+		update.SetSyncKeyHash(lib.syncKeyHash(userID, SyncKey(password)))
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+	return nil
+}
+
+// SyncKey returns the key KOReader sends instead of the password.
+func SyncKey(password string) string {
+	sum := md5.Sum([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+// syncKeyHash returns the stored hash of the sync key. Every request of
+// a reading app is checked against it, so it's a MAC keyed by the server
+// secret rather than a slow password hash: the key is a hash of
+// the password already and can't be guessed without the secret.
+func (lib *Library) syncKeyHash(userID int64, key string) string {
+	return lib.Signer.MAC("kosync:" + strconv.FormatInt(userID, 10) + ":" + key)
+}
+
+// SyncUser returns the user with the login if the key matches the sync password.
+func (lib *Library) SyncUser(ctx context.Context, login, key string) (*ent.User, error) {
+	found, err := lib.Storage.User.Query().
+		Where(user.Login(login)).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, ErrSyncUnauthorized
+	case err != nil:
+		return nil, fmt.Errorf("db: %w", err)
+	case found.SyncKeyHash == "":
+		return nil, ErrSyncUnauthorized
+	}
+
+	hash := lib.syncKeyHash(found.ID, key)
+	if !hmac.Equal([]byte(hash), []byte(found.SyncKeyHash)) {
+		return nil, ErrSyncUnauthorized
+	}
+	return found, nil
+}
+
+// Progress is the position in the document reported by a reading app.
+type Progress struct {
+	Document   string
+	Progress   string
+	Percentage float64
+	Device     string
+	DeviceID   string
+}
+
+// SaveProgress replaces the progress of the user in the document.
+func (lib *Library) SaveProgress(ctx context.Context, userID int64, progress *Progress) (*ent.ReadingProgress, error) {
+	query := lib.Storage.ReadingProgress.Query().
+		Where(readingprogress.Document(progress.Document), readingprogress.HasUserWith(user.ID(userID)))
+
+	found, errQuery := query.Only(ctx)
+	if ent.IsNotFound(errQuery) {
+		created, errCreate := lib.Storage.ReadingProgress.Create().
+			SetDocument(progress.Document).
+			SetProgress(progress.Progress).
+			SetPercentage(progress.Percentage).
+			SetDevice(progress.Device).
+			SetDeviceID(progress.DeviceID).
+			SetUserID(userID).
+			Save(ctx)
+		if !ent.IsConstraintError(errCreate) {
+			if errCreate != nil {
+				return nil, fmt.Errorf("db: %w", errCreate)
+			}
+			return created, nil
+		}
+		// created by a concurrent request
+		found, errQuery = query.Only(ctx)
+	}
+	if errQuery != nil {
+		return nil, fmt.Errorf("db: %w", errQuery)
+	}
+
+	updated, errUpdate := found.Update().
+		SetProgress(progress.Progress).
+		SetPercentage(progress.Percentage).
+		SetDevice(progress.Device).
+		SetDeviceID(progress.DeviceID).
+		Save(ctx)
+	if errUpdate != nil {
+		return nil, fmt.Errorf("db: %w", errUpdate)
+	}
+	return updated, nil
+}
+
+// DocumentProgress returns the progress of the user in the document, nil if none.
+func (lib *Library) DocumentProgress(ctx context.Context, userID int64, hash string) (*ent.ReadingProgress, error) {
+	found, err := lib.Storage.ReadingProgress.Query().
+		Where(readingprogress.Document(hash), readingprogress.HasUserWith(user.ID(userID))).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	return found, nil
+}
+
+// BookProgress returns the latest progress of the user in any document
+// of the book, nil if the user hasn't synced one.
+func (lib *Library) BookProgress(ctx context.Context, userID int64, found *ent.Book) (*ent.ReadingProgress, error) {
+	hashes, errHashes := lib.Storage.Document.Query().
+		Where(document.HasBookWith(book.ID(found.ID))).
+		Select(document.FieldHash).
+		Strings(ctx)
+	if errHashes != nil {
+		return nil, fmt.Errorf("db: %w", errHashes)
+	}
+
+	latest, err := lib.Storage.ReadingProgress.Query().
+		Where(readingprogress.DocumentIn(hashes...), readingprogress.HasUserWith(user.ID(userID))).
+		Order(ent.Desc(readingprogress.FieldUpdatedAt), ent.Desc(readingprogress.FieldID)).
+		First(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	return latest, nil
+}
@@ -0,0 +1,200 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/document"
+)
+
+// sampleFile returns n bytes which differ between KOReader samples.
+func sampleFile(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i*7 + i/251)
+	}
+	return data
+}
+
+func TestPartialMD5(t *testing.T) {
+	// hashes are computed by util.partialMD5 of KOReader
+	tests := []struct {
+		size int
+		want string
+	}{
+		{0, "d41d8cd98f00b204e9800998ecf8427e"},
+		// a part of the first sample
+		{100, "109febc79b8e9e79d686d0773cf44fa0"},
+		// samples at 0, 1 KB, 4 KB and 16 KB
+		{20000, "42c76279b95ed6f55f6f2e0e239a176d"},
+		// up to the partial sample at 1 MB
+		{1<<20 + 7, "6c7b299ed945926df8070d6b73625ecc"},
+	}
+	for _, tc := range tests {
+		file := bytes.NewReader(sampleFile(tc.size))
+		if _, err := file.Seek(10, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := PartialMD5(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("%d bytes: got %s, want %s", tc.size, got, tc.want)
+		}
+		if pos, _ := file.Seek(0, io.SeekCurrent); pos != 0 {
+			t.Errorf("%d bytes: file is at %d, want rewound", tc.size, pos)
+		}
+	}
+}
+
+func TestSyncUser(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		ann, err := lib.Storage.User.Create().SetLogin("ann").SetPasswordHash("-").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.Storage.User.Create().SetLogin("bob").SetPasswordHash("-").Save(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.SetSyncPassword(ctx, ann.ID, "sync password"); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := lib.SyncUser(ctx, "ann", SyncKey("sync password"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.ID != ann.ID {
+			t.Errorf("got user %d, want %d", found.ID, ann.ID)
+		}
+
+		tests := []struct {
+			name       string
+			login, key string
+		}{
+			{"wrong password", "ann", SyncKey("login password")},
+			{"password instead of the key", "ann", "sync password"},
+			{"sync is off", "bob", SyncKey("sync password")},
+			{"unknown login", "eve", SyncKey("sync password")},
+		}
+		for _, tc := range tests {
+			if _, err := lib.SyncUser(ctx, tc.login, tc.key); !errors.Is(err, ErrSyncUnauthorized) {
+				t.Errorf("%s: got %v, want %v", tc.name, err, ErrSyncUnauthorized)
+			}
+		}
+
+		// the hash is bound to the user, it can't be copied to another one
+		if err := lib.Storage.User.Update().SetSyncKeyHash(found.SyncKeyHash).Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.SyncUser(ctx, "bob", SyncKey("sync password")); !errors.Is(err, ErrSyncUnauthorized) {
+			t.Errorf("copied hash: got %v, want %v", err, ErrSyncUnauthorized)
+		}
+
+		if err := lib.SetSyncPassword(ctx, ann.ID, ""); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.SyncUser(ctx, "ann", SyncKey("sync password")); !errors.Is(err, ErrSyncUnauthorized) {
+			t.Errorf("turned off: got %v, want %v", err, ErrSyncUnauthorized)
+		}
+	})
+}
+
+func TestBookProgress(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		created, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader, err := lib.Storage.User.Create().SetLogin("ann").SetPasswordHash("-").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		document, err := lib.Storage.Document.Query().Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if found, err := lib.BookProgress(ctx, reader.ID, created); err != nil || found != nil {
+			t.Fatalf("before sync: got %v, %v, want none", found, err)
+		}
+
+		for _, percentage := range []float64{0.25, 0.5} {
+			_, errSave := lib.SaveProgress(ctx, reader.ID, &Progress{
+				Document:   document.Hash,
+				Progress:   "/body/DocFragment[2]",
+				Percentage: percentage,
+				Device:     "Kobo",
+			})
+			if errSave != nil {
+				t.Fatal(errSave)
+			}
+		}
+		// progress in documents unknown to the library is kept too
+		if _, err := lib.SaveProgress(ctx, reader.ID, &Progress{Document: "unknown", Progress: "1", Percentage: 1, Device: "Kobo"}); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := lib.BookProgress(ctx, reader.ID, created)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found == nil || found.Percentage != 0.5 {
+			t.Fatalf("got %+v, want the latest progress", found)
+		}
+		if n, _ := lib.Storage.ReadingProgress.Query().Count(ctx); n != 2 {
+			t.Errorf("got %d progress records, want one per document", n)
+		}
+
+		// book pages only read progress, files are linked when they are stored
+		unlinked, err := lib.Storage.Book.Create().SetTitle("Unlinked").SetFileID("missing.fb2").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found, err := lib.BookProgress(ctx, reader.ID, unlinked); err != nil || found != nil {
+			t.Errorf("book without documents: got %v, %v, want none", found, err)
+		}
+		if n, _ := lib.Storage.Document.Query().Count(ctx); n != 1 {
+			t.Errorf("got %d documents, want 1", n)
+		}
+	})
+}
+
+func TestSendToDeviceLinksDocument(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		lib.Mailer = &notify.Outbox{Storage: lib.Storage, Files: lib.Files}
+		created, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ann := newReaders(t, lib, "ann")[0]
+		kindle := newDevice(t, lib, ann, device.KindKindle)
+
+		// the converted file has its own hash, KOReader on the device sends it
+		if _, err := lib.SendToDevice(ctx, ann.ID, kindle.ID, created.ID); err != nil {
+			t.Fatal(err)
+		}
+		linked, err := lib.Storage.Document.Query().
+			Where(document.HasBookWith(book.ID(created.ID))).
+			Count(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if linked != 2 {
+			t.Errorf("got %d documents of the book, want the upload and the conversion", linked)
+		}
+	})
+}
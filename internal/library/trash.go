@@ -0,0 +1,208 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
+)
+
+// ErrBookOnLoan is returned on deleting a book with copies on loan,
+// they must be checked in first.
+var ErrBookOnLoan = errors.New("copies of the book are on loan")
+
+// checkNoLoans returns ErrBookOnLoan if copies of the book are on loan.
+func checkNoLoans(ctx context.Context, client *ent.Client, id int64) error {
+	onLoan, err := client.Loan.Query().
+		Where(
+			loan.ReturnedAtIsNil(),
+			loan.HasCopyWith(bookcopy.HasBookWith(book.ID(id))),
+		).
+		Exist(ctx)
+	switch {
+	case err != nil:
+		return fmt.Errorf("db: %w", err)
+	case onLoan:
+		return ErrBookOnLoan
+	}
+	return nil
+}
+
+// Trash returns deleted books with authors, recently deleted first.
+func (lib *Library) Trash(ctx context.Context) ([]*ent.Book, error) {
+	books, err := lib.Storage.Book.Query().
+		Where(book.DeletedAtNotNil()).
+		WithAuthors().
+		Order(ent.Desc(book.FieldDeletedAt), ent.Desc(book.FieldID)).
+		All(intercept.SkipSoftDelete(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+	return books, nil
+}
+
+// RestoreBook returns the deleted book to the catalog and the search index.
+func (lib *Library) RestoreBook(ctx context.Context, id int64) (*ent.Book, error) {
+	restored, err := lib.Storage.Book.UpdateOneID(id).
+		Where(book.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Save(intercept.SkipSoftDelete(ctx))
+	switch {
+	case ent.IsNotFound(err):
+		return nil, ErrBookNotFound
+	case err != nil:
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	if err := lib.IndexBook(ctx, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// PurgeBook removes the deleted book with its revisions for good.
+// Paper copies go with the book, loans of them are kept without the copy.
+// Stored files are kept, they may be shared with other books,
+// see CollectGarbage.
+func (lib *Library) PurgeBook(ctx context.Context, id int64) error {
+	return lib.withTx(ctx, func(client *ent.Client) error {
+		if err := checkNoLoans(ctx, client, id); err != nil {
+			return err
+		}
+
+		errDelete := client.Book.DeleteOneID(id).
+			Where(book.DeletedAtNotNil()).
+			Exec(intercept.SkipSoftDelete(ctx))
+		switch {
+		case ent.IsNotFound(errDelete):
+			return ErrBookNotFound
+		case errDelete != nil:
+			return fmt.Errorf("db: %w", errDelete)
+		}
+
+		_, errRevisions := client.Revision.Delete().
+			Where(revision.Entity(ent.TypeBook), revision.EntityID(id)).
+			Exec(ctx)
+		if errRevisions != nil {
+			return fmt.Errorf("db: %w", errRevisions)
+		}
+		return nil
+	})
+}
+
+// PurgeTrash purges books deleted before the time and returns their number.
+// Books with copies on loan are left in the trash.
+func (lib *Library) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	ids, errIDs := lib.Storage.Book.Query().
+		Where(book.DeletedAtLT(before.Unix())).
+		IDs(intercept.SkipSoftDelete(ctx))
+	if errIDs != nil {
+		return 0, fmt.Errorf("db: %w", errIDs)
+	}
+
+	purged := 0
+	for _, id := range ids {
+		err := lib.PurgeBook(ctx, id)
+		switch {
+		case errors.Is(err, ErrBookOnLoan):
+			continue
+		case err != nil:
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// CollectGarbage removes stored files modified before the time which are
+// referenced neither by books, including deleted ones, nor by revisions of
+// books nor by messages still to be sent. Recent files are kept, so uploads
+// in progress are not removed before their books are saved. Validation
+// results of removed files are dropped. It returns the number of removed files and their total size.
+func (lib *Library) CollectGarbage(ctx context.Context, before time.Time) (int, int64, error) {
+	used, errUsed := lib.usedFiles(ctx)
+	if errUsed != nil {
+		return 0, 0, errUsed
+	}
+
+	stored, errList := lib.Files.List()
+	if errList != nil {
+		return 0, 0, errList
+	}
+
+	removed, size := 0, int64(0)
+	for _, file := range stored {
+		if used[file.ID] || !file.ModTime.Before(before) {
+			continue
+		}
+		if err := lib.Files.Remove(file.ID); err != nil {
+			return removed, size, err
+		}
+		// a file stored again later is validated again
+		_, errValidation := lib.Storage.Validation.Delete().
+			Where(validation.FileID(file.ID)).
+			Exec(ctx)
+		if errValidation != nil {
+			return removed, size, fmt.Errorf("db: %w", errValidation)
+		}
+		removed++
+		size += file.Size
+	}
+	return removed, size, nil
+}
+
+// usedFiles returns ids of stored files which are referenced in the database.
+func (lib *Library) usedFiles(ctx context.Context) (map[string]bool, error) {
+	used := map[string]bool{}
+
+	books, errBooks := lib.Storage.Book.Query().
+		Select(book.FieldCoverID, book.FieldFileID).
+		All(intercept.SkipSoftDelete(ctx))
+	if errBooks != nil {
+		return nil, fmt.Errorf("db: %w", errBooks)
+	}
+	for _, item := range books {
+		used[item.CoverID] = true
+		used[item.FileID] = true
+	}
+
+	revisions, errRevisions := lib.Storage.Revision.Query().
+		Where(revision.Entity(ent.TypeBook)).
+		All(ctx)
+	if errRevisions != nil {
+		return nil, fmt.Errorf("db: %w", errRevisions)
+	}
+	for _, item := range revisions {
+		for _, field := range []string{book.FieldCoverID, book.FieldFileID} {
+			if id, ok := item.Data[field].(string); ok {
+				used[id] = true
+			}
+		}
+	}
+
+	pending := lib.Storage.OutboxMessage.Query().
+		Where(outboxmessage.SentAtIsNil(), outboxmessage.AttachmentNEQ(""))
+	if lib.MailAttempts > 0 {
+		// messages which used up their attempts are never sent
+		pending.Where(outboxmessage.AttemptsLT(lib.MailAttempts))
+	}
+	attachments, errAttachments := pending.
+		Select(outboxmessage.FieldAttachment).
+		Strings(ctx)
+	if errAttachments != nil {
+		return nil, fmt.Errorf("db: %w", errAttachments)
+	}
+	for _, id := range attachments {
+		used[id] = true
+	}
+	return used, nil
+}
@@ -0,0 +1,274 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+)
+
+// newLoan creates a book with a copy lent to a new user.
+func newLoan(t *testing.T, lib *Library, barcode string) (*ent.Book, *ent.Loan) {
+	t.Helper()
+	ctx := context.Background()
+	created, err := lib.Storage.Book.Create().SetTitle("Lent " + barcode).Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lib.Storage.BookCopy.Create().SetBarcode(barcode).SetBook(created).Save(ctx); err != nil {
+		t.Fatal(err)
+	}
+	borrower, err := lib.Storage.User.Create().SetLogin("reader-" + barcode).SetPasswordHash("-").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lent, err := lib.CheckOut(ctx, barcode, borrower.Login, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created, lent
+}
+
+func TestDeleteBookOnLoan(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		lent, _ := newLoan(t, lib, "001")
+
+		if err := lib.DeleteBook(ctx, lent.ID); !errors.Is(err, ErrBookOnLoan) {
+			t.Fatalf("got %v, want %v", err, ErrBookOnLoan)
+		}
+		if _, err := lib.Storage.Book.Get(ctx, lent.ID); err != nil {
+			t.Fatalf("the book is not in the catalog: %v", err)
+		}
+
+		if err := lib.CheckIn(ctx, "001"); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteBook(ctx, lent.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteBook(ctx, lent.ID); !errors.Is(err, ErrBookNotFound) {
+			t.Errorf("deleted twice: got %v, want %v", err, ErrBookNotFound)
+		}
+
+		// copies of deleted books are not lent
+		if _, err := lib.CheckOut(ctx, "001", "reader-001", time.Now().Add(time.Hour)); !errors.Is(err, ErrCopyNotFound) {
+			t.Errorf("check out: got %v, want %v", err, ErrCopyNotFound)
+		}
+	})
+}
+
+func TestPurgeBookKeepsLoans(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		lent, returned := newLoan(t, lib, "001")
+
+		if err := lib.CheckIn(ctx, "001"); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.PurgeBook(ctx, lent.ID); !errors.Is(err, ErrBookNotFound) {
+			t.Fatalf("purge of a book out of the trash: got %v, want %v", err, ErrBookNotFound)
+		}
+		if err := lib.DeleteBook(ctx, lent.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.PurgeBook(ctx, lent.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := lib.Storage.Book.Get(intercept.SkipSoftDelete(ctx), lent.ID); !ent.IsNotFound(err) {
+			t.Errorf("the book is not purged: %v", err)
+		}
+		if n, _ := lib.Storage.BookCopy.Query().Count(ctx); n != 0 {
+			t.Errorf("got %d copies after purge, want 0", n)
+		}
+		kept, err := lib.Storage.Loan.Query().Where(loan.ID(returned.ID)).WithBorrower().Only(ctx)
+		if err != nil {
+			t.Fatalf("the loan is not kept: %v", err)
+		}
+		if kept.ReturnedAt == nil || kept.Edges.Borrower == nil {
+			t.Errorf("the loan lost its history: returned %v, borrower %v", kept.ReturnedAt, kept.Edges.Borrower)
+		}
+		if has, _ := kept.QueryCopy().Exist(ctx); has {
+			t.Error("the loan still refers to the purged copy")
+		}
+	})
+}
+
+func TestPurgeTrashSkipsLoans(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		lent, _ := newLoan(t, lib, "001")
+		free, err := lib.Storage.Book.Create().SetTitle("Free").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// a loan left open on a book trashed before loans were checked
+		past := time.Now().Add(-time.Hour).Unix()
+		for _, id := range []int64{lent.ID, free.ID} {
+			if err := lib.Storage.Book.UpdateOneID(id).SetDeletedAt(past).Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := lib.PurgeBook(ctx, lent.ID); !errors.Is(err, ErrBookOnLoan) {
+			t.Errorf("purge: got %v, want %v", err, ErrBookOnLoan)
+		}
+		n, err := lib.PurgeTrash(ctx, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Errorf("got %d purged, want 1", n)
+		}
+
+		trash, err := lib.Trash(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trash) != 1 || trash[0].ID != lent.ID {
+			t.Errorf("trash: got %v, want the book on loan", trash)
+		}
+		if open, _ := lib.Storage.Loan.Query().Where(loan.ReturnedAtIsNil()).Count(ctx); open != 1 {
+			t.Errorf("got %d open loans, want 1", open)
+		}
+	})
+}
+
+func TestImportTrashed(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		created, err := importExample(t, lib)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteBook(ctx, created.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		// the file is still stored, the book is to be restored instead
+		if _, err := importExample(t, lib); !errors.Is(err, ErrExistsInTrash) || !errors.Is(err, ErrExists) {
+			t.Errorf("got %v, want %v", err, ErrExistsInTrash)
+		}
+
+		if err := lib.PurgeBook(ctx, created.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := importExample(t, lib); err != nil {
+			t.Errorf("import after purge: %v", err)
+		}
+	})
+}
+
+func TestPlaceHoldTrashed(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		lent, _ := newLoan(t, lib, "001")
+		reader, err := lib.Storage.User.Create().SetLogin("ann").SetPasswordHash("-").Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := lib.CheckIn(ctx, "001"); err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteBook(ctx, lent.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.PlaceHold(ctx, lent.ID, reader.ID); !errors.Is(err, ErrBookNotFound) {
+			t.Errorf("got %v, want %v", err, ErrBookNotFound)
+		}
+
+		if _, err := lib.RestoreBook(ctx, lent.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lib.PlaceHold(ctx, lent.ID, reader.ID); err != nil {
+			t.Errorf("restored book: %v", err)
+		}
+	})
+}
+
+func TestCollectGarbage(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+		put := func(content string) string {
+			t.Helper()
+			id, err := lib.Files.Put(".txt", strings.NewReader(content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return id
+		}
+		bookFile, trashedFile, pending, sent, orphan := put("book"), put("trashed"), put("pending"), put("sent"), put("orphan")
+		exhausted := put("exhausted")
+		lib.MailAttempts = 3
+
+		if _, err := lib.Storage.Book.Create().SetTitle("Kept").SetFileID(bookFile).Save(ctx); err != nil {
+			t.Fatal(err)
+		}
+		trashed, err := lib.Storage.Book.Create().SetTitle("Trashed").SetFileID(trashedFile).Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := lib.DeleteBook(ctx, trashed.ID); err != nil {
+			t.Fatal(err)
+		}
+		for _, attachment := range []string{pending, sent, exhausted} {
+			message := lib.Storage.OutboxMessage.Create().
+				SetKind("device").
+				SetTo("ann@example.com").
+				SetSubject("Book").
+				SetBody("").
+				SetAttachment(attachment)
+			switch attachment {
+			case sent:
+				message.SetSentAt(time.Now().Unix())
+			case pending:
+				message.SetAttempts(lib.MailAttempts - 1)
+			case exhausted:
+				// left unsent for good
+				message.SetAttempts(lib.MailAttempts)
+			}
+			if _, err := message.Save(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, id := range []string{bookFile, orphan} {
+			if err := lib.saveValidation(ctx, id, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// recent files are kept
+		if removed, _, err := lib.CollectGarbage(ctx, time.Now().Add(-time.Hour)); err != nil || removed != 0 {
+			t.Fatalf("got %d removed, %v, want none", removed, err)
+		}
+
+		removed, size, err := lib.CollectGarbage(ctx, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if removed != 3 || size != int64(len("sent")+len("orphan")+len("exhausted")) {
+			t.Errorf("got %d files of %d bytes removed, want the sent and exhausted attachments and the orphan", removed, size)
+		}
+		for _, id := range []string{bookFile, trashedFile, pending, sent, orphan, exhausted} {
+			file, err := lib.Files.Open(id)
+			if err == nil {
+				_ = file.Close()
+			}
+			if kept := id != sent && id != orphan && id != exhausted; kept != (err == nil) {
+				t.Errorf("%s: kept %v, got %v", id, kept, err)
+			}
+		}
+		if validated, err := lib.FileProblems(ctx, []string{bookFile, orphan}); err != nil || len(validated) != 1 || validated[bookFile] == nil {
+			t.Errorf("got validations %v, %v, want only the kept file's", validated, err)
+		}
+	})
+}
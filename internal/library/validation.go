@@ -0,0 +1,92 @@
+package library
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
+)
+
+// ValidateFile validates the stored FB2 file, the problems replace
+// the ones kept for the validation report.
+func (lib *Library) ValidateFile(ctx context.Context, fileID string) ([]bookinfo.Problem, error) {
+	file, errOpen := lib.Files.Open(fileID)
+	if errOpen != nil {
+		return nil, errOpen
+	}
+	defer func() { _ = file.Close() }()
+
+	problems, errValidate := bookinfo.ValidateFB2(file)
+	if errValidate != nil {
+		return nil, errValidate
+	}
+	if err := lib.saveValidation(ctx, fileID, problems); err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// saveValidation keeps problems found in the stored file.
+func (lib *Library) saveValidation(ctx context.Context, fileID string, problems []bookinfo.Problem) error {
+	saved := make([]schema.FileProblem, 0, len(problems))
+	for _, problem := range problems {
+		saved = append(saved, schema.FileProblem{
+			Severity: problem.Severity.String(),
+			Location: problem.Location,
+			Message:  problem.Message,
+		})
+	}
+
+	n, errUpdate := lib.Storage.Validation.Update().
+		Where(validation.FileID(fileID)).
+		SetProblems(saved).
+		Save(ctx)
+	switch {
+	case errUpdate != nil:
+		return fmt.Errorf("db: %w", errUpdate)
+	case n > 0:
+		return nil
+	}
+
+	errCreate := lib.Storage.Validation.Create().
+		SetFileID(fileID).
+		SetProblems(saved).
+		Exec(ctx)
+	// the same file stored concurrently has the same problems
+	if errCreate != nil && !ent.IsConstraintError(errCreate) {
+		return fmt.Errorf("db: %w", errCreate)
+	}
+	return nil
+}
+
+// FileProblems returns kept problems of the stored files by their ids,
+// files which weren't validated are missing.
+func (lib *Library) FileProblems(ctx context.Context, fileIDs []string) (map[string][]bookinfo.Problem, error) {
+	validations, err := lib.Storage.Validation.Query().
+		Where(validation.FileIDIn(fileIDs...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	found := make(map[string][]bookinfo.Problem, len(validations))
+	for _, item := range validations {
+		problems := make([]bookinfo.Problem, 0, len(item.Problems))
+		for _, problem := range item.Problems {
+			severity := bookinfo.SeverityWarning
+			if problem.Severity == bookinfo.SeverityError.String() {
+				severity = bookinfo.SeverityError
+			}
+			problems = append(problems, bookinfo.Problem{
+				Severity: severity,
+				Location: problem.Location,
+				Message:  problem.Message,
+			})
+		}
+		found[item.FileID] = problems
+	}
+	return found, nil
+}
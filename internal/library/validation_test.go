@@ -0,0 +1,72 @@
+package library
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+)
+
+func TestValidation(t *testing.T) {
+	forEachLibrary(t, func(t *testing.T, lib *Library) {
+		ctx := context.Background()
+
+		// the title is there, the rest of the description is missing
+		const broken = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0"><description><title-info>` +
+			`<book-title>Broken</book-title></title-info></description><body><p>text</p></body></FictionBook>`
+		stored, err := lib.StoreFile(ctx, "broken.fb2", strings.NewReader(broken))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hasErrors(stored.Problems) {
+			t.Fatalf("got problems %v, want errors", stored.Problems)
+		}
+		kept, err := lib.FileProblems(ctx, []string{stored.FileID, "unknown.fb2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(kept) != 1 || len(kept[stored.FileID]) != len(stored.Problems) || kept[stored.FileID][0] != stored.Problems[0] {
+			t.Errorf("got kept %v, want %v", kept, stored.Problems)
+		}
+
+		// the same file stored again keeps one result
+		if _, err := lib.StoreFile(ctx, "copy.fb2", strings.NewReader(broken)); err != nil {
+			t.Fatal(err)
+		}
+		if n, _ := lib.Storage.Validation.Query().Count(ctx); n != 1 {
+			t.Errorf("got %d results, want 1", n)
+		}
+
+		// validation of the stored file replaces the result
+		example, err := os.ReadFile("../bookinfo/testdata/example.fb2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(lib.Files.Dir, stored.FileID), example, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		problems, err := lib.ValidateFile(ctx, stored.FileID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kept, err = lib.FileProblems(ctx, []string{stored.FileID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(kept[stored.FileID]) != len(problems) || hasErrors(kept[stored.FileID]) {
+			t.Errorf("got kept %v, want %v", kept[stored.FileID], problems)
+		}
+	})
+}
+
+func hasErrors(problems []bookinfo.Problem) bool {
+	for _, problem := range problems {
+		if problem.Severity == bookinfo.SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+// Package notify queues email notifications in the database outbox
+// and delivers them over SMTP with retries, so messages survive restarts
+// and outages of the mail server.
+package notify
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+)
+
+type Kind string
+
+const (
+	KindDueReminder Kind = "due_reminder"
+	KindHoldReady   Kind = "hold_ready"
+	KindHoldExpired Kind = "hold_expired"
+	KindNewBook     Kind = "new_book"
+	// KindBookFile carries a book file to a device of the user,
+	// it is sent regardless of preferences.
+	KindBookFile Kind = "book_file"
+)
+
+// Data of message templates by kind.
+type (
+	DueReminder struct {
+		Book    *ent.Book
+		Barcode string
+		Due     time.Time
+	}
+
+	HoldReady struct {
+		Book    *ent.Book
+		Barcode string
+		Expires time.Time
+	}
+
+	HoldExpired struct {
+		Book *ent.Book
+	}
+
+	NewBook struct {
+		Book    *ent.Book
+		Authors []string
+	}
+
+	BookFile struct {
+		Book   *ent.Book
+		Device *ent.Device
+	}
+)
+
+//go:embed templates/*.txt
+var templateFS embed.FS
+
+// Templates are named by kind. The first line of the output is
+// the subject, the body follows after an empty line.
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.txt"))
+
+var errNoSubject = errors.New("template output must start with a subject line")
+
+// wanted reports whether the user agreed to receive messages of the kind.
+func wanted(to *ent.User, kind Kind) bool {
+	switch kind {
+	case KindDueReminder:
+		return to.NotifyDue
+	case KindHoldReady, KindHoldExpired:
+		return to.NotifyHolds
+	case KindNewBook:
+		return to.NotifyNewBooks
+	default:
+		return true
+	}
+}
+
+// Message is an email to send.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+	// Attachment is nil for messages without files.
+	Attachment *Attachment
+}
+
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Render executes the template of the kind.
+func Render(kind Kind, to *ent.User, baseURL string, data any) (*Message, error) {
+	buf := &bytes.Buffer{}
+	errExecute := templates.ExecuteTemplate(buf, string(kind)+".txt", map[string]any{
+		"User":    to,
+		"BaseURL": strings.TrimSuffix(baseURL, "/"),
+		"Data":    data,
+	})
+	if errExecute != nil {
+		return nil, fmt.Errorf("template %s: %w", kind, errExecute)
+	}
+
+	subject, body, ok := strings.Cut(buf.String(), "\n\n")
+	if !ok || strings.Contains(subject, "\n") {
+		return nil, fmt.Errorf("template %s: %w", kind, errNoSubject)
+	}
+	return &Message{
+		To:      to.Email,
+		Subject: subject,
+		Body:    strings.TrimSpace(body) + "\n",
+	}, nil
+}
@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+// Sender delivers a message, SMTP is the only implementation.
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Outbox keeps messages in the database until they are sent.
+type Outbox struct {
+	Storage *ent.Client
+	// Sender is nil in commands which only queue messages.
+	Sender Sender
+	// BaseURL of the library is used in links.
+	BaseURL string
+	// MaxAttempts of delivery, the message is left unsent after them.
+	MaxAttempts int
+	// Files keeps attachments of messages.
+	Files *files.Store
+}
+
+const (
+	deliveryBatch = 20
+	minBackoff    = time.Minute
+	maxBackoff    = 6 * time.Hour
+)
+
+// Notify queues a message to the user, unless the user has no email
+// or turned off messages of the kind.
+func (outbox *Outbox) Notify(ctx context.Context, to *ent.User, kind Kind, data any) error {
+	if to.Email == "" || !wanted(to, kind) {
+		return nil
+	}
+
+	msg, errRender := Render(kind, to, outbox.BaseURL, data)
+	if errRender != nil {
+		return errRender
+	}
+
+	errCreate := outbox.Storage.OutboxMessage.Create().
+		SetKind(string(kind)).
+		SetTo(msg.To).
+		SetSubject(msg.Subject).
+		SetBody(msg.Body).
+		Exec(ctx)
+	if errCreate != nil {
+		return fmt.Errorf("outbox: %w", errCreate)
+	}
+	return nil
+}
+
+// SendFile queues the stored file to the device of the user.
+func (outbox *Outbox) SendFile(ctx context.Context, to *ent.User, data *BookFile, fileID, name string) (*ent.OutboxMessage, error) {
+	msg, errRender := Render(KindBookFile, to, outbox.BaseURL, data)
+	if errRender != nil {
+		return nil, errRender
+	}
+
+	created, errCreate := outbox.Storage.OutboxMessage.Create().
+		SetKind(string(KindBookFile)).
+		SetTo(data.Device.Email).
+		SetSubject(msg.Subject).
+		SetBody(msg.Body).
+		SetAttachment(fileID).
+		SetAttachmentName(name).
+		Save(ctx)
+	if errCreate != nil {
+		return nil, fmt.Errorf("outbox: %w", errCreate)
+	}
+	return created, nil
+}
+
+// Deliver sends due messages and reschedules failed ones with exponential
+// backoff. It returns the number of sent messages.
+func (outbox *Outbox) Deliver(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		now := time.Now()
+		batch, errQuery := outbox.Storage.OutboxMessage.Query().
+			Where(
+				outboxmessage.SentAtIsNil(),
+				outboxmessage.NextAttemptAtLTE(now.Unix()),
+				outboxmessage.AttemptsLT(outbox.MaxAttempts),
+			).
+			Order(ent.Asc(outboxmessage.FieldID)).
+			Limit(deliveryBatch).
+			All(ctx)
+		if errQuery != nil {
+			return sent, fmt.Errorf("outbox: %w", errQuery)
+		}
+
+		for _, item := range batch {
+			if ctx.Err() != nil {
+				return sent, ctx.Err()
+			}
+
+			msg := &Message{
+				To:      item.To,
+				Subject: item.Subject,
+				Body:    item.Body,
+			}
+			var errSend error
+			if item.Attachment != "" {
+				msg.Attachment, errSend = outbox.attachment(item)
+			}
+			if errSend == nil {
+				errSend = outbox.Sender.Send(ctx, msg)
+			}
+			if errSend != nil && ctx.Err() != nil {
+				// not an attempt, the message is sent by the next call
+				return sent, ctx.Err()
+			}
+
+			update := outbox.Storage.OutboxMessage.UpdateOne(item).
+				AddAttempts(1)
+			if errSend == nil {
+				update.SetSentAt(time.Now().Unix())
+				sent++
+			} else {
+				log.Printf("ERROR: outbox: message %d to %s: %v", item.ID, item.To, errSend)
+				update.
+					SetLastError(errSend.Error()).
+					SetNextAttemptAt(now.Add(backoff(item.Attempts)).Unix())
+			}
+			// the result is saved even if the call is canceled meanwhile,
+			// so a sent message is not sent again
+			if err := update.Exec(context.WithoutCancel(ctx)); err != nil {
+				return sent, fmt.Errorf("outbox: %w", err)
+			}
+		}
+
+		if len(batch) < deliveryBatch {
+			return sent, nil
+		}
+	}
+}
+
+func (outbox *Outbox) attachment(item *ent.OutboxMessage) (*Attachment, error) {
+	file, errOpen := outbox.Files.Open(item.Attachment)
+	if errOpen != nil {
+		return nil, fmt.Errorf("attachment: %w", errOpen)
+	}
+	defer func() { _ = file.Close() }()
+
+	data, errRead := io.ReadAll(file)
+	if errRead != nil {
+		return nil, fmt.Errorf("attachment: %w", errRead)
+	}
+
+	return &Attachment{
+		Name:        item.AttachmentName,
+		ContentType: files.ContentType(item.Attachment),
+		Data:        data,
+	}, nil
+}
+
+func backoff(attempts int) time.Duration {
+	delay := minBackoff << attempts
+	if delay > maxBackoff || delay <= 0 {
+		return maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,277 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/database/dbtest"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/files"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+)
+
+func openStorage(t *testing.T, driver string) *ent.Client {
+	t.Helper()
+	db, dbDialect := dbtest.Open(t, driver)
+	if err := (&migrations.Migrator{DB: db, Dialect: dbDialect}).Up(context.Background(), ""); err != nil {
+		t.Fatal(err)
+	}
+	return ent.NewClient(ent.Driver(sql.OpenDB(dbDialect, db)))
+}
+
+// forEachStorage runs the test as a subtest for every driver
+// of dbtest.Drivers with the storage on an empty database.
+func forEachStorage(t *testing.T, test func(t *testing.T, storage *ent.Client)) {
+	for _, driver := range dbtest.Drivers {
+		t.Run(driver, func(t *testing.T) {
+			test(t, openStorage(t, driver))
+		})
+	}
+}
+
+// fakeSender records sent messages and fails to send to addresses in fail.
+type fakeSender struct {
+	sent []*Message
+	fail map[string]bool
+}
+
+var errRejected = errors.New("recipient rejected")
+
+func (sender *fakeSender) Send(_ context.Context, msg *Message) error {
+	if sender.fail[msg.To] {
+		return errRejected
+	}
+	sender.sent = append(sender.sent, msg)
+	return nil
+}
+
+func TestOutboxNotify(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, storage *ent.Client) {
+		ctx := context.Background()
+		outbox := &Outbox{Storage: storage, BaseURL: "https://library.example/"}
+
+		book := &ent.Book{ID: 7, Title: "Solaris"}
+		data := &NewBook{Book: book, Authors: []string{"Stanisław Lem"}}
+		users := []*ent.User{
+			{Login: "ann", Email: "ann@example.com", NotifyNewBooks: true},
+			// opted out of the kind
+			{Login: "bob", Email: "bob@example.com", NotifyNewBooks: false, NotifyDue: true},
+			// has no email
+			{Login: "eve", NotifyNewBooks: true},
+		}
+		for _, to := range users {
+			if err := outbox.Notify(ctx, to, KindNewBook, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		queued, err := storage.OutboxMessage.Query().All(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(queued) != 1 {
+			t.Fatalf("got %d queued messages, want 1", len(queued))
+		}
+		msg := queued[0]
+		if msg.Kind != string(KindNewBook) || msg.To != "ann@example.com" || msg.Subject != "New book: Solaris" {
+			t.Errorf("got %s message to %s %q", msg.Kind, msg.To, msg.Subject)
+		}
+		if !strings.Contains(msg.Body, "https://library.example/books/7") {
+			t.Errorf("body has no link to the book:\n%s", msg.Body)
+		}
+		if msg.SentAt != nil || msg.Attempts != 0 || msg.NextAttemptAt > time.Now().Unix() {
+			t.Errorf("new message is not due: sent %v, attempts %d, next attempt %d", msg.SentAt, msg.Attempts, msg.NextAttemptAt)
+		}
+	})
+}
+
+func TestOutboxDeliver(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, storage *ent.Client) {
+		ctx := context.Background()
+		sender := &fakeSender{fail: map[string]bool{"down@example.com": true}}
+		outbox := &Outbox{Storage: storage, Sender: sender, MaxAttempts: 3}
+
+		for _, to := range []string{"ann@example.com", "down@example.com", "bob@example.com"} {
+			errCreate := storage.OutboxMessage.Create().
+				SetKind(string(KindDueReminder)).
+				SetTo(to).
+				SetSubject("Due").
+				SetBody("Return the book\n").
+				Exec(ctx)
+			if errCreate != nil {
+				t.Fatal(errCreate)
+			}
+		}
+
+		start := time.Now()
+		sent, err := outbox.Deliver(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent != 2 || len(sender.sent) != 2 {
+			t.Fatalf("got %d sent, %d delivered, want 2", sent, len(sender.sent))
+		}
+
+		failed, err := storage.OutboxMessage.Query().Where(outboxmessage.To("down@example.com")).Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if failed.SentAt != nil || failed.Attempts != 1 || failed.LastError != errRejected.Error() {
+			t.Errorf("failed message: sent %v, attempts %d, error %q", failed.SentAt, failed.Attempts, failed.LastError)
+		}
+		if next := time.Unix(failed.NextAttemptAt, 0); next.Before(start.Add(minBackoff).Truncate(time.Second)) {
+			t.Errorf("retry at %v, want after the backoff of %v", next, minBackoff)
+		}
+
+		// sent messages are kept, but not sent again
+		delivered, err := storage.OutboxMessage.Query().Where(outboxmessage.SentAtNotNil()).Count(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delivered != 2 {
+			t.Errorf("got %d messages marked sent, want 2", delivered)
+		}
+
+		// the failed message waits for its next attempt
+		if sent, err := outbox.Deliver(ctx); err != nil || sent != 0 {
+			t.Fatalf("before backoff: got %d, %v, want nothing sent", sent, err)
+		}
+		if failed, _ = storage.OutboxMessage.Get(ctx, failed.ID); failed.Attempts != 1 {
+			t.Errorf("attempts before backoff: got %d, want 1", failed.Attempts)
+		}
+
+		// due attempts are made until MaxAttempts
+		for attempt := 2; attempt <= 4; attempt++ {
+			if err := storage.OutboxMessage.UpdateOne(failed).SetNextAttemptAt(0).Exec(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := outbox.Deliver(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if failed, err = storage.OutboxMessage.Get(ctx, failed.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if failed.Attempts != outbox.MaxAttempts {
+			t.Errorf("attempts: got %d, want %d", failed.Attempts, outbox.MaxAttempts)
+		}
+
+		// a recovered server gets the message on a later attempt
+		delete(sender.fail, "down@example.com")
+		outbox.MaxAttempts++
+		if err := storage.OutboxMessage.UpdateOne(failed).SetNextAttemptAt(0).Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if sent, err := outbox.Deliver(ctx); err != nil || sent != 1 {
+			t.Fatalf("after recovery: got %d, %v, want 1 sent", sent, err)
+		}
+	})
+}
+
+func TestOutboxDeliverAttachment(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, storage *ent.Client) {
+		ctx := context.Background()
+		store := &files.Store{Dir: t.TempDir()}
+		sender := &fakeSender{}
+		outbox := &Outbox{Storage: storage, Sender: sender, MaxAttempts: 1, Files: store}
+
+		fileID, err := store.Put(".epub", strings.NewReader("book content"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := &BookFile{
+			Book:   &ent.Book{ID: 1, Title: "Solaris"},
+			Device: &ent.Device{Name: "Kindle", Email: "kindle@example.com"},
+		}
+		to := &ent.User{Login: "ann", Email: "ann@example.com"}
+		if _, err := outbox.SendFile(ctx, to, data, fileID, "Solaris.epub"); err != nil {
+			t.Fatal(err)
+		}
+
+		if sent, err := outbox.Deliver(ctx); err != nil || sent != 1 {
+			t.Fatalf("got %d, %v, want 1 sent", sent, err)
+		}
+		msg := sender.sent[0]
+		if msg.To != "kindle@example.com" {
+			t.Errorf("to: got %q, want the device", msg.To)
+		}
+		if msg.Attachment == nil || msg.Attachment.Name != "Solaris.epub" ||
+			msg.Attachment.ContentType != files.ContentType(fileID) || string(msg.Attachment.Data) != "book content" {
+			t.Errorf("attachment: got %+v", msg.Attachment)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{5, 32 * time.Minute},
+		{9, maxBackoff},
+		{64, maxBackoff},
+		{1000, maxBackoff},
+	}
+	for _, tc := range tests {
+		if got := backoff(tc.attempts); got != tc.want {
+			t.Errorf("%d attempts: got %v, want %v", tc.attempts, got, tc.want)
+		}
+	}
+}
+
+// cancelSender cancels the delivery while the message is sent.
+type cancelSender struct {
+	cancel context.CancelFunc
+	// delivered makes Send succeed before the cancel is noticed.
+	delivered bool
+}
+
+func (sender *cancelSender) Send(ctx context.Context, _ *Message) error {
+	sender.cancel()
+	if sender.delivered {
+		return nil
+	}
+	return ctx.Err()
+}
+
+func TestOutboxDeliverCanceled(t *testing.T) {
+	for _, driver := range dbtest.Drivers {
+		t.Run(driver, func(t *testing.T) {
+			for _, delivered := range []bool{false, true} {
+				storage := openStorage(t, driver)
+				errCreate := storage.OutboxMessage.Create().
+					SetKind(string(KindDueReminder)).
+					SetTo("ann@example.com").
+					SetSubject("Due").
+					SetBody("Return the book\n").
+					Exec(context.Background())
+				if errCreate != nil {
+					t.Fatal(errCreate)
+				}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				outbox := &Outbox{Storage: storage, Sender: &cancelSender{cancel: cancel, delivered: delivered}, MaxAttempts: 3}
+				_, _ = outbox.Deliver(ctx)
+
+				msg, err := storage.OutboxMessage.Query().Only(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+				switch {
+				case delivered && (msg.SentAt == nil || msg.Attempts != 1):
+					t.Errorf("sent message: sent %v, attempts %d, want marked sent", msg.SentAt, msg.Attempts)
+				case !delivered && (msg.SentAt != nil || msg.Attempts != 0 || msg.NextAttemptAt > time.Now().Unix()):
+					t.Errorf("interrupted message: sent %v, attempts %d, next attempt %d, want due again",
+						msg.SentAt, msg.Attempts, msg.NextAttemptAt)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// SMTP sends messages through a mail server. STARTTLS is used
+// if the server offers it, unless the connection is already TLS.
+type SMTP struct {
+	// Addr is host:port of the server.
+	Addr string
+	From string
+	// Username and Password are used for PLAIN auth if set,
+	// which requires TLS unless AllowInsecureAuth is set.
+	Username string
+	Password string
+	// ImplicitTLS connects with TLS from the start, usually to port 465.
+	ImplicitTLS bool
+	// AllowInsecureAuth sends the password to servers without STARTTLS,
+	// for relays on a trusted network.
+	AllowInsecureAuth bool
+	Timeout           time.Duration
+}
+
+// ErrSMTPNoTLS is returned if the server offers no STARTTLS,
+// so the password would be sent in plain text.
+var ErrSMTPNoTLS = errors.New("smtp: the server does not support STARTTLS, auth without TLS is not allowed")
+
+const defaultSMTPTimeout = 30 * time.Second
+
+func (sender *SMTP) Send(ctx context.Context, msg *Message) error {
+	from, errFrom := mail.ParseAddress(sender.From)
+	if errFrom != nil {
+		return fmt.Errorf("from: %w", errFrom)
+	}
+	to, errTo := mail.ParseAddress(msg.To)
+	if errTo != nil {
+		return fmt.Errorf("to: %w", errTo)
+	}
+	host, _, errAddr := net.SplitHostPort(sender.Addr)
+	if errAddr != nil {
+		return fmt.Errorf("smtp: %w", errAddr)
+	}
+
+	timeout := sender.Timeout
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	dialer := &net.Dialer{Deadline: deadline}
+	var (
+		conn    net.Conn
+		errDial error
+	)
+	if sender.ImplicitTLS {
+		conn, errDial = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", sender.Addr)
+	} else {
+		conn, errDial = dialer.DialContext(ctx, "tcp", sender.Addr)
+	}
+	if errDial != nil {
+		return fmt.Errorf("smtp: %w", errDial)
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, errClient := smtp.NewClient(conn, host)
+	if errClient != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp: %w", errClient)
+	}
+	defer func() { _ = client.Close() }()
+
+	secure := sender.ImplicitTLS
+	if !secure {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+				return fmt.Errorf("smtp: starttls: %w", err)
+			}
+			secure = true
+		}
+	}
+	if sender.Username != "" {
+		auth := smtp.PlainAuth("", sender.Username, sender.Password, host)
+		switch {
+		case !secure && !sender.AllowInsecureAuth:
+			return ErrSMTPNoTLS
+		case !secure:
+			auth = insecureAuth{auth}
+		}
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp: auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp: mail from: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("smtp: rcpt to: %w", err)
+	}
+	data, errData := client.Data()
+	if errData != nil {
+		return fmt.Errorf("smtp: data: %w", errData)
+	}
+	if _, err := data.Write(format(from, to, msg, time.Now())); err != nil {
+		return fmt.Errorf("smtp: data: %w", err)
+	}
+	if err := data.Close(); err != nil {
+		return fmt.Errorf("smtp: data: %w", err)
+	}
+	return client.Quit()
+}
+
+// insecureAuth lets PLAIN auth run without TLS,
+// net/smtp refuses it for servers other than localhost.
+type insecureAuth struct {
+	smtp.Auth
+}
+
+func (auth insecureAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	trusted := *server
+	trusted.TLS = true
+	return auth.Auth.Start(&trusted)
+}
+
+// format returns the message with headers and a quoted-printable body,
+// a message with an attachment is multipart with the file in base64.
+func format(from, to *mail.Address, msg *Message, now time.Time) []byte {
+	buf := &bytes.Buffer{}
+	header := func(name, value string) {
+		fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+	}
+	header("From", from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", messageID(from))
+	header("MIME-Version", "1.0")
+
+	if msg.Attachment == nil {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		writeText(buf, msg.Body)
+		return buf.Bytes()
+	}
+
+	parts := multipart.NewWriter(buf)
+	header("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": parts.Boundary()}))
+	buf.WriteString("\r\n")
+
+	text, _ := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	writeText(text, msg.Body)
+
+	file, _ := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {msg.Attachment.ContentType},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": msg.Attachment.Name})},
+	})
+	encoded := base64.StdEncoding.EncodeToString(msg.Attachment.Data)
+	for len(encoded) > base64LineLen {
+		_, _ = io.WriteString(file, encoded[:base64LineLen]+"\r\n")
+		encoded = encoded[base64LineLen:]
+	}
+	_, _ = io.WriteString(file, encoded+"\r\n")
+
+	_ = parts.Close()
+	return buf.Bytes()
+}
+
+// base64LineLen keeps lines under the 78 characters limit of RFC 5322.
+const base64LineLen = 76
+
+func writeText(w io.Writer, text string) {
+	body := quotedprintable.NewWriter(w)
+	_, _ = body.Write(bytes.ReplaceAll([]byte(text), []byte("\n"), []byte("\r\n")))
+	_ = body.Close()
+}
+
+func messageID(from *mail.Address) string {
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+	domain := "localhost"
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 {
+		domain = from.Address[at+1:]
+	}
+	return "<" + hex.EncodeToString(random) + "@" + domain + ">"
+}
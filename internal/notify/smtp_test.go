@@ -0,0 +1,335 @@
+package notify
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+)
+
+// fakeSMTP is a mail server without TLS accepting one message
+// per connection, it rejects recipients listed in reject.
+type fakeSMTP struct {
+	addr     string
+	reject   map[string]bool
+	messages chan smtpMessage
+	// credentials receives decoded PLAIN auth responses
+	credentials chan string
+}
+
+type smtpMessage struct {
+	from, to string
+	data     string
+}
+
+func startSMTP(t *testing.T, reject ...string) *fakeSMTP {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &fakeSMTP{
+		addr:        listener.Addr().String(),
+		reject:      map[string]bool{},
+		messages:    make(chan smtpMessage, 10),
+		credentials: make(chan string, 10),
+	}
+	for _, addr := range reject {
+		server.reject[addr] = true
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *fakeSMTP) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	text := textproto.NewConn(conn)
+
+	var msg smtpMessage
+	_ = text.PrintfLine("220 fake ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250-fake\r\n250-AUTH PLAIN\r\n250 8BITMIME")
+		case "AUTH":
+			_, response, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(response)
+			server.credentials <- string(decoded)
+			_ = text.PrintfLine("235 authenticated")
+		case "MAIL":
+			// parameters like BODY=8BITMIME follow the address
+			addr, _, _ := strings.Cut(strings.TrimPrefix(arg, "FROM:"), " ")
+			msg.from = strings.Trim(addr, "<>")
+			_ = text.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if server.reject[msg.to] {
+				_ = text.PrintfLine("550 no such user")
+				continue
+			}
+			_ = text.PrintfLine("250 ok")
+		case "DATA":
+			_ = text.PrintfLine("354 go ahead")
+			// line endings of the data are turned into \n
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			server.messages <- msg
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			return
+		default:
+			_ = text.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func (server *fakeSMTP) receive(t *testing.T) smtpMessage {
+	t.Helper()
+	select {
+	case msg := <-server.messages:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+		return smtpMessage{}
+	}
+}
+
+func TestSMTPSend(t *testing.T) {
+	server := startSMTP(t)
+	sender := &SMTP{Addr: server.addr, From: "Library <library@example.com>"}
+
+	msg := &Message{
+		To:      "ann@example.com",
+		Subject: "Книга готова",
+		Body:    "Hello, ann!\nThe book is waiting for you.\n",
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+
+	got := server.receive(t)
+	if got.from != "library@example.com" || got.to != "ann@example.com" {
+		t.Errorf("envelope: got %s -> %s", got.from, got.to)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(got.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != msg.Subject {
+		t.Errorf("subject: got %q, want %q", subject, msg.Subject)
+	}
+	if got := parsed.Header.Get("Message-Id"); !strings.HasSuffix(got, "@example.com>") {
+		t.Errorf("message id: got %q", got)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != msg.Body {
+		t.Errorf("body: got %q, want %q", body, msg.Body)
+	}
+}
+
+func TestSMTPSendAttachment(t *testing.T) {
+	server := startSMTP(t)
+	sender := &SMTP{Addr: server.addr, From: "library@example.com"}
+
+	data := []byte(strings.Repeat("0123456789", 20))
+	msg := &Message{
+		To:         "kindle@example.com",
+		Subject:    "Solaris",
+		Body:       "Your book.\n",
+		Attachment: &Attachment{Name: "Солярис.epub", ContentType: "application/epub+zip", Data: data},
+	}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(server.receive(t).data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("content type: got %q, %v", mediaType, err)
+	}
+
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	text, err := parts.NextRawPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := text.Header.Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("text part: got %q", got)
+	}
+
+	// NextPart decodes quoted-printable only, base64 is read by hand
+	file, err := parts.NextRawPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := file.FileName(); got != "Солярис.epub" {
+		t.Errorf("file name: got %q", got)
+	}
+	encoded, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(encoded)), "\n")
+	for _, line := range lines {
+		if len(line) > base64LineLen {
+			t.Errorf("line of %d characters", len(line))
+		}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(lines, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decoded) != string(data) {
+		t.Errorf("attachment: got %q, want %q", decoded, data)
+	}
+}
+
+func TestSMTPSendAuthWithoutTLS(t *testing.T) {
+	server := startSMTP(t)
+	// net/smtp allows plain text auth to 127.0.0.1, the sender must refuse it itself
+	sender := &SMTP{Addr: server.addr, From: "library@example.com", Username: "library", Password: "secret"}
+	msg := &Message{To: "ann@example.com", Subject: "Hello", Body: "text"}
+
+	if err := sender.Send(context.Background(), msg); !errors.Is(err, ErrSMTPNoTLS) {
+		t.Fatalf("got %v, want %v", err, ErrSMTPNoTLS)
+	}
+	select {
+	case got := <-server.credentials:
+		t.Fatalf("the password is sent without TLS: %q", got)
+	default:
+	}
+
+	sender.AllowInsecureAuth = true
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-server.credentials:
+		if got != "\x00library\x00secret" {
+			t.Errorf("got credentials %q", got)
+		}
+	default:
+		t.Error("no auth")
+	}
+	if got := server.receive(t); got.to != "ann@example.com" {
+		t.Errorf("got message to %s", got.to)
+	}
+}
+
+func TestSMTPSendRejected(t *testing.T) {
+	server := startSMTP(t, "nobody@example.com")
+	sender := &SMTP{Addr: server.addr, From: "library@example.com"}
+
+	err := sender.Send(context.Background(), &Message{To: "nobody@example.com", Subject: "Hi", Body: "Hi\n"})
+	if err == nil || !strings.Contains(err.Error(), "rcpt to") {
+		t.Fatalf("got %v, want a rejected recipient", err)
+	}
+}
+
+func TestSMTPSendUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	sender := &SMTP{Addr: addr, From: "library@example.com", Timeout: time.Second}
+	if err := sender.Send(context.Background(), &Message{To: "ann@example.com", Subject: "Hi", Body: "Hi\n"}); err == nil {
+		t.Fatal("sent to a closed port")
+	}
+}
+
+func TestOutboxDeliverSMTP(t *testing.T) {
+	forEachStorage(t, func(t *testing.T, storage *ent.Client) {
+		ctx := context.Background()
+		server := startSMTP(t, "gone@example.com")
+		outbox := &Outbox{
+			Storage:     storage,
+			Sender:      &SMTP{Addr: server.addr, From: "library@example.com"},
+			MaxAttempts: 3,
+		}
+
+		for _, to := range []string{"ann@example.com", "gone@example.com"} {
+			errCreate := storage.OutboxMessage.Create().
+				SetKind(string(KindHoldReady)).
+				SetTo(to).
+				SetSubject("Ready").
+				SetBody("The book is ready\n").
+				Exec(ctx)
+			if errCreate != nil {
+				t.Fatal(errCreate)
+			}
+		}
+
+		sent, err := outbox.Deliver(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sent != 1 {
+			t.Errorf("got %d sent, want 1", sent)
+		}
+		if got := server.receive(t); got.to != "ann@example.com" {
+			t.Errorf("delivered to %s", got.to)
+		}
+
+		messages, err := storage.OutboxMessage.Query().All(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, msg := range messages {
+			switch msg.To {
+			case "ann@example.com":
+				if msg.SentAt == nil {
+					t.Error("delivered message is not marked sent")
+				}
+			case "gone@example.com":
+				if msg.SentAt != nil || !strings.Contains(msg.LastError, "550") {
+					t.Errorf("rejected message: sent %v, error %q", msg.SentAt, msg.LastError)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+type bookProblems struct {
+	Book     *ent.Book
+	Problems []bookinfo.Problem
+}
+
+type validationView struct {
+	Books []bookProblems
+	// Unchecked are books with files stored before
+	// validation results were kept.
+	Unchecked []*ent.Book
+	page
+}
+
+// getValidationReport lists books which have problems in their FB2 files
+// as they were found when the files were stored or checked.
+func (srv *Service) getValidationReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	books, err := srv.Storage.Book.Query().
+		Where(book.FileIDHasSuffix(".fb2")).
+		Order(ent.Asc(book.FieldTitle)).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	fileIDs := make([]string, 0, len(books))
+	for _, found := range books {
+		fileIDs = append(fileIDs, found.FileID)
+	}
+	problems, errProblems := srv.library().FileProblems(ctx, fileIDs)
+	if errProblems != nil {
+		http.Error(w, errProblems.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &validationView{page: srv.page(w, r)}
+	for _, found := range books {
+		fileProblems, validated := problems[found.FileID]
+		switch {
+		case !validated:
+			data.Unchecked = append(data.Unchecked, found)
+		case len(fileProblems) > 0:
+			data.Books = append(data.Books, bookProblems{Book: found, Problems: fileProblems})
+		}
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "validation.html", data); err != nil {
+		log.Printf("ERROR: validation.html: %s", err)
+		return
+	}
+}
+
+type usersView struct {
+	Users []*ent.User
+	Roles []user.Role
+	// Self is the current admin, whose role can't be changed here.
+	Self *ent.User
+	page
+}
+
+func (srv *Service) listUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	users, err := srv.Storage.User.Query().
+		Order(ent.Asc(user.FieldLogin)).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &usersView{
+		Users: users,
+		Roles: auth.Roles,
+		Self:  currentUser(ctx),
+		page:  srv.page(w, r),
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "users.html", data); err != nil {
+		log.Printf("ERROR: users.html: %s", err)
+		return
+	}
+}
+
+// setUserRole changes the role of another user. Admins can't change
+// their own role, so the last admin can't lock everyone out.
+func (srv *Service) setUserRole(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if id == currentUser(r.Context()).ID {
+		http.Error(w, "can't change own role", http.StatusBadRequest)
+		return
+	}
+
+	role := user.Role(r.PostFormValue("role"))
+	if err := user.RoleValidator(role); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := srv.Storage.User.UpdateOneID(id).
+		SetRole(role).
+		Exec(r.Context())
+	switch {
+	case ent.IsNotFound(err):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+func TestValidationReport(t *testing.T) {
+	forEachService(t, func(t *testing.T, srv *Service) {
+		ctx := context.Background()
+		srv.Templ = template.Must(template.ParseGlob("../../templ/*.html"))
+		srv.ValidationReport = true
+		librarian := newUser(t, srv, "ann", user.RoleLibrarian)
+
+		const broken = `<FictionBook xmlns="http://www.gribuser.ru/xml/fictionbook/2.0"><description><title-info>` +
+			`<book-title>Broken</book-title></title-info></description><body><p>text</p></body></FictionBook>`
+		stored, err := srv.library().StoreFile(ctx, "broken.fb2", strings.NewReader(broken))
+		if err != nil {
+			t.Fatal(err)
+		}
+		unchecked, err := srv.Files.Put(".fb2", strings.NewReader("<FictionBook/>"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for title, fileID := range map[string]string{"Broken": stored.FileID, "Unchecked": unchecked} {
+			if _, err := srv.Storage.Book.Create().SetTitle(title).SetFileID(fileID).Save(ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// the report shows kept problems without reading files
+		if err := srv.Files.Remove(stored.FileID); err != nil {
+			t.Fatal(err)
+		}
+
+		mux := chi.NewMux()
+		srv.BuildRoutes(mux)
+		r := httptest.NewRequest(http.MethodGet, "/admin/validation", nil)
+		r.AddCookie(&http.Cookie{Name: sessionCookie, Value: newSession(t, srv, librarian, time.Now().Add(time.Hour))})
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+
+		body := w.Body.String()
+		if w.Code != http.StatusOK {
+			t.Fatalf("got status %d: %s", w.Code, body)
+		}
+		problems, notValidated, _ := strings.Cut(body, "Not validated")
+		if !strings.Contains(problems, "Broken") || !strings.Contains(problems, "no authors") {
+			t.Errorf("the report has no problems of the broken file:\n%s", body)
+		}
+		if !strings.Contains(notValidated, "Unchecked") {
+			t.Errorf("the report doesn't list the unchecked file:\n%s", body)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/auditentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+)
+
+// auditPageSize limits the audit log page, older entries
+// are listed by the before parameter.
+const auditPageSize = 100
+
+// auditEntities are the types recorded by hook.Audit.
+var auditEntities = []string{ent.TypeBook, ent.TypeAuthor}
+
+type auditFilter struct {
+	Entity   string        `schema:"entity"`
+	EntityID int64         `schema:"id"`
+	Actor    string        `schema:"actor"`
+	Op       auditentry.Op `schema:"op"`
+	// Before is the id of the last entry of the previous page.
+	Before int64 `schema:"before"`
+}
+
+type auditChange struct {
+	Field string
+	Old   string
+	New   string
+	// Edge tells the change lists added and removed ids.
+	Edge bool
+}
+
+type auditEntryView struct {
+	ID       int64
+	Date     string
+	Op       auditentry.Op
+	Entity   string
+	EntityID int64
+	// Actor is the login, empty for commands and jobs.
+	Actor   string
+	Changes []auditChange
+}
+
+type auditView struct {
+	Entries  []auditEntryView
+	Filter   auditFilter
+	Entities []string
+	Ops      []auditentry.Op
+	// Older links the next page with the same filter, empty on the last one.
+	Older string
+	page
+}
+
+// getAuditLog lists changes of the catalog, newest first.
+func (srv *Service) getAuditLog(w http.ResponseWriter, r *http.Request) {
+	var filter auditFilter
+	if err := binder.Decode(&filter, r.URL.Query()); err != nil {
+		http.Error(w, "query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := srv.Storage.AuditEntry.Query()
+	if filter.Entity != "" {
+		query = query.Where(auditentry.Entity(filter.Entity))
+	}
+	if filter.EntityID != 0 {
+		query = query.Where(auditentry.EntityID(filter.EntityID))
+	}
+	if filter.Actor != "" {
+		query = query.Where(auditentry.ActorLogin(filter.Actor))
+	}
+	if filter.Op != "" {
+		query = query.Where(auditentry.OpEQ(filter.Op))
+	}
+	if filter.Before != 0 {
+		query = query.Where(auditentry.IDLT(filter.Before))
+	}
+
+	entries, err := query.
+		Order(ent.Desc(auditentry.FieldID)).
+		Limit(auditPageSize + 1).
+		All(r.Context())
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &auditView{
+		Filter:   filter,
+		Entities: auditEntities,
+		Ops:      []auditentry.Op{auditentry.OpCreate, auditentry.OpUpdate, auditentry.OpDelete},
+		page:     srv.page(w, r),
+	}
+	if len(entries) > auditPageSize {
+		entries = entries[:auditPageSize]
+		params := r.URL.Query()
+		params.Set("before", strconv.FormatInt(entries[len(entries)-1].ID, 10))
+		data.Older = "/admin/audit?" + params.Encode()
+	}
+	for _, item := range entries {
+		data.Entries = append(data.Entries, newAuditEntryView(item))
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "audit.html", data); err != nil {
+		log.Printf("ERROR: audit.html: %s", err)
+		return
+	}
+}
+
+func newAuditEntryView(item *ent.AuditEntry) auditEntryView {
+	view := auditEntryView{
+		ID:       item.ID,
+		Date:     formatTime(item.CreatedAt),
+		Op:       item.Op,
+		Entity:   item.Entity,
+		EntityID: item.EntityID,
+		Actor:    item.ActorLogin,
+	}
+	for _, change := range item.Changes {
+		view.Changes = append(view.Changes, newAuditChange(change))
+	}
+	return view
+}
+
+func newAuditChange(change schema.FieldChange) auditChange {
+	if len(change.Added) > 0 || len(change.Removed) > 0 {
+		return auditChange{
+			Field: change.Field,
+			Old:   formatIDs(change.Removed),
+			New:   formatIDs(change.Added),
+			Edge:  true,
+		}
+	}
+	return auditChange{
+		Field: change.Field,
+		Old:   formatAuditValue(change.Old),
+		New:   formatAuditValue(change.New),
+	}
+}
+
+func formatAuditValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strconv.Quote(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func formatIDs(ids []int64) string {
+	formatted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		formatted = append(formatted, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(formatted, ", ")
+}
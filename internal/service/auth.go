@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/apitoken"
+	"github.com/ninedraft/bibliotheca/storage/ent/hook"
+	"github.com/ninedraft/bibliotheca/storage/ent/session"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+const sessionCookie = "session"
+
+type principalKey struct{}
+
+// principal is the authenticated user of the request,
+// token is set if the request is authenticated by an API token.
+type principal struct {
+	user  *ent.User
+	token *ent.APIToken
+}
+
+// currentUser returns the logged in user or nil.
+func currentUser(ctx context.Context) *ent.User {
+	found, _ := ctx.Value(principalKey{}).(*principal)
+	if found == nil {
+		return nil
+	}
+	return found.user
+}
+
+// currentToken returns the API token of the request or nil.
+func currentToken(ctx context.Context) *ent.APIToken {
+	found, _ := ctx.Value(principalKey{}).(*principal)
+	if found == nil {
+		return nil
+	}
+	return found.token
+}
+
+// authMW puts the user into the request context. API tokens are accepted
+// in the Authorization header as a Bearer token or as the password of
+// HTTP Basic auth, which is all most OPDS readers support.
+// The session cookie is used if there is no header.
+func (srv *Service) authMW(next http.Handler) http.Handler {
+	var handle http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		var (
+			found *principal
+			err   error
+		)
+		if r.Header.Get("Authorization") != "" {
+			found, err = srv.tokenPrincipal(r)
+		} else {
+			found, err = srv.sessionPrincipal(r)
+		}
+		switch {
+		case errors.Is(err, errBadToken):
+			unauthorized(w, err.Error())
+			return
+		case err != nil:
+			http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+			return
+		case found != nil:
+			ctx := context.WithValue(r.Context(), principalKey{}, found)
+			// changes of the catalog are recorded as made by the user
+			ctx = hook.WithActor(ctx, hook.Actor{ID: found.user.ID, Login: found.user.Login})
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	}
+	return handle
+}
+
+func (srv *Service) sessionPrincipal(r *http.Request) (*principal, error) {
+	cookie, errCookie := r.Cookie(sessionCookie)
+	if errCookie != nil {
+		return nil, nil
+	}
+
+	found, err := srv.Storage.Session.Query().
+		Where(
+			session.TokenHash(auth.HashToken(cookie.Value)),
+			session.ExpiresAtGT(time.Now().Unix()),
+		).
+		QueryUser().
+		Only(r.Context())
+	switch {
+	case ent.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return &principal{user: found}, nil
+}
+
+var errBadToken = errors.New("invalid API token")
+
+// lastUsedPrecision limits writes caused by frequent requests.
+const lastUsedPrecision = time.Minute
+
+func (srv *Service) tokenPrincipal(r *http.Request) (*principal, error) {
+	ctx := r.Context()
+
+	login, secret, isBasic := r.BasicAuth()
+	if !isBasic {
+		var isBearer bool
+		secret, isBearer = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !isBearer {
+			return nil, errBadToken
+		}
+	}
+
+	token, err := srv.Storage.APIToken.Query().
+		Where(apitoken.TokenHash(auth.HashToken(strings.TrimSpace(secret)))).
+		WithUser().
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		return nil, errBadToken
+	case err != nil:
+		return nil, err
+	case isBasic && token.Edges.User.Login != login:
+		return nil, errBadToken
+	}
+
+	now := time.Now()
+	if now.Sub(time.Unix(token.LastUsedAt, 0)) > lastUsedPrecision {
+		errUpdate := srv.Storage.APIToken.UpdateOne(token).
+			SetLastUsedAt(now.Unix()).
+			Exec(ctx)
+		if errUpdate != nil {
+			return nil, errUpdate
+		}
+	}
+
+	return &principal{user: token.Edges.User, token: token}, nil
+}
+
+func unauthorized(w http.ResponseWriter, msg string) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="bibliotheca", charset="UTF-8"`)
+	http.Error(w, msg, http.StatusUnauthorized)
+}
+
+// can reports whether the current user, or an anonymous visitor, has the permission.
+// Requests authenticated by a token are limited by its scopes.
+func (srv *Service) can(ctx context.Context, perm auth.Permission) bool {
+	if token := currentToken(ctx); token != nil && !slices.Contains(token.Scopes, string(perm)) {
+		return false
+	}
+	if found := currentUser(ctx); found != nil {
+		return auth.Allowed(found.Role, perm)
+	}
+	return srv.PublicBrowsing && slices.Contains(auth.AnonymousPermissions, perm)
+}
+
+// access lists permissions of the current user for templates.
+type access []auth.Permission
+
+func (srv *Service) access(ctx context.Context) access {
+	var granted access
+	for _, perm := range auth.Permissions {
+		if srv.can(ctx, perm) {
+			granted = append(granted, perm)
+		}
+	}
+	return granted
+}
+
+func (granted access) Can(perm auth.Permission) bool {
+	return slices.Contains(granted, perm)
+}
+
+// require rejects requests without the permission. Anonymous users
+// are redirected to the login page, others get 403.
+func (srv *Service) require(perm auth.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		var handle http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case srv.can(r.Context(), perm):
+				next.ServeHTTP(w, r)
+			case currentUser(r.Context()) == nil && !acceptsHTML(r):
+				unauthorized(w, "login required")
+			case currentUser(r.Context()) == nil:
+				target := "/login?" + url.Values{"next": {r.URL.RequestURI()}}.Encode()
+				http.Redirect(w, r, target, http.StatusSeeOther)
+			default:
+				http.Error(w, "forbidden: "+string(perm)+" permission required", http.StatusForbidden)
+			}
+		}
+		return handle
+	}
+}
+
+// requireSession allows only users logged in with a cookie,
+// so API tokens can't be used to manage accounts and tokens.
+func (srv *Service) requireSession(next http.Handler) http.Handler {
+	var handle http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case currentToken(r.Context()) != nil:
+			http.Error(w, "forbidden: not available to API tokens", http.StatusForbidden)
+		case currentUser(r.Context()) == nil:
+			target := "/login?" + url.Values{"next": {r.URL.RequestURI()}}.Encode()
+			http.Redirect(w, r, target, http.StatusSeeOther)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	}
+	return handle
+}
+
+// acceptsHTML tells browsers, which can be sent to the login page,
+// from scripts and readers, which need a 401 to send credentials.
+func acceptsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+type loginView struct {
+	Login string
+	Next  string
+	Error string
+	page
+}
+
+func (srv *Service) getLogin(w http.ResponseWriter, r *http.Request) {
+	srv.renderLogin(w, r, http.StatusOK, &loginView{Next: r.URL.Query().Get("next")})
+}
+
+func (srv *Service) renderLogin(w http.ResponseWriter, r *http.Request, status int, view *loginView) {
+	view.page = srv.page(w, r)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := srv.Templ.ExecuteTemplate(w, "login.html", view); err != nil {
+		log.Printf("ERROR: login.html: %s", err)
+	}
+}
+
+var errWrongCredentials = errors.New("wrong login or password")
+
+func (srv *Service) postLogin(w http.ResponseWriter, r *http.Request) {
+	view := &loginView{
+		Login: r.PostFormValue("login"),
+		Next:  r.PostFormValue("next"),
+	}
+	ctx := r.Context()
+
+	found, err := srv.Storage.User.Query().
+		Where(user.Login(view.Login)).
+		Only(ctx)
+	switch {
+	case ent.IsNotFound(err):
+		// hash anyway, so response time doesn't reveal existing logins
+		_, _ = auth.HashPassword(r.PostFormValue("password"))
+		err = errWrongCredentials
+	case err == nil:
+		err = auth.CheckPassword(found.PasswordHash, r.PostFormValue("password"))
+		if errors.Is(err, auth.ErrPasswordMismatch) {
+			err = errWrongCredentials
+		}
+	}
+	if errors.Is(err, errWrongCredentials) {
+		view.Error = err.Error()
+		srv.renderLogin(w, r, http.StatusUnauthorized, view)
+		return
+	}
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	token, errToken := auth.NewToken()
+	if errToken != nil {
+		http.Error(w, errToken.Error(), http.StatusInternalServerError)
+		return
+	}
+	expires := time.Now().Add(srv.SessionTTL)
+	errSession := srv.Storage.Session.Create().
+		SetTokenHash(auth.HashToken(token)).
+		SetExpiresAt(expires.Unix()).
+		SetUser(found).
+		Exec(ctx)
+	if errSession != nil {
+		http.Error(w, "db: "+errSession.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   srv.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, localPath(view.Next, "/books"), http.StatusSeeOther)
+}
+
+func (srv *Service) postLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		_, errDelete := srv.Storage.Session.Delete().
+			Where(session.TokenHash(auth.HashToken(cookie.Value))).
+			Exec(r.Context())
+		if errDelete != nil {
+			http.Error(w, "db: "+errDelete.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   srv.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/books", http.StatusSeeOther)
+}
+
+// localPath returns the target if it's a path on this site, so login
+// can't be used to redirect to another one.
+func localPath(target, fallback string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return fallback
+	}
+	return target
+}
+
+func (srv *Service) deleteExpiredSessions(ctx context.Context) error {
+	_, err := srv.Storage.Session.Delete().
+		Where(session.ExpiresAtLTE(time.Now().Unix())).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,573 @@
+package service
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+func withUser(role user.Role, scopes ...auth.Permission) context.Context {
+	found := &principal{user: &ent.User{ID: 1, Login: "someone", Role: role}}
+	if scopes != nil {
+		found.token = &ent.APIToken{ID: 1}
+		for _, scope := range scopes {
+			found.token.Scopes = append(found.token.Scopes, string(scope))
+		}
+	}
+	return context.WithValue(context.Background(), principalKey{}, found)
+}
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		name   string
+		public bool
+		ctx    context.Context
+		want   []auth.Permission
+	}{
+		{
+			name: "anonymous",
+			ctx:  context.Background(),
+			want: nil,
+		},
+		{
+			name:   "anonymous with public browsing",
+			public: true,
+			ctx:    context.Background(),
+			want:   []auth.Permission{auth.ReadCatalog, auth.Download},
+		},
+		{
+			name: "reader",
+			ctx:  withUser(user.RoleReader),
+			want: []auth.Permission{auth.ReadCatalog, auth.Download, auth.Reserve, auth.Review},
+		},
+		{
+			name: "librarian",
+			ctx:  withUser(user.RoleLibrarian),
+			want: []auth.Permission{auth.ReadCatalog, auth.Download, auth.Reserve, auth.Review,
+				auth.Upload, auth.EditMetadata, auth.Delete, auth.Circulate, auth.Moderate},
+		},
+		{
+			name: "admin",
+			ctx:  withUser(user.RoleAdmin),
+			want: auth.Permissions,
+		},
+		{
+			name:   "public browsing doesn't change roles",
+			public: true,
+			ctx:    withUser(user.RoleReader),
+			want:   []auth.Permission{auth.ReadCatalog, auth.Download, auth.Reserve, auth.Review},
+		},
+		{
+			name: "token narrows the role",
+			ctx:  withUser(user.RoleAdmin, auth.ReadCatalog, auth.Download),
+			want: []auth.Permission{auth.ReadCatalog, auth.Download},
+		},
+		{
+			name: "token doesn't widen the role",
+			ctx:  withUser(user.RoleReader, auth.ReadCatalog, auth.Upload, auth.ManageUsers),
+			want: []auth.Permission{auth.ReadCatalog},
+		},
+		{
+			name: "token without scopes",
+			ctx:  withUser(user.RoleAdmin, []auth.Permission{}...),
+			want: nil,
+		},
+		{
+			name:   "token of a reader with public browsing",
+			public: true,
+			ctx:    withUser(user.RoleReader, auth.Review),
+			want:   []auth.Permission{auth.Review},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := &Service{PublicBrowsing: tc.public}
+			for _, perm := range auth.Permissions {
+				want := slices.Contains(tc.want, perm)
+				if got := srv.can(tc.ctx, perm); got != want {
+					t.Errorf("%s: got %v, want %v", perm, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name     string
+		public   bool
+		ctx      context.Context
+		perm     auth.Permission
+		html     bool
+		status   int
+		location string
+	}{
+		{
+			name:   "allowed",
+			ctx:    withUser(user.RoleLibrarian),
+			perm:   auth.Upload,
+			status: http.StatusOK,
+		},
+		{
+			name:   "public browsing",
+			public: true,
+			ctx:    context.Background(),
+			perm:   auth.Download,
+			status: http.StatusOK,
+		},
+		{
+			name:   "anonymous client",
+			public: true,
+			ctx:    context.Background(),
+			perm:   auth.Review,
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:     "anonymous browser",
+			ctx:      context.Background(),
+			perm:     auth.ReadCatalog,
+			html:     true,
+			status:   http.StatusSeeOther,
+			location: "/login?next=%2Fbooks%3Fq%3Dx",
+		},
+		{
+			name:   "forbidden",
+			ctx:    withUser(user.RoleReader),
+			perm:   auth.Delete,
+			html:   true,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "out of the token scopes",
+			ctx:    withUser(user.RoleAdmin, auth.ReadCatalog),
+			perm:   auth.Download,
+			status: http.StatusForbidden,
+		},
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := &Service{PublicBrowsing: tc.public}
+			r := httptest.NewRequest(http.MethodGet, "/books?q=x", nil).WithContext(tc.ctx)
+			if tc.html {
+				r.Header.Set("Accept", "text/html")
+			}
+			w := httptest.NewRecorder()
+			srv.require(tc.perm)(ok).ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Errorf("status: got %d, want %d", w.Code, tc.status)
+			}
+			if got := w.Header().Get("Location"); got != tc.location {
+				t.Errorf("location: got %q, want %q", got, tc.location)
+			}
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		status int
+	}{
+		{name: "session", ctx: withUser(user.RoleReader), status: http.StatusOK},
+		{name: "token", ctx: withUser(user.RoleAdmin, auth.Permissions...), status: http.StatusForbidden},
+		{name: "anonymous", ctx: context.Background(), status: http.StatusSeeOther},
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := &Service{}
+			r := httptest.NewRequest(http.MethodGet, "/account/tokens", nil).WithContext(tc.ctx)
+			w := httptest.NewRecorder()
+			srv.requireSession(ok).ServeHTTP(w, r)
+
+			if w.Code != tc.status {
+				t.Errorf("status: got %d, want %d", w.Code, tc.status)
+			}
+			if tc.status == http.StatusSeeOther && !strings.HasPrefix(w.Header().Get("Location"), "/login?") {
+				t.Errorf("location: got %q, want the login page", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+// outcome is how the permission middleware handles a request.
+type outcome int
+
+const (
+	// routePassed reaches the handler.
+	routePassed outcome = iota
+	// routeLogin asks to log in.
+	routeLogin
+	// routeDenied is forbidden.
+	routeDenied
+)
+
+func (got outcome) String() string {
+	return [...]string{"passed", "login", "denied"}[got]
+}
+
+// routeAccess is the outcome for an anonymous visitor, a reader,
+// a librarian, an admin and an admin's token scoped to reading
+// the catalog and uploads.
+type routeAccess [5]outcome
+
+var (
+	catalogAccess  = routeAccess{routeLogin, routePassed, routePassed, routePassed, routePassed}
+	readerAccess   = routeAccess{routeLogin, routePassed, routePassed, routePassed, routeDenied}
+	uploadAccess   = routeAccess{routeLogin, routeDenied, routePassed, routePassed, routePassed}
+	staffAccess    = routeAccess{routeLogin, routeDenied, routePassed, routePassed, routeDenied}
+	adminAccess    = routeAccess{routeLogin, routeDenied, routeDenied, routePassed, routeDenied}
+	sessionAccess  = routeAccess{routeLogin, routePassed, routePassed, routePassed, routeDenied}
+	tokenScopes    = []string{string(auth.ReadCatalog), string(auth.Upload)}
+	principalNames = []string{"anonymous", "reader", "librarian", "admin", "token"}
+)
+
+func TestRoutes(t *testing.T) {
+	routes := []struct {
+		method string
+		path   string
+		access routeAccess
+	}{
+		{http.MethodGet, "/books", catalogAccess},
+		{http.MethodPost, "/books", uploadAccess},
+		{http.MethodGet, "/books/new", uploadAccess},
+		{http.MethodGet, "/books/{id}", catalogAccess},
+		{http.MethodGet, "/books/{id}/file", readerAccess},
+		{http.MethodGet, "/books/{id}/cover", catalogAccess},
+		{http.MethodGet, "/books/{id}/edit", staffAccess},
+		{http.MethodPost, "/books/{id}/edit", staffAccess},
+		{http.MethodPost, "/books/{id}/delete", staffAccess},
+		{http.MethodPost, "/books/{id}/copies", staffAccess},
+		{http.MethodPost, "/books/{id}/holds", readerAccess},
+		{http.MethodPost, "/books/{id}/send", sessionAccess},
+		{http.MethodPost, "/books/{id}/status", sessionAccess},
+		{http.MethodPost, "/books/{id}/review", readerAccess},
+		{http.MethodPost, "/books/{id}/review/delete", readerAccess},
+		{http.MethodGet, "/books/{id}/read", sessionAccess},
+		{http.MethodGet, "/books/{id}/notes", sessionAccess},
+		{http.MethodGet, "/books/{id}/highlights", sessionAccess},
+		{http.MethodPost, "/books/{id}/highlights", sessionAccess},
+
+		{http.MethodGet, "/shelves", sessionAccess},
+		{http.MethodPost, "/shelves", sessionAccess},
+		{http.MethodPost, "/shelves/{id}/delete", sessionAccess},
+		{http.MethodPost, "/shelves/{id}/books", sessionAccess},
+		{http.MethodPost, "/shelves/{id}/books/remove", sessionAccess},
+
+		{http.MethodGet, "/trash", staffAccess},
+		{http.MethodPost, "/trash/{id}/restore", staffAccess},
+		{http.MethodPost, "/trash/{id}/purge", staffAccess},
+
+		{http.MethodGet, "/highlights/export", sessionAccess},
+		{http.MethodPost, "/highlights/{id}", sessionAccess},
+		{http.MethodPost, "/highlights/{id}/delete", sessionAccess},
+
+		{http.MethodPost, "/revisions/{id}/restore", staffAccess},
+
+		{http.MethodGet, "/reviews", staffAccess},
+		{http.MethodPost, "/reviews/{id}/hide", staffAccess},
+		{http.MethodPost, "/reviews/{id}/show", staffAccess},
+
+		{http.MethodGet, "/holds", readerAccess},
+		{http.MethodPost, "/holds/{id}/cancel", readerAccess},
+
+		{http.MethodGet, "/circulation", staffAccess},
+		{http.MethodPost, "/circulation/checkout", staffAccess},
+		{http.MethodPost, "/circulation/checkin", staffAccess},
+		{http.MethodGet, "/circulation/overdue", staffAccess},
+
+		{http.MethodGet, "/authors", catalogAccess},
+		{http.MethodPost, "/authors", staffAccess},
+		{http.MethodGet, "/authors/new", staffAccess},
+		{http.MethodPost, "/authors/{id}/delete", staffAccess},
+		{http.MethodPost, "/authors/{id}/follow", sessionAccess},
+		{http.MethodPost, "/authors/{id}/unfollow", sessionAccess},
+
+		{http.MethodGet, "/account/tokens", sessionAccess},
+		{http.MethodPost, "/account/tokens", sessionAccess},
+		{http.MethodPost, "/account/tokens/{id}/revoke", sessionAccess},
+		{http.MethodGet, "/account/preferences", sessionAccess},
+		{http.MethodPost, "/account/preferences", sessionAccess},
+		{http.MethodGet, "/account/devices", sessionAccess},
+		{http.MethodPost, "/account/devices", sessionAccess},
+		{http.MethodPost, "/account/devices/{id}/delete", sessionAccess},
+		{http.MethodGet, "/account/sync", sessionAccess},
+		{http.MethodPost, "/account/sync", sessionAccess},
+
+		{http.MethodGet, "/admin/validation", staffAccess},
+		{http.MethodGet, "/admin/users", adminAccess},
+		{http.MethodPost, "/admin/users/{id}/role", adminAccess},
+		{http.MethodGet, "/admin/audit", adminAccess},
+	}
+
+	forEachService(t, func(t *testing.T, srv *Service) {
+		ctx := context.Background()
+		srv.Templ = template.Must(template.ParseGlob("../../templ/*.html"))
+		srv.ValidationReport = true
+		srv.SessionTTL = time.Hour
+
+		// every principal is sent with a form token, so only permissions decide
+		seed := "seed"
+		csrf := srv.Signer.MAC(csrfPurpose + ":" + seed)
+		credentials := make([]func(r *http.Request), len(principalNames))
+		credentials[0] = func(*http.Request) {}
+		for i, role := range auth.Roles {
+			owner := newUser(t, srv, string(role), role)
+			sessionToken := newSession(t, srv, owner, time.Now().Add(time.Hour))
+			credentials[i+1] = func(r *http.Request) {
+				r.AddCookie(&http.Cookie{Name: sessionCookie, Value: sessionToken})
+			}
+		}
+		admin, err := srv.Storage.User.Query().Where(user.Login(string(user.RoleAdmin))).Only(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		errToken := srv.Storage.APIToken.Create().
+			SetName("opds").
+			SetTokenHash(auth.HashToken("secret")).
+			SetScopes(tokenScopes).
+			SetUser(admin).
+			Exec(ctx)
+		if errToken != nil {
+			t.Fatal(errToken)
+		}
+		credentials[4] = func(r *http.Request) { r.Header.Set("Authorization", "Bearer secret") }
+
+		mux := chi.NewMux()
+		srv.BuildRoutes(mux)
+
+		// a new route can't be left out of the table
+		listed := map[string]bool{}
+		for _, route := range routes {
+			listed[route.method+" "+route.path] = true
+		}
+		errWalk := chi.Walk(mux, func(method, pattern string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			pattern = strings.TrimSuffix(pattern, "/")
+			public := strings.HasPrefix(pattern, "/static") || strings.HasPrefix(pattern, "/kosync") ||
+				pattern == "/login" || pattern == "/logout"
+			if !public && !listed[method+" "+pattern] {
+				t.Errorf("%s %s is not checked", method, pattern)
+			}
+			return nil
+		})
+		if errWalk != nil {
+			t.Fatal(errWalk)
+		}
+		// handlers change the state, so the token goes first,
+		// before the admin revokes it
+		for i := len(principalNames) - 1; i >= 0; i-- {
+			for _, route := range routes {
+				r := httptest.NewRequest(route.method, strings.ReplaceAll(route.path, "{id}", "1"), nil)
+				r.AddCookie(&http.Cookie{Name: csrfCookie, Value: seed})
+				r.Header.Set(csrfHeader, csrf)
+				credentials[i](r)
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, r)
+
+				got := routePassed
+				switch {
+				case w.Code == http.StatusUnauthorized,
+					w.Code == http.StatusSeeOther && strings.HasPrefix(w.Header().Get("Location"), "/login?"):
+					got = routeLogin
+				case w.Code == http.StatusForbidden && strings.HasPrefix(w.Body.String(), "forbidden:"):
+					got = routeDenied
+				}
+				if got != route.access[i] {
+					t.Errorf("%s %s as %s: got %s with status %d, want %s",
+						route.method, route.path, principalNames[i], got, w.Code, route.access[i])
+				}
+			}
+		}
+	})
+}
+
+// authRequest returns a request with a valid form token
+// and the session cookie, if it's set.
+func authRequest(srv *Service, method, target string, form url.Values, sessionToken string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{Name: csrfCookie, Value: "seed"})
+	r.Header.Set(csrfHeader, srv.Signer.MAC(csrfPurpose+":seed"))
+	if sessionToken != "" {
+		r.AddCookie(&http.Cookie{Name: sessionCookie, Value: sessionToken})
+	}
+	return r
+}
+
+// sessionCount returns the number of stored sessions.
+func sessionCount(t *testing.T, srv *Service) int {
+	t.Helper()
+	n, err := srv.Storage.Session.Query().Count(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestLogin(t *testing.T) {
+	forEachService(t, func(t *testing.T, srv *Service) {
+		ctx := context.Background()
+		srv.Templ = template.Must(template.ParseGlob("../../templ/*.html"))
+		srv.SessionTTL = time.Hour
+		srv.SecureCookie = true
+		mux := chi.NewMux()
+		srv.BuildRoutes(mux)
+
+		hash, err := auth.HashPassword("correct horse")
+		if err != nil {
+			t.Fatal(err)
+		}
+		errUser := srv.Storage.User.Create().SetLogin("ann").SetPasswordHash(hash).Exec(ctx)
+		if errUser != nil {
+			t.Fatal(errUser)
+		}
+
+		for _, login := range []string{"ann", "bob"} {
+			form := url.Values{"login": {login}, "password": {"wrong horse"}, "next": {"/authors"}}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, authRequest(srv, http.MethodPost, "/login", form, ""))
+			if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), errWrongCredentials.Error()) {
+				t.Errorf("%s: got status %d, want the login page with an error", login, w.Code)
+			}
+			for _, cookie := range w.Result().Cookies() {
+				if cookie.Name == sessionCookie {
+					t.Errorf("%s: got a session cookie", login)
+				}
+			}
+		}
+		if n := sessionCount(t, srv); n != 0 {
+			t.Fatalf("got %d sessions after wrong passwords", n)
+		}
+
+		form := url.Values{"login": {"ann"}, "password": {"correct horse"}, "next": {"/authors"}}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, authRequest(srv, http.MethodPost, "/login", form, ""))
+		if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/authors" {
+			t.Fatalf("got status %d to %q, want the next page", w.Code, w.Header().Get("Location"))
+		}
+		var cookie *http.Cookie
+		for _, set := range w.Result().Cookies() {
+			if set.Name == sessionCookie {
+				cookie = set
+			}
+		}
+		if cookie == nil {
+			t.Fatal("no session cookie")
+		}
+		if !cookie.Secure || !cookie.HttpOnly || cookie.SameSite != http.SameSiteLaxMode || cookie.Path != "/" {
+			t.Errorf("got cookie %v, want a secure HTTP only lax one", cookie)
+		}
+		if left := time.Until(cookie.Expires); left <= 0 || left > srv.SessionTTL {
+			t.Errorf("got cookie expiring in %v, want within the session TTL", left)
+		}
+
+		// the cookie is a session of the user
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, authRequest(srv, http.MethodGet, "/account/tokens", nil, cookie.Value))
+		if w.Code != http.StatusOK {
+			t.Errorf("with the session: got status %d", w.Code)
+		}
+	})
+}
+
+func TestLogout(t *testing.T) {
+	forEachService(t, func(t *testing.T, srv *Service) {
+		srv.Templ = template.Must(template.ParseGlob("../../templ/*.html"))
+		mux := chi.NewMux()
+		srv.BuildRoutes(mux)
+		owner := newUser(t, srv, "ann", user.RoleReader)
+		sessionToken := newSession(t, srv, owner, time.Now().Add(time.Hour))
+		// sessions on other devices are kept
+		newSession(t, srv, owner, time.Now().Add(time.Hour))
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, authRequest(srv, http.MethodPost, "/logout", nil, sessionToken))
+		if w.Code != http.StatusSeeOther {
+			t.Fatalf("got status %d", w.Code)
+		}
+		if cookies := w.Result().Cookies(); len(cookies) != 1 || cookies[0].Name != sessionCookie || cookies[0].MaxAge >= 0 {
+			t.Errorf("got cookies %v, want the session removed", cookies)
+		}
+		if n := sessionCount(t, srv); n != 1 {
+			t.Errorf("got %d sessions, want the other device's one", n)
+		}
+
+		// a copy of the cookie doesn't work anymore
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, authRequest(srv, http.MethodGet, "/account/tokens", nil, sessionToken))
+		if w.Code != http.StatusSeeOther || !strings.HasPrefix(w.Header().Get("Location"), "/login?") {
+			t.Errorf("got status %d to %q, want the login page", w.Code, w.Header().Get("Location"))
+		}
+	})
+}
+
+func TestLoggedOutChanges(t *testing.T) {
+	forEachService(t, func(t *testing.T, srv *Service) {
+		srv.Templ = template.Must(template.ParseGlob("../../templ/*.html"))
+		mux := chi.NewMux()
+		srv.BuildRoutes(mux)
+		librarian := newUser(t, srv, "ann", user.RoleLibrarian)
+		expired := newSession(t, srv, librarian, time.Now().Add(-time.Minute))
+
+		tests := []struct {
+			name         string
+			path         string
+			sessionToken string
+			accept       string
+			status       int
+		}{
+			{name: "upload", path: "/books", status: http.StatusUnauthorized},
+			{name: "upload in browser", path: "/books", accept: "text/html", status: http.StatusSeeOther},
+			{name: "upload with expired session", path: "/books", sessionToken: expired, status: http.StatusUnauthorized},
+			{name: "author", path: "/authors", status: http.StatusUnauthorized},
+			{name: "author in browser", path: "/authors", accept: "text/html", status: http.StatusSeeOther},
+			{name: "author with expired session", path: "/authors", sessionToken: expired, accept: "text/html", status: http.StatusSeeOther},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				r := authRequest(srv, http.MethodPost, tc.path, url.Values{"title": {"Poems"}, "name": {"Ann"}}, tc.sessionToken)
+				if tc.accept != "" {
+					r.Header.Set("Accept", tc.accept)
+				}
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, r)
+
+				if w.Code != tc.status {
+					t.Errorf("got status %d, want %d", w.Code, tc.status)
+				}
+				wantLocation := ""
+				if tc.status == http.StatusSeeOther {
+					wantLocation = "/login?" + url.Values{"next": {tc.path}}.Encode()
+				}
+				if got := w.Header().Get("Location"); got != wantLocation {
+					t.Errorf("location: got %q, want %q", got, wantLocation)
+				}
+			})
+		}
+
+		// nothing is created
+		if n, err := srv.Storage.Author.Query().Count(context.Background()); err != nil || n != 0 {
+			t.Errorf("got %d authors, %v", n, err)
+		}
+		if n, err := srv.Storage.Book.Query().Count(context.Background()); err != nil || n != 0 {
+			t.Errorf("got %d books, %v", n, err)
+		}
+	})
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+// loanPeriod is the due date offered for new loans.
+const loanPeriod = 14 * 24 * time.Hour
+
+type copyView struct {
+	ID        int64
+	Barcode   string
+	Location  string
+	Condition bookcopy.Condition
+	// Loan is the open loan, nil if the copy is available.
+	Loan *ent.Loan
+	Due  string
+	// Hold is the ready hold the copy is set aside for.
+	Hold        *ent.Hold
+	HoldExpires string
+}
+
+type bookPageView struct {
+	Book      *ent.Book
+	Year      int
+	Copies    []copyView
+	Available int
+	// Waiting is the length of the holds queue.
+	Waiting    int
+	Conditions []bookcopy.Condition
+	// Devices of the current user the book can be sent to,
+	// SendEnabled is false for anonymous users or without mail.
+	Devices     []*ent.Device
+	SendEnabled bool
+	// Shelves of the current user, nil for anonymous users.
+	Shelves []bookShelf
+	// Status is the kind of the status shelf holding the book.
+	Status   shelf.Kind
+	Statuses []shelfOption
+	// Rating is the average of visible reviews, empty without them.
+	Rating  string
+	Reviews []reviewView
+	// MyReview is the review of the current user, if any.
+	MyReview *ent.Review
+	Ratings  []int
+	// Reading is the progress synced by reading apps of the current user.
+	Reading *readingView
+	// Readable tells if the book can be opened in the web reader.
+	Readable bool
+	// Revisions of the metadata, newest first, are shown to editors.
+	Revisions []revisionView
+	page
+}
+
+type bookShelf struct {
+	ID   int64
+	Name string
+	// Has tells if the book is on the shelf.
+	Has bool
+}
+
+// getBook shows the book with its paper copies and their availability.
+func (srv *Service) getBook(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	copies, err := found.QueryCopies().
+		WithLoans(func(query *ent.LoanQuery) {
+			query.Where(loan.ReturnedAtIsNil()).WithBorrower()
+		}).
+		WithHolds(func(query *ent.HoldQuery) {
+			query.Where(hold.StatusEQ(hold.StatusReady)).WithUser()
+		}).
+		Order(ent.Asc(bookcopy.FieldBarcode)).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	waiting, errWaiting := found.QueryHolds().
+		Where(hold.StatusEQ(hold.StatusWaiting)).
+		Count(ctx)
+	if errWaiting != nil {
+		http.Error(w, "db: "+errWaiting.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &bookPageView{
+		Book:       found,
+		Year:       time.Unix(found.WrittenAt, 0).Year(),
+		Waiting:    waiting,
+		Conditions: []bookcopy.Condition{bookcopy.ConditionNew, bookcopy.ConditionGood, bookcopy.ConditionWorn, bookcopy.ConditionDamaged},
+		Ratings:    []int{5, 4, 3, 2, 1},
+		Readable:   files.Ext(found.FileID) == "fb2",
+		page:       srv.page(w, r),
+	}
+	if current := currentUser(ctx); current != nil {
+		if !srv.bookShelves(w, r, current, data) {
+			return
+		}
+		progress, errProgress := srv.library().BookProgress(ctx, current.ID, found)
+		if errProgress != nil {
+			http.Error(w, errProgress.Error(), http.StatusInternalServerError)
+			return
+		}
+		if progress != nil {
+			data.Reading = newReadingView(progress)
+		}
+	}
+	if !srv.bookReviews(w, r, data) {
+		return
+	}
+	if !srv.bookRevisions(w, r, data) {
+		return
+	}
+
+	if current := currentUser(ctx); current != nil && srv.Outbox != nil {
+		data.SendEnabled = true
+		data.Devices, err = current.QueryDevices().
+			Order(ent.Asc(device.FieldName)).
+			All(ctx)
+		if err != nil {
+			http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	for _, item := range copies {
+		view := copyView{
+			ID:        item.ID,
+			Barcode:   item.Barcode,
+			Location:  item.Location,
+			Condition: item.Condition,
+		}
+		switch {
+		case len(item.Edges.Loans) > 0:
+			view.Loan = item.Edges.Loans[0]
+			view.Due = time.Unix(view.Loan.DueAt, 0).Format(time.DateOnly)
+		case len(item.Edges.Holds) > 0:
+			view.Hold = item.Edges.Holds[0]
+			view.HoldExpires = formatTime(*view.Hold.ExpiresAt)
+		default:
+			data.Available++
+		}
+		data.Copies = append(data.Copies, view)
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "book.html", data); err != nil {
+		log.Printf("ERROR: book.html: %s", err)
+		return
+	}
+}
+
+// bookShelves fills shelves of the user for the book page.
+func (srv *Service) bookShelves(w http.ResponseWriter, r *http.Request, current *ent.User, data *bookPageView) bool {
+	shelves, err := current.QueryShelves().
+		WithEntries(func(query *ent.ShelfEntryQuery) {
+			query.Where(shelfentry.HasBookWith(book.ID(data.Book.ID)))
+		}).
+		Order(ent.Asc(shelf.FieldName)).
+		All(r.Context())
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	data.Shelves = []bookShelf{}
+	for _, kind := range library.StatusShelves {
+		data.Statuses = append(data.Statuses, shelfOption{Value: string(kind), Name: library.StatusNames[kind]})
+	}
+	for _, item := range shelves {
+		has := len(item.Edges.Entries) > 0
+		if item.Kind != shelf.KindCustom {
+			if has {
+				data.Status = item.Kind
+			}
+			continue
+		}
+		data.Shelves = append(data.Shelves, bookShelf{ID: item.ID, Name: item.Name, Has: has})
+	}
+	return true
+}
+
+type copyForm struct {
+	Barcode   string             `schema:"barcode"`
+	Location  string             `schema:"location"`
+	Condition bookcopy.Condition `schema:"condition"`
+}
+
+func (srv *Service) addCopy(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	bookPage := "/books/" + strconv.FormatInt(id, 10)
+
+	var form copyForm
+	if err := binder.Decode(&form, r.PostForm); err != nil {
+		srv.withError(w, r, bookPage, err)
+		return
+	}
+	if form.Condition == "" {
+		form.Condition = bookcopy.DefaultCondition
+	}
+	if err := bookcopy.ConditionValidator(form.Condition); err != nil {
+		srv.withError(w, r, bookPage, err)
+		return
+	}
+
+	errCreate := srv.Storage.BookCopy.Create().
+		SetBookID(id).
+		SetBarcode(form.Barcode).
+		SetLocation(form.Location).
+		SetCondition(form.Condition).
+		Exec(r.Context())
+	switch {
+	case ent.IsValidationError(errCreate):
+		srv.withError(w, r, bookPage, errors.New("barcode is required"))
+		return
+	case ent.IsConstraintError(errCreate):
+		srv.withError(w, r, bookPage, fmt.Errorf("barcode %q is already used or the book is deleted", form.Barcode))
+		return
+	case errCreate != nil:
+		http.Error(w, "db: "+errCreate.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, bookPage, http.StatusSeeOther)
+}
+
+type circulationView struct {
+	Barcode    string
+	DefaultDue string
+	// Loans are all open loans.
+	Loans []*ent.Loan
+	now   time.Time
+	page
+}
+
+func (view *circulationView) Due(item *ent.Loan) string {
+	return time.Unix(item.DueAt, 0).Format(time.DateOnly)
+}
+
+func (view *circulationView) Overdue(item *ent.Loan) bool {
+	return item.DueAt < view.now.Unix()
+}
+
+func (srv *Service) getCirculation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	loans, err := srv.Storage.Loan.Query().
+		Where(loan.ReturnedAtIsNil()).
+		WithCopy(func(query *ent.BookCopyQuery) { query.WithBook() }).
+		WithBorrower().
+		Order(ent.Asc(loan.FieldDueAt)).
+		All(intercept.SkipSoftDelete(ctx))
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &circulationView{
+		Barcode:    r.URL.Query().Get("barcode"),
+		DefaultDue: time.Now().Add(loanPeriod).Format(time.DateOnly),
+		Loans:      loans,
+		now:        time.Now(),
+		page:       srv.page(w, r),
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "circulation.html", data); err != nil {
+		log.Printf("ERROR: circulation.html: %s", err)
+		return
+	}
+}
+
+type checkOutForm struct {
+	Barcode  string `schema:"barcode"`
+	Borrower string `schema:"borrower"`
+	Due      Date   `schema:"due"`
+}
+
+func (srv *Service) checkOut(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var form checkOutForm
+	if err := binder.Decode(&form, r.PostForm); err != nil {
+		srv.withError(w, r, "/circulation", err)
+		return
+	}
+	due := form.Due.Time
+	if due.IsZero() {
+		due = time.Now().Add(loanPeriod)
+	} else {
+		// the copy is due by the end of the day
+		due = due.Add(24*time.Hour - time.Second)
+	}
+
+	_, err := srv.library().CheckOut(r.Context(), form.Barcode, form.Borrower, due)
+	switch {
+	case errors.Is(err, library.ErrCopyNotFound),
+		errors.Is(err, library.ErrBorrowerNotFound),
+		errors.Is(err, library.ErrOnLoan),
+		errors.Is(err, library.ErrReserved),
+		errors.Is(err, library.ErrDueInPast):
+		srv.withError(w, r, "/circulation", fmt.Errorf("%s: %w", form.Barcode, err))
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.setFlash(w, fmt.Sprintf("%s is checked out to %s until %s", form.Barcode, form.Borrower, due.Format(time.DateOnly)))
+	http.Redirect(w, r, "/circulation", http.StatusSeeOther)
+}
+
+func (srv *Service) checkIn(w http.ResponseWriter, r *http.Request) {
+	barcode := r.PostFormValue("barcode")
+
+	err := srv.library().CheckIn(r.Context(), barcode)
+	switch {
+	case errors.Is(err, library.ErrCopyNotFound), errors.Is(err, library.ErrNotOnLoan):
+		srv.withError(w, r, "/circulation", fmt.Errorf("%s: %w", barcode, err))
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.setFlash(w, barcode+" is checked in")
+	http.Redirect(w, r, "/circulation", http.StatusSeeOther)
+}
+
+type overdueView struct {
+	Loans []*ent.Loan
+	Now   time.Time
+	page
+}
+
+// DaysOverdue returns the number of full days since the loan was due.
+func (view *overdueView) DaysOverdue(item *ent.Loan) int {
+	return int(view.Now.Sub(time.Unix(item.DueAt, 0)) / (24 * time.Hour))
+}
+
+func (srv *Service) getOverdue(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	loans, err := srv.library().Overdue(r.Context(), now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &overdueView{
+		Loans: loans,
+		Now:   now,
+		page:  srv.page(w, r),
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "overdue.html", data); err != nil {
+		log.Printf("ERROR: overdue.html: %s", err)
+		return
+	}
+}
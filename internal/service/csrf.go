@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ninedraft/bibliotheca/internal/auth"
+)
+
+const (
+	csrfCookie = "csrf"
+	csrfField  = "csrf_token"
+	csrfHeader = "X-CSRF-Token"
+	// csrfPurpose prefixes seeds as MAC inputs, see auth.Signer.
+	csrfPurpose = "csrf"
+)
+
+type csrfKey struct{}
+
+// csrfMW rejects unsafe requests without a form token matching the csrf
+// cookie. The cookie holds a random seed and the form token is its MAC,
+// so a cookie planted by another site doesn't help to forge a token.
+// The MAC input is prefixed, so MACs of other values like signed flash
+// messages aren't tokens.
+// Requests with a Bearer token are exempt, browsers never send one on their own.
+func (srv *Service) csrfMW(next http.Handler) http.Handler {
+	var handle http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		var seed string
+		if cookie, err := r.Cookie(csrfCookie); err == nil && cookie.Value != "" {
+			seed = cookie.Value
+		} else {
+			var errSeed error
+			seed, errSeed = auth.NewToken()
+			if errSeed != nil {
+				http.Error(w, errSeed.Error(), http.StatusInternalServerError)
+				return
+			}
+			http.SetCookie(w, &http.Cookie{
+				Name:     csrfCookie,
+				Value:    seed,
+				Path:     "/",
+				HttpOnly: true,
+				Secure:   srv.SecureCookie,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+
+		if !isSafeMethod(r.Method) && !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			token := r.Header.Get(csrfHeader)
+			if token == "" {
+				token = r.PostFormValue(csrfField)
+			}
+			if !srv.Signer.CheckMAC(csrfPurpose+":"+seed, token) {
+				log.Printf("%s %s: CSRF token mismatch", r.Method, r.URL.Path)
+				http.Error(w, "invalid form token, reload the page and try again", http.StatusForbidden)
+				return
+			}
+		}
+
+		ctx := context.WithValue(r.Context(), csrfKey{}, srv.Signer.MAC(csrfPurpose+":"+seed))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return handle
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// csrfToken returns the form token for the request.
+func csrfToken(ctx context.Context) string {
+	token, _ := ctx.Value(csrfKey{}).(string)
+	return token
+}
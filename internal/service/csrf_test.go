@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/internal/auth"
+)
+
+func TestCSRF(t *testing.T) {
+	srv := &Service{Signer: &auth.Signer{Key: []byte("0123456789abcdef0123456789abcdef")}}
+	var token string
+	handler := srv.csrfMW(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = csrfToken(r.Context())
+	}))
+
+	// the first visit sets the seed cookie
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := w.Result().Cookies()
+	if w.Code != http.StatusOK || len(cookies) != 1 || cookies[0].Name != csrfCookie || token == "" {
+		t.Fatalf("got status %d, cookies %v, token %q", w.Code, cookies, token)
+	}
+	seed := cookies[0]
+
+	other := &http.Cookie{Name: csrfCookie, Value: "planted"}
+	// signed flash messages are MACs of values the user can choose
+	flash := httptest.NewRecorder()
+	srv.setFlash(flash, "upload failed: chosen.fb2")
+	encoded, flashMAC, _ := strings.Cut(flash.Result().Cookies()[0].Value, ".")
+	planted := &http.Cookie{Name: csrfCookie, Value: encoded}
+	tests := []struct {
+		name   string
+		method string
+		cookie *http.Cookie
+		form   string
+		header map[string]string
+		status int
+	}{
+		{name: "form token", method: http.MethodPost, cookie: seed, form: token, status: http.StatusOK},
+		{name: "header token", method: http.MethodDelete, cookie: seed, header: map[string]string{csrfHeader: token}, status: http.StatusOK},
+		{name: "no token", method: http.MethodPost, cookie: seed, status: http.StatusForbidden},
+		{name: "wrong token", method: http.MethodPost, cookie: seed, form: token + "x", status: http.StatusForbidden},
+		{name: "no cookie", method: http.MethodPost, form: token, status: http.StatusForbidden},
+		// the seed alone is not a token
+		{name: "seed as token", method: http.MethodPost, cookie: other, form: other.Value, status: http.StatusForbidden},
+		{name: "flash signature as token", method: http.MethodPost, cookie: planted, form: flashMAC, status: http.StatusForbidden},
+		{name: "bearer", method: http.MethodPost, header: map[string]string{"Authorization": "Bearer secret"}, status: http.StatusOK},
+		{name: "basic", method: http.MethodPost, header: map[string]string{"Authorization": "Basic c2VjcmV0"}, status: http.StatusForbidden},
+		{name: "safe method", method: http.MethodHead, status: http.StatusOK},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			form := url.Values{}
+			if tc.form != "" {
+				form.Set(csrfField, tc.form)
+			}
+			r := httptest.NewRequest(tc.method, "/books/1/delete", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			for name, value := range tc.header {
+				r.Header.Set(name, value)
+			}
+			if tc.cookie != nil {
+				r.AddCookie(tc.cookie)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tc.status {
+				t.Errorf("status: got %d, want %d", w.Code, tc.status)
+			}
+		})
+	}
+}
+
+func TestFlash(t *testing.T) {
+	srv := &Service{Signer: &auth.Signer{Key: []byte("0123456789abcdef0123456789abcdef")}}
+
+	w := httptest.NewRecorder()
+	srv.withError(w, httptest.NewRequest(http.MethodPost, "/upload", nil), "/books", errors.New("no file"))
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/books" {
+		t.Fatalf("got status %d to %q", w.Code, w.Header().Get("Location"))
+	}
+	flash := w.Result().Cookies()[0]
+
+	r := httptest.NewRequest(http.MethodGet, "/books", nil)
+	r.AddCookie(flash)
+	w = httptest.NewRecorder()
+	if got := srv.popFlash(w, r); got != "no file" {
+		t.Errorf("got %q, want the error", got)
+	}
+	// the message is shown once
+	if removed := w.Result().Cookies(); len(removed) != 1 || removed[0].MaxAge >= 0 {
+		t.Errorf("got cookies %v, want the flash removed", removed)
+	}
+
+	// messages of other sites are not shown
+	r = httptest.NewRequest(http.MethodGet, "/books", nil)
+	r.AddCookie(&http.Cookie{Name: flashCookie, Value: (&auth.Signer{Key: []byte("other")}).Sign(flashPurpose, "phishing")})
+	if got := srv.popFlash(httptest.NewRecorder(), r); got != "" {
+		t.Errorf("got %q from a foreign cookie", got)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/mail"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+type devicesView struct {
+	Devices    []*ent.Device
+	Deliveries []*ent.Delivery
+	Kinds      []device.Kind
+	// From is the sender address, Kindle accepts mail
+	// only from approved ones.
+	From        string
+	MailEnabled bool
+	maxAttempts int
+	page
+}
+
+func (view *devicesView) List() []any {
+	type deliveryView struct {
+		Book   *ent.Book
+		To     string
+		Format string
+		Date   string
+		Status string
+		Error  string
+	}
+
+	var list []any
+	for _, item := range view.Deliveries {
+		row := deliveryView{
+			Book:   item.Edges.Book,
+			To:     item.To,
+			Format: item.Format,
+			Date:   formatTime(item.CreatedAt),
+			Status: "failed",
+			Error:  item.Error,
+		}
+		if msg := item.Edges.Message; msg != nil {
+			row.Error = msg.LastError
+			switch {
+			case msg.SentAt != nil:
+				row.Status, row.Error = "sent", ""
+			case msg.Attempts < view.maxAttempts:
+				row.Status = "sending"
+			}
+		}
+		list = append(list, row)
+	}
+	return list
+}
+
+// recentDeliveries limits the history shown on the devices page.
+const recentDeliveries = 20
+
+func (srv *Service) listDevices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	current := currentUser(ctx)
+
+	devices, errDevices := srv.Storage.Device.Query().
+		Where(device.HasUserWith(user.ID(current.ID))).
+		Order(ent.Asc(device.FieldName)).
+		All(ctx)
+	if errDevices != nil {
+		http.Error(w, "db: "+errDevices.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	deliveries, errDeliveries := srv.Storage.Delivery.Query().
+		Where(
+			delivery.HasUserWith(user.ID(current.ID)),
+			delivery.HasBookWith(book.DeletedAtIsNil()),
+		).
+		WithBook().
+		WithMessage().
+		Order(ent.Desc(delivery.FieldID)).
+		Limit(recentDeliveries).
+		All(ctx)
+	if errDeliveries != nil {
+		http.Error(w, "db: "+errDeliveries.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &devicesView{
+		Devices:     devices,
+		Deliveries:  deliveries,
+		Kinds:       []device.Kind{device.KindKindle, device.KindOther},
+		MailEnabled: srv.Outbox != nil,
+		page:        srv.page(w, r),
+	}
+	if srv.Outbox != nil {
+		data.maxAttempts = srv.Outbox.MaxAttempts
+		if sender, ok := srv.Outbox.Sender.(*notify.SMTP); ok {
+			data.From = sender.From
+		}
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "devices.html", data); err != nil {
+		log.Printf("ERROR: devices.html: %s", err)
+		return
+	}
+}
+
+type deviceForm struct {
+	Name  string      `schema:"name"`
+	Email string      `schema:"email"`
+	Kind  device.Kind `schema:"kind"`
+}
+
+var errDeviceExists = errors.New("the device is already added")
+
+func (srv *Service) addDevice(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var form deviceForm
+	if err := binder.Decode(&form, r.PostForm); err != nil {
+		srv.withError(w, r, "/account/devices", err)
+		return
+	}
+	address, errAddress := mail.ParseAddress(form.Email)
+	if errAddress != nil || address.Name != "" {
+		srv.withError(w, r, "/account/devices", errBadEmail)
+		return
+	}
+	if form.Name == "" {
+		form.Name = address.Address
+	}
+	if err := device.KindValidator(form.Kind); err != nil {
+		srv.withError(w, r, "/account/devices", err)
+		return
+	}
+
+	errCreate := srv.Storage.Device.Create().
+		SetName(form.Name).
+		SetEmail(address.Address).
+		SetKind(form.Kind).
+		SetUser(currentUser(r.Context())).
+		Exec(r.Context())
+	switch {
+	case ent.IsConstraintError(errCreate):
+		srv.withError(w, r, "/account/devices", errDeviceExists)
+		return
+	case errCreate != nil:
+		http.Error(w, "db: "+errCreate.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/account/devices", http.StatusSeeOther)
+}
+
+func (srv *Service) deleteDevice(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	_, errDelete := srv.Storage.Device.Delete().
+		Where(device.ID(id), device.HasUserWith(user.ID(currentUser(r.Context()).ID))).
+		Exec(r.Context())
+	if errDelete != nil {
+		http.Error(w, "db: "+errDelete.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/account/devices", http.StatusSeeOther)
+}
+
+// sendToDevice mails the book to a device of the current user.
+func (srv *Service) sendToDevice(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	deviceID, errDevice := strconv.ParseInt(r.PostFormValue("device"), 10, 64)
+	if errDevice != nil {
+		http.Error(w, "form: device is required", http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	bookPage := "/books/" + strconv.FormatInt(id, 10)
+
+	sent, err := srv.library().SendToDevice(ctx, currentUser(ctx).ID, deviceID, id)
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		http.NotFound(w, r)
+		return
+	// the failed delivery is recorded
+	case sent != nil && err != nil,
+		errors.Is(err, library.ErrDeviceNotFound),
+		errors.Is(err, library.ErrNoFile),
+		errors.Is(err, library.ErrMailDisabled):
+		srv.withError(w, r, bookPage, err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.setFlash(w, "the book is queued for sending to "+sent.To)
+	http.Redirect(w, r, bookPage, http.StatusSeeOther)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	flashCookie = "flash"
+	flashMaxAge = time.Minute
+	// flashPurpose keeps signed messages apart from other MACs.
+	flashPurpose = "flash"
+)
+
+// page holds values shared by page templates.
+type page struct {
+	access
+	// CSRF is the token every POST form must send as csrf_token.
+	CSRF string
+	// Flash is a message left by the previous request.
+	Flash string
+}
+
+// page collects the shared template values, the flash message
+// is removed, so it's shown once.
+func (srv *Service) page(w http.ResponseWriter, r *http.Request) page {
+	ctx := r.Context()
+	return page{
+		access: srv.access(ctx),
+		CSRF:   csrfToken(ctx),
+		Flash:  srv.popFlash(w, r),
+	}
+}
+
+// withError redirects to the page, which shows the error as a flash message.
+func (srv *Service) withError(w http.ResponseWriter, r *http.Request, to string, err error) {
+	if err == nil {
+		return
+	}
+	srv.setFlash(w, err.Error())
+	http.Redirect(w, r, to, http.StatusSeeOther)
+}
+
+// setFlash leaves a message for the next page. The cookie is signed,
+// so other sites can't make the library show their text.
+func (srv *Service) setFlash(w http.ResponseWriter, msg string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     flashCookie,
+		Value:    srv.Signer.Sign(flashPurpose, msg),
+		Path:     "/",
+		MaxAge:   int(flashMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   srv.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (srv *Service) popFlash(w http.ResponseWriter, r *http.Request) string {
+	cookie, errCookie := r.Cookie(flashCookie)
+	if errCookie != nil {
+		return ""
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     flashCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   srv.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	msg, errVerify := srv.Signer.Verify(flashPurpose, cookie.Value)
+	if errVerify != nil {
+		return ""
+	}
+	return msg
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/ninedraft/bibliotheca/internal/convert"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/files"
+)
+
+// chapterSanitizer cleans chapters of the web reader. The converter escapes
+// the text, but links of the book may carry scripts. Anchors, ids of link
+// targets and inlined images are kept.
+var chapterSanitizer = func() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("id", "class", "data-anchor").Globally()
+	policy.AllowDataURIImages()
+	return policy
+}()
+
+var highlightColors = []highlight.Color{highlight.ColorYellow, highlight.ColorGreen, highlight.ColorBlue, highlight.ColorPink}
+
+// maxHighlightBody limits requests of the highlights API.
+const maxHighlightBody = 64 << 10
+
+type chapterOption struct {
+	Number int
+	Title  string
+}
+
+type readerView struct {
+	Book     *ent.Book
+	Chapters []chapterOption
+	// Chapter is the number of the shown chapter, from 1.
+	Chapter    int
+	Title      string
+	Text       template.HTML
+	Prev, Next int
+	Colors     []highlight.Color
+	page
+}
+
+// readBook shows a chapter of the FB2 book with highlights of the user.
+func (srv *Service) readBook(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+	bookPage := "/books/" + strconv.FormatInt(found.ID, 10)
+	if files.Ext(found.FileID) != "fb2" {
+		srv.withError(w, r, bookPage, library.ErrNotFB2)
+		return
+	}
+
+	file, errOpen := srv.Files.Open(found.FileID)
+	if errOpen != nil {
+		http.Error(w, "files: "+errOpen.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	chapters, errConvert := convert.FB2ToHTML(file)
+	if errConvert != nil {
+		log.Printf("ERROR: book %d: read: %v", found.ID, errConvert)
+		srv.withError(w, r, bookPage, errConvert)
+		return
+	}
+	if len(chapters) == 0 {
+		srv.withError(w, r, bookPage, library.ErrNotFB2)
+		return
+	}
+
+	n, errChapter := strconv.Atoi(r.URL.Query().Get("chapter"))
+	if errChapter != nil || n < 1 || n > len(chapters) {
+		n = 1
+	}
+	chapter := chapters[n-1]
+
+	data := &readerView{
+		Book:    found,
+		Chapter: n,
+		Title:   chapter.Title,
+		Text:    template.HTML(chapterSanitizer.Sanitize(chapter.HTML)),
+		Colors:  highlightColors,
+		page:    srv.page(w, r),
+	}
+	for i, item := range chapters {
+		data.Chapters = append(data.Chapters, chapterOption{Number: i + 1, Title: item.Title})
+	}
+	if n > 1 {
+		data.Prev = n - 1
+	}
+	if n < len(chapters) {
+		data.Next = n + 1
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "reader.html", data); err != nil {
+		log.Printf("ERROR: reader.html: %s", err)
+		return
+	}
+}
+
+type highlightJSON struct {
+	ID        int64           `json:"id"`
+	Anchor    string          `json:"anchor"`
+	Start     int             `json:"start"`
+	End       int             `json:"end"`
+	Text      string          `json:"text"`
+	Chapter   string          `json:"chapter,omitempty"`
+	Note      string          `json:"note,omitempty"`
+	Color     highlight.Color `json:"color"`
+	CreatedAt int64           `json:"created_at"`
+	UpdatedAt int64           `json:"updated_at"`
+}
+
+func newHighlightJSON(item *ent.Highlight) highlightJSON {
+	return highlightJSON{
+		ID:        item.ID,
+		Anchor:    item.Anchor,
+		Start:     item.Start,
+		End:       item.End,
+		Text:      item.Text,
+		Chapter:   item.Chapter,
+		Note:      item.Note,
+		Color:     item.Color,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("ERROR: json: %s", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// listHighlights returns highlights of the current user in the book.
+func (srv *Service) listHighlights(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+
+	found, err := srv.library().Highlights(ctx, currentUser(ctx).ID, id)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	items := make([]highlightJSON, 0, len(found))
+	for _, item := range found {
+		items = append(items, newHighlightJSON(item))
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+type highlightForm struct {
+	Anchor string          `json:"anchor"`
+	Start  int             `json:"start"`
+	End    int             `json:"end"`
+	Note   string          `json:"note"`
+	Color  highlight.Color `json:"color"`
+}
+
+// createHighlight saves the highlight sent by the reader as JSON,
+// the text is taken from the book file.
+func (srv *Service) createHighlight(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var form highlightForm
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxHighlightBody)).Decode(&form); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "json: "+err.Error())
+		return
+	}
+	ctx := r.Context()
+
+	created, err := srv.library().AddHighlight(ctx, currentUser(ctx).ID, id, &library.NewHighlight{
+		Anchor: form.Anchor,
+		Start:  form.Start,
+		End:    form.End,
+		Note:   strings.TrimSpace(form.Note),
+		Color:  form.Color,
+	})
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	case errors.Is(err, library.ErrNotFB2), errors.Is(err, convert.ErrBadAnchor), ent.IsValidationError(err):
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newHighlightJSON(created))
+}
+
+type noteView struct {
+	ID   int64
+	Text string
+	// Note is the Markdown source, Rendered is the HTML shown.
+	Note     string
+	Rendered template.HTML
+	Color    highlight.Color
+	Chapter  string
+	Date     string
+}
+
+type notesView struct {
+	Book   *ent.Book
+	Notes  []noteView
+	Colors []highlight.Color
+	// Readable tells if the book can be opened in the web reader.
+	Readable bool
+	page
+}
+
+// getNotes shows highlights and notes of the current user in the book.
+func (srv *Service) getNotes(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	highlights, err := srv.library().Highlights(ctx, currentUser(ctx).ID, found.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &notesView{
+		Book:     found,
+		Colors:   highlightColors,
+		Readable: files.Ext(found.FileID) == "fb2",
+		page:     srv.page(w, r),
+	}
+	for _, item := range highlights {
+		data.Notes = append(data.Notes, noteView{
+			ID:       item.ID,
+			Text:     item.Text,
+			Note:     item.Note,
+			Rendered: renderMarkdown(item.Note),
+			Color:    item.Color,
+			Chapter:  item.Chapter,
+			Date:     formatTime(item.UpdatedAt),
+		})
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "notes.html", data); err != nil {
+		log.Printf("ERROR: notes.html: %s", err)
+		return
+	}
+}
+
+type noteForm struct {
+	Note  string          `schema:"note"`
+	Color highlight.Color `schema:"color"`
+	Next  string          `schema:"next"`
+}
+
+// updateHighlight changes the note and the color of the highlight
+// and returns to the next page.
+func (srv *Service) updateHighlight(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var form noteForm
+	if err := binder.Decode(&form, r.PostForm); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	next := localPath(form.Next, "/books")
+
+	_, err := srv.library().UpdateHighlight(ctx, currentUser(ctx).ID, id, strings.TrimSpace(form.Note), form.Color)
+	switch {
+	case errors.Is(err, library.ErrHighlightNotFound):
+		http.NotFound(w, r)
+		return
+	case ent.IsValidationError(err):
+		srv.withError(w, r, next, err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, next, http.StatusSeeOther)
+}
+
+func (srv *Service) deleteHighlight(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+
+	err := srv.library().DeleteHighlight(ctx, currentUser(ctx).ID, id)
+	switch {
+	case errors.Is(err, library.ErrHighlightNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, localPath(r.PostFormValue("next"), "/books"), http.StatusSeeOther)
+}
+
+// exportHighlights downloads highlights of the current user as Markdown,
+// of one book if the book parameter is set.
+func (srv *Service) exportHighlights(w http.ResponseWriter, r *http.Request) {
+	var bookID int64
+	if param := r.URL.Query().Get("book"); param != "" {
+		id, errID := strconv.ParseInt(param, 10, 64)
+		if errID != nil {
+			http.NotFound(w, r)
+			return
+		}
+		bookID = id
+	}
+	ctx := r.Context()
+
+	highlights, err := srv.library().Highlights(ctx, currentUser(ctx).ID, bookID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	name := "highlights.md"
+	if bookID != 0 && len(highlights) > 0 {
+		name = highlights[0].Edges.Book.Title + ".md"
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	if err := library.ExportHighlights(w, highlights); err != nil {
+		log.Printf("ERROR: export highlights: %s", err)
+	}
+}
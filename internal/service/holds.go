@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// holdsInterval is how often ready holds are checked for expiration.
+const holdsInterval = 10 * time.Minute
+
+func (srv *Service) expireHolds(ctx context.Context) error {
+	n, err := srv.library().ExpireHolds(ctx, time.Now())
+	if n > 0 {
+		log.Printf("expired %d holds", n)
+	}
+	return err
+}
+
+func (srv *Service) placeHold(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+	bookPage := "/books/" + strconv.FormatInt(id, 10)
+
+	placed, err := srv.library().PlaceHold(ctx, id, currentUser(ctx).ID)
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		http.NotFound(w, r)
+		return
+	case errors.Is(err, library.ErrNoCopies), errors.Is(err, library.ErrHoldExists):
+		srv.withError(w, r, bookPage, err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if placed.Status == hold.StatusReady {
+		srv.setFlash(w, "a copy is set aside for you until "+formatTime(*placed.ExpiresAt))
+	} else {
+		position, errPosition := srv.library().QueuePosition(ctx, placed)
+		if errPosition != nil {
+			http.Error(w, errPosition.Error(), http.StatusInternalServerError)
+			return
+		}
+		srv.setFlash(w, fmt.Sprintf("you are #%d in the queue", position))
+	}
+	http.Redirect(w, r, bookPage, http.StatusSeeOther)
+}
+
+func (srv *Service) cancelHold(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+
+	err := srv.library().CancelHold(ctx, id, currentUser(ctx).ID)
+	switch {
+	case errors.Is(err, library.ErrHoldNotActive):
+		srv.withError(w, r, "/holds", err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/holds", http.StatusSeeOther)
+}
+
+type holdView struct {
+	ID       int64
+	Book     *ent.Book
+	Status   hold.Status
+	Position int
+	Barcode  string
+	Expires  string
+	// Active holds can be canceled.
+	Active bool
+}
+
+type holdsView struct {
+	Holds []holdView
+	page
+}
+
+// listHolds shows holds of the current user, the active ones first.
+// Paper copies of deleted books still circulate, so their holds are listed.
+func (srv *Service) listHolds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	holds, err := srv.Storage.Hold.Query().
+		Where(hold.HasUserWith(user.ID(currentUser(ctx).ID))).
+		WithBook().
+		WithCopy().
+		Order(ent.Desc(hold.FieldID)).
+		Limit(recentHolds).
+		All(intercept.SkipSoftDelete(ctx))
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &holdsView{page: srv.page(w, r)}
+	var inactive []holdView
+	for _, item := range holds {
+		view := holdView{
+			ID:     item.ID,
+			Book:   item.Edges.Book,
+			Status: item.Status,
+		}
+		switch item.Status {
+		case hold.StatusWaiting:
+			view.Active = true
+			view.Position, err = srv.library().QueuePosition(ctx, item)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		case hold.StatusReady:
+			view.Active = true
+			view.Expires = formatTime(*item.ExpiresAt)
+			if item.Edges.Copy != nil {
+				view.Barcode = item.Edges.Copy.Barcode
+			}
+		}
+		if view.Active {
+			data.Holds = append(data.Holds, view)
+		} else {
+			inactive = append(inactive, view)
+		}
+	}
+	data.Holds = append(data.Holds, inactive...)
+
+	if err := srv.Templ.ExecuteTemplate(w, "holds.html", data); err != nil {
+		log.Printf("ERROR: holds.html: %s", err)
+		return
+	}
+}
+
+// recentHolds limits the history shown on the holds page.
+const recentHolds = 50
+
+func formatTime(unix int64) string {
+	return time.Unix(unix, 0).Format("2006-01-02 15:04")
+}
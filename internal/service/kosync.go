@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+)
+
+// The KOReader progress sync API, as served by koreader-sync-server.
+// KOReader logs in with the login and a sync password set on the account
+// page, it sends MD5 of the password as the key. Documents are identified
+// by library.PartialMD5 of the file.
+
+const kosyncMediaType = "application/vnd.koreader.v1+json"
+
+// error codes of the protocol
+const (
+	kosyncUnknownError = 2000
+	kosyncUnauthorized = 2001
+	kosyncInvalid      = 2003
+	kosyncNoDocument   = 2004
+)
+
+// maxSyncBody limits request bodies of the sync API.
+const maxSyncBody = 64 << 10
+
+func (srv *Service) buildSyncRoutes(r chi.Router) {
+	r.Get("/healthcheck", srv.syncHealthcheck)
+	r.Post("/users/create", srv.syncRegister)
+	r.Group(func(r chi.Router) {
+		r.Use(srv.syncAuthMW)
+		r.Get("/users/auth", srv.syncAuth)
+		r.Put("/syncs/progress", srv.putProgress)
+		r.Get("/syncs/progress/{document}", srv.getProgress)
+	})
+}
+
+type syncError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeSyncJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", kosyncMediaType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("ERROR: sync: %s", err)
+	}
+}
+
+func writeSyncError(w http.ResponseWriter, status, code int, message string) {
+	writeSyncJSON(w, status, &syncError{Code: code, Message: message})
+}
+
+func (srv *Service) syncHealthcheck(w http.ResponseWriter, _ *http.Request) {
+	writeSyncJSON(w, http.StatusOK, map[string]string{"state": "OK"})
+}
+
+type syncUser struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// syncRegister doesn't create users, they are added by the library.
+// It succeeds for users who have set the same sync password,
+// so the register button of KOReader works as login.
+func (srv *Service) syncRegister(w http.ResponseWriter, r *http.Request) {
+	var form syncUser
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSyncBody)).Decode(&form); err != nil || form.Username == "" || form.Password == "" {
+		writeSyncError(w, http.StatusForbidden, kosyncInvalid, "Invalid request")
+		return
+	}
+
+	_, err := srv.library().SyncUser(r.Context(), form.Username, form.Password)
+	switch {
+	case errors.Is(err, library.ErrSyncUnauthorized):
+		writeSyncError(w, http.StatusForbidden, kosyncUnauthorized,
+			"Accounts are created by the library, set a sync password on its KOReader page and log in")
+		return
+	case err != nil:
+		log.Printf("ERROR: sync: %s", err)
+		writeSyncError(w, http.StatusInternalServerError, kosyncUnknownError, "Unknown server error")
+		return
+	}
+
+	writeSyncJSON(w, http.StatusCreated, map[string]string{"username": form.Username})
+}
+
+// syncAuthMW authenticates requests by the x-auth-user and x-auth-key headers.
+func (srv *Service) syncAuthMW(next http.Handler) http.Handler {
+	var handle http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		found, err := srv.library().SyncUser(r.Context(), r.Header.Get("x-auth-user"), r.Header.Get("x-auth-key"))
+		switch {
+		case errors.Is(err, library.ErrSyncUnauthorized):
+			writeSyncError(w, http.StatusUnauthorized, kosyncUnauthorized, "Unauthorized")
+			return
+		case err != nil:
+			log.Printf("ERROR: sync: %s", err)
+			writeSyncError(w, http.StatusInternalServerError, kosyncUnknownError, "Unknown server error")
+			return
+		case !auth.Allowed(found.Role, auth.ReadCatalog):
+			writeSyncError(w, http.StatusUnauthorized, kosyncUnauthorized, "Unauthorized")
+			return
+		}
+		ctx := context.WithValue(r.Context(), principalKey{}, &principal{user: found})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return handle
+}
+
+func (srv *Service) syncAuth(w http.ResponseWriter, _ *http.Request) {
+	writeSyncJSON(w, http.StatusOK, map[string]string{"authorized": "OK"})
+}
+
+type syncProgress struct {
+	Document   string  `json:"document"`
+	Progress   string  `json:"progress"`
+	Percentage float64 `json:"percentage"`
+	Device     string  `json:"device"`
+	DeviceID   string  `json:"device_id"`
+	Timestamp  int64   `json:"timestamp,omitempty"`
+}
+
+func (srv *Service) putProgress(w http.ResponseWriter, r *http.Request) {
+	var form syncProgress
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSyncBody)).Decode(&form); err != nil {
+		writeSyncError(w, http.StatusForbidden, kosyncInvalid, "Invalid request")
+		return
+	}
+	if form.Document == "" {
+		writeSyncError(w, http.StatusForbidden, kosyncNoDocument, "Field 'document' not provided.")
+		return
+	}
+	if form.Progress == "" || form.Device == "" || form.Percentage < 0 || form.Percentage > 1 {
+		writeSyncError(w, http.StatusForbidden, kosyncInvalid, "Invalid request")
+		return
+	}
+	ctx := r.Context()
+
+	saved, err := srv.library().SaveProgress(ctx, currentUser(ctx).ID, &library.Progress{
+		Document:   form.Document,
+		Progress:   form.Progress,
+		Percentage: form.Percentage,
+		Device:     form.Device,
+		DeviceID:   form.DeviceID,
+	})
+	if err != nil {
+		log.Printf("ERROR: sync: %s", err)
+		writeSyncError(w, http.StatusInternalServerError, kosyncUnknownError, "Unknown server error")
+		return
+	}
+
+	writeSyncJSON(w, http.StatusOK, map[string]any{
+		"document":  saved.Document,
+		"timestamp": saved.UpdatedAt,
+	})
+}
+
+// getProgress returns the progress in the document,
+// an empty object if there is none.
+func (srv *Service) getProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	found, err := srv.library().DocumentProgress(ctx, currentUser(ctx).ID, chi.URLParam(r, "document"))
+	switch {
+	case err != nil:
+		log.Printf("ERROR: sync: %s", err)
+		writeSyncError(w, http.StatusInternalServerError, kosyncUnknownError, "Unknown server error")
+		return
+	case found == nil:
+		writeSyncJSON(w, http.StatusOK, struct{}{})
+		return
+	}
+
+	writeSyncJSON(w, http.StatusOK, &syncProgress{
+		Document:   found.Document,
+		Progress:   found.Progress,
+		Percentage: found.Percentage,
+		Device:     found.Device,
+		DeviceID:   found.DeviceID,
+		Timestamp:  found.UpdatedAt,
+	})
+}
+
+type readingView struct {
+	Percent string
+	Device  string
+	Date    string
+}
+
+func newReadingView(progress *ent.ReadingProgress) *readingView {
+	return &readingView{
+		Percent: fmt.Sprintf("%.0f%%", progress.Percentage*100),
+		Device:  progress.Device,
+		Date:    formatTime(progress.UpdatedAt),
+	}
+}
+
+type syncView struct {
+	Enabled bool
+	// Server is the address to enter in KOReader.
+	Server string
+	page
+}
+
+func (srv *Service) getSyncSettings(w http.ResponseWriter, r *http.Request) {
+	scheme := "http"
+	if r.TLS != nil || srv.SecureCookie {
+		scheme = "https"
+	}
+
+	data := &syncView{
+		Enabled: currentUser(r.Context()).SyncKeyHash != "",
+		Server:  scheme + "://" + r.Host + "/kosync",
+		page:    srv.page(w, r),
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "sync.html", data); err != nil {
+		log.Printf("ERROR: sync.html: %s", err)
+		return
+	}
+}
+
+var errSyncPassword = fmt.Errorf("sync password must be at least %d characters long", auth.MinPasswordLen)
+
+// setSyncPassword sets the sync password, an empty one turns sync off.
+func (srv *Service) setSyncPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	password := r.PostFormValue("password")
+	if password != "" && len(password) < auth.MinPasswordLen {
+		srv.withError(w, r, "/account/sync", errSyncPassword)
+		return
+	}
+
+	if err := srv.library().SetSyncPassword(ctx, currentUser(ctx).ID, password); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if password == "" {
+		srv.setFlash(w, "progress sync is off")
+	} else {
+		srv.setFlash(w, "the sync password is set")
+	}
+	http.Redirect(w, r, "/account/sync", http.StatusSeeOther)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// kosyncClient sends requests the way KOReader does.
+type kosyncClient struct {
+	t       *testing.T
+	handler http.Handler
+	login   string
+	key     string
+}
+
+func (client *kosyncClient) do(method, path, body string) (int, map[string]any) {
+	client.t.Helper()
+	r := httptest.NewRequest(method, "/kosync"+path, strings.NewReader(body))
+	r.Header.Set("Accept", kosyncMediaType)
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if client.login != "" {
+		r.Header.Set("x-auth-user", client.login)
+		r.Header.Set("x-auth-key", client.key)
+	}
+	w := httptest.NewRecorder()
+	client.handler.ServeHTTP(w, r)
+
+	if got := w.Header().Get("Content-Type"); got != kosyncMediaType {
+		client.t.Errorf("%s %s: content type %q", method, path, got)
+	}
+	var response map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		client.t.Fatalf("%s %s: %v: %s", method, path, err, w.Body)
+	}
+	return w.Code, response
+}
+
+func newSyncClient(t *testing.T, srv *Service) *kosyncClient {
+	mux := chi.NewMux()
+	mux.Route("/kosync", srv.buildSyncRoutes)
+	return &kosyncClient{t: t, handler: mux}
+}
+
+func TestSyncRegister(t *testing.T) {
+	forEachService(t, func(t *testing.T, srv *Service) {
+		client := newSyncClient(t, srv)
+		ann := newUser(t, srv, "ann", user.RoleReader)
+		if err := srv.library().SetSyncPassword(context.Background(), ann.ID, "sync password"); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			name   string
+			body   string
+			status int
+			code   float64
+		}{
+			{
+				name:   "sync password",
+				body:   `{"username":"ann","password":"` + library.SyncKey("sync password") + `"}`,
+				status: http.StatusCreated,
+			},
+			{
+				name:   "wrong password",
+				body:   `{"username":"ann","password":"` + library.SyncKey("other") + `"}`,
+				status: http.StatusForbidden,
+				code:   kosyncUnauthorized,
+			},
+			{
+				name:   "new account",
+				body:   `{"username":"eve","password":"` + library.SyncKey("sync password") + `"}`,
+				status: http.StatusForbidden,
+				code:   kosyncUnauthorized,
+			},
+			{
+				name:   "no password",
+				body:   `{"username":"ann"}`,
+				status: http.StatusForbidden,
+				code:   kosyncInvalid,
+			},
+			{
+				name:   "malformed",
+				body:   `{"username":`,
+				status: http.StatusForbidden,
+				code:   kosyncInvalid,
+			},
+		}
+		for _, tc := range tests {
+			status, response := client.do(http.MethodPost, "/users/create", tc.body)
+			if status != tc.status {
+				t.Errorf("%s: got status %d, want %d", tc.name, status, tc.status)
+			}
+			if tc.code != 0 && response["code"] != tc.code {
+				t.Errorf("%s: got code %v, want %v", tc.name, response["code"], tc.code)
+			}
+			if tc.code == 0 && response["username"] != "ann" {
+				t.Errorf("%s: got %v", tc.name, response)
+			}
+		}
+	})
+}
+
+func TestSyncAuth(t *testing.T) {
+	forEachService(t, func(t *testing.T, srv *Service) {
+		client := newSyncClient(t, srv)
+		ann := newUser(t, srv, "ann", user.RoleReader)
+		if err := srv.library().SetSyncPassword(context.Background(), ann.ID, "sync password"); err != nil {
+			t.Fatal(err)
+		}
+
+		client.login, client.key = "ann", library.SyncKey("sync password")
+		if status, response := client.do(http.MethodGet, "/users/auth", ""); status != http.StatusOK || response["authorized"] != "OK" {
+			t.Errorf("got %d %v, want authorized", status, response)
+		}
+
+		for _, key := range []string{"", "sync password", library.SyncKey("other")} {
+			client.key = key
+			status, response := client.do(http.MethodGet, "/users/auth", "")
+			if status != http.StatusUnauthorized || response["code"] != float64(kosyncUnauthorized) {
+				t.Errorf("key %q: got %d %v, want unauthorized", key, status, response)
+			}
+		}
+	})
+}
+
+func TestSyncProgress(t *testing.T) {
+	forEachService(t, func(t *testing.T, srv *Service) {
+		client := newSyncClient(t, srv)
+		for _, login := range []string{"ann", "bob"} {
+			created := newUser(t, srv, login, user.RoleReader)
+			if err := srv.library().SetSyncPassword(context.Background(), created.ID, login+" password"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		client.login, client.key = "ann", library.SyncKey("ann password")
+
+		if status, response := client.do(http.MethodGet, "/syncs/progress/0123abcd", ""); status != http.StatusOK || len(response) != 0 {
+			t.Errorf("before sync: got %d %v, want an empty object", status, response)
+		}
+
+		for _, percentage := range []string{"0.25", "0.5"} {
+			body := `{"document":"0123abcd","progress":"/body/DocFragment[3]","percentage":` + percentage +
+				`,"device":"Kobo","device_id":"K1"}`
+			status, response := client.do(http.MethodPut, "/syncs/progress", body)
+			if status != http.StatusOK || response["document"] != "0123abcd" || response["timestamp"] == nil {
+				t.Errorf("put %s: got %d %v", percentage, status, response)
+			}
+		}
+
+		status, response := client.do(http.MethodGet, "/syncs/progress/0123abcd", "")
+		want := map[string]any{
+			"document":   "0123abcd",
+			"progress":   "/body/DocFragment[3]",
+			"percentage": 0.5,
+			"device":     "Kobo",
+			"device_id":  "K1",
+		}
+		if status != http.StatusOK {
+			t.Errorf("get: got status %d", status)
+		}
+		for field, value := range want {
+			if response[field] != value {
+				t.Errorf("get %s: got %v, want %v", field, response[field], value)
+			}
+		}
+
+		// progress is kept per user
+		client.login, client.key = "bob", library.SyncKey("bob password")
+		if _, response := client.do(http.MethodGet, "/syncs/progress/0123abcd", ""); len(response) != 0 {
+			t.Errorf("another user: got %v, want an empty object", response)
+		}
+
+		invalid := []struct {
+			body string
+			code float64
+		}{
+			{`{"progress":"1","percentage":0.1,"device":"Kobo"}`, kosyncNoDocument},
+			{`{"document":"0123abcd","progress":"1","percentage":2,"device":"Kobo"}`, kosyncInvalid},
+			{`{"document":"0123abcd","percentage":0.1,"device":"Kobo"}`, kosyncInvalid},
+			{`[]`, kosyncInvalid},
+		}
+		for _, tc := range invalid {
+			status, response := client.do(http.MethodPut, "/syncs/progress", tc.body)
+			if status != http.StatusForbidden || response["code"] != tc.code {
+				t.Errorf("put %s: got %d %v, want code %v", tc.body, status, response, tc.code)
+			}
+		}
+
+		client.key = ""
+		if status, _ := client.do(http.MethodPut, "/syncs/progress", `{"document":"0123abcd"}`); status != http.StatusUnauthorized {
+			t.Errorf("without a key: got %d, want %d", status, http.StatusUnauthorized)
+		}
+	})
+}
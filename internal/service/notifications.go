@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/author"
+)
+
+// outboxInterval is how often queued mail is sent.
+const outboxInterval = time.Minute
+
+func (srv *Service) deliverMail(ctx context.Context) error {
+	n, err := srv.Outbox.Deliver(ctx)
+	if n > 0 {
+		log.Printf("sent %d messages", n)
+	}
+	return err
+}
+
+func (srv *Service) remindDue(ctx context.Context) error {
+	n, err := srv.library().RemindDue(ctx, time.Now(), srv.DueReminder)
+	if n > 0 {
+		log.Printf("queued %d due date reminders", n)
+	}
+	return err
+}
+
+type preferencesView struct {
+	User     *ent.User
+	Followed []*ent.Author
+	// MailEnabled tells if the library sends mail at all.
+	MailEnabled bool
+	page
+}
+
+func (srv *Service) getPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	current := currentUser(ctx)
+
+	followed, err := current.QueryFollowedAuthors().
+		Order(ent.Asc(author.FieldName)).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &preferencesView{
+		User:        current,
+		Followed:    followed,
+		MailEnabled: srv.Outbox != nil,
+		page:        srv.page(w, r),
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "preferences.html", data); err != nil {
+		log.Printf("ERROR: preferences.html: %s", err)
+		return
+	}
+}
+
+type preferencesForm struct {
+	Email          string `schema:"email"`
+	NotifyDue      bool   `schema:"notify_due"`
+	NotifyHolds    bool   `schema:"notify_holds"`
+	NotifyNewBooks bool   `schema:"notify_new_books"`
+}
+
+var errBadEmail = errors.New("email address is not valid")
+
+func (srv *Service) postPreferences(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var form preferencesForm
+	if err := binder.Decode(&form, r.PostForm); err != nil {
+		srv.withError(w, r, "/account/preferences", err)
+		return
+	}
+	if form.Email != "" {
+		address, err := mail.ParseAddress(form.Email)
+		if err != nil || address.Name != "" {
+			srv.withError(w, r, "/account/preferences", errBadEmail)
+			return
+		}
+	}
+
+	errUpdate := srv.Storage.User.UpdateOne(currentUser(r.Context())).
+		SetEmail(form.Email).
+		SetNotifyDue(form.NotifyDue).
+		SetNotifyHolds(form.NotifyHolds).
+		SetNotifyNewBooks(form.NotifyNewBooks).
+		Exec(r.Context())
+	if errUpdate != nil {
+		http.Error(w, "db: "+errUpdate.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.setFlash(w, "preferences are saved")
+	http.Redirect(w, r, "/account/preferences", http.StatusSeeOther)
+}
+
+// followAuthor subscribes the user to new books of the author.
+func (srv *Service) followAuthor(w http.ResponseWriter, r *http.Request) {
+	srv.updateFollow(w, r, true)
+}
+
+func (srv *Service) unfollowAuthor(w http.ResponseWriter, r *http.Request) {
+	srv.updateFollow(w, r, false)
+}
+
+func (srv *Service) updateFollow(w http.ResponseWriter, r *http.Request, follow bool) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	update := srv.Storage.User.UpdateOne(currentUser(r.Context()))
+	if follow {
+		update.AddFollowedAuthorIDs(id)
+	} else {
+		update.RemoveFollowedAuthorIDs(id)
+	}
+	err := update.Exec(r.Context())
+	switch {
+	// following twice or a missing author
+	case ent.IsConstraintError(err):
+	case err != nil:
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, localPath(r.PostFormValue("next"), "/authors"), http.StatusSeeOther)
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+	"github.com/yuin/goldmark"
+)
+
+// markdown renders review texts, raw HTML is dropped by goldmark
+// and the result is sanitized anyway, as links may carry scripts.
+var (
+	markdown  = goldmark.New()
+	sanitizer = bluemonday.UGCPolicy()
+)
+
+func renderMarkdown(text string) template.HTML {
+	buf := &bytes.Buffer{}
+	if err := markdown.Convert([]byte(text), buf); err != nil {
+		return template.HTML(template.HTMLEscapeString(text))
+	}
+	return template.HTML(sanitizer.SanitizeBytes(buf.Bytes()))
+}
+
+// stars shows the rating as filled and empty stars.
+func stars(rating int) string {
+	return strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
+}
+
+// formatRating shows the average rating with the number of reviews,
+// it's empty for books without reviews.
+func formatRating(rating library.Rating) string {
+	if rating.Count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.1f (%d)", rating.Average, rating.Count)
+}
+
+type reviewView struct {
+	ID     int64
+	Book   *ent.Book
+	Author string
+	Rating int
+	Stars  string
+	Text   template.HTML
+	Date   string
+	Hidden bool
+	// HiddenBy is the login of the moderator.
+	HiddenBy string
+}
+
+func newReviewView(item *ent.Review) reviewView {
+	view := reviewView{
+		ID:     item.ID,
+		Book:   item.Edges.Book,
+		Rating: item.Rating,
+		Stars:  stars(item.Rating),
+		Text:   renderMarkdown(item.Text),
+		Date:   formatTime(item.UpdatedAt),
+		Hidden: item.Hidden,
+	}
+	if item.Edges.User != nil {
+		view.Author = item.Edges.User.Login
+	}
+	if item.Edges.HiddenBy != nil {
+		view.HiddenBy = item.Edges.HiddenBy.Login
+	}
+	return view
+}
+
+// bookReviews fills reviews of the book page. Hidden reviews
+// are shown to moderators and to their authors only.
+func (srv *Service) bookReviews(w http.ResponseWriter, r *http.Request, data *bookPageView) bool {
+	ctx := r.Context()
+	current := currentUser(ctx)
+
+	query := data.Book.QueryReviews()
+	switch {
+	case srv.can(ctx, auth.Moderate):
+	case current != nil:
+		query = query.Where(review.Or(review.Hidden(false), review.HasUserWith(user.ID(current.ID))))
+	default:
+		query = query.Where(review.Hidden(false))
+	}
+	reviews, err := query.
+		WithUser().
+		WithHiddenBy().
+		Order(ent.Desc(review.FieldUpdatedAt), ent.Desc(review.FieldID)).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	ratings, errRatings := srv.library().Ratings(ctx, data.Book.ID)
+	if errRatings != nil {
+		http.Error(w, errRatings.Error(), http.StatusInternalServerError)
+		return false
+	}
+	data.Rating = formatRating(ratings[data.Book.ID])
+
+	for _, item := range reviews {
+		if current != nil && item.Edges.User.ID == current.ID {
+			data.MyReview = item
+		}
+		data.Reviews = append(data.Reviews, newReviewView(item))
+	}
+	return true
+}
+
+type reviewForm struct {
+	Rating int    `schema:"rating"`
+	Text   string `schema:"text"`
+}
+
+var errRating = errors.New("choose from 1 to 5 stars")
+
+// reviewBook saves the review of the current user.
+func (srv *Service) reviewBook(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	bookPage := "/books/" + strconv.FormatInt(id, 10)
+
+	var form reviewForm
+	if err := binder.Decode(&form, r.PostForm); err != nil || form.Rating < 1 || form.Rating > 5 {
+		srv.withError(w, r, bookPage, errRating)
+		return
+	}
+
+	_, err := srv.library().ReviewBook(ctx, currentUser(ctx).ID, id, form.Rating, strings.TrimSpace(form.Text))
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		http.NotFound(w, r)
+		return
+	case ent.IsValidationError(err):
+		srv.withError(w, r, bookPage, err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, bookPage, http.StatusSeeOther)
+}
+
+func (srv *Service) deleteReview(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+
+	if err := srv.library().DeleteReview(ctx, currentUser(ctx).ID, id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/books/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+}
+
+type reviewsView struct {
+	Reviews []reviewView
+	// Hidden lists only hidden reviews.
+	Hidden bool
+	page
+}
+
+// recentReviews limits the moderation page.
+const recentReviews = 100
+
+// listReviews shows recent reviews of all books for moderation.
+func (srv *Service) listReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	hidden := r.URL.Query().Get("hidden") != ""
+
+	query := srv.Storage.Review.Query().
+		Where(review.HasBookWith(book.DeletedAtIsNil()))
+	if hidden {
+		query = query.Where(review.Hidden(true))
+	}
+	reviews, err := query.
+		WithBook().
+		WithUser().
+		WithHiddenBy().
+		Order(ent.Desc(review.FieldUpdatedAt), ent.Desc(review.FieldID)).
+		Limit(recentReviews).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &reviewsView{Hidden: hidden, page: srv.page(w, r)}
+	for _, item := range reviews {
+		data.Reviews = append(data.Reviews, newReviewView(item))
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "reviews.html", data); err != nil {
+		log.Printf("ERROR: reviews.html: %s", err)
+		return
+	}
+}
+
+func (srv *Service) hideReview(w http.ResponseWriter, r *http.Request) {
+	srv.moderateReview(w, r, true)
+}
+
+func (srv *Service) showReview(w http.ResponseWriter, r *http.Request) {
+	srv.moderateReview(w, r, false)
+}
+
+// moderateReview hides or shows the review and returns
+// to the next page, the moderation page by default.
+func (srv *Service) moderateReview(w http.ResponseWriter, r *http.Request, hidden bool) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+
+	_, err := srv.library().HideReview(ctx, currentUser(ctx).ID, id, hidden)
+	switch {
+	case errors.Is(err, library.ErrReviewNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, localPath(r.PostFormValue("next"), "/reviews"), http.StatusSeeOther)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/author"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+)
+
+// bookRevisionsShown limits revisions on the book page.
+const bookRevisionsShown = 50
+
+type revisionView struct {
+	ID    int64
+	Date  string
+	Actor string
+	// Changes are differences from the previous revision.
+	Changes []auditChange
+	// Current tells the book has the metadata of the revision.
+	Current bool
+}
+
+// bookRevisions fills revisions of the book page for editors, newest first.
+func (srv *Service) bookRevisions(w http.ResponseWriter, r *http.Request, data *bookPageView) bool {
+	ctx := r.Context()
+	if !srv.can(ctx, auth.EditMetadata) {
+		return true
+	}
+
+	// one more revision is loaded to show changes of the oldest one shown
+	revisions, err := srv.Storage.Revision.Query().
+		Where(revision.Entity(ent.TypeBook), revision.EntityID(data.Book.ID)).
+		Order(ent.Desc(revision.FieldID)).
+		Limit(bookRevisionsShown + 1).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	var ids []int64
+	for _, item := range revisions {
+		ids = append(ids, revisionAuthors(item.Data)...)
+	}
+	names := map[int64]string{}
+	authors, errAuthors := srv.Storage.Author.Query().
+		Where(author.IDIn(ids...)).
+		All(ctx)
+	if errAuthors != nil {
+		http.Error(w, "db: "+errAuthors.Error(), http.StatusInternalServerError)
+		return false
+	}
+	for _, item := range authors {
+		names[item.ID] = item.Name
+	}
+
+	for i, item := range revisions {
+		if i == bookRevisionsShown {
+			break
+		}
+		var prev map[string]any
+		if i+1 < len(revisions) {
+			prev = revisions[i+1].Data
+		}
+		data.Revisions = append(data.Revisions, revisionView{
+			ID:      item.ID,
+			Date:    formatTime(item.CreatedAt),
+			Actor:   item.ActorLogin,
+			Changes: diffRevisions(prev, item.Data, names),
+			Current: i == 0,
+		})
+	}
+	return true
+}
+
+// diffRevisions returns changes of fields between snapshots,
+// authors are shown by names.
+func diffRevisions(prev, next map[string]any, names map[int64]string) []auditChange {
+	var fields []string
+	for name := range prev {
+		fields = append(fields, name)
+	}
+	for name := range next {
+		if _, ok := prev[name]; !ok {
+			fields = append(fields, name)
+		}
+	}
+	sort.Strings(fields)
+
+	var changes []auditChange
+	for _, name := range fields {
+		if name == "authors" {
+			before, after := revisionAuthors(prev), revisionAuthors(next)
+			change := auditChange{Field: name, Edge: true}
+			change.Old = authorNames(before, after, names)
+			change.New = authorNames(after, before, names)
+			if change.Old != "" || change.New != "" {
+				changes = append(changes, change)
+			}
+			continue
+		}
+
+		before, after := formatRevisionValue(name, prev[name]), formatRevisionValue(name, next[name])
+		if before != after {
+			changes = append(changes, auditChange{Field: name, Old: before, New: after})
+		}
+	}
+	return changes
+}
+
+// revisionAuthors returns ids of authors of the book revision.
+func revisionAuthors(data map[string]any) []int64 {
+	values, _ := data["authors"].([]any)
+	ids := make([]int64, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(float64); ok {
+			ids = append(ids, int64(id))
+		}
+	}
+	return ids
+}
+
+// authorNames lists authors from ids missing from other.
+func authorNames(ids, other []int64, names map[int64]string) string {
+	var listed []string
+	for _, id := range ids {
+		if slices.Contains(other, id) {
+			continue
+		}
+		name, ok := names[id]
+		if !ok {
+			name = "#" + strconv.FormatInt(id, 10)
+		}
+		listed = append(listed, name)
+	}
+	return strings.Join(listed, ", ")
+}
+
+func formatRevisionValue(field string, value any) string {
+	if unix, ok := value.(float64); ok && field == "written_at" {
+		return time.Unix(int64(unix), 0).UTC().Format(time.DateOnly)
+	}
+	return formatAuditValue(value)
+}
+
+// restoreRevision applies the revision and returns to the book page,
+// or to the authors list for revisions of authors.
+func (srv *Service) restoreRevision(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	restored, err := srv.library().RestoreRevision(r.Context(), id)
+	switch {
+	case errors.Is(err, library.ErrRevisionNotFound),
+		errors.Is(err, library.ErrBookNotFound),
+		errors.Is(err, library.ErrAuthorNotFound):
+		http.NotFound(w, r)
+		return
+	case ent.IsValidationError(err):
+		srv.withError(w, r, localPath(r.PostFormValue("next"), "/books"), err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	next := "/authors"
+	if restored.Entity == ent.TypeBook {
+		next = "/books/" + strconv.FormatInt(restored.EntityID, 10)
+	}
+	srv.setFlash(w, "the revision of "+formatTime(restored.CreatedAt)+" is restored")
+	http.Redirect(w, r, next, http.StatusSeeOther)
+}
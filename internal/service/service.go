@@ -1,53 +1,240 @@
 package service
 
 import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
 	"log"
+	"mime"
 	"net/http"
-	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	binding "github.com/gorilla/schema"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/internal/convert"
+	"github.com/ninedraft/bibliotheca/internal/jobs"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/database"
 	"github.com/ninedraft/bibliotheca/storage/ent"
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/files"
+	"github.com/ninedraft/bibliotheca/storage/search"
 )
 
 type Service struct {
 	Storage *ent.Client
-	Templ   *template.Template
-	Static  fs.FS
+	// Search is the full-text index, nil disables it.
+	Search *search.Index
+	Files  *files.Store
+	Templ  *template.Template
+	// Static is served under /static/.
+	Static fs.FS
+
+	// EmbedMetadata enables rewriting of FB2 metadata on download.
+	EmbedMetadata bool
+	// ValidationReport enables the /admin/validation page.
+	ValidationReport bool
+
+	// PublicBrowsing lets anonymous users list and download books,
+	// changes always require login.
+	PublicBrowsing bool
+	SessionTTL     time.Duration
+	// SecureCookie restricts cookies to HTTPS.
+	SecureCookie bool
+	// Signer signs CSRF tokens and flash messages.
+	Signer *auth.Signer
+	// PickupWindow is how long a copy is set aside for a hold.
+	PickupWindow time.Duration
+	// Outbox queues and sends notifications, nil disables them.
+	Outbox *notify.Outbox
+	// DueReminder is how long before the due date borrowers are reminded.
+	DueReminder time.Duration
+	// Converter turns books into formats of devices they are sent to.
+	Converter *convert.Converter
+	// MaxFileSize of books sent to devices.
+	MaxFileSize int64
+	// TrashRetention is how long deleted books are kept,
+	// zero keeps them until purged by hand.
+	TrashRetention time.Duration
 }
 
 func (srv *Service) BuildRoutes(mux chi.Router) {
-	mux.Handle("/static/*", http.FileServer(http.FS(srv.Static)))
-	mux.Route("/books", func(r chi.Router) {
-		r.Get("/", srv.getBooks)
-		r.Post("/", srv.createBook)
-		r.Get("/new", srv.getBookForm)
-	})
+	mux.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.FS(srv.Static))))
+	mux.Route("/kosync", srv.buildSyncRoutes)
+
+	mux.Group(func(r chi.Router) {
+		r.Use(srv.authMW)
+		r.Use(srv.csrfMW)
+		r.Get("/login", srv.getLogin)
+		r.Post("/login", srv.postLogin)
+		r.Post("/logout", srv.postLogout)
+
+		r.Route("/books", func(r chi.Router) {
+			r.With(srv.require(auth.ReadCatalog)).Get("/", srv.getBooks)
+			r.With(srv.require(auth.Upload)).Post("/", srv.createBook)
+			r.With(srv.require(auth.Upload)).Get("/new", srv.getBookForm)
+			r.With(srv.require(auth.ReadCatalog)).Get("/{id}", srv.getBook)
+			r.With(srv.require(auth.Download)).Get("/{id}/file", srv.getBookFile)
+			r.With(srv.require(auth.ReadCatalog)).Get("/{id}/cover", srv.getBookCover)
+			r.With(srv.require(auth.EditMetadata)).Get("/{id}/edit", srv.getBookEditForm)
+			r.With(srv.require(auth.EditMetadata)).Post("/{id}/edit", srv.updateBook)
+			r.With(srv.require(auth.Delete)).Post("/{id}/delete", srv.deleteBook)
+			r.With(srv.require(auth.EditMetadata)).Post("/{id}/copies", srv.addCopy)
+			r.With(srv.require(auth.Reserve)).Post("/{id}/holds", srv.placeHold)
+			r.With(srv.requireSession, srv.require(auth.Download)).Post("/{id}/send", srv.sendToDevice)
+			r.With(srv.requireSession, srv.require(auth.ReadCatalog)).Post("/{id}/status", srv.setReadingStatus)
+			r.With(srv.require(auth.Review)).Post("/{id}/review", srv.reviewBook)
+			r.With(srv.require(auth.Review)).Post("/{id}/review/delete", srv.deleteReview)
+			r.With(srv.requireSession, srv.require(auth.Download)).Get("/{id}/read", srv.readBook)
+			r.With(srv.requireSession, srv.require(auth.Download)).Get("/{id}/notes", srv.getNotes)
+			r.With(srv.requireSession, srv.require(auth.Download)).Get("/{id}/highlights", srv.listHighlights)
+			r.With(srv.requireSession, srv.require(auth.Download)).Post("/{id}/highlights", srv.createHighlight)
+		})
+
+		r.Route("/shelves", func(r chi.Router) {
+			r.Use(srv.requireSession, srv.require(auth.ReadCatalog))
+			r.Get("/", srv.listShelves)
+			r.Post("/", srv.createShelf)
+			r.Post("/{id}/delete", srv.deleteShelf)
+			r.Post("/{id}/books", srv.addToShelf)
+			r.Post("/{id}/books/remove", srv.removeFromShelf)
+		})
+
+		r.Route("/trash", func(r chi.Router) {
+			r.Use(srv.require(auth.Delete))
+			r.Get("/", srv.getTrash)
+			r.Post("/{id}/restore", srv.restoreBook)
+			r.Post("/{id}/purge", srv.purgeBook)
+		})
+
+		r.Route("/highlights", func(r chi.Router) {
+			r.Use(srv.requireSession, srv.require(auth.Download))
+			r.Get("/export", srv.exportHighlights)
+			r.Post("/{id}", srv.updateHighlight)
+			r.Post("/{id}/delete", srv.deleteHighlight)
+		})
+
+		r.Route("/revisions", func(r chi.Router) {
+			r.Use(srv.require(auth.EditMetadata))
+			r.Post("/{id}/restore", srv.restoreRevision)
+		})
+
+		r.Route("/reviews", func(r chi.Router) {
+			r.Use(srv.require(auth.Moderate))
+			r.Get("/", srv.listReviews)
+			r.Post("/{id}/hide", srv.hideReview)
+			r.Post("/{id}/show", srv.showReview)
+		})
+
+		r.Route("/holds", func(r chi.Router) {
+			r.Use(srv.require(auth.Reserve))
+			r.Get("/", srv.listHolds)
+			r.Post("/{id}/cancel", srv.cancelHold)
+		})
 
-	mux.Route("/authors", func(r chi.Router) {
-		r.Get("/", srv.listAuthors)
-		r.Post("/", srv.createAuthor)
-		r.Get("/new", srv.getAuthorForm)
+		r.Route("/circulation", func(r chi.Router) {
+			r.Use(srv.require(auth.Circulate))
+			r.Get("/", srv.getCirculation)
+			r.Post("/checkout", srv.checkOut)
+			r.Post("/checkin", srv.checkIn)
+			r.Get("/overdue", srv.getOverdue)
+		})
+
+		r.Route("/authors", func(r chi.Router) {
+			r.With(srv.require(auth.ReadCatalog)).Get("/", srv.listAuthors)
+			r.With(srv.require(auth.EditMetadata)).Post("/", srv.createAuthor)
+			r.With(srv.require(auth.EditMetadata)).Get("/new", srv.getAuthorForm)
+			r.With(srv.require(auth.Delete)).Post("/{id}/delete", srv.deleteAuthor)
+			r.With(srv.requireSession).Post("/{id}/follow", srv.followAuthor)
+			r.With(srv.requireSession).Post("/{id}/unfollow", srv.unfollowAuthor)
+		})
+
+		r.Route("/account", func(r chi.Router) {
+			r.Use(srv.requireSession)
+			r.Get("/tokens", srv.listTokens)
+			r.Post("/tokens", srv.createToken)
+			r.Post("/tokens/{id}/revoke", srv.revokeToken)
+			r.Get("/preferences", srv.getPreferences)
+			r.Post("/preferences", srv.postPreferences)
+			r.Get("/devices", srv.listDevices)
+			r.Post("/devices", srv.addDevice)
+			r.Post("/devices/{id}/delete", srv.deleteDevice)
+			r.Get("/sync", srv.getSyncSettings)
+			r.Post("/sync", srv.setSyncPassword)
+		})
+
+		r.Route("/admin", func(r chi.Router) {
+			if srv.ValidationReport {
+				r.With(srv.require(auth.EditMetadata)).Get("/validation", srv.getValidationReport)
+			}
+			r.With(srv.require(auth.ManageUsers)).Get("/users", srv.listUsers)
+			r.With(srv.require(auth.ManageUsers)).Post("/users/{id}/role", srv.setUserRole)
+			r.With(srv.require(auth.ManageUsers)).Get("/audit", srv.getAuditLog)
+		})
 	})
 }
 
+// Jobs returns background jobs of the service. None needs a checkpoint:
+// expired sessions and holds are found by their expiry, purged books and
+// unused files are gone, reminded loans and sent messages are marked,
+// so an interrupted run leaves the rest to the next one.
+func (srv *Service) Jobs() []jobs.Job {
+	list := []jobs.Job{
+		{Name: "expired sessions", Interval: time.Hour, Run: srv.deleteExpiredSessions},
+		{Name: "expired holds", Interval: holdsInterval, Run: srv.expireHolds},
+		{Name: "trash", Interval: trashInterval, Run: srv.emptyTrash},
+	}
+	if srv.Outbox != nil {
+		list = append(list,
+			jobs.Job{Name: "due reminders", Interval: time.Hour, Run: srv.remindDue},
+			jobs.Job{Name: "mail outbox", Interval: outboxInterval, Run: srv.deliverMail},
+		)
+	}
+	return list
+}
+
 type booksView struct {
 	Books   []*ent.Book
 	Authors map[int64][]*ent.Author
+	// Problems found in the just uploaded file.
+	Problems []bookinfo.Problem
+	// ValidationReport shows the link to the report page.
+	ValidationReport bool
+	User             *ent.User
+	// Query and Shelf are the search and the shelf filter,
+	// Shelves are filter options for logged in users.
+	Query   string
+	Shelf   string
+	Shelves []shelfOption
+	// Ratings of listed books, Sort is the chosen order.
+	Ratings map[int64]library.Rating
+	Sort    string
+	page
 }
 
 func (view *booksView) List() []any {
 	type bookView struct {
-		Title     string
-		WrittenAt time.Time
-		Authors   []string
+		ID      int64
+		Title   string
+		Year    int
+		Authors []string
+		HasFile bool
+		Rating  string
 	}
 
 	var list []any
@@ -57,26 +244,61 @@ func (view *booksView) List() []any {
 		for _, author := range authors {
 			names = append(names, author.Name)
 		}
-		list = append(list, bookView{
-			Title:     book.Title,
-			WrittenAt: time.Unix(book.WrittenAt, 0),
-			Authors:   names,
-		})
+		item := bookView{
+			ID:      book.ID,
+			Title:   book.Title,
+			Authors: names,
+			HasFile: book.FileID != "",
+			Rating:  formatRating(view.Ratings[book.ID]),
+		}
+		if book.WrittenAt != 0 {
+			item.Year = time.Unix(book.WrittenAt, 0).Year()
+		}
+		list = append(list, item)
 	}
 
 	return list
 }
 
 func (srv *Service) getBooks(w http.ResponseWriter, r *http.Request) {
+	srv.renderBooks(w, r, nil)
+}
+
+func (srv *Service) renderBooks(w http.ResponseWriter, r *http.Request, problems []bookinfo.Problem) {
 	ctx := r.Context()
 	q := r.URL.Query().Get("q")
 	query := srv.Storage.Book.Query()
 
 	if q != "" {
-		query = query.Where(book.Or(
-			book.TitleContainsFold(q),
-			book.HasAuthorsWith(author.NameContainsFold(q)),
-		))
+		// substring match finds books by parts of words, which FTS can't do
+		predicates := []predicate.Book{
+			predicate.Book(database.ContainsFold(book.FieldTitle, q)),
+			book.HasAuthorsWith(predicate.Author(database.ContainsFold(author.FieldName, q))),
+		}
+		if srv.Search != nil {
+			ids, errSearch := srv.Search.Search(ctx, q, searchLimit)
+			if errSearch != nil {
+				http.Error(w, errSearch.Error(), http.StatusInternalServerError)
+				return
+			}
+			predicates = append(predicates, book.IDIn(ids...))
+		}
+		query = query.Where(book.Or(predicates...))
+	}
+
+	current := currentUser(ctx)
+	filter := r.URL.Query().Get("shelf")
+	var shelves []*ent.Shelf
+	if current != nil {
+		if onShelf, ok := shelfPredicate(current.ID, filter); ok {
+			query = query.Where(onShelf)
+		}
+		var errShelves error
+		shelves, errShelves = current.QueryShelves().Order(ent.Asc(shelf.FieldName)).All(ctx)
+		if errShelves != nil {
+			http.Error(w, "db: "+errShelves.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
 
 	books, err := query.WithAuthors().All(ctx)
@@ -85,6 +307,27 @@ func (srv *Service) getBooks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ids := make([]int64, 0, len(books))
+	for _, item := range books {
+		ids = append(ids, item.ID)
+	}
+	ratings, errRatings := srv.library().Ratings(ctx, ids...)
+	if errRatings != nil {
+		http.Error(w, errRatings.Error(), http.StatusInternalServerError)
+		return
+	}
+	order := r.URL.Query().Get("sort")
+	if order == sortRating {
+		// unrated books go last, ties are broken by the number of reviews
+		slices.SortStableFunc(books, func(a, b *ent.Book) int {
+			ra, rb := ratings[a.ID], ratings[b.ID]
+			if ra.Average != rb.Average {
+				return cmp.Compare(rb.Average, ra.Average)
+			}
+			return cmp.Compare(rb.Count, ra.Count)
+		})
+	}
+
 	bookAuthors := make(map[int64][]*ent.Author)
 	for _, book := range books {
 		authors := book.QueryAuthors().AllX(ctx)
@@ -92,8 +335,19 @@ func (srv *Service) getBooks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := &booksView{
-		Books:   books,
-		Authors: bookAuthors,
+		Books:            books,
+		Authors:          bookAuthors,
+		Problems:         problems,
+		ValidationReport: srv.ValidationReport,
+		User:             current,
+		Query:            q,
+		Shelf:            filter,
+		Ratings:          ratings,
+		Sort:             order,
+		page:             srv.page(w, r),
+	}
+	if current != nil {
+		data.Shelves = shelfFilters(shelves)
 	}
 
 	if err := srv.Templ.ExecuteTemplate(w, "books.html", data); err != nil {
@@ -102,6 +356,11 @@ func (srv *Service) getBooks(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+const searchLimit = 100
+
+// sortRating orders the book list by the average rating.
+const sortRating = "rating"
+
 func (srv *Service) getBookForm(w http.ResponseWriter, r *http.Request) {
 	authors, err := srv.Storage.Author.Query().All(r.Context())
 	if err != nil {
@@ -109,12 +368,9 @@ func (srv *Service) getBookForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data := map[string]any{
-		"Authors": authors,
-	}
-
-	if r.URL.Query().Get("error") != "" {
-		data["Error"] = r.URL.Query().Get("error")
+	data := &bookFormView{
+		Authors: authors,
+		page:    srv.page(w, r),
 	}
 
 	if err := srv.Templ.ExecuteTemplate(w, "books_create.html", data); err != nil {
@@ -123,6 +379,11 @@ func (srv *Service) getBookForm(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type bookFormView struct {
+	Authors []*ent.Author
+	page
+}
+
 type bookForm struct {
 	Title     string  `schema:"title"`
 	WrittenAt Date    `schema:"written_at"`
@@ -136,6 +397,9 @@ type Date struct{ time.Time }
 func init() {
 	binder.IgnoreUnknownKeys(true)
 	binder.RegisterConverter(Date{}, func(s string) reflect.Value {
+		if s == "" {
+			return reflect.ValueOf(Date{})
+		}
 		t, err := time.Parse(time.DateOnly, s)
 		if err != nil {
 			return reflect.Value{}
@@ -144,46 +408,432 @@ func init() {
 	})
 }
 
+const maxUploadSize = 256 << 20
+
 func (srv *Service) createBook(w http.ResponseWriter, r *http.Request) {
-	if err := r.ParseForm(); err != nil {
-		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+	errForm := r.ParseMultipartForm(maxUploadSize)
+	if errForm != nil && !errors.Is(errForm, http.ErrNotMultipart) {
+		http.Error(w, "form: "+errForm.Error(), http.StatusBadRequest)
 		return
 	}
 
 	var book bookForm
 	errBind := binder.Decode(&book, r.PostForm)
 	if errBind != nil {
-		withError(w, r, "/books/new", errBind)
+		srv.withError(w, r, "/books/new", errBind)
 		return
 	}
 
 	if book.WrittenAt.After(time.Now()) {
-		withError(w, r, "/books/new", errors.New("book written in future"))
+		srv.withError(w, r, "/books/new", errors.New("book written in future"))
 		return
 	}
 
-	bookCreation := srv.Storage.Book.Create().
-		SetTitle(book.Title).
-		SetWrittenAt(book.WrittenAt.Unix())
+	ctx := r.Context()
+	bookCreation := srv.Storage.Book.Create()
+
+	upload, errUpload := srv.storeUpload(r)
+	if errUpload != nil {
+		srv.withError(w, r, "/books/new", errUpload)
+		return
+	}
+
+	if upload != nil {
+		bookCreation.SetFileID(upload.FileID)
+		if upload.CoverID != "" {
+			bookCreation.SetCoverID(upload.CoverID)
+		}
+		if upload.Info.Pages > 0 {
+			bookCreation.SetPages(int64(upload.Info.Pages))
+		}
+
+		// form values take precedence over the file metadata
+		info := upload.Info
+		if book.Title == "" {
+			book.Title = info.Title
+		}
+		if book.WrittenAt.IsZero() {
+			book.WrittenAt = Date{info.WrittenAt}
+		}
+		if len(book.Authors) == 0 {
+			ids, errAuthors := srv.library().EnsureAuthors(ctx, info.Authors)
+			if errAuthors != nil {
+				http.Error(w, "db: "+errAuthors.Error(), http.StatusInternalServerError)
+				return
+			}
+			book.Authors = ids
+		}
+	}
+
+	if book.Title == "" {
+		srv.withError(w, r, "/books/new", errors.New("book title is required"))
+		return
+	}
+
+	bookCreation.SetTitle(book.Title)
+	if !book.WrittenAt.IsZero() {
+		bookCreation.SetWrittenAt(book.WrittenAt.Unix())
+	}
 
 	bookCreation.Mutation().AddAuthorIDs(book.Authors...)
 
-	_, err := bookCreation.Save(r.Context())
+	created, err := bookCreation.Save(ctx)
 	if err != nil {
 		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	srv.getBooks(w, r)
+	if upload != nil {
+		if errLink := srv.library().LinkDocument(ctx, created.ID, upload.Document); errLink != nil {
+			http.Error(w, errLink.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if errIndex := srv.library().IndexBook(ctx, created); errIndex != nil {
+		http.Error(w, errIndex.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if errAnnounce := srv.library().AnnounceBook(ctx, created); errAnnounce != nil {
+		http.Error(w, errAnnounce.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var problems []bookinfo.Problem
+	if upload != nil {
+		problems = upload.Problems
+	}
+	srv.renderBooks(w, r, problems)
 }
 
-func (srv *Service) getAuthorForm(w http.ResponseWriter, r *http.Request) {
-	data := map[string]any{}
+// library returns catalog operations backed by the service storage.
+func (srv *Service) library() *library.Library {
+	lib := &library.Library{
+		Storage: srv.Storage,
+		Files:   srv.Files,
+		Search:  srv.Search,
+
+		PickupWindow: srv.PickupWindow,
+		Converter:    srv.Converter,
+		MaxFileSize:  srv.MaxFileSize,
+		Signer:       srv.Signer,
+	}
+	// a nil *Outbox in the interface would not be nil
+	if srv.Outbox != nil {
+		lib.Notifier = srv.Outbox
+		lib.Mailer = srv.Outbox
+		lib.MailAttempts = srv.Outbox.MaxAttempts
+	}
+	return lib
+}
+
+// storeUpload saves the uploaded book file and its cover.
+// It returns nil if the request has no file.
+func (srv *Service) storeUpload(r *http.Request) (*library.StoredFile, error) {
+	file, header, errFile := r.FormFile("file")
+	switch {
+	case errors.Is(errFile, http.ErrMissingFile), errors.Is(errFile, http.ErrNotMultipart):
+		return nil, nil
+	case errFile != nil:
+		return nil, errFile
+	}
+	defer func() { _ = file.Close() }()
+
+	stored, errStore := srv.library().StoreFile(r.Context(), header.Filename, file)
+	if errStore != nil {
+		return nil, fmt.Errorf("%s: %w", header.Filename, errStore)
+	}
+	return stored, nil
+}
+
+func (srv *Service) bookByID(w http.ResponseWriter, r *http.Request) (*ent.Book, bool) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	found, err := srv.Storage.Book.Query().
+		Where(book.ID(id)).
+		WithAuthors().
+		Only(r.Context())
+	switch {
+	case ent.IsNotFound(err):
+		http.NotFound(w, r)
+		return nil, false
+	case err != nil:
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return found, true
+}
+
+// getBookFile serves the book file. Metadata of FB2 files is updated
+// from the catalog, so edits made in the library end up in the download.
+func (srv *Service) getBookFile(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+	if found.FileID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, errOpen := srv.Files.Open(found.FileID)
+	if errOpen != nil {
+		http.Error(w, "files: "+errOpen.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	stat, errStat := file.Stat()
+	if errStat != nil {
+		http.Error(w, "files: "+errStat.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	name := found.Title + "." + files.Ext(found.FileID)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+
+	var content io.ReadSeeker = file
+	modTime := stat.ModTime()
+	if srv.EmbedMetadata && files.Ext(found.FileID) == "fb2" {
+		embedded, errEmbed := embedFB2Metadata(file, found)
+		if errEmbed != nil {
+			log.Printf("ERROR: book %d: embed metadata: %v", found.ID, errEmbed)
+		} else {
+			content = embedded
+			// the content changes with the catalog, not with the stored file,
+			// so caches are validated by the embedded metadata instead of the modtime
+			w.Header().Set("ETag", metadataETag(found))
+			modTime = time.Time{}
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			http.Error(w, "files: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	// reading apps sync progress by the hash of the file they have
+	if err := srv.library().RememberDocument(r.Context(), found.ID, content); err != nil {
+		log.Printf("ERROR: book %d: remember document: %v", found.ID, err)
+	}
+
+	http.ServeContent(w, r, name, modTime, content)
+}
+
+// metadataETag identifies the file of the book with the metadata
+// embedFB2Metadata writes into it.
+func metadataETag(found *ent.Book) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%d", found.FileID, found.Title, found.WrittenAt)
+	for _, author := range found.Edges.Authors {
+		fmt.Fprintf(hash, "\x00%s", author.Name)
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// embedFB2Metadata writes the title, authors and, if known, the date
+// of the book into the FB2 file.
+func embedFB2Metadata(file io.Reader, found *ent.Book) (io.ReadSeeker, error) {
+	data, errRead := io.ReadAll(file)
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	info, errParse := bookinfo.ParseFB2(bytes.NewReader(data))
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	var authors []string
+	for _, author := range found.Edges.Authors {
+		authors = append(authors, author.Name)
+	}
+	// the date of the file is kept if the catalog does not know it
+	writtenAt := info.WrittenAt
+	if found.WrittenAt != 0 {
+		writtenAt = time.Unix(found.WrittenAt, 0).UTC()
+	}
+
+	if info.Title == found.Title &&
+		slices.Equal(info.Authors, authors) &&
+		info.WrittenAt.Format(time.DateOnly) == writtenAt.Format(time.DateOnly) {
+		return bytes.NewReader(data), nil
+	}
+
+	info.Title = found.Title
+	info.Authors = authors
+	info.WrittenAt = writtenAt
+
+	buf := &bytes.Buffer{}
+	if err := bookinfo.WriteFB2Metadata(buf, bytes.NewReader(data), info); err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(buf.Bytes()), nil
+}
+
+func (srv *Service) getBookCover(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+	if found.CoverID == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	file, errOpen := srv.Files.Open(found.CoverID)
+	if errOpen != nil {
+		http.Error(w, "files: "+errOpen.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	stat, errStat := file.Stat()
+	if errStat != nil {
+		http.Error(w, "files: "+errStat.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.ServeContent(w, r, found.CoverID, stat.ModTime(), file)
+}
+
+func (srv *Service) deleteBook(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err := srv.library().DeleteBook(r.Context(), id)
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		http.NotFound(w, r)
+		return
+	case errors.Is(err, library.ErrBookOnLoan):
+		srv.withError(w, r, "/books/"+strconv.FormatInt(id, 10), err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/books", http.StatusSeeOther)
+}
+
+type bookEditView struct {
+	Book *ent.Book
+	// WrittenAt is the value of the date input, empty if unknown.
+	WrittenAt string
+	Authors   []authorOption
+	page
+}
+
+type authorOption struct {
+	ID       int64
+	Name     string
+	Selected bool
+}
+
+func (srv *Service) getBookEditForm(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+
+	authors, err := srv.Storage.Author.Query().All(r.Context())
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-	if r.URL.Query().Get("error") != "" {
-		data["Error"] = r.URL.Query().Get("error")
+	data := &bookEditView{
+		Book: found,
+		page: srv.page(w, r),
+	}
+	if found.WrittenAt != 0 {
+		data.WrittenAt = time.Unix(found.WrittenAt, 0).UTC().Format(time.DateOnly)
+	}
+	for _, a := range authors {
+		data.Authors = append(data.Authors, authorOption{
+			ID:   a.ID,
+			Name: a.Name,
+			Selected: slices.ContainsFunc(found.Edges.Authors, func(bookAuthor *ent.Author) bool {
+				return bookAuthor.ID == a.ID
+			}),
+		})
 	}
 
+	if err := srv.Templ.ExecuteTemplate(w, "books_edit.html", data); err != nil {
+		log.Printf("ERROR: books_edit.html: %s", err)
+		return
+	}
+}
+
+// updateBook replaces the title, date and authors of the book,
+// an empty date marks it unknown.
+func (srv *Service) updateBook(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+	editPath := "/books/" + strconv.FormatInt(found.ID, 10) + "/edit"
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var form bookForm
+	if err := binder.Decode(&form, r.PostForm); err != nil {
+		srv.withError(w, r, editPath, err)
+		return
+	}
+	if form.Title == "" {
+		srv.withError(w, r, editPath, errors.New("book title is required"))
+		return
+	}
+	if form.WrittenAt.After(time.Now()) {
+		srv.withError(w, r, editPath, errors.New("book written in future"))
+		return
+	}
+
+	meta := &library.BookMetadata{
+		Title:   form.Title,
+		CoverID: found.CoverID,
+		FileID:  found.FileID,
+		Pages:   found.Pages,
+		Authors: form.Authors,
+	}
+	if !form.WrittenAt.IsZero() {
+		meta.WrittenAt = form.WrittenAt.Unix()
+	}
+
+	updated, err := srv.library().UpdateBook(r.Context(), found.ID, meta)
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		http.NotFound(w, r)
+		return
+	case ent.IsValidationError(err):
+		srv.withError(w, r, editPath, err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.setFlash(w, "the book is updated")
+	http.Redirect(w, r, "/books/"+strconv.FormatInt(updated.ID, 10), http.StatusSeeOther)
+}
+
+func (srv *Service) getAuthorForm(w http.ResponseWriter, r *http.Request) {
+	data := srv.page(w, r)
+
 	if err := srv.Templ.ExecuteTemplate(w, "authors_create.html", data); err != nil {
 		log.Printf("ERROR: authors_create.html: %s", err)
 		return
@@ -204,7 +854,7 @@ func (srv *Service) createAuthor(w http.ResponseWriter, r *http.Request) {
 	var form authorForm
 	errForm := binder.Decode(&form, r.PostForm)
 	if errForm != nil {
-		withError(w, r, "/authors/new", errForm)
+		srv.withError(w, r, "/authors/new", errForm)
 		return
 	}
 
@@ -222,6 +872,14 @@ func (srv *Service) createAuthor(w http.ResponseWriter, r *http.Request) {
 	srv.listAuthors(w, r)
 }
 
+type authorsView struct {
+	Authors []*ent.Author
+	// CanFollow is set for logged in users, Followed are their authors by ID.
+	CanFollow bool
+	Followed  map[int64]bool
+	page
+}
+
 func (srv *Service) listAuthors(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	authors, err := srv.Storage.Author.Query().All(ctx)
@@ -230,23 +888,45 @@ func (srv *Service) listAuthors(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := srv.Templ.ExecuteTemplate(w, "authors", authors); err != nil {
+	data := &authorsView{
+		Authors: authors,
+		page:    srv.page(w, r),
+	}
+	if current := currentUser(ctx); current != nil && currentToken(ctx) == nil {
+		ids, errFollowed := current.QueryFollowedAuthors().IDs(ctx)
+		if errFollowed != nil {
+			http.Error(w, "db: "+errFollowed.Error(), http.StatusInternalServerError)
+			return
+		}
+		data.CanFollow = true
+		data.Followed = map[int64]bool{}
+		for _, id := range ids {
+			data.Followed[id] = true
+		}
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "authors", data); err != nil {
 		log.Printf("ERROR: template: %v", err)
 		return
 	}
 }
 
-func withError(rw http.ResponseWriter, req *http.Request, to string, err error) {
-	if err == nil {
+func (srv *Service) deleteAuthor(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
 		return
 	}
 
-	dst := &url.URL{
-		Path: to,
-		RawQuery: url.Values{
-			"error": {err.Error()},
-		}.Encode(),
+	err := srv.library().DeleteAuthor(r.Context(), id)
+	switch {
+	case ent.IsNotFound(err):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	http.Redirect(rw, req, dst.String(), http.StatusSeeOther)
+	http.Redirect(w, r, "/authors", http.StatusSeeOther)
 }
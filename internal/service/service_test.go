@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/bookinfo"
+	"github.com/ninedraft/bibliotheca/storage/database/dbtest"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+	"github.com/ninedraft/bibliotheca/storage/files"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+)
+
+// newService returns a service on an empty database of the driver.
+func newService(t *testing.T, driver string) *Service {
+	t.Helper()
+	db, dbDialect := dbtest.Open(t, driver)
+	if err := (&migrations.Migrator{DB: db, Dialect: dbDialect}).Up(context.Background(), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	return &Service{
+		Storage: ent.NewClient(ent.Driver(sql.OpenDB(dbDialect, db))),
+		Files:   &files.Store{Dir: t.TempDir()},
+		Signer:  &auth.Signer{Key: []byte("0123456789abcdef0123456789abcdef")},
+	}
+}
+
+// forEachService runs the test as a subtest for every driver
+// of dbtest.Drivers with a service on an empty database.
+func forEachService(t *testing.T, test func(t *testing.T, srv *Service)) {
+	for _, driver := range dbtest.Drivers {
+		t.Run(driver, func(t *testing.T) {
+			test(t, newService(t, driver))
+		})
+	}
+}
+
+// newUser creates a user with the role.
+func newUser(t *testing.T, srv *Service, login string, role user.Role) *ent.User {
+	t.Helper()
+	created, err := srv.Storage.User.Create().
+		SetLogin(login).
+		SetPasswordHash("-").
+		SetRole(role).
+		Save(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created
+}
+
+// newSession stores a session of the user and returns its cookie value.
+func newSession(t *testing.T, srv *Service, owner *ent.User, expires time.Time) string {
+	t.Helper()
+	token, err := auth.NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	errSession := srv.Storage.Session.Create().
+		SetTokenHash(auth.HashToken(token)).
+		SetExpiresAt(expires.Unix()).
+		SetUser(owner).
+		Exec(context.Background())
+	if errSession != nil {
+		t.Fatal(errSession)
+	}
+	return token
+}
+
+func TestEmbedFB2Metadata(t *testing.T) {
+	src, err := os.ReadFile("../bookinfo/testdata/example.fb2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	written := time.Date(1999, 3, 4, 0, 0, 0, 0, time.UTC)
+	fileDate := time.Date(2011, 7, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		book      *ent.Book
+		written   time.Time
+		unchanged bool
+	}{
+		{
+			name: "edited",
+			book: &ent.Book{Title: "New Title", WrittenAt: written.Unix(), Edges: ent.BookEdges{
+				Authors: []*ent.Author{{Name: "Ann Lee"}, {Name: "John Doe"}},
+			}},
+			written: written,
+		},
+		{
+			name: "unknown date",
+			book: &ent.Book{Title: "New Title", Edges: ent.BookEdges{
+				Authors: []*ent.Author{{Name: "John Doe"}},
+			}},
+			written: fileDate,
+		},
+		{
+			name: "unchanged",
+			book: &ent.Book{Title: "Fiction Book", WrittenAt: fileDate.Unix(), Edges: ent.BookEdges{
+				Authors: []*ent.Author{{Name: "John Doe"}},
+			}},
+			written:   fileDate,
+			unchanged: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			embedded, err := embedFB2Metadata(bytes.NewReader(src), tc.book)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := io.ReadAll(embedded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.unchanged != bytes.Equal(out, src) {
+				t.Errorf("unchanged: got %v, want %v", !tc.unchanged, tc.unchanged)
+			}
+
+			got, err := bookinfo.ParseFB2(bytes.NewReader(out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var authors []string
+			for _, author := range tc.book.Edges.Authors {
+				authors = append(authors, author.Name)
+			}
+			if got.Title != tc.book.Title || !slices.Equal(got.Authors, authors) || !got.WrittenAt.Equal(tc.written) {
+				t.Errorf("got %q by %q written at %v", got.Title, got.Authors, got.WrittenAt)
+			}
+			// metadata the catalog doesn't keep comes from the file
+			if got.Annotation != "Hello" || got.Language != "en" {
+				t.Errorf("lost metadata: %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+type shelfOption struct {
+	Value string
+	Name  string
+}
+
+// filterUnread lists books the user hasn't finished.
+const filterUnread = "unread"
+
+// shelfFilters returns options of the shelf filter of the book list.
+func shelfFilters(shelves []*ent.Shelf) []shelfOption {
+	options := []shelfOption{{Value: filterUnread, Name: "Not read by me"}}
+	for _, kind := range library.StatusShelves {
+		options = append(options, shelfOption{Value: string(kind), Name: library.StatusNames[kind]})
+	}
+	for _, item := range shelves {
+		if item.Kind == shelf.KindCustom {
+			options = append(options, shelfOption{Value: strconv.FormatInt(item.ID, 10), Name: item.Name})
+		}
+	}
+	return options
+}
+
+// shelfPredicate filters books by the value of the shelf filter, the value
+// is a status shelf kind, an id of a custom shelf or filterUnread.
+func shelfPredicate(userID int64, filter string) (predicate.Book, bool) {
+	ofUser := shelf.HasUserWith(user.ID(userID))
+	if filter == filterUnread {
+		return book.Not(book.HasShelfEntriesWith(
+			shelfentry.HasShelfWith(ofUser, shelf.KindEQ(shelf.KindFinished)),
+		)), true
+	}
+	if _, ok := library.StatusNames[shelf.Kind(filter)]; ok {
+		return book.HasShelfEntriesWith(
+			shelfentry.HasShelfWith(ofUser, shelf.KindEQ(shelf.Kind(filter))),
+		), true
+	}
+	if id, err := strconv.ParseInt(filter, 10, 64); err == nil {
+		return book.HasShelfEntriesWith(
+			shelfentry.HasShelfWith(ofUser, shelf.ID(id)),
+		), true
+	}
+	return nil, false
+}
+
+type shelvedBook struct {
+	Book  *ent.Book
+	Added string
+}
+
+type shelfView struct {
+	// ID is 0 for status shelves without books yet.
+	ID     int64
+	Name   string
+	Custom bool
+	Books  []shelvedBook
+}
+
+type shelvesView struct {
+	Shelves []shelfView
+	page
+}
+
+// listShelves shows shelves of the current user with their books,
+// status shelves go first.
+func (srv *Service) listShelves(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	shelves, err := currentUser(ctx).QueryShelves().
+		WithEntries(func(query *ent.ShelfEntryQuery) {
+			query.Where(shelfentry.HasBookWith(book.DeletedAtIsNil())).
+				WithBook().
+				Order(ent.Desc(shelfentry.FieldAddedAt), ent.Desc(shelfentry.FieldID))
+		}).
+		Order(ent.Asc(shelf.FieldName)).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	byKind := map[shelf.Kind]*ent.Shelf{}
+	for _, item := range shelves {
+		if item.Kind != shelf.KindCustom {
+			byKind[item.Kind] = item
+		}
+	}
+
+	data := &shelvesView{page: srv.page(w, r)}
+	for _, kind := range library.StatusShelves {
+		view := shelfView{Name: library.StatusNames[kind]}
+		if found := byKind[kind]; found != nil {
+			view = newShelfView(found)
+		}
+		data.Shelves = append(data.Shelves, view)
+	}
+	for _, item := range shelves {
+		if item.Kind == shelf.KindCustom {
+			data.Shelves = append(data.Shelves, newShelfView(item))
+		}
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "shelves.html", data); err != nil {
+		log.Printf("ERROR: shelves.html: %s", err)
+		return
+	}
+}
+
+func newShelfView(item *ent.Shelf) shelfView {
+	view := shelfView{
+		ID:     item.ID,
+		Name:   item.Name,
+		Custom: item.Kind == shelf.KindCustom,
+	}
+	for _, entry := range item.Edges.Entries {
+		view.Books = append(view.Books, shelvedBook{
+			Book:  entry.Edges.Book,
+			Added: formatTime(entry.AddedAt),
+		})
+	}
+	return view
+}
+
+func (srv *Service) createShelf(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := strings.TrimSpace(r.PostFormValue("name"))
+
+	_, err := srv.library().CreateShelf(ctx, currentUser(ctx).ID, name)
+	switch {
+	case errors.Is(err, library.ErrShelfExists), ent.IsValidationError(err):
+		srv.withError(w, r, "/shelves", err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/shelves", http.StatusSeeOther)
+}
+
+func (srv *Service) deleteShelf(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	ctx := r.Context()
+
+	err := srv.library().DeleteShelf(ctx, currentUser(ctx).ID, id)
+	switch {
+	case errors.Is(err, library.ErrShelfNotFound):
+		http.NotFound(w, r)
+		return
+	case errors.Is(err, library.ErrStatusShelf):
+		srv.withError(w, r, "/shelves", err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/shelves", http.StatusSeeOther)
+}
+
+// addToShelf puts the book from the form on the shelf
+// and returns to the next page, the book page by default.
+func (srv *Service) addToShelf(w http.ResponseWriter, r *http.Request) {
+	srv.updateShelf(w, r, true)
+}
+
+func (srv *Service) removeFromShelf(w http.ResponseWriter, r *http.Request) {
+	srv.updateShelf(w, r, false)
+}
+
+func (srv *Service) updateShelf(w http.ResponseWriter, r *http.Request, add bool) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+	bookID, errBook := strconv.ParseInt(r.PostFormValue("book"), 10, 64)
+	if errBook != nil {
+		http.Error(w, "form: book is required", http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	userID := currentUser(ctx).ID
+
+	var err error
+	if add {
+		err = srv.library().AddToShelf(ctx, userID, id, bookID)
+	} else {
+		err = srv.library().RemoveFromShelf(ctx, userID, id, bookID)
+	}
+	switch {
+	case errors.Is(err, library.ErrShelfNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	bookPage := "/books/" + strconv.FormatInt(bookID, 10)
+	http.Redirect(w, r, localPath(r.PostFormValue("next"), bookPage), http.StatusSeeOther)
+}
+
+// setReadingStatus moves the book to a status shelf,
+// an empty status takes it off them.
+func (srv *Service) setReadingStatus(w http.ResponseWriter, r *http.Request) {
+	found, ok := srv.bookByID(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+	bookPage := "/books/" + strconv.FormatInt(found.ID, 10)
+
+	err := srv.library().SetReadingStatus(ctx, currentUser(ctx).ID, found.ID, shelf.Kind(r.PostFormValue("status")))
+	switch {
+	case errors.Is(err, library.ErrShelfNotFound):
+		srv.withError(w, r, bookPage, err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, bookPage, http.StatusSeeOther)
+}
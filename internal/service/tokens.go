@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/apitoken"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+type tokensView struct {
+	Tokens []*ent.APIToken
+	// Scopes the user can grant, a token can't do more than its owner.
+	Scopes []auth.Permission
+	// Created is the secret of the just created token, it's shown once.
+	Created string
+	Error   string
+	page
+}
+
+func (view *tokensView) List() []any {
+	type tokenView struct {
+		ID       int64
+		Name     string
+		Scopes   []string
+		Created  string
+		LastUsed string
+	}
+
+	var list []any
+	for _, token := range view.Tokens {
+		lastUsed := "never"
+		if token.LastUsedAt != 0 {
+			lastUsed = time.Unix(token.LastUsedAt, 0).Format(time.DateTime)
+		}
+		list = append(list, tokenView{
+			ID:       token.ID,
+			Name:     token.Name,
+			Scopes:   token.Scopes,
+			Created:  time.Unix(token.CreatedAt, 0).Format(time.DateTime),
+			LastUsed: lastUsed,
+		})
+	}
+
+	return list
+}
+
+func (srv *Service) listTokens(w http.ResponseWriter, r *http.Request) {
+	srv.renderTokens(w, r, http.StatusOK, &tokensView{})
+}
+
+func (srv *Service) renderTokens(w http.ResponseWriter, r *http.Request, status int, view *tokensView) {
+	ctx := r.Context()
+	owner := currentUser(ctx)
+
+	tokens, err := srv.Storage.APIToken.Query().
+		Where(apitoken.HasUserWith(user.ID(owner.ID))).
+		Order(ent.Desc(apitoken.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	view.Tokens = tokens
+	view.Scopes = grantableScopes(owner)
+	view.page = srv.page(w, r)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := srv.Templ.ExecuteTemplate(w, "tokens.html", view); err != nil {
+		log.Printf("ERROR: tokens.html: %s", err)
+	}
+}
+
+func grantableScopes(owner *ent.User) []auth.Permission {
+	var scopes []auth.Permission
+	for _, perm := range auth.Permissions {
+		if auth.Allowed(owner.Role, perm) {
+			scopes = append(scopes, perm)
+		}
+	}
+	return scopes
+}
+
+var (
+	errTokenName   = errors.New("token name is required")
+	errTokenScopes = errors.New("select at least one scope")
+)
+
+func (srv *Service) createToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "form: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+	owner := currentUser(ctx)
+
+	name := r.PostForm.Get("name")
+	scopes := r.PostForm["scope"]
+	granted := grantableScopes(owner)
+	for _, scope := range scopes {
+		if !slices.Contains(granted, auth.Permission(scope)) {
+			http.Error(w, "unknown scope "+strconv.Quote(scope), http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch {
+	case name == "":
+		srv.renderTokens(w, r, http.StatusBadRequest, &tokensView{Error: errTokenName.Error()})
+		return
+	case len(scopes) == 0:
+		srv.renderTokens(w, r, http.StatusBadRequest, &tokensView{Error: errTokenScopes.Error()})
+		return
+	}
+
+	secret, errSecret := auth.NewToken()
+	if errSecret != nil {
+		http.Error(w, errSecret.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	errCreate := srv.Storage.APIToken.Create().
+		SetName(name).
+		SetTokenHash(auth.HashToken(secret)).
+		SetScopes(scopes).
+		SetUser(owner).
+		Exec(ctx)
+	if errCreate != nil {
+		http.Error(w, "db: "+errCreate.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.renderTokens(w, r, http.StatusOK, &tokensView{Created: secret})
+}
+
+func (srv *Service) revokeToken(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	n, err := srv.Storage.APIToken.Delete().
+		Where(
+			apitoken.ID(id),
+			apitoken.HasUserWith(user.ID(currentUser(r.Context()).ID)),
+		).
+		Exec(r.Context())
+	switch {
+	case err != nil:
+		http.Error(w, "db: "+err.Error(), http.StatusInternalServerError)
+		return
+	case n == 0:
+		http.NotFound(w, r)
+		return
+	}
+
+	http.Redirect(w, r, "/account/tokens", http.StatusSeeOther)
+}
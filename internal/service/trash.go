@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/library"
+)
+
+const (
+	trashInterval = time.Hour
+	// orphanGrace keeps recently stored files, their books may be not saved yet.
+	orphanGrace = 24 * time.Hour
+)
+
+type trashItem struct {
+	ID      int64
+	Title   string
+	Authors string
+	Deleted string
+	// Purge is when the retention job purges the book, empty if it doesn't.
+	Purge string
+}
+
+type trashView struct {
+	Books []trashItem
+	page
+}
+
+// getTrash lists deleted books, recently deleted first.
+func (srv *Service) getTrash(w http.ResponseWriter, r *http.Request) {
+	books, err := srv.library().Trash(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := &trashView{page: srv.page(w, r)}
+	for _, item := range books {
+		var authors []string
+		for _, author := range item.Edges.Authors {
+			authors = append(authors, author.Name)
+		}
+		view := trashItem{
+			ID:      item.ID,
+			Title:   item.Title,
+			Authors: strings.Join(authors, ", "),
+			Deleted: formatTime(*item.DeletedAt),
+		}
+		if srv.TrashRetention > 0 {
+			view.Purge = formatTime(*item.DeletedAt + int64(srv.TrashRetention/time.Second))
+		}
+		data.Books = append(data.Books, view)
+	}
+
+	if err := srv.Templ.ExecuteTemplate(w, "trash.html", data); err != nil {
+		log.Printf("ERROR: trash.html: %s", err)
+		return
+	}
+}
+
+func (srv *Service) restoreBook(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	restored, err := srv.library().RestoreBook(r.Context(), id)
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.setFlash(w, restored.Title+" is restored")
+	http.Redirect(w, r, "/trash", http.StatusSeeOther)
+}
+
+func (srv *Service) purgeBook(w http.ResponseWriter, r *http.Request) {
+	id, errID := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if errID != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err := srv.library().PurgeBook(r.Context(), id)
+	switch {
+	case errors.Is(err, library.ErrBookNotFound):
+		http.NotFound(w, r)
+		return
+	case errors.Is(err, library.ErrBookOnLoan):
+		srv.withError(w, r, "/trash", err)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	srv.setFlash(w, "the book is deleted for good")
+	http.Redirect(w, r, "/trash", http.StatusSeeOther)
+}
+
+// emptyTrash purges books kept in the trash longer than the retention
+// and removes stored files no book refers to.
+func (srv *Service) emptyTrash(ctx context.Context) error {
+	if srv.TrashRetention > 0 {
+		n, err := srv.library().PurgeTrash(ctx, time.Now().Add(-srv.TrashRetention))
+		if n > 0 {
+			log.Printf("purged %d deleted books", n)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	n, size, err := srv.library().CollectGarbage(ctx, time.Now().Add(-orphanGrace))
+	if n > 0 {
+		log.Printf("removed %d unused files, %d KB", n, size>>10)
+	}
+	return err
+}
@@ -2,24 +2,12 @@ package main
 
 import (
 	"context"
-	dbsql "database/sql"
 	"embed"
-	"errors"
-	"flag"
-	"html/template"
-	"log"
-	"net/http"
+	"fmt"
 	"os"
 	"os/signal"
-	"time"
-
-	"entgo.io/ent/dialect"
-	"entgo.io/ent/dialect/sql"
-	"github.com/go-chi/chi/v5"
-	"github.com/ninedraft/bibliotheca/internal/service"
-	"github.com/ninedraft/bibliotheca/storage/ent"
-
-	_ "modernc.org/sqlite"
+	"strings"
+	"syscall"
 )
 
 var (
@@ -28,76 +16,60 @@ var (
 
 	//go:embed templ/*
 	assetsFS embed.FS
-	assets   = template.Must(template.ParseFS(assetsFS, "templ/*.html"))
 )
 
-func main() {
-	addr := "localhost:8080"
-	flag.StringVar(&addr, "addr", addr, "server address")
-	flag.Parse()
-
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
-	defer cancel()
-
-	go func() {
-		<-ctx.Done()
-		time.Sleep(5 * time.Second)
-		log.Println("force shutdown")
-		os.Exit(1)
-	}()
-
-	db, errOpenDB := dbsql.Open("sqlite",
-		"file:bib.sqlite?cache=shared&_pragma=foreign_keys(1)")
-	if errOpenDB != nil {
-		panic("db: " + errOpenDB.Error())
-	}
-	defer func() { _ = db.Close() }()
+type command struct {
+	run   func(args []string)
+	usage string
+}
 
-	drv := sql.OpenDB(dialect.SQLite, db)
-	defer func() { _ = drv.Close() }()
+var commands = map[string]command{
+	"serve":   {runServe, "start the web server, the default command"},
+	"import":  {runImport, "add book files or directories to the catalog"},
+	"export":  {runExport, "write the catalog as JSON, optionally with book files"},
+	"migrate": {runMigrate, "apply or inspect database migrations"},
+	"reindex": {runReindex, "rebuild the full-text search index"},
+	"check":   {runCheck, "check stored files and FB2 documents of all books"},
+	"user":    {runUser, "manage user accounts"},
+	"config":  {runConfig, "print the effective config"},
+}
 
-	client := ent.NewClient(
-		ent.Driver(drv),
-		ent.Log(log.Println),
-	)
-	defer func() { _ = client.Close() }()
+var commandOrder = []string{"serve", "import", "export", "migrate", "reindex", "check", "user", "config"}
 
-	if errMigrate := client.Schema.Create(ctx); errMigrate != nil {
-		panic("migrate: " + errMigrate.Error())
+func main() {
+	name, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
 	}
 
-	srv := &service.Service{
-		Storage: client,
-		Static:  static,
-		Templ:   assets,
-	}
-	mux := chi.NewMux().With(logMW)
-
-	srv.BuildRoutes(mux)
-
-	log.Printf("starting server at %s", addr)
-	server := &http.Server{
-		ReadHeaderTimeout: 5 * time.Second,
-		Addr:              addr,
-		Handler:           mux,
+	cmd, ok := commands[name]
+	if !ok {
+		usage()
+		if name != "help" {
+			os.Exit(2)
+		}
+		return
 	}
+	cmd.run(args)
+}
 
+// signalContext is canceled by the first interrupt or termination signal,
+// the next one kills the process.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-ctx.Done()
-		log.Println("shutting down service")
-		_ = server.Shutdown(context.Background())
+		stop()
 	}()
-
-	errServe := server.ListenAndServe()
-	if errServe != nil && !errors.Is(errServe, http.ErrServerClosed) {
-		panic("server: " + errServe.Error())
-	}
+	return ctx, stop
 }
 
-func logMW(next http.Handler) http.Handler {
-	var handle http.HandlerFunc = func(rw http.ResponseWriter, req *http.Request) {
-		log.Printf("HTTP: %s %s", req.Method, req.URL)
-		next.ServeHTTP(rw, req)
+func usage() {
+	out := os.Stderr
+	_, _ = fmt.Fprintln(out, "usage: bibliotheca <command> [flags] [args]")
+	_, _ = fmt.Fprintln(out, "\ncommands:")
+	for _, name := range commandOrder {
+		_, _ = fmt.Fprintf(out, "  %-8s %s\n", name, commands[name].usage)
 	}
-	return handle
+	_, _ = fmt.Fprintln(out, "\nrun bibliotheca <command> -h for command flags")
 }
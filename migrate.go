@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/storage/database"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+)
+
+func runMigrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "usage: bibliotheca migrate [flags] up|status|dry-run")
+		flags.PrintDefaults()
+	}
+	cfgFlags := registerConfigFlags(flags)
+	baseline := ""
+	flags.StringVar(&baseline, "baseline", baseline,
+		"mark migrations up to this version as applied, for databases created before versioned migrations")
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	cfg := cfgFlags.load(flags)
+
+	if err := migrateDB(context.Background(), cfg, flags.Arg(0), baseline); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// migrateDB runs the migrate command: up, status or dry-run.
+func migrateDB(ctx context.Context, cfg *config.Config, cmd, baseline string) error {
+	db, dbDialect, errOpenDB := database.Open(cfg.Database.Driver, cfg.Database.DSN)
+	if errOpenDB != nil {
+		return fmt.Errorf("db: %w", errOpenDB)
+	}
+	defer func() { _ = db.Close() }()
+
+	migrator := &migrations.Migrator{DB: db, Dialect: dbDialect, Log: os.Stdout}
+
+	switch cmd {
+	case "up":
+		return migrator.Up(ctx, baseline)
+	case "status":
+		status, errStatus := migrator.Status(ctx)
+		if errStatus != nil {
+			return errStatus
+		}
+		printMigrationStatus(status)
+	case "dry-run":
+		status, errStatus := migrator.Status(ctx)
+		if errStatus != nil {
+			return errStatus
+		}
+		if status.Unversioned {
+			fmt.Println("-- database has no migration history, migrate it with -baseline")
+		}
+		for _, file := range status.Pending {
+			stmts, errStmts := file.Stmts()
+			if errStmts != nil {
+				return fmt.Errorf("%s: %w", file.Name(), errStmts)
+			}
+			fmt.Printf("-- %s\n", file.Name())
+			for _, stmt := range stmts {
+				fmt.Println(stmt)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q", cmd)
+	}
+	return nil
+}
+
+func printMigrationStatus(status *migrations.Status) {
+	for _, revision := range status.Applied {
+		state := "applied"
+		switch {
+		case revision.Error != "":
+			state = "failed: " + revision.Error
+		case revision.Applied < revision.Total:
+			state = fmt.Sprintf("partially applied %d/%d", revision.Applied, revision.Total)
+		case revision.Type == migrate.RevisionTypeBaseline:
+			state = "baseline"
+		}
+		fmt.Printf("%s %s\t%s at %s\n", revision.Version, revision.Description,
+			state, revision.ExecutedAt.Format(time.DateTime))
+	}
+	for _, file := range status.Pending {
+		fmt.Printf("%s %s\tpending\n", file.Version(), file.Desc())
+	}
+	if status.Unversioned {
+		fmt.Println("database has no migration history, migrate it with -baseline <version of its schema>")
+	}
+}
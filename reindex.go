@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/internal/jobs"
+)
+
+func runReindex(args []string) {
+	flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+	cfgFlags := registerConfigFlags(flags)
+	restart := false
+	flags.BoolVar(&restart, "restart", restart, "start over instead of continuing an interrupted reindex")
+	_ = flags.Parse(args)
+	cfg := cfgFlags.load(flags)
+
+	if !cfg.Features.Search {
+		log.Fatal("full-text search is disabled by features.search")
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	n, errReindex := reindex(ctx, cfg, restart)
+	switch {
+	case errors.Is(errReindex, context.Canceled):
+		fmt.Printf("interrupted after %d books, run reindex again to continue\n", n)
+		os.Exit(1)
+	case errReindex != nil:
+		log.Fatal(errReindex)
+	}
+	fmt.Printf("indexed %d books\n", n)
+}
+
+// reindex indexes books from the checkpoint or from the start
+// and returns the number of indexed books.
+func reindex(ctx context.Context, cfg *config.Config, restart bool) (int, error) {
+	st, errStorage := openStorage(ctx, cfg)
+	if errStorage != nil {
+		return 0, errStorage
+	}
+	defer func() { _ = st.Close() }()
+
+	progress := &jobs.Checkpoint{Storage: st.client, Name: "reindex"}
+	if restart {
+		if errClear := progress.Clear(ctx); errClear != nil {
+			return 0, errClear
+		}
+	}
+	return st.library.Reindex(ctx, progress)
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/internal/jobs"
+	"github.com/ninedraft/bibliotheca/internal/service"
+)
+
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	cfgFlags := registerConfigFlags(flags)
+	flags.String("addr", "", "server address, overrides server.addr")
+	flags.String("files", "", "directory for book files and covers, overrides storage.files")
+	flags.Bool("auto-migrate", false, "apply pending migrations instead of refusing to start, overrides database.auto_migrate")
+	_ = flags.Parse(args)
+	cfg := cfgFlags.load(flags)
+
+	if cfg.Log.File != "" {
+		logFile, errLog := os.OpenFile(cfg.Log.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if errLog != nil {
+			panic("log: " + errLog.Error())
+		}
+		defer func() { _ = logFile.Close() }()
+		log.SetOutput(logFile)
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	st, errStorage := openStorage(ctx, cfg)
+	if errStorage != nil {
+		log.Fatal(errStorage)
+	}
+
+	templ, staticFS, errAssets := loadAssets(cfg.Storage)
+	if errAssets != nil {
+		panic("assets: " + errAssets.Error())
+	}
+
+	signer, errSigner := newSigner(cfg.Auth.Secret)
+	if errSigner != nil {
+		log.Fatal(errSigner)
+	}
+
+	srv := &service.Service{
+		Storage:          st.client,
+		Search:           st.library.Search,
+		Files:            st.library.Files,
+		Static:           staticFS,
+		Templ:            templ,
+		EmbedMetadata:    cfg.Features.EmbedMetadata,
+		ValidationReport: cfg.Features.ValidationReport,
+		PublicBrowsing:   cfg.Auth.PublicBrowsing,
+		SessionTTL:       cfg.Auth.SessionTTL,
+		SecureCookie:     cfg.Auth.SecureCookie,
+		Signer:           signer,
+		PickupWindow:     cfg.Circulation.PickupWindow,
+		Outbox:           st.outbox,
+		DueReminder:      cfg.Mail.DueReminder,
+		Converter:        st.library.Converter,
+		MaxFileSize:      cfg.Devices.MaxFileSize,
+		TrashRetention:   cfg.Storage.TrashRetention,
+	}
+	mux := chi.NewMux()
+	if cfg.Log.Requests {
+		mux.Use(logMW)
+	}
+
+	srv.BuildRoutes(mux)
+
+	workers := &jobs.Runner{Jobs: srv.Jobs()}
+	workers.Start(context.Background())
+
+	log.Printf("starting server at %s", cfg.Server.Addr)
+	requests := &handlers{}
+	server := &http.Server{
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		Addr:              cfg.Server.Addr,
+		Handler:           requests.track(mux),
+	}
+
+	errServe := make(chan error, 1)
+	go func() {
+		errServe <- server.ListenAndServe()
+	}()
+
+	var errStart error
+	select {
+	case errStart = <-errServe:
+		log.Printf("ERROR: server: %v", errStart)
+	case <-ctx.Done():
+		log.Println("shutting down service")
+	}
+
+	shutdown(cfg.Server.ShutdownTimeout, server, requests, workers, st)
+	if errStart != nil {
+		os.Exit(1)
+	}
+}
+
+// shutdown stops accepting requests and background jobs and waits for
+// running ones, then closes the database, all within the timeout. Requests
+// still running at the timeout are aborted. The database is closed only
+// after handlers and jobs return, otherwise it's left to the process exit.
+func shutdown(timeout time.Duration, server *http.Server, requests *handlers, workers *jobs.Runner, st *storage) {
+	stopped := true
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// jobs don't serve requests, so they stop while requests drain
+	errJobs := make(chan error, 1)
+	go func() { errJobs <- workers.Stop(ctx) }()
+
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("ERROR: shutdown: %v, closing connections", err)
+		_ = server.Close()
+
+		// handlers of closed connections get a canceled context,
+		// they are waited for while the timeout lasts
+		if errWait := requests.wait(ctx); errWait != nil {
+			log.Printf("ERROR: shutdown: requests: %v", errWait)
+			stopped = false
+		}
+	}
+
+	if err := <-errJobs; err != nil {
+		log.Printf("ERROR: shutdown: jobs: %v", err)
+		stopped = false
+	}
+
+	if !stopped {
+		log.Println("service stopped, leaving the database open to running work")
+		return
+	}
+	if err := st.Close(); err != nil {
+		log.Printf("ERROR: shutdown: db: %v", err)
+	}
+	log.Println("service stopped")
+}
+
+// handlers counts running request handlers, server.Close doesn't wait for them.
+type handlers struct {
+	running sync.WaitGroup
+}
+
+func (h *handlers) track(next http.Handler) http.Handler {
+	var handle http.HandlerFunc = func(w http.ResponseWriter, r *http.Request) {
+		h.running.Add(1)
+		defer h.running.Done()
+		next.ServeHTTP(w, r)
+	}
+	return handle
+}
+
+// wait waits for running handlers to return until the context is done.
+func (h *handlers) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loadAssets returns templates and static files,
+// directories from the config take precedence over embedded ones.
+func loadAssets(cfg config.Storage) (*template.Template, fs.FS, error) {
+	templFS, errTempl := fs.Sub(assetsFS, "templ")
+	if errTempl != nil {
+		return nil, nil, errTempl
+	}
+	if cfg.Templates != "" {
+		templFS = os.DirFS(cfg.Templates)
+	}
+	templ, errParse := template.ParseFS(templFS, "*.html")
+	if errParse != nil {
+		return nil, nil, fmt.Errorf("templates: %w", errParse)
+	}
+
+	staticFS, errStatic := fs.Sub(static, "static")
+	if errStatic != nil {
+		return nil, nil, errStatic
+	}
+	if cfg.Static != "" {
+		staticFS = os.DirFS(cfg.Static)
+	}
+
+	return templ, staticFS, nil
+}
+
+func logMW(next http.Handler) http.Handler {
+	var handle http.HandlerFunc = func(rw http.ResponseWriter, req *http.Request) {
+		log.Printf("HTTP: %s %s", req.Method, req.URL)
+		next.ServeHTTP(rw, req)
+	}
+	return handle
+}
+
+func newSigner(secret string) (*auth.Signer, error) {
+	if secret != "" {
+		return &auth.Signer{Key: []byte(secret)}, nil
+	}
+	log.Printf("auth.secret is not set, using a random one: KOReader sync passwords have to be set again after a restart")
+	key, err := auth.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Signer{Key: key}, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/bibliotheca/internal/jobs"
+)
+
+func TestShutdownTimeout(t *testing.T) {
+	st := newStorage(t, testConfig(t))
+	release := make(chan struct{})
+	defer close(release)
+
+	// a request and a job ignoring cancellation
+	requests := &handlers{}
+	started := make(chan struct{}, 2)
+	server := &http.Server{Handler: requests.track(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		started <- struct{}{}
+		<-release
+	}))}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = server.Serve(listener) }()
+	go func() {
+		if resp, err := http.Get("http://" + listener.Addr().String()); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	workers := &jobs.Runner{Jobs: []jobs.Job{{
+		Name:     "stuck",
+		Interval: time.Hour,
+		Run: func(context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+	}}}
+	workers.Start(context.Background())
+	<-started
+	<-started
+
+	// the timeout limits the whole shutdown, not each of its steps
+	const timeout = 200 * time.Millisecond
+	start := time.Now()
+	shutdown(timeout, server, requests, workers, st)
+	if elapsed := time.Since(start); elapsed > timeout+timeout/2 {
+		t.Errorf("shutdown took %v with the timeout of %v", elapsed, timeout)
+	}
+}
@@ -0,0 +1,118 @@
+// Highlights of the web reader. Text blocks of the chapter carry
+// data-anchor, highlights are stored as the anchor with offsets
+// in the text of the block.
+(function () {
+    "use strict";
+
+    var reader = document.getElementById("reader");
+    if (!reader) {
+        return;
+    }
+    var api = reader.dataset.api;
+    var csrf = reader.dataset.csrf;
+    var status = document.getElementById("highlight-status");
+
+    function blockOf(node) {
+        var element = node.nodeType === Node.ELEMENT_NODE ? node : node.parentElement;
+        return element ? element.closest("[data-anchor]") : null;
+    }
+
+    // offsetIn returns the offset of the boundary in the text of the block.
+    function offsetIn(block, node, offset) {
+        var range = document.createRange();
+        range.setStart(block, 0);
+        range.setEnd(node, offset);
+        return range.toString().length;
+    }
+
+    // boundaryAt returns the text node and the offset in it
+    // at the offset in the text of the block.
+    function boundaryAt(block, offset) {
+        var walker = document.createTreeWalker(block, NodeFilter.SHOW_TEXT);
+        var node, last = null;
+        while ((node = walker.nextNode())) {
+            if (offset <= node.length) {
+                return { node: node, offset: offset };
+            }
+            offset -= node.length;
+            last = node;
+        }
+        return last ? { node: last, offset: last.length } : null;
+    }
+
+    function mark(item) {
+        var block = reader.querySelector('[data-anchor="' + CSS.escape(item.anchor) + '"]');
+        if (!block) {
+            return;
+        }
+        var start = boundaryAt(block, item.start);
+        var end = boundaryAt(block, item.end);
+        if (!start || !end) {
+            return;
+        }
+        var range = document.createRange();
+        range.setStart(start.node, start.offset);
+        range.setEnd(end.node, end.offset);
+
+        var wrapper = document.createElement("mark");
+        wrapper.className = "highlight " + item.color;
+        if (item.note) {
+            wrapper.title = item.note;
+        }
+        wrapper.appendChild(range.extractContents());
+        range.insertNode(wrapper);
+    }
+
+    function load() {
+        fetch(api, { credentials: "same-origin", headers: { "Accept": "application/json" } })
+            .then(function (resp) { return resp.json(); })
+            .then(function (items) { items.forEach(mark); })
+            .catch(function (err) { status.textContent = "Highlights are not loaded: " + err; });
+    }
+
+    function highlightSelection() {
+        var selection = window.getSelection();
+        if (!selection.rangeCount || selection.isCollapsed) {
+            status.textContent = "Select some text first.";
+            return;
+        }
+        var range = selection.getRangeAt(0);
+        var block = blockOf(range.startContainer);
+        if (!block || block !== blockOf(range.endContainer) || !reader.contains(block)) {
+            status.textContent = "Select text within one paragraph.";
+            return;
+        }
+        var item = {
+            anchor: block.dataset.anchor,
+            start: offsetIn(block, range.startContainer, range.startOffset),
+            end: offsetIn(block, range.endContainer, range.endOffset),
+            note: document.getElementById("highlight-note").value,
+            color: document.getElementById("highlight-color").value
+        };
+
+        fetch(api, {
+            method: "POST",
+            credentials: "same-origin",
+            headers: { "Content-Type": "application/json", "X-CSRF-Token": csrf },
+            body: JSON.stringify(item)
+        })
+            .then(function (resp) {
+                return resp.json().then(function (body) {
+                    if (!resp.ok) {
+                        throw new Error(body.error || resp.statusText);
+                    }
+                    return body;
+                });
+            })
+            .then(function (saved) {
+                selection.removeAllRanges();
+                document.getElementById("highlight-note").value = "";
+                mark(saved);
+                status.textContent = "Saved.";
+            })
+            .catch(function (err) { status.textContent = "Not saved: " + err.message; });
+    }
+
+    document.getElementById("highlight-button").addEventListener("click", highlightSelection);
+    load();
+})();
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/internal/convert"
+	"github.com/ninedraft/bibliotheca/internal/library"
+	"github.com/ninedraft/bibliotheca/internal/notify"
+	"github.com/ninedraft/bibliotheca/storage/database"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/hook"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/files"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+	"github.com/ninedraft/bibliotheca/storage/search"
+)
+
+// storage is the database and the file store shared by commands.
+type storage struct {
+	client  *ent.Client
+	library *library.Library
+	// outbox is nil if mail is not configured.
+	outbox *notify.Outbox
+}
+
+// openStorage connects to the database and refuses to work with
+// a schema behind migrations, unless auto migration is enabled.
+func openStorage(ctx context.Context, cfg *config.Config) (*storage, error) {
+	db, dbDialect, errOpenDB := database.Open(cfg.Database.Driver, cfg.Database.DSN)
+	if errOpenDB != nil {
+		return nil, fmt.Errorf("db: %w", errOpenDB)
+	}
+
+	migrator := &migrations.Migrator{DB: db, Dialect: dbDialect, Log: log.Writer()}
+	errCheck := migrator.Check(ctx)
+	switch {
+	case errors.Is(errCheck, migrations.ErrPending) && cfg.Database.AutoMigrate:
+		errCheck = migrator.Up(ctx, "")
+	case errors.Is(errCheck, migrations.ErrPending):
+		errCheck = fmt.Errorf("%w; run `migrate up` or enable database.auto_migrate", errCheck)
+	}
+	if errCheck != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrate: %w", errCheck)
+	}
+
+	if errFiles := os.MkdirAll(cfg.Storage.Files, 0o755); errFiles != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("files: %w", errFiles)
+	}
+
+	clientOptions := []ent.Option{
+		ent.Driver(sql.OpenDB(dbDialect, db)),
+		ent.Log(log.Println),
+	}
+	if cfg.Log.SQL {
+		clientOptions = append(clientOptions, ent.Debug())
+	}
+	client := ent.NewClient(clientOptions...)
+	// changes of the catalog are recorded to the audit log,
+	// revisions of metadata are kept to roll back edits
+	client.Book.Use(hook.Audit(), hook.Revisions())
+	client.Author.Use(hook.Audit(), hook.Revisions())
+	// deleted books are kept in the trash until purged
+	client.Book.Intercept(intercept.SoftDelete())
+
+	lib := &library.Library{
+		Storage: client,
+		Files:   &files.Store{Dir: cfg.Storage.Files},
+		Converter: &convert.Converter{
+			Command: cfg.Devices.ConvertCommand,
+			Timeout: cfg.Devices.ConvertTimeout,
+		},
+		MaxFileSize: cfg.Devices.MaxFileSize,
+	}
+	if cfg.Features.Search {
+		lib.Search = &search.Index{DB: db, Dialect: dbDialect}
+	}
+
+	var outbox *notify.Outbox
+	if cfg.Mail.SMTPAddr != "" {
+		outbox = newOutbox(cfg.Mail, client, lib.Files)
+		lib.Notifier = outbox
+		lib.Mailer = outbox
+		lib.MailAttempts = outbox.MaxAttempts
+	}
+
+	return &storage{client: client, library: lib, outbox: outbox}, nil
+}
+
+func newOutbox(cfg config.Mail, client *ent.Client, store *files.Store) *notify.Outbox {
+	return &notify.Outbox{
+		Storage: client,
+		Sender: &notify.SMTP{
+			Addr:              cfg.SMTPAddr,
+			From:              cfg.From,
+			Username:          cfg.Username,
+			Password:          cfg.Password,
+			ImplicitTLS:       cfg.ImplicitTLS,
+			AllowInsecureAuth: cfg.AllowInsecureAuth,
+		},
+		BaseURL:     cfg.BaseURL,
+		MaxAttempts: cfg.MaxAttempts,
+		Files:       store,
+	}
+}
+
+func (st *storage) Close() error {
+	// the client closes the shared *sql.DB
+	return st.client.Close()
+}
@@ -0,0 +1,49 @@
+// Package database opens SQL databases supported by the storage.
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// Drivers accepted by Open.
+const (
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+)
+
+// DefaultSQLiteDSN is a database file in the working directory.
+const DefaultSQLiteDSN = "file:bib.sqlite?cache=shared&_pragma=foreign_keys(1)"
+
+var ErrUnknownDriver = errors.New("unknown database driver")
+
+// Open connects to the database and returns it with the matching ent dialect.
+// An empty DSN means DefaultSQLiteDSN for SQLite and is an error for Postgres.
+func Open(driverName, dsn string) (*sql.DB, string, error) {
+	var sqlDriver, entDialect string
+	switch driverName {
+	case SQLite:
+		sqlDriver, entDialect = "sqlite", dialect.SQLite
+		if dsn == "" {
+			dsn = DefaultSQLiteDSN
+		}
+	case Postgres:
+		sqlDriver, entDialect = "pgx", dialect.Postgres
+		if dsn == "" {
+			return nil, "", fmt.Errorf("%s: DSN is required", driverName)
+		}
+	default:
+		return nil, "", fmt.Errorf("%w %q", ErrUnknownDriver, driverName)
+	}
+
+	db, errOpen := sql.Open(sqlDriver, dsn)
+	if errOpen != nil {
+		return nil, "", fmt.Errorf("%s: %w", driverName, errOpen)
+	}
+	return db, entDialect, nil
+}
@@ -0,0 +1,97 @@
+// Package dbtest opens empty databases for tests of the storage.
+package dbtest
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/storage/database"
+)
+
+// PostgresEnv names the environment variable with the DSN of a Postgres
+// database for tests, they are skipped if it is empty.
+const PostgresEnv = "TEST_POSTGRES_DSN"
+
+// Drivers lists drivers to run tests against.
+var Drivers = []string{database.SQLite, database.Postgres}
+
+// Open returns an empty database of the driver and its ent dialect.
+// SQLite databases are files in a temporary directory opened with the shared
+// cache like DefaultSQLiteDSN, so concurrent writes behave as in the server.
+// Postgres ones are schemas dropped at the end of the test.
+func Open(t *testing.T, driver string) (*sql.DB, string) {
+	t.Helper()
+	switch driver {
+	case database.SQLite:
+		dsn := "file:" + filepath.Join(t.TempDir(), "test.sqlite") + "?cache=shared&_pragma=foreign_keys(1)"
+		return open(t, driver, dsn)
+	case database.Postgres:
+		return openPostgres(t)
+	default:
+		t.Fatalf("unknown driver %q", driver)
+		return nil, ""
+	}
+}
+
+func openPostgres(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+	dsn := lookupPostgres(t)
+
+	schema := "test_" + randomHex(t)
+	admin, _ := open(t, database.Postgres, dsn)
+	if _, err := admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`); err != nil {
+			t.Errorf("drop schema: %v", err)
+		}
+	})
+
+	// URL and keyword/value DSNs take the search path differently
+	switch {
+	case strings.Contains(dsn, "://") && strings.Contains(dsn, "?"):
+		dsn += "&search_path=" + schema
+	case strings.Contains(dsn, "://"):
+		dsn += "?search_path=" + schema
+	default:
+		dsn += " search_path=" + schema
+	}
+	return open(t, database.Postgres, dsn)
+}
+
+func lookupPostgres(t *testing.T) string {
+	t.Helper()
+	dsn := os.Getenv(PostgresEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", PostgresEnv)
+	}
+	return dsn
+}
+
+func open(t *testing.T, driver, dsn string) (*sql.DB, string) {
+	t.Helper()
+	db, dbDialect, err := database.Open(driver, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Ping(); err != nil {
+		t.Fatalf("%s: %v", driver, err)
+	}
+	return db, dbDialect
+}
+
+func randomHex(t *testing.T) string {
+	t.Helper()
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(buf)
+}
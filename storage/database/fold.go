@@ -0,0 +1,47 @@
+package database
+
+import (
+	"database/sql/driver"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"modernc.org/sqlite"
+)
+
+// sqliteLower folds case of all letters, unlike the built-in lower()
+// which folds only ASCII ones and misses Cyrillic titles. It has its own
+// name, so other users of the SQLite driver in the process keep the standard lower().
+const sqliteLower = "bibliotheca_lower"
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction(sqliteLower, 1,
+		func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+			switch arg := args[0].(type) {
+			case string:
+				return strings.ToLower(arg), nil
+			case []byte:
+				return strings.ToLower(string(arg)), nil
+			default:
+				return arg, nil
+			}
+		})
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ContainsFold is a case-insensitive substring predicate of the column,
+// use it instead of ent's ContainsFold predicates for non-ASCII text.
+func ContainsFold(column, substr string) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if s.Dialect() != dialect.SQLite {
+			s.Where(sql.ContainsFold(s.C(column), substr))
+			return
+		}
+		s.Where(sql.P(func(b *sql.Builder) {
+			b.WriteString(sqliteLower + "(").Ident(s.C(column)).WriteString(") LIKE ")
+			b.Arg("%" + likeEscaper.Replace(strings.ToLower(substr)) + "%")
+			b.WriteString(` ESCAPE '\'`)
+		}))
+	}
+}
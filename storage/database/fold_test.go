@@ -0,0 +1,74 @@
+package database_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/database"
+	"github.com/ninedraft/bibliotheca/storage/database/dbtest"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+)
+
+func TestContainsFold(t *testing.T) {
+	for _, driver := range dbtest.Drivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			db, dbDialect := dbtest.Open(t, driver)
+			if err := (&migrations.Migrator{DB: db, Dialect: dbDialect}).Up(ctx, ""); err != nil {
+				t.Fatal(err)
+			}
+			client := ent.NewClient(ent.Driver(sql.OpenDB(dbDialect, db)))
+
+			titles := []string{"Мастер и Маргарита", "The MASTER of Go", "100% Pure", "snake_case", `C:\Books`}
+			for _, title := range titles {
+				if _, err := client.Book.Create().SetTitle(title).Save(ctx); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tests := []struct {
+				substr string
+				want   []string
+			}{
+				{substr: "МАСТЕР", want: []string{"Мастер и Маргарита"}},
+				{substr: "маргарита", want: []string{"Мастер и Маргарита"}},
+				{substr: "master", want: []string{"The MASTER of Go"}},
+				// LIKE wildcards match themselves
+				{substr: "%", want: []string{"100% Pure"}},
+				{substr: "e_c", want: []string{"snake_case"}},
+				{substr: `c:\b`, want: []string{`C:\Books`}},
+				{substr: "missing", want: nil},
+			}
+			for _, tc := range tests {
+				got, err := client.Book.Query().
+					Where(predicate.Book(database.ContainsFold(book.FieldTitle, tc.substr))).
+					Select(book.FieldTitle).
+					Strings(ctx)
+				if err != nil {
+					t.Fatalf("%q: %v", tc.substr, err)
+				}
+				if !slices.Equal(got, tc.want) {
+					t.Errorf("%q: got %q, want %q", tc.substr, got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLowerIsBuiltIn(t *testing.T) {
+	db, _ := dbtest.Open(t, database.SQLite)
+
+	// other users of the driver keep the ASCII-only lower()
+	var lower string
+	if err := db.QueryRow(`SELECT lower('ÄB')`).Scan(&lower); err != nil {
+		t.Fatal(err)
+	}
+	if lower != "Äb" {
+		t.Errorf("got %q, want %q", lower, "Äb")
+	}
+}
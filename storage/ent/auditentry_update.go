@@ -0,0 +1,506 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/auditentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// AuditEntryUpdate is the builder for updating AuditEntry entities.
+type AuditEntryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuditEntryMutation
+}
+
+// Where appends a list predicates to the AuditEntryUpdate builder.
+func (aeu *AuditEntryUpdate) Where(ps ...predicate.AuditEntry) *AuditEntryUpdate {
+	aeu.mutation.Where(ps...)
+	return aeu
+}
+
+// SetOp sets the "op" field.
+func (aeu *AuditEntryUpdate) SetOp(a auditentry.Op) *AuditEntryUpdate {
+	aeu.mutation.SetOpField(a)
+	return aeu
+}
+
+// SetEntity sets the "entity" field.
+func (aeu *AuditEntryUpdate) SetEntity(s string) *AuditEntryUpdate {
+	aeu.mutation.SetEntity(s)
+	return aeu
+}
+
+// SetEntityID sets the "entity_id" field.
+func (aeu *AuditEntryUpdate) SetEntityID(i int64) *AuditEntryUpdate {
+	aeu.mutation.ResetEntityID()
+	aeu.mutation.SetEntityID(i)
+	return aeu
+}
+
+// AddEntityID adds i to the "entity_id" field.
+func (aeu *AuditEntryUpdate) AddEntityID(i int64) *AuditEntryUpdate {
+	aeu.mutation.AddEntityID(i)
+	return aeu
+}
+
+// SetChanges sets the "changes" field.
+func (aeu *AuditEntryUpdate) SetChanges(sc []schema.FieldChange) *AuditEntryUpdate {
+	aeu.mutation.SetChanges(sc)
+	return aeu
+}
+
+// AppendChanges appends sc to the "changes" field.
+func (aeu *AuditEntryUpdate) AppendChanges(sc []schema.FieldChange) *AuditEntryUpdate {
+	aeu.mutation.AppendChanges(sc)
+	return aeu
+}
+
+// ClearChanges clears the value of the "changes" field.
+func (aeu *AuditEntryUpdate) ClearChanges() *AuditEntryUpdate {
+	aeu.mutation.ClearChanges()
+	return aeu
+}
+
+// SetActorLogin sets the "actor_login" field.
+func (aeu *AuditEntryUpdate) SetActorLogin(s string) *AuditEntryUpdate {
+	aeu.mutation.SetActorLogin(s)
+	return aeu
+}
+
+// SetNillableActorLogin sets the "actor_login" field if the given value is not nil.
+func (aeu *AuditEntryUpdate) SetNillableActorLogin(s *string) *AuditEntryUpdate {
+	if s != nil {
+		aeu.SetActorLogin(*s)
+	}
+	return aeu
+}
+
+// ClearActorLogin clears the value of the "actor_login" field.
+func (aeu *AuditEntryUpdate) ClearActorLogin() *AuditEntryUpdate {
+	aeu.mutation.ClearActorLogin()
+	return aeu
+}
+
+// SetActorID sets the "actor" edge to the User entity by ID.
+func (aeu *AuditEntryUpdate) SetActorID(id int64) *AuditEntryUpdate {
+	aeu.mutation.SetActorID(id)
+	return aeu
+}
+
+// SetNillableActorID sets the "actor" edge to the User entity by ID if the given value is not nil.
+func (aeu *AuditEntryUpdate) SetNillableActorID(id *int64) *AuditEntryUpdate {
+	if id != nil {
+		aeu = aeu.SetActorID(*id)
+	}
+	return aeu
+}
+
+// SetActor sets the "actor" edge to the User entity.
+func (aeu *AuditEntryUpdate) SetActor(u *User) *AuditEntryUpdate {
+	return aeu.SetActorID(u.ID)
+}
+
+// Mutation returns the AuditEntryMutation object of the builder.
+func (aeu *AuditEntryUpdate) Mutation() *AuditEntryMutation {
+	return aeu.mutation
+}
+
+// ClearActor clears the "actor" edge to the User entity.
+func (aeu *AuditEntryUpdate) ClearActor() *AuditEntryUpdate {
+	aeu.mutation.ClearActor()
+	return aeu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (aeu *AuditEntryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, aeu.sqlSave, aeu.mutation, aeu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aeu *AuditEntryUpdate) SaveX(ctx context.Context) int {
+	affected, err := aeu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (aeu *AuditEntryUpdate) Exec(ctx context.Context) error {
+	_, err := aeu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aeu *AuditEntryUpdate) ExecX(ctx context.Context) {
+	if err := aeu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aeu *AuditEntryUpdate) check() error {
+	if v, ok := aeu.mutation.GetOp(); ok {
+		if err := auditentry.OpValidator(v); err != nil {
+			return &ValidationError{Name: "op", err: fmt.Errorf(`ent: validator failed for field "AuditEntry.op": %w`, err)}
+		}
+	}
+	if v, ok := aeu.mutation.Entity(); ok {
+		if err := auditentry.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "AuditEntry.entity": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (aeu *AuditEntryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := aeu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditentry.Table, auditentry.Columns, sqlgraph.NewFieldSpec(auditentry.FieldID, field.TypeInt64))
+	if ps := aeu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aeu.mutation.GetOp(); ok {
+		_spec.SetField(auditentry.FieldOp, field.TypeEnum, value)
+	}
+	if value, ok := aeu.mutation.Entity(); ok {
+		_spec.SetField(auditentry.FieldEntity, field.TypeString, value)
+	}
+	if value, ok := aeu.mutation.EntityID(); ok {
+		_spec.SetField(auditentry.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := aeu.mutation.AddedEntityID(); ok {
+		_spec.AddField(auditentry.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := aeu.mutation.Changes(); ok {
+		_spec.SetField(auditentry.FieldChanges, field.TypeJSON, value)
+	}
+	if value, ok := aeu.mutation.AppendedChanges(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, auditentry.FieldChanges, value)
+		})
+	}
+	if aeu.mutation.ChangesCleared() {
+		_spec.ClearField(auditentry.FieldChanges, field.TypeJSON)
+	}
+	if value, ok := aeu.mutation.ActorLogin(); ok {
+		_spec.SetField(auditentry.FieldActorLogin, field.TypeString, value)
+	}
+	if aeu.mutation.ActorLoginCleared() {
+		_spec.ClearField(auditentry.FieldActorLogin, field.TypeString)
+	}
+	if aeu.mutation.ActorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   auditentry.ActorTable,
+			Columns: []string{auditentry.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := aeu.mutation.ActorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   auditentry.ActorTable,
+			Columns: []string{auditentry.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, aeu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	aeu.mutation.done = true
+	return n, nil
+}
+
+// AuditEntryUpdateOne is the builder for updating a single AuditEntry entity.
+type AuditEntryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuditEntryMutation
+}
+
+// SetOp sets the "op" field.
+func (aeuo *AuditEntryUpdateOne) SetOp(a auditentry.Op) *AuditEntryUpdateOne {
+	aeuo.mutation.SetOpField(a)
+	return aeuo
+}
+
+// SetEntity sets the "entity" field.
+func (aeuo *AuditEntryUpdateOne) SetEntity(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetEntity(s)
+	return aeuo
+}
+
+// SetEntityID sets the "entity_id" field.
+func (aeuo *AuditEntryUpdateOne) SetEntityID(i int64) *AuditEntryUpdateOne {
+	aeuo.mutation.ResetEntityID()
+	aeuo.mutation.SetEntityID(i)
+	return aeuo
+}
+
+// AddEntityID adds i to the "entity_id" field.
+func (aeuo *AuditEntryUpdateOne) AddEntityID(i int64) *AuditEntryUpdateOne {
+	aeuo.mutation.AddEntityID(i)
+	return aeuo
+}
+
+// SetChanges sets the "changes" field.
+func (aeuo *AuditEntryUpdateOne) SetChanges(sc []schema.FieldChange) *AuditEntryUpdateOne {
+	aeuo.mutation.SetChanges(sc)
+	return aeuo
+}
+
+// AppendChanges appends sc to the "changes" field.
+func (aeuo *AuditEntryUpdateOne) AppendChanges(sc []schema.FieldChange) *AuditEntryUpdateOne {
+	aeuo.mutation.AppendChanges(sc)
+	return aeuo
+}
+
+// ClearChanges clears the value of the "changes" field.
+func (aeuo *AuditEntryUpdateOne) ClearChanges() *AuditEntryUpdateOne {
+	aeuo.mutation.ClearChanges()
+	return aeuo
+}
+
+// SetActorLogin sets the "actor_login" field.
+func (aeuo *AuditEntryUpdateOne) SetActorLogin(s string) *AuditEntryUpdateOne {
+	aeuo.mutation.SetActorLogin(s)
+	return aeuo
+}
+
+// SetNillableActorLogin sets the "actor_login" field if the given value is not nil.
+func (aeuo *AuditEntryUpdateOne) SetNillableActorLogin(s *string) *AuditEntryUpdateOne {
+	if s != nil {
+		aeuo.SetActorLogin(*s)
+	}
+	return aeuo
+}
+
+// ClearActorLogin clears the value of the "actor_login" field.
+func (aeuo *AuditEntryUpdateOne) ClearActorLogin() *AuditEntryUpdateOne {
+	aeuo.mutation.ClearActorLogin()
+	return aeuo
+}
+
+// SetActorID sets the "actor" edge to the User entity by ID.
+func (aeuo *AuditEntryUpdateOne) SetActorID(id int64) *AuditEntryUpdateOne {
+	aeuo.mutation.SetActorID(id)
+	return aeuo
+}
+
+// SetNillableActorID sets the "actor" edge to the User entity by ID if the given value is not nil.
+func (aeuo *AuditEntryUpdateOne) SetNillableActorID(id *int64) *AuditEntryUpdateOne {
+	if id != nil {
+		aeuo = aeuo.SetActorID(*id)
+	}
+	return aeuo
+}
+
+// SetActor sets the "actor" edge to the User entity.
+func (aeuo *AuditEntryUpdateOne) SetActor(u *User) *AuditEntryUpdateOne {
+	return aeuo.SetActorID(u.ID)
+}
+
+// Mutation returns the AuditEntryMutation object of the builder.
+func (aeuo *AuditEntryUpdateOne) Mutation() *AuditEntryMutation {
+	return aeuo.mutation
+}
+
+// ClearActor clears the "actor" edge to the User entity.
+func (aeuo *AuditEntryUpdateOne) ClearActor() *AuditEntryUpdateOne {
+	aeuo.mutation.ClearActor()
+	return aeuo
+}
+
+// Where appends a list predicates to the AuditEntryUpdate builder.
+func (aeuo *AuditEntryUpdateOne) Where(ps ...predicate.AuditEntry) *AuditEntryUpdateOne {
+	aeuo.mutation.Where(ps...)
+	return aeuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (aeuo *AuditEntryUpdateOne) Select(field string, fields ...string) *AuditEntryUpdateOne {
+	aeuo.fields = append([]string{field}, fields...)
+	return aeuo
+}
+
+// Save executes the query and returns the updated AuditEntry entity.
+func (aeuo *AuditEntryUpdateOne) Save(ctx context.Context) (*AuditEntry, error) {
+	return withHooks(ctx, aeuo.sqlSave, aeuo.mutation, aeuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aeuo *AuditEntryUpdateOne) SaveX(ctx context.Context) *AuditEntry {
+	node, err := aeuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aeuo *AuditEntryUpdateOne) Exec(ctx context.Context) error {
+	_, err := aeuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aeuo *AuditEntryUpdateOne) ExecX(ctx context.Context) {
+	if err := aeuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aeuo *AuditEntryUpdateOne) check() error {
+	if v, ok := aeuo.mutation.GetOp(); ok {
+		if err := auditentry.OpValidator(v); err != nil {
+			return &ValidationError{Name: "op", err: fmt.Errorf(`ent: validator failed for field "AuditEntry.op": %w`, err)}
+		}
+	}
+	if v, ok := aeuo.mutation.Entity(); ok {
+		if err := auditentry.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "AuditEntry.entity": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (aeuo *AuditEntryUpdateOne) sqlSave(ctx context.Context) (_node *AuditEntry, err error) {
+	if err := aeuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditentry.Table, auditentry.Columns, sqlgraph.NewFieldSpec(auditentry.FieldID, field.TypeInt64))
+	id, ok := aeuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuditEntry.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := aeuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, auditentry.FieldID)
+		for _, f := range fields {
+			if !auditentry.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != auditentry.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := aeuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aeuo.mutation.GetOp(); ok {
+		_spec.SetField(auditentry.FieldOp, field.TypeEnum, value)
+	}
+	if value, ok := aeuo.mutation.Entity(); ok {
+		_spec.SetField(auditentry.FieldEntity, field.TypeString, value)
+	}
+	if value, ok := aeuo.mutation.EntityID(); ok {
+		_spec.SetField(auditentry.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := aeuo.mutation.AddedEntityID(); ok {
+		_spec.AddField(auditentry.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := aeuo.mutation.Changes(); ok {
+		_spec.SetField(auditentry.FieldChanges, field.TypeJSON, value)
+	}
+	if value, ok := aeuo.mutation.AppendedChanges(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, auditentry.FieldChanges, value)
+		})
+	}
+	if aeuo.mutation.ChangesCleared() {
+		_spec.ClearField(auditentry.FieldChanges, field.TypeJSON)
+	}
+	if value, ok := aeuo.mutation.ActorLogin(); ok {
+		_spec.SetField(auditentry.FieldActorLogin, field.TypeString, value)
+	}
+	if aeuo.mutation.ActorLoginCleared() {
+		_spec.ClearField(auditentry.FieldActorLogin, field.TypeString)
+	}
+	if aeuo.mutation.ActorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   auditentry.ActorTable,
+			Columns: []string{auditentry.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := aeuo.mutation.ActorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   auditentry.ActorTable,
+			Columns: []string{auditentry.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &AuditEntry{config: aeuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, aeuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	aeuo.mutation.done = true
+	return _node, nil
+}
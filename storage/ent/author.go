@@ -30,9 +30,11 @@ type Author struct {
 type AuthorEdges struct {
 	// Books holds the value of the books edge.
 	Books []*Book `json:"books,omitempty"`
+	// Followers holds the value of the followers edge.
+	Followers []*User `json:"followers,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [2]bool
 }
 
 // BooksOrErr returns the Books value or an error if the edge
@@ -44,6 +46,15 @@ func (e AuthorEdges) BooksOrErr() ([]*Book, error) {
 	return nil, &NotLoadedError{edge: "books"}
 }
 
+// FollowersOrErr returns the Followers value or an error if the edge
+// was not loaded in eager-loading.
+func (e AuthorEdges) FollowersOrErr() ([]*User, error) {
+	if e.loadedTypes[1] {
+		return e.Followers, nil
+	}
+	return nil, &NotLoadedError{edge: "followers"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Author) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
@@ -104,6 +115,11 @@ func (a *Author) QueryBooks() *BookQuery {
 	return NewAuthorClient(a.config).QueryBooks(a)
 }
 
+// QueryFollowers queries the "followers" edge of the Author entity.
+func (a *Author) QueryFollowers() *UserQuery {
+	return NewAuthorClient(a.config).QueryFollowers(a)
+}
+
 // Update returns a builder for updating this Author.
 // Note that you need to call Author.Unwrap() before calling this method if this Author
 // was returned from a transaction, and the transaction was committed or rolled back.
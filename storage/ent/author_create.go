@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
 )
 
 // AuthorCreate is the builder for creating a Author entity.
@@ -61,6 +62,21 @@ func (ac *AuthorCreate) AddBooks(b ...*Book) *AuthorCreate {
 	return ac.AddBookIDs(ids...)
 }
 
+// AddFollowerIDs adds the "followers" edge to the User entity by IDs.
+func (ac *AuthorCreate) AddFollowerIDs(ids ...int64) *AuthorCreate {
+	ac.mutation.AddFollowerIDs(ids...)
+	return ac
+}
+
+// AddFollowers adds the "followers" edges to the User entity.
+func (ac *AuthorCreate) AddFollowers(u ...*User) *AuthorCreate {
+	ids := make([]int64, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return ac.AddFollowerIDs(ids...)
+}
+
 // Mutation returns the AuthorMutation object of the builder.
 func (ac *AuthorCreate) Mutation() *AuthorMutation {
 	return ac.mutation
@@ -154,6 +170,22 @@ func (ac *AuthorCreate) createSpec() (*Author, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := ac.mutation.FollowersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   author.FollowersTable,
+			Columns: author.FollowersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
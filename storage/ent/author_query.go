@@ -14,16 +14,18 @@ import (
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
 	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
 )
 
 // AuthorQuery is the builder for querying Author entities.
 type AuthorQuery struct {
 	config
-	ctx        *QueryContext
-	order      []author.OrderOption
-	inters     []Interceptor
-	predicates []predicate.Author
-	withBooks  *BookQuery
+	ctx           *QueryContext
+	order         []author.OrderOption
+	inters        []Interceptor
+	predicates    []predicate.Author
+	withBooks     *BookQuery
+	withFollowers *UserQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -82,6 +84,28 @@ func (aq *AuthorQuery) QueryBooks() *BookQuery {
 	return query
 }
 
+// QueryFollowers chains the current query on the "followers" edge.
+func (aq *AuthorQuery) QueryFollowers() *UserQuery {
+	query := (&UserClient{config: aq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := aq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := aq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(author.Table, author.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, true, author.FollowersTable, author.FollowersPrimaryKey...),
+		)
+		fromU = sqlgraph.SetNeighbors(aq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Author entity from the query.
 // Returns a *NotFoundError when no Author was found.
 func (aq *AuthorQuery) First(ctx context.Context) (*Author, error) {
@@ -269,12 +293,13 @@ func (aq *AuthorQuery) Clone() *AuthorQuery {
 		return nil
 	}
 	return &AuthorQuery{
-		config:     aq.config,
-		ctx:        aq.ctx.Clone(),
-		order:      append([]author.OrderOption{}, aq.order...),
-		inters:     append([]Interceptor{}, aq.inters...),
-		predicates: append([]predicate.Author{}, aq.predicates...),
-		withBooks:  aq.withBooks.Clone(),
+		config:        aq.config,
+		ctx:           aq.ctx.Clone(),
+		order:         append([]author.OrderOption{}, aq.order...),
+		inters:        append([]Interceptor{}, aq.inters...),
+		predicates:    append([]predicate.Author{}, aq.predicates...),
+		withBooks:     aq.withBooks.Clone(),
+		withFollowers: aq.withFollowers.Clone(),
 		// clone intermediate query.
 		sql:  aq.sql.Clone(),
 		path: aq.path,
@@ -292,6 +317,17 @@ func (aq *AuthorQuery) WithBooks(opts ...func(*BookQuery)) *AuthorQuery {
 	return aq
 }
 
+// WithFollowers tells the query-builder to eager-load the nodes that are connected to
+// the "followers" edge. The optional arguments are used to configure the query builder of the edge.
+func (aq *AuthorQuery) WithFollowers(opts ...func(*UserQuery)) *AuthorQuery {
+	query := (&UserClient{config: aq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	aq.withFollowers = query
+	return aq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -370,8 +406,9 @@ func (aq *AuthorQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Autho
 	var (
 		nodes       = []*Author{}
 		_spec       = aq.querySpec()
-		loadedTypes = [1]bool{
+		loadedTypes = [2]bool{
 			aq.withBooks != nil,
+			aq.withFollowers != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -399,6 +436,13 @@ func (aq *AuthorQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Autho
 			return nil, err
 		}
 	}
+	if query := aq.withFollowers; query != nil {
+		if err := aq.loadFollowers(ctx, query, nodes,
+			func(n *Author) { n.Edges.Followers = []*User{} },
+			func(n *Author, e *User) { n.Edges.Followers = append(n.Edges.Followers, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -463,6 +507,67 @@ func (aq *AuthorQuery) loadBooks(ctx context.Context, query *BookQuery, nodes []
 	}
 	return nil
 }
+func (aq *AuthorQuery) loadFollowers(ctx context.Context, query *UserQuery, nodes []*Author, init func(*Author), assign func(*Author, *User)) error {
+	edgeIDs := make([]driver.Value, len(nodes))
+	byID := make(map[int64]*Author)
+	nids := make(map[int64]map[*Author]struct{})
+	for i, node := range nodes {
+		edgeIDs[i] = node.ID
+		byID[node.ID] = node
+		if init != nil {
+			init(node)
+		}
+	}
+	query.Where(func(s *sql.Selector) {
+		joinT := sql.Table(author.FollowersTable)
+		s.Join(joinT).On(s.C(user.FieldID), joinT.C(author.FollowersPrimaryKey[0]))
+		s.Where(sql.InValues(joinT.C(author.FollowersPrimaryKey[1]), edgeIDs...))
+		columns := s.SelectedColumns()
+		s.Select(joinT.C(author.FollowersPrimaryKey[1]))
+		s.AppendSelect(columns...)
+		s.SetDistinct(false)
+	})
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+			assign := spec.Assign
+			values := spec.ScanValues
+			spec.ScanValues = func(columns []string) ([]any, error) {
+				values, err := values(columns[1:])
+				if err != nil {
+					return nil, err
+				}
+				return append([]any{new(sql.NullInt64)}, values...), nil
+			}
+			spec.Assign = func(columns []string, values []any) error {
+				outValue := values[0].(*sql.NullInt64).Int64
+				inValue := values[1].(*sql.NullInt64).Int64
+				if nids[inValue] == nil {
+					nids[inValue] = map[*Author]struct{}{byID[outValue]: {}}
+					return assign(columns[1:], values[1:])
+				}
+				nids[inValue][byID[outValue]] = struct{}{}
+				return nil
+			}
+		})
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected "followers" node returned %v`, n.ID)
+		}
+		for kn := range nodes {
+			assign(kn, n)
+		}
+	}
+	return nil
+}
 
 func (aq *AuthorQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := aq.querySpec()
@@ -13,6 +13,7 @@ import (
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
 	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
 )
 
 // AuthorUpdate is the builder for updating Author entities.
@@ -69,6 +70,21 @@ func (au *AuthorUpdate) AddBooks(b ...*Book) *AuthorUpdate {
 	return au.AddBookIDs(ids...)
 }
 
+// AddFollowerIDs adds the "followers" edge to the User entity by IDs.
+func (au *AuthorUpdate) AddFollowerIDs(ids ...int64) *AuthorUpdate {
+	au.mutation.AddFollowerIDs(ids...)
+	return au
+}
+
+// AddFollowers adds the "followers" edges to the User entity.
+func (au *AuthorUpdate) AddFollowers(u ...*User) *AuthorUpdate {
+	ids := make([]int64, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return au.AddFollowerIDs(ids...)
+}
+
 // Mutation returns the AuthorMutation object of the builder.
 func (au *AuthorUpdate) Mutation() *AuthorMutation {
 	return au.mutation
@@ -95,6 +111,27 @@ func (au *AuthorUpdate) RemoveBooks(b ...*Book) *AuthorUpdate {
 	return au.RemoveBookIDs(ids...)
 }
 
+// ClearFollowers clears all "followers" edges to the User entity.
+func (au *AuthorUpdate) ClearFollowers() *AuthorUpdate {
+	au.mutation.ClearFollowers()
+	return au
+}
+
+// RemoveFollowerIDs removes the "followers" edge to User entities by IDs.
+func (au *AuthorUpdate) RemoveFollowerIDs(ids ...int64) *AuthorUpdate {
+	au.mutation.RemoveFollowerIDs(ids...)
+	return au
+}
+
+// RemoveFollowers removes "followers" edges to User entities.
+func (au *AuthorUpdate) RemoveFollowers(u ...*User) *AuthorUpdate {
+	ids := make([]int64, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return au.RemoveFollowerIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (au *AuthorUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, au.sqlSave, au.mutation, au.hooks)
@@ -185,6 +222,51 @@ func (au *AuthorUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if au.mutation.FollowersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   author.FollowersTable,
+			Columns: author.FollowersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := au.mutation.RemovedFollowersIDs(); len(nodes) > 0 && !au.mutation.FollowersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   author.FollowersTable,
+			Columns: author.FollowersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := au.mutation.FollowersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   author.FollowersTable,
+			Columns: author.FollowersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, au.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{author.Label}
@@ -246,6 +328,21 @@ func (auo *AuthorUpdateOne) AddBooks(b ...*Book) *AuthorUpdateOne {
 	return auo.AddBookIDs(ids...)
 }
 
+// AddFollowerIDs adds the "followers" edge to the User entity by IDs.
+func (auo *AuthorUpdateOne) AddFollowerIDs(ids ...int64) *AuthorUpdateOne {
+	auo.mutation.AddFollowerIDs(ids...)
+	return auo
+}
+
+// AddFollowers adds the "followers" edges to the User entity.
+func (auo *AuthorUpdateOne) AddFollowers(u ...*User) *AuthorUpdateOne {
+	ids := make([]int64, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return auo.AddFollowerIDs(ids...)
+}
+
 // Mutation returns the AuthorMutation object of the builder.
 func (auo *AuthorUpdateOne) Mutation() *AuthorMutation {
 	return auo.mutation
@@ -272,6 +369,27 @@ func (auo *AuthorUpdateOne) RemoveBooks(b ...*Book) *AuthorUpdateOne {
 	return auo.RemoveBookIDs(ids...)
 }
 
+// ClearFollowers clears all "followers" edges to the User entity.
+func (auo *AuthorUpdateOne) ClearFollowers() *AuthorUpdateOne {
+	auo.mutation.ClearFollowers()
+	return auo
+}
+
+// RemoveFollowerIDs removes the "followers" edge to User entities by IDs.
+func (auo *AuthorUpdateOne) RemoveFollowerIDs(ids ...int64) *AuthorUpdateOne {
+	auo.mutation.RemoveFollowerIDs(ids...)
+	return auo
+}
+
+// RemoveFollowers removes "followers" edges to User entities.
+func (auo *AuthorUpdateOne) RemoveFollowers(u ...*User) *AuthorUpdateOne {
+	ids := make([]int64, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return auo.RemoveFollowerIDs(ids...)
+}
+
 // Where appends a list predicates to the AuthorUpdate builder.
 func (auo *AuthorUpdateOne) Where(ps ...predicate.Author) *AuthorUpdateOne {
 	auo.mutation.Where(ps...)
@@ -392,6 +510,51 @@ func (auo *AuthorUpdateOne) sqlSave(ctx context.Context) (_node *Author, err err
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if auo.mutation.FollowersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   author.FollowersTable,
+			Columns: author.FollowersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := auo.mutation.RemovedFollowersIDs(); len(nodes) > 0 && !auo.mutation.FollowersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   author.FollowersTable,
+			Columns: author.FollowersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := auo.mutation.FollowersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   author.FollowersTable,
+			Columns: author.FollowersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Author{config: auo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
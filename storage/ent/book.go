@@ -24,6 +24,10 @@ type Book struct {
 	CoverID string `json:"cover_id,omitempty"`
 	// FileID holds the value of the "file_id" field.
 	FileID string `json:"file_id,omitempty"`
+	// Pages holds the value of the "pages" field.
+	Pages int64 `json:"pages,omitempty"`
+	// DeletedAt holds the value of the "deleted_at" field.
+	DeletedAt *int64 `json:"deleted_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the BookQuery when eager-loading is set.
 	Edges        BookEdges `json:"edges"`
@@ -34,9 +38,23 @@ type Book struct {
 type BookEdges struct {
 	// Authors holds the value of the authors edge.
 	Authors []*Author `json:"authors,omitempty"`
+	// Copies holds the value of the copies edge.
+	Copies []*BookCopy `json:"copies,omitempty"`
+	// Holds holds the value of the holds edge.
+	Holds []*Hold `json:"holds,omitempty"`
+	// Deliveries holds the value of the deliveries edge.
+	Deliveries []*Delivery `json:"deliveries,omitempty"`
+	// ShelfEntries holds the value of the shelf_entries edge.
+	ShelfEntries []*ShelfEntry `json:"shelf_entries,omitempty"`
+	// Reviews holds the value of the reviews edge.
+	Reviews []*Review `json:"reviews,omitempty"`
+	// Documents holds the value of the documents edge.
+	Documents []*Document `json:"documents,omitempty"`
+	// Highlights holds the value of the highlights edge.
+	Highlights []*Highlight `json:"highlights,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [8]bool
 }
 
 // AuthorsOrErr returns the Authors value or an error if the edge
@@ -48,12 +66,75 @@ func (e BookEdges) AuthorsOrErr() ([]*Author, error) {
 	return nil, &NotLoadedError{edge: "authors"}
 }
 
+// CopiesOrErr returns the Copies value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookEdges) CopiesOrErr() ([]*BookCopy, error) {
+	if e.loadedTypes[1] {
+		return e.Copies, nil
+	}
+	return nil, &NotLoadedError{edge: "copies"}
+}
+
+// HoldsOrErr returns the Holds value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookEdges) HoldsOrErr() ([]*Hold, error) {
+	if e.loadedTypes[2] {
+		return e.Holds, nil
+	}
+	return nil, &NotLoadedError{edge: "holds"}
+}
+
+// DeliveriesOrErr returns the Deliveries value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookEdges) DeliveriesOrErr() ([]*Delivery, error) {
+	if e.loadedTypes[3] {
+		return e.Deliveries, nil
+	}
+	return nil, &NotLoadedError{edge: "deliveries"}
+}
+
+// ShelfEntriesOrErr returns the ShelfEntries value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookEdges) ShelfEntriesOrErr() ([]*ShelfEntry, error) {
+	if e.loadedTypes[4] {
+		return e.ShelfEntries, nil
+	}
+	return nil, &NotLoadedError{edge: "shelf_entries"}
+}
+
+// ReviewsOrErr returns the Reviews value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookEdges) ReviewsOrErr() ([]*Review, error) {
+	if e.loadedTypes[5] {
+		return e.Reviews, nil
+	}
+	return nil, &NotLoadedError{edge: "reviews"}
+}
+
+// DocumentsOrErr returns the Documents value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookEdges) DocumentsOrErr() ([]*Document, error) {
+	if e.loadedTypes[6] {
+		return e.Documents, nil
+	}
+	return nil, &NotLoadedError{edge: "documents"}
+}
+
+// HighlightsOrErr returns the Highlights value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookEdges) HighlightsOrErr() ([]*Highlight, error) {
+	if e.loadedTypes[7] {
+		return e.Highlights, nil
+	}
+	return nil, &NotLoadedError{edge: "highlights"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Book) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case book.FieldID, book.FieldWrittenAt:
+		case book.FieldID, book.FieldWrittenAt, book.FieldPages, book.FieldDeletedAt:
 			values[i] = new(sql.NullInt64)
 		case book.FieldTitle, book.FieldCoverID, book.FieldFileID:
 			values[i] = new(sql.NullString)
@@ -102,6 +183,19 @@ func (b *Book) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				b.FileID = value.String
 			}
+		case book.FieldPages:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field pages", values[i])
+			} else if value.Valid {
+				b.Pages = value.Int64
+			}
+		case book.FieldDeletedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field deleted_at", values[i])
+			} else if value.Valid {
+				b.DeletedAt = new(int64)
+				*b.DeletedAt = value.Int64
+			}
 		default:
 			b.selectValues.Set(columns[i], values[i])
 		}
@@ -120,6 +214,41 @@ func (b *Book) QueryAuthors() *AuthorQuery {
 	return NewBookClient(b.config).QueryAuthors(b)
 }
 
+// QueryCopies queries the "copies" edge of the Book entity.
+func (b *Book) QueryCopies() *BookCopyQuery {
+	return NewBookClient(b.config).QueryCopies(b)
+}
+
+// QueryHolds queries the "holds" edge of the Book entity.
+func (b *Book) QueryHolds() *HoldQuery {
+	return NewBookClient(b.config).QueryHolds(b)
+}
+
+// QueryDeliveries queries the "deliveries" edge of the Book entity.
+func (b *Book) QueryDeliveries() *DeliveryQuery {
+	return NewBookClient(b.config).QueryDeliveries(b)
+}
+
+// QueryShelfEntries queries the "shelf_entries" edge of the Book entity.
+func (b *Book) QueryShelfEntries() *ShelfEntryQuery {
+	return NewBookClient(b.config).QueryShelfEntries(b)
+}
+
+// QueryReviews queries the "reviews" edge of the Book entity.
+func (b *Book) QueryReviews() *ReviewQuery {
+	return NewBookClient(b.config).QueryReviews(b)
+}
+
+// QueryDocuments queries the "documents" edge of the Book entity.
+func (b *Book) QueryDocuments() *DocumentQuery {
+	return NewBookClient(b.config).QueryDocuments(b)
+}
+
+// QueryHighlights queries the "highlights" edge of the Book entity.
+func (b *Book) QueryHighlights() *HighlightQuery {
+	return NewBookClient(b.config).QueryHighlights(b)
+}
+
 // Update returns a builder for updating this Book.
 // Note that you need to call Book.Unwrap() before calling this method if this Book
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -154,6 +283,14 @@ func (b *Book) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("file_id=")
 	builder.WriteString(b.FileID)
+	builder.WriteString(", ")
+	builder.WriteString("pages=")
+	builder.WriteString(fmt.Sprintf("%v", b.Pages))
+	builder.WriteString(", ")
+	if v := b.DeletedAt; v != nil {
+		builder.WriteString("deleted_at=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
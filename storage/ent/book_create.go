@@ -11,6 +11,13 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/document"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
 )
 
 // BookCreate is the builder for creating a Book entity.
@@ -68,6 +75,34 @@ func (bc *BookCreate) SetNillableFileID(s *string) *BookCreate {
 	return bc
 }
 
+// SetPages sets the "pages" field.
+func (bc *BookCreate) SetPages(i int64) *BookCreate {
+	bc.mutation.SetPages(i)
+	return bc
+}
+
+// SetNillablePages sets the "pages" field if the given value is not nil.
+func (bc *BookCreate) SetNillablePages(i *int64) *BookCreate {
+	if i != nil {
+		bc.SetPages(*i)
+	}
+	return bc
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (bc *BookCreate) SetDeletedAt(i int64) *BookCreate {
+	bc.mutation.SetDeletedAt(i)
+	return bc
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (bc *BookCreate) SetNillableDeletedAt(i *int64) *BookCreate {
+	if i != nil {
+		bc.SetDeletedAt(*i)
+	}
+	return bc
+}
+
 // SetID sets the "id" field.
 func (bc *BookCreate) SetID(i int64) *BookCreate {
 	bc.mutation.SetID(i)
@@ -89,6 +124,111 @@ func (bc *BookCreate) AddAuthors(a ...*Author) *BookCreate {
 	return bc.AddAuthorIDs(ids...)
 }
 
+// AddCopyIDs adds the "copies" edge to the BookCopy entity by IDs.
+func (bc *BookCreate) AddCopyIDs(ids ...int64) *BookCreate {
+	bc.mutation.AddCopyIDs(ids...)
+	return bc
+}
+
+// AddCopies adds the "copies" edges to the BookCopy entity.
+func (bc *BookCreate) AddCopies(b ...*BookCopy) *BookCreate {
+	ids := make([]int64, len(b))
+	for i := range b {
+		ids[i] = b[i].ID
+	}
+	return bc.AddCopyIDs(ids...)
+}
+
+// AddHoldIDs adds the "holds" edge to the Hold entity by IDs.
+func (bc *BookCreate) AddHoldIDs(ids ...int64) *BookCreate {
+	bc.mutation.AddHoldIDs(ids...)
+	return bc
+}
+
+// AddHolds adds the "holds" edges to the Hold entity.
+func (bc *BookCreate) AddHolds(h ...*Hold) *BookCreate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bc.AddHoldIDs(ids...)
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the Delivery entity by IDs.
+func (bc *BookCreate) AddDeliveryIDs(ids ...int64) *BookCreate {
+	bc.mutation.AddDeliveryIDs(ids...)
+	return bc
+}
+
+// AddDeliveries adds the "deliveries" edges to the Delivery entity.
+func (bc *BookCreate) AddDeliveries(d ...*Delivery) *BookCreate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return bc.AddDeliveryIDs(ids...)
+}
+
+// AddShelfEntryIDs adds the "shelf_entries" edge to the ShelfEntry entity by IDs.
+func (bc *BookCreate) AddShelfEntryIDs(ids ...int64) *BookCreate {
+	bc.mutation.AddShelfEntryIDs(ids...)
+	return bc
+}
+
+// AddShelfEntries adds the "shelf_entries" edges to the ShelfEntry entity.
+func (bc *BookCreate) AddShelfEntries(s ...*ShelfEntry) *BookCreate {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return bc.AddShelfEntryIDs(ids...)
+}
+
+// AddReviewIDs adds the "reviews" edge to the Review entity by IDs.
+func (bc *BookCreate) AddReviewIDs(ids ...int64) *BookCreate {
+	bc.mutation.AddReviewIDs(ids...)
+	return bc
+}
+
+// AddReviews adds the "reviews" edges to the Review entity.
+func (bc *BookCreate) AddReviews(r ...*Review) *BookCreate {
+	ids := make([]int64, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return bc.AddReviewIDs(ids...)
+}
+
+// AddDocumentIDs adds the "documents" edge to the Document entity by IDs.
+func (bc *BookCreate) AddDocumentIDs(ids ...int64) *BookCreate {
+	bc.mutation.AddDocumentIDs(ids...)
+	return bc
+}
+
+// AddDocuments adds the "documents" edges to the Document entity.
+func (bc *BookCreate) AddDocuments(d ...*Document) *BookCreate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return bc.AddDocumentIDs(ids...)
+}
+
+// AddHighlightIDs adds the "highlights" edge to the Highlight entity by IDs.
+func (bc *BookCreate) AddHighlightIDs(ids ...int64) *BookCreate {
+	bc.mutation.AddHighlightIDs(ids...)
+	return bc
+}
+
+// AddHighlights adds the "highlights" edges to the Highlight entity.
+func (bc *BookCreate) AddHighlights(h ...*Highlight) *BookCreate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bc.AddHighlightIDs(ids...)
+}
+
 // Mutation returns the BookMutation object of the builder.
 func (bc *BookCreate) Mutation() *BookMutation {
 	return bc.mutation
@@ -125,7 +265,7 @@ func (bc *BookCreate) ExecX(ctx context.Context) {
 // defaults sets the default values of the builder before save.
 func (bc *BookCreate) defaults() {
 	if _, ok := bc.mutation.WrittenAt(); !ok {
-		v := book.DefaultWrittenAt()
+		v := book.DefaultWrittenAt
 		bc.mutation.SetWrittenAt(v)
 	}
 }
@@ -186,6 +326,14 @@ func (bc *BookCreate) createSpec() (*Book, *sqlgraph.CreateSpec) {
 		_spec.SetField(book.FieldFileID, field.TypeString, value)
 		_node.FileID = value
 	}
+	if value, ok := bc.mutation.Pages(); ok {
+		_spec.SetField(book.FieldPages, field.TypeInt64, value)
+		_node.Pages = value
+	}
+	if value, ok := bc.mutation.DeletedAt(); ok {
+		_spec.SetField(book.FieldDeletedAt, field.TypeInt64, value)
+		_node.DeletedAt = &value
+	}
 	if nodes := bc.mutation.AuthorsIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -202,6 +350,118 @@ func (bc *BookCreate) createSpec() (*Book, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := bc.mutation.CopiesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.CopiesTable,
+			Columns: []string{book.CopiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bc.mutation.HoldsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HoldsTable,
+			Columns: []string{book.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bc.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DeliveriesTable,
+			Columns: []string{book.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bc.mutation.ShelfEntriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ShelfEntriesTable,
+			Columns: []string{book.ShelfEntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bc.mutation.ReviewsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ReviewsTable,
+			Columns: []string{book.ReviewsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bc.mutation.DocumentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DocumentsTable,
+			Columns: []string{book.DocumentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(document.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bc.mutation.HighlightsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HighlightsTable,
+			Columns: []string{book.HighlightsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
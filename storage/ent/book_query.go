@@ -13,17 +13,31 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/document"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
 	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
 )
 
 // BookQuery is the builder for querying Book entities.
 type BookQuery struct {
 	config
-	ctx         *QueryContext
-	order       []book.OrderOption
-	inters      []Interceptor
-	predicates  []predicate.Book
-	withAuthors *AuthorQuery
+	ctx              *QueryContext
+	order            []book.OrderOption
+	inters           []Interceptor
+	predicates       []predicate.Book
+	withAuthors      *AuthorQuery
+	withCopies       *BookCopyQuery
+	withHolds        *HoldQuery
+	withDeliveries   *DeliveryQuery
+	withShelfEntries *ShelfEntryQuery
+	withReviews      *ReviewQuery
+	withDocuments    *DocumentQuery
+	withHighlights   *HighlightQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -82,6 +96,160 @@ func (bq *BookQuery) QueryAuthors() *AuthorQuery {
 	return query
 }
 
+// QueryCopies chains the current query on the "copies" edge.
+func (bq *BookQuery) QueryCopies() *BookCopyQuery {
+	query := (&BookCopyClient{config: bq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(book.Table, book.FieldID, selector),
+			sqlgraph.To(bookcopy.Table, bookcopy.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, book.CopiesTable, book.CopiesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryHolds chains the current query on the "holds" edge.
+func (bq *BookQuery) QueryHolds() *HoldQuery {
+	query := (&HoldClient{config: bq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(book.Table, book.FieldID, selector),
+			sqlgraph.To(hold.Table, hold.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, book.HoldsTable, book.HoldsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryDeliveries chains the current query on the "deliveries" edge.
+func (bq *BookQuery) QueryDeliveries() *DeliveryQuery {
+	query := (&DeliveryClient{config: bq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(book.Table, book.FieldID, selector),
+			sqlgraph.To(delivery.Table, delivery.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, book.DeliveriesTable, book.DeliveriesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryShelfEntries chains the current query on the "shelf_entries" edge.
+func (bq *BookQuery) QueryShelfEntries() *ShelfEntryQuery {
+	query := (&ShelfEntryClient{config: bq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(book.Table, book.FieldID, selector),
+			sqlgraph.To(shelfentry.Table, shelfentry.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, book.ShelfEntriesTable, book.ShelfEntriesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryReviews chains the current query on the "reviews" edge.
+func (bq *BookQuery) QueryReviews() *ReviewQuery {
+	query := (&ReviewClient{config: bq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(book.Table, book.FieldID, selector),
+			sqlgraph.To(review.Table, review.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, book.ReviewsTable, book.ReviewsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryDocuments chains the current query on the "documents" edge.
+func (bq *BookQuery) QueryDocuments() *DocumentQuery {
+	query := (&DocumentClient{config: bq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(book.Table, book.FieldID, selector),
+			sqlgraph.To(document.Table, document.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, book.DocumentsTable, book.DocumentsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryHighlights chains the current query on the "highlights" edge.
+func (bq *BookQuery) QueryHighlights() *HighlightQuery {
+	query := (&HighlightClient{config: bq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(book.Table, book.FieldID, selector),
+			sqlgraph.To(highlight.Table, highlight.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, book.HighlightsTable, book.HighlightsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Book entity from the query.
 // Returns a *NotFoundError when no Book was found.
 func (bq *BookQuery) First(ctx context.Context) (*Book, error) {
@@ -269,12 +437,19 @@ func (bq *BookQuery) Clone() *BookQuery {
 		return nil
 	}
 	return &BookQuery{
-		config:      bq.config,
-		ctx:         bq.ctx.Clone(),
-		order:       append([]book.OrderOption{}, bq.order...),
-		inters:      append([]Interceptor{}, bq.inters...),
-		predicates:  append([]predicate.Book{}, bq.predicates...),
-		withAuthors: bq.withAuthors.Clone(),
+		config:           bq.config,
+		ctx:              bq.ctx.Clone(),
+		order:            append([]book.OrderOption{}, bq.order...),
+		inters:           append([]Interceptor{}, bq.inters...),
+		predicates:       append([]predicate.Book{}, bq.predicates...),
+		withAuthors:      bq.withAuthors.Clone(),
+		withCopies:       bq.withCopies.Clone(),
+		withHolds:        bq.withHolds.Clone(),
+		withDeliveries:   bq.withDeliveries.Clone(),
+		withShelfEntries: bq.withShelfEntries.Clone(),
+		withReviews:      bq.withReviews.Clone(),
+		withDocuments:    bq.withDocuments.Clone(),
+		withHighlights:   bq.withHighlights.Clone(),
 		// clone intermediate query.
 		sql:  bq.sql.Clone(),
 		path: bq.path,
@@ -292,6 +467,83 @@ func (bq *BookQuery) WithAuthors(opts ...func(*AuthorQuery)) *BookQuery {
 	return bq
 }
 
+// WithCopies tells the query-builder to eager-load the nodes that are connected to
+// the "copies" edge. The optional arguments are used to configure the query builder of the edge.
+func (bq *BookQuery) WithCopies(opts ...func(*BookCopyQuery)) *BookQuery {
+	query := (&BookCopyClient{config: bq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bq.withCopies = query
+	return bq
+}
+
+// WithHolds tells the query-builder to eager-load the nodes that are connected to
+// the "holds" edge. The optional arguments are used to configure the query builder of the edge.
+func (bq *BookQuery) WithHolds(opts ...func(*HoldQuery)) *BookQuery {
+	query := (&HoldClient{config: bq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bq.withHolds = query
+	return bq
+}
+
+// WithDeliveries tells the query-builder to eager-load the nodes that are connected to
+// the "deliveries" edge. The optional arguments are used to configure the query builder of the edge.
+func (bq *BookQuery) WithDeliveries(opts ...func(*DeliveryQuery)) *BookQuery {
+	query := (&DeliveryClient{config: bq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bq.withDeliveries = query
+	return bq
+}
+
+// WithShelfEntries tells the query-builder to eager-load the nodes that are connected to
+// the "shelf_entries" edge. The optional arguments are used to configure the query builder of the edge.
+func (bq *BookQuery) WithShelfEntries(opts ...func(*ShelfEntryQuery)) *BookQuery {
+	query := (&ShelfEntryClient{config: bq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bq.withShelfEntries = query
+	return bq
+}
+
+// WithReviews tells the query-builder to eager-load the nodes that are connected to
+// the "reviews" edge. The optional arguments are used to configure the query builder of the edge.
+func (bq *BookQuery) WithReviews(opts ...func(*ReviewQuery)) *BookQuery {
+	query := (&ReviewClient{config: bq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bq.withReviews = query
+	return bq
+}
+
+// WithDocuments tells the query-builder to eager-load the nodes that are connected to
+// the "documents" edge. The optional arguments are used to configure the query builder of the edge.
+func (bq *BookQuery) WithDocuments(opts ...func(*DocumentQuery)) *BookQuery {
+	query := (&DocumentClient{config: bq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bq.withDocuments = query
+	return bq
+}
+
+// WithHighlights tells the query-builder to eager-load the nodes that are connected to
+// the "highlights" edge. The optional arguments are used to configure the query builder of the edge.
+func (bq *BookQuery) WithHighlights(opts ...func(*HighlightQuery)) *BookQuery {
+	query := (&HighlightClient{config: bq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bq.withHighlights = query
+	return bq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -370,8 +622,15 @@ func (bq *BookQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Book, e
 	var (
 		nodes       = []*Book{}
 		_spec       = bq.querySpec()
-		loadedTypes = [1]bool{
+		loadedTypes = [8]bool{
 			bq.withAuthors != nil,
+			bq.withCopies != nil,
+			bq.withHolds != nil,
+			bq.withDeliveries != nil,
+			bq.withShelfEntries != nil,
+			bq.withReviews != nil,
+			bq.withDocuments != nil,
+			bq.withHighlights != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -399,6 +658,55 @@ func (bq *BookQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Book, e
 			return nil, err
 		}
 	}
+	if query := bq.withCopies; query != nil {
+		if err := bq.loadCopies(ctx, query, nodes,
+			func(n *Book) { n.Edges.Copies = []*BookCopy{} },
+			func(n *Book, e *BookCopy) { n.Edges.Copies = append(n.Edges.Copies, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bq.withHolds; query != nil {
+		if err := bq.loadHolds(ctx, query, nodes,
+			func(n *Book) { n.Edges.Holds = []*Hold{} },
+			func(n *Book, e *Hold) { n.Edges.Holds = append(n.Edges.Holds, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bq.withDeliveries; query != nil {
+		if err := bq.loadDeliveries(ctx, query, nodes,
+			func(n *Book) { n.Edges.Deliveries = []*Delivery{} },
+			func(n *Book, e *Delivery) { n.Edges.Deliveries = append(n.Edges.Deliveries, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bq.withShelfEntries; query != nil {
+		if err := bq.loadShelfEntries(ctx, query, nodes,
+			func(n *Book) { n.Edges.ShelfEntries = []*ShelfEntry{} },
+			func(n *Book, e *ShelfEntry) { n.Edges.ShelfEntries = append(n.Edges.ShelfEntries, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bq.withReviews; query != nil {
+		if err := bq.loadReviews(ctx, query, nodes,
+			func(n *Book) { n.Edges.Reviews = []*Review{} },
+			func(n *Book, e *Review) { n.Edges.Reviews = append(n.Edges.Reviews, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bq.withDocuments; query != nil {
+		if err := bq.loadDocuments(ctx, query, nodes,
+			func(n *Book) { n.Edges.Documents = []*Document{} },
+			func(n *Book, e *Document) { n.Edges.Documents = append(n.Edges.Documents, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bq.withHighlights; query != nil {
+		if err := bq.loadHighlights(ctx, query, nodes,
+			func(n *Book) { n.Edges.Highlights = []*Highlight{} },
+			func(n *Book, e *Highlight) { n.Edges.Highlights = append(n.Edges.Highlights, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -463,6 +771,223 @@ func (bq *BookQuery) loadAuthors(ctx context.Context, query *AuthorQuery, nodes
 	}
 	return nil
 }
+func (bq *BookQuery) loadCopies(ctx context.Context, query *BookCopyQuery, nodes []*Book, init func(*Book), assign func(*Book, *BookCopy)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*Book)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.BookCopy(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(book.CopiesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.book_copy_book
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "book_copy_book" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "book_copy_book" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (bq *BookQuery) loadHolds(ctx context.Context, query *HoldQuery, nodes []*Book, init func(*Book), assign func(*Book, *Hold)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*Book)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Hold(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(book.HoldsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.hold_book
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "hold_book" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "hold_book" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (bq *BookQuery) loadDeliveries(ctx context.Context, query *DeliveryQuery, nodes []*Book, init func(*Book), assign func(*Book, *Delivery)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*Book)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Delivery(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(book.DeliveriesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.delivery_book
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "delivery_book" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "delivery_book" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (bq *BookQuery) loadShelfEntries(ctx context.Context, query *ShelfEntryQuery, nodes []*Book, init func(*Book), assign func(*Book, *ShelfEntry)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*Book)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.ShelfEntry(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(book.ShelfEntriesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.shelf_entry_book
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "shelf_entry_book" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "shelf_entry_book" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (bq *BookQuery) loadReviews(ctx context.Context, query *ReviewQuery, nodes []*Book, init func(*Book), assign func(*Book, *Review)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*Book)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Review(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(book.ReviewsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.review_book
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "review_book" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "review_book" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (bq *BookQuery) loadDocuments(ctx context.Context, query *DocumentQuery, nodes []*Book, init func(*Book), assign func(*Book, *Document)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*Book)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Document(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(book.DocumentsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.document_book
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "document_book" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "document_book" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (bq *BookQuery) loadHighlights(ctx context.Context, query *HighlightQuery, nodes []*Book, init func(*Book), assign func(*Book, *Highlight)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*Book)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Highlight(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(book.HighlightsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.highlight_book
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "highlight_book" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "highlight_book" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (bq *BookQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := bq.querySpec()
@@ -12,7 +12,14 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/document"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
 	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
 )
 
 // BookUpdate is the builder for updating Book entities.
@@ -95,6 +102,60 @@ func (bu *BookUpdate) ClearFileID() *BookUpdate {
 	return bu
 }
 
+// SetPages sets the "pages" field.
+func (bu *BookUpdate) SetPages(i int64) *BookUpdate {
+	bu.mutation.ResetPages()
+	bu.mutation.SetPages(i)
+	return bu
+}
+
+// SetNillablePages sets the "pages" field if the given value is not nil.
+func (bu *BookUpdate) SetNillablePages(i *int64) *BookUpdate {
+	if i != nil {
+		bu.SetPages(*i)
+	}
+	return bu
+}
+
+// AddPages adds i to the "pages" field.
+func (bu *BookUpdate) AddPages(i int64) *BookUpdate {
+	bu.mutation.AddPages(i)
+	return bu
+}
+
+// ClearPages clears the value of the "pages" field.
+func (bu *BookUpdate) ClearPages() *BookUpdate {
+	bu.mutation.ClearPages()
+	return bu
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (bu *BookUpdate) SetDeletedAt(i int64) *BookUpdate {
+	bu.mutation.ResetDeletedAt()
+	bu.mutation.SetDeletedAt(i)
+	return bu
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (bu *BookUpdate) SetNillableDeletedAt(i *int64) *BookUpdate {
+	if i != nil {
+		bu.SetDeletedAt(*i)
+	}
+	return bu
+}
+
+// AddDeletedAt adds i to the "deleted_at" field.
+func (bu *BookUpdate) AddDeletedAt(i int64) *BookUpdate {
+	bu.mutation.AddDeletedAt(i)
+	return bu
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (bu *BookUpdate) ClearDeletedAt() *BookUpdate {
+	bu.mutation.ClearDeletedAt()
+	return bu
+}
+
 // AddAuthorIDs adds the "authors" edge to the Author entity by IDs.
 func (bu *BookUpdate) AddAuthorIDs(ids ...int64) *BookUpdate {
 	bu.mutation.AddAuthorIDs(ids...)
@@ -110,6 +171,111 @@ func (bu *BookUpdate) AddAuthors(a ...*Author) *BookUpdate {
 	return bu.AddAuthorIDs(ids...)
 }
 
+// AddCopyIDs adds the "copies" edge to the BookCopy entity by IDs.
+func (bu *BookUpdate) AddCopyIDs(ids ...int64) *BookUpdate {
+	bu.mutation.AddCopyIDs(ids...)
+	return bu
+}
+
+// AddCopies adds the "copies" edges to the BookCopy entity.
+func (bu *BookUpdate) AddCopies(b ...*BookCopy) *BookUpdate {
+	ids := make([]int64, len(b))
+	for i := range b {
+		ids[i] = b[i].ID
+	}
+	return bu.AddCopyIDs(ids...)
+}
+
+// AddHoldIDs adds the "holds" edge to the Hold entity by IDs.
+func (bu *BookUpdate) AddHoldIDs(ids ...int64) *BookUpdate {
+	bu.mutation.AddHoldIDs(ids...)
+	return bu
+}
+
+// AddHolds adds the "holds" edges to the Hold entity.
+func (bu *BookUpdate) AddHolds(h ...*Hold) *BookUpdate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bu.AddHoldIDs(ids...)
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the Delivery entity by IDs.
+func (bu *BookUpdate) AddDeliveryIDs(ids ...int64) *BookUpdate {
+	bu.mutation.AddDeliveryIDs(ids...)
+	return bu
+}
+
+// AddDeliveries adds the "deliveries" edges to the Delivery entity.
+func (bu *BookUpdate) AddDeliveries(d ...*Delivery) *BookUpdate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return bu.AddDeliveryIDs(ids...)
+}
+
+// AddShelfEntryIDs adds the "shelf_entries" edge to the ShelfEntry entity by IDs.
+func (bu *BookUpdate) AddShelfEntryIDs(ids ...int64) *BookUpdate {
+	bu.mutation.AddShelfEntryIDs(ids...)
+	return bu
+}
+
+// AddShelfEntries adds the "shelf_entries" edges to the ShelfEntry entity.
+func (bu *BookUpdate) AddShelfEntries(s ...*ShelfEntry) *BookUpdate {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return bu.AddShelfEntryIDs(ids...)
+}
+
+// AddReviewIDs adds the "reviews" edge to the Review entity by IDs.
+func (bu *BookUpdate) AddReviewIDs(ids ...int64) *BookUpdate {
+	bu.mutation.AddReviewIDs(ids...)
+	return bu
+}
+
+// AddReviews adds the "reviews" edges to the Review entity.
+func (bu *BookUpdate) AddReviews(r ...*Review) *BookUpdate {
+	ids := make([]int64, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return bu.AddReviewIDs(ids...)
+}
+
+// AddDocumentIDs adds the "documents" edge to the Document entity by IDs.
+func (bu *BookUpdate) AddDocumentIDs(ids ...int64) *BookUpdate {
+	bu.mutation.AddDocumentIDs(ids...)
+	return bu
+}
+
+// AddDocuments adds the "documents" edges to the Document entity.
+func (bu *BookUpdate) AddDocuments(d ...*Document) *BookUpdate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return bu.AddDocumentIDs(ids...)
+}
+
+// AddHighlightIDs adds the "highlights" edge to the Highlight entity by IDs.
+func (bu *BookUpdate) AddHighlightIDs(ids ...int64) *BookUpdate {
+	bu.mutation.AddHighlightIDs(ids...)
+	return bu
+}
+
+// AddHighlights adds the "highlights" edges to the Highlight entity.
+func (bu *BookUpdate) AddHighlights(h ...*Highlight) *BookUpdate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bu.AddHighlightIDs(ids...)
+}
+
 // Mutation returns the BookMutation object of the builder.
 func (bu *BookUpdate) Mutation() *BookMutation {
 	return bu.mutation
@@ -136,6 +302,153 @@ func (bu *BookUpdate) RemoveAuthors(a ...*Author) *BookUpdate {
 	return bu.RemoveAuthorIDs(ids...)
 }
 
+// ClearCopies clears all "copies" edges to the BookCopy entity.
+func (bu *BookUpdate) ClearCopies() *BookUpdate {
+	bu.mutation.ClearCopies()
+	return bu
+}
+
+// RemoveCopyIDs removes the "copies" edge to BookCopy entities by IDs.
+func (bu *BookUpdate) RemoveCopyIDs(ids ...int64) *BookUpdate {
+	bu.mutation.RemoveCopyIDs(ids...)
+	return bu
+}
+
+// RemoveCopies removes "copies" edges to BookCopy entities.
+func (bu *BookUpdate) RemoveCopies(b ...*BookCopy) *BookUpdate {
+	ids := make([]int64, len(b))
+	for i := range b {
+		ids[i] = b[i].ID
+	}
+	return bu.RemoveCopyIDs(ids...)
+}
+
+// ClearHolds clears all "holds" edges to the Hold entity.
+func (bu *BookUpdate) ClearHolds() *BookUpdate {
+	bu.mutation.ClearHolds()
+	return bu
+}
+
+// RemoveHoldIDs removes the "holds" edge to Hold entities by IDs.
+func (bu *BookUpdate) RemoveHoldIDs(ids ...int64) *BookUpdate {
+	bu.mutation.RemoveHoldIDs(ids...)
+	return bu
+}
+
+// RemoveHolds removes "holds" edges to Hold entities.
+func (bu *BookUpdate) RemoveHolds(h ...*Hold) *BookUpdate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bu.RemoveHoldIDs(ids...)
+}
+
+// ClearDeliveries clears all "deliveries" edges to the Delivery entity.
+func (bu *BookUpdate) ClearDeliveries() *BookUpdate {
+	bu.mutation.ClearDeliveries()
+	return bu
+}
+
+// RemoveDeliveryIDs removes the "deliveries" edge to Delivery entities by IDs.
+func (bu *BookUpdate) RemoveDeliveryIDs(ids ...int64) *BookUpdate {
+	bu.mutation.RemoveDeliveryIDs(ids...)
+	return bu
+}
+
+// RemoveDeliveries removes "deliveries" edges to Delivery entities.
+func (bu *BookUpdate) RemoveDeliveries(d ...*Delivery) *BookUpdate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return bu.RemoveDeliveryIDs(ids...)
+}
+
+// ClearShelfEntries clears all "shelf_entries" edges to the ShelfEntry entity.
+func (bu *BookUpdate) ClearShelfEntries() *BookUpdate {
+	bu.mutation.ClearShelfEntries()
+	return bu
+}
+
+// RemoveShelfEntryIDs removes the "shelf_entries" edge to ShelfEntry entities by IDs.
+func (bu *BookUpdate) RemoveShelfEntryIDs(ids ...int64) *BookUpdate {
+	bu.mutation.RemoveShelfEntryIDs(ids...)
+	return bu
+}
+
+// RemoveShelfEntries removes "shelf_entries" edges to ShelfEntry entities.
+func (bu *BookUpdate) RemoveShelfEntries(s ...*ShelfEntry) *BookUpdate {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return bu.RemoveShelfEntryIDs(ids...)
+}
+
+// ClearReviews clears all "reviews" edges to the Review entity.
+func (bu *BookUpdate) ClearReviews() *BookUpdate {
+	bu.mutation.ClearReviews()
+	return bu
+}
+
+// RemoveReviewIDs removes the "reviews" edge to Review entities by IDs.
+func (bu *BookUpdate) RemoveReviewIDs(ids ...int64) *BookUpdate {
+	bu.mutation.RemoveReviewIDs(ids...)
+	return bu
+}
+
+// RemoveReviews removes "reviews" edges to Review entities.
+func (bu *BookUpdate) RemoveReviews(r ...*Review) *BookUpdate {
+	ids := make([]int64, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return bu.RemoveReviewIDs(ids...)
+}
+
+// ClearDocuments clears all "documents" edges to the Document entity.
+func (bu *BookUpdate) ClearDocuments() *BookUpdate {
+	bu.mutation.ClearDocuments()
+	return bu
+}
+
+// RemoveDocumentIDs removes the "documents" edge to Document entities by IDs.
+func (bu *BookUpdate) RemoveDocumentIDs(ids ...int64) *BookUpdate {
+	bu.mutation.RemoveDocumentIDs(ids...)
+	return bu
+}
+
+// RemoveDocuments removes "documents" edges to Document entities.
+func (bu *BookUpdate) RemoveDocuments(d ...*Document) *BookUpdate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return bu.RemoveDocumentIDs(ids...)
+}
+
+// ClearHighlights clears all "highlights" edges to the Highlight entity.
+func (bu *BookUpdate) ClearHighlights() *BookUpdate {
+	bu.mutation.ClearHighlights()
+	return bu
+}
+
+// RemoveHighlightIDs removes the "highlights" edge to Highlight entities by IDs.
+func (bu *BookUpdate) RemoveHighlightIDs(ids ...int64) *BookUpdate {
+	bu.mutation.RemoveHighlightIDs(ids...)
+	return bu
+}
+
+// RemoveHighlights removes "highlights" edges to Highlight entities.
+func (bu *BookUpdate) RemoveHighlights(h ...*Highlight) *BookUpdate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bu.RemoveHighlightIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (bu *BookUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, bu.sqlSave, bu.mutation, bu.hooks)
@@ -193,6 +506,24 @@ func (bu *BookUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if bu.mutation.FileIDCleared() {
 		_spec.ClearField(book.FieldFileID, field.TypeString)
 	}
+	if value, ok := bu.mutation.Pages(); ok {
+		_spec.SetField(book.FieldPages, field.TypeInt64, value)
+	}
+	if value, ok := bu.mutation.AddedPages(); ok {
+		_spec.AddField(book.FieldPages, field.TypeInt64, value)
+	}
+	if bu.mutation.PagesCleared() {
+		_spec.ClearField(book.FieldPages, field.TypeInt64)
+	}
+	if value, ok := bu.mutation.DeletedAt(); ok {
+		_spec.SetField(book.FieldDeletedAt, field.TypeInt64, value)
+	}
+	if value, ok := bu.mutation.AddedDeletedAt(); ok {
+		_spec.AddField(book.FieldDeletedAt, field.TypeInt64, value)
+	}
+	if bu.mutation.DeletedAtCleared() {
+		_spec.ClearField(book.FieldDeletedAt, field.TypeInt64)
+	}
 	if bu.mutation.AuthorsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -238,132 +569,753 @@ func (bu *BookUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if n, err = sqlgraph.UpdateNodes(ctx, bu.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{book.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
+	if bu.mutation.CopiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.CopiesTable,
+			Columns: []string{book.CopiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
 		}
-		return 0, err
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	bu.mutation.done = true
-	return n, nil
-}
-
-// BookUpdateOne is the builder for updating a single Book entity.
-type BookUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *BookMutation
-}
-
-// SetTitle sets the "title" field.
-func (buo *BookUpdateOne) SetTitle(s string) *BookUpdateOne {
-	buo.mutation.SetTitle(s)
+	if nodes := bu.mutation.RemovedCopiesIDs(); len(nodes) > 0 && !bu.mutation.CopiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.CopiesTable,
+			Columns: []string{book.CopiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.CopiesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.CopiesTable,
+			Columns: []string{book.CopiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bu.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HoldsTable,
+			Columns: []string{book.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.RemovedHoldsIDs(); len(nodes) > 0 && !bu.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HoldsTable,
+			Columns: []string{book.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.HoldsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HoldsTable,
+			Columns: []string{book.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bu.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DeliveriesTable,
+			Columns: []string{book.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.RemovedDeliveriesIDs(); len(nodes) > 0 && !bu.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DeliveriesTable,
+			Columns: []string{book.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DeliveriesTable,
+			Columns: []string{book.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bu.mutation.ShelfEntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ShelfEntriesTable,
+			Columns: []string{book.ShelfEntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.RemovedShelfEntriesIDs(); len(nodes) > 0 && !bu.mutation.ShelfEntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ShelfEntriesTable,
+			Columns: []string{book.ShelfEntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.ShelfEntriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ShelfEntriesTable,
+			Columns: []string{book.ShelfEntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bu.mutation.ReviewsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ReviewsTable,
+			Columns: []string{book.ReviewsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.RemovedReviewsIDs(); len(nodes) > 0 && !bu.mutation.ReviewsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ReviewsTable,
+			Columns: []string{book.ReviewsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.ReviewsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ReviewsTable,
+			Columns: []string{book.ReviewsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bu.mutation.DocumentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DocumentsTable,
+			Columns: []string{book.DocumentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(document.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.RemovedDocumentsIDs(); len(nodes) > 0 && !bu.mutation.DocumentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DocumentsTable,
+			Columns: []string{book.DocumentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(document.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.DocumentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DocumentsTable,
+			Columns: []string{book.DocumentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(document.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bu.mutation.HighlightsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HighlightsTable,
+			Columns: []string{book.HighlightsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.RemovedHighlightsIDs(); len(nodes) > 0 && !bu.mutation.HighlightsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HighlightsTable,
+			Columns: []string{book.HighlightsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bu.mutation.HighlightsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HighlightsTable,
+			Columns: []string{book.HighlightsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, bu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{book.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	bu.mutation.done = true
+	return n, nil
+}
+
+// BookUpdateOne is the builder for updating a single Book entity.
+type BookUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *BookMutation
+}
+
+// SetTitle sets the "title" field.
+func (buo *BookUpdateOne) SetTitle(s string) *BookUpdateOne {
+	buo.mutation.SetTitle(s)
+	return buo
+}
+
+// SetWrittenAt sets the "written_at" field.
+func (buo *BookUpdateOne) SetWrittenAt(i int64) *BookUpdateOne {
+	buo.mutation.ResetWrittenAt()
+	buo.mutation.SetWrittenAt(i)
+	return buo
+}
+
+// SetNillableWrittenAt sets the "written_at" field if the given value is not nil.
+func (buo *BookUpdateOne) SetNillableWrittenAt(i *int64) *BookUpdateOne {
+	if i != nil {
+		buo.SetWrittenAt(*i)
+	}
+	return buo
+}
+
+// AddWrittenAt adds i to the "written_at" field.
+func (buo *BookUpdateOne) AddWrittenAt(i int64) *BookUpdateOne {
+	buo.mutation.AddWrittenAt(i)
+	return buo
+}
+
+// SetCoverID sets the "cover_id" field.
+func (buo *BookUpdateOne) SetCoverID(s string) *BookUpdateOne {
+	buo.mutation.SetCoverID(s)
+	return buo
+}
+
+// SetNillableCoverID sets the "cover_id" field if the given value is not nil.
+func (buo *BookUpdateOne) SetNillableCoverID(s *string) *BookUpdateOne {
+	if s != nil {
+		buo.SetCoverID(*s)
+	}
+	return buo
+}
+
+// ClearCoverID clears the value of the "cover_id" field.
+func (buo *BookUpdateOne) ClearCoverID() *BookUpdateOne {
+	buo.mutation.ClearCoverID()
+	return buo
+}
+
+// SetFileID sets the "file_id" field.
+func (buo *BookUpdateOne) SetFileID(s string) *BookUpdateOne {
+	buo.mutation.SetFileID(s)
+	return buo
+}
+
+// SetNillableFileID sets the "file_id" field if the given value is not nil.
+func (buo *BookUpdateOne) SetNillableFileID(s *string) *BookUpdateOne {
+	if s != nil {
+		buo.SetFileID(*s)
+	}
+	return buo
+}
+
+// ClearFileID clears the value of the "file_id" field.
+func (buo *BookUpdateOne) ClearFileID() *BookUpdateOne {
+	buo.mutation.ClearFileID()
+	return buo
+}
+
+// SetPages sets the "pages" field.
+func (buo *BookUpdateOne) SetPages(i int64) *BookUpdateOne {
+	buo.mutation.ResetPages()
+	buo.mutation.SetPages(i)
+	return buo
+}
+
+// SetNillablePages sets the "pages" field if the given value is not nil.
+func (buo *BookUpdateOne) SetNillablePages(i *int64) *BookUpdateOne {
+	if i != nil {
+		buo.SetPages(*i)
+	}
+	return buo
+}
+
+// AddPages adds i to the "pages" field.
+func (buo *BookUpdateOne) AddPages(i int64) *BookUpdateOne {
+	buo.mutation.AddPages(i)
+	return buo
+}
+
+// ClearPages clears the value of the "pages" field.
+func (buo *BookUpdateOne) ClearPages() *BookUpdateOne {
+	buo.mutation.ClearPages()
+	return buo
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (buo *BookUpdateOne) SetDeletedAt(i int64) *BookUpdateOne {
+	buo.mutation.ResetDeletedAt()
+	buo.mutation.SetDeletedAt(i)
+	return buo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (buo *BookUpdateOne) SetNillableDeletedAt(i *int64) *BookUpdateOne {
+	if i != nil {
+		buo.SetDeletedAt(*i)
+	}
+	return buo
+}
+
+// AddDeletedAt adds i to the "deleted_at" field.
+func (buo *BookUpdateOne) AddDeletedAt(i int64) *BookUpdateOne {
+	buo.mutation.AddDeletedAt(i)
+	return buo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (buo *BookUpdateOne) ClearDeletedAt() *BookUpdateOne {
+	buo.mutation.ClearDeletedAt()
+	return buo
+}
+
+// AddAuthorIDs adds the "authors" edge to the Author entity by IDs.
+func (buo *BookUpdateOne) AddAuthorIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddAuthorIDs(ids...)
+	return buo
+}
+
+// AddAuthors adds the "authors" edges to the Author entity.
+func (buo *BookUpdateOne) AddAuthors(a ...*Author) *BookUpdateOne {
+	ids := make([]int64, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return buo.AddAuthorIDs(ids...)
+}
+
+// AddCopyIDs adds the "copies" edge to the BookCopy entity by IDs.
+func (buo *BookUpdateOne) AddCopyIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddCopyIDs(ids...)
+	return buo
+}
+
+// AddCopies adds the "copies" edges to the BookCopy entity.
+func (buo *BookUpdateOne) AddCopies(b ...*BookCopy) *BookUpdateOne {
+	ids := make([]int64, len(b))
+	for i := range b {
+		ids[i] = b[i].ID
+	}
+	return buo.AddCopyIDs(ids...)
+}
+
+// AddHoldIDs adds the "holds" edge to the Hold entity by IDs.
+func (buo *BookUpdateOne) AddHoldIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddHoldIDs(ids...)
+	return buo
+}
+
+// AddHolds adds the "holds" edges to the Hold entity.
+func (buo *BookUpdateOne) AddHolds(h ...*Hold) *BookUpdateOne {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return buo.AddHoldIDs(ids...)
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the Delivery entity by IDs.
+func (buo *BookUpdateOne) AddDeliveryIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddDeliveryIDs(ids...)
+	return buo
+}
+
+// AddDeliveries adds the "deliveries" edges to the Delivery entity.
+func (buo *BookUpdateOne) AddDeliveries(d ...*Delivery) *BookUpdateOne {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return buo.AddDeliveryIDs(ids...)
+}
+
+// AddShelfEntryIDs adds the "shelf_entries" edge to the ShelfEntry entity by IDs.
+func (buo *BookUpdateOne) AddShelfEntryIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddShelfEntryIDs(ids...)
+	return buo
+}
+
+// AddShelfEntries adds the "shelf_entries" edges to the ShelfEntry entity.
+func (buo *BookUpdateOne) AddShelfEntries(s ...*ShelfEntry) *BookUpdateOne {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return buo.AddShelfEntryIDs(ids...)
+}
+
+// AddReviewIDs adds the "reviews" edge to the Review entity by IDs.
+func (buo *BookUpdateOne) AddReviewIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddReviewIDs(ids...)
+	return buo
+}
+
+// AddReviews adds the "reviews" edges to the Review entity.
+func (buo *BookUpdateOne) AddReviews(r ...*Review) *BookUpdateOne {
+	ids := make([]int64, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return buo.AddReviewIDs(ids...)
+}
+
+// AddDocumentIDs adds the "documents" edge to the Document entity by IDs.
+func (buo *BookUpdateOne) AddDocumentIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddDocumentIDs(ids...)
+	return buo
+}
+
+// AddDocuments adds the "documents" edges to the Document entity.
+func (buo *BookUpdateOne) AddDocuments(d ...*Document) *BookUpdateOne {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return buo.AddDocumentIDs(ids...)
+}
+
+// AddHighlightIDs adds the "highlights" edge to the Highlight entity by IDs.
+func (buo *BookUpdateOne) AddHighlightIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.AddHighlightIDs(ids...)
+	return buo
+}
+
+// AddHighlights adds the "highlights" edges to the Highlight entity.
+func (buo *BookUpdateOne) AddHighlights(h ...*Highlight) *BookUpdateOne {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return buo.AddHighlightIDs(ids...)
+}
+
+// Mutation returns the BookMutation object of the builder.
+func (buo *BookUpdateOne) Mutation() *BookMutation {
+	return buo.mutation
+}
+
+// ClearAuthors clears all "authors" edges to the Author entity.
+func (buo *BookUpdateOne) ClearAuthors() *BookUpdateOne {
+	buo.mutation.ClearAuthors()
+	return buo
+}
+
+// RemoveAuthorIDs removes the "authors" edge to Author entities by IDs.
+func (buo *BookUpdateOne) RemoveAuthorIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveAuthorIDs(ids...)
+	return buo
+}
+
+// RemoveAuthors removes "authors" edges to Author entities.
+func (buo *BookUpdateOne) RemoveAuthors(a ...*Author) *BookUpdateOne {
+	ids := make([]int64, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return buo.RemoveAuthorIDs(ids...)
+}
+
+// ClearCopies clears all "copies" edges to the BookCopy entity.
+func (buo *BookUpdateOne) ClearCopies() *BookUpdateOne {
+	buo.mutation.ClearCopies()
+	return buo
+}
+
+// RemoveCopyIDs removes the "copies" edge to BookCopy entities by IDs.
+func (buo *BookUpdateOne) RemoveCopyIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveCopyIDs(ids...)
+	return buo
+}
+
+// RemoveCopies removes "copies" edges to BookCopy entities.
+func (buo *BookUpdateOne) RemoveCopies(b ...*BookCopy) *BookUpdateOne {
+	ids := make([]int64, len(b))
+	for i := range b {
+		ids[i] = b[i].ID
+	}
+	return buo.RemoveCopyIDs(ids...)
+}
+
+// ClearHolds clears all "holds" edges to the Hold entity.
+func (buo *BookUpdateOne) ClearHolds() *BookUpdateOne {
+	buo.mutation.ClearHolds()
+	return buo
+}
+
+// RemoveHoldIDs removes the "holds" edge to Hold entities by IDs.
+func (buo *BookUpdateOne) RemoveHoldIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveHoldIDs(ids...)
+	return buo
+}
+
+// RemoveHolds removes "holds" edges to Hold entities.
+func (buo *BookUpdateOne) RemoveHolds(h ...*Hold) *BookUpdateOne {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return buo.RemoveHoldIDs(ids...)
+}
+
+// ClearDeliveries clears all "deliveries" edges to the Delivery entity.
+func (buo *BookUpdateOne) ClearDeliveries() *BookUpdateOne {
+	buo.mutation.ClearDeliveries()
 	return buo
 }
 
-// SetWrittenAt sets the "written_at" field.
-func (buo *BookUpdateOne) SetWrittenAt(i int64) *BookUpdateOne {
-	buo.mutation.ResetWrittenAt()
-	buo.mutation.SetWrittenAt(i)
+// RemoveDeliveryIDs removes the "deliveries" edge to Delivery entities by IDs.
+func (buo *BookUpdateOne) RemoveDeliveryIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveDeliveryIDs(ids...)
 	return buo
 }
 
-// SetNillableWrittenAt sets the "written_at" field if the given value is not nil.
-func (buo *BookUpdateOne) SetNillableWrittenAt(i *int64) *BookUpdateOne {
-	if i != nil {
-		buo.SetWrittenAt(*i)
+// RemoveDeliveries removes "deliveries" edges to Delivery entities.
+func (buo *BookUpdateOne) RemoveDeliveries(d ...*Delivery) *BookUpdateOne {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
 	}
-	return buo
+	return buo.RemoveDeliveryIDs(ids...)
 }
 
-// AddWrittenAt adds i to the "written_at" field.
-func (buo *BookUpdateOne) AddWrittenAt(i int64) *BookUpdateOne {
-	buo.mutation.AddWrittenAt(i)
+// ClearShelfEntries clears all "shelf_entries" edges to the ShelfEntry entity.
+func (buo *BookUpdateOne) ClearShelfEntries() *BookUpdateOne {
+	buo.mutation.ClearShelfEntries()
 	return buo
 }
 
-// SetCoverID sets the "cover_id" field.
-func (buo *BookUpdateOne) SetCoverID(s string) *BookUpdateOne {
-	buo.mutation.SetCoverID(s)
+// RemoveShelfEntryIDs removes the "shelf_entries" edge to ShelfEntry entities by IDs.
+func (buo *BookUpdateOne) RemoveShelfEntryIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveShelfEntryIDs(ids...)
 	return buo
 }
 
-// SetNillableCoverID sets the "cover_id" field if the given value is not nil.
-func (buo *BookUpdateOne) SetNillableCoverID(s *string) *BookUpdateOne {
-	if s != nil {
-		buo.SetCoverID(*s)
+// RemoveShelfEntries removes "shelf_entries" edges to ShelfEntry entities.
+func (buo *BookUpdateOne) RemoveShelfEntries(s ...*ShelfEntry) *BookUpdateOne {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
 	}
-	return buo
+	return buo.RemoveShelfEntryIDs(ids...)
 }
 
-// ClearCoverID clears the value of the "cover_id" field.
-func (buo *BookUpdateOne) ClearCoverID() *BookUpdateOne {
-	buo.mutation.ClearCoverID()
+// ClearReviews clears all "reviews" edges to the Review entity.
+func (buo *BookUpdateOne) ClearReviews() *BookUpdateOne {
+	buo.mutation.ClearReviews()
 	return buo
 }
 
-// SetFileID sets the "file_id" field.
-func (buo *BookUpdateOne) SetFileID(s string) *BookUpdateOne {
-	buo.mutation.SetFileID(s)
+// RemoveReviewIDs removes the "reviews" edge to Review entities by IDs.
+func (buo *BookUpdateOne) RemoveReviewIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveReviewIDs(ids...)
 	return buo
 }
 
-// SetNillableFileID sets the "file_id" field if the given value is not nil.
-func (buo *BookUpdateOne) SetNillableFileID(s *string) *BookUpdateOne {
-	if s != nil {
-		buo.SetFileID(*s)
+// RemoveReviews removes "reviews" edges to Review entities.
+func (buo *BookUpdateOne) RemoveReviews(r ...*Review) *BookUpdateOne {
+	ids := make([]int64, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
 	}
-	return buo
+	return buo.RemoveReviewIDs(ids...)
 }
 
-// ClearFileID clears the value of the "file_id" field.
-func (buo *BookUpdateOne) ClearFileID() *BookUpdateOne {
-	buo.mutation.ClearFileID()
+// ClearDocuments clears all "documents" edges to the Document entity.
+func (buo *BookUpdateOne) ClearDocuments() *BookUpdateOne {
+	buo.mutation.ClearDocuments()
 	return buo
 }
 
-// AddAuthorIDs adds the "authors" edge to the Author entity by IDs.
-func (buo *BookUpdateOne) AddAuthorIDs(ids ...int64) *BookUpdateOne {
-	buo.mutation.AddAuthorIDs(ids...)
+// RemoveDocumentIDs removes the "documents" edge to Document entities by IDs.
+func (buo *BookUpdateOne) RemoveDocumentIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveDocumentIDs(ids...)
 	return buo
 }
 
-// AddAuthors adds the "authors" edges to the Author entity.
-func (buo *BookUpdateOne) AddAuthors(a ...*Author) *BookUpdateOne {
-	ids := make([]int64, len(a))
-	for i := range a {
-		ids[i] = a[i].ID
+// RemoveDocuments removes "documents" edges to Document entities.
+func (buo *BookUpdateOne) RemoveDocuments(d ...*Document) *BookUpdateOne {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
 	}
-	return buo.AddAuthorIDs(ids...)
-}
-
-// Mutation returns the BookMutation object of the builder.
-func (buo *BookUpdateOne) Mutation() *BookMutation {
-	return buo.mutation
+	return buo.RemoveDocumentIDs(ids...)
 }
 
-// ClearAuthors clears all "authors" edges to the Author entity.
-func (buo *BookUpdateOne) ClearAuthors() *BookUpdateOne {
-	buo.mutation.ClearAuthors()
+// ClearHighlights clears all "highlights" edges to the Highlight entity.
+func (buo *BookUpdateOne) ClearHighlights() *BookUpdateOne {
+	buo.mutation.ClearHighlights()
 	return buo
 }
 
-// RemoveAuthorIDs removes the "authors" edge to Author entities by IDs.
-func (buo *BookUpdateOne) RemoveAuthorIDs(ids ...int64) *BookUpdateOne {
-	buo.mutation.RemoveAuthorIDs(ids...)
+// RemoveHighlightIDs removes the "highlights" edge to Highlight entities by IDs.
+func (buo *BookUpdateOne) RemoveHighlightIDs(ids ...int64) *BookUpdateOne {
+	buo.mutation.RemoveHighlightIDs(ids...)
 	return buo
 }
 
-// RemoveAuthors removes "authors" edges to Author entities.
-func (buo *BookUpdateOne) RemoveAuthors(a ...*Author) *BookUpdateOne {
-	ids := make([]int64, len(a))
-	for i := range a {
-		ids[i] = a[i].ID
+// RemoveHighlights removes "highlights" edges to Highlight entities.
+func (buo *BookUpdateOne) RemoveHighlights(h ...*Highlight) *BookUpdateOne {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
 	}
-	return buo.RemoveAuthorIDs(ids...)
+	return buo.RemoveHighlightIDs(ids...)
 }
 
 // Where appends a list predicates to the BookUpdate builder.
@@ -453,6 +1405,24 @@ func (buo *BookUpdateOne) sqlSave(ctx context.Context) (_node *Book, err error)
 	if buo.mutation.FileIDCleared() {
 		_spec.ClearField(book.FieldFileID, field.TypeString)
 	}
+	if value, ok := buo.mutation.Pages(); ok {
+		_spec.SetField(book.FieldPages, field.TypeInt64, value)
+	}
+	if value, ok := buo.mutation.AddedPages(); ok {
+		_spec.AddField(book.FieldPages, field.TypeInt64, value)
+	}
+	if buo.mutation.PagesCleared() {
+		_spec.ClearField(book.FieldPages, field.TypeInt64)
+	}
+	if value, ok := buo.mutation.DeletedAt(); ok {
+		_spec.SetField(book.FieldDeletedAt, field.TypeInt64, value)
+	}
+	if value, ok := buo.mutation.AddedDeletedAt(); ok {
+		_spec.AddField(book.FieldDeletedAt, field.TypeInt64, value)
+	}
+	if buo.mutation.DeletedAtCleared() {
+		_spec.ClearField(book.FieldDeletedAt, field.TypeInt64)
+	}
 	if buo.mutation.AuthorsCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -498,6 +1468,321 @@ func (buo *BookUpdateOne) sqlSave(ctx context.Context) (_node *Book, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if buo.mutation.CopiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.CopiesTable,
+			Columns: []string{book.CopiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.RemovedCopiesIDs(); len(nodes) > 0 && !buo.mutation.CopiesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.CopiesTable,
+			Columns: []string{book.CopiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.CopiesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.CopiesTable,
+			Columns: []string{book.CopiesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if buo.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HoldsTable,
+			Columns: []string{book.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.RemovedHoldsIDs(); len(nodes) > 0 && !buo.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HoldsTable,
+			Columns: []string{book.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.HoldsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HoldsTable,
+			Columns: []string{book.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if buo.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DeliveriesTable,
+			Columns: []string{book.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.RemovedDeliveriesIDs(); len(nodes) > 0 && !buo.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DeliveriesTable,
+			Columns: []string{book.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DeliveriesTable,
+			Columns: []string{book.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if buo.mutation.ShelfEntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ShelfEntriesTable,
+			Columns: []string{book.ShelfEntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.RemovedShelfEntriesIDs(); len(nodes) > 0 && !buo.mutation.ShelfEntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ShelfEntriesTable,
+			Columns: []string{book.ShelfEntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.ShelfEntriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ShelfEntriesTable,
+			Columns: []string{book.ShelfEntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if buo.mutation.ReviewsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ReviewsTable,
+			Columns: []string{book.ReviewsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.RemovedReviewsIDs(); len(nodes) > 0 && !buo.mutation.ReviewsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ReviewsTable,
+			Columns: []string{book.ReviewsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.ReviewsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.ReviewsTable,
+			Columns: []string{book.ReviewsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if buo.mutation.DocumentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DocumentsTable,
+			Columns: []string{book.DocumentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(document.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.RemovedDocumentsIDs(); len(nodes) > 0 && !buo.mutation.DocumentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DocumentsTable,
+			Columns: []string{book.DocumentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(document.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.DocumentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.DocumentsTable,
+			Columns: []string{book.DocumentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(document.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if buo.mutation.HighlightsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HighlightsTable,
+			Columns: []string{book.HighlightsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.RemovedHighlightsIDs(); len(nodes) > 0 && !buo.mutation.HighlightsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HighlightsTable,
+			Columns: []string{book.HighlightsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := buo.mutation.HighlightsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   book.HighlightsTable,
+			Columns: []string{book.HighlightsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Book{config: buo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,209 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+)
+
+// BookCopy is the model entity for the BookCopy schema.
+type BookCopy struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Barcode holds the value of the "barcode" field.
+	Barcode string `json:"barcode,omitempty"`
+	// Location holds the value of the "location" field.
+	Location string `json:"location,omitempty"`
+	// Condition holds the value of the "condition" field.
+	Condition bookcopy.Condition `json:"condition,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the BookCopyQuery when eager-loading is set.
+	Edges          BookCopyEdges `json:"edges"`
+	book_copy_book *int64
+	selectValues   sql.SelectValues
+}
+
+// BookCopyEdges holds the relations/edges for other nodes in the graph.
+type BookCopyEdges struct {
+	// Book holds the value of the book edge.
+	Book *Book `json:"book,omitempty"`
+	// Loans holds the value of the loans edge.
+	Loans []*Loan `json:"loans,omitempty"`
+	// Holds holds the value of the holds edge.
+	Holds []*Hold `json:"holds,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [3]bool
+}
+
+// BookOrErr returns the Book value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e BookCopyEdges) BookOrErr() (*Book, error) {
+	if e.loadedTypes[0] {
+		if e.Book == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: book.Label}
+		}
+		return e.Book, nil
+	}
+	return nil, &NotLoadedError{edge: "book"}
+}
+
+// LoansOrErr returns the Loans value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookCopyEdges) LoansOrErr() ([]*Loan, error) {
+	if e.loadedTypes[1] {
+		return e.Loans, nil
+	}
+	return nil, &NotLoadedError{edge: "loans"}
+}
+
+// HoldsOrErr returns the Holds value or an error if the edge
+// was not loaded in eager-loading.
+func (e BookCopyEdges) HoldsOrErr() ([]*Hold, error) {
+	if e.loadedTypes[2] {
+		return e.Holds, nil
+	}
+	return nil, &NotLoadedError{edge: "holds"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*BookCopy) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case bookcopy.FieldID, bookcopy.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case bookcopy.FieldBarcode, bookcopy.FieldLocation, bookcopy.FieldCondition:
+			values[i] = new(sql.NullString)
+		case bookcopy.ForeignKeys[0]: // book_copy_book
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the BookCopy fields.
+func (bc *BookCopy) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case bookcopy.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			bc.ID = int64(value.Int64)
+		case bookcopy.FieldBarcode:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field barcode", values[i])
+			} else if value.Valid {
+				bc.Barcode = value.String
+			}
+		case bookcopy.FieldLocation:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field location", values[i])
+			} else if value.Valid {
+				bc.Location = value.String
+			}
+		case bookcopy.FieldCondition:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field condition", values[i])
+			} else if value.Valid {
+				bc.Condition = bookcopy.Condition(value.String)
+			}
+		case bookcopy.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				bc.CreatedAt = value.Int64
+			}
+		case bookcopy.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field book_copy_book", value)
+			} else if value.Valid {
+				bc.book_copy_book = new(int64)
+				*bc.book_copy_book = int64(value.Int64)
+			}
+		default:
+			bc.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the BookCopy.
+// This includes values selected through modifiers, order, etc.
+func (bc *BookCopy) Value(name string) (ent.Value, error) {
+	return bc.selectValues.Get(name)
+}
+
+// QueryBook queries the "book" edge of the BookCopy entity.
+func (bc *BookCopy) QueryBook() *BookQuery {
+	return NewBookCopyClient(bc.config).QueryBook(bc)
+}
+
+// QueryLoans queries the "loans" edge of the BookCopy entity.
+func (bc *BookCopy) QueryLoans() *LoanQuery {
+	return NewBookCopyClient(bc.config).QueryLoans(bc)
+}
+
+// QueryHolds queries the "holds" edge of the BookCopy entity.
+func (bc *BookCopy) QueryHolds() *HoldQuery {
+	return NewBookCopyClient(bc.config).QueryHolds(bc)
+}
+
+// Update returns a builder for updating this BookCopy.
+// Note that you need to call BookCopy.Unwrap() before calling this method if this BookCopy
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (bc *BookCopy) Update() *BookCopyUpdateOne {
+	return NewBookCopyClient(bc.config).UpdateOne(bc)
+}
+
+// Unwrap unwraps the BookCopy entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (bc *BookCopy) Unwrap() *BookCopy {
+	_tx, ok := bc.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: BookCopy is not a transactional entity")
+	}
+	bc.config.driver = _tx.drv
+	return bc
+}
+
+// String implements the fmt.Stringer.
+func (bc *BookCopy) String() string {
+	var builder strings.Builder
+	builder.WriteString("BookCopy(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", bc.ID))
+	builder.WriteString("barcode=")
+	builder.WriteString(bc.Barcode)
+	builder.WriteString(", ")
+	builder.WriteString("location=")
+	builder.WriteString(bc.Location)
+	builder.WriteString(", ")
+	builder.WriteString("condition=")
+	builder.WriteString(fmt.Sprintf("%v", bc.Condition))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", bc.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// BookCopies is a parsable slice of BookCopy.
+type BookCopies []*BookCopy
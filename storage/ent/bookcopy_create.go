@@ -0,0 +1,375 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+)
+
+// BookCopyCreate is the builder for creating a BookCopy entity.
+type BookCopyCreate struct {
+	config
+	mutation *BookCopyMutation
+	hooks    []Hook
+}
+
+// SetBarcode sets the "barcode" field.
+func (bcc *BookCopyCreate) SetBarcode(s string) *BookCopyCreate {
+	bcc.mutation.SetBarcode(s)
+	return bcc
+}
+
+// SetLocation sets the "location" field.
+func (bcc *BookCopyCreate) SetLocation(s string) *BookCopyCreate {
+	bcc.mutation.SetLocation(s)
+	return bcc
+}
+
+// SetNillableLocation sets the "location" field if the given value is not nil.
+func (bcc *BookCopyCreate) SetNillableLocation(s *string) *BookCopyCreate {
+	if s != nil {
+		bcc.SetLocation(*s)
+	}
+	return bcc
+}
+
+// SetCondition sets the "condition" field.
+func (bcc *BookCopyCreate) SetCondition(b bookcopy.Condition) *BookCopyCreate {
+	bcc.mutation.SetCondition(b)
+	return bcc
+}
+
+// SetNillableCondition sets the "condition" field if the given value is not nil.
+func (bcc *BookCopyCreate) SetNillableCondition(b *bookcopy.Condition) *BookCopyCreate {
+	if b != nil {
+		bcc.SetCondition(*b)
+	}
+	return bcc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (bcc *BookCopyCreate) SetCreatedAt(i int64) *BookCopyCreate {
+	bcc.mutation.SetCreatedAt(i)
+	return bcc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (bcc *BookCopyCreate) SetNillableCreatedAt(i *int64) *BookCopyCreate {
+	if i != nil {
+		bcc.SetCreatedAt(*i)
+	}
+	return bcc
+}
+
+// SetID sets the "id" field.
+func (bcc *BookCopyCreate) SetID(i int64) *BookCopyCreate {
+	bcc.mutation.SetID(i)
+	return bcc
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (bcc *BookCopyCreate) SetBookID(id int64) *BookCopyCreate {
+	bcc.mutation.SetBookID(id)
+	return bcc
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (bcc *BookCopyCreate) SetBook(b *Book) *BookCopyCreate {
+	return bcc.SetBookID(b.ID)
+}
+
+// AddLoanIDs adds the "loans" edge to the Loan entity by IDs.
+func (bcc *BookCopyCreate) AddLoanIDs(ids ...int64) *BookCopyCreate {
+	bcc.mutation.AddLoanIDs(ids...)
+	return bcc
+}
+
+// AddLoans adds the "loans" edges to the Loan entity.
+func (bcc *BookCopyCreate) AddLoans(l ...*Loan) *BookCopyCreate {
+	ids := make([]int64, len(l))
+	for i := range l {
+		ids[i] = l[i].ID
+	}
+	return bcc.AddLoanIDs(ids...)
+}
+
+// AddHoldIDs adds the "holds" edge to the Hold entity by IDs.
+func (bcc *BookCopyCreate) AddHoldIDs(ids ...int64) *BookCopyCreate {
+	bcc.mutation.AddHoldIDs(ids...)
+	return bcc
+}
+
+// AddHolds adds the "holds" edges to the Hold entity.
+func (bcc *BookCopyCreate) AddHolds(h ...*Hold) *BookCopyCreate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bcc.AddHoldIDs(ids...)
+}
+
+// Mutation returns the BookCopyMutation object of the builder.
+func (bcc *BookCopyCreate) Mutation() *BookCopyMutation {
+	return bcc.mutation
+}
+
+// Save creates the BookCopy in the database.
+func (bcc *BookCopyCreate) Save(ctx context.Context) (*BookCopy, error) {
+	bcc.defaults()
+	return withHooks(ctx, bcc.sqlSave, bcc.mutation, bcc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (bcc *BookCopyCreate) SaveX(ctx context.Context) *BookCopy {
+	v, err := bcc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (bcc *BookCopyCreate) Exec(ctx context.Context) error {
+	_, err := bcc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (bcc *BookCopyCreate) ExecX(ctx context.Context) {
+	if err := bcc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (bcc *BookCopyCreate) defaults() {
+	if _, ok := bcc.mutation.Condition(); !ok {
+		v := bookcopy.DefaultCondition
+		bcc.mutation.SetCondition(v)
+	}
+	if _, ok := bcc.mutation.CreatedAt(); !ok {
+		v := bookcopy.DefaultCreatedAt()
+		bcc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (bcc *BookCopyCreate) check() error {
+	if _, ok := bcc.mutation.Barcode(); !ok {
+		return &ValidationError{Name: "barcode", err: errors.New(`ent: missing required field "BookCopy.barcode"`)}
+	}
+	if v, ok := bcc.mutation.Barcode(); ok {
+		if err := bookcopy.BarcodeValidator(v); err != nil {
+			return &ValidationError{Name: "barcode", err: fmt.Errorf(`ent: validator failed for field "BookCopy.barcode": %w`, err)}
+		}
+	}
+	if _, ok := bcc.mutation.Condition(); !ok {
+		return &ValidationError{Name: "condition", err: errors.New(`ent: missing required field "BookCopy.condition"`)}
+	}
+	if v, ok := bcc.mutation.Condition(); ok {
+		if err := bookcopy.ConditionValidator(v); err != nil {
+			return &ValidationError{Name: "condition", err: fmt.Errorf(`ent: validator failed for field "BookCopy.condition": %w`, err)}
+		}
+	}
+	if _, ok := bcc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "BookCopy.created_at"`)}
+	}
+	if _, ok := bcc.mutation.BookID(); !ok {
+		return &ValidationError{Name: "book", err: errors.New(`ent: missing required edge "BookCopy.book"`)}
+	}
+	return nil
+}
+
+func (bcc *BookCopyCreate) sqlSave(ctx context.Context) (*BookCopy, error) {
+	if err := bcc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := bcc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, bcc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	bcc.mutation.id = &_node.ID
+	bcc.mutation.done = true
+	return _node, nil
+}
+
+func (bcc *BookCopyCreate) createSpec() (*BookCopy, *sqlgraph.CreateSpec) {
+	var (
+		_node = &BookCopy{config: bcc.config}
+		_spec = sqlgraph.NewCreateSpec(bookcopy.Table, sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64))
+	)
+	if id, ok := bcc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := bcc.mutation.Barcode(); ok {
+		_spec.SetField(bookcopy.FieldBarcode, field.TypeString, value)
+		_node.Barcode = value
+	}
+	if value, ok := bcc.mutation.Location(); ok {
+		_spec.SetField(bookcopy.FieldLocation, field.TypeString, value)
+		_node.Location = value
+	}
+	if value, ok := bcc.mutation.Condition(); ok {
+		_spec.SetField(bookcopy.FieldCondition, field.TypeEnum, value)
+		_node.Condition = value
+	}
+	if value, ok := bcc.mutation.CreatedAt(); ok {
+		_spec.SetField(bookcopy.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := bcc.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   bookcopy.BookTable,
+			Columns: []string{bookcopy.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.book_copy_book = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bcc.mutation.LoansIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.LoansTable,
+			Columns: []string{bookcopy.LoansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := bcc.mutation.HoldsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.HoldsTable,
+			Columns: []string{bookcopy.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// BookCopyCreateBulk is the builder for creating many BookCopy entities in bulk.
+type BookCopyCreateBulk struct {
+	config
+	err      error
+	builders []*BookCopyCreate
+}
+
+// Save creates the BookCopy entities in the database.
+func (bccb *BookCopyCreateBulk) Save(ctx context.Context) ([]*BookCopy, error) {
+	if bccb.err != nil {
+		return nil, bccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(bccb.builders))
+	nodes := make([]*BookCopy, len(bccb.builders))
+	mutators := make([]Mutator, len(bccb.builders))
+	for i := range bccb.builders {
+		func(i int, root context.Context) {
+			builder := bccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*BookCopyMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, bccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, bccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, bccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (bccb *BookCopyCreateBulk) SaveX(ctx context.Context) []*BookCopy {
+	v, err := bccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (bccb *BookCopyCreateBulk) Exec(ctx context.Context) error {
+	_, err := bccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (bccb *BookCopyCreateBulk) ExecX(ctx context.Context) {
+	if err := bccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
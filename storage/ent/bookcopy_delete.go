@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// BookCopyDelete is the builder for deleting a BookCopy entity.
+type BookCopyDelete struct {
+	config
+	hooks    []Hook
+	mutation *BookCopyMutation
+}
+
+// Where appends a list predicates to the BookCopyDelete builder.
+func (bcd *BookCopyDelete) Where(ps ...predicate.BookCopy) *BookCopyDelete {
+	bcd.mutation.Where(ps...)
+	return bcd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (bcd *BookCopyDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, bcd.sqlExec, bcd.mutation, bcd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (bcd *BookCopyDelete) ExecX(ctx context.Context) int {
+	n, err := bcd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (bcd *BookCopyDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(bookcopy.Table, sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64))
+	if ps := bcd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, bcd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	bcd.mutation.done = true
+	return affected, err
+}
+
+// BookCopyDeleteOne is the builder for deleting a single BookCopy entity.
+type BookCopyDeleteOne struct {
+	bcd *BookCopyDelete
+}
+
+// Where appends a list predicates to the BookCopyDelete builder.
+func (bcdo *BookCopyDeleteOne) Where(ps ...predicate.BookCopy) *BookCopyDeleteOne {
+	bcdo.bcd.mutation.Where(ps...)
+	return bcdo
+}
+
+// Exec executes the deletion query.
+func (bcdo *BookCopyDeleteOne) Exec(ctx context.Context) error {
+	n, err := bcdo.bcd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{bookcopy.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (bcdo *BookCopyDeleteOne) ExecX(ctx context.Context) {
+	if err := bcdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
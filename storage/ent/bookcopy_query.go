@@ -0,0 +1,764 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// BookCopyQuery is the builder for querying BookCopy entities.
+type BookCopyQuery struct {
+	config
+	ctx        *QueryContext
+	order      []bookcopy.OrderOption
+	inters     []Interceptor
+	predicates []predicate.BookCopy
+	withBook   *BookQuery
+	withLoans  *LoanQuery
+	withHolds  *HoldQuery
+	withFKs    bool
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the BookCopyQuery builder.
+func (bcq *BookCopyQuery) Where(ps ...predicate.BookCopy) *BookCopyQuery {
+	bcq.predicates = append(bcq.predicates, ps...)
+	return bcq
+}
+
+// Limit the number of records to be returned by this query.
+func (bcq *BookCopyQuery) Limit(limit int) *BookCopyQuery {
+	bcq.ctx.Limit = &limit
+	return bcq
+}
+
+// Offset to start from.
+func (bcq *BookCopyQuery) Offset(offset int) *BookCopyQuery {
+	bcq.ctx.Offset = &offset
+	return bcq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (bcq *BookCopyQuery) Unique(unique bool) *BookCopyQuery {
+	bcq.ctx.Unique = &unique
+	return bcq
+}
+
+// Order specifies how the records should be ordered.
+func (bcq *BookCopyQuery) Order(o ...bookcopy.OrderOption) *BookCopyQuery {
+	bcq.order = append(bcq.order, o...)
+	return bcq
+}
+
+// QueryBook chains the current query on the "book" edge.
+func (bcq *BookCopyQuery) QueryBook() *BookQuery {
+	query := (&BookClient{config: bcq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bcq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bcq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(bookcopy.Table, bookcopy.FieldID, selector),
+			sqlgraph.To(book.Table, book.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, bookcopy.BookTable, bookcopy.BookColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bcq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryLoans chains the current query on the "loans" edge.
+func (bcq *BookCopyQuery) QueryLoans() *LoanQuery {
+	query := (&LoanClient{config: bcq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bcq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bcq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(bookcopy.Table, bookcopy.FieldID, selector),
+			sqlgraph.To(loan.Table, loan.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, bookcopy.LoansTable, bookcopy.LoansColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bcq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryHolds chains the current query on the "holds" edge.
+func (bcq *BookCopyQuery) QueryHolds() *HoldQuery {
+	query := (&HoldClient{config: bcq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := bcq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := bcq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(bookcopy.Table, bookcopy.FieldID, selector),
+			sqlgraph.To(hold.Table, hold.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, bookcopy.HoldsTable, bookcopy.HoldsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(bcq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first BookCopy entity from the query.
+// Returns a *NotFoundError when no BookCopy was found.
+func (bcq *BookCopyQuery) First(ctx context.Context) (*BookCopy, error) {
+	nodes, err := bcq.Limit(1).All(setContextOp(ctx, bcq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{bookcopy.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (bcq *BookCopyQuery) FirstX(ctx context.Context) *BookCopy {
+	node, err := bcq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first BookCopy ID from the query.
+// Returns a *NotFoundError when no BookCopy ID was found.
+func (bcq *BookCopyQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = bcq.Limit(1).IDs(setContextOp(ctx, bcq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{bookcopy.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (bcq *BookCopyQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := bcq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single BookCopy entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one BookCopy entity is found.
+// Returns a *NotFoundError when no BookCopy entities are found.
+func (bcq *BookCopyQuery) Only(ctx context.Context) (*BookCopy, error) {
+	nodes, err := bcq.Limit(2).All(setContextOp(ctx, bcq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{bookcopy.Label}
+	default:
+		return nil, &NotSingularError{bookcopy.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (bcq *BookCopyQuery) OnlyX(ctx context.Context) *BookCopy {
+	node, err := bcq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only BookCopy ID in the query.
+// Returns a *NotSingularError when more than one BookCopy ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (bcq *BookCopyQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = bcq.Limit(2).IDs(setContextOp(ctx, bcq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{bookcopy.Label}
+	default:
+		err = &NotSingularError{bookcopy.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (bcq *BookCopyQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := bcq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of BookCopies.
+func (bcq *BookCopyQuery) All(ctx context.Context) ([]*BookCopy, error) {
+	ctx = setContextOp(ctx, bcq.ctx, "All")
+	if err := bcq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*BookCopy, *BookCopyQuery]()
+	return withInterceptors[[]*BookCopy](ctx, bcq, qr, bcq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (bcq *BookCopyQuery) AllX(ctx context.Context) []*BookCopy {
+	nodes, err := bcq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of BookCopy IDs.
+func (bcq *BookCopyQuery) IDs(ctx context.Context) (ids []int64, err error) {
+	if bcq.ctx.Unique == nil && bcq.path != nil {
+		bcq.Unique(true)
+	}
+	ctx = setContextOp(ctx, bcq.ctx, "IDs")
+	if err = bcq.Select(bookcopy.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (bcq *BookCopyQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := bcq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (bcq *BookCopyQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, bcq.ctx, "Count")
+	if err := bcq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, bcq, querierCount[*BookCopyQuery](), bcq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (bcq *BookCopyQuery) CountX(ctx context.Context) int {
+	count, err := bcq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (bcq *BookCopyQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, bcq.ctx, "Exist")
+	switch _, err := bcq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (bcq *BookCopyQuery) ExistX(ctx context.Context) bool {
+	exist, err := bcq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the BookCopyQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (bcq *BookCopyQuery) Clone() *BookCopyQuery {
+	if bcq == nil {
+		return nil
+	}
+	return &BookCopyQuery{
+		config:     bcq.config,
+		ctx:        bcq.ctx.Clone(),
+		order:      append([]bookcopy.OrderOption{}, bcq.order...),
+		inters:     append([]Interceptor{}, bcq.inters...),
+		predicates: append([]predicate.BookCopy{}, bcq.predicates...),
+		withBook:   bcq.withBook.Clone(),
+		withLoans:  bcq.withLoans.Clone(),
+		withHolds:  bcq.withHolds.Clone(),
+		// clone intermediate query.
+		sql:  bcq.sql.Clone(),
+		path: bcq.path,
+	}
+}
+
+// WithBook tells the query-builder to eager-load the nodes that are connected to
+// the "book" edge. The optional arguments are used to configure the query builder of the edge.
+func (bcq *BookCopyQuery) WithBook(opts ...func(*BookQuery)) *BookCopyQuery {
+	query := (&BookClient{config: bcq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bcq.withBook = query
+	return bcq
+}
+
+// WithLoans tells the query-builder to eager-load the nodes that are connected to
+// the "loans" edge. The optional arguments are used to configure the query builder of the edge.
+func (bcq *BookCopyQuery) WithLoans(opts ...func(*LoanQuery)) *BookCopyQuery {
+	query := (&LoanClient{config: bcq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bcq.withLoans = query
+	return bcq
+}
+
+// WithHolds tells the query-builder to eager-load the nodes that are connected to
+// the "holds" edge. The optional arguments are used to configure the query builder of the edge.
+func (bcq *BookCopyQuery) WithHolds(opts ...func(*HoldQuery)) *BookCopyQuery {
+	query := (&HoldClient{config: bcq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	bcq.withHolds = query
+	return bcq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Barcode string `json:"barcode,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.BookCopy.Query().
+//		GroupBy(bookcopy.FieldBarcode).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (bcq *BookCopyQuery) GroupBy(field string, fields ...string) *BookCopyGroupBy {
+	bcq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &BookCopyGroupBy{build: bcq}
+	grbuild.flds = &bcq.ctx.Fields
+	grbuild.label = bookcopy.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Barcode string `json:"barcode,omitempty"`
+//	}
+//
+//	client.BookCopy.Query().
+//		Select(bookcopy.FieldBarcode).
+//		Scan(ctx, &v)
+func (bcq *BookCopyQuery) Select(fields ...string) *BookCopySelect {
+	bcq.ctx.Fields = append(bcq.ctx.Fields, fields...)
+	sbuild := &BookCopySelect{BookCopyQuery: bcq}
+	sbuild.label = bookcopy.Label
+	sbuild.flds, sbuild.scan = &bcq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a BookCopySelect configured with the given aggregations.
+func (bcq *BookCopyQuery) Aggregate(fns ...AggregateFunc) *BookCopySelect {
+	return bcq.Select().Aggregate(fns...)
+}
+
+func (bcq *BookCopyQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range bcq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, bcq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range bcq.ctx.Fields {
+		if !bookcopy.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if bcq.path != nil {
+		prev, err := bcq.path(ctx)
+		if err != nil {
+			return err
+		}
+		bcq.sql = prev
+	}
+	return nil
+}
+
+func (bcq *BookCopyQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*BookCopy, error) {
+	var (
+		nodes       = []*BookCopy{}
+		withFKs     = bcq.withFKs
+		_spec       = bcq.querySpec()
+		loadedTypes = [3]bool{
+			bcq.withBook != nil,
+			bcq.withLoans != nil,
+			bcq.withHolds != nil,
+		}
+	)
+	if bcq.withBook != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, bookcopy.ForeignKeys...)
+	}
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*BookCopy).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &BookCopy{config: bcq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, bcq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := bcq.withBook; query != nil {
+		if err := bcq.loadBook(ctx, query, nodes, nil,
+			func(n *BookCopy, e *Book) { n.Edges.Book = e }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bcq.withLoans; query != nil {
+		if err := bcq.loadLoans(ctx, query, nodes,
+			func(n *BookCopy) { n.Edges.Loans = []*Loan{} },
+			func(n *BookCopy, e *Loan) { n.Edges.Loans = append(n.Edges.Loans, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := bcq.withHolds; query != nil {
+		if err := bcq.loadHolds(ctx, query, nodes,
+			func(n *BookCopy) { n.Edges.Holds = []*Hold{} },
+			func(n *BookCopy, e *Hold) { n.Edges.Holds = append(n.Edges.Holds, e) }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (bcq *BookCopyQuery) loadBook(ctx context.Context, query *BookQuery, nodes []*BookCopy, init func(*BookCopy), assign func(*BookCopy, *Book)) error {
+	ids := make([]int64, 0, len(nodes))
+	nodeids := make(map[int64][]*BookCopy)
+	for i := range nodes {
+		if nodes[i].book_copy_book == nil {
+			continue
+		}
+		fk := *nodes[i].book_copy_book
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(book.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "book_copy_book" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+func (bcq *BookCopyQuery) loadLoans(ctx context.Context, query *LoanQuery, nodes []*BookCopy, init func(*BookCopy), assign func(*BookCopy, *Loan)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*BookCopy)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Loan(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(bookcopy.LoansColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.loan_copy
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "loan_copy" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "loan_copy" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (bcq *BookCopyQuery) loadHolds(ctx context.Context, query *HoldQuery, nodes []*BookCopy, init func(*BookCopy), assign func(*BookCopy, *Hold)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int64]*BookCopy)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Hold(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(bookcopy.HoldsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.hold_copy
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "hold_copy" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "hold_copy" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+
+func (bcq *BookCopyQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := bcq.querySpec()
+	_spec.Node.Columns = bcq.ctx.Fields
+	if len(bcq.ctx.Fields) > 0 {
+		_spec.Unique = bcq.ctx.Unique != nil && *bcq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, bcq.driver, _spec)
+}
+
+func (bcq *BookCopyQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(bookcopy.Table, bookcopy.Columns, sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64))
+	_spec.From = bcq.sql
+	if unique := bcq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if bcq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := bcq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, bookcopy.FieldID)
+		for i := range fields {
+			if fields[i] != bookcopy.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := bcq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := bcq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := bcq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := bcq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (bcq *BookCopyQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(bcq.driver.Dialect())
+	t1 := builder.Table(bookcopy.Table)
+	columns := bcq.ctx.Fields
+	if len(columns) == 0 {
+		columns = bookcopy.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if bcq.sql != nil {
+		selector = bcq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if bcq.ctx.Unique != nil && *bcq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range bcq.predicates {
+		p(selector)
+	}
+	for _, p := range bcq.order {
+		p(selector)
+	}
+	if offset := bcq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := bcq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// BookCopyGroupBy is the group-by builder for BookCopy entities.
+type BookCopyGroupBy struct {
+	selector
+	build *BookCopyQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (bcgb *BookCopyGroupBy) Aggregate(fns ...AggregateFunc) *BookCopyGroupBy {
+	bcgb.fns = append(bcgb.fns, fns...)
+	return bcgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (bcgb *BookCopyGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, bcgb.build.ctx, "GroupBy")
+	if err := bcgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*BookCopyQuery, *BookCopyGroupBy](ctx, bcgb.build, bcgb, bcgb.build.inters, v)
+}
+
+func (bcgb *BookCopyGroupBy) sqlScan(ctx context.Context, root *BookCopyQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(bcgb.fns))
+	for _, fn := range bcgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*bcgb.flds)+len(bcgb.fns))
+		for _, f := range *bcgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*bcgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := bcgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// BookCopySelect is the builder for selecting fields of BookCopy entities.
+type BookCopySelect struct {
+	*BookCopyQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (bcs *BookCopySelect) Aggregate(fns ...AggregateFunc) *BookCopySelect {
+	bcs.fns = append(bcs.fns, fns...)
+	return bcs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (bcs *BookCopySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, bcs.ctx, "Select")
+	if err := bcs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*BookCopyQuery, *BookCopySelect](ctx, bcs.BookCopyQuery, bcs, bcs.inters, v)
+}
+
+func (bcs *BookCopySelect) sqlScan(ctx context.Context, root *BookCopyQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(bcs.fns))
+	for _, fn := range bcs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*bcs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := bcs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
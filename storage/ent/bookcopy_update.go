@@ -0,0 +1,740 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// BookCopyUpdate is the builder for updating BookCopy entities.
+type BookCopyUpdate struct {
+	config
+	hooks    []Hook
+	mutation *BookCopyMutation
+}
+
+// Where appends a list predicates to the BookCopyUpdate builder.
+func (bcu *BookCopyUpdate) Where(ps ...predicate.BookCopy) *BookCopyUpdate {
+	bcu.mutation.Where(ps...)
+	return bcu
+}
+
+// SetBarcode sets the "barcode" field.
+func (bcu *BookCopyUpdate) SetBarcode(s string) *BookCopyUpdate {
+	bcu.mutation.SetBarcode(s)
+	return bcu
+}
+
+// SetLocation sets the "location" field.
+func (bcu *BookCopyUpdate) SetLocation(s string) *BookCopyUpdate {
+	bcu.mutation.SetLocation(s)
+	return bcu
+}
+
+// SetNillableLocation sets the "location" field if the given value is not nil.
+func (bcu *BookCopyUpdate) SetNillableLocation(s *string) *BookCopyUpdate {
+	if s != nil {
+		bcu.SetLocation(*s)
+	}
+	return bcu
+}
+
+// ClearLocation clears the value of the "location" field.
+func (bcu *BookCopyUpdate) ClearLocation() *BookCopyUpdate {
+	bcu.mutation.ClearLocation()
+	return bcu
+}
+
+// SetCondition sets the "condition" field.
+func (bcu *BookCopyUpdate) SetCondition(b bookcopy.Condition) *BookCopyUpdate {
+	bcu.mutation.SetCondition(b)
+	return bcu
+}
+
+// SetNillableCondition sets the "condition" field if the given value is not nil.
+func (bcu *BookCopyUpdate) SetNillableCondition(b *bookcopy.Condition) *BookCopyUpdate {
+	if b != nil {
+		bcu.SetCondition(*b)
+	}
+	return bcu
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (bcu *BookCopyUpdate) SetBookID(id int64) *BookCopyUpdate {
+	bcu.mutation.SetBookID(id)
+	return bcu
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (bcu *BookCopyUpdate) SetBook(b *Book) *BookCopyUpdate {
+	return bcu.SetBookID(b.ID)
+}
+
+// AddLoanIDs adds the "loans" edge to the Loan entity by IDs.
+func (bcu *BookCopyUpdate) AddLoanIDs(ids ...int64) *BookCopyUpdate {
+	bcu.mutation.AddLoanIDs(ids...)
+	return bcu
+}
+
+// AddLoans adds the "loans" edges to the Loan entity.
+func (bcu *BookCopyUpdate) AddLoans(l ...*Loan) *BookCopyUpdate {
+	ids := make([]int64, len(l))
+	for i := range l {
+		ids[i] = l[i].ID
+	}
+	return bcu.AddLoanIDs(ids...)
+}
+
+// AddHoldIDs adds the "holds" edge to the Hold entity by IDs.
+func (bcu *BookCopyUpdate) AddHoldIDs(ids ...int64) *BookCopyUpdate {
+	bcu.mutation.AddHoldIDs(ids...)
+	return bcu
+}
+
+// AddHolds adds the "holds" edges to the Hold entity.
+func (bcu *BookCopyUpdate) AddHolds(h ...*Hold) *BookCopyUpdate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bcu.AddHoldIDs(ids...)
+}
+
+// Mutation returns the BookCopyMutation object of the builder.
+func (bcu *BookCopyUpdate) Mutation() *BookCopyMutation {
+	return bcu.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (bcu *BookCopyUpdate) ClearBook() *BookCopyUpdate {
+	bcu.mutation.ClearBook()
+	return bcu
+}
+
+// ClearLoans clears all "loans" edges to the Loan entity.
+func (bcu *BookCopyUpdate) ClearLoans() *BookCopyUpdate {
+	bcu.mutation.ClearLoans()
+	return bcu
+}
+
+// RemoveLoanIDs removes the "loans" edge to Loan entities by IDs.
+func (bcu *BookCopyUpdate) RemoveLoanIDs(ids ...int64) *BookCopyUpdate {
+	bcu.mutation.RemoveLoanIDs(ids...)
+	return bcu
+}
+
+// RemoveLoans removes "loans" edges to Loan entities.
+func (bcu *BookCopyUpdate) RemoveLoans(l ...*Loan) *BookCopyUpdate {
+	ids := make([]int64, len(l))
+	for i := range l {
+		ids[i] = l[i].ID
+	}
+	return bcu.RemoveLoanIDs(ids...)
+}
+
+// ClearHolds clears all "holds" edges to the Hold entity.
+func (bcu *BookCopyUpdate) ClearHolds() *BookCopyUpdate {
+	bcu.mutation.ClearHolds()
+	return bcu
+}
+
+// RemoveHoldIDs removes the "holds" edge to Hold entities by IDs.
+func (bcu *BookCopyUpdate) RemoveHoldIDs(ids ...int64) *BookCopyUpdate {
+	bcu.mutation.RemoveHoldIDs(ids...)
+	return bcu
+}
+
+// RemoveHolds removes "holds" edges to Hold entities.
+func (bcu *BookCopyUpdate) RemoveHolds(h ...*Hold) *BookCopyUpdate {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bcu.RemoveHoldIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (bcu *BookCopyUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, bcu.sqlSave, bcu.mutation, bcu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (bcu *BookCopyUpdate) SaveX(ctx context.Context) int {
+	affected, err := bcu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (bcu *BookCopyUpdate) Exec(ctx context.Context) error {
+	_, err := bcu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (bcu *BookCopyUpdate) ExecX(ctx context.Context) {
+	if err := bcu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (bcu *BookCopyUpdate) check() error {
+	if v, ok := bcu.mutation.Barcode(); ok {
+		if err := bookcopy.BarcodeValidator(v); err != nil {
+			return &ValidationError{Name: "barcode", err: fmt.Errorf(`ent: validator failed for field "BookCopy.barcode": %w`, err)}
+		}
+	}
+	if v, ok := bcu.mutation.Condition(); ok {
+		if err := bookcopy.ConditionValidator(v); err != nil {
+			return &ValidationError{Name: "condition", err: fmt.Errorf(`ent: validator failed for field "BookCopy.condition": %w`, err)}
+		}
+	}
+	if _, ok := bcu.mutation.BookID(); bcu.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "BookCopy.book"`)
+	}
+	return nil
+}
+
+func (bcu *BookCopyUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := bcu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(bookcopy.Table, bookcopy.Columns, sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64))
+	if ps := bcu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := bcu.mutation.Barcode(); ok {
+		_spec.SetField(bookcopy.FieldBarcode, field.TypeString, value)
+	}
+	if value, ok := bcu.mutation.Location(); ok {
+		_spec.SetField(bookcopy.FieldLocation, field.TypeString, value)
+	}
+	if bcu.mutation.LocationCleared() {
+		_spec.ClearField(bookcopy.FieldLocation, field.TypeString)
+	}
+	if value, ok := bcu.mutation.Condition(); ok {
+		_spec.SetField(bookcopy.FieldCondition, field.TypeEnum, value)
+	}
+	if bcu.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   bookcopy.BookTable,
+			Columns: []string{bookcopy.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcu.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   bookcopy.BookTable,
+			Columns: []string{bookcopy.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bcu.mutation.LoansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.LoansTable,
+			Columns: []string{bookcopy.LoansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcu.mutation.RemovedLoansIDs(); len(nodes) > 0 && !bcu.mutation.LoansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.LoansTable,
+			Columns: []string{bookcopy.LoansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcu.mutation.LoansIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.LoansTable,
+			Columns: []string{bookcopy.LoansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bcu.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.HoldsTable,
+			Columns: []string{bookcopy.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcu.mutation.RemovedHoldsIDs(); len(nodes) > 0 && !bcu.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.HoldsTable,
+			Columns: []string{bookcopy.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcu.mutation.HoldsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.HoldsTable,
+			Columns: []string{bookcopy.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, bcu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{bookcopy.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	bcu.mutation.done = true
+	return n, nil
+}
+
+// BookCopyUpdateOne is the builder for updating a single BookCopy entity.
+type BookCopyUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *BookCopyMutation
+}
+
+// SetBarcode sets the "barcode" field.
+func (bcuo *BookCopyUpdateOne) SetBarcode(s string) *BookCopyUpdateOne {
+	bcuo.mutation.SetBarcode(s)
+	return bcuo
+}
+
+// SetLocation sets the "location" field.
+func (bcuo *BookCopyUpdateOne) SetLocation(s string) *BookCopyUpdateOne {
+	bcuo.mutation.SetLocation(s)
+	return bcuo
+}
+
+// SetNillableLocation sets the "location" field if the given value is not nil.
+func (bcuo *BookCopyUpdateOne) SetNillableLocation(s *string) *BookCopyUpdateOne {
+	if s != nil {
+		bcuo.SetLocation(*s)
+	}
+	return bcuo
+}
+
+// ClearLocation clears the value of the "location" field.
+func (bcuo *BookCopyUpdateOne) ClearLocation() *BookCopyUpdateOne {
+	bcuo.mutation.ClearLocation()
+	return bcuo
+}
+
+// SetCondition sets the "condition" field.
+func (bcuo *BookCopyUpdateOne) SetCondition(b bookcopy.Condition) *BookCopyUpdateOne {
+	bcuo.mutation.SetCondition(b)
+	return bcuo
+}
+
+// SetNillableCondition sets the "condition" field if the given value is not nil.
+func (bcuo *BookCopyUpdateOne) SetNillableCondition(b *bookcopy.Condition) *BookCopyUpdateOne {
+	if b != nil {
+		bcuo.SetCondition(*b)
+	}
+	return bcuo
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (bcuo *BookCopyUpdateOne) SetBookID(id int64) *BookCopyUpdateOne {
+	bcuo.mutation.SetBookID(id)
+	return bcuo
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (bcuo *BookCopyUpdateOne) SetBook(b *Book) *BookCopyUpdateOne {
+	return bcuo.SetBookID(b.ID)
+}
+
+// AddLoanIDs adds the "loans" edge to the Loan entity by IDs.
+func (bcuo *BookCopyUpdateOne) AddLoanIDs(ids ...int64) *BookCopyUpdateOne {
+	bcuo.mutation.AddLoanIDs(ids...)
+	return bcuo
+}
+
+// AddLoans adds the "loans" edges to the Loan entity.
+func (bcuo *BookCopyUpdateOne) AddLoans(l ...*Loan) *BookCopyUpdateOne {
+	ids := make([]int64, len(l))
+	for i := range l {
+		ids[i] = l[i].ID
+	}
+	return bcuo.AddLoanIDs(ids...)
+}
+
+// AddHoldIDs adds the "holds" edge to the Hold entity by IDs.
+func (bcuo *BookCopyUpdateOne) AddHoldIDs(ids ...int64) *BookCopyUpdateOne {
+	bcuo.mutation.AddHoldIDs(ids...)
+	return bcuo
+}
+
+// AddHolds adds the "holds" edges to the Hold entity.
+func (bcuo *BookCopyUpdateOne) AddHolds(h ...*Hold) *BookCopyUpdateOne {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bcuo.AddHoldIDs(ids...)
+}
+
+// Mutation returns the BookCopyMutation object of the builder.
+func (bcuo *BookCopyUpdateOne) Mutation() *BookCopyMutation {
+	return bcuo.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (bcuo *BookCopyUpdateOne) ClearBook() *BookCopyUpdateOne {
+	bcuo.mutation.ClearBook()
+	return bcuo
+}
+
+// ClearLoans clears all "loans" edges to the Loan entity.
+func (bcuo *BookCopyUpdateOne) ClearLoans() *BookCopyUpdateOne {
+	bcuo.mutation.ClearLoans()
+	return bcuo
+}
+
+// RemoveLoanIDs removes the "loans" edge to Loan entities by IDs.
+func (bcuo *BookCopyUpdateOne) RemoveLoanIDs(ids ...int64) *BookCopyUpdateOne {
+	bcuo.mutation.RemoveLoanIDs(ids...)
+	return bcuo
+}
+
+// RemoveLoans removes "loans" edges to Loan entities.
+func (bcuo *BookCopyUpdateOne) RemoveLoans(l ...*Loan) *BookCopyUpdateOne {
+	ids := make([]int64, len(l))
+	for i := range l {
+		ids[i] = l[i].ID
+	}
+	return bcuo.RemoveLoanIDs(ids...)
+}
+
+// ClearHolds clears all "holds" edges to the Hold entity.
+func (bcuo *BookCopyUpdateOne) ClearHolds() *BookCopyUpdateOne {
+	bcuo.mutation.ClearHolds()
+	return bcuo
+}
+
+// RemoveHoldIDs removes the "holds" edge to Hold entities by IDs.
+func (bcuo *BookCopyUpdateOne) RemoveHoldIDs(ids ...int64) *BookCopyUpdateOne {
+	bcuo.mutation.RemoveHoldIDs(ids...)
+	return bcuo
+}
+
+// RemoveHolds removes "holds" edges to Hold entities.
+func (bcuo *BookCopyUpdateOne) RemoveHolds(h ...*Hold) *BookCopyUpdateOne {
+	ids := make([]int64, len(h))
+	for i := range h {
+		ids[i] = h[i].ID
+	}
+	return bcuo.RemoveHoldIDs(ids...)
+}
+
+// Where appends a list predicates to the BookCopyUpdate builder.
+func (bcuo *BookCopyUpdateOne) Where(ps ...predicate.BookCopy) *BookCopyUpdateOne {
+	bcuo.mutation.Where(ps...)
+	return bcuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (bcuo *BookCopyUpdateOne) Select(field string, fields ...string) *BookCopyUpdateOne {
+	bcuo.fields = append([]string{field}, fields...)
+	return bcuo
+}
+
+// Save executes the query and returns the updated BookCopy entity.
+func (bcuo *BookCopyUpdateOne) Save(ctx context.Context) (*BookCopy, error) {
+	return withHooks(ctx, bcuo.sqlSave, bcuo.mutation, bcuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (bcuo *BookCopyUpdateOne) SaveX(ctx context.Context) *BookCopy {
+	node, err := bcuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (bcuo *BookCopyUpdateOne) Exec(ctx context.Context) error {
+	_, err := bcuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (bcuo *BookCopyUpdateOne) ExecX(ctx context.Context) {
+	if err := bcuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (bcuo *BookCopyUpdateOne) check() error {
+	if v, ok := bcuo.mutation.Barcode(); ok {
+		if err := bookcopy.BarcodeValidator(v); err != nil {
+			return &ValidationError{Name: "barcode", err: fmt.Errorf(`ent: validator failed for field "BookCopy.barcode": %w`, err)}
+		}
+	}
+	if v, ok := bcuo.mutation.Condition(); ok {
+		if err := bookcopy.ConditionValidator(v); err != nil {
+			return &ValidationError{Name: "condition", err: fmt.Errorf(`ent: validator failed for field "BookCopy.condition": %w`, err)}
+		}
+	}
+	if _, ok := bcuo.mutation.BookID(); bcuo.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "BookCopy.book"`)
+	}
+	return nil
+}
+
+func (bcuo *BookCopyUpdateOne) sqlSave(ctx context.Context) (_node *BookCopy, err error) {
+	if err := bcuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(bookcopy.Table, bookcopy.Columns, sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64))
+	id, ok := bcuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "BookCopy.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := bcuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, bookcopy.FieldID)
+		for _, f := range fields {
+			if !bookcopy.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != bookcopy.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := bcuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := bcuo.mutation.Barcode(); ok {
+		_spec.SetField(bookcopy.FieldBarcode, field.TypeString, value)
+	}
+	if value, ok := bcuo.mutation.Location(); ok {
+		_spec.SetField(bookcopy.FieldLocation, field.TypeString, value)
+	}
+	if bcuo.mutation.LocationCleared() {
+		_spec.ClearField(bookcopy.FieldLocation, field.TypeString)
+	}
+	if value, ok := bcuo.mutation.Condition(); ok {
+		_spec.SetField(bookcopy.FieldCondition, field.TypeEnum, value)
+	}
+	if bcuo.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   bookcopy.BookTable,
+			Columns: []string{bookcopy.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcuo.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   bookcopy.BookTable,
+			Columns: []string{bookcopy.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bcuo.mutation.LoansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.LoansTable,
+			Columns: []string{bookcopy.LoansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcuo.mutation.RemovedLoansIDs(); len(nodes) > 0 && !bcuo.mutation.LoansCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.LoansTable,
+			Columns: []string{bookcopy.LoansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcuo.mutation.LoansIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.LoansTable,
+			Columns: []string{bookcopy.LoansColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if bcuo.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.HoldsTable,
+			Columns: []string{bookcopy.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcuo.mutation.RemovedHoldsIDs(); len(nodes) > 0 && !bcuo.mutation.HoldsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.HoldsTable,
+			Columns: []string{bookcopy.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := bcuo.mutation.HoldsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   bookcopy.HoldsTable,
+			Columns: []string{bookcopy.HoldsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &BookCopy{config: bcuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, bcuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{bookcopy.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	bcuo.mutation.done = true
+	return _node, nil
+}
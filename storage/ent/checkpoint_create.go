@@ -0,0 +1,227 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/checkpoint"
+)
+
+// CheckpointCreate is the builder for creating a Checkpoint entity.
+type CheckpointCreate struct {
+	config
+	mutation *CheckpointMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (cc *CheckpointCreate) SetName(s string) *CheckpointCreate {
+	cc.mutation.SetName(s)
+	return cc
+}
+
+// SetValue sets the "value" field.
+func (cc *CheckpointCreate) SetValue(s string) *CheckpointCreate {
+	cc.mutation.SetValue(s)
+	return cc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (cc *CheckpointCreate) SetUpdatedAt(i int64) *CheckpointCreate {
+	cc.mutation.SetUpdatedAt(i)
+	return cc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (cc *CheckpointCreate) SetNillableUpdatedAt(i *int64) *CheckpointCreate {
+	if i != nil {
+		cc.SetUpdatedAt(*i)
+	}
+	return cc
+}
+
+// Mutation returns the CheckpointMutation object of the builder.
+func (cc *CheckpointCreate) Mutation() *CheckpointMutation {
+	return cc.mutation
+}
+
+// Save creates the Checkpoint in the database.
+func (cc *CheckpointCreate) Save(ctx context.Context) (*Checkpoint, error) {
+	cc.defaults()
+	return withHooks(ctx, cc.sqlSave, cc.mutation, cc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cc *CheckpointCreate) SaveX(ctx context.Context) *Checkpoint {
+	v, err := cc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cc *CheckpointCreate) Exec(ctx context.Context) error {
+	_, err := cc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cc *CheckpointCreate) ExecX(ctx context.Context) {
+	if err := cc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cc *CheckpointCreate) defaults() {
+	if _, ok := cc.mutation.UpdatedAt(); !ok {
+		v := checkpoint.DefaultUpdatedAt()
+		cc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cc *CheckpointCreate) check() error {
+	if _, ok := cc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Checkpoint.name"`)}
+	}
+	if _, ok := cc.mutation.Value(); !ok {
+		return &ValidationError{Name: "value", err: errors.New(`ent: missing required field "Checkpoint.value"`)}
+	}
+	if _, ok := cc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Checkpoint.updated_at"`)}
+	}
+	return nil
+}
+
+func (cc *CheckpointCreate) sqlSave(ctx context.Context) (*Checkpoint, error) {
+	if err := cc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	cc.mutation.id = &_node.ID
+	cc.mutation.done = true
+	return _node, nil
+}
+
+func (cc *CheckpointCreate) createSpec() (*Checkpoint, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Checkpoint{config: cc.config}
+		_spec = sqlgraph.NewCreateSpec(checkpoint.Table, sqlgraph.NewFieldSpec(checkpoint.FieldID, field.TypeInt))
+	)
+	if value, ok := cc.mutation.Name(); ok {
+		_spec.SetField(checkpoint.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := cc.mutation.Value(); ok {
+		_spec.SetField(checkpoint.FieldValue, field.TypeString, value)
+		_node.Value = value
+	}
+	if value, ok := cc.mutation.UpdatedAt(); ok {
+		_spec.SetField(checkpoint.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	return _node, _spec
+}
+
+// CheckpointCreateBulk is the builder for creating many Checkpoint entities in bulk.
+type CheckpointCreateBulk struct {
+	config
+	err      error
+	builders []*CheckpointCreate
+}
+
+// Save creates the Checkpoint entities in the database.
+func (ccb *CheckpointCreateBulk) Save(ctx context.Context) ([]*Checkpoint, error) {
+	if ccb.err != nil {
+		return nil, ccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ccb.builders))
+	nodes := make([]*Checkpoint, len(ccb.builders))
+	mutators := make([]Mutator, len(ccb.builders))
+	for i := range ccb.builders {
+		func(i int, root context.Context) {
+			builder := ccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CheckpointMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccb *CheckpointCreateBulk) SaveX(ctx context.Context) []*Checkpoint {
+	v, err := ccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ccb *CheckpointCreateBulk) Exec(ctx context.Context) error {
+	_, err := ccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccb *CheckpointCreateBulk) ExecX(ctx context.Context) {
+	if err := ccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,267 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/checkpoint"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// CheckpointUpdate is the builder for updating Checkpoint entities.
+type CheckpointUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CheckpointMutation
+}
+
+// Where appends a list predicates to the CheckpointUpdate builder.
+func (cu *CheckpointUpdate) Where(ps ...predicate.Checkpoint) *CheckpointUpdate {
+	cu.mutation.Where(ps...)
+	return cu
+}
+
+// SetName sets the "name" field.
+func (cu *CheckpointUpdate) SetName(s string) *CheckpointUpdate {
+	cu.mutation.SetName(s)
+	return cu
+}
+
+// SetValue sets the "value" field.
+func (cu *CheckpointUpdate) SetValue(s string) *CheckpointUpdate {
+	cu.mutation.SetValue(s)
+	return cu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (cu *CheckpointUpdate) SetUpdatedAt(i int64) *CheckpointUpdate {
+	cu.mutation.ResetUpdatedAt()
+	cu.mutation.SetUpdatedAt(i)
+	return cu
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (cu *CheckpointUpdate) AddUpdatedAt(i int64) *CheckpointUpdate {
+	cu.mutation.AddUpdatedAt(i)
+	return cu
+}
+
+// Mutation returns the CheckpointMutation object of the builder.
+func (cu *CheckpointUpdate) Mutation() *CheckpointMutation {
+	return cu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cu *CheckpointUpdate) Save(ctx context.Context) (int, error) {
+	cu.defaults()
+	return withHooks(ctx, cu.sqlSave, cu.mutation, cu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cu *CheckpointUpdate) SaveX(ctx context.Context) int {
+	affected, err := cu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cu *CheckpointUpdate) Exec(ctx context.Context) error {
+	_, err := cu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cu *CheckpointUpdate) ExecX(ctx context.Context) {
+	if err := cu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cu *CheckpointUpdate) defaults() {
+	if _, ok := cu.mutation.UpdatedAt(); !ok {
+		v := checkpoint.UpdateDefaultUpdatedAt()
+		cu.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (cu *CheckpointUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(checkpoint.Table, checkpoint.Columns, sqlgraph.NewFieldSpec(checkpoint.FieldID, field.TypeInt))
+	if ps := cu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cu.mutation.Name(); ok {
+		_spec.SetField(checkpoint.FieldName, field.TypeString, value)
+	}
+	if value, ok := cu.mutation.Value(); ok {
+		_spec.SetField(checkpoint.FieldValue, field.TypeString, value)
+	}
+	if value, ok := cu.mutation.UpdatedAt(); ok {
+		_spec.SetField(checkpoint.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := cu.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(checkpoint.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{checkpoint.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cu.mutation.done = true
+	return n, nil
+}
+
+// CheckpointUpdateOne is the builder for updating a single Checkpoint entity.
+type CheckpointUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CheckpointMutation
+}
+
+// SetName sets the "name" field.
+func (cuo *CheckpointUpdateOne) SetName(s string) *CheckpointUpdateOne {
+	cuo.mutation.SetName(s)
+	return cuo
+}
+
+// SetValue sets the "value" field.
+func (cuo *CheckpointUpdateOne) SetValue(s string) *CheckpointUpdateOne {
+	cuo.mutation.SetValue(s)
+	return cuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (cuo *CheckpointUpdateOne) SetUpdatedAt(i int64) *CheckpointUpdateOne {
+	cuo.mutation.ResetUpdatedAt()
+	cuo.mutation.SetUpdatedAt(i)
+	return cuo
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (cuo *CheckpointUpdateOne) AddUpdatedAt(i int64) *CheckpointUpdateOne {
+	cuo.mutation.AddUpdatedAt(i)
+	return cuo
+}
+
+// Mutation returns the CheckpointMutation object of the builder.
+func (cuo *CheckpointUpdateOne) Mutation() *CheckpointMutation {
+	return cuo.mutation
+}
+
+// Where appends a list predicates to the CheckpointUpdate builder.
+func (cuo *CheckpointUpdateOne) Where(ps ...predicate.Checkpoint) *CheckpointUpdateOne {
+	cuo.mutation.Where(ps...)
+	return cuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cuo *CheckpointUpdateOne) Select(field string, fields ...string) *CheckpointUpdateOne {
+	cuo.fields = append([]string{field}, fields...)
+	return cuo
+}
+
+// Save executes the query and returns the updated Checkpoint entity.
+func (cuo *CheckpointUpdateOne) Save(ctx context.Context) (*Checkpoint, error) {
+	cuo.defaults()
+	return withHooks(ctx, cuo.sqlSave, cuo.mutation, cuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cuo *CheckpointUpdateOne) SaveX(ctx context.Context) *Checkpoint {
+	node, err := cuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cuo *CheckpointUpdateOne) Exec(ctx context.Context) error {
+	_, err := cuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cuo *CheckpointUpdateOne) ExecX(ctx context.Context) {
+	if err := cuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cuo *CheckpointUpdateOne) defaults() {
+	if _, ok := cuo.mutation.UpdatedAt(); !ok {
+		v := checkpoint.UpdateDefaultUpdatedAt()
+		cuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (cuo *CheckpointUpdateOne) sqlSave(ctx context.Context) (_node *Checkpoint, err error) {
+	_spec := sqlgraph.NewUpdateSpec(checkpoint.Table, checkpoint.Columns, sqlgraph.NewFieldSpec(checkpoint.FieldID, field.TypeInt))
+	id, ok := cuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Checkpoint.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, checkpoint.FieldID)
+		for _, f := range fields {
+			if !checkpoint.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != checkpoint.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cuo.mutation.Name(); ok {
+		_spec.SetField(checkpoint.FieldName, field.TypeString, value)
+	}
+	if value, ok := cuo.mutation.Value(); ok {
+		_spec.SetField(checkpoint.FieldValue, field.TypeString, value)
+	}
+	if value, ok := cuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(checkpoint.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := cuo.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(checkpoint.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	_node = &Checkpoint{config: cuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{checkpoint.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,270 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Delivery is the model entity for the Delivery schema.
+type Delivery struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// To holds the value of the "to" field.
+	To string `json:"to,omitempty"`
+	// Format holds the value of the "format" field.
+	Format string `json:"format,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the DeliveryQuery when eager-loading is set.
+	Edges                   DeliveryEdges `json:"edges"`
+	delivery_user           *int64
+	delivery_book           *int64
+	delivery_device         *int64
+	outbox_message_delivery *int64
+	selectValues            sql.SelectValues
+}
+
+// DeliveryEdges holds the relations/edges for other nodes in the graph.
+type DeliveryEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// Book holds the value of the book edge.
+	Book *Book `json:"book,omitempty"`
+	// Device holds the value of the device edge.
+	Device *Device `json:"device,omitempty"`
+	// Message holds the value of the message edge.
+	Message *OutboxMessage `json:"message,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [4]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e DeliveryEdges) UserOrErr() (*User, error) {
+	if e.loadedTypes[0] {
+		if e.User == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.User, nil
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// BookOrErr returns the Book value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e DeliveryEdges) BookOrErr() (*Book, error) {
+	if e.loadedTypes[1] {
+		if e.Book == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: book.Label}
+		}
+		return e.Book, nil
+	}
+	return nil, &NotLoadedError{edge: "book"}
+}
+
+// DeviceOrErr returns the Device value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e DeliveryEdges) DeviceOrErr() (*Device, error) {
+	if e.loadedTypes[2] {
+		if e.Device == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: device.Label}
+		}
+		return e.Device, nil
+	}
+	return nil, &NotLoadedError{edge: "device"}
+}
+
+// MessageOrErr returns the Message value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e DeliveryEdges) MessageOrErr() (*OutboxMessage, error) {
+	if e.loadedTypes[3] {
+		if e.Message == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: outboxmessage.Label}
+		}
+		return e.Message, nil
+	}
+	return nil, &NotLoadedError{edge: "message"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Delivery) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case delivery.FieldID, delivery.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case delivery.FieldTo, delivery.FieldFormat, delivery.FieldError:
+			values[i] = new(sql.NullString)
+		case delivery.ForeignKeys[0]: // delivery_user
+			values[i] = new(sql.NullInt64)
+		case delivery.ForeignKeys[1]: // delivery_book
+			values[i] = new(sql.NullInt64)
+		case delivery.ForeignKeys[2]: // delivery_device
+			values[i] = new(sql.NullInt64)
+		case delivery.ForeignKeys[3]: // outbox_message_delivery
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Delivery fields.
+func (d *Delivery) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case delivery.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			d.ID = int64(value.Int64)
+		case delivery.FieldTo:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field to", values[i])
+			} else if value.Valid {
+				d.To = value.String
+			}
+		case delivery.FieldFormat:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field format", values[i])
+			} else if value.Valid {
+				d.Format = value.String
+			}
+		case delivery.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				d.Error = value.String
+			}
+		case delivery.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				d.CreatedAt = value.Int64
+			}
+		case delivery.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field delivery_user", value)
+			} else if value.Valid {
+				d.delivery_user = new(int64)
+				*d.delivery_user = int64(value.Int64)
+			}
+		case delivery.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field delivery_book", value)
+			} else if value.Valid {
+				d.delivery_book = new(int64)
+				*d.delivery_book = int64(value.Int64)
+			}
+		case delivery.ForeignKeys[2]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field delivery_device", value)
+			} else if value.Valid {
+				d.delivery_device = new(int64)
+				*d.delivery_device = int64(value.Int64)
+			}
+		case delivery.ForeignKeys[3]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field outbox_message_delivery", value)
+			} else if value.Valid {
+				d.outbox_message_delivery = new(int64)
+				*d.outbox_message_delivery = int64(value.Int64)
+			}
+		default:
+			d.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Delivery.
+// This includes values selected through modifiers, order, etc.
+func (d *Delivery) Value(name string) (ent.Value, error) {
+	return d.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the Delivery entity.
+func (d *Delivery) QueryUser() *UserQuery {
+	return NewDeliveryClient(d.config).QueryUser(d)
+}
+
+// QueryBook queries the "book" edge of the Delivery entity.
+func (d *Delivery) QueryBook() *BookQuery {
+	return NewDeliveryClient(d.config).QueryBook(d)
+}
+
+// QueryDevice queries the "device" edge of the Delivery entity.
+func (d *Delivery) QueryDevice() *DeviceQuery {
+	return NewDeliveryClient(d.config).QueryDevice(d)
+}
+
+// QueryMessage queries the "message" edge of the Delivery entity.
+func (d *Delivery) QueryMessage() *OutboxMessageQuery {
+	return NewDeliveryClient(d.config).QueryMessage(d)
+}
+
+// Update returns a builder for updating this Delivery.
+// Note that you need to call Delivery.Unwrap() before calling this method if this Delivery
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (d *Delivery) Update() *DeliveryUpdateOne {
+	return NewDeliveryClient(d.config).UpdateOne(d)
+}
+
+// Unwrap unwraps the Delivery entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (d *Delivery) Unwrap() *Delivery {
+	_tx, ok := d.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Delivery is not a transactional entity")
+	}
+	d.config.driver = _tx.drv
+	return d
+}
+
+// String implements the fmt.Stringer.
+func (d *Delivery) String() string {
+	var builder strings.Builder
+	builder.WriteString("Delivery(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", d.ID))
+	builder.WriteString("to=")
+	builder.WriteString(d.To)
+	builder.WriteString(", ")
+	builder.WriteString("format=")
+	builder.WriteString(d.Format)
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(d.Error)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", d.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Deliveries is a parsable slice of Delivery.
+type Deliveries []*Delivery
@@ -0,0 +1,395 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// DeliveryCreate is the builder for creating a Delivery entity.
+type DeliveryCreate struct {
+	config
+	mutation *DeliveryMutation
+	hooks    []Hook
+}
+
+// SetTo sets the "to" field.
+func (dc *DeliveryCreate) SetTo(s string) *DeliveryCreate {
+	dc.mutation.SetTo(s)
+	return dc
+}
+
+// SetFormat sets the "format" field.
+func (dc *DeliveryCreate) SetFormat(s string) *DeliveryCreate {
+	dc.mutation.SetFormat(s)
+	return dc
+}
+
+// SetError sets the "error" field.
+func (dc *DeliveryCreate) SetError(s string) *DeliveryCreate {
+	dc.mutation.SetError(s)
+	return dc
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (dc *DeliveryCreate) SetNillableError(s *string) *DeliveryCreate {
+	if s != nil {
+		dc.SetError(*s)
+	}
+	return dc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (dc *DeliveryCreate) SetCreatedAt(i int64) *DeliveryCreate {
+	dc.mutation.SetCreatedAt(i)
+	return dc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (dc *DeliveryCreate) SetNillableCreatedAt(i *int64) *DeliveryCreate {
+	if i != nil {
+		dc.SetCreatedAt(*i)
+	}
+	return dc
+}
+
+// SetID sets the "id" field.
+func (dc *DeliveryCreate) SetID(i int64) *DeliveryCreate {
+	dc.mutation.SetID(i)
+	return dc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (dc *DeliveryCreate) SetUserID(id int64) *DeliveryCreate {
+	dc.mutation.SetUserID(id)
+	return dc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (dc *DeliveryCreate) SetUser(u *User) *DeliveryCreate {
+	return dc.SetUserID(u.ID)
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (dc *DeliveryCreate) SetBookID(id int64) *DeliveryCreate {
+	dc.mutation.SetBookID(id)
+	return dc
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (dc *DeliveryCreate) SetBook(b *Book) *DeliveryCreate {
+	return dc.SetBookID(b.ID)
+}
+
+// SetDeviceID sets the "device" edge to the Device entity by ID.
+func (dc *DeliveryCreate) SetDeviceID(id int64) *DeliveryCreate {
+	dc.mutation.SetDeviceID(id)
+	return dc
+}
+
+// SetNillableDeviceID sets the "device" edge to the Device entity by ID if the given value is not nil.
+func (dc *DeliveryCreate) SetNillableDeviceID(id *int64) *DeliveryCreate {
+	if id != nil {
+		dc = dc.SetDeviceID(*id)
+	}
+	return dc
+}
+
+// SetDevice sets the "device" edge to the Device entity.
+func (dc *DeliveryCreate) SetDevice(d *Device) *DeliveryCreate {
+	return dc.SetDeviceID(d.ID)
+}
+
+// SetMessageID sets the "message" edge to the OutboxMessage entity by ID.
+func (dc *DeliveryCreate) SetMessageID(id int64) *DeliveryCreate {
+	dc.mutation.SetMessageID(id)
+	return dc
+}
+
+// SetNillableMessageID sets the "message" edge to the OutboxMessage entity by ID if the given value is not nil.
+func (dc *DeliveryCreate) SetNillableMessageID(id *int64) *DeliveryCreate {
+	if id != nil {
+		dc = dc.SetMessageID(*id)
+	}
+	return dc
+}
+
+// SetMessage sets the "message" edge to the OutboxMessage entity.
+func (dc *DeliveryCreate) SetMessage(o *OutboxMessage) *DeliveryCreate {
+	return dc.SetMessageID(o.ID)
+}
+
+// Mutation returns the DeliveryMutation object of the builder.
+func (dc *DeliveryCreate) Mutation() *DeliveryMutation {
+	return dc.mutation
+}
+
+// Save creates the Delivery in the database.
+func (dc *DeliveryCreate) Save(ctx context.Context) (*Delivery, error) {
+	dc.defaults()
+	return withHooks(ctx, dc.sqlSave, dc.mutation, dc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (dc *DeliveryCreate) SaveX(ctx context.Context) *Delivery {
+	v, err := dc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (dc *DeliveryCreate) Exec(ctx context.Context) error {
+	_, err := dc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (dc *DeliveryCreate) ExecX(ctx context.Context) {
+	if err := dc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (dc *DeliveryCreate) defaults() {
+	if _, ok := dc.mutation.CreatedAt(); !ok {
+		v := delivery.DefaultCreatedAt()
+		dc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (dc *DeliveryCreate) check() error {
+	if _, ok := dc.mutation.To(); !ok {
+		return &ValidationError{Name: "to", err: errors.New(`ent: missing required field "Delivery.to"`)}
+	}
+	if _, ok := dc.mutation.Format(); !ok {
+		return &ValidationError{Name: "format", err: errors.New(`ent: missing required field "Delivery.format"`)}
+	}
+	if _, ok := dc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Delivery.created_at"`)}
+	}
+	if _, ok := dc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Delivery.user"`)}
+	}
+	if _, ok := dc.mutation.BookID(); !ok {
+		return &ValidationError{Name: "book", err: errors.New(`ent: missing required edge "Delivery.book"`)}
+	}
+	return nil
+}
+
+func (dc *DeliveryCreate) sqlSave(ctx context.Context) (*Delivery, error) {
+	if err := dc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := dc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, dc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	dc.mutation.id = &_node.ID
+	dc.mutation.done = true
+	return _node, nil
+}
+
+func (dc *DeliveryCreate) createSpec() (*Delivery, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Delivery{config: dc.config}
+		_spec = sqlgraph.NewCreateSpec(delivery.Table, sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64))
+	)
+	if id, ok := dc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := dc.mutation.To(); ok {
+		_spec.SetField(delivery.FieldTo, field.TypeString, value)
+		_node.To = value
+	}
+	if value, ok := dc.mutation.Format(); ok {
+		_spec.SetField(delivery.FieldFormat, field.TypeString, value)
+		_node.Format = value
+	}
+	if value, ok := dc.mutation.Error(); ok {
+		_spec.SetField(delivery.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := dc.mutation.CreatedAt(); ok {
+		_spec.SetField(delivery.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := dc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.UserTable,
+			Columns: []string{delivery.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.delivery_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := dc.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.BookTable,
+			Columns: []string{delivery.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.delivery_book = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := dc.mutation.DeviceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.DeviceTable,
+			Columns: []string{delivery.DeviceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.delivery_device = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := dc.mutation.MessageIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   delivery.MessageTable,
+			Columns: []string{delivery.MessageColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.outbox_message_delivery = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// DeliveryCreateBulk is the builder for creating many Delivery entities in bulk.
+type DeliveryCreateBulk struct {
+	config
+	err      error
+	builders []*DeliveryCreate
+}
+
+// Save creates the Delivery entities in the database.
+func (dcb *DeliveryCreateBulk) Save(ctx context.Context) ([]*Delivery, error) {
+	if dcb.err != nil {
+		return nil, dcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(dcb.builders))
+	nodes := make([]*Delivery, len(dcb.builders))
+	mutators := make([]Mutator, len(dcb.builders))
+	for i := range dcb.builders {
+		func(i int, root context.Context) {
+			builder := dcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DeliveryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, dcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, dcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, dcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (dcb *DeliveryCreateBulk) SaveX(ctx context.Context) []*Delivery {
+	v, err := dcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (dcb *DeliveryCreateBulk) Exec(ctx context.Context) error {
+	_, err := dcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (dcb *DeliveryCreateBulk) ExecX(ctx context.Context) {
+	if err := dcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,695 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// DeliveryUpdate is the builder for updating Delivery entities.
+type DeliveryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DeliveryMutation
+}
+
+// Where appends a list predicates to the DeliveryUpdate builder.
+func (du *DeliveryUpdate) Where(ps ...predicate.Delivery) *DeliveryUpdate {
+	du.mutation.Where(ps...)
+	return du
+}
+
+// SetTo sets the "to" field.
+func (du *DeliveryUpdate) SetTo(s string) *DeliveryUpdate {
+	du.mutation.SetTo(s)
+	return du
+}
+
+// SetFormat sets the "format" field.
+func (du *DeliveryUpdate) SetFormat(s string) *DeliveryUpdate {
+	du.mutation.SetFormat(s)
+	return du
+}
+
+// SetError sets the "error" field.
+func (du *DeliveryUpdate) SetError(s string) *DeliveryUpdate {
+	du.mutation.SetError(s)
+	return du
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (du *DeliveryUpdate) SetNillableError(s *string) *DeliveryUpdate {
+	if s != nil {
+		du.SetError(*s)
+	}
+	return du
+}
+
+// ClearError clears the value of the "error" field.
+func (du *DeliveryUpdate) ClearError() *DeliveryUpdate {
+	du.mutation.ClearError()
+	return du
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (du *DeliveryUpdate) SetUserID(id int64) *DeliveryUpdate {
+	du.mutation.SetUserID(id)
+	return du
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (du *DeliveryUpdate) SetUser(u *User) *DeliveryUpdate {
+	return du.SetUserID(u.ID)
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (du *DeliveryUpdate) SetBookID(id int64) *DeliveryUpdate {
+	du.mutation.SetBookID(id)
+	return du
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (du *DeliveryUpdate) SetBook(b *Book) *DeliveryUpdate {
+	return du.SetBookID(b.ID)
+}
+
+// SetDeviceID sets the "device" edge to the Device entity by ID.
+func (du *DeliveryUpdate) SetDeviceID(id int64) *DeliveryUpdate {
+	du.mutation.SetDeviceID(id)
+	return du
+}
+
+// SetNillableDeviceID sets the "device" edge to the Device entity by ID if the given value is not nil.
+func (du *DeliveryUpdate) SetNillableDeviceID(id *int64) *DeliveryUpdate {
+	if id != nil {
+		du = du.SetDeviceID(*id)
+	}
+	return du
+}
+
+// SetDevice sets the "device" edge to the Device entity.
+func (du *DeliveryUpdate) SetDevice(d *Device) *DeliveryUpdate {
+	return du.SetDeviceID(d.ID)
+}
+
+// SetMessageID sets the "message" edge to the OutboxMessage entity by ID.
+func (du *DeliveryUpdate) SetMessageID(id int64) *DeliveryUpdate {
+	du.mutation.SetMessageID(id)
+	return du
+}
+
+// SetNillableMessageID sets the "message" edge to the OutboxMessage entity by ID if the given value is not nil.
+func (du *DeliveryUpdate) SetNillableMessageID(id *int64) *DeliveryUpdate {
+	if id != nil {
+		du = du.SetMessageID(*id)
+	}
+	return du
+}
+
+// SetMessage sets the "message" edge to the OutboxMessage entity.
+func (du *DeliveryUpdate) SetMessage(o *OutboxMessage) *DeliveryUpdate {
+	return du.SetMessageID(o.ID)
+}
+
+// Mutation returns the DeliveryMutation object of the builder.
+func (du *DeliveryUpdate) Mutation() *DeliveryMutation {
+	return du.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (du *DeliveryUpdate) ClearUser() *DeliveryUpdate {
+	du.mutation.ClearUser()
+	return du
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (du *DeliveryUpdate) ClearBook() *DeliveryUpdate {
+	du.mutation.ClearBook()
+	return du
+}
+
+// ClearDevice clears the "device" edge to the Device entity.
+func (du *DeliveryUpdate) ClearDevice() *DeliveryUpdate {
+	du.mutation.ClearDevice()
+	return du
+}
+
+// ClearMessage clears the "message" edge to the OutboxMessage entity.
+func (du *DeliveryUpdate) ClearMessage() *DeliveryUpdate {
+	du.mutation.ClearMessage()
+	return du
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (du *DeliveryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, du.sqlSave, du.mutation, du.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (du *DeliveryUpdate) SaveX(ctx context.Context) int {
+	affected, err := du.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (du *DeliveryUpdate) Exec(ctx context.Context) error {
+	_, err := du.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (du *DeliveryUpdate) ExecX(ctx context.Context) {
+	if err := du.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (du *DeliveryUpdate) check() error {
+	if _, ok := du.mutation.UserID(); du.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Delivery.user"`)
+	}
+	if _, ok := du.mutation.BookID(); du.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Delivery.book"`)
+	}
+	return nil
+}
+
+func (du *DeliveryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := du.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(delivery.Table, delivery.Columns, sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64))
+	if ps := du.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := du.mutation.To(); ok {
+		_spec.SetField(delivery.FieldTo, field.TypeString, value)
+	}
+	if value, ok := du.mutation.Format(); ok {
+		_spec.SetField(delivery.FieldFormat, field.TypeString, value)
+	}
+	if value, ok := du.mutation.Error(); ok {
+		_spec.SetField(delivery.FieldError, field.TypeString, value)
+	}
+	if du.mutation.ErrorCleared() {
+		_spec.ClearField(delivery.FieldError, field.TypeString)
+	}
+	if du.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.UserTable,
+			Columns: []string{delivery.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := du.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.UserTable,
+			Columns: []string{delivery.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if du.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.BookTable,
+			Columns: []string{delivery.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := du.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.BookTable,
+			Columns: []string{delivery.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if du.mutation.DeviceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.DeviceTable,
+			Columns: []string{delivery.DeviceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := du.mutation.DeviceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.DeviceTable,
+			Columns: []string{delivery.DeviceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if du.mutation.MessageCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   delivery.MessageTable,
+			Columns: []string{delivery.MessageColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := du.mutation.MessageIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   delivery.MessageTable,
+			Columns: []string{delivery.MessageColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, du.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{delivery.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	du.mutation.done = true
+	return n, nil
+}
+
+// DeliveryUpdateOne is the builder for updating a single Delivery entity.
+type DeliveryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DeliveryMutation
+}
+
+// SetTo sets the "to" field.
+func (duo *DeliveryUpdateOne) SetTo(s string) *DeliveryUpdateOne {
+	duo.mutation.SetTo(s)
+	return duo
+}
+
+// SetFormat sets the "format" field.
+func (duo *DeliveryUpdateOne) SetFormat(s string) *DeliveryUpdateOne {
+	duo.mutation.SetFormat(s)
+	return duo
+}
+
+// SetError sets the "error" field.
+func (duo *DeliveryUpdateOne) SetError(s string) *DeliveryUpdateOne {
+	duo.mutation.SetError(s)
+	return duo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (duo *DeliveryUpdateOne) SetNillableError(s *string) *DeliveryUpdateOne {
+	if s != nil {
+		duo.SetError(*s)
+	}
+	return duo
+}
+
+// ClearError clears the value of the "error" field.
+func (duo *DeliveryUpdateOne) ClearError() *DeliveryUpdateOne {
+	duo.mutation.ClearError()
+	return duo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (duo *DeliveryUpdateOne) SetUserID(id int64) *DeliveryUpdateOne {
+	duo.mutation.SetUserID(id)
+	return duo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (duo *DeliveryUpdateOne) SetUser(u *User) *DeliveryUpdateOne {
+	return duo.SetUserID(u.ID)
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (duo *DeliveryUpdateOne) SetBookID(id int64) *DeliveryUpdateOne {
+	duo.mutation.SetBookID(id)
+	return duo
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (duo *DeliveryUpdateOne) SetBook(b *Book) *DeliveryUpdateOne {
+	return duo.SetBookID(b.ID)
+}
+
+// SetDeviceID sets the "device" edge to the Device entity by ID.
+func (duo *DeliveryUpdateOne) SetDeviceID(id int64) *DeliveryUpdateOne {
+	duo.mutation.SetDeviceID(id)
+	return duo
+}
+
+// SetNillableDeviceID sets the "device" edge to the Device entity by ID if the given value is not nil.
+func (duo *DeliveryUpdateOne) SetNillableDeviceID(id *int64) *DeliveryUpdateOne {
+	if id != nil {
+		duo = duo.SetDeviceID(*id)
+	}
+	return duo
+}
+
+// SetDevice sets the "device" edge to the Device entity.
+func (duo *DeliveryUpdateOne) SetDevice(d *Device) *DeliveryUpdateOne {
+	return duo.SetDeviceID(d.ID)
+}
+
+// SetMessageID sets the "message" edge to the OutboxMessage entity by ID.
+func (duo *DeliveryUpdateOne) SetMessageID(id int64) *DeliveryUpdateOne {
+	duo.mutation.SetMessageID(id)
+	return duo
+}
+
+// SetNillableMessageID sets the "message" edge to the OutboxMessage entity by ID if the given value is not nil.
+func (duo *DeliveryUpdateOne) SetNillableMessageID(id *int64) *DeliveryUpdateOne {
+	if id != nil {
+		duo = duo.SetMessageID(*id)
+	}
+	return duo
+}
+
+// SetMessage sets the "message" edge to the OutboxMessage entity.
+func (duo *DeliveryUpdateOne) SetMessage(o *OutboxMessage) *DeliveryUpdateOne {
+	return duo.SetMessageID(o.ID)
+}
+
+// Mutation returns the DeliveryMutation object of the builder.
+func (duo *DeliveryUpdateOne) Mutation() *DeliveryMutation {
+	return duo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (duo *DeliveryUpdateOne) ClearUser() *DeliveryUpdateOne {
+	duo.mutation.ClearUser()
+	return duo
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (duo *DeliveryUpdateOne) ClearBook() *DeliveryUpdateOne {
+	duo.mutation.ClearBook()
+	return duo
+}
+
+// ClearDevice clears the "device" edge to the Device entity.
+func (duo *DeliveryUpdateOne) ClearDevice() *DeliveryUpdateOne {
+	duo.mutation.ClearDevice()
+	return duo
+}
+
+// ClearMessage clears the "message" edge to the OutboxMessage entity.
+func (duo *DeliveryUpdateOne) ClearMessage() *DeliveryUpdateOne {
+	duo.mutation.ClearMessage()
+	return duo
+}
+
+// Where appends a list predicates to the DeliveryUpdate builder.
+func (duo *DeliveryUpdateOne) Where(ps ...predicate.Delivery) *DeliveryUpdateOne {
+	duo.mutation.Where(ps...)
+	return duo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (duo *DeliveryUpdateOne) Select(field string, fields ...string) *DeliveryUpdateOne {
+	duo.fields = append([]string{field}, fields...)
+	return duo
+}
+
+// Save executes the query and returns the updated Delivery entity.
+func (duo *DeliveryUpdateOne) Save(ctx context.Context) (*Delivery, error) {
+	return withHooks(ctx, duo.sqlSave, duo.mutation, duo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (duo *DeliveryUpdateOne) SaveX(ctx context.Context) *Delivery {
+	node, err := duo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (duo *DeliveryUpdateOne) Exec(ctx context.Context) error {
+	_, err := duo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (duo *DeliveryUpdateOne) ExecX(ctx context.Context) {
+	if err := duo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (duo *DeliveryUpdateOne) check() error {
+	if _, ok := duo.mutation.UserID(); duo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Delivery.user"`)
+	}
+	if _, ok := duo.mutation.BookID(); duo.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Delivery.book"`)
+	}
+	return nil
+}
+
+func (duo *DeliveryUpdateOne) sqlSave(ctx context.Context) (_node *Delivery, err error) {
+	if err := duo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(delivery.Table, delivery.Columns, sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64))
+	id, ok := duo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Delivery.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := duo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, delivery.FieldID)
+		for _, f := range fields {
+			if !delivery.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != delivery.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := duo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := duo.mutation.To(); ok {
+		_spec.SetField(delivery.FieldTo, field.TypeString, value)
+	}
+	if value, ok := duo.mutation.Format(); ok {
+		_spec.SetField(delivery.FieldFormat, field.TypeString, value)
+	}
+	if value, ok := duo.mutation.Error(); ok {
+		_spec.SetField(delivery.FieldError, field.TypeString, value)
+	}
+	if duo.mutation.ErrorCleared() {
+		_spec.ClearField(delivery.FieldError, field.TypeString)
+	}
+	if duo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.UserTable,
+			Columns: []string{delivery.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := duo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.UserTable,
+			Columns: []string{delivery.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if duo.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.BookTable,
+			Columns: []string{delivery.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := duo.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.BookTable,
+			Columns: []string{delivery.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if duo.mutation.DeviceCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.DeviceTable,
+			Columns: []string{delivery.DeviceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := duo.mutation.DeviceIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   delivery.DeviceTable,
+			Columns: []string{delivery.DeviceColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if duo.mutation.MessageCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   delivery.MessageTable,
+			Columns: []string{delivery.MessageColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := duo.mutation.MessageIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   delivery.MessageTable,
+			Columns: []string{delivery.MessageColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Delivery{config: duo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, duo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{delivery.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	duo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,343 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// DeviceCreate is the builder for creating a Device entity.
+type DeviceCreate struct {
+	config
+	mutation *DeviceMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (dc *DeviceCreate) SetName(s string) *DeviceCreate {
+	dc.mutation.SetName(s)
+	return dc
+}
+
+// SetEmail sets the "email" field.
+func (dc *DeviceCreate) SetEmail(s string) *DeviceCreate {
+	dc.mutation.SetEmail(s)
+	return dc
+}
+
+// SetKind sets the "kind" field.
+func (dc *DeviceCreate) SetKind(d device.Kind) *DeviceCreate {
+	dc.mutation.SetKind(d)
+	return dc
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (dc *DeviceCreate) SetNillableKind(d *device.Kind) *DeviceCreate {
+	if d != nil {
+		dc.SetKind(*d)
+	}
+	return dc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (dc *DeviceCreate) SetCreatedAt(i int64) *DeviceCreate {
+	dc.mutation.SetCreatedAt(i)
+	return dc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (dc *DeviceCreate) SetNillableCreatedAt(i *int64) *DeviceCreate {
+	if i != nil {
+		dc.SetCreatedAt(*i)
+	}
+	return dc
+}
+
+// SetID sets the "id" field.
+func (dc *DeviceCreate) SetID(i int64) *DeviceCreate {
+	dc.mutation.SetID(i)
+	return dc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (dc *DeviceCreate) SetUserID(id int64) *DeviceCreate {
+	dc.mutation.SetUserID(id)
+	return dc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (dc *DeviceCreate) SetUser(u *User) *DeviceCreate {
+	return dc.SetUserID(u.ID)
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the Delivery entity by IDs.
+func (dc *DeviceCreate) AddDeliveryIDs(ids ...int64) *DeviceCreate {
+	dc.mutation.AddDeliveryIDs(ids...)
+	return dc
+}
+
+// AddDeliveries adds the "deliveries" edges to the Delivery entity.
+func (dc *DeviceCreate) AddDeliveries(d ...*Delivery) *DeviceCreate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return dc.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the DeviceMutation object of the builder.
+func (dc *DeviceCreate) Mutation() *DeviceMutation {
+	return dc.mutation
+}
+
+// Save creates the Device in the database.
+func (dc *DeviceCreate) Save(ctx context.Context) (*Device, error) {
+	dc.defaults()
+	return withHooks(ctx, dc.sqlSave, dc.mutation, dc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (dc *DeviceCreate) SaveX(ctx context.Context) *Device {
+	v, err := dc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (dc *DeviceCreate) Exec(ctx context.Context) error {
+	_, err := dc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (dc *DeviceCreate) ExecX(ctx context.Context) {
+	if err := dc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (dc *DeviceCreate) defaults() {
+	if _, ok := dc.mutation.Kind(); !ok {
+		v := device.DefaultKind
+		dc.mutation.SetKind(v)
+	}
+	if _, ok := dc.mutation.CreatedAt(); !ok {
+		v := device.DefaultCreatedAt()
+		dc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (dc *DeviceCreate) check() error {
+	if _, ok := dc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Device.name"`)}
+	}
+	if v, ok := dc.mutation.Name(); ok {
+		if err := device.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Device.name": %w`, err)}
+		}
+	}
+	if _, ok := dc.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "Device.email"`)}
+	}
+	if v, ok := dc.mutation.Email(); ok {
+		if err := device.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Device.email": %w`, err)}
+		}
+	}
+	if _, ok := dc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "Device.kind"`)}
+	}
+	if v, ok := dc.mutation.Kind(); ok {
+		if err := device.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Device.kind": %w`, err)}
+		}
+	}
+	if _, ok := dc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Device.created_at"`)}
+	}
+	if _, ok := dc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Device.user"`)}
+	}
+	return nil
+}
+
+func (dc *DeviceCreate) sqlSave(ctx context.Context) (*Device, error) {
+	if err := dc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := dc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, dc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	dc.mutation.id = &_node.ID
+	dc.mutation.done = true
+	return _node, nil
+}
+
+func (dc *DeviceCreate) createSpec() (*Device, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Device{config: dc.config}
+		_spec = sqlgraph.NewCreateSpec(device.Table, sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64))
+	)
+	if id, ok := dc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := dc.mutation.Name(); ok {
+		_spec.SetField(device.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := dc.mutation.Email(); ok {
+		_spec.SetField(device.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := dc.mutation.Kind(); ok {
+		_spec.SetField(device.FieldKind, field.TypeEnum, value)
+		_node.Kind = value
+	}
+	if value, ok := dc.mutation.CreatedAt(); ok {
+		_spec.SetField(device.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := dc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   device.UserTable,
+			Columns: []string{device.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.device_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := dc.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   device.DeliveriesTable,
+			Columns: []string{device.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// DeviceCreateBulk is the builder for creating many Device entities in bulk.
+type DeviceCreateBulk struct {
+	config
+	err      error
+	builders []*DeviceCreate
+}
+
+// Save creates the Device entities in the database.
+func (dcb *DeviceCreateBulk) Save(ctx context.Context) ([]*Device, error) {
+	if dcb.err != nil {
+		return nil, dcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(dcb.builders))
+	nodes := make([]*Device, len(dcb.builders))
+	mutators := make([]Mutator, len(dcb.builders))
+	for i := range dcb.builders {
+		func(i int, root context.Context) {
+			builder := dcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DeviceMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, dcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, dcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, dcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (dcb *DeviceCreateBulk) SaveX(ctx context.Context) []*Device {
+	v, err := dcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (dcb *DeviceCreateBulk) Exec(ctx context.Context) error {
+	_, err := dcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (dcb *DeviceCreateBulk) ExecX(ctx context.Context) {
+	if err := dcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,553 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// DeviceUpdate is the builder for updating Device entities.
+type DeviceUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DeviceMutation
+}
+
+// Where appends a list predicates to the DeviceUpdate builder.
+func (du *DeviceUpdate) Where(ps ...predicate.Device) *DeviceUpdate {
+	du.mutation.Where(ps...)
+	return du
+}
+
+// SetName sets the "name" field.
+func (du *DeviceUpdate) SetName(s string) *DeviceUpdate {
+	du.mutation.SetName(s)
+	return du
+}
+
+// SetEmail sets the "email" field.
+func (du *DeviceUpdate) SetEmail(s string) *DeviceUpdate {
+	du.mutation.SetEmail(s)
+	return du
+}
+
+// SetKind sets the "kind" field.
+func (du *DeviceUpdate) SetKind(d device.Kind) *DeviceUpdate {
+	du.mutation.SetKind(d)
+	return du
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (du *DeviceUpdate) SetNillableKind(d *device.Kind) *DeviceUpdate {
+	if d != nil {
+		du.SetKind(*d)
+	}
+	return du
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (du *DeviceUpdate) SetUserID(id int64) *DeviceUpdate {
+	du.mutation.SetUserID(id)
+	return du
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (du *DeviceUpdate) SetUser(u *User) *DeviceUpdate {
+	return du.SetUserID(u.ID)
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the Delivery entity by IDs.
+func (du *DeviceUpdate) AddDeliveryIDs(ids ...int64) *DeviceUpdate {
+	du.mutation.AddDeliveryIDs(ids...)
+	return du
+}
+
+// AddDeliveries adds the "deliveries" edges to the Delivery entity.
+func (du *DeviceUpdate) AddDeliveries(d ...*Delivery) *DeviceUpdate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return du.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the DeviceMutation object of the builder.
+func (du *DeviceUpdate) Mutation() *DeviceMutation {
+	return du.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (du *DeviceUpdate) ClearUser() *DeviceUpdate {
+	du.mutation.ClearUser()
+	return du
+}
+
+// ClearDeliveries clears all "deliveries" edges to the Delivery entity.
+func (du *DeviceUpdate) ClearDeliveries() *DeviceUpdate {
+	du.mutation.ClearDeliveries()
+	return du
+}
+
+// RemoveDeliveryIDs removes the "deliveries" edge to Delivery entities by IDs.
+func (du *DeviceUpdate) RemoveDeliveryIDs(ids ...int64) *DeviceUpdate {
+	du.mutation.RemoveDeliveryIDs(ids...)
+	return du
+}
+
+// RemoveDeliveries removes "deliveries" edges to Delivery entities.
+func (du *DeviceUpdate) RemoveDeliveries(d ...*Delivery) *DeviceUpdate {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return du.RemoveDeliveryIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (du *DeviceUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, du.sqlSave, du.mutation, du.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (du *DeviceUpdate) SaveX(ctx context.Context) int {
+	affected, err := du.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (du *DeviceUpdate) Exec(ctx context.Context) error {
+	_, err := du.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (du *DeviceUpdate) ExecX(ctx context.Context) {
+	if err := du.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (du *DeviceUpdate) check() error {
+	if v, ok := du.mutation.Name(); ok {
+		if err := device.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Device.name": %w`, err)}
+		}
+	}
+	if v, ok := du.mutation.Email(); ok {
+		if err := device.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Device.email": %w`, err)}
+		}
+	}
+	if v, ok := du.mutation.Kind(); ok {
+		if err := device.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Device.kind": %w`, err)}
+		}
+	}
+	if _, ok := du.mutation.UserID(); du.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Device.user"`)
+	}
+	return nil
+}
+
+func (du *DeviceUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := du.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(device.Table, device.Columns, sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64))
+	if ps := du.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := du.mutation.Name(); ok {
+		_spec.SetField(device.FieldName, field.TypeString, value)
+	}
+	if value, ok := du.mutation.Email(); ok {
+		_spec.SetField(device.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := du.mutation.Kind(); ok {
+		_spec.SetField(device.FieldKind, field.TypeEnum, value)
+	}
+	if du.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   device.UserTable,
+			Columns: []string{device.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := du.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   device.UserTable,
+			Columns: []string{device.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if du.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   device.DeliveriesTable,
+			Columns: []string{device.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := du.mutation.RemovedDeliveriesIDs(); len(nodes) > 0 && !du.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   device.DeliveriesTable,
+			Columns: []string{device.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := du.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   device.DeliveriesTable,
+			Columns: []string{device.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, du.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{device.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	du.mutation.done = true
+	return n, nil
+}
+
+// DeviceUpdateOne is the builder for updating a single Device entity.
+type DeviceUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DeviceMutation
+}
+
+// SetName sets the "name" field.
+func (duo *DeviceUpdateOne) SetName(s string) *DeviceUpdateOne {
+	duo.mutation.SetName(s)
+	return duo
+}
+
+// SetEmail sets the "email" field.
+func (duo *DeviceUpdateOne) SetEmail(s string) *DeviceUpdateOne {
+	duo.mutation.SetEmail(s)
+	return duo
+}
+
+// SetKind sets the "kind" field.
+func (duo *DeviceUpdateOne) SetKind(d device.Kind) *DeviceUpdateOne {
+	duo.mutation.SetKind(d)
+	return duo
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (duo *DeviceUpdateOne) SetNillableKind(d *device.Kind) *DeviceUpdateOne {
+	if d != nil {
+		duo.SetKind(*d)
+	}
+	return duo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (duo *DeviceUpdateOne) SetUserID(id int64) *DeviceUpdateOne {
+	duo.mutation.SetUserID(id)
+	return duo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (duo *DeviceUpdateOne) SetUser(u *User) *DeviceUpdateOne {
+	return duo.SetUserID(u.ID)
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the Delivery entity by IDs.
+func (duo *DeviceUpdateOne) AddDeliveryIDs(ids ...int64) *DeviceUpdateOne {
+	duo.mutation.AddDeliveryIDs(ids...)
+	return duo
+}
+
+// AddDeliveries adds the "deliveries" edges to the Delivery entity.
+func (duo *DeviceUpdateOne) AddDeliveries(d ...*Delivery) *DeviceUpdateOne {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return duo.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the DeviceMutation object of the builder.
+func (duo *DeviceUpdateOne) Mutation() *DeviceMutation {
+	return duo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (duo *DeviceUpdateOne) ClearUser() *DeviceUpdateOne {
+	duo.mutation.ClearUser()
+	return duo
+}
+
+// ClearDeliveries clears all "deliveries" edges to the Delivery entity.
+func (duo *DeviceUpdateOne) ClearDeliveries() *DeviceUpdateOne {
+	duo.mutation.ClearDeliveries()
+	return duo
+}
+
+// RemoveDeliveryIDs removes the "deliveries" edge to Delivery entities by IDs.
+func (duo *DeviceUpdateOne) RemoveDeliveryIDs(ids ...int64) *DeviceUpdateOne {
+	duo.mutation.RemoveDeliveryIDs(ids...)
+	return duo
+}
+
+// RemoveDeliveries removes "deliveries" edges to Delivery entities.
+func (duo *DeviceUpdateOne) RemoveDeliveries(d ...*Delivery) *DeviceUpdateOne {
+	ids := make([]int64, len(d))
+	for i := range d {
+		ids[i] = d[i].ID
+	}
+	return duo.RemoveDeliveryIDs(ids...)
+}
+
+// Where appends a list predicates to the DeviceUpdate builder.
+func (duo *DeviceUpdateOne) Where(ps ...predicate.Device) *DeviceUpdateOne {
+	duo.mutation.Where(ps...)
+	return duo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (duo *DeviceUpdateOne) Select(field string, fields ...string) *DeviceUpdateOne {
+	duo.fields = append([]string{field}, fields...)
+	return duo
+}
+
+// Save executes the query and returns the updated Device entity.
+func (duo *DeviceUpdateOne) Save(ctx context.Context) (*Device, error) {
+	return withHooks(ctx, duo.sqlSave, duo.mutation, duo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (duo *DeviceUpdateOne) SaveX(ctx context.Context) *Device {
+	node, err := duo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (duo *DeviceUpdateOne) Exec(ctx context.Context) error {
+	_, err := duo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (duo *DeviceUpdateOne) ExecX(ctx context.Context) {
+	if err := duo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (duo *DeviceUpdateOne) check() error {
+	if v, ok := duo.mutation.Name(); ok {
+		if err := device.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Device.name": %w`, err)}
+		}
+	}
+	if v, ok := duo.mutation.Email(); ok {
+		if err := device.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Device.email": %w`, err)}
+		}
+	}
+	if v, ok := duo.mutation.Kind(); ok {
+		if err := device.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Device.kind": %w`, err)}
+		}
+	}
+	if _, ok := duo.mutation.UserID(); duo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Device.user"`)
+	}
+	return nil
+}
+
+func (duo *DeviceUpdateOne) sqlSave(ctx context.Context) (_node *Device, err error) {
+	if err := duo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(device.Table, device.Columns, sqlgraph.NewFieldSpec(device.FieldID, field.TypeInt64))
+	id, ok := duo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Device.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := duo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, device.FieldID)
+		for _, f := range fields {
+			if !device.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != device.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := duo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := duo.mutation.Name(); ok {
+		_spec.SetField(device.FieldName, field.TypeString, value)
+	}
+	if value, ok := duo.mutation.Email(); ok {
+		_spec.SetField(device.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := duo.mutation.Kind(); ok {
+		_spec.SetField(device.FieldKind, field.TypeEnum, value)
+	}
+	if duo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   device.UserTable,
+			Columns: []string{device.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := duo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   device.UserTable,
+			Columns: []string{device.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if duo.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   device.DeliveriesTable,
+			Columns: []string{device.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := duo.mutation.RemovedDeliveriesIDs(); len(nodes) > 0 && !duo.mutation.DeliveriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   device.DeliveriesTable,
+			Columns: []string{device.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := duo.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   device.DeliveriesTable,
+			Columns: []string{device.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Device{config: duo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, duo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{device.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	duo.mutation.done = true
+	return _node, nil
+}
@@ -12,8 +12,27 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/ninedraft/bibliotheca/storage/ent/apitoken"
+	"github.com/ninedraft/bibliotheca/storage/ent/auditentry"
 	"github.com/ninedraft/bibliotheca/storage/ent/author"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/checkpoint"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/document"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+	"github.com/ninedraft/bibliotheca/storage/ent/session"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -74,8 +93,27 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			author.Table: author.ValidColumn,
-			book.Table:   book.ValidColumn,
+			apitoken.Table:        apitoken.ValidColumn,
+			auditentry.Table:      auditentry.ValidColumn,
+			author.Table:          author.ValidColumn,
+			book.Table:            book.ValidColumn,
+			bookcopy.Table:        bookcopy.ValidColumn,
+			checkpoint.Table:      checkpoint.ValidColumn,
+			delivery.Table:        delivery.ValidColumn,
+			device.Table:          device.ValidColumn,
+			document.Table:        document.ValidColumn,
+			highlight.Table:       highlight.ValidColumn,
+			hold.Table:            hold.ValidColumn,
+			loan.Table:            loan.ValidColumn,
+			outboxmessage.Table:   outboxmessage.ValidColumn,
+			readingprogress.Table: readingprogress.ValidColumn,
+			review.Table:          review.ValidColumn,
+			revision.Table:        revision.ValidColumn,
+			session.Table:         session.ValidColumn,
+			shelf.Table:           shelf.ValidColumn,
+			shelfentry.Table:      shelfentry.ValidColumn,
+			user.Table:            user.ValidColumn,
+			validation.Table:      validation.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -0,0 +1,263 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Highlight is the model entity for the Highlight schema.
+type Highlight struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Anchor holds the value of the "anchor" field.
+	Anchor string `json:"anchor,omitempty"`
+	// Start holds the value of the "start" field.
+	Start int `json:"start,omitempty"`
+	// End holds the value of the "end" field.
+	End int `json:"end,omitempty"`
+	// Text holds the value of the "text" field.
+	Text string `json:"text,omitempty"`
+	// Chapter holds the value of the "chapter" field.
+	Chapter string `json:"chapter,omitempty"`
+	// Note holds the value of the "note" field.
+	Note string `json:"note,omitempty"`
+	// Color holds the value of the "color" field.
+	Color highlight.Color `json:"color,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the HighlightQuery when eager-loading is set.
+	Edges          HighlightEdges `json:"edges"`
+	highlight_book *int64
+	highlight_user *int64
+	selectValues   sql.SelectValues
+}
+
+// HighlightEdges holds the relations/edges for other nodes in the graph.
+type HighlightEdges struct {
+	// Book holds the value of the book edge.
+	Book *Book `json:"book,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// BookOrErr returns the Book value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e HighlightEdges) BookOrErr() (*Book, error) {
+	if e.loadedTypes[0] {
+		if e.Book == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: book.Label}
+		}
+		return e.Book, nil
+	}
+	return nil, &NotLoadedError{edge: "book"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e HighlightEdges) UserOrErr() (*User, error) {
+	if e.loadedTypes[1] {
+		if e.User == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.User, nil
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Highlight) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case highlight.FieldID, highlight.FieldStart, highlight.FieldEnd, highlight.FieldCreatedAt, highlight.FieldUpdatedAt:
+			values[i] = new(sql.NullInt64)
+		case highlight.FieldAnchor, highlight.FieldText, highlight.FieldChapter, highlight.FieldNote, highlight.FieldColor:
+			values[i] = new(sql.NullString)
+		case highlight.ForeignKeys[0]: // highlight_book
+			values[i] = new(sql.NullInt64)
+		case highlight.ForeignKeys[1]: // highlight_user
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Highlight fields.
+func (h *Highlight) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case highlight.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			h.ID = int64(value.Int64)
+		case highlight.FieldAnchor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field anchor", values[i])
+			} else if value.Valid {
+				h.Anchor = value.String
+			}
+		case highlight.FieldStart:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field start", values[i])
+			} else if value.Valid {
+				h.Start = int(value.Int64)
+			}
+		case highlight.FieldEnd:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field end", values[i])
+			} else if value.Valid {
+				h.End = int(value.Int64)
+			}
+		case highlight.FieldText:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field text", values[i])
+			} else if value.Valid {
+				h.Text = value.String
+			}
+		case highlight.FieldChapter:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field chapter", values[i])
+			} else if value.Valid {
+				h.Chapter = value.String
+			}
+		case highlight.FieldNote:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field note", values[i])
+			} else if value.Valid {
+				h.Note = value.String
+			}
+		case highlight.FieldColor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field color", values[i])
+			} else if value.Valid {
+				h.Color = highlight.Color(value.String)
+			}
+		case highlight.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				h.CreatedAt = value.Int64
+			}
+		case highlight.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				h.UpdatedAt = value.Int64
+			}
+		case highlight.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field highlight_book", value)
+			} else if value.Valid {
+				h.highlight_book = new(int64)
+				*h.highlight_book = int64(value.Int64)
+			}
+		case highlight.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field highlight_user", value)
+			} else if value.Valid {
+				h.highlight_user = new(int64)
+				*h.highlight_user = int64(value.Int64)
+			}
+		default:
+			h.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Highlight.
+// This includes values selected through modifiers, order, etc.
+func (h *Highlight) Value(name string) (ent.Value, error) {
+	return h.selectValues.Get(name)
+}
+
+// QueryBook queries the "book" edge of the Highlight entity.
+func (h *Highlight) QueryBook() *BookQuery {
+	return NewHighlightClient(h.config).QueryBook(h)
+}
+
+// QueryUser queries the "user" edge of the Highlight entity.
+func (h *Highlight) QueryUser() *UserQuery {
+	return NewHighlightClient(h.config).QueryUser(h)
+}
+
+// Update returns a builder for updating this Highlight.
+// Note that you need to call Highlight.Unwrap() before calling this method if this Highlight
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (h *Highlight) Update() *HighlightUpdateOne {
+	return NewHighlightClient(h.config).UpdateOne(h)
+}
+
+// Unwrap unwraps the Highlight entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (h *Highlight) Unwrap() *Highlight {
+	_tx, ok := h.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Highlight is not a transactional entity")
+	}
+	h.config.driver = _tx.drv
+	return h
+}
+
+// String implements the fmt.Stringer.
+func (h *Highlight) String() string {
+	var builder strings.Builder
+	builder.WriteString("Highlight(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", h.ID))
+	builder.WriteString("anchor=")
+	builder.WriteString(h.Anchor)
+	builder.WriteString(", ")
+	builder.WriteString("start=")
+	builder.WriteString(fmt.Sprintf("%v", h.Start))
+	builder.WriteString(", ")
+	builder.WriteString("end=")
+	builder.WriteString(fmt.Sprintf("%v", h.End))
+	builder.WriteString(", ")
+	builder.WriteString("text=")
+	builder.WriteString(h.Text)
+	builder.WriteString(", ")
+	builder.WriteString("chapter=")
+	builder.WriteString(h.Chapter)
+	builder.WriteString(", ")
+	builder.WriteString("note=")
+	builder.WriteString(h.Note)
+	builder.WriteString(", ")
+	builder.WriteString("color=")
+	builder.WriteString(fmt.Sprintf("%v", h.Color))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", h.CreatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(fmt.Sprintf("%v", h.UpdatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Highlights is a parsable slice of Highlight.
+type Highlights []*Highlight
@@ -0,0 +1,615 @@
+// Code generated by ent, DO NOT EDIT.
+
+package highlight
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldID, id))
+}
+
+// Anchor applies equality check predicate on the "anchor" field. It's identical to AnchorEQ.
+func Anchor(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldAnchor, v))
+}
+
+// Start applies equality check predicate on the "start" field. It's identical to StartEQ.
+func Start(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldStart, v))
+}
+
+// End applies equality check predicate on the "end" field. It's identical to EndEQ.
+func End(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldEnd, v))
+}
+
+// Text applies equality check predicate on the "text" field. It's identical to TextEQ.
+func Text(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldText, v))
+}
+
+// Chapter applies equality check predicate on the "chapter" field. It's identical to ChapterEQ.
+func Chapter(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldChapter, v))
+}
+
+// Note applies equality check predicate on the "note" field. It's identical to NoteEQ.
+func Note(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldNote, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// AnchorEQ applies the EQ predicate on the "anchor" field.
+func AnchorEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldAnchor, v))
+}
+
+// AnchorNEQ applies the NEQ predicate on the "anchor" field.
+func AnchorNEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldAnchor, v))
+}
+
+// AnchorIn applies the In predicate on the "anchor" field.
+func AnchorIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldAnchor, vs...))
+}
+
+// AnchorNotIn applies the NotIn predicate on the "anchor" field.
+func AnchorNotIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldAnchor, vs...))
+}
+
+// AnchorGT applies the GT predicate on the "anchor" field.
+func AnchorGT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldAnchor, v))
+}
+
+// AnchorGTE applies the GTE predicate on the "anchor" field.
+func AnchorGTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldAnchor, v))
+}
+
+// AnchorLT applies the LT predicate on the "anchor" field.
+func AnchorLT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldAnchor, v))
+}
+
+// AnchorLTE applies the LTE predicate on the "anchor" field.
+func AnchorLTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldAnchor, v))
+}
+
+// AnchorContains applies the Contains predicate on the "anchor" field.
+func AnchorContains(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContains(FieldAnchor, v))
+}
+
+// AnchorHasPrefix applies the HasPrefix predicate on the "anchor" field.
+func AnchorHasPrefix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasPrefix(FieldAnchor, v))
+}
+
+// AnchorHasSuffix applies the HasSuffix predicate on the "anchor" field.
+func AnchorHasSuffix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasSuffix(FieldAnchor, v))
+}
+
+// AnchorEqualFold applies the EqualFold predicate on the "anchor" field.
+func AnchorEqualFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEqualFold(FieldAnchor, v))
+}
+
+// AnchorContainsFold applies the ContainsFold predicate on the "anchor" field.
+func AnchorContainsFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContainsFold(FieldAnchor, v))
+}
+
+// StartEQ applies the EQ predicate on the "start" field.
+func StartEQ(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldStart, v))
+}
+
+// StartNEQ applies the NEQ predicate on the "start" field.
+func StartNEQ(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldStart, v))
+}
+
+// StartIn applies the In predicate on the "start" field.
+func StartIn(vs ...int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldStart, vs...))
+}
+
+// StartNotIn applies the NotIn predicate on the "start" field.
+func StartNotIn(vs ...int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldStart, vs...))
+}
+
+// StartGT applies the GT predicate on the "start" field.
+func StartGT(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldStart, v))
+}
+
+// StartGTE applies the GTE predicate on the "start" field.
+func StartGTE(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldStart, v))
+}
+
+// StartLT applies the LT predicate on the "start" field.
+func StartLT(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldStart, v))
+}
+
+// StartLTE applies the LTE predicate on the "start" field.
+func StartLTE(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldStart, v))
+}
+
+// EndEQ applies the EQ predicate on the "end" field.
+func EndEQ(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldEnd, v))
+}
+
+// EndNEQ applies the NEQ predicate on the "end" field.
+func EndNEQ(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldEnd, v))
+}
+
+// EndIn applies the In predicate on the "end" field.
+func EndIn(vs ...int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldEnd, vs...))
+}
+
+// EndNotIn applies the NotIn predicate on the "end" field.
+func EndNotIn(vs ...int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldEnd, vs...))
+}
+
+// EndGT applies the GT predicate on the "end" field.
+func EndGT(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldEnd, v))
+}
+
+// EndGTE applies the GTE predicate on the "end" field.
+func EndGTE(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldEnd, v))
+}
+
+// EndLT applies the LT predicate on the "end" field.
+func EndLT(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldEnd, v))
+}
+
+// EndLTE applies the LTE predicate on the "end" field.
+func EndLTE(v int) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldEnd, v))
+}
+
+// TextEQ applies the EQ predicate on the "text" field.
+func TextEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldText, v))
+}
+
+// TextNEQ applies the NEQ predicate on the "text" field.
+func TextNEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldText, v))
+}
+
+// TextIn applies the In predicate on the "text" field.
+func TextIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldText, vs...))
+}
+
+// TextNotIn applies the NotIn predicate on the "text" field.
+func TextNotIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldText, vs...))
+}
+
+// TextGT applies the GT predicate on the "text" field.
+func TextGT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldText, v))
+}
+
+// TextGTE applies the GTE predicate on the "text" field.
+func TextGTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldText, v))
+}
+
+// TextLT applies the LT predicate on the "text" field.
+func TextLT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldText, v))
+}
+
+// TextLTE applies the LTE predicate on the "text" field.
+func TextLTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldText, v))
+}
+
+// TextContains applies the Contains predicate on the "text" field.
+func TextContains(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContains(FieldText, v))
+}
+
+// TextHasPrefix applies the HasPrefix predicate on the "text" field.
+func TextHasPrefix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasPrefix(FieldText, v))
+}
+
+// TextHasSuffix applies the HasSuffix predicate on the "text" field.
+func TextHasSuffix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasSuffix(FieldText, v))
+}
+
+// TextEqualFold applies the EqualFold predicate on the "text" field.
+func TextEqualFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEqualFold(FieldText, v))
+}
+
+// TextContainsFold applies the ContainsFold predicate on the "text" field.
+func TextContainsFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContainsFold(FieldText, v))
+}
+
+// ChapterEQ applies the EQ predicate on the "chapter" field.
+func ChapterEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldChapter, v))
+}
+
+// ChapterNEQ applies the NEQ predicate on the "chapter" field.
+func ChapterNEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldChapter, v))
+}
+
+// ChapterIn applies the In predicate on the "chapter" field.
+func ChapterIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldChapter, vs...))
+}
+
+// ChapterNotIn applies the NotIn predicate on the "chapter" field.
+func ChapterNotIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldChapter, vs...))
+}
+
+// ChapterGT applies the GT predicate on the "chapter" field.
+func ChapterGT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldChapter, v))
+}
+
+// ChapterGTE applies the GTE predicate on the "chapter" field.
+func ChapterGTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldChapter, v))
+}
+
+// ChapterLT applies the LT predicate on the "chapter" field.
+func ChapterLT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldChapter, v))
+}
+
+// ChapterLTE applies the LTE predicate on the "chapter" field.
+func ChapterLTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldChapter, v))
+}
+
+// ChapterContains applies the Contains predicate on the "chapter" field.
+func ChapterContains(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContains(FieldChapter, v))
+}
+
+// ChapterHasPrefix applies the HasPrefix predicate on the "chapter" field.
+func ChapterHasPrefix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasPrefix(FieldChapter, v))
+}
+
+// ChapterHasSuffix applies the HasSuffix predicate on the "chapter" field.
+func ChapterHasSuffix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasSuffix(FieldChapter, v))
+}
+
+// ChapterIsNil applies the IsNil predicate on the "chapter" field.
+func ChapterIsNil() predicate.Highlight {
+	return predicate.Highlight(sql.FieldIsNull(FieldChapter))
+}
+
+// ChapterNotNil applies the NotNil predicate on the "chapter" field.
+func ChapterNotNil() predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotNull(FieldChapter))
+}
+
+// ChapterEqualFold applies the EqualFold predicate on the "chapter" field.
+func ChapterEqualFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEqualFold(FieldChapter, v))
+}
+
+// ChapterContainsFold applies the ContainsFold predicate on the "chapter" field.
+func ChapterContainsFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContainsFold(FieldChapter, v))
+}
+
+// NoteEQ applies the EQ predicate on the "note" field.
+func NoteEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldNote, v))
+}
+
+// NoteNEQ applies the NEQ predicate on the "note" field.
+func NoteNEQ(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldNote, v))
+}
+
+// NoteIn applies the In predicate on the "note" field.
+func NoteIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldNote, vs...))
+}
+
+// NoteNotIn applies the NotIn predicate on the "note" field.
+func NoteNotIn(vs ...string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldNote, vs...))
+}
+
+// NoteGT applies the GT predicate on the "note" field.
+func NoteGT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldNote, v))
+}
+
+// NoteGTE applies the GTE predicate on the "note" field.
+func NoteGTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldNote, v))
+}
+
+// NoteLT applies the LT predicate on the "note" field.
+func NoteLT(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldNote, v))
+}
+
+// NoteLTE applies the LTE predicate on the "note" field.
+func NoteLTE(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldNote, v))
+}
+
+// NoteContains applies the Contains predicate on the "note" field.
+func NoteContains(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContains(FieldNote, v))
+}
+
+// NoteHasPrefix applies the HasPrefix predicate on the "note" field.
+func NoteHasPrefix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasPrefix(FieldNote, v))
+}
+
+// NoteHasSuffix applies the HasSuffix predicate on the "note" field.
+func NoteHasSuffix(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldHasSuffix(FieldNote, v))
+}
+
+// NoteIsNil applies the IsNil predicate on the "note" field.
+func NoteIsNil() predicate.Highlight {
+	return predicate.Highlight(sql.FieldIsNull(FieldNote))
+}
+
+// NoteNotNil applies the NotNil predicate on the "note" field.
+func NoteNotNil() predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotNull(FieldNote))
+}
+
+// NoteEqualFold applies the EqualFold predicate on the "note" field.
+func NoteEqualFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEqualFold(FieldNote, v))
+}
+
+// NoteContainsFold applies the ContainsFold predicate on the "note" field.
+func NoteContainsFold(v string) predicate.Highlight {
+	return predicate.Highlight(sql.FieldContainsFold(FieldNote, v))
+}
+
+// ColorEQ applies the EQ predicate on the "color" field.
+func ColorEQ(v Color) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldColor, v))
+}
+
+// ColorNEQ applies the NEQ predicate on the "color" field.
+func ColorNEQ(v Color) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldColor, v))
+}
+
+// ColorIn applies the In predicate on the "color" field.
+func ColorIn(vs ...Color) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldColor, vs...))
+}
+
+// ColorNotIn applies the NotIn predicate on the "color" field.
+func ColorNotIn(vs ...Color) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldColor, vs...))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v int64) predicate.Highlight {
+	return predicate.Highlight(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// HasBook applies the HasEdge predicate on the "book" edge.
+func HasBook() predicate.Highlight {
+	return predicate.Highlight(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, BookTable, BookColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasBookWith applies the HasEdge predicate on the "book" edge with a given conditions (other predicates).
+func HasBookWith(preds ...predicate.Book) predicate.Highlight {
+	return predicate.Highlight(func(s *sql.Selector) {
+		step := newBookStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasUser applies the HasEdge predicate on the "user" edge.
+func HasUser() predicate.Highlight {
+	return predicate.Highlight(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, UserTable, UserColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasUserWith applies the HasEdge predicate on the "user" edge with a given conditions (other predicates).
+func HasUserWith(preds ...predicate.User) predicate.Highlight {
+	return predicate.Highlight(func(s *sql.Selector) {
+		step := newUserStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Highlight) predicate.Highlight {
+	return predicate.Highlight(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Highlight) predicate.Highlight {
+	return predicate.Highlight(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Highlight) predicate.Highlight {
+	return predicate.Highlight(sql.NotPredicates(p))
+}
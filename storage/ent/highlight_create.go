@@ -0,0 +1,445 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// HighlightCreate is the builder for creating a Highlight entity.
+type HighlightCreate struct {
+	config
+	mutation *HighlightMutation
+	hooks    []Hook
+}
+
+// SetAnchor sets the "anchor" field.
+func (hc *HighlightCreate) SetAnchor(s string) *HighlightCreate {
+	hc.mutation.SetAnchor(s)
+	return hc
+}
+
+// SetStart sets the "start" field.
+func (hc *HighlightCreate) SetStart(i int) *HighlightCreate {
+	hc.mutation.SetStart(i)
+	return hc
+}
+
+// SetEnd sets the "end" field.
+func (hc *HighlightCreate) SetEnd(i int) *HighlightCreate {
+	hc.mutation.SetEnd(i)
+	return hc
+}
+
+// SetText sets the "text" field.
+func (hc *HighlightCreate) SetText(s string) *HighlightCreate {
+	hc.mutation.SetText(s)
+	return hc
+}
+
+// SetChapter sets the "chapter" field.
+func (hc *HighlightCreate) SetChapter(s string) *HighlightCreate {
+	hc.mutation.SetChapter(s)
+	return hc
+}
+
+// SetNillableChapter sets the "chapter" field if the given value is not nil.
+func (hc *HighlightCreate) SetNillableChapter(s *string) *HighlightCreate {
+	if s != nil {
+		hc.SetChapter(*s)
+	}
+	return hc
+}
+
+// SetNote sets the "note" field.
+func (hc *HighlightCreate) SetNote(s string) *HighlightCreate {
+	hc.mutation.SetNote(s)
+	return hc
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (hc *HighlightCreate) SetNillableNote(s *string) *HighlightCreate {
+	if s != nil {
+		hc.SetNote(*s)
+	}
+	return hc
+}
+
+// SetColor sets the "color" field.
+func (hc *HighlightCreate) SetColor(h highlight.Color) *HighlightCreate {
+	hc.mutation.SetColor(h)
+	return hc
+}
+
+// SetNillableColor sets the "color" field if the given value is not nil.
+func (hc *HighlightCreate) SetNillableColor(h *highlight.Color) *HighlightCreate {
+	if h != nil {
+		hc.SetColor(*h)
+	}
+	return hc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (hc *HighlightCreate) SetCreatedAt(i int64) *HighlightCreate {
+	hc.mutation.SetCreatedAt(i)
+	return hc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (hc *HighlightCreate) SetNillableCreatedAt(i *int64) *HighlightCreate {
+	if i != nil {
+		hc.SetCreatedAt(*i)
+	}
+	return hc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (hc *HighlightCreate) SetUpdatedAt(i int64) *HighlightCreate {
+	hc.mutation.SetUpdatedAt(i)
+	return hc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (hc *HighlightCreate) SetNillableUpdatedAt(i *int64) *HighlightCreate {
+	if i != nil {
+		hc.SetUpdatedAt(*i)
+	}
+	return hc
+}
+
+// SetID sets the "id" field.
+func (hc *HighlightCreate) SetID(i int64) *HighlightCreate {
+	hc.mutation.SetID(i)
+	return hc
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (hc *HighlightCreate) SetBookID(id int64) *HighlightCreate {
+	hc.mutation.SetBookID(id)
+	return hc
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (hc *HighlightCreate) SetBook(b *Book) *HighlightCreate {
+	return hc.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (hc *HighlightCreate) SetUserID(id int64) *HighlightCreate {
+	hc.mutation.SetUserID(id)
+	return hc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (hc *HighlightCreate) SetUser(u *User) *HighlightCreate {
+	return hc.SetUserID(u.ID)
+}
+
+// Mutation returns the HighlightMutation object of the builder.
+func (hc *HighlightCreate) Mutation() *HighlightMutation {
+	return hc.mutation
+}
+
+// Save creates the Highlight in the database.
+func (hc *HighlightCreate) Save(ctx context.Context) (*Highlight, error) {
+	hc.defaults()
+	return withHooks(ctx, hc.sqlSave, hc.mutation, hc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (hc *HighlightCreate) SaveX(ctx context.Context) *Highlight {
+	v, err := hc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (hc *HighlightCreate) Exec(ctx context.Context) error {
+	_, err := hc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (hc *HighlightCreate) ExecX(ctx context.Context) {
+	if err := hc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (hc *HighlightCreate) defaults() {
+	if _, ok := hc.mutation.Color(); !ok {
+		v := highlight.DefaultColor
+		hc.mutation.SetColor(v)
+	}
+	if _, ok := hc.mutation.CreatedAt(); !ok {
+		v := highlight.DefaultCreatedAt()
+		hc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := hc.mutation.UpdatedAt(); !ok {
+		v := highlight.DefaultUpdatedAt()
+		hc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (hc *HighlightCreate) check() error {
+	if _, ok := hc.mutation.Anchor(); !ok {
+		return &ValidationError{Name: "anchor", err: errors.New(`ent: missing required field "Highlight.anchor"`)}
+	}
+	if v, ok := hc.mutation.Anchor(); ok {
+		if err := highlight.AnchorValidator(v); err != nil {
+			return &ValidationError{Name: "anchor", err: fmt.Errorf(`ent: validator failed for field "Highlight.anchor": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.Start(); !ok {
+		return &ValidationError{Name: "start", err: errors.New(`ent: missing required field "Highlight.start"`)}
+	}
+	if v, ok := hc.mutation.Start(); ok {
+		if err := highlight.StartValidator(v); err != nil {
+			return &ValidationError{Name: "start", err: fmt.Errorf(`ent: validator failed for field "Highlight.start": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.End(); !ok {
+		return &ValidationError{Name: "end", err: errors.New(`ent: missing required field "Highlight.end"`)}
+	}
+	if v, ok := hc.mutation.End(); ok {
+		if err := highlight.EndValidator(v); err != nil {
+			return &ValidationError{Name: "end", err: fmt.Errorf(`ent: validator failed for field "Highlight.end": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.Text(); !ok {
+		return &ValidationError{Name: "text", err: errors.New(`ent: missing required field "Highlight.text"`)}
+	}
+	if v, ok := hc.mutation.Text(); ok {
+		if err := highlight.TextValidator(v); err != nil {
+			return &ValidationError{Name: "text", err: fmt.Errorf(`ent: validator failed for field "Highlight.text": %w`, err)}
+		}
+	}
+	if v, ok := hc.mutation.Note(); ok {
+		if err := highlight.NoteValidator(v); err != nil {
+			return &ValidationError{Name: "note", err: fmt.Errorf(`ent: validator failed for field "Highlight.note": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.Color(); !ok {
+		return &ValidationError{Name: "color", err: errors.New(`ent: missing required field "Highlight.color"`)}
+	}
+	if v, ok := hc.mutation.Color(); ok {
+		if err := highlight.ColorValidator(v); err != nil {
+			return &ValidationError{Name: "color", err: fmt.Errorf(`ent: validator failed for field "Highlight.color": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Highlight.created_at"`)}
+	}
+	if _, ok := hc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Highlight.updated_at"`)}
+	}
+	if _, ok := hc.mutation.BookID(); !ok {
+		return &ValidationError{Name: "book", err: errors.New(`ent: missing required edge "Highlight.book"`)}
+	}
+	if _, ok := hc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Highlight.user"`)}
+	}
+	return nil
+}
+
+func (hc *HighlightCreate) sqlSave(ctx context.Context) (*Highlight, error) {
+	if err := hc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := hc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, hc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	hc.mutation.id = &_node.ID
+	hc.mutation.done = true
+	return _node, nil
+}
+
+func (hc *HighlightCreate) createSpec() (*Highlight, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Highlight{config: hc.config}
+		_spec = sqlgraph.NewCreateSpec(highlight.Table, sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64))
+	)
+	if id, ok := hc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := hc.mutation.Anchor(); ok {
+		_spec.SetField(highlight.FieldAnchor, field.TypeString, value)
+		_node.Anchor = value
+	}
+	if value, ok := hc.mutation.Start(); ok {
+		_spec.SetField(highlight.FieldStart, field.TypeInt, value)
+		_node.Start = value
+	}
+	if value, ok := hc.mutation.End(); ok {
+		_spec.SetField(highlight.FieldEnd, field.TypeInt, value)
+		_node.End = value
+	}
+	if value, ok := hc.mutation.Text(); ok {
+		_spec.SetField(highlight.FieldText, field.TypeString, value)
+		_node.Text = value
+	}
+	if value, ok := hc.mutation.Chapter(); ok {
+		_spec.SetField(highlight.FieldChapter, field.TypeString, value)
+		_node.Chapter = value
+	}
+	if value, ok := hc.mutation.Note(); ok {
+		_spec.SetField(highlight.FieldNote, field.TypeString, value)
+		_node.Note = value
+	}
+	if value, ok := hc.mutation.Color(); ok {
+		_spec.SetField(highlight.FieldColor, field.TypeEnum, value)
+		_node.Color = value
+	}
+	if value, ok := hc.mutation.CreatedAt(); ok {
+		_spec.SetField(highlight.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := hc.mutation.UpdatedAt(); ok {
+		_spec.SetField(highlight.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := hc.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.BookTable,
+			Columns: []string{highlight.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.highlight_book = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := hc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.UserTable,
+			Columns: []string{highlight.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.highlight_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// HighlightCreateBulk is the builder for creating many Highlight entities in bulk.
+type HighlightCreateBulk struct {
+	config
+	err      error
+	builders []*HighlightCreate
+}
+
+// Save creates the Highlight entities in the database.
+func (hcb *HighlightCreateBulk) Save(ctx context.Context) ([]*Highlight, error) {
+	if hcb.err != nil {
+		return nil, hcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(hcb.builders))
+	nodes := make([]*Highlight, len(hcb.builders))
+	mutators := make([]Mutator, len(hcb.builders))
+	for i := range hcb.builders {
+		func(i int, root context.Context) {
+			builder := hcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*HighlightMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, hcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, hcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, hcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (hcb *HighlightCreateBulk) SaveX(ctx context.Context) []*Highlight {
+	v, err := hcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (hcb *HighlightCreateBulk) Exec(ctx context.Context) error {
+	_, err := hcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (hcb *HighlightCreateBulk) ExecX(ctx context.Context) {
+	if err := hcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
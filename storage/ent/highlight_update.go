@@ -0,0 +1,755 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// HighlightUpdate is the builder for updating Highlight entities.
+type HighlightUpdate struct {
+	config
+	hooks    []Hook
+	mutation *HighlightMutation
+}
+
+// Where appends a list predicates to the HighlightUpdate builder.
+func (hu *HighlightUpdate) Where(ps ...predicate.Highlight) *HighlightUpdate {
+	hu.mutation.Where(ps...)
+	return hu
+}
+
+// SetAnchor sets the "anchor" field.
+func (hu *HighlightUpdate) SetAnchor(s string) *HighlightUpdate {
+	hu.mutation.SetAnchor(s)
+	return hu
+}
+
+// SetStart sets the "start" field.
+func (hu *HighlightUpdate) SetStart(i int) *HighlightUpdate {
+	hu.mutation.ResetStart()
+	hu.mutation.SetStart(i)
+	return hu
+}
+
+// AddStart adds i to the "start" field.
+func (hu *HighlightUpdate) AddStart(i int) *HighlightUpdate {
+	hu.mutation.AddStart(i)
+	return hu
+}
+
+// SetEnd sets the "end" field.
+func (hu *HighlightUpdate) SetEnd(i int) *HighlightUpdate {
+	hu.mutation.ResetEnd()
+	hu.mutation.SetEnd(i)
+	return hu
+}
+
+// AddEnd adds i to the "end" field.
+func (hu *HighlightUpdate) AddEnd(i int) *HighlightUpdate {
+	hu.mutation.AddEnd(i)
+	return hu
+}
+
+// SetText sets the "text" field.
+func (hu *HighlightUpdate) SetText(s string) *HighlightUpdate {
+	hu.mutation.SetText(s)
+	return hu
+}
+
+// SetChapter sets the "chapter" field.
+func (hu *HighlightUpdate) SetChapter(s string) *HighlightUpdate {
+	hu.mutation.SetChapter(s)
+	return hu
+}
+
+// SetNillableChapter sets the "chapter" field if the given value is not nil.
+func (hu *HighlightUpdate) SetNillableChapter(s *string) *HighlightUpdate {
+	if s != nil {
+		hu.SetChapter(*s)
+	}
+	return hu
+}
+
+// ClearChapter clears the value of the "chapter" field.
+func (hu *HighlightUpdate) ClearChapter() *HighlightUpdate {
+	hu.mutation.ClearChapter()
+	return hu
+}
+
+// SetNote sets the "note" field.
+func (hu *HighlightUpdate) SetNote(s string) *HighlightUpdate {
+	hu.mutation.SetNote(s)
+	return hu
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (hu *HighlightUpdate) SetNillableNote(s *string) *HighlightUpdate {
+	if s != nil {
+		hu.SetNote(*s)
+	}
+	return hu
+}
+
+// ClearNote clears the value of the "note" field.
+func (hu *HighlightUpdate) ClearNote() *HighlightUpdate {
+	hu.mutation.ClearNote()
+	return hu
+}
+
+// SetColor sets the "color" field.
+func (hu *HighlightUpdate) SetColor(h highlight.Color) *HighlightUpdate {
+	hu.mutation.SetColor(h)
+	return hu
+}
+
+// SetNillableColor sets the "color" field if the given value is not nil.
+func (hu *HighlightUpdate) SetNillableColor(h *highlight.Color) *HighlightUpdate {
+	if h != nil {
+		hu.SetColor(*h)
+	}
+	return hu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (hu *HighlightUpdate) SetUpdatedAt(i int64) *HighlightUpdate {
+	hu.mutation.ResetUpdatedAt()
+	hu.mutation.SetUpdatedAt(i)
+	return hu
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (hu *HighlightUpdate) AddUpdatedAt(i int64) *HighlightUpdate {
+	hu.mutation.AddUpdatedAt(i)
+	return hu
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (hu *HighlightUpdate) SetBookID(id int64) *HighlightUpdate {
+	hu.mutation.SetBookID(id)
+	return hu
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (hu *HighlightUpdate) SetBook(b *Book) *HighlightUpdate {
+	return hu.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (hu *HighlightUpdate) SetUserID(id int64) *HighlightUpdate {
+	hu.mutation.SetUserID(id)
+	return hu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (hu *HighlightUpdate) SetUser(u *User) *HighlightUpdate {
+	return hu.SetUserID(u.ID)
+}
+
+// Mutation returns the HighlightMutation object of the builder.
+func (hu *HighlightUpdate) Mutation() *HighlightMutation {
+	return hu.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (hu *HighlightUpdate) ClearBook() *HighlightUpdate {
+	hu.mutation.ClearBook()
+	return hu
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (hu *HighlightUpdate) ClearUser() *HighlightUpdate {
+	hu.mutation.ClearUser()
+	return hu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (hu *HighlightUpdate) Save(ctx context.Context) (int, error) {
+	hu.defaults()
+	return withHooks(ctx, hu.sqlSave, hu.mutation, hu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (hu *HighlightUpdate) SaveX(ctx context.Context) int {
+	affected, err := hu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (hu *HighlightUpdate) Exec(ctx context.Context) error {
+	_, err := hu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (hu *HighlightUpdate) ExecX(ctx context.Context) {
+	if err := hu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (hu *HighlightUpdate) defaults() {
+	if _, ok := hu.mutation.UpdatedAt(); !ok {
+		v := highlight.UpdateDefaultUpdatedAt()
+		hu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (hu *HighlightUpdate) check() error {
+	if v, ok := hu.mutation.Anchor(); ok {
+		if err := highlight.AnchorValidator(v); err != nil {
+			return &ValidationError{Name: "anchor", err: fmt.Errorf(`ent: validator failed for field "Highlight.anchor": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.Start(); ok {
+		if err := highlight.StartValidator(v); err != nil {
+			return &ValidationError{Name: "start", err: fmt.Errorf(`ent: validator failed for field "Highlight.start": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.End(); ok {
+		if err := highlight.EndValidator(v); err != nil {
+			return &ValidationError{Name: "end", err: fmt.Errorf(`ent: validator failed for field "Highlight.end": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.Text(); ok {
+		if err := highlight.TextValidator(v); err != nil {
+			return &ValidationError{Name: "text", err: fmt.Errorf(`ent: validator failed for field "Highlight.text": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.Note(); ok {
+		if err := highlight.NoteValidator(v); err != nil {
+			return &ValidationError{Name: "note", err: fmt.Errorf(`ent: validator failed for field "Highlight.note": %w`, err)}
+		}
+	}
+	if v, ok := hu.mutation.Color(); ok {
+		if err := highlight.ColorValidator(v); err != nil {
+			return &ValidationError{Name: "color", err: fmt.Errorf(`ent: validator failed for field "Highlight.color": %w`, err)}
+		}
+	}
+	if _, ok := hu.mutation.BookID(); hu.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Highlight.book"`)
+	}
+	if _, ok := hu.mutation.UserID(); hu.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Highlight.user"`)
+	}
+	return nil
+}
+
+func (hu *HighlightUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := hu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(highlight.Table, highlight.Columns, sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64))
+	if ps := hu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := hu.mutation.Anchor(); ok {
+		_spec.SetField(highlight.FieldAnchor, field.TypeString, value)
+	}
+	if value, ok := hu.mutation.Start(); ok {
+		_spec.SetField(highlight.FieldStart, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.AddedStart(); ok {
+		_spec.AddField(highlight.FieldStart, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.End(); ok {
+		_spec.SetField(highlight.FieldEnd, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.AddedEnd(); ok {
+		_spec.AddField(highlight.FieldEnd, field.TypeInt, value)
+	}
+	if value, ok := hu.mutation.Text(); ok {
+		_spec.SetField(highlight.FieldText, field.TypeString, value)
+	}
+	if value, ok := hu.mutation.Chapter(); ok {
+		_spec.SetField(highlight.FieldChapter, field.TypeString, value)
+	}
+	if hu.mutation.ChapterCleared() {
+		_spec.ClearField(highlight.FieldChapter, field.TypeString)
+	}
+	if value, ok := hu.mutation.Note(); ok {
+		_spec.SetField(highlight.FieldNote, field.TypeString, value)
+	}
+	if hu.mutation.NoteCleared() {
+		_spec.ClearField(highlight.FieldNote, field.TypeString)
+	}
+	if value, ok := hu.mutation.Color(); ok {
+		_spec.SetField(highlight.FieldColor, field.TypeEnum, value)
+	}
+	if value, ok := hu.mutation.UpdatedAt(); ok {
+		_spec.SetField(highlight.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := hu.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(highlight.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if hu.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.BookTable,
+			Columns: []string{highlight.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := hu.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.BookTable,
+			Columns: []string{highlight.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if hu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.UserTable,
+			Columns: []string{highlight.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := hu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.UserTable,
+			Columns: []string{highlight.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, hu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{highlight.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	hu.mutation.done = true
+	return n, nil
+}
+
+// HighlightUpdateOne is the builder for updating a single Highlight entity.
+type HighlightUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *HighlightMutation
+}
+
+// SetAnchor sets the "anchor" field.
+func (huo *HighlightUpdateOne) SetAnchor(s string) *HighlightUpdateOne {
+	huo.mutation.SetAnchor(s)
+	return huo
+}
+
+// SetStart sets the "start" field.
+func (huo *HighlightUpdateOne) SetStart(i int) *HighlightUpdateOne {
+	huo.mutation.ResetStart()
+	huo.mutation.SetStart(i)
+	return huo
+}
+
+// AddStart adds i to the "start" field.
+func (huo *HighlightUpdateOne) AddStart(i int) *HighlightUpdateOne {
+	huo.mutation.AddStart(i)
+	return huo
+}
+
+// SetEnd sets the "end" field.
+func (huo *HighlightUpdateOne) SetEnd(i int) *HighlightUpdateOne {
+	huo.mutation.ResetEnd()
+	huo.mutation.SetEnd(i)
+	return huo
+}
+
+// AddEnd adds i to the "end" field.
+func (huo *HighlightUpdateOne) AddEnd(i int) *HighlightUpdateOne {
+	huo.mutation.AddEnd(i)
+	return huo
+}
+
+// SetText sets the "text" field.
+func (huo *HighlightUpdateOne) SetText(s string) *HighlightUpdateOne {
+	huo.mutation.SetText(s)
+	return huo
+}
+
+// SetChapter sets the "chapter" field.
+func (huo *HighlightUpdateOne) SetChapter(s string) *HighlightUpdateOne {
+	huo.mutation.SetChapter(s)
+	return huo
+}
+
+// SetNillableChapter sets the "chapter" field if the given value is not nil.
+func (huo *HighlightUpdateOne) SetNillableChapter(s *string) *HighlightUpdateOne {
+	if s != nil {
+		huo.SetChapter(*s)
+	}
+	return huo
+}
+
+// ClearChapter clears the value of the "chapter" field.
+func (huo *HighlightUpdateOne) ClearChapter() *HighlightUpdateOne {
+	huo.mutation.ClearChapter()
+	return huo
+}
+
+// SetNote sets the "note" field.
+func (huo *HighlightUpdateOne) SetNote(s string) *HighlightUpdateOne {
+	huo.mutation.SetNote(s)
+	return huo
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (huo *HighlightUpdateOne) SetNillableNote(s *string) *HighlightUpdateOne {
+	if s != nil {
+		huo.SetNote(*s)
+	}
+	return huo
+}
+
+// ClearNote clears the value of the "note" field.
+func (huo *HighlightUpdateOne) ClearNote() *HighlightUpdateOne {
+	huo.mutation.ClearNote()
+	return huo
+}
+
+// SetColor sets the "color" field.
+func (huo *HighlightUpdateOne) SetColor(h highlight.Color) *HighlightUpdateOne {
+	huo.mutation.SetColor(h)
+	return huo
+}
+
+// SetNillableColor sets the "color" field if the given value is not nil.
+func (huo *HighlightUpdateOne) SetNillableColor(h *highlight.Color) *HighlightUpdateOne {
+	if h != nil {
+		huo.SetColor(*h)
+	}
+	return huo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (huo *HighlightUpdateOne) SetUpdatedAt(i int64) *HighlightUpdateOne {
+	huo.mutation.ResetUpdatedAt()
+	huo.mutation.SetUpdatedAt(i)
+	return huo
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (huo *HighlightUpdateOne) AddUpdatedAt(i int64) *HighlightUpdateOne {
+	huo.mutation.AddUpdatedAt(i)
+	return huo
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (huo *HighlightUpdateOne) SetBookID(id int64) *HighlightUpdateOne {
+	huo.mutation.SetBookID(id)
+	return huo
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (huo *HighlightUpdateOne) SetBook(b *Book) *HighlightUpdateOne {
+	return huo.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (huo *HighlightUpdateOne) SetUserID(id int64) *HighlightUpdateOne {
+	huo.mutation.SetUserID(id)
+	return huo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (huo *HighlightUpdateOne) SetUser(u *User) *HighlightUpdateOne {
+	return huo.SetUserID(u.ID)
+}
+
+// Mutation returns the HighlightMutation object of the builder.
+func (huo *HighlightUpdateOne) Mutation() *HighlightMutation {
+	return huo.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (huo *HighlightUpdateOne) ClearBook() *HighlightUpdateOne {
+	huo.mutation.ClearBook()
+	return huo
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (huo *HighlightUpdateOne) ClearUser() *HighlightUpdateOne {
+	huo.mutation.ClearUser()
+	return huo
+}
+
+// Where appends a list predicates to the HighlightUpdate builder.
+func (huo *HighlightUpdateOne) Where(ps ...predicate.Highlight) *HighlightUpdateOne {
+	huo.mutation.Where(ps...)
+	return huo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (huo *HighlightUpdateOne) Select(field string, fields ...string) *HighlightUpdateOne {
+	huo.fields = append([]string{field}, fields...)
+	return huo
+}
+
+// Save executes the query and returns the updated Highlight entity.
+func (huo *HighlightUpdateOne) Save(ctx context.Context) (*Highlight, error) {
+	huo.defaults()
+	return withHooks(ctx, huo.sqlSave, huo.mutation, huo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (huo *HighlightUpdateOne) SaveX(ctx context.Context) *Highlight {
+	node, err := huo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (huo *HighlightUpdateOne) Exec(ctx context.Context) error {
+	_, err := huo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (huo *HighlightUpdateOne) ExecX(ctx context.Context) {
+	if err := huo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (huo *HighlightUpdateOne) defaults() {
+	if _, ok := huo.mutation.UpdatedAt(); !ok {
+		v := highlight.UpdateDefaultUpdatedAt()
+		huo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (huo *HighlightUpdateOne) check() error {
+	if v, ok := huo.mutation.Anchor(); ok {
+		if err := highlight.AnchorValidator(v); err != nil {
+			return &ValidationError{Name: "anchor", err: fmt.Errorf(`ent: validator failed for field "Highlight.anchor": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.Start(); ok {
+		if err := highlight.StartValidator(v); err != nil {
+			return &ValidationError{Name: "start", err: fmt.Errorf(`ent: validator failed for field "Highlight.start": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.End(); ok {
+		if err := highlight.EndValidator(v); err != nil {
+			return &ValidationError{Name: "end", err: fmt.Errorf(`ent: validator failed for field "Highlight.end": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.Text(); ok {
+		if err := highlight.TextValidator(v); err != nil {
+			return &ValidationError{Name: "text", err: fmt.Errorf(`ent: validator failed for field "Highlight.text": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.Note(); ok {
+		if err := highlight.NoteValidator(v); err != nil {
+			return &ValidationError{Name: "note", err: fmt.Errorf(`ent: validator failed for field "Highlight.note": %w`, err)}
+		}
+	}
+	if v, ok := huo.mutation.Color(); ok {
+		if err := highlight.ColorValidator(v); err != nil {
+			return &ValidationError{Name: "color", err: fmt.Errorf(`ent: validator failed for field "Highlight.color": %w`, err)}
+		}
+	}
+	if _, ok := huo.mutation.BookID(); huo.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Highlight.book"`)
+	}
+	if _, ok := huo.mutation.UserID(); huo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Highlight.user"`)
+	}
+	return nil
+}
+
+func (huo *HighlightUpdateOne) sqlSave(ctx context.Context) (_node *Highlight, err error) {
+	if err := huo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(highlight.Table, highlight.Columns, sqlgraph.NewFieldSpec(highlight.FieldID, field.TypeInt64))
+	id, ok := huo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Highlight.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := huo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, highlight.FieldID)
+		for _, f := range fields {
+			if !highlight.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != highlight.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := huo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := huo.mutation.Anchor(); ok {
+		_spec.SetField(highlight.FieldAnchor, field.TypeString, value)
+	}
+	if value, ok := huo.mutation.Start(); ok {
+		_spec.SetField(highlight.FieldStart, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.AddedStart(); ok {
+		_spec.AddField(highlight.FieldStart, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.End(); ok {
+		_spec.SetField(highlight.FieldEnd, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.AddedEnd(); ok {
+		_spec.AddField(highlight.FieldEnd, field.TypeInt, value)
+	}
+	if value, ok := huo.mutation.Text(); ok {
+		_spec.SetField(highlight.FieldText, field.TypeString, value)
+	}
+	if value, ok := huo.mutation.Chapter(); ok {
+		_spec.SetField(highlight.FieldChapter, field.TypeString, value)
+	}
+	if huo.mutation.ChapterCleared() {
+		_spec.ClearField(highlight.FieldChapter, field.TypeString)
+	}
+	if value, ok := huo.mutation.Note(); ok {
+		_spec.SetField(highlight.FieldNote, field.TypeString, value)
+	}
+	if huo.mutation.NoteCleared() {
+		_spec.ClearField(highlight.FieldNote, field.TypeString)
+	}
+	if value, ok := huo.mutation.Color(); ok {
+		_spec.SetField(highlight.FieldColor, field.TypeEnum, value)
+	}
+	if value, ok := huo.mutation.UpdatedAt(); ok {
+		_spec.SetField(highlight.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := huo.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(highlight.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if huo.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.BookTable,
+			Columns: []string{highlight.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := huo.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.BookTable,
+			Columns: []string{highlight.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if huo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.UserTable,
+			Columns: []string{highlight.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := huo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   highlight.UserTable,
+			Columns: []string{highlight.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Highlight{config: huo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, huo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{highlight.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	huo.mutation.done = true
+	return _node, nil
+}
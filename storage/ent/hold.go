@@ -0,0 +1,245 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Hold is the model entity for the Hold schema.
+type Hold struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Status holds the value of the "status" field.
+	Status hold.Status `json:"status,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// ReadyAt holds the value of the "ready_at" field.
+	ReadyAt *int64 `json:"ready_at,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the HoldQuery when eager-loading is set.
+	Edges        HoldEdges `json:"edges"`
+	hold_book    *int64
+	hold_user    *int64
+	hold_copy    *int64
+	selectValues sql.SelectValues
+}
+
+// HoldEdges holds the relations/edges for other nodes in the graph.
+type HoldEdges struct {
+	// Book holds the value of the book edge.
+	Book *Book `json:"book,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// Copy holds the value of the copy edge.
+	Copy *BookCopy `json:"copy,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [3]bool
+}
+
+// BookOrErr returns the Book value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e HoldEdges) BookOrErr() (*Book, error) {
+	if e.loadedTypes[0] {
+		if e.Book == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: book.Label}
+		}
+		return e.Book, nil
+	}
+	return nil, &NotLoadedError{edge: "book"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e HoldEdges) UserOrErr() (*User, error) {
+	if e.loadedTypes[1] {
+		if e.User == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.User, nil
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// CopyOrErr returns the Copy value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e HoldEdges) CopyOrErr() (*BookCopy, error) {
+	if e.loadedTypes[2] {
+		if e.Copy == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: bookcopy.Label}
+		}
+		return e.Copy, nil
+	}
+	return nil, &NotLoadedError{edge: "copy"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Hold) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case hold.FieldID, hold.FieldCreatedAt, hold.FieldReadyAt, hold.FieldExpiresAt:
+			values[i] = new(sql.NullInt64)
+		case hold.FieldStatus:
+			values[i] = new(sql.NullString)
+		case hold.ForeignKeys[0]: // hold_book
+			values[i] = new(sql.NullInt64)
+		case hold.ForeignKeys[1]: // hold_user
+			values[i] = new(sql.NullInt64)
+		case hold.ForeignKeys[2]: // hold_copy
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Hold fields.
+func (h *Hold) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case hold.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			h.ID = int64(value.Int64)
+		case hold.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				h.Status = hold.Status(value.String)
+			}
+		case hold.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				h.CreatedAt = value.Int64
+			}
+		case hold.FieldReadyAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field ready_at", values[i])
+			} else if value.Valid {
+				h.ReadyAt = new(int64)
+				*h.ReadyAt = value.Int64
+			}
+		case hold.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				h.ExpiresAt = new(int64)
+				*h.ExpiresAt = value.Int64
+			}
+		case hold.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field hold_book", value)
+			} else if value.Valid {
+				h.hold_book = new(int64)
+				*h.hold_book = int64(value.Int64)
+			}
+		case hold.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field hold_user", value)
+			} else if value.Valid {
+				h.hold_user = new(int64)
+				*h.hold_user = int64(value.Int64)
+			}
+		case hold.ForeignKeys[2]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field hold_copy", value)
+			} else if value.Valid {
+				h.hold_copy = new(int64)
+				*h.hold_copy = int64(value.Int64)
+			}
+		default:
+			h.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Hold.
+// This includes values selected through modifiers, order, etc.
+func (h *Hold) Value(name string) (ent.Value, error) {
+	return h.selectValues.Get(name)
+}
+
+// QueryBook queries the "book" edge of the Hold entity.
+func (h *Hold) QueryBook() *BookQuery {
+	return NewHoldClient(h.config).QueryBook(h)
+}
+
+// QueryUser queries the "user" edge of the Hold entity.
+func (h *Hold) QueryUser() *UserQuery {
+	return NewHoldClient(h.config).QueryUser(h)
+}
+
+// QueryCopy queries the "copy" edge of the Hold entity.
+func (h *Hold) QueryCopy() *BookCopyQuery {
+	return NewHoldClient(h.config).QueryCopy(h)
+}
+
+// Update returns a builder for updating this Hold.
+// Note that you need to call Hold.Unwrap() before calling this method if this Hold
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (h *Hold) Update() *HoldUpdateOne {
+	return NewHoldClient(h.config).UpdateOne(h)
+}
+
+// Unwrap unwraps the Hold entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (h *Hold) Unwrap() *Hold {
+	_tx, ok := h.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Hold is not a transactional entity")
+	}
+	h.config.driver = _tx.drv
+	return h
+}
+
+// String implements the fmt.Stringer.
+func (h *Hold) String() string {
+	var builder strings.Builder
+	builder.WriteString("Hold(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", h.ID))
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", h.Status))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", h.CreatedAt))
+	builder.WriteString(", ")
+	if v := h.ReadyAt; v != nil {
+		builder.WriteString("ready_at=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := h.ExpiresAt; v != nil {
+		builder.WriteString("expires_at=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Holds is a parsable slice of Hold.
+type Holds []*Hold
@@ -0,0 +1,380 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// HoldCreate is the builder for creating a Hold entity.
+type HoldCreate struct {
+	config
+	mutation *HoldMutation
+	hooks    []Hook
+}
+
+// SetStatus sets the "status" field.
+func (hc *HoldCreate) SetStatus(h hold.Status) *HoldCreate {
+	hc.mutation.SetStatus(h)
+	return hc
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (hc *HoldCreate) SetNillableStatus(h *hold.Status) *HoldCreate {
+	if h != nil {
+		hc.SetStatus(*h)
+	}
+	return hc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (hc *HoldCreate) SetCreatedAt(i int64) *HoldCreate {
+	hc.mutation.SetCreatedAt(i)
+	return hc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (hc *HoldCreate) SetNillableCreatedAt(i *int64) *HoldCreate {
+	if i != nil {
+		hc.SetCreatedAt(*i)
+	}
+	return hc
+}
+
+// SetReadyAt sets the "ready_at" field.
+func (hc *HoldCreate) SetReadyAt(i int64) *HoldCreate {
+	hc.mutation.SetReadyAt(i)
+	return hc
+}
+
+// SetNillableReadyAt sets the "ready_at" field if the given value is not nil.
+func (hc *HoldCreate) SetNillableReadyAt(i *int64) *HoldCreate {
+	if i != nil {
+		hc.SetReadyAt(*i)
+	}
+	return hc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (hc *HoldCreate) SetExpiresAt(i int64) *HoldCreate {
+	hc.mutation.SetExpiresAt(i)
+	return hc
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (hc *HoldCreate) SetNillableExpiresAt(i *int64) *HoldCreate {
+	if i != nil {
+		hc.SetExpiresAt(*i)
+	}
+	return hc
+}
+
+// SetID sets the "id" field.
+func (hc *HoldCreate) SetID(i int64) *HoldCreate {
+	hc.mutation.SetID(i)
+	return hc
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (hc *HoldCreate) SetBookID(id int64) *HoldCreate {
+	hc.mutation.SetBookID(id)
+	return hc
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (hc *HoldCreate) SetBook(b *Book) *HoldCreate {
+	return hc.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (hc *HoldCreate) SetUserID(id int64) *HoldCreate {
+	hc.mutation.SetUserID(id)
+	return hc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (hc *HoldCreate) SetUser(u *User) *HoldCreate {
+	return hc.SetUserID(u.ID)
+}
+
+// SetCopyID sets the "copy" edge to the BookCopy entity by ID.
+func (hc *HoldCreate) SetCopyID(id int64) *HoldCreate {
+	hc.mutation.SetCopyID(id)
+	return hc
+}
+
+// SetNillableCopyID sets the "copy" edge to the BookCopy entity by ID if the given value is not nil.
+func (hc *HoldCreate) SetNillableCopyID(id *int64) *HoldCreate {
+	if id != nil {
+		hc = hc.SetCopyID(*id)
+	}
+	return hc
+}
+
+// SetCopy sets the "copy" edge to the BookCopy entity.
+func (hc *HoldCreate) SetCopy(b *BookCopy) *HoldCreate {
+	return hc.SetCopyID(b.ID)
+}
+
+// Mutation returns the HoldMutation object of the builder.
+func (hc *HoldCreate) Mutation() *HoldMutation {
+	return hc.mutation
+}
+
+// Save creates the Hold in the database.
+func (hc *HoldCreate) Save(ctx context.Context) (*Hold, error) {
+	hc.defaults()
+	return withHooks(ctx, hc.sqlSave, hc.mutation, hc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (hc *HoldCreate) SaveX(ctx context.Context) *Hold {
+	v, err := hc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (hc *HoldCreate) Exec(ctx context.Context) error {
+	_, err := hc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (hc *HoldCreate) ExecX(ctx context.Context) {
+	if err := hc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (hc *HoldCreate) defaults() {
+	if _, ok := hc.mutation.Status(); !ok {
+		v := hold.DefaultStatus
+		hc.mutation.SetStatus(v)
+	}
+	if _, ok := hc.mutation.CreatedAt(); !ok {
+		v := hold.DefaultCreatedAt()
+		hc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (hc *HoldCreate) check() error {
+	if _, ok := hc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Hold.status"`)}
+	}
+	if v, ok := hc.mutation.Status(); ok {
+		if err := hold.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Hold.status": %w`, err)}
+		}
+	}
+	if _, ok := hc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Hold.created_at"`)}
+	}
+	if _, ok := hc.mutation.BookID(); !ok {
+		return &ValidationError{Name: "book", err: errors.New(`ent: missing required edge "Hold.book"`)}
+	}
+	if _, ok := hc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Hold.user"`)}
+	}
+	return nil
+}
+
+func (hc *HoldCreate) sqlSave(ctx context.Context) (*Hold, error) {
+	if err := hc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := hc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, hc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	hc.mutation.id = &_node.ID
+	hc.mutation.done = true
+	return _node, nil
+}
+
+func (hc *HoldCreate) createSpec() (*Hold, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Hold{config: hc.config}
+		_spec = sqlgraph.NewCreateSpec(hold.Table, sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64))
+	)
+	if id, ok := hc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := hc.mutation.Status(); ok {
+		_spec.SetField(hold.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := hc.mutation.CreatedAt(); ok {
+		_spec.SetField(hold.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := hc.mutation.ReadyAt(); ok {
+		_spec.SetField(hold.FieldReadyAt, field.TypeInt64, value)
+		_node.ReadyAt = &value
+	}
+	if value, ok := hc.mutation.ExpiresAt(); ok {
+		_spec.SetField(hold.FieldExpiresAt, field.TypeInt64, value)
+		_node.ExpiresAt = &value
+	}
+	if nodes := hc.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.BookTable,
+			Columns: []string{hold.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.hold_book = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := hc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.UserTable,
+			Columns: []string{hold.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.hold_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := hc.mutation.CopyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.CopyTable,
+			Columns: []string{hold.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.hold_copy = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// HoldCreateBulk is the builder for creating many Hold entities in bulk.
+type HoldCreateBulk struct {
+	config
+	err      error
+	builders []*HoldCreate
+}
+
+// Save creates the Hold entities in the database.
+func (hcb *HoldCreateBulk) Save(ctx context.Context) ([]*Hold, error) {
+	if hcb.err != nil {
+		return nil, hcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(hcb.builders))
+	nodes := make([]*Hold, len(hcb.builders))
+	mutators := make([]Mutator, len(hcb.builders))
+	for i := range hcb.builders {
+		func(i int, root context.Context) {
+			builder := hcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*HoldMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, hcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, hcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, hcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (hcb *HoldCreateBulk) SaveX(ctx context.Context) []*Hold {
+	v, err := hcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (hcb *HoldCreateBulk) Exec(ctx context.Context) error {
+	_, err := hcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (hcb *HoldCreateBulk) ExecX(ctx context.Context) {
+	if err := hcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
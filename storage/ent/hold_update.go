@@ -0,0 +1,686 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// HoldUpdate is the builder for updating Hold entities.
+type HoldUpdate struct {
+	config
+	hooks    []Hook
+	mutation *HoldMutation
+}
+
+// Where appends a list predicates to the HoldUpdate builder.
+func (hu *HoldUpdate) Where(ps ...predicate.Hold) *HoldUpdate {
+	hu.mutation.Where(ps...)
+	return hu
+}
+
+// SetStatus sets the "status" field.
+func (hu *HoldUpdate) SetStatus(h hold.Status) *HoldUpdate {
+	hu.mutation.SetStatus(h)
+	return hu
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (hu *HoldUpdate) SetNillableStatus(h *hold.Status) *HoldUpdate {
+	if h != nil {
+		hu.SetStatus(*h)
+	}
+	return hu
+}
+
+// SetReadyAt sets the "ready_at" field.
+func (hu *HoldUpdate) SetReadyAt(i int64) *HoldUpdate {
+	hu.mutation.ResetReadyAt()
+	hu.mutation.SetReadyAt(i)
+	return hu
+}
+
+// SetNillableReadyAt sets the "ready_at" field if the given value is not nil.
+func (hu *HoldUpdate) SetNillableReadyAt(i *int64) *HoldUpdate {
+	if i != nil {
+		hu.SetReadyAt(*i)
+	}
+	return hu
+}
+
+// AddReadyAt adds i to the "ready_at" field.
+func (hu *HoldUpdate) AddReadyAt(i int64) *HoldUpdate {
+	hu.mutation.AddReadyAt(i)
+	return hu
+}
+
+// ClearReadyAt clears the value of the "ready_at" field.
+func (hu *HoldUpdate) ClearReadyAt() *HoldUpdate {
+	hu.mutation.ClearReadyAt()
+	return hu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (hu *HoldUpdate) SetExpiresAt(i int64) *HoldUpdate {
+	hu.mutation.ResetExpiresAt()
+	hu.mutation.SetExpiresAt(i)
+	return hu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (hu *HoldUpdate) SetNillableExpiresAt(i *int64) *HoldUpdate {
+	if i != nil {
+		hu.SetExpiresAt(*i)
+	}
+	return hu
+}
+
+// AddExpiresAt adds i to the "expires_at" field.
+func (hu *HoldUpdate) AddExpiresAt(i int64) *HoldUpdate {
+	hu.mutation.AddExpiresAt(i)
+	return hu
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (hu *HoldUpdate) ClearExpiresAt() *HoldUpdate {
+	hu.mutation.ClearExpiresAt()
+	return hu
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (hu *HoldUpdate) SetBookID(id int64) *HoldUpdate {
+	hu.mutation.SetBookID(id)
+	return hu
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (hu *HoldUpdate) SetBook(b *Book) *HoldUpdate {
+	return hu.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (hu *HoldUpdate) SetUserID(id int64) *HoldUpdate {
+	hu.mutation.SetUserID(id)
+	return hu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (hu *HoldUpdate) SetUser(u *User) *HoldUpdate {
+	return hu.SetUserID(u.ID)
+}
+
+// SetCopyID sets the "copy" edge to the BookCopy entity by ID.
+func (hu *HoldUpdate) SetCopyID(id int64) *HoldUpdate {
+	hu.mutation.SetCopyID(id)
+	return hu
+}
+
+// SetNillableCopyID sets the "copy" edge to the BookCopy entity by ID if the given value is not nil.
+func (hu *HoldUpdate) SetNillableCopyID(id *int64) *HoldUpdate {
+	if id != nil {
+		hu = hu.SetCopyID(*id)
+	}
+	return hu
+}
+
+// SetCopy sets the "copy" edge to the BookCopy entity.
+func (hu *HoldUpdate) SetCopy(b *BookCopy) *HoldUpdate {
+	return hu.SetCopyID(b.ID)
+}
+
+// Mutation returns the HoldMutation object of the builder.
+func (hu *HoldUpdate) Mutation() *HoldMutation {
+	return hu.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (hu *HoldUpdate) ClearBook() *HoldUpdate {
+	hu.mutation.ClearBook()
+	return hu
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (hu *HoldUpdate) ClearUser() *HoldUpdate {
+	hu.mutation.ClearUser()
+	return hu
+}
+
+// ClearCopy clears the "copy" edge to the BookCopy entity.
+func (hu *HoldUpdate) ClearCopy() *HoldUpdate {
+	hu.mutation.ClearCopy()
+	return hu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (hu *HoldUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, hu.sqlSave, hu.mutation, hu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (hu *HoldUpdate) SaveX(ctx context.Context) int {
+	affected, err := hu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (hu *HoldUpdate) Exec(ctx context.Context) error {
+	_, err := hu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (hu *HoldUpdate) ExecX(ctx context.Context) {
+	if err := hu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (hu *HoldUpdate) check() error {
+	if v, ok := hu.mutation.Status(); ok {
+		if err := hold.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Hold.status": %w`, err)}
+		}
+	}
+	if _, ok := hu.mutation.BookID(); hu.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Hold.book"`)
+	}
+	if _, ok := hu.mutation.UserID(); hu.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Hold.user"`)
+	}
+	return nil
+}
+
+func (hu *HoldUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := hu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(hold.Table, hold.Columns, sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64))
+	if ps := hu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := hu.mutation.Status(); ok {
+		_spec.SetField(hold.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := hu.mutation.ReadyAt(); ok {
+		_spec.SetField(hold.FieldReadyAt, field.TypeInt64, value)
+	}
+	if value, ok := hu.mutation.AddedReadyAt(); ok {
+		_spec.AddField(hold.FieldReadyAt, field.TypeInt64, value)
+	}
+	if hu.mutation.ReadyAtCleared() {
+		_spec.ClearField(hold.FieldReadyAt, field.TypeInt64)
+	}
+	if value, ok := hu.mutation.ExpiresAt(); ok {
+		_spec.SetField(hold.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := hu.mutation.AddedExpiresAt(); ok {
+		_spec.AddField(hold.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if hu.mutation.ExpiresAtCleared() {
+		_spec.ClearField(hold.FieldExpiresAt, field.TypeInt64)
+	}
+	if hu.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.BookTable,
+			Columns: []string{hold.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := hu.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.BookTable,
+			Columns: []string{hold.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if hu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.UserTable,
+			Columns: []string{hold.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := hu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.UserTable,
+			Columns: []string{hold.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if hu.mutation.CopyCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.CopyTable,
+			Columns: []string{hold.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := hu.mutation.CopyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.CopyTable,
+			Columns: []string{hold.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, hu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{hold.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	hu.mutation.done = true
+	return n, nil
+}
+
+// HoldUpdateOne is the builder for updating a single Hold entity.
+type HoldUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *HoldMutation
+}
+
+// SetStatus sets the "status" field.
+func (huo *HoldUpdateOne) SetStatus(h hold.Status) *HoldUpdateOne {
+	huo.mutation.SetStatus(h)
+	return huo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (huo *HoldUpdateOne) SetNillableStatus(h *hold.Status) *HoldUpdateOne {
+	if h != nil {
+		huo.SetStatus(*h)
+	}
+	return huo
+}
+
+// SetReadyAt sets the "ready_at" field.
+func (huo *HoldUpdateOne) SetReadyAt(i int64) *HoldUpdateOne {
+	huo.mutation.ResetReadyAt()
+	huo.mutation.SetReadyAt(i)
+	return huo
+}
+
+// SetNillableReadyAt sets the "ready_at" field if the given value is not nil.
+func (huo *HoldUpdateOne) SetNillableReadyAt(i *int64) *HoldUpdateOne {
+	if i != nil {
+		huo.SetReadyAt(*i)
+	}
+	return huo
+}
+
+// AddReadyAt adds i to the "ready_at" field.
+func (huo *HoldUpdateOne) AddReadyAt(i int64) *HoldUpdateOne {
+	huo.mutation.AddReadyAt(i)
+	return huo
+}
+
+// ClearReadyAt clears the value of the "ready_at" field.
+func (huo *HoldUpdateOne) ClearReadyAt() *HoldUpdateOne {
+	huo.mutation.ClearReadyAt()
+	return huo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (huo *HoldUpdateOne) SetExpiresAt(i int64) *HoldUpdateOne {
+	huo.mutation.ResetExpiresAt()
+	huo.mutation.SetExpiresAt(i)
+	return huo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (huo *HoldUpdateOne) SetNillableExpiresAt(i *int64) *HoldUpdateOne {
+	if i != nil {
+		huo.SetExpiresAt(*i)
+	}
+	return huo
+}
+
+// AddExpiresAt adds i to the "expires_at" field.
+func (huo *HoldUpdateOne) AddExpiresAt(i int64) *HoldUpdateOne {
+	huo.mutation.AddExpiresAt(i)
+	return huo
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (huo *HoldUpdateOne) ClearExpiresAt() *HoldUpdateOne {
+	huo.mutation.ClearExpiresAt()
+	return huo
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (huo *HoldUpdateOne) SetBookID(id int64) *HoldUpdateOne {
+	huo.mutation.SetBookID(id)
+	return huo
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (huo *HoldUpdateOne) SetBook(b *Book) *HoldUpdateOne {
+	return huo.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (huo *HoldUpdateOne) SetUserID(id int64) *HoldUpdateOne {
+	huo.mutation.SetUserID(id)
+	return huo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (huo *HoldUpdateOne) SetUser(u *User) *HoldUpdateOne {
+	return huo.SetUserID(u.ID)
+}
+
+// SetCopyID sets the "copy" edge to the BookCopy entity by ID.
+func (huo *HoldUpdateOne) SetCopyID(id int64) *HoldUpdateOne {
+	huo.mutation.SetCopyID(id)
+	return huo
+}
+
+// SetNillableCopyID sets the "copy" edge to the BookCopy entity by ID if the given value is not nil.
+func (huo *HoldUpdateOne) SetNillableCopyID(id *int64) *HoldUpdateOne {
+	if id != nil {
+		huo = huo.SetCopyID(*id)
+	}
+	return huo
+}
+
+// SetCopy sets the "copy" edge to the BookCopy entity.
+func (huo *HoldUpdateOne) SetCopy(b *BookCopy) *HoldUpdateOne {
+	return huo.SetCopyID(b.ID)
+}
+
+// Mutation returns the HoldMutation object of the builder.
+func (huo *HoldUpdateOne) Mutation() *HoldMutation {
+	return huo.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (huo *HoldUpdateOne) ClearBook() *HoldUpdateOne {
+	huo.mutation.ClearBook()
+	return huo
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (huo *HoldUpdateOne) ClearUser() *HoldUpdateOne {
+	huo.mutation.ClearUser()
+	return huo
+}
+
+// ClearCopy clears the "copy" edge to the BookCopy entity.
+func (huo *HoldUpdateOne) ClearCopy() *HoldUpdateOne {
+	huo.mutation.ClearCopy()
+	return huo
+}
+
+// Where appends a list predicates to the HoldUpdate builder.
+func (huo *HoldUpdateOne) Where(ps ...predicate.Hold) *HoldUpdateOne {
+	huo.mutation.Where(ps...)
+	return huo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (huo *HoldUpdateOne) Select(field string, fields ...string) *HoldUpdateOne {
+	huo.fields = append([]string{field}, fields...)
+	return huo
+}
+
+// Save executes the query and returns the updated Hold entity.
+func (huo *HoldUpdateOne) Save(ctx context.Context) (*Hold, error) {
+	return withHooks(ctx, huo.sqlSave, huo.mutation, huo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (huo *HoldUpdateOne) SaveX(ctx context.Context) *Hold {
+	node, err := huo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (huo *HoldUpdateOne) Exec(ctx context.Context) error {
+	_, err := huo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (huo *HoldUpdateOne) ExecX(ctx context.Context) {
+	if err := huo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (huo *HoldUpdateOne) check() error {
+	if v, ok := huo.mutation.Status(); ok {
+		if err := hold.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Hold.status": %w`, err)}
+		}
+	}
+	if _, ok := huo.mutation.BookID(); huo.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Hold.book"`)
+	}
+	if _, ok := huo.mutation.UserID(); huo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Hold.user"`)
+	}
+	return nil
+}
+
+func (huo *HoldUpdateOne) sqlSave(ctx context.Context) (_node *Hold, err error) {
+	if err := huo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(hold.Table, hold.Columns, sqlgraph.NewFieldSpec(hold.FieldID, field.TypeInt64))
+	id, ok := huo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Hold.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := huo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, hold.FieldID)
+		for _, f := range fields {
+			if !hold.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != hold.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := huo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := huo.mutation.Status(); ok {
+		_spec.SetField(hold.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := huo.mutation.ReadyAt(); ok {
+		_spec.SetField(hold.FieldReadyAt, field.TypeInt64, value)
+	}
+	if value, ok := huo.mutation.AddedReadyAt(); ok {
+		_spec.AddField(hold.FieldReadyAt, field.TypeInt64, value)
+	}
+	if huo.mutation.ReadyAtCleared() {
+		_spec.ClearField(hold.FieldReadyAt, field.TypeInt64)
+	}
+	if value, ok := huo.mutation.ExpiresAt(); ok {
+		_spec.SetField(hold.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if value, ok := huo.mutation.AddedExpiresAt(); ok {
+		_spec.AddField(hold.FieldExpiresAt, field.TypeInt64, value)
+	}
+	if huo.mutation.ExpiresAtCleared() {
+		_spec.ClearField(hold.FieldExpiresAt, field.TypeInt64)
+	}
+	if huo.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.BookTable,
+			Columns: []string{hold.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := huo.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.BookTable,
+			Columns: []string{hold.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if huo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.UserTable,
+			Columns: []string{hold.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := huo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.UserTable,
+			Columns: []string{hold.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if huo.mutation.CopyCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.CopyTable,
+			Columns: []string{hold.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := huo.mutation.CopyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   hold.CopyTable,
+			Columns: []string{hold.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Hold{config: huo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, huo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{hold.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	huo.mutation.done = true
+	return _node, nil
+}
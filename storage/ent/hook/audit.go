@@ -0,0 +1,279 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/auditentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/author"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/intercept"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+)
+
+// Actor is the user who makes changes, see WithActor.
+type Actor struct {
+	ID    int64
+	Login string
+}
+
+type actorKey struct{}
+
+// WithActor returns the context changes made within are recorded
+// by Audit as made by the actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor of the context, false if there is none.
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+// auditedMutation is implemented by mutations of entities with int64 ids.
+type auditedMutation interface {
+	ent.Mutation
+	ID() (int64, bool)
+	IDs(ctx context.Context) ([]int64, error)
+	Client() *ent.Client
+}
+
+// Audit records mutations to the audit log: an entry for every changed
+// entity with differences of fields and edges and the actor from the context.
+// Entries are written by the client of the mutation, so they are a part
+// of its transaction, if any. Old values are loaded for Book and Author only.
+//
+//	client.Book.Use(hook.Audit())
+func Audit() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			am, ok := m.(auditedMutation)
+			if !ok {
+				return nil, fmt.Errorf("audit: unexpected mutation type %T", m)
+			}
+
+			var ids []int64
+			var before map[int64]map[string]any
+			if !m.Op().Is(ent.OpCreate) {
+				var errIDs error
+				if ids, errIDs = am.IDs(ctx); errIDs != nil {
+					return nil, errIDs
+				}
+				var errSnapshot error
+				if before, errSnapshot = snapshot(ctx, am, ids); errSnapshot != nil {
+					return nil, errSnapshot
+				}
+			}
+
+			value, err := next.Mutate(ctx, m)
+			if err != nil {
+				return value, err
+			}
+
+			op := auditentry.OpUpdate
+			switch {
+			case m.Op().Is(ent.OpCreate):
+				op = auditentry.OpCreate
+				if id, ok := am.ID(); ok {
+					ids = []int64{id}
+				}
+			case m.Op().Is(ent.OpDelete | ent.OpDeleteOne):
+				op = auditentry.OpDelete
+			}
+
+			entries := make([]*ent.AuditEntryCreate, 0, len(ids))
+			for _, id := range ids {
+				var changes []schema.FieldChange
+				if op == auditentry.OpDelete {
+					changes = deleted(before[id])
+				} else {
+					changes = diff(m, before[id])
+				}
+				if op == auditentry.OpUpdate && len(changes) == 0 {
+					continue
+				}
+
+				entry := am.Client().AuditEntry.Create().
+					SetOp(op).
+					SetEntity(m.Type()).
+					SetEntityID(id).
+					SetChanges(changes)
+				if actor, ok := ActorFrom(ctx); ok {
+					entry.SetActorID(actor.ID).SetActorLogin(actor.Login)
+				}
+				entries = append(entries, entry)
+			}
+			if len(entries) == 0 {
+				return value, nil
+			}
+
+			if errAudit := am.Client().AuditEntry.CreateBulk(entries...).Exec(ctx); errAudit != nil {
+				return nil, fmt.Errorf("audit: %w", errAudit)
+			}
+			return value, nil
+		})
+	}
+}
+
+// snapshot loads fields of the entities keyed by field names,
+// books have ids of their authors as authors. Books in the trash are loaded too.
+func snapshot(ctx context.Context, m auditedMutation, ids []int64) (map[int64]map[string]any, error) {
+	ctx = intercept.SkipSoftDelete(ctx)
+	var items []any
+	switch m.(type) {
+	case *ent.BookMutation:
+		found, err := m.Client().Book.Query().
+			Where(book.IDIn(ids...)).
+			WithAuthors(func(query *ent.AuthorQuery) {
+				query.Select(author.FieldID).Order(ent.Asc(author.FieldID))
+			}).
+			All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range found {
+			authors := make([]int64, 0, len(item.Edges.Authors))
+			for _, a := range item.Edges.Authors {
+				authors = append(authors, a.ID)
+			}
+			items = append(items, struct {
+				*ent.Book
+				Authors []int64 `json:"authors,omitempty"`
+			}{item, authors})
+		}
+	case *ent.AuthorMutation:
+		found, err := m.Client().Author.Query().Where(author.IDIn(ids...)).All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range found {
+			items = append(items, item)
+		}
+	}
+
+	fields := make(map[int64]map[string]any, len(items))
+	for _, item := range items {
+		// entities are encoded with field names, sensitive fields are skipped
+		values, err := jsonValue(item)
+		if err != nil {
+			return nil, err
+		}
+		object, _ := values.(map[string]any)
+		delete(object, "edges")
+		id, _ := object["id"].(float64)
+		delete(object, "id")
+		fields[int64(id)] = object
+	}
+	return fields, nil
+}
+
+// diff returns changes of fields and edges made by the mutation.
+func diff(m ent.Mutation, before map[string]any) []schema.FieldChange {
+	var changes []schema.FieldChange
+	for _, name := range m.Fields() {
+		value, _ := m.Field(name)
+		next, err := jsonValue(value)
+		if err != nil {
+			next = fmt.Sprint(value)
+		}
+		prev, ok := before[name]
+		if !ok && before != nil {
+			// zero values are omitted by the encoding
+			prev = zeroJSON(next)
+		}
+		if before != nil && equalJSON(prev, next) {
+			continue
+		}
+		changes = append(changes, schema.FieldChange{Field: name, Old: before[name], New: next})
+	}
+	for _, name := range m.ClearedFields() {
+		if prev, ok := before[name]; ok {
+			changes = append(changes, schema.FieldChange{Field: name, Old: prev})
+		}
+	}
+
+	edges := map[string]*schema.FieldChange{}
+	var order []string
+	edge := func(name string) *schema.FieldChange {
+		if change, ok := edges[name]; ok {
+			return change
+		}
+		order = append(order, name)
+		edges[name] = &schema.FieldChange{Field: name}
+		return edges[name]
+	}
+	for _, name := range m.AddedEdges() {
+		change := edge(name)
+		change.Added = append(change.Added, edgeIDs(m.AddedIDs(name))...)
+	}
+	for _, name := range m.RemovedEdges() {
+		change := edge(name)
+		change.Removed = append(change.Removed, edgeIDs(m.RemovedIDs(name))...)
+	}
+	for _, name := range order {
+		changes = append(changes, *edges[name])
+	}
+	return changes
+}
+
+// deleted returns the fields of the deleted entity as old values.
+func deleted(before map[string]any) []schema.FieldChange {
+	names := make([]string, 0, len(before))
+	for name := range before {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changes := make([]schema.FieldChange, 0, len(names))
+	for _, name := range names {
+		changes = append(changes, schema.FieldChange{Field: name, Old: before[name]})
+	}
+	return changes
+}
+
+func edgeIDs(values []ent.Value) []int64 {
+	ids := make([]int64, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(int64); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// jsonValue returns the value as it's stored in the changes column.
+func jsonValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// zeroJSON returns the zero value of the type of the decoded value.
+func zeroJSON(value any) any {
+	switch value.(type) {
+	case string:
+		return ""
+	case float64:
+		return float64(0)
+	case bool:
+		return false
+	}
+	return nil
+}
+
+func equalJSON(a, b any) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+	return errX == nil && errY == nil && bytes.Equal(x, y)
+}
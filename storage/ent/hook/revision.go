@@ -0,0 +1,110 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+)
+
+// Revisions stores a snapshot of every created or changed entity, so a bad
+// edit can be rolled back. The state before the first change of an entity
+// created without the hook is stored too. Snapshots are loaded for Book
+// and Author only, the trash state of books is not a part of them.
+//
+//	client.Book.Use(hook.Revisions())
+func Revisions() ent.Hook {
+	hook := func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			am, ok := m.(auditedMutation)
+			if !ok {
+				return nil, fmt.Errorf("revisions: unexpected mutation type %T", m)
+			}
+
+			var ids []int64
+			var before map[int64]map[string]any
+			if !m.Op().Is(ent.OpCreate) {
+				var errIDs error
+				if ids, errIDs = am.IDs(ctx); errIDs != nil {
+					return nil, errIDs
+				}
+				var errSnapshot error
+				if before, errSnapshot = snapshot(ctx, am, ids); errSnapshot != nil {
+					return nil, errSnapshot
+				}
+			}
+
+			value, err := next.Mutate(ctx, m)
+			if err != nil {
+				return value, err
+			}
+			if m.Op().Is(ent.OpCreate) {
+				if id, ok := am.ID(); ok {
+					ids = []int64{id}
+				}
+			}
+
+			after, errSnapshot := snapshot(ctx, am, ids)
+			if errSnapshot != nil {
+				return nil, fmt.Errorf("revisions: %w", errSnapshot)
+			}
+			for _, id := range ids {
+				data, ok := after[id]
+				if !ok {
+					continue
+				}
+				// moving to the trash doesn't change the metadata
+				delete(data, book.FieldDeletedAt)
+				if prev, ok := before[id]; ok {
+					delete(prev, book.FieldDeletedAt)
+				}
+				if err := storeRevision(ctx, am, id, before[id], data); err != nil {
+					return nil, fmt.Errorf("revisions: %w", err)
+				}
+			}
+			return value, nil
+		})
+	}
+	return On(hook, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne)
+}
+
+// storeRevision stores the snapshot unless it's the same as the latest one.
+func storeRevision(ctx context.Context, m auditedMutation, id int64, before, data map[string]any) error {
+	client := m.Client()
+
+	latest, errLatest := client.Revision.Query().
+		Where(revision.Entity(m.Type()), revision.EntityID(id)).
+		Order(ent.Desc(revision.FieldID)).
+		First(ctx)
+	switch {
+	case ent.IsNotFound(errLatest) && before != nil:
+		// the entity was created before revisions were kept
+		errBaseline := client.Revision.Create().
+			SetEntity(m.Type()).
+			SetEntityID(id).
+			SetData(before).
+			Exec(ctx)
+		if errBaseline != nil {
+			return errBaseline
+		}
+		if equalJSON(before, data) {
+			return nil
+		}
+	case ent.IsNotFound(errLatest):
+	case errLatest != nil:
+		return errLatest
+	case equalJSON(latest.Data, data):
+		return nil
+	}
+
+	create := client.Revision.Create().
+		SetEntity(m.Type()).
+		SetEntityID(id).
+		SetData(data)
+	if actor, ok := ActorFrom(ctx); ok {
+		create.SetActorID(actor.ID).SetActorLogin(actor.Login)
+	}
+	return create.Exec(ctx)
+}
@@ -0,0 +1,30 @@
+package intercept
+
+import (
+	"context"
+
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+)
+
+type skipSoftDeleteKey struct{}
+
+// SkipSoftDelete returns the context queries made within
+// see books in the trash, see SoftDelete.
+func SkipSoftDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipSoftDeleteKey{}, true)
+}
+
+// SoftDelete hides books with deleted_at set from queries, eager loads
+// and traversals, unless the context is made by SkipSoftDelete.
+//
+//	client.Book.Intercept(intercept.SoftDelete())
+func SoftDelete() ent.Interceptor {
+	return TraverseBook(func(ctx context.Context, q *ent.BookQuery) error {
+		if skip, _ := ctx.Value(skipSoftDeleteKey{}).(bool); skip {
+			return nil
+		}
+		q.Where(book.DeletedAtIsNil())
+		return nil
+	})
+}
@@ -0,0 +1,212 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Loan is the model entity for the Loan schema.
+type Loan struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// CheckedOutAt holds the value of the "checked_out_at" field.
+	CheckedOutAt int64 `json:"checked_out_at,omitempty"`
+	// DueAt holds the value of the "due_at" field.
+	DueAt int64 `json:"due_at,omitempty"`
+	// ReturnedAt holds the value of the "returned_at" field.
+	ReturnedAt *int64 `json:"returned_at,omitempty"`
+	// RemindedAt holds the value of the "reminded_at" field.
+	RemindedAt *int64 `json:"reminded_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the LoanQuery when eager-loading is set.
+	Edges         LoanEdges `json:"edges"`
+	loan_copy     *int64
+	loan_borrower *int64
+	selectValues  sql.SelectValues
+}
+
+// LoanEdges holds the relations/edges for other nodes in the graph.
+type LoanEdges struct {
+	// Copy holds the value of the copy edge.
+	Copy *BookCopy `json:"copy,omitempty"`
+	// Borrower holds the value of the borrower edge.
+	Borrower *User `json:"borrower,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// CopyOrErr returns the Copy value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e LoanEdges) CopyOrErr() (*BookCopy, error) {
+	if e.loadedTypes[0] {
+		if e.Copy == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: bookcopy.Label}
+		}
+		return e.Copy, nil
+	}
+	return nil, &NotLoadedError{edge: "copy"}
+}
+
+// BorrowerOrErr returns the Borrower value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e LoanEdges) BorrowerOrErr() (*User, error) {
+	if e.loadedTypes[1] {
+		if e.Borrower == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.Borrower, nil
+	}
+	return nil, &NotLoadedError{edge: "borrower"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Loan) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case loan.FieldID, loan.FieldCheckedOutAt, loan.FieldDueAt, loan.FieldReturnedAt, loan.FieldRemindedAt:
+			values[i] = new(sql.NullInt64)
+		case loan.ForeignKeys[0]: // loan_copy
+			values[i] = new(sql.NullInt64)
+		case loan.ForeignKeys[1]: // loan_borrower
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Loan fields.
+func (l *Loan) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case loan.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			l.ID = int64(value.Int64)
+		case loan.FieldCheckedOutAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field checked_out_at", values[i])
+			} else if value.Valid {
+				l.CheckedOutAt = value.Int64
+			}
+		case loan.FieldDueAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field due_at", values[i])
+			} else if value.Valid {
+				l.DueAt = value.Int64
+			}
+		case loan.FieldReturnedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field returned_at", values[i])
+			} else if value.Valid {
+				l.ReturnedAt = new(int64)
+				*l.ReturnedAt = value.Int64
+			}
+		case loan.FieldRemindedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field reminded_at", values[i])
+			} else if value.Valid {
+				l.RemindedAt = new(int64)
+				*l.RemindedAt = value.Int64
+			}
+		case loan.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field loan_copy", value)
+			} else if value.Valid {
+				l.loan_copy = new(int64)
+				*l.loan_copy = int64(value.Int64)
+			}
+		case loan.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field loan_borrower", value)
+			} else if value.Valid {
+				l.loan_borrower = new(int64)
+				*l.loan_borrower = int64(value.Int64)
+			}
+		default:
+			l.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Loan.
+// This includes values selected through modifiers, order, etc.
+func (l *Loan) Value(name string) (ent.Value, error) {
+	return l.selectValues.Get(name)
+}
+
+// QueryCopy queries the "copy" edge of the Loan entity.
+func (l *Loan) QueryCopy() *BookCopyQuery {
+	return NewLoanClient(l.config).QueryCopy(l)
+}
+
+// QueryBorrower queries the "borrower" edge of the Loan entity.
+func (l *Loan) QueryBorrower() *UserQuery {
+	return NewLoanClient(l.config).QueryBorrower(l)
+}
+
+// Update returns a builder for updating this Loan.
+// Note that you need to call Loan.Unwrap() before calling this method if this Loan
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (l *Loan) Update() *LoanUpdateOne {
+	return NewLoanClient(l.config).UpdateOne(l)
+}
+
+// Unwrap unwraps the Loan entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (l *Loan) Unwrap() *Loan {
+	_tx, ok := l.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Loan is not a transactional entity")
+	}
+	l.config.driver = _tx.drv
+	return l
+}
+
+// String implements the fmt.Stringer.
+func (l *Loan) String() string {
+	var builder strings.Builder
+	builder.WriteString("Loan(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", l.ID))
+	builder.WriteString("checked_out_at=")
+	builder.WriteString(fmt.Sprintf("%v", l.CheckedOutAt))
+	builder.WriteString(", ")
+	builder.WriteString("due_at=")
+	builder.WriteString(fmt.Sprintf("%v", l.DueAt))
+	builder.WriteString(", ")
+	if v := l.ReturnedAt; v != nil {
+		builder.WriteString("returned_at=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := l.RemindedAt; v != nil {
+		builder.WriteString("reminded_at=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Loans is a parsable slice of Loan.
+type Loans []*Loan
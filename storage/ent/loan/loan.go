@@ -0,0 +1,135 @@
+// Code generated by ent, DO NOT EDIT.
+
+package loan
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the loan type in the database.
+	Label = "loan"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldCheckedOutAt holds the string denoting the checked_out_at field in the database.
+	FieldCheckedOutAt = "checked_out_at"
+	// FieldDueAt holds the string denoting the due_at field in the database.
+	FieldDueAt = "due_at"
+	// FieldReturnedAt holds the string denoting the returned_at field in the database.
+	FieldReturnedAt = "returned_at"
+	// FieldRemindedAt holds the string denoting the reminded_at field in the database.
+	FieldRemindedAt = "reminded_at"
+	// EdgeCopy holds the string denoting the copy edge name in mutations.
+	EdgeCopy = "copy"
+	// EdgeBorrower holds the string denoting the borrower edge name in mutations.
+	EdgeBorrower = "borrower"
+	// Table holds the table name of the loan in the database.
+	Table = "loans"
+	// CopyTable is the table that holds the copy relation/edge.
+	CopyTable = "loans"
+	// CopyInverseTable is the table name for the BookCopy entity.
+	// It exists in this package in order to avoid circular dependency with the "bookcopy" package.
+	CopyInverseTable = "book_copies"
+	// CopyColumn is the table column denoting the copy relation/edge.
+	CopyColumn = "loan_copy"
+	// BorrowerTable is the table that holds the borrower relation/edge.
+	BorrowerTable = "loans"
+	// BorrowerInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	BorrowerInverseTable = "users"
+	// BorrowerColumn is the table column denoting the borrower relation/edge.
+	BorrowerColumn = "loan_borrower"
+)
+
+// Columns holds all SQL columns for loan fields.
+var Columns = []string{
+	FieldID,
+	FieldCheckedOutAt,
+	FieldDueAt,
+	FieldReturnedAt,
+	FieldRemindedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "loans"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"loan_copy",
+	"loan_borrower",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultCheckedOutAt holds the default value on creation for the "checked_out_at" field.
+	DefaultCheckedOutAt func() int64
+)
+
+// OrderOption defines the ordering options for the Loan queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByCheckedOutAt orders the results by the checked_out_at field.
+func ByCheckedOutAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCheckedOutAt, opts...).ToFunc()
+}
+
+// ByDueAt orders the results by the due_at field.
+func ByDueAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDueAt, opts...).ToFunc()
+}
+
+// ByReturnedAt orders the results by the returned_at field.
+func ByReturnedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldReturnedAt, opts...).ToFunc()
+}
+
+// ByRemindedAt orders the results by the reminded_at field.
+func ByRemindedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRemindedAt, opts...).ToFunc()
+}
+
+// ByCopyField orders the results by copy field.
+func ByCopyField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newCopyStep(), sql.OrderByField(field, opts...))
+	}
+}
+
+// ByBorrowerField orders the results by borrower field.
+func ByBorrowerField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newBorrowerStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newCopyStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(CopyInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, false, CopyTable, CopyColumn),
+	)
+}
+func newBorrowerStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(BorrowerInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, false, BorrowerTable, BorrowerColumn),
+	)
+}
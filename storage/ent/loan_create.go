@@ -0,0 +1,331 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// LoanCreate is the builder for creating a Loan entity.
+type LoanCreate struct {
+	config
+	mutation *LoanMutation
+	hooks    []Hook
+}
+
+// SetCheckedOutAt sets the "checked_out_at" field.
+func (lc *LoanCreate) SetCheckedOutAt(i int64) *LoanCreate {
+	lc.mutation.SetCheckedOutAt(i)
+	return lc
+}
+
+// SetNillableCheckedOutAt sets the "checked_out_at" field if the given value is not nil.
+func (lc *LoanCreate) SetNillableCheckedOutAt(i *int64) *LoanCreate {
+	if i != nil {
+		lc.SetCheckedOutAt(*i)
+	}
+	return lc
+}
+
+// SetDueAt sets the "due_at" field.
+func (lc *LoanCreate) SetDueAt(i int64) *LoanCreate {
+	lc.mutation.SetDueAt(i)
+	return lc
+}
+
+// SetReturnedAt sets the "returned_at" field.
+func (lc *LoanCreate) SetReturnedAt(i int64) *LoanCreate {
+	lc.mutation.SetReturnedAt(i)
+	return lc
+}
+
+// SetNillableReturnedAt sets the "returned_at" field if the given value is not nil.
+func (lc *LoanCreate) SetNillableReturnedAt(i *int64) *LoanCreate {
+	if i != nil {
+		lc.SetReturnedAt(*i)
+	}
+	return lc
+}
+
+// SetRemindedAt sets the "reminded_at" field.
+func (lc *LoanCreate) SetRemindedAt(i int64) *LoanCreate {
+	lc.mutation.SetRemindedAt(i)
+	return lc
+}
+
+// SetNillableRemindedAt sets the "reminded_at" field if the given value is not nil.
+func (lc *LoanCreate) SetNillableRemindedAt(i *int64) *LoanCreate {
+	if i != nil {
+		lc.SetRemindedAt(*i)
+	}
+	return lc
+}
+
+// SetID sets the "id" field.
+func (lc *LoanCreate) SetID(i int64) *LoanCreate {
+	lc.mutation.SetID(i)
+	return lc
+}
+
+// SetCopyID sets the "copy" edge to the BookCopy entity by ID.
+func (lc *LoanCreate) SetCopyID(id int64) *LoanCreate {
+	lc.mutation.SetCopyID(id)
+	return lc
+}
+
+// SetNillableCopyID sets the "copy" edge to the BookCopy entity by ID if the given value is not nil.
+func (lc *LoanCreate) SetNillableCopyID(id *int64) *LoanCreate {
+	if id != nil {
+		lc = lc.SetCopyID(*id)
+	}
+	return lc
+}
+
+// SetCopy sets the "copy" edge to the BookCopy entity.
+func (lc *LoanCreate) SetCopy(b *BookCopy) *LoanCreate {
+	return lc.SetCopyID(b.ID)
+}
+
+// SetBorrowerID sets the "borrower" edge to the User entity by ID.
+func (lc *LoanCreate) SetBorrowerID(id int64) *LoanCreate {
+	lc.mutation.SetBorrowerID(id)
+	return lc
+}
+
+// SetBorrower sets the "borrower" edge to the User entity.
+func (lc *LoanCreate) SetBorrower(u *User) *LoanCreate {
+	return lc.SetBorrowerID(u.ID)
+}
+
+// Mutation returns the LoanMutation object of the builder.
+func (lc *LoanCreate) Mutation() *LoanMutation {
+	return lc.mutation
+}
+
+// Save creates the Loan in the database.
+func (lc *LoanCreate) Save(ctx context.Context) (*Loan, error) {
+	lc.defaults()
+	return withHooks(ctx, lc.sqlSave, lc.mutation, lc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (lc *LoanCreate) SaveX(ctx context.Context) *Loan {
+	v, err := lc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (lc *LoanCreate) Exec(ctx context.Context) error {
+	_, err := lc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (lc *LoanCreate) ExecX(ctx context.Context) {
+	if err := lc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (lc *LoanCreate) defaults() {
+	if _, ok := lc.mutation.CheckedOutAt(); !ok {
+		v := loan.DefaultCheckedOutAt()
+		lc.mutation.SetCheckedOutAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (lc *LoanCreate) check() error {
+	if _, ok := lc.mutation.CheckedOutAt(); !ok {
+		return &ValidationError{Name: "checked_out_at", err: errors.New(`ent: missing required field "Loan.checked_out_at"`)}
+	}
+	if _, ok := lc.mutation.DueAt(); !ok {
+		return &ValidationError{Name: "due_at", err: errors.New(`ent: missing required field "Loan.due_at"`)}
+	}
+	if _, ok := lc.mutation.BorrowerID(); !ok {
+		return &ValidationError{Name: "borrower", err: errors.New(`ent: missing required edge "Loan.borrower"`)}
+	}
+	return nil
+}
+
+func (lc *LoanCreate) sqlSave(ctx context.Context) (*Loan, error) {
+	if err := lc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := lc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, lc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	lc.mutation.id = &_node.ID
+	lc.mutation.done = true
+	return _node, nil
+}
+
+func (lc *LoanCreate) createSpec() (*Loan, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Loan{config: lc.config}
+		_spec = sqlgraph.NewCreateSpec(loan.Table, sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64))
+	)
+	if id, ok := lc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := lc.mutation.CheckedOutAt(); ok {
+		_spec.SetField(loan.FieldCheckedOutAt, field.TypeInt64, value)
+		_node.CheckedOutAt = value
+	}
+	if value, ok := lc.mutation.DueAt(); ok {
+		_spec.SetField(loan.FieldDueAt, field.TypeInt64, value)
+		_node.DueAt = value
+	}
+	if value, ok := lc.mutation.ReturnedAt(); ok {
+		_spec.SetField(loan.FieldReturnedAt, field.TypeInt64, value)
+		_node.ReturnedAt = &value
+	}
+	if value, ok := lc.mutation.RemindedAt(); ok {
+		_spec.SetField(loan.FieldRemindedAt, field.TypeInt64, value)
+		_node.RemindedAt = &value
+	}
+	if nodes := lc.mutation.CopyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.CopyTable,
+			Columns: []string{loan.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.loan_copy = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := lc.mutation.BorrowerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.BorrowerTable,
+			Columns: []string{loan.BorrowerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.loan_borrower = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// LoanCreateBulk is the builder for creating many Loan entities in bulk.
+type LoanCreateBulk struct {
+	config
+	err      error
+	builders []*LoanCreate
+}
+
+// Save creates the Loan entities in the database.
+func (lcb *LoanCreateBulk) Save(ctx context.Context) ([]*Loan, error) {
+	if lcb.err != nil {
+		return nil, lcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(lcb.builders))
+	nodes := make([]*Loan, len(lcb.builders))
+	mutators := make([]Mutator, len(lcb.builders))
+	for i := range lcb.builders {
+		func(i int, root context.Context) {
+			builder := lcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*LoanMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, lcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, lcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, lcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (lcb *LoanCreateBulk) SaveX(ctx context.Context) []*Loan {
+	v, err := lcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (lcb *LoanCreateBulk) Exec(ctx context.Context) error {
+	_, err := lcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (lcb *LoanCreateBulk) ExecX(ctx context.Context) {
+	if err := lcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,581 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// LoanUpdate is the builder for updating Loan entities.
+type LoanUpdate struct {
+	config
+	hooks    []Hook
+	mutation *LoanMutation
+}
+
+// Where appends a list predicates to the LoanUpdate builder.
+func (lu *LoanUpdate) Where(ps ...predicate.Loan) *LoanUpdate {
+	lu.mutation.Where(ps...)
+	return lu
+}
+
+// SetDueAt sets the "due_at" field.
+func (lu *LoanUpdate) SetDueAt(i int64) *LoanUpdate {
+	lu.mutation.ResetDueAt()
+	lu.mutation.SetDueAt(i)
+	return lu
+}
+
+// AddDueAt adds i to the "due_at" field.
+func (lu *LoanUpdate) AddDueAt(i int64) *LoanUpdate {
+	lu.mutation.AddDueAt(i)
+	return lu
+}
+
+// SetReturnedAt sets the "returned_at" field.
+func (lu *LoanUpdate) SetReturnedAt(i int64) *LoanUpdate {
+	lu.mutation.ResetReturnedAt()
+	lu.mutation.SetReturnedAt(i)
+	return lu
+}
+
+// SetNillableReturnedAt sets the "returned_at" field if the given value is not nil.
+func (lu *LoanUpdate) SetNillableReturnedAt(i *int64) *LoanUpdate {
+	if i != nil {
+		lu.SetReturnedAt(*i)
+	}
+	return lu
+}
+
+// AddReturnedAt adds i to the "returned_at" field.
+func (lu *LoanUpdate) AddReturnedAt(i int64) *LoanUpdate {
+	lu.mutation.AddReturnedAt(i)
+	return lu
+}
+
+// ClearReturnedAt clears the value of the "returned_at" field.
+func (lu *LoanUpdate) ClearReturnedAt() *LoanUpdate {
+	lu.mutation.ClearReturnedAt()
+	return lu
+}
+
+// SetRemindedAt sets the "reminded_at" field.
+func (lu *LoanUpdate) SetRemindedAt(i int64) *LoanUpdate {
+	lu.mutation.ResetRemindedAt()
+	lu.mutation.SetRemindedAt(i)
+	return lu
+}
+
+// SetNillableRemindedAt sets the "reminded_at" field if the given value is not nil.
+func (lu *LoanUpdate) SetNillableRemindedAt(i *int64) *LoanUpdate {
+	if i != nil {
+		lu.SetRemindedAt(*i)
+	}
+	return lu
+}
+
+// AddRemindedAt adds i to the "reminded_at" field.
+func (lu *LoanUpdate) AddRemindedAt(i int64) *LoanUpdate {
+	lu.mutation.AddRemindedAt(i)
+	return lu
+}
+
+// ClearRemindedAt clears the value of the "reminded_at" field.
+func (lu *LoanUpdate) ClearRemindedAt() *LoanUpdate {
+	lu.mutation.ClearRemindedAt()
+	return lu
+}
+
+// SetCopyID sets the "copy" edge to the BookCopy entity by ID.
+func (lu *LoanUpdate) SetCopyID(id int64) *LoanUpdate {
+	lu.mutation.SetCopyID(id)
+	return lu
+}
+
+// SetNillableCopyID sets the "copy" edge to the BookCopy entity by ID if the given value is not nil.
+func (lu *LoanUpdate) SetNillableCopyID(id *int64) *LoanUpdate {
+	if id != nil {
+		lu = lu.SetCopyID(*id)
+	}
+	return lu
+}
+
+// SetCopy sets the "copy" edge to the BookCopy entity.
+func (lu *LoanUpdate) SetCopy(b *BookCopy) *LoanUpdate {
+	return lu.SetCopyID(b.ID)
+}
+
+// SetBorrowerID sets the "borrower" edge to the User entity by ID.
+func (lu *LoanUpdate) SetBorrowerID(id int64) *LoanUpdate {
+	lu.mutation.SetBorrowerID(id)
+	return lu
+}
+
+// SetBorrower sets the "borrower" edge to the User entity.
+func (lu *LoanUpdate) SetBorrower(u *User) *LoanUpdate {
+	return lu.SetBorrowerID(u.ID)
+}
+
+// Mutation returns the LoanMutation object of the builder.
+func (lu *LoanUpdate) Mutation() *LoanMutation {
+	return lu.mutation
+}
+
+// ClearCopy clears the "copy" edge to the BookCopy entity.
+func (lu *LoanUpdate) ClearCopy() *LoanUpdate {
+	lu.mutation.ClearCopy()
+	return lu
+}
+
+// ClearBorrower clears the "borrower" edge to the User entity.
+func (lu *LoanUpdate) ClearBorrower() *LoanUpdate {
+	lu.mutation.ClearBorrower()
+	return lu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (lu *LoanUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, lu.sqlSave, lu.mutation, lu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (lu *LoanUpdate) SaveX(ctx context.Context) int {
+	affected, err := lu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (lu *LoanUpdate) Exec(ctx context.Context) error {
+	_, err := lu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (lu *LoanUpdate) ExecX(ctx context.Context) {
+	if err := lu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (lu *LoanUpdate) check() error {
+	if _, ok := lu.mutation.BorrowerID(); lu.mutation.BorrowerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Loan.borrower"`)
+	}
+	return nil
+}
+
+func (lu *LoanUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := lu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loan.Table, loan.Columns, sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64))
+	if ps := lu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := lu.mutation.DueAt(); ok {
+		_spec.SetField(loan.FieldDueAt, field.TypeInt64, value)
+	}
+	if value, ok := lu.mutation.AddedDueAt(); ok {
+		_spec.AddField(loan.FieldDueAt, field.TypeInt64, value)
+	}
+	if value, ok := lu.mutation.ReturnedAt(); ok {
+		_spec.SetField(loan.FieldReturnedAt, field.TypeInt64, value)
+	}
+	if value, ok := lu.mutation.AddedReturnedAt(); ok {
+		_spec.AddField(loan.FieldReturnedAt, field.TypeInt64, value)
+	}
+	if lu.mutation.ReturnedAtCleared() {
+		_spec.ClearField(loan.FieldReturnedAt, field.TypeInt64)
+	}
+	if value, ok := lu.mutation.RemindedAt(); ok {
+		_spec.SetField(loan.FieldRemindedAt, field.TypeInt64, value)
+	}
+	if value, ok := lu.mutation.AddedRemindedAt(); ok {
+		_spec.AddField(loan.FieldRemindedAt, field.TypeInt64, value)
+	}
+	if lu.mutation.RemindedAtCleared() {
+		_spec.ClearField(loan.FieldRemindedAt, field.TypeInt64)
+	}
+	if lu.mutation.CopyCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.CopyTable,
+			Columns: []string{loan.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := lu.mutation.CopyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.CopyTable,
+			Columns: []string{loan.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if lu.mutation.BorrowerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.BorrowerTable,
+			Columns: []string{loan.BorrowerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := lu.mutation.BorrowerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.BorrowerTable,
+			Columns: []string{loan.BorrowerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, lu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loan.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	lu.mutation.done = true
+	return n, nil
+}
+
+// LoanUpdateOne is the builder for updating a single Loan entity.
+type LoanUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *LoanMutation
+}
+
+// SetDueAt sets the "due_at" field.
+func (luo *LoanUpdateOne) SetDueAt(i int64) *LoanUpdateOne {
+	luo.mutation.ResetDueAt()
+	luo.mutation.SetDueAt(i)
+	return luo
+}
+
+// AddDueAt adds i to the "due_at" field.
+func (luo *LoanUpdateOne) AddDueAt(i int64) *LoanUpdateOne {
+	luo.mutation.AddDueAt(i)
+	return luo
+}
+
+// SetReturnedAt sets the "returned_at" field.
+func (luo *LoanUpdateOne) SetReturnedAt(i int64) *LoanUpdateOne {
+	luo.mutation.ResetReturnedAt()
+	luo.mutation.SetReturnedAt(i)
+	return luo
+}
+
+// SetNillableReturnedAt sets the "returned_at" field if the given value is not nil.
+func (luo *LoanUpdateOne) SetNillableReturnedAt(i *int64) *LoanUpdateOne {
+	if i != nil {
+		luo.SetReturnedAt(*i)
+	}
+	return luo
+}
+
+// AddReturnedAt adds i to the "returned_at" field.
+func (luo *LoanUpdateOne) AddReturnedAt(i int64) *LoanUpdateOne {
+	luo.mutation.AddReturnedAt(i)
+	return luo
+}
+
+// ClearReturnedAt clears the value of the "returned_at" field.
+func (luo *LoanUpdateOne) ClearReturnedAt() *LoanUpdateOne {
+	luo.mutation.ClearReturnedAt()
+	return luo
+}
+
+// SetRemindedAt sets the "reminded_at" field.
+func (luo *LoanUpdateOne) SetRemindedAt(i int64) *LoanUpdateOne {
+	luo.mutation.ResetRemindedAt()
+	luo.mutation.SetRemindedAt(i)
+	return luo
+}
+
+// SetNillableRemindedAt sets the "reminded_at" field if the given value is not nil.
+func (luo *LoanUpdateOne) SetNillableRemindedAt(i *int64) *LoanUpdateOne {
+	if i != nil {
+		luo.SetRemindedAt(*i)
+	}
+	return luo
+}
+
+// AddRemindedAt adds i to the "reminded_at" field.
+func (luo *LoanUpdateOne) AddRemindedAt(i int64) *LoanUpdateOne {
+	luo.mutation.AddRemindedAt(i)
+	return luo
+}
+
+// ClearRemindedAt clears the value of the "reminded_at" field.
+func (luo *LoanUpdateOne) ClearRemindedAt() *LoanUpdateOne {
+	luo.mutation.ClearRemindedAt()
+	return luo
+}
+
+// SetCopyID sets the "copy" edge to the BookCopy entity by ID.
+func (luo *LoanUpdateOne) SetCopyID(id int64) *LoanUpdateOne {
+	luo.mutation.SetCopyID(id)
+	return luo
+}
+
+// SetNillableCopyID sets the "copy" edge to the BookCopy entity by ID if the given value is not nil.
+func (luo *LoanUpdateOne) SetNillableCopyID(id *int64) *LoanUpdateOne {
+	if id != nil {
+		luo = luo.SetCopyID(*id)
+	}
+	return luo
+}
+
+// SetCopy sets the "copy" edge to the BookCopy entity.
+func (luo *LoanUpdateOne) SetCopy(b *BookCopy) *LoanUpdateOne {
+	return luo.SetCopyID(b.ID)
+}
+
+// SetBorrowerID sets the "borrower" edge to the User entity by ID.
+func (luo *LoanUpdateOne) SetBorrowerID(id int64) *LoanUpdateOne {
+	luo.mutation.SetBorrowerID(id)
+	return luo
+}
+
+// SetBorrower sets the "borrower" edge to the User entity.
+func (luo *LoanUpdateOne) SetBorrower(u *User) *LoanUpdateOne {
+	return luo.SetBorrowerID(u.ID)
+}
+
+// Mutation returns the LoanMutation object of the builder.
+func (luo *LoanUpdateOne) Mutation() *LoanMutation {
+	return luo.mutation
+}
+
+// ClearCopy clears the "copy" edge to the BookCopy entity.
+func (luo *LoanUpdateOne) ClearCopy() *LoanUpdateOne {
+	luo.mutation.ClearCopy()
+	return luo
+}
+
+// ClearBorrower clears the "borrower" edge to the User entity.
+func (luo *LoanUpdateOne) ClearBorrower() *LoanUpdateOne {
+	luo.mutation.ClearBorrower()
+	return luo
+}
+
+// Where appends a list predicates to the LoanUpdate builder.
+func (luo *LoanUpdateOne) Where(ps ...predicate.Loan) *LoanUpdateOne {
+	luo.mutation.Where(ps...)
+	return luo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (luo *LoanUpdateOne) Select(field string, fields ...string) *LoanUpdateOne {
+	luo.fields = append([]string{field}, fields...)
+	return luo
+}
+
+// Save executes the query and returns the updated Loan entity.
+func (luo *LoanUpdateOne) Save(ctx context.Context) (*Loan, error) {
+	return withHooks(ctx, luo.sqlSave, luo.mutation, luo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (luo *LoanUpdateOne) SaveX(ctx context.Context) *Loan {
+	node, err := luo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (luo *LoanUpdateOne) Exec(ctx context.Context) error {
+	_, err := luo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (luo *LoanUpdateOne) ExecX(ctx context.Context) {
+	if err := luo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (luo *LoanUpdateOne) check() error {
+	if _, ok := luo.mutation.BorrowerID(); luo.mutation.BorrowerCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Loan.borrower"`)
+	}
+	return nil
+}
+
+func (luo *LoanUpdateOne) sqlSave(ctx context.Context) (_node *Loan, err error) {
+	if err := luo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(loan.Table, loan.Columns, sqlgraph.NewFieldSpec(loan.FieldID, field.TypeInt64))
+	id, ok := luo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Loan.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := luo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, loan.FieldID)
+		for _, f := range fields {
+			if !loan.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != loan.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := luo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := luo.mutation.DueAt(); ok {
+		_spec.SetField(loan.FieldDueAt, field.TypeInt64, value)
+	}
+	if value, ok := luo.mutation.AddedDueAt(); ok {
+		_spec.AddField(loan.FieldDueAt, field.TypeInt64, value)
+	}
+	if value, ok := luo.mutation.ReturnedAt(); ok {
+		_spec.SetField(loan.FieldReturnedAt, field.TypeInt64, value)
+	}
+	if value, ok := luo.mutation.AddedReturnedAt(); ok {
+		_spec.AddField(loan.FieldReturnedAt, field.TypeInt64, value)
+	}
+	if luo.mutation.ReturnedAtCleared() {
+		_spec.ClearField(loan.FieldReturnedAt, field.TypeInt64)
+	}
+	if value, ok := luo.mutation.RemindedAt(); ok {
+		_spec.SetField(loan.FieldRemindedAt, field.TypeInt64, value)
+	}
+	if value, ok := luo.mutation.AddedRemindedAt(); ok {
+		_spec.AddField(loan.FieldRemindedAt, field.TypeInt64, value)
+	}
+	if luo.mutation.RemindedAtCleared() {
+		_spec.ClearField(loan.FieldRemindedAt, field.TypeInt64)
+	}
+	if luo.mutation.CopyCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.CopyTable,
+			Columns: []string{loan.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := luo.mutation.CopyIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.CopyTable,
+			Columns: []string{loan.CopyColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(bookcopy.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if luo.mutation.BorrowerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.BorrowerTable,
+			Columns: []string{loan.BorrowerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := luo.mutation.BorrowerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   loan.BorrowerTable,
+			Columns: []string{loan.BorrowerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Loan{config: luo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, luo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{loan.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	luo.mutation.done = true
+	return _node, nil
+}
@@ -3,11 +3,73 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// APITokensColumns holds the columns for the "api_tokens" table.
+	APITokensColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "scopes", Type: field.TypeJSON},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "last_used_at", Type: field.TypeInt64, Nullable: true},
+		{Name: "api_token_user", Type: field.TypeInt64},
+	}
+	// APITokensTable holds the schema information for the "api_tokens" table.
+	APITokensTable = &schema.Table{
+		Name:       "api_tokens",
+		Columns:    APITokensColumns,
+		PrimaryKey: []*schema.Column{APITokensColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "api_tokens_users_user",
+				Columns:    []*schema.Column{APITokensColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// AuditEntriesColumns holds the columns for the "audit_entries" table.
+	AuditEntriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "op", Type: field.TypeEnum, Enums: []string{"create", "update", "delete"}},
+		{Name: "entity", Type: field.TypeString},
+		{Name: "entity_id", Type: field.TypeInt64},
+		{Name: "changes", Type: field.TypeJSON, Nullable: true},
+		{Name: "actor_login", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "audit_entry_actor", Type: field.TypeInt64, Nullable: true},
+	}
+	// AuditEntriesTable holds the schema information for the "audit_entries" table.
+	AuditEntriesTable = &schema.Table{
+		Name:       "audit_entries",
+		Columns:    AuditEntriesColumns,
+		PrimaryKey: []*schema.Column{AuditEntriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "audit_entries_users_actor",
+				Columns:    []*schema.Column{AuditEntriesColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditentry_entity_entity_id",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEntriesColumns[2], AuditEntriesColumns[3]},
+			},
+			{
+				Name:    "auditentry_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{AuditEntriesColumns[6]},
+			},
+		},
+	}
 	// AuthorsColumns holds the columns for the "authors" table.
 	AuthorsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
@@ -24,15 +86,557 @@ var (
 	BooksColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt64, Increment: true},
 		{Name: "title", Type: field.TypeString},
-		{Name: "written_at", Type: field.TypeInt64},
+		{Name: "written_at", Type: field.TypeInt64, Default: 0},
 		{Name: "cover_id", Type: field.TypeString, Nullable: true},
 		{Name: "file_id", Type: field.TypeString, Nullable: true},
+		{Name: "pages", Type: field.TypeInt64, Nullable: true},
+		{Name: "deleted_at", Type: field.TypeInt64, Nullable: true},
 	}
 	// BooksTable holds the schema information for the "books" table.
 	BooksTable = &schema.Table{
 		Name:       "books",
 		Columns:    BooksColumns,
 		PrimaryKey: []*schema.Column{BooksColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "book_deleted_at",
+				Unique:  false,
+				Columns: []*schema.Column{BooksColumns[6]},
+			},
+		},
+	}
+	// BookCopiesColumns holds the columns for the "book_copies" table.
+	BookCopiesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "barcode", Type: field.TypeString, Unique: true},
+		{Name: "location", Type: field.TypeString, Nullable: true},
+		{Name: "condition", Type: field.TypeEnum, Enums: []string{"new", "good", "worn", "damaged"}, Default: "good"},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "book_copy_book", Type: field.TypeInt64},
+	}
+	// BookCopiesTable holds the schema information for the "book_copies" table.
+	BookCopiesTable = &schema.Table{
+		Name:       "book_copies",
+		Columns:    BookCopiesColumns,
+		PrimaryKey: []*schema.Column{BookCopiesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "book_copies_books_book",
+				Columns:    []*schema.Column{BookCopiesColumns[5]},
+				RefColumns: []*schema.Column{BooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// CheckpointsColumns holds the columns for the "checkpoints" table.
+	CheckpointsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "name", Type: field.TypeString, Unique: true},
+		{Name: "value", Type: field.TypeString},
+		{Name: "updated_at", Type: field.TypeInt64},
+	}
+	// CheckpointsTable holds the schema information for the "checkpoints" table.
+	CheckpointsTable = &schema.Table{
+		Name:       "checkpoints",
+		Columns:    CheckpointsColumns,
+		PrimaryKey: []*schema.Column{CheckpointsColumns[0]},
+	}
+	// DeliveriesColumns holds the columns for the "deliveries" table.
+	DeliveriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "to", Type: field.TypeString},
+		{Name: "format", Type: field.TypeString},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "delivery_user", Type: field.TypeInt64},
+		{Name: "delivery_book", Type: field.TypeInt64},
+		{Name: "delivery_device", Type: field.TypeInt64, Nullable: true},
+		{Name: "outbox_message_delivery", Type: field.TypeInt64, Unique: true, Nullable: true},
+	}
+	// DeliveriesTable holds the schema information for the "deliveries" table.
+	DeliveriesTable = &schema.Table{
+		Name:       "deliveries",
+		Columns:    DeliveriesColumns,
+		PrimaryKey: []*schema.Column{DeliveriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "deliveries_users_user",
+				Columns:    []*schema.Column{DeliveriesColumns[5]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "deliveries_books_book",
+				Columns:    []*schema.Column{DeliveriesColumns[6]},
+				RefColumns: []*schema.Column{BooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "deliveries_devices_device",
+				Columns:    []*schema.Column{DeliveriesColumns[7]},
+				RefColumns: []*schema.Column{DevicesColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+			{
+				Symbol:     "deliveries_outbox_messages_delivery",
+				Columns:    []*schema.Column{DeliveriesColumns[8]},
+				RefColumns: []*schema.Column{OutboxMessagesColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+	}
+	// DevicesColumns holds the columns for the "devices" table.
+	DevicesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "email", Type: field.TypeString},
+		{Name: "kind", Type: field.TypeEnum, Enums: []string{"kindle", "other"}, Default: "kindle"},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "device_user", Type: field.TypeInt64},
+	}
+	// DevicesTable holds the schema information for the "devices" table.
+	DevicesTable = &schema.Table{
+		Name:       "devices",
+		Columns:    DevicesColumns,
+		PrimaryKey: []*schema.Column{DevicesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "devices_users_user",
+				Columns:    []*schema.Column{DevicesColumns[5]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "device_email_device_user",
+				Unique:  true,
+				Columns: []*schema.Column{DevicesColumns[2], DevicesColumns[5]},
+			},
+		},
+	}
+	// DocumentsColumns holds the columns for the "documents" table.
+	DocumentsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "hash", Type: field.TypeString, Unique: true},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "document_book", Type: field.TypeInt64},
+	}
+	// DocumentsTable holds the schema information for the "documents" table.
+	DocumentsTable = &schema.Table{
+		Name:       "documents",
+		Columns:    DocumentsColumns,
+		PrimaryKey: []*schema.Column{DocumentsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "documents_books_book",
+				Columns:    []*schema.Column{DocumentsColumns[3]},
+				RefColumns: []*schema.Column{BooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// HighlightsColumns holds the columns for the "highlights" table.
+	HighlightsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "anchor", Type: field.TypeString},
+		{Name: "start", Type: field.TypeInt},
+		{Name: "end", Type: field.TypeInt},
+		{Name: "text", Type: field.TypeString, Size: 2147483647},
+		{Name: "chapter", Type: field.TypeString, Nullable: true},
+		{Name: "note", Type: field.TypeString, Nullable: true, Size: 10000},
+		{Name: "color", Type: field.TypeEnum, Enums: []string{"yellow", "green", "blue", "pink"}, Default: "yellow"},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "updated_at", Type: field.TypeInt64},
+		{Name: "highlight_book", Type: field.TypeInt64},
+		{Name: "highlight_user", Type: field.TypeInt64},
+	}
+	// HighlightsTable holds the schema information for the "highlights" table.
+	HighlightsTable = &schema.Table{
+		Name:       "highlights",
+		Columns:    HighlightsColumns,
+		PrimaryKey: []*schema.Column{HighlightsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "highlights_books_book",
+				Columns:    []*schema.Column{HighlightsColumns[10]},
+				RefColumns: []*schema.Column{BooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "highlights_users_user",
+				Columns:    []*schema.Column{HighlightsColumns[11]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "highlight_highlight_book_highlight_user",
+				Unique:  false,
+				Columns: []*schema.Column{HighlightsColumns[10], HighlightsColumns[11]},
+			},
+		},
+	}
+	// HoldsColumns holds the columns for the "holds" table.
+	HoldsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"waiting", "ready", "fulfilled", "expired", "canceled"}, Default: "waiting"},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "ready_at", Type: field.TypeInt64, Nullable: true},
+		{Name: "expires_at", Type: field.TypeInt64, Nullable: true},
+		{Name: "hold_book", Type: field.TypeInt64},
+		{Name: "hold_user", Type: field.TypeInt64},
+		{Name: "hold_copy", Type: field.TypeInt64, Nullable: true},
+	}
+	// HoldsTable holds the schema information for the "holds" table.
+	HoldsTable = &schema.Table{
+		Name:       "holds",
+		Columns:    HoldsColumns,
+		PrimaryKey: []*schema.Column{HoldsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "holds_books_book",
+				Columns:    []*schema.Column{HoldsColumns[5]},
+				RefColumns: []*schema.Column{BooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "holds_users_user",
+				Columns:    []*schema.Column{HoldsColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "holds_book_copies_copy",
+				Columns:    []*schema.Column{HoldsColumns[7]},
+				RefColumns: []*schema.Column{BookCopiesColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "hold_hold_book_hold_user",
+				Unique:  true,
+				Columns: []*schema.Column{HoldsColumns[5], HoldsColumns[6]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "status IN ('waiting', 'ready')",
+				},
+			},
+			{
+				Name:    "hold_hold_copy",
+				Unique:  true,
+				Columns: []*schema.Column{HoldsColumns[7]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "status = 'ready'",
+				},
+			},
+		},
+	}
+	// LoansColumns holds the columns for the "loans" table.
+	LoansColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "checked_out_at", Type: field.TypeInt64},
+		{Name: "due_at", Type: field.TypeInt64},
+		{Name: "returned_at", Type: field.TypeInt64, Nullable: true},
+		{Name: "reminded_at", Type: field.TypeInt64, Nullable: true},
+		{Name: "loan_copy", Type: field.TypeInt64, Nullable: true},
+		{Name: "loan_borrower", Type: field.TypeInt64},
+	}
+	// LoansTable holds the schema information for the "loans" table.
+	LoansTable = &schema.Table{
+		Name:       "loans",
+		Columns:    LoansColumns,
+		PrimaryKey: []*schema.Column{LoansColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "loans_book_copies_copy",
+				Columns:    []*schema.Column{LoansColumns[5]},
+				RefColumns: []*schema.Column{BookCopiesColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+			{
+				Symbol:     "loans_users_borrower",
+				Columns:    []*schema.Column{LoansColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "loan_loan_copy",
+				Unique:  true,
+				Columns: []*schema.Column{LoansColumns[5]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "returned_at IS NULL",
+				},
+			},
+		},
+	}
+	// OutboxMessagesColumns holds the columns for the "outbox_messages" table.
+	OutboxMessagesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "kind", Type: field.TypeString},
+		{Name: "to", Type: field.TypeString},
+		{Name: "subject", Type: field.TypeString},
+		{Name: "body", Type: field.TypeString, Size: 2147483647},
+		{Name: "attachment", Type: field.TypeString, Nullable: true},
+		{Name: "attachment_name", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "next_attempt_at", Type: field.TypeInt64},
+		{Name: "sent_at", Type: field.TypeInt64, Nullable: true},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+	}
+	// OutboxMessagesTable holds the schema information for the "outbox_messages" table.
+	OutboxMessagesTable = &schema.Table{
+		Name:       "outbox_messages",
+		Columns:    OutboxMessagesColumns,
+		PrimaryKey: []*schema.Column{OutboxMessagesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "outboxmessage_sent_at_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{OutboxMessagesColumns[10], OutboxMessagesColumns[9]},
+			},
+		},
+	}
+	// ReadingProgressesColumns holds the columns for the "reading_progresses" table.
+	ReadingProgressesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "document", Type: field.TypeString},
+		{Name: "progress", Type: field.TypeString},
+		{Name: "percentage", Type: field.TypeFloat64},
+		{Name: "device", Type: field.TypeString},
+		{Name: "device_id", Type: field.TypeString},
+		{Name: "updated_at", Type: field.TypeInt64},
+		{Name: "reading_progress_user", Type: field.TypeInt64},
+	}
+	// ReadingProgressesTable holds the schema information for the "reading_progresses" table.
+	ReadingProgressesTable = &schema.Table{
+		Name:       "reading_progresses",
+		Columns:    ReadingProgressesColumns,
+		PrimaryKey: []*schema.Column{ReadingProgressesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "reading_progresses_users_user",
+				Columns:    []*schema.Column{ReadingProgressesColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "readingprogress_document_reading_progress_user",
+				Unique:  true,
+				Columns: []*schema.Column{ReadingProgressesColumns[1], ReadingProgressesColumns[7]},
+			},
+		},
+	}
+	// ReviewsColumns holds the columns for the "reviews" table.
+	ReviewsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "rating", Type: field.TypeInt},
+		{Name: "text", Type: field.TypeString, Nullable: true, Size: 10000},
+		{Name: "hidden", Type: field.TypeBool, Default: false},
+		{Name: "hidden_at", Type: field.TypeInt64, Nullable: true},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "updated_at", Type: field.TypeInt64},
+		{Name: "review_book", Type: field.TypeInt64},
+		{Name: "review_user", Type: field.TypeInt64},
+		{Name: "review_hidden_by", Type: field.TypeInt64, Nullable: true},
+	}
+	// ReviewsTable holds the schema information for the "reviews" table.
+	ReviewsTable = &schema.Table{
+		Name:       "reviews",
+		Columns:    ReviewsColumns,
+		PrimaryKey: []*schema.Column{ReviewsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "reviews_books_book",
+				Columns:    []*schema.Column{ReviewsColumns[7]},
+				RefColumns: []*schema.Column{BooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "reviews_users_user",
+				Columns:    []*schema.Column{ReviewsColumns[8]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "reviews_users_hidden_by",
+				Columns:    []*schema.Column{ReviewsColumns[9]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "review_review_book_review_user",
+				Unique:  true,
+				Columns: []*schema.Column{ReviewsColumns[7], ReviewsColumns[8]},
+			},
+		},
+	}
+	// RevisionsColumns holds the columns for the "revisions" table.
+	RevisionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "entity", Type: field.TypeString},
+		{Name: "entity_id", Type: field.TypeInt64},
+		{Name: "data", Type: field.TypeJSON},
+		{Name: "actor_login", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "revision_actor", Type: field.TypeInt64, Nullable: true},
+	}
+	// RevisionsTable holds the schema information for the "revisions" table.
+	RevisionsTable = &schema.Table{
+		Name:       "revisions",
+		Columns:    RevisionsColumns,
+		PrimaryKey: []*schema.Column{RevisionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "revisions_users_actor",
+				Columns:    []*schema.Column{RevisionsColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "revision_entity_entity_id",
+				Unique:  false,
+				Columns: []*schema.Column{RevisionsColumns[1], RevisionsColumns[2]},
+			},
+		},
+	}
+	// SessionsColumns holds the columns for the "sessions" table.
+	SessionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "expires_at", Type: field.TypeInt64},
+		{Name: "session_user", Type: field.TypeInt64},
+	}
+	// SessionsTable holds the schema information for the "sessions" table.
+	SessionsTable = &schema.Table{
+		Name:       "sessions",
+		Columns:    SessionsColumns,
+		PrimaryKey: []*schema.Column{SessionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "sessions_users_user",
+				Columns:    []*schema.Column{SessionsColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// ShelvesColumns holds the columns for the "shelves" table.
+	ShelvesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "kind", Type: field.TypeEnum, Enums: []string{"want_to_read", "reading", "finished", "custom"}, Default: "custom"},
+		{Name: "created_at", Type: field.TypeInt64},
+		{Name: "shelf_user", Type: field.TypeInt64},
+	}
+	// ShelvesTable holds the schema information for the "shelves" table.
+	ShelvesTable = &schema.Table{
+		Name:       "shelves",
+		Columns:    ShelvesColumns,
+		PrimaryKey: []*schema.Column{ShelvesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "shelves_users_user",
+				Columns:    []*schema.Column{ShelvesColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "shelf_name_shelf_user",
+				Unique:  true,
+				Columns: []*schema.Column{ShelvesColumns[1], ShelvesColumns[4]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "kind = 'custom'",
+				},
+			},
+			{
+				Name:    "shelf_kind_shelf_user",
+				Unique:  true,
+				Columns: []*schema.Column{ShelvesColumns[2], ShelvesColumns[4]},
+				Annotation: &entsql.IndexAnnotation{
+					Where: "kind <> 'custom'",
+				},
+			},
+		},
+	}
+	// ShelfEntriesColumns holds the columns for the "shelf_entries" table.
+	ShelfEntriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "added_at", Type: field.TypeInt64},
+		{Name: "shelf_entry_shelf", Type: field.TypeInt64},
+		{Name: "shelf_entry_book", Type: field.TypeInt64},
+	}
+	// ShelfEntriesTable holds the schema information for the "shelf_entries" table.
+	ShelfEntriesTable = &schema.Table{
+		Name:       "shelf_entries",
+		Columns:    ShelfEntriesColumns,
+		PrimaryKey: []*schema.Column{ShelfEntriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "shelf_entries_shelves_shelf",
+				Columns:    []*schema.Column{ShelfEntriesColumns[2]},
+				RefColumns: []*schema.Column{ShelvesColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "shelf_entries_books_book",
+				Columns:    []*schema.Column{ShelfEntriesColumns[3]},
+				RefColumns: []*schema.Column{BooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "shelfentry_shelf_entry_shelf_shelf_entry_book",
+				Unique:  true,
+				Columns: []*schema.Column{ShelfEntriesColumns[2], ShelfEntriesColumns[3]},
+			},
+		},
+	}
+	// UsersColumns holds the columns for the "users" table.
+	UsersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "login", Type: field.TypeString, Unique: true},
+		{Name: "password_hash", Type: field.TypeString},
+		{Name: "role", Type: field.TypeEnum, Enums: []string{"reader", "librarian", "admin"}, Default: "reader"},
+		{Name: "email", Type: field.TypeString, Nullable: true},
+		{Name: "notify_due", Type: field.TypeBool, Default: true},
+		{Name: "notify_holds", Type: field.TypeBool, Default: true},
+		{Name: "notify_new_books", Type: field.TypeBool, Default: true},
+		{Name: "sync_key_hash", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeInt64},
+	}
+	// UsersTable holds the schema information for the "users" table.
+	UsersTable = &schema.Table{
+		Name:       "users",
+		Columns:    UsersColumns,
+		PrimaryKey: []*schema.Column{UsersColumns[0]},
+	}
+	// ValidationsColumns holds the columns for the "validations" table.
+	ValidationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt64, Increment: true},
+		{Name: "file_id", Type: field.TypeString, Unique: true},
+		{Name: "problems", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeInt64},
+	}
+	// ValidationsTable holds the schema information for the "validations" table.
+	ValidationsTable = &schema.Table{
+		Name:       "validations",
+		Columns:    ValidationsColumns,
+		PrimaryKey: []*schema.Column{ValidationsColumns[0]},
 	}
 	// BookAuthorsColumns holds the columns for the "book_authors" table.
 	BookAuthorsColumns = []*schema.Column{
@@ -59,15 +663,87 @@ var (
 			},
 		},
 	}
+	// UserFollowedAuthorsColumns holds the columns for the "user_followed_authors" table.
+	UserFollowedAuthorsColumns = []*schema.Column{
+		{Name: "user_id", Type: field.TypeInt64},
+		{Name: "author_id", Type: field.TypeInt64},
+	}
+	// UserFollowedAuthorsTable holds the schema information for the "user_followed_authors" table.
+	UserFollowedAuthorsTable = &schema.Table{
+		Name:       "user_followed_authors",
+		Columns:    UserFollowedAuthorsColumns,
+		PrimaryKey: []*schema.Column{UserFollowedAuthorsColumns[0], UserFollowedAuthorsColumns[1]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_followed_authors_user_id",
+				Columns:    []*schema.Column{UserFollowedAuthorsColumns[0]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+			{
+				Symbol:     "user_followed_authors_author_id",
+				Columns:    []*schema.Column{UserFollowedAuthorsColumns[1]},
+				RefColumns: []*schema.Column{AuthorsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		APITokensTable,
+		AuditEntriesTable,
 		AuthorsTable,
 		BooksTable,
+		BookCopiesTable,
+		CheckpointsTable,
+		DeliveriesTable,
+		DevicesTable,
+		DocumentsTable,
+		HighlightsTable,
+		HoldsTable,
+		LoansTable,
+		OutboxMessagesTable,
+		ReadingProgressesTable,
+		ReviewsTable,
+		RevisionsTable,
+		SessionsTable,
+		ShelvesTable,
+		ShelfEntriesTable,
+		UsersTable,
+		ValidationsTable,
 		BookAuthorsTable,
+		UserFollowedAuthorsTable,
 	}
 )
 
 func init() {
+	APITokensTable.ForeignKeys[0].RefTable = UsersTable
+	AuditEntriesTable.ForeignKeys[0].RefTable = UsersTable
+	BookCopiesTable.ForeignKeys[0].RefTable = BooksTable
+	DeliveriesTable.ForeignKeys[0].RefTable = UsersTable
+	DeliveriesTable.ForeignKeys[1].RefTable = BooksTable
+	DeliveriesTable.ForeignKeys[2].RefTable = DevicesTable
+	DeliveriesTable.ForeignKeys[3].RefTable = OutboxMessagesTable
+	DevicesTable.ForeignKeys[0].RefTable = UsersTable
+	DocumentsTable.ForeignKeys[0].RefTable = BooksTable
+	HighlightsTable.ForeignKeys[0].RefTable = BooksTable
+	HighlightsTable.ForeignKeys[1].RefTable = UsersTable
+	HoldsTable.ForeignKeys[0].RefTable = BooksTable
+	HoldsTable.ForeignKeys[1].RefTable = UsersTable
+	HoldsTable.ForeignKeys[2].RefTable = BookCopiesTable
+	LoansTable.ForeignKeys[0].RefTable = BookCopiesTable
+	LoansTable.ForeignKeys[1].RefTable = UsersTable
+	ReadingProgressesTable.ForeignKeys[0].RefTable = UsersTable
+	ReviewsTable.ForeignKeys[0].RefTable = BooksTable
+	ReviewsTable.ForeignKeys[1].RefTable = UsersTable
+	ReviewsTable.ForeignKeys[2].RefTable = UsersTable
+	RevisionsTable.ForeignKeys[0].RefTable = UsersTable
+	SessionsTable.ForeignKeys[0].RefTable = UsersTable
+	ShelvesTable.ForeignKeys[0].RefTable = UsersTable
+	ShelfEntriesTable.ForeignKeys[0].RefTable = ShelvesTable
+	ShelfEntriesTable.ForeignKeys[1].RefTable = BooksTable
 	BookAuthorsTable.ForeignKeys[0].RefTable = BooksTable
 	BookAuthorsTable.ForeignKeys[1].RefTable = AuthorsTable
+	UserFollowedAuthorsTable.ForeignKeys[0].RefTable = UsersTable
+	UserFollowedAuthorsTable.ForeignKeys[1].RefTable = AuthorsTable
 }
@@ -0,0 +1,247 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+)
+
+// OutboxMessage is the model entity for the OutboxMessage schema.
+type OutboxMessage struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind string `json:"kind,omitempty"`
+	// To holds the value of the "to" field.
+	To string `json:"to,omitempty"`
+	// Subject holds the value of the "subject" field.
+	Subject string `json:"subject,omitempty"`
+	// Body holds the value of the "body" field.
+	Body string `json:"body,omitempty"`
+	// Attachment holds the value of the "attachment" field.
+	Attachment string `json:"attachment,omitempty"`
+	// AttachmentName holds the value of the "attachment_name" field.
+	AttachmentName string `json:"attachment_name,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Attempts holds the value of the "attempts" field.
+	Attempts int `json:"attempts,omitempty"`
+	// NextAttemptAt holds the value of the "next_attempt_at" field.
+	NextAttemptAt int64 `json:"next_attempt_at,omitempty"`
+	// SentAt holds the value of the "sent_at" field.
+	SentAt *int64 `json:"sent_at,omitempty"`
+	// LastError holds the value of the "last_error" field.
+	LastError string `json:"last_error,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the OutboxMessageQuery when eager-loading is set.
+	Edges        OutboxMessageEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// OutboxMessageEdges holds the relations/edges for other nodes in the graph.
+type OutboxMessageEdges struct {
+	// Delivery holds the value of the delivery edge.
+	Delivery *Delivery `json:"delivery,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// DeliveryOrErr returns the Delivery value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e OutboxMessageEdges) DeliveryOrErr() (*Delivery, error) {
+	if e.loadedTypes[0] {
+		if e.Delivery == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: delivery.Label}
+		}
+		return e.Delivery, nil
+	}
+	return nil, &NotLoadedError{edge: "delivery"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*OutboxMessage) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case outboxmessage.FieldID, outboxmessage.FieldCreatedAt, outboxmessage.FieldAttempts, outboxmessage.FieldNextAttemptAt, outboxmessage.FieldSentAt:
+			values[i] = new(sql.NullInt64)
+		case outboxmessage.FieldKind, outboxmessage.FieldTo, outboxmessage.FieldSubject, outboxmessage.FieldBody, outboxmessage.FieldAttachment, outboxmessage.FieldAttachmentName, outboxmessage.FieldLastError:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the OutboxMessage fields.
+func (om *OutboxMessage) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case outboxmessage.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			om.ID = int64(value.Int64)
+		case outboxmessage.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				om.Kind = value.String
+			}
+		case outboxmessage.FieldTo:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field to", values[i])
+			} else if value.Valid {
+				om.To = value.String
+			}
+		case outboxmessage.FieldSubject:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field subject", values[i])
+			} else if value.Valid {
+				om.Subject = value.String
+			}
+		case outboxmessage.FieldBody:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field body", values[i])
+			} else if value.Valid {
+				om.Body = value.String
+			}
+		case outboxmessage.FieldAttachment:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field attachment", values[i])
+			} else if value.Valid {
+				om.Attachment = value.String
+			}
+		case outboxmessage.FieldAttachmentName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field attachment_name", values[i])
+			} else if value.Valid {
+				om.AttachmentName = value.String
+			}
+		case outboxmessage.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				om.CreatedAt = value.Int64
+			}
+		case outboxmessage.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				om.Attempts = int(value.Int64)
+			}
+		case outboxmessage.FieldNextAttemptAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field next_attempt_at", values[i])
+			} else if value.Valid {
+				om.NextAttemptAt = value.Int64
+			}
+		case outboxmessage.FieldSentAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field sent_at", values[i])
+			} else if value.Valid {
+				om.SentAt = new(int64)
+				*om.SentAt = value.Int64
+			}
+		case outboxmessage.FieldLastError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field last_error", values[i])
+			} else if value.Valid {
+				om.LastError = value.String
+			}
+		default:
+			om.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the OutboxMessage.
+// This includes values selected through modifiers, order, etc.
+func (om *OutboxMessage) Value(name string) (ent.Value, error) {
+	return om.selectValues.Get(name)
+}
+
+// QueryDelivery queries the "delivery" edge of the OutboxMessage entity.
+func (om *OutboxMessage) QueryDelivery() *DeliveryQuery {
+	return NewOutboxMessageClient(om.config).QueryDelivery(om)
+}
+
+// Update returns a builder for updating this OutboxMessage.
+// Note that you need to call OutboxMessage.Unwrap() before calling this method if this OutboxMessage
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (om *OutboxMessage) Update() *OutboxMessageUpdateOne {
+	return NewOutboxMessageClient(om.config).UpdateOne(om)
+}
+
+// Unwrap unwraps the OutboxMessage entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (om *OutboxMessage) Unwrap() *OutboxMessage {
+	_tx, ok := om.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: OutboxMessage is not a transactional entity")
+	}
+	om.config.driver = _tx.drv
+	return om
+}
+
+// String implements the fmt.Stringer.
+func (om *OutboxMessage) String() string {
+	var builder strings.Builder
+	builder.WriteString("OutboxMessage(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", om.ID))
+	builder.WriteString("kind=")
+	builder.WriteString(om.Kind)
+	builder.WriteString(", ")
+	builder.WriteString("to=")
+	builder.WriteString(om.To)
+	builder.WriteString(", ")
+	builder.WriteString("subject=")
+	builder.WriteString(om.Subject)
+	builder.WriteString(", ")
+	builder.WriteString("body=")
+	builder.WriteString(om.Body)
+	builder.WriteString(", ")
+	builder.WriteString("attachment=")
+	builder.WriteString(om.Attachment)
+	builder.WriteString(", ")
+	builder.WriteString("attachment_name=")
+	builder.WriteString(om.AttachmentName)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", om.CreatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", om.Attempts))
+	builder.WriteString(", ")
+	builder.WriteString("next_attempt_at=")
+	builder.WriteString(fmt.Sprintf("%v", om.NextAttemptAt))
+	builder.WriteString(", ")
+	if v := om.SentAt; v != nil {
+		builder.WriteString("sent_at=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("last_error=")
+	builder.WriteString(om.LastError)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// OutboxMessages is a parsable slice of OutboxMessage.
+type OutboxMessages []*OutboxMessage
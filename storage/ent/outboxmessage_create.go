@@ -0,0 +1,423 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+)
+
+// OutboxMessageCreate is the builder for creating a OutboxMessage entity.
+type OutboxMessageCreate struct {
+	config
+	mutation *OutboxMessageMutation
+	hooks    []Hook
+}
+
+// SetKind sets the "kind" field.
+func (omc *OutboxMessageCreate) SetKind(s string) *OutboxMessageCreate {
+	omc.mutation.SetKind(s)
+	return omc
+}
+
+// SetTo sets the "to" field.
+func (omc *OutboxMessageCreate) SetTo(s string) *OutboxMessageCreate {
+	omc.mutation.SetTo(s)
+	return omc
+}
+
+// SetSubject sets the "subject" field.
+func (omc *OutboxMessageCreate) SetSubject(s string) *OutboxMessageCreate {
+	omc.mutation.SetSubject(s)
+	return omc
+}
+
+// SetBody sets the "body" field.
+func (omc *OutboxMessageCreate) SetBody(s string) *OutboxMessageCreate {
+	omc.mutation.SetBody(s)
+	return omc
+}
+
+// SetAttachment sets the "attachment" field.
+func (omc *OutboxMessageCreate) SetAttachment(s string) *OutboxMessageCreate {
+	omc.mutation.SetAttachment(s)
+	return omc
+}
+
+// SetNillableAttachment sets the "attachment" field if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableAttachment(s *string) *OutboxMessageCreate {
+	if s != nil {
+		omc.SetAttachment(*s)
+	}
+	return omc
+}
+
+// SetAttachmentName sets the "attachment_name" field.
+func (omc *OutboxMessageCreate) SetAttachmentName(s string) *OutboxMessageCreate {
+	omc.mutation.SetAttachmentName(s)
+	return omc
+}
+
+// SetNillableAttachmentName sets the "attachment_name" field if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableAttachmentName(s *string) *OutboxMessageCreate {
+	if s != nil {
+		omc.SetAttachmentName(*s)
+	}
+	return omc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (omc *OutboxMessageCreate) SetCreatedAt(i int64) *OutboxMessageCreate {
+	omc.mutation.SetCreatedAt(i)
+	return omc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableCreatedAt(i *int64) *OutboxMessageCreate {
+	if i != nil {
+		omc.SetCreatedAt(*i)
+	}
+	return omc
+}
+
+// SetAttempts sets the "attempts" field.
+func (omc *OutboxMessageCreate) SetAttempts(i int) *OutboxMessageCreate {
+	omc.mutation.SetAttempts(i)
+	return omc
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableAttempts(i *int) *OutboxMessageCreate {
+	if i != nil {
+		omc.SetAttempts(*i)
+	}
+	return omc
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (omc *OutboxMessageCreate) SetNextAttemptAt(i int64) *OutboxMessageCreate {
+	omc.mutation.SetNextAttemptAt(i)
+	return omc
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableNextAttemptAt(i *int64) *OutboxMessageCreate {
+	if i != nil {
+		omc.SetNextAttemptAt(*i)
+	}
+	return omc
+}
+
+// SetSentAt sets the "sent_at" field.
+func (omc *OutboxMessageCreate) SetSentAt(i int64) *OutboxMessageCreate {
+	omc.mutation.SetSentAt(i)
+	return omc
+}
+
+// SetNillableSentAt sets the "sent_at" field if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableSentAt(i *int64) *OutboxMessageCreate {
+	if i != nil {
+		omc.SetSentAt(*i)
+	}
+	return omc
+}
+
+// SetLastError sets the "last_error" field.
+func (omc *OutboxMessageCreate) SetLastError(s string) *OutboxMessageCreate {
+	omc.mutation.SetLastError(s)
+	return omc
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableLastError(s *string) *OutboxMessageCreate {
+	if s != nil {
+		omc.SetLastError(*s)
+	}
+	return omc
+}
+
+// SetID sets the "id" field.
+func (omc *OutboxMessageCreate) SetID(i int64) *OutboxMessageCreate {
+	omc.mutation.SetID(i)
+	return omc
+}
+
+// SetDeliveryID sets the "delivery" edge to the Delivery entity by ID.
+func (omc *OutboxMessageCreate) SetDeliveryID(id int64) *OutboxMessageCreate {
+	omc.mutation.SetDeliveryID(id)
+	return omc
+}
+
+// SetNillableDeliveryID sets the "delivery" edge to the Delivery entity by ID if the given value is not nil.
+func (omc *OutboxMessageCreate) SetNillableDeliveryID(id *int64) *OutboxMessageCreate {
+	if id != nil {
+		omc = omc.SetDeliveryID(*id)
+	}
+	return omc
+}
+
+// SetDelivery sets the "delivery" edge to the Delivery entity.
+func (omc *OutboxMessageCreate) SetDelivery(d *Delivery) *OutboxMessageCreate {
+	return omc.SetDeliveryID(d.ID)
+}
+
+// Mutation returns the OutboxMessageMutation object of the builder.
+func (omc *OutboxMessageCreate) Mutation() *OutboxMessageMutation {
+	return omc.mutation
+}
+
+// Save creates the OutboxMessage in the database.
+func (omc *OutboxMessageCreate) Save(ctx context.Context) (*OutboxMessage, error) {
+	omc.defaults()
+	return withHooks(ctx, omc.sqlSave, omc.mutation, omc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (omc *OutboxMessageCreate) SaveX(ctx context.Context) *OutboxMessage {
+	v, err := omc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (omc *OutboxMessageCreate) Exec(ctx context.Context) error {
+	_, err := omc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (omc *OutboxMessageCreate) ExecX(ctx context.Context) {
+	if err := omc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (omc *OutboxMessageCreate) defaults() {
+	if _, ok := omc.mutation.CreatedAt(); !ok {
+		v := outboxmessage.DefaultCreatedAt()
+		omc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := omc.mutation.Attempts(); !ok {
+		v := outboxmessage.DefaultAttempts
+		omc.mutation.SetAttempts(v)
+	}
+	if _, ok := omc.mutation.NextAttemptAt(); !ok {
+		v := outboxmessage.DefaultNextAttemptAt()
+		omc.mutation.SetNextAttemptAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (omc *OutboxMessageCreate) check() error {
+	if _, ok := omc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "OutboxMessage.kind"`)}
+	}
+	if _, ok := omc.mutation.To(); !ok {
+		return &ValidationError{Name: "to", err: errors.New(`ent: missing required field "OutboxMessage.to"`)}
+	}
+	if _, ok := omc.mutation.Subject(); !ok {
+		return &ValidationError{Name: "subject", err: errors.New(`ent: missing required field "OutboxMessage.subject"`)}
+	}
+	if _, ok := omc.mutation.Body(); !ok {
+		return &ValidationError{Name: "body", err: errors.New(`ent: missing required field "OutboxMessage.body"`)}
+	}
+	if _, ok := omc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "OutboxMessage.created_at"`)}
+	}
+	if _, ok := omc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "OutboxMessage.attempts"`)}
+	}
+	if _, ok := omc.mutation.NextAttemptAt(); !ok {
+		return &ValidationError{Name: "next_attempt_at", err: errors.New(`ent: missing required field "OutboxMessage.next_attempt_at"`)}
+	}
+	return nil
+}
+
+func (omc *OutboxMessageCreate) sqlSave(ctx context.Context) (*OutboxMessage, error) {
+	if err := omc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := omc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, omc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	omc.mutation.id = &_node.ID
+	omc.mutation.done = true
+	return _node, nil
+}
+
+func (omc *OutboxMessageCreate) createSpec() (*OutboxMessage, *sqlgraph.CreateSpec) {
+	var (
+		_node = &OutboxMessage{config: omc.config}
+		_spec = sqlgraph.NewCreateSpec(outboxmessage.Table, sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64))
+	)
+	if id, ok := omc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := omc.mutation.Kind(); ok {
+		_spec.SetField(outboxmessage.FieldKind, field.TypeString, value)
+		_node.Kind = value
+	}
+	if value, ok := omc.mutation.To(); ok {
+		_spec.SetField(outboxmessage.FieldTo, field.TypeString, value)
+		_node.To = value
+	}
+	if value, ok := omc.mutation.Subject(); ok {
+		_spec.SetField(outboxmessage.FieldSubject, field.TypeString, value)
+		_node.Subject = value
+	}
+	if value, ok := omc.mutation.Body(); ok {
+		_spec.SetField(outboxmessage.FieldBody, field.TypeString, value)
+		_node.Body = value
+	}
+	if value, ok := omc.mutation.Attachment(); ok {
+		_spec.SetField(outboxmessage.FieldAttachment, field.TypeString, value)
+		_node.Attachment = value
+	}
+	if value, ok := omc.mutation.AttachmentName(); ok {
+		_spec.SetField(outboxmessage.FieldAttachmentName, field.TypeString, value)
+		_node.AttachmentName = value
+	}
+	if value, ok := omc.mutation.CreatedAt(); ok {
+		_spec.SetField(outboxmessage.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := omc.mutation.Attempts(); ok {
+		_spec.SetField(outboxmessage.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := omc.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outboxmessage.FieldNextAttemptAt, field.TypeInt64, value)
+		_node.NextAttemptAt = value
+	}
+	if value, ok := omc.mutation.SentAt(); ok {
+		_spec.SetField(outboxmessage.FieldSentAt, field.TypeInt64, value)
+		_node.SentAt = &value
+	}
+	if value, ok := omc.mutation.LastError(); ok {
+		_spec.SetField(outboxmessage.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if nodes := omc.mutation.DeliveryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   outboxmessage.DeliveryTable,
+			Columns: []string{outboxmessage.DeliveryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OutboxMessageCreateBulk is the builder for creating many OutboxMessage entities in bulk.
+type OutboxMessageCreateBulk struct {
+	config
+	err      error
+	builders []*OutboxMessageCreate
+}
+
+// Save creates the OutboxMessage entities in the database.
+func (omcb *OutboxMessageCreateBulk) Save(ctx context.Context) ([]*OutboxMessage, error) {
+	if omcb.err != nil {
+		return nil, omcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(omcb.builders))
+	nodes := make([]*OutboxMessage, len(omcb.builders))
+	mutators := make([]Mutator, len(omcb.builders))
+	for i := range omcb.builders {
+		func(i int, root context.Context) {
+			builder := omcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OutboxMessageMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, omcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, omcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, omcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (omcb *OutboxMessageCreateBulk) SaveX(ctx context.Context) []*OutboxMessage {
+	v, err := omcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (omcb *OutboxMessageCreateBulk) Exec(ctx context.Context) error {
+	_, err := omcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (omcb *OutboxMessageCreateBulk) ExecX(ctx context.Context) {
+	if err := omcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,692 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// OutboxMessageUpdate is the builder for updating OutboxMessage entities.
+type OutboxMessageUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OutboxMessageMutation
+}
+
+// Where appends a list predicates to the OutboxMessageUpdate builder.
+func (omu *OutboxMessageUpdate) Where(ps ...predicate.OutboxMessage) *OutboxMessageUpdate {
+	omu.mutation.Where(ps...)
+	return omu
+}
+
+// SetKind sets the "kind" field.
+func (omu *OutboxMessageUpdate) SetKind(s string) *OutboxMessageUpdate {
+	omu.mutation.SetKind(s)
+	return omu
+}
+
+// SetTo sets the "to" field.
+func (omu *OutboxMessageUpdate) SetTo(s string) *OutboxMessageUpdate {
+	omu.mutation.SetTo(s)
+	return omu
+}
+
+// SetSubject sets the "subject" field.
+func (omu *OutboxMessageUpdate) SetSubject(s string) *OutboxMessageUpdate {
+	omu.mutation.SetSubject(s)
+	return omu
+}
+
+// SetBody sets the "body" field.
+func (omu *OutboxMessageUpdate) SetBody(s string) *OutboxMessageUpdate {
+	omu.mutation.SetBody(s)
+	return omu
+}
+
+// SetAttachment sets the "attachment" field.
+func (omu *OutboxMessageUpdate) SetAttachment(s string) *OutboxMessageUpdate {
+	omu.mutation.SetAttachment(s)
+	return omu
+}
+
+// SetNillableAttachment sets the "attachment" field if the given value is not nil.
+func (omu *OutboxMessageUpdate) SetNillableAttachment(s *string) *OutboxMessageUpdate {
+	if s != nil {
+		omu.SetAttachment(*s)
+	}
+	return omu
+}
+
+// ClearAttachment clears the value of the "attachment" field.
+func (omu *OutboxMessageUpdate) ClearAttachment() *OutboxMessageUpdate {
+	omu.mutation.ClearAttachment()
+	return omu
+}
+
+// SetAttachmentName sets the "attachment_name" field.
+func (omu *OutboxMessageUpdate) SetAttachmentName(s string) *OutboxMessageUpdate {
+	omu.mutation.SetAttachmentName(s)
+	return omu
+}
+
+// SetNillableAttachmentName sets the "attachment_name" field if the given value is not nil.
+func (omu *OutboxMessageUpdate) SetNillableAttachmentName(s *string) *OutboxMessageUpdate {
+	if s != nil {
+		omu.SetAttachmentName(*s)
+	}
+	return omu
+}
+
+// ClearAttachmentName clears the value of the "attachment_name" field.
+func (omu *OutboxMessageUpdate) ClearAttachmentName() *OutboxMessageUpdate {
+	omu.mutation.ClearAttachmentName()
+	return omu
+}
+
+// SetAttempts sets the "attempts" field.
+func (omu *OutboxMessageUpdate) SetAttempts(i int) *OutboxMessageUpdate {
+	omu.mutation.ResetAttempts()
+	omu.mutation.SetAttempts(i)
+	return omu
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (omu *OutboxMessageUpdate) SetNillableAttempts(i *int) *OutboxMessageUpdate {
+	if i != nil {
+		omu.SetAttempts(*i)
+	}
+	return omu
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (omu *OutboxMessageUpdate) AddAttempts(i int) *OutboxMessageUpdate {
+	omu.mutation.AddAttempts(i)
+	return omu
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (omu *OutboxMessageUpdate) SetNextAttemptAt(i int64) *OutboxMessageUpdate {
+	omu.mutation.ResetNextAttemptAt()
+	omu.mutation.SetNextAttemptAt(i)
+	return omu
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (omu *OutboxMessageUpdate) SetNillableNextAttemptAt(i *int64) *OutboxMessageUpdate {
+	if i != nil {
+		omu.SetNextAttemptAt(*i)
+	}
+	return omu
+}
+
+// AddNextAttemptAt adds i to the "next_attempt_at" field.
+func (omu *OutboxMessageUpdate) AddNextAttemptAt(i int64) *OutboxMessageUpdate {
+	omu.mutation.AddNextAttemptAt(i)
+	return omu
+}
+
+// SetSentAt sets the "sent_at" field.
+func (omu *OutboxMessageUpdate) SetSentAt(i int64) *OutboxMessageUpdate {
+	omu.mutation.ResetSentAt()
+	omu.mutation.SetSentAt(i)
+	return omu
+}
+
+// SetNillableSentAt sets the "sent_at" field if the given value is not nil.
+func (omu *OutboxMessageUpdate) SetNillableSentAt(i *int64) *OutboxMessageUpdate {
+	if i != nil {
+		omu.SetSentAt(*i)
+	}
+	return omu
+}
+
+// AddSentAt adds i to the "sent_at" field.
+func (omu *OutboxMessageUpdate) AddSentAt(i int64) *OutboxMessageUpdate {
+	omu.mutation.AddSentAt(i)
+	return omu
+}
+
+// ClearSentAt clears the value of the "sent_at" field.
+func (omu *OutboxMessageUpdate) ClearSentAt() *OutboxMessageUpdate {
+	omu.mutation.ClearSentAt()
+	return omu
+}
+
+// SetLastError sets the "last_error" field.
+func (omu *OutboxMessageUpdate) SetLastError(s string) *OutboxMessageUpdate {
+	omu.mutation.SetLastError(s)
+	return omu
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (omu *OutboxMessageUpdate) SetNillableLastError(s *string) *OutboxMessageUpdate {
+	if s != nil {
+		omu.SetLastError(*s)
+	}
+	return omu
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (omu *OutboxMessageUpdate) ClearLastError() *OutboxMessageUpdate {
+	omu.mutation.ClearLastError()
+	return omu
+}
+
+// SetDeliveryID sets the "delivery" edge to the Delivery entity by ID.
+func (omu *OutboxMessageUpdate) SetDeliveryID(id int64) *OutboxMessageUpdate {
+	omu.mutation.SetDeliveryID(id)
+	return omu
+}
+
+// SetNillableDeliveryID sets the "delivery" edge to the Delivery entity by ID if the given value is not nil.
+func (omu *OutboxMessageUpdate) SetNillableDeliveryID(id *int64) *OutboxMessageUpdate {
+	if id != nil {
+		omu = omu.SetDeliveryID(*id)
+	}
+	return omu
+}
+
+// SetDelivery sets the "delivery" edge to the Delivery entity.
+func (omu *OutboxMessageUpdate) SetDelivery(d *Delivery) *OutboxMessageUpdate {
+	return omu.SetDeliveryID(d.ID)
+}
+
+// Mutation returns the OutboxMessageMutation object of the builder.
+func (omu *OutboxMessageUpdate) Mutation() *OutboxMessageMutation {
+	return omu.mutation
+}
+
+// ClearDelivery clears the "delivery" edge to the Delivery entity.
+func (omu *OutboxMessageUpdate) ClearDelivery() *OutboxMessageUpdate {
+	omu.mutation.ClearDelivery()
+	return omu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (omu *OutboxMessageUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, omu.sqlSave, omu.mutation, omu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (omu *OutboxMessageUpdate) SaveX(ctx context.Context) int {
+	affected, err := omu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (omu *OutboxMessageUpdate) Exec(ctx context.Context) error {
+	_, err := omu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (omu *OutboxMessageUpdate) ExecX(ctx context.Context) {
+	if err := omu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (omu *OutboxMessageUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(outboxmessage.Table, outboxmessage.Columns, sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64))
+	if ps := omu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := omu.mutation.Kind(); ok {
+		_spec.SetField(outboxmessage.FieldKind, field.TypeString, value)
+	}
+	if value, ok := omu.mutation.To(); ok {
+		_spec.SetField(outboxmessage.FieldTo, field.TypeString, value)
+	}
+	if value, ok := omu.mutation.Subject(); ok {
+		_spec.SetField(outboxmessage.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := omu.mutation.Body(); ok {
+		_spec.SetField(outboxmessage.FieldBody, field.TypeString, value)
+	}
+	if value, ok := omu.mutation.Attachment(); ok {
+		_spec.SetField(outboxmessage.FieldAttachment, field.TypeString, value)
+	}
+	if omu.mutation.AttachmentCleared() {
+		_spec.ClearField(outboxmessage.FieldAttachment, field.TypeString)
+	}
+	if value, ok := omu.mutation.AttachmentName(); ok {
+		_spec.SetField(outboxmessage.FieldAttachmentName, field.TypeString, value)
+	}
+	if omu.mutation.AttachmentNameCleared() {
+		_spec.ClearField(outboxmessage.FieldAttachmentName, field.TypeString)
+	}
+	if value, ok := omu.mutation.Attempts(); ok {
+		_spec.SetField(outboxmessage.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := omu.mutation.AddedAttempts(); ok {
+		_spec.AddField(outboxmessage.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := omu.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outboxmessage.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	if value, ok := omu.mutation.AddedNextAttemptAt(); ok {
+		_spec.AddField(outboxmessage.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	if value, ok := omu.mutation.SentAt(); ok {
+		_spec.SetField(outboxmessage.FieldSentAt, field.TypeInt64, value)
+	}
+	if value, ok := omu.mutation.AddedSentAt(); ok {
+		_spec.AddField(outboxmessage.FieldSentAt, field.TypeInt64, value)
+	}
+	if omu.mutation.SentAtCleared() {
+		_spec.ClearField(outboxmessage.FieldSentAt, field.TypeInt64)
+	}
+	if value, ok := omu.mutation.LastError(); ok {
+		_spec.SetField(outboxmessage.FieldLastError, field.TypeString, value)
+	}
+	if omu.mutation.LastErrorCleared() {
+		_spec.ClearField(outboxmessage.FieldLastError, field.TypeString)
+	}
+	if omu.mutation.DeliveryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   outboxmessage.DeliveryTable,
+			Columns: []string{outboxmessage.DeliveryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := omu.mutation.DeliveryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   outboxmessage.DeliveryTable,
+			Columns: []string{outboxmessage.DeliveryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, omu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outboxmessage.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	omu.mutation.done = true
+	return n, nil
+}
+
+// OutboxMessageUpdateOne is the builder for updating a single OutboxMessage entity.
+type OutboxMessageUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *OutboxMessageMutation
+}
+
+// SetKind sets the "kind" field.
+func (omuo *OutboxMessageUpdateOne) SetKind(s string) *OutboxMessageUpdateOne {
+	omuo.mutation.SetKind(s)
+	return omuo
+}
+
+// SetTo sets the "to" field.
+func (omuo *OutboxMessageUpdateOne) SetTo(s string) *OutboxMessageUpdateOne {
+	omuo.mutation.SetTo(s)
+	return omuo
+}
+
+// SetSubject sets the "subject" field.
+func (omuo *OutboxMessageUpdateOne) SetSubject(s string) *OutboxMessageUpdateOne {
+	omuo.mutation.SetSubject(s)
+	return omuo
+}
+
+// SetBody sets the "body" field.
+func (omuo *OutboxMessageUpdateOne) SetBody(s string) *OutboxMessageUpdateOne {
+	omuo.mutation.SetBody(s)
+	return omuo
+}
+
+// SetAttachment sets the "attachment" field.
+func (omuo *OutboxMessageUpdateOne) SetAttachment(s string) *OutboxMessageUpdateOne {
+	omuo.mutation.SetAttachment(s)
+	return omuo
+}
+
+// SetNillableAttachment sets the "attachment" field if the given value is not nil.
+func (omuo *OutboxMessageUpdateOne) SetNillableAttachment(s *string) *OutboxMessageUpdateOne {
+	if s != nil {
+		omuo.SetAttachment(*s)
+	}
+	return omuo
+}
+
+// ClearAttachment clears the value of the "attachment" field.
+func (omuo *OutboxMessageUpdateOne) ClearAttachment() *OutboxMessageUpdateOne {
+	omuo.mutation.ClearAttachment()
+	return omuo
+}
+
+// SetAttachmentName sets the "attachment_name" field.
+func (omuo *OutboxMessageUpdateOne) SetAttachmentName(s string) *OutboxMessageUpdateOne {
+	omuo.mutation.SetAttachmentName(s)
+	return omuo
+}
+
+// SetNillableAttachmentName sets the "attachment_name" field if the given value is not nil.
+func (omuo *OutboxMessageUpdateOne) SetNillableAttachmentName(s *string) *OutboxMessageUpdateOne {
+	if s != nil {
+		omuo.SetAttachmentName(*s)
+	}
+	return omuo
+}
+
+// ClearAttachmentName clears the value of the "attachment_name" field.
+func (omuo *OutboxMessageUpdateOne) ClearAttachmentName() *OutboxMessageUpdateOne {
+	omuo.mutation.ClearAttachmentName()
+	return omuo
+}
+
+// SetAttempts sets the "attempts" field.
+func (omuo *OutboxMessageUpdateOne) SetAttempts(i int) *OutboxMessageUpdateOne {
+	omuo.mutation.ResetAttempts()
+	omuo.mutation.SetAttempts(i)
+	return omuo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (omuo *OutboxMessageUpdateOne) SetNillableAttempts(i *int) *OutboxMessageUpdateOne {
+	if i != nil {
+		omuo.SetAttempts(*i)
+	}
+	return omuo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (omuo *OutboxMessageUpdateOne) AddAttempts(i int) *OutboxMessageUpdateOne {
+	omuo.mutation.AddAttempts(i)
+	return omuo
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (omuo *OutboxMessageUpdateOne) SetNextAttemptAt(i int64) *OutboxMessageUpdateOne {
+	omuo.mutation.ResetNextAttemptAt()
+	omuo.mutation.SetNextAttemptAt(i)
+	return omuo
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (omuo *OutboxMessageUpdateOne) SetNillableNextAttemptAt(i *int64) *OutboxMessageUpdateOne {
+	if i != nil {
+		omuo.SetNextAttemptAt(*i)
+	}
+	return omuo
+}
+
+// AddNextAttemptAt adds i to the "next_attempt_at" field.
+func (omuo *OutboxMessageUpdateOne) AddNextAttemptAt(i int64) *OutboxMessageUpdateOne {
+	omuo.mutation.AddNextAttemptAt(i)
+	return omuo
+}
+
+// SetSentAt sets the "sent_at" field.
+func (omuo *OutboxMessageUpdateOne) SetSentAt(i int64) *OutboxMessageUpdateOne {
+	omuo.mutation.ResetSentAt()
+	omuo.mutation.SetSentAt(i)
+	return omuo
+}
+
+// SetNillableSentAt sets the "sent_at" field if the given value is not nil.
+func (omuo *OutboxMessageUpdateOne) SetNillableSentAt(i *int64) *OutboxMessageUpdateOne {
+	if i != nil {
+		omuo.SetSentAt(*i)
+	}
+	return omuo
+}
+
+// AddSentAt adds i to the "sent_at" field.
+func (omuo *OutboxMessageUpdateOne) AddSentAt(i int64) *OutboxMessageUpdateOne {
+	omuo.mutation.AddSentAt(i)
+	return omuo
+}
+
+// ClearSentAt clears the value of the "sent_at" field.
+func (omuo *OutboxMessageUpdateOne) ClearSentAt() *OutboxMessageUpdateOne {
+	omuo.mutation.ClearSentAt()
+	return omuo
+}
+
+// SetLastError sets the "last_error" field.
+func (omuo *OutboxMessageUpdateOne) SetLastError(s string) *OutboxMessageUpdateOne {
+	omuo.mutation.SetLastError(s)
+	return omuo
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (omuo *OutboxMessageUpdateOne) SetNillableLastError(s *string) *OutboxMessageUpdateOne {
+	if s != nil {
+		omuo.SetLastError(*s)
+	}
+	return omuo
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (omuo *OutboxMessageUpdateOne) ClearLastError() *OutboxMessageUpdateOne {
+	omuo.mutation.ClearLastError()
+	return omuo
+}
+
+// SetDeliveryID sets the "delivery" edge to the Delivery entity by ID.
+func (omuo *OutboxMessageUpdateOne) SetDeliveryID(id int64) *OutboxMessageUpdateOne {
+	omuo.mutation.SetDeliveryID(id)
+	return omuo
+}
+
+// SetNillableDeliveryID sets the "delivery" edge to the Delivery entity by ID if the given value is not nil.
+func (omuo *OutboxMessageUpdateOne) SetNillableDeliveryID(id *int64) *OutboxMessageUpdateOne {
+	if id != nil {
+		omuo = omuo.SetDeliveryID(*id)
+	}
+	return omuo
+}
+
+// SetDelivery sets the "delivery" edge to the Delivery entity.
+func (omuo *OutboxMessageUpdateOne) SetDelivery(d *Delivery) *OutboxMessageUpdateOne {
+	return omuo.SetDeliveryID(d.ID)
+}
+
+// Mutation returns the OutboxMessageMutation object of the builder.
+func (omuo *OutboxMessageUpdateOne) Mutation() *OutboxMessageMutation {
+	return omuo.mutation
+}
+
+// ClearDelivery clears the "delivery" edge to the Delivery entity.
+func (omuo *OutboxMessageUpdateOne) ClearDelivery() *OutboxMessageUpdateOne {
+	omuo.mutation.ClearDelivery()
+	return omuo
+}
+
+// Where appends a list predicates to the OutboxMessageUpdate builder.
+func (omuo *OutboxMessageUpdateOne) Where(ps ...predicate.OutboxMessage) *OutboxMessageUpdateOne {
+	omuo.mutation.Where(ps...)
+	return omuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (omuo *OutboxMessageUpdateOne) Select(field string, fields ...string) *OutboxMessageUpdateOne {
+	omuo.fields = append([]string{field}, fields...)
+	return omuo
+}
+
+// Save executes the query and returns the updated OutboxMessage entity.
+func (omuo *OutboxMessageUpdateOne) Save(ctx context.Context) (*OutboxMessage, error) {
+	return withHooks(ctx, omuo.sqlSave, omuo.mutation, omuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (omuo *OutboxMessageUpdateOne) SaveX(ctx context.Context) *OutboxMessage {
+	node, err := omuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (omuo *OutboxMessageUpdateOne) Exec(ctx context.Context) error {
+	_, err := omuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (omuo *OutboxMessageUpdateOne) ExecX(ctx context.Context) {
+	if err := omuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (omuo *OutboxMessageUpdateOne) sqlSave(ctx context.Context) (_node *OutboxMessage, err error) {
+	_spec := sqlgraph.NewUpdateSpec(outboxmessage.Table, outboxmessage.Columns, sqlgraph.NewFieldSpec(outboxmessage.FieldID, field.TypeInt64))
+	id, ok := omuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "OutboxMessage.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := omuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, outboxmessage.FieldID)
+		for _, f := range fields {
+			if !outboxmessage.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != outboxmessage.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := omuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := omuo.mutation.Kind(); ok {
+		_spec.SetField(outboxmessage.FieldKind, field.TypeString, value)
+	}
+	if value, ok := omuo.mutation.To(); ok {
+		_spec.SetField(outboxmessage.FieldTo, field.TypeString, value)
+	}
+	if value, ok := omuo.mutation.Subject(); ok {
+		_spec.SetField(outboxmessage.FieldSubject, field.TypeString, value)
+	}
+	if value, ok := omuo.mutation.Body(); ok {
+		_spec.SetField(outboxmessage.FieldBody, field.TypeString, value)
+	}
+	if value, ok := omuo.mutation.Attachment(); ok {
+		_spec.SetField(outboxmessage.FieldAttachment, field.TypeString, value)
+	}
+	if omuo.mutation.AttachmentCleared() {
+		_spec.ClearField(outboxmessage.FieldAttachment, field.TypeString)
+	}
+	if value, ok := omuo.mutation.AttachmentName(); ok {
+		_spec.SetField(outboxmessage.FieldAttachmentName, field.TypeString, value)
+	}
+	if omuo.mutation.AttachmentNameCleared() {
+		_spec.ClearField(outboxmessage.FieldAttachmentName, field.TypeString)
+	}
+	if value, ok := omuo.mutation.Attempts(); ok {
+		_spec.SetField(outboxmessage.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := omuo.mutation.AddedAttempts(); ok {
+		_spec.AddField(outboxmessage.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := omuo.mutation.NextAttemptAt(); ok {
+		_spec.SetField(outboxmessage.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	if value, ok := omuo.mutation.AddedNextAttemptAt(); ok {
+		_spec.AddField(outboxmessage.FieldNextAttemptAt, field.TypeInt64, value)
+	}
+	if value, ok := omuo.mutation.SentAt(); ok {
+		_spec.SetField(outboxmessage.FieldSentAt, field.TypeInt64, value)
+	}
+	if value, ok := omuo.mutation.AddedSentAt(); ok {
+		_spec.AddField(outboxmessage.FieldSentAt, field.TypeInt64, value)
+	}
+	if omuo.mutation.SentAtCleared() {
+		_spec.ClearField(outboxmessage.FieldSentAt, field.TypeInt64)
+	}
+	if value, ok := omuo.mutation.LastError(); ok {
+		_spec.SetField(outboxmessage.FieldLastError, field.TypeString, value)
+	}
+	if omuo.mutation.LastErrorCleared() {
+		_spec.ClearField(outboxmessage.FieldLastError, field.TypeString)
+	}
+	if omuo.mutation.DeliveryCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   outboxmessage.DeliveryTable,
+			Columns: []string{outboxmessage.DeliveryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := omuo.mutation.DeliveryIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   outboxmessage.DeliveryTable,
+			Columns: []string{outboxmessage.DeliveryColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(delivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &OutboxMessage{config: omuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, omuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{outboxmessage.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	omuo.mutation.done = true
+	return _node, nil
+}
@@ -6,8 +6,65 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// APIToken is the predicate function for apitoken builders.
+type APIToken func(*sql.Selector)
+
+// AuditEntry is the predicate function for auditentry builders.
+type AuditEntry func(*sql.Selector)
+
 // Author is the predicate function for author builders.
 type Author func(*sql.Selector)
 
 // Book is the predicate function for book builders.
 type Book func(*sql.Selector)
+
+// BookCopy is the predicate function for bookcopy builders.
+type BookCopy func(*sql.Selector)
+
+// Checkpoint is the predicate function for checkpoint builders.
+type Checkpoint func(*sql.Selector)
+
+// Delivery is the predicate function for delivery builders.
+type Delivery func(*sql.Selector)
+
+// Device is the predicate function for device builders.
+type Device func(*sql.Selector)
+
+// Document is the predicate function for document builders.
+type Document func(*sql.Selector)
+
+// Highlight is the predicate function for highlight builders.
+type Highlight func(*sql.Selector)
+
+// Hold is the predicate function for hold builders.
+type Hold func(*sql.Selector)
+
+// Loan is the predicate function for loan builders.
+type Loan func(*sql.Selector)
+
+// OutboxMessage is the predicate function for outboxmessage builders.
+type OutboxMessage func(*sql.Selector)
+
+// ReadingProgress is the predicate function for readingprogress builders.
+type ReadingProgress func(*sql.Selector)
+
+// Review is the predicate function for review builders.
+type Review func(*sql.Selector)
+
+// Revision is the predicate function for revision builders.
+type Revision func(*sql.Selector)
+
+// Session is the predicate function for session builders.
+type Session func(*sql.Selector)
+
+// Shelf is the predicate function for shelf builders.
+type Shelf func(*sql.Selector)
+
+// ShelfEntry is the predicate function for shelfentry builders.
+type ShelfEntry func(*sql.Selector)
+
+// User is the predicate function for user builders.
+type User func(*sql.Selector)
+
+// Validation is the predicate function for validation builders.
+type Validation func(*sql.Selector)
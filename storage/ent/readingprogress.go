@@ -0,0 +1,201 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ReadingProgress is the model entity for the ReadingProgress schema.
+type ReadingProgress struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Document holds the value of the "document" field.
+	Document string `json:"document,omitempty"`
+	// Progress holds the value of the "progress" field.
+	Progress string `json:"progress,omitempty"`
+	// Percentage holds the value of the "percentage" field.
+	Percentage float64 `json:"percentage,omitempty"`
+	// Device holds the value of the "device" field.
+	Device string `json:"device,omitempty"`
+	// DeviceID holds the value of the "device_id" field.
+	DeviceID string `json:"device_id,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ReadingProgressQuery when eager-loading is set.
+	Edges                 ReadingProgressEdges `json:"edges"`
+	reading_progress_user *int64
+	selectValues          sql.SelectValues
+}
+
+// ReadingProgressEdges holds the relations/edges for other nodes in the graph.
+type ReadingProgressEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ReadingProgressEdges) UserOrErr() (*User, error) {
+	if e.loadedTypes[0] {
+		if e.User == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.User, nil
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ReadingProgress) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case readingprogress.FieldPercentage:
+			values[i] = new(sql.NullFloat64)
+		case readingprogress.FieldID, readingprogress.FieldUpdatedAt:
+			values[i] = new(sql.NullInt64)
+		case readingprogress.FieldDocument, readingprogress.FieldProgress, readingprogress.FieldDevice, readingprogress.FieldDeviceID:
+			values[i] = new(sql.NullString)
+		case readingprogress.ForeignKeys[0]: // reading_progress_user
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ReadingProgress fields.
+func (rp *ReadingProgress) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case readingprogress.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			rp.ID = int64(value.Int64)
+		case readingprogress.FieldDocument:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field document", values[i])
+			} else if value.Valid {
+				rp.Document = value.String
+			}
+		case readingprogress.FieldProgress:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field progress", values[i])
+			} else if value.Valid {
+				rp.Progress = value.String
+			}
+		case readingprogress.FieldPercentage:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field percentage", values[i])
+			} else if value.Valid {
+				rp.Percentage = value.Float64
+			}
+		case readingprogress.FieldDevice:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field device", values[i])
+			} else if value.Valid {
+				rp.Device = value.String
+			}
+		case readingprogress.FieldDeviceID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field device_id", values[i])
+			} else if value.Valid {
+				rp.DeviceID = value.String
+			}
+		case readingprogress.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				rp.UpdatedAt = value.Int64
+			}
+		case readingprogress.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field reading_progress_user", value)
+			} else if value.Valid {
+				rp.reading_progress_user = new(int64)
+				*rp.reading_progress_user = int64(value.Int64)
+			}
+		default:
+			rp.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ReadingProgress.
+// This includes values selected through modifiers, order, etc.
+func (rp *ReadingProgress) Value(name string) (ent.Value, error) {
+	return rp.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the ReadingProgress entity.
+func (rp *ReadingProgress) QueryUser() *UserQuery {
+	return NewReadingProgressClient(rp.config).QueryUser(rp)
+}
+
+// Update returns a builder for updating this ReadingProgress.
+// Note that you need to call ReadingProgress.Unwrap() before calling this method if this ReadingProgress
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (rp *ReadingProgress) Update() *ReadingProgressUpdateOne {
+	return NewReadingProgressClient(rp.config).UpdateOne(rp)
+}
+
+// Unwrap unwraps the ReadingProgress entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (rp *ReadingProgress) Unwrap() *ReadingProgress {
+	_tx, ok := rp.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ReadingProgress is not a transactional entity")
+	}
+	rp.config.driver = _tx.drv
+	return rp
+}
+
+// String implements the fmt.Stringer.
+func (rp *ReadingProgress) String() string {
+	var builder strings.Builder
+	builder.WriteString("ReadingProgress(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", rp.ID))
+	builder.WriteString("document=")
+	builder.WriteString(rp.Document)
+	builder.WriteString(", ")
+	builder.WriteString("progress=")
+	builder.WriteString(rp.Progress)
+	builder.WriteString(", ")
+	builder.WriteString("percentage=")
+	builder.WriteString(fmt.Sprintf("%v", rp.Percentage))
+	builder.WriteString(", ")
+	builder.WriteString("device=")
+	builder.WriteString(rp.Device)
+	builder.WriteString(", ")
+	builder.WriteString("device_id=")
+	builder.WriteString(rp.DeviceID)
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(fmt.Sprintf("%v", rp.UpdatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ReadingProgresses is a parsable slice of ReadingProgress.
+type ReadingProgresses []*ReadingProgress
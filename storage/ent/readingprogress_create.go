@@ -0,0 +1,315 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ReadingProgressCreate is the builder for creating a ReadingProgress entity.
+type ReadingProgressCreate struct {
+	config
+	mutation *ReadingProgressMutation
+	hooks    []Hook
+}
+
+// SetDocument sets the "document" field.
+func (rpc *ReadingProgressCreate) SetDocument(s string) *ReadingProgressCreate {
+	rpc.mutation.SetDocument(s)
+	return rpc
+}
+
+// SetProgress sets the "progress" field.
+func (rpc *ReadingProgressCreate) SetProgress(s string) *ReadingProgressCreate {
+	rpc.mutation.SetProgress(s)
+	return rpc
+}
+
+// SetPercentage sets the "percentage" field.
+func (rpc *ReadingProgressCreate) SetPercentage(f float64) *ReadingProgressCreate {
+	rpc.mutation.SetPercentage(f)
+	return rpc
+}
+
+// SetDevice sets the "device" field.
+func (rpc *ReadingProgressCreate) SetDevice(s string) *ReadingProgressCreate {
+	rpc.mutation.SetDevice(s)
+	return rpc
+}
+
+// SetDeviceID sets the "device_id" field.
+func (rpc *ReadingProgressCreate) SetDeviceID(s string) *ReadingProgressCreate {
+	rpc.mutation.SetDeviceID(s)
+	return rpc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (rpc *ReadingProgressCreate) SetUpdatedAt(i int64) *ReadingProgressCreate {
+	rpc.mutation.SetUpdatedAt(i)
+	return rpc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (rpc *ReadingProgressCreate) SetNillableUpdatedAt(i *int64) *ReadingProgressCreate {
+	if i != nil {
+		rpc.SetUpdatedAt(*i)
+	}
+	return rpc
+}
+
+// SetID sets the "id" field.
+func (rpc *ReadingProgressCreate) SetID(i int64) *ReadingProgressCreate {
+	rpc.mutation.SetID(i)
+	return rpc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (rpc *ReadingProgressCreate) SetUserID(id int64) *ReadingProgressCreate {
+	rpc.mutation.SetUserID(id)
+	return rpc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (rpc *ReadingProgressCreate) SetUser(u *User) *ReadingProgressCreate {
+	return rpc.SetUserID(u.ID)
+}
+
+// Mutation returns the ReadingProgressMutation object of the builder.
+func (rpc *ReadingProgressCreate) Mutation() *ReadingProgressMutation {
+	return rpc.mutation
+}
+
+// Save creates the ReadingProgress in the database.
+func (rpc *ReadingProgressCreate) Save(ctx context.Context) (*ReadingProgress, error) {
+	rpc.defaults()
+	return withHooks(ctx, rpc.sqlSave, rpc.mutation, rpc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rpc *ReadingProgressCreate) SaveX(ctx context.Context) *ReadingProgress {
+	v, err := rpc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rpc *ReadingProgressCreate) Exec(ctx context.Context) error {
+	_, err := rpc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rpc *ReadingProgressCreate) ExecX(ctx context.Context) {
+	if err := rpc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rpc *ReadingProgressCreate) defaults() {
+	if _, ok := rpc.mutation.UpdatedAt(); !ok {
+		v := readingprogress.DefaultUpdatedAt()
+		rpc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rpc *ReadingProgressCreate) check() error {
+	if _, ok := rpc.mutation.Document(); !ok {
+		return &ValidationError{Name: "document", err: errors.New(`ent: missing required field "ReadingProgress.document"`)}
+	}
+	if v, ok := rpc.mutation.Document(); ok {
+		if err := readingprogress.DocumentValidator(v); err != nil {
+			return &ValidationError{Name: "document", err: fmt.Errorf(`ent: validator failed for field "ReadingProgress.document": %w`, err)}
+		}
+	}
+	if _, ok := rpc.mutation.Progress(); !ok {
+		return &ValidationError{Name: "progress", err: errors.New(`ent: missing required field "ReadingProgress.progress"`)}
+	}
+	if _, ok := rpc.mutation.Percentage(); !ok {
+		return &ValidationError{Name: "percentage", err: errors.New(`ent: missing required field "ReadingProgress.percentage"`)}
+	}
+	if _, ok := rpc.mutation.Device(); !ok {
+		return &ValidationError{Name: "device", err: errors.New(`ent: missing required field "ReadingProgress.device"`)}
+	}
+	if _, ok := rpc.mutation.DeviceID(); !ok {
+		return &ValidationError{Name: "device_id", err: errors.New(`ent: missing required field "ReadingProgress.device_id"`)}
+	}
+	if _, ok := rpc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "ReadingProgress.updated_at"`)}
+	}
+	if _, ok := rpc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "ReadingProgress.user"`)}
+	}
+	return nil
+}
+
+func (rpc *ReadingProgressCreate) sqlSave(ctx context.Context) (*ReadingProgress, error) {
+	if err := rpc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := rpc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rpc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	rpc.mutation.id = &_node.ID
+	rpc.mutation.done = true
+	return _node, nil
+}
+
+func (rpc *ReadingProgressCreate) createSpec() (*ReadingProgress, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ReadingProgress{config: rpc.config}
+		_spec = sqlgraph.NewCreateSpec(readingprogress.Table, sqlgraph.NewFieldSpec(readingprogress.FieldID, field.TypeInt64))
+	)
+	if id, ok := rpc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := rpc.mutation.Document(); ok {
+		_spec.SetField(readingprogress.FieldDocument, field.TypeString, value)
+		_node.Document = value
+	}
+	if value, ok := rpc.mutation.Progress(); ok {
+		_spec.SetField(readingprogress.FieldProgress, field.TypeString, value)
+		_node.Progress = value
+	}
+	if value, ok := rpc.mutation.Percentage(); ok {
+		_spec.SetField(readingprogress.FieldPercentage, field.TypeFloat64, value)
+		_node.Percentage = value
+	}
+	if value, ok := rpc.mutation.Device(); ok {
+		_spec.SetField(readingprogress.FieldDevice, field.TypeString, value)
+		_node.Device = value
+	}
+	if value, ok := rpc.mutation.DeviceID(); ok {
+		_spec.SetField(readingprogress.FieldDeviceID, field.TypeString, value)
+		_node.DeviceID = value
+	}
+	if value, ok := rpc.mutation.UpdatedAt(); ok {
+		_spec.SetField(readingprogress.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := rpc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   readingprogress.UserTable,
+			Columns: []string{readingprogress.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.reading_progress_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ReadingProgressCreateBulk is the builder for creating many ReadingProgress entities in bulk.
+type ReadingProgressCreateBulk struct {
+	config
+	err      error
+	builders []*ReadingProgressCreate
+}
+
+// Save creates the ReadingProgress entities in the database.
+func (rpcb *ReadingProgressCreateBulk) Save(ctx context.Context) ([]*ReadingProgress, error) {
+	if rpcb.err != nil {
+		return nil, rpcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(rpcb.builders))
+	nodes := make([]*ReadingProgress, len(rpcb.builders))
+	mutators := make([]Mutator, len(rpcb.builders))
+	for i := range rpcb.builders {
+		func(i int, root context.Context) {
+			builder := rpcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ReadingProgressMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rpcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rpcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rpcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rpcb *ReadingProgressCreateBulk) SaveX(ctx context.Context) []*ReadingProgress {
+	v, err := rpcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rpcb *ReadingProgressCreateBulk) Exec(ctx context.Context) error {
+	_, err := rpcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rpcb *ReadingProgressCreateBulk) ExecX(ctx context.Context) {
+	if err := rpcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+)
+
+// ReadingProgressDelete is the builder for deleting a ReadingProgress entity.
+type ReadingProgressDelete struct {
+	config
+	hooks    []Hook
+	mutation *ReadingProgressMutation
+}
+
+// Where appends a list predicates to the ReadingProgressDelete builder.
+func (rpd *ReadingProgressDelete) Where(ps ...predicate.ReadingProgress) *ReadingProgressDelete {
+	rpd.mutation.Where(ps...)
+	return rpd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (rpd *ReadingProgressDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, rpd.sqlExec, rpd.mutation, rpd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rpd *ReadingProgressDelete) ExecX(ctx context.Context) int {
+	n, err := rpd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (rpd *ReadingProgressDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(readingprogress.Table, sqlgraph.NewFieldSpec(readingprogress.FieldID, field.TypeInt64))
+	if ps := rpd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, rpd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	rpd.mutation.done = true
+	return affected, err
+}
+
+// ReadingProgressDeleteOne is the builder for deleting a single ReadingProgress entity.
+type ReadingProgressDeleteOne struct {
+	rpd *ReadingProgressDelete
+}
+
+// Where appends a list predicates to the ReadingProgressDelete builder.
+func (rpdo *ReadingProgressDeleteOne) Where(ps ...predicate.ReadingProgress) *ReadingProgressDeleteOne {
+	rpdo.rpd.mutation.Where(ps...)
+	return rpdo
+}
+
+// Exec executes the deletion query.
+func (rpdo *ReadingProgressDeleteOne) Exec(ctx context.Context) error {
+	n, err := rpdo.rpd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{readingprogress.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rpdo *ReadingProgressDeleteOne) ExecX(ctx context.Context) {
+	if err := rpdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
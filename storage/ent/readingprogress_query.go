@@ -0,0 +1,613 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ReadingProgressQuery is the builder for querying ReadingProgress entities.
+type ReadingProgressQuery struct {
+	config
+	ctx        *QueryContext
+	order      []readingprogress.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ReadingProgress
+	withUser   *UserQuery
+	withFKs    bool
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ReadingProgressQuery builder.
+func (rpq *ReadingProgressQuery) Where(ps ...predicate.ReadingProgress) *ReadingProgressQuery {
+	rpq.predicates = append(rpq.predicates, ps...)
+	return rpq
+}
+
+// Limit the number of records to be returned by this query.
+func (rpq *ReadingProgressQuery) Limit(limit int) *ReadingProgressQuery {
+	rpq.ctx.Limit = &limit
+	return rpq
+}
+
+// Offset to start from.
+func (rpq *ReadingProgressQuery) Offset(offset int) *ReadingProgressQuery {
+	rpq.ctx.Offset = &offset
+	return rpq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (rpq *ReadingProgressQuery) Unique(unique bool) *ReadingProgressQuery {
+	rpq.ctx.Unique = &unique
+	return rpq
+}
+
+// Order specifies how the records should be ordered.
+func (rpq *ReadingProgressQuery) Order(o ...readingprogress.OrderOption) *ReadingProgressQuery {
+	rpq.order = append(rpq.order, o...)
+	return rpq
+}
+
+// QueryUser chains the current query on the "user" edge.
+func (rpq *ReadingProgressQuery) QueryUser() *UserQuery {
+	query := (&UserClient{config: rpq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := rpq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := rpq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(readingprogress.Table, readingprogress.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, readingprogress.UserTable, readingprogress.UserColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(rpq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first ReadingProgress entity from the query.
+// Returns a *NotFoundError when no ReadingProgress was found.
+func (rpq *ReadingProgressQuery) First(ctx context.Context) (*ReadingProgress, error) {
+	nodes, err := rpq.Limit(1).All(setContextOp(ctx, rpq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{readingprogress.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) FirstX(ctx context.Context) *ReadingProgress {
+	node, err := rpq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ReadingProgress ID from the query.
+// Returns a *NotFoundError when no ReadingProgress ID was found.
+func (rpq *ReadingProgressQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = rpq.Limit(1).IDs(setContextOp(ctx, rpq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{readingprogress.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := rpq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ReadingProgress entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ReadingProgress entity is found.
+// Returns a *NotFoundError when no ReadingProgress entities are found.
+func (rpq *ReadingProgressQuery) Only(ctx context.Context) (*ReadingProgress, error) {
+	nodes, err := rpq.Limit(2).All(setContextOp(ctx, rpq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{readingprogress.Label}
+	default:
+		return nil, &NotSingularError{readingprogress.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) OnlyX(ctx context.Context) *ReadingProgress {
+	node, err := rpq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ReadingProgress ID in the query.
+// Returns a *NotSingularError when more than one ReadingProgress ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (rpq *ReadingProgressQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = rpq.Limit(2).IDs(setContextOp(ctx, rpq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{readingprogress.Label}
+	default:
+		err = &NotSingularError{readingprogress.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := rpq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ReadingProgresses.
+func (rpq *ReadingProgressQuery) All(ctx context.Context) ([]*ReadingProgress, error) {
+	ctx = setContextOp(ctx, rpq.ctx, "All")
+	if err := rpq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ReadingProgress, *ReadingProgressQuery]()
+	return withInterceptors[[]*ReadingProgress](ctx, rpq, qr, rpq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) AllX(ctx context.Context) []*ReadingProgress {
+	nodes, err := rpq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ReadingProgress IDs.
+func (rpq *ReadingProgressQuery) IDs(ctx context.Context) (ids []int64, err error) {
+	if rpq.ctx.Unique == nil && rpq.path != nil {
+		rpq.Unique(true)
+	}
+	ctx = setContextOp(ctx, rpq.ctx, "IDs")
+	if err = rpq.Select(readingprogress.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := rpq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (rpq *ReadingProgressQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, rpq.ctx, "Count")
+	if err := rpq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, rpq, querierCount[*ReadingProgressQuery](), rpq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) CountX(ctx context.Context) int {
+	count, err := rpq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (rpq *ReadingProgressQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, rpq.ctx, "Exist")
+	switch _, err := rpq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (rpq *ReadingProgressQuery) ExistX(ctx context.Context) bool {
+	exist, err := rpq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ReadingProgressQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (rpq *ReadingProgressQuery) Clone() *ReadingProgressQuery {
+	if rpq == nil {
+		return nil
+	}
+	return &ReadingProgressQuery{
+		config:     rpq.config,
+		ctx:        rpq.ctx.Clone(),
+		order:      append([]readingprogress.OrderOption{}, rpq.order...),
+		inters:     append([]Interceptor{}, rpq.inters...),
+		predicates: append([]predicate.ReadingProgress{}, rpq.predicates...),
+		withUser:   rpq.withUser.Clone(),
+		// clone intermediate query.
+		sql:  rpq.sql.Clone(),
+		path: rpq.path,
+	}
+}
+
+// WithUser tells the query-builder to eager-load the nodes that are connected to
+// the "user" edge. The optional arguments are used to configure the query builder of the edge.
+func (rpq *ReadingProgressQuery) WithUser(opts ...func(*UserQuery)) *ReadingProgressQuery {
+	query := (&UserClient{config: rpq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	rpq.withUser = query
+	return rpq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		Document string `json:"document,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ReadingProgress.Query().
+//		GroupBy(readingprogress.FieldDocument).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (rpq *ReadingProgressQuery) GroupBy(field string, fields ...string) *ReadingProgressGroupBy {
+	rpq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ReadingProgressGroupBy{build: rpq}
+	grbuild.flds = &rpq.ctx.Fields
+	grbuild.label = readingprogress.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		Document string `json:"document,omitempty"`
+//	}
+//
+//	client.ReadingProgress.Query().
+//		Select(readingprogress.FieldDocument).
+//		Scan(ctx, &v)
+func (rpq *ReadingProgressQuery) Select(fields ...string) *ReadingProgressSelect {
+	rpq.ctx.Fields = append(rpq.ctx.Fields, fields...)
+	sbuild := &ReadingProgressSelect{ReadingProgressQuery: rpq}
+	sbuild.label = readingprogress.Label
+	sbuild.flds, sbuild.scan = &rpq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ReadingProgressSelect configured with the given aggregations.
+func (rpq *ReadingProgressQuery) Aggregate(fns ...AggregateFunc) *ReadingProgressSelect {
+	return rpq.Select().Aggregate(fns...)
+}
+
+func (rpq *ReadingProgressQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range rpq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, rpq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range rpq.ctx.Fields {
+		if !readingprogress.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if rpq.path != nil {
+		prev, err := rpq.path(ctx)
+		if err != nil {
+			return err
+		}
+		rpq.sql = prev
+	}
+	return nil
+}
+
+func (rpq *ReadingProgressQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ReadingProgress, error) {
+	var (
+		nodes       = []*ReadingProgress{}
+		withFKs     = rpq.withFKs
+		_spec       = rpq.querySpec()
+		loadedTypes = [1]bool{
+			rpq.withUser != nil,
+		}
+	)
+	if rpq.withUser != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, readingprogress.ForeignKeys...)
+	}
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ReadingProgress).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ReadingProgress{config: rpq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, rpq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := rpq.withUser; query != nil {
+		if err := rpq.loadUser(ctx, query, nodes, nil,
+			func(n *ReadingProgress, e *User) { n.Edges.User = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (rpq *ReadingProgressQuery) loadUser(ctx context.Context, query *UserQuery, nodes []*ReadingProgress, init func(*ReadingProgress), assign func(*ReadingProgress, *User)) error {
+	ids := make([]int64, 0, len(nodes))
+	nodeids := make(map[int64][]*ReadingProgress)
+	for i := range nodes {
+		if nodes[i].reading_progress_user == nil {
+			continue
+		}
+		fk := *nodes[i].reading_progress_user
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(user.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "reading_progress_user" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (rpq *ReadingProgressQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := rpq.querySpec()
+	_spec.Node.Columns = rpq.ctx.Fields
+	if len(rpq.ctx.Fields) > 0 {
+		_spec.Unique = rpq.ctx.Unique != nil && *rpq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, rpq.driver, _spec)
+}
+
+func (rpq *ReadingProgressQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(readingprogress.Table, readingprogress.Columns, sqlgraph.NewFieldSpec(readingprogress.FieldID, field.TypeInt64))
+	_spec.From = rpq.sql
+	if unique := rpq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if rpq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := rpq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, readingprogress.FieldID)
+		for i := range fields {
+			if fields[i] != readingprogress.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := rpq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := rpq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := rpq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := rpq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (rpq *ReadingProgressQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(rpq.driver.Dialect())
+	t1 := builder.Table(readingprogress.Table)
+	columns := rpq.ctx.Fields
+	if len(columns) == 0 {
+		columns = readingprogress.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if rpq.sql != nil {
+		selector = rpq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if rpq.ctx.Unique != nil && *rpq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range rpq.predicates {
+		p(selector)
+	}
+	for _, p := range rpq.order {
+		p(selector)
+	}
+	if offset := rpq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := rpq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ReadingProgressGroupBy is the group-by builder for ReadingProgress entities.
+type ReadingProgressGroupBy struct {
+	selector
+	build *ReadingProgressQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (rpgb *ReadingProgressGroupBy) Aggregate(fns ...AggregateFunc) *ReadingProgressGroupBy {
+	rpgb.fns = append(rpgb.fns, fns...)
+	return rpgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (rpgb *ReadingProgressGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, rpgb.build.ctx, "GroupBy")
+	if err := rpgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ReadingProgressQuery, *ReadingProgressGroupBy](ctx, rpgb.build, rpgb, rpgb.build.inters, v)
+}
+
+func (rpgb *ReadingProgressGroupBy) sqlScan(ctx context.Context, root *ReadingProgressQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(rpgb.fns))
+	for _, fn := range rpgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*rpgb.flds)+len(rpgb.fns))
+		for _, f := range *rpgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*rpgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := rpgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ReadingProgressSelect is the builder for selecting fields of ReadingProgress entities.
+type ReadingProgressSelect struct {
+	*ReadingProgressQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (rps *ReadingProgressSelect) Aggregate(fns ...AggregateFunc) *ReadingProgressSelect {
+	rps.fns = append(rps.fns, fns...)
+	return rps
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (rps *ReadingProgressSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, rps.ctx, "Select")
+	if err := rps.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ReadingProgressQuery, *ReadingProgressSelect](ctx, rps.ReadingProgressQuery, rps, rps.inters, v)
+}
+
+func (rps *ReadingProgressSelect) sqlScan(ctx context.Context, root *ReadingProgressQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(rps.fns))
+	for _, fn := range rps.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*rps.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := rps.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,466 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ReadingProgressUpdate is the builder for updating ReadingProgress entities.
+type ReadingProgressUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ReadingProgressMutation
+}
+
+// Where appends a list predicates to the ReadingProgressUpdate builder.
+func (rpu *ReadingProgressUpdate) Where(ps ...predicate.ReadingProgress) *ReadingProgressUpdate {
+	rpu.mutation.Where(ps...)
+	return rpu
+}
+
+// SetDocument sets the "document" field.
+func (rpu *ReadingProgressUpdate) SetDocument(s string) *ReadingProgressUpdate {
+	rpu.mutation.SetDocument(s)
+	return rpu
+}
+
+// SetProgress sets the "progress" field.
+func (rpu *ReadingProgressUpdate) SetProgress(s string) *ReadingProgressUpdate {
+	rpu.mutation.SetProgress(s)
+	return rpu
+}
+
+// SetPercentage sets the "percentage" field.
+func (rpu *ReadingProgressUpdate) SetPercentage(f float64) *ReadingProgressUpdate {
+	rpu.mutation.ResetPercentage()
+	rpu.mutation.SetPercentage(f)
+	return rpu
+}
+
+// AddPercentage adds f to the "percentage" field.
+func (rpu *ReadingProgressUpdate) AddPercentage(f float64) *ReadingProgressUpdate {
+	rpu.mutation.AddPercentage(f)
+	return rpu
+}
+
+// SetDevice sets the "device" field.
+func (rpu *ReadingProgressUpdate) SetDevice(s string) *ReadingProgressUpdate {
+	rpu.mutation.SetDevice(s)
+	return rpu
+}
+
+// SetDeviceID sets the "device_id" field.
+func (rpu *ReadingProgressUpdate) SetDeviceID(s string) *ReadingProgressUpdate {
+	rpu.mutation.SetDeviceID(s)
+	return rpu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (rpu *ReadingProgressUpdate) SetUpdatedAt(i int64) *ReadingProgressUpdate {
+	rpu.mutation.ResetUpdatedAt()
+	rpu.mutation.SetUpdatedAt(i)
+	return rpu
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (rpu *ReadingProgressUpdate) AddUpdatedAt(i int64) *ReadingProgressUpdate {
+	rpu.mutation.AddUpdatedAt(i)
+	return rpu
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (rpu *ReadingProgressUpdate) SetUserID(id int64) *ReadingProgressUpdate {
+	rpu.mutation.SetUserID(id)
+	return rpu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (rpu *ReadingProgressUpdate) SetUser(u *User) *ReadingProgressUpdate {
+	return rpu.SetUserID(u.ID)
+}
+
+// Mutation returns the ReadingProgressMutation object of the builder.
+func (rpu *ReadingProgressUpdate) Mutation() *ReadingProgressMutation {
+	return rpu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (rpu *ReadingProgressUpdate) ClearUser() *ReadingProgressUpdate {
+	rpu.mutation.ClearUser()
+	return rpu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (rpu *ReadingProgressUpdate) Save(ctx context.Context) (int, error) {
+	rpu.defaults()
+	return withHooks(ctx, rpu.sqlSave, rpu.mutation, rpu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rpu *ReadingProgressUpdate) SaveX(ctx context.Context) int {
+	affected, err := rpu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (rpu *ReadingProgressUpdate) Exec(ctx context.Context) error {
+	_, err := rpu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rpu *ReadingProgressUpdate) ExecX(ctx context.Context) {
+	if err := rpu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rpu *ReadingProgressUpdate) defaults() {
+	if _, ok := rpu.mutation.UpdatedAt(); !ok {
+		v := readingprogress.UpdateDefaultUpdatedAt()
+		rpu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rpu *ReadingProgressUpdate) check() error {
+	if v, ok := rpu.mutation.Document(); ok {
+		if err := readingprogress.DocumentValidator(v); err != nil {
+			return &ValidationError{Name: "document", err: fmt.Errorf(`ent: validator failed for field "ReadingProgress.document": %w`, err)}
+		}
+	}
+	if _, ok := rpu.mutation.UserID(); rpu.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "ReadingProgress.user"`)
+	}
+	return nil
+}
+
+func (rpu *ReadingProgressUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := rpu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(readingprogress.Table, readingprogress.Columns, sqlgraph.NewFieldSpec(readingprogress.FieldID, field.TypeInt64))
+	if ps := rpu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rpu.mutation.Document(); ok {
+		_spec.SetField(readingprogress.FieldDocument, field.TypeString, value)
+	}
+	if value, ok := rpu.mutation.Progress(); ok {
+		_spec.SetField(readingprogress.FieldProgress, field.TypeString, value)
+	}
+	if value, ok := rpu.mutation.Percentage(); ok {
+		_spec.SetField(readingprogress.FieldPercentage, field.TypeFloat64, value)
+	}
+	if value, ok := rpu.mutation.AddedPercentage(); ok {
+		_spec.AddField(readingprogress.FieldPercentage, field.TypeFloat64, value)
+	}
+	if value, ok := rpu.mutation.Device(); ok {
+		_spec.SetField(readingprogress.FieldDevice, field.TypeString, value)
+	}
+	if value, ok := rpu.mutation.DeviceID(); ok {
+		_spec.SetField(readingprogress.FieldDeviceID, field.TypeString, value)
+	}
+	if value, ok := rpu.mutation.UpdatedAt(); ok {
+		_spec.SetField(readingprogress.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := rpu.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(readingprogress.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if rpu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   readingprogress.UserTable,
+			Columns: []string{readingprogress.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := rpu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   readingprogress.UserTable,
+			Columns: []string{readingprogress.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, rpu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{readingprogress.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	rpu.mutation.done = true
+	return n, nil
+}
+
+// ReadingProgressUpdateOne is the builder for updating a single ReadingProgress entity.
+type ReadingProgressUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ReadingProgressMutation
+}
+
+// SetDocument sets the "document" field.
+func (rpuo *ReadingProgressUpdateOne) SetDocument(s string) *ReadingProgressUpdateOne {
+	rpuo.mutation.SetDocument(s)
+	return rpuo
+}
+
+// SetProgress sets the "progress" field.
+func (rpuo *ReadingProgressUpdateOne) SetProgress(s string) *ReadingProgressUpdateOne {
+	rpuo.mutation.SetProgress(s)
+	return rpuo
+}
+
+// SetPercentage sets the "percentage" field.
+func (rpuo *ReadingProgressUpdateOne) SetPercentage(f float64) *ReadingProgressUpdateOne {
+	rpuo.mutation.ResetPercentage()
+	rpuo.mutation.SetPercentage(f)
+	return rpuo
+}
+
+// AddPercentage adds f to the "percentage" field.
+func (rpuo *ReadingProgressUpdateOne) AddPercentage(f float64) *ReadingProgressUpdateOne {
+	rpuo.mutation.AddPercentage(f)
+	return rpuo
+}
+
+// SetDevice sets the "device" field.
+func (rpuo *ReadingProgressUpdateOne) SetDevice(s string) *ReadingProgressUpdateOne {
+	rpuo.mutation.SetDevice(s)
+	return rpuo
+}
+
+// SetDeviceID sets the "device_id" field.
+func (rpuo *ReadingProgressUpdateOne) SetDeviceID(s string) *ReadingProgressUpdateOne {
+	rpuo.mutation.SetDeviceID(s)
+	return rpuo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (rpuo *ReadingProgressUpdateOne) SetUpdatedAt(i int64) *ReadingProgressUpdateOne {
+	rpuo.mutation.ResetUpdatedAt()
+	rpuo.mutation.SetUpdatedAt(i)
+	return rpuo
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (rpuo *ReadingProgressUpdateOne) AddUpdatedAt(i int64) *ReadingProgressUpdateOne {
+	rpuo.mutation.AddUpdatedAt(i)
+	return rpuo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (rpuo *ReadingProgressUpdateOne) SetUserID(id int64) *ReadingProgressUpdateOne {
+	rpuo.mutation.SetUserID(id)
+	return rpuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (rpuo *ReadingProgressUpdateOne) SetUser(u *User) *ReadingProgressUpdateOne {
+	return rpuo.SetUserID(u.ID)
+}
+
+// Mutation returns the ReadingProgressMutation object of the builder.
+func (rpuo *ReadingProgressUpdateOne) Mutation() *ReadingProgressMutation {
+	return rpuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (rpuo *ReadingProgressUpdateOne) ClearUser() *ReadingProgressUpdateOne {
+	rpuo.mutation.ClearUser()
+	return rpuo
+}
+
+// Where appends a list predicates to the ReadingProgressUpdate builder.
+func (rpuo *ReadingProgressUpdateOne) Where(ps ...predicate.ReadingProgress) *ReadingProgressUpdateOne {
+	rpuo.mutation.Where(ps...)
+	return rpuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (rpuo *ReadingProgressUpdateOne) Select(field string, fields ...string) *ReadingProgressUpdateOne {
+	rpuo.fields = append([]string{field}, fields...)
+	return rpuo
+}
+
+// Save executes the query and returns the updated ReadingProgress entity.
+func (rpuo *ReadingProgressUpdateOne) Save(ctx context.Context) (*ReadingProgress, error) {
+	rpuo.defaults()
+	return withHooks(ctx, rpuo.sqlSave, rpuo.mutation, rpuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rpuo *ReadingProgressUpdateOne) SaveX(ctx context.Context) *ReadingProgress {
+	node, err := rpuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (rpuo *ReadingProgressUpdateOne) Exec(ctx context.Context) error {
+	_, err := rpuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rpuo *ReadingProgressUpdateOne) ExecX(ctx context.Context) {
+	if err := rpuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rpuo *ReadingProgressUpdateOne) defaults() {
+	if _, ok := rpuo.mutation.UpdatedAt(); !ok {
+		v := readingprogress.UpdateDefaultUpdatedAt()
+		rpuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rpuo *ReadingProgressUpdateOne) check() error {
+	if v, ok := rpuo.mutation.Document(); ok {
+		if err := readingprogress.DocumentValidator(v); err != nil {
+			return &ValidationError{Name: "document", err: fmt.Errorf(`ent: validator failed for field "ReadingProgress.document": %w`, err)}
+		}
+	}
+	if _, ok := rpuo.mutation.UserID(); rpuo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "ReadingProgress.user"`)
+	}
+	return nil
+}
+
+func (rpuo *ReadingProgressUpdateOne) sqlSave(ctx context.Context) (_node *ReadingProgress, err error) {
+	if err := rpuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(readingprogress.Table, readingprogress.Columns, sqlgraph.NewFieldSpec(readingprogress.FieldID, field.TypeInt64))
+	id, ok := rpuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ReadingProgress.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := rpuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, readingprogress.FieldID)
+		for _, f := range fields {
+			if !readingprogress.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != readingprogress.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := rpuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := rpuo.mutation.Document(); ok {
+		_spec.SetField(readingprogress.FieldDocument, field.TypeString, value)
+	}
+	if value, ok := rpuo.mutation.Progress(); ok {
+		_spec.SetField(readingprogress.FieldProgress, field.TypeString, value)
+	}
+	if value, ok := rpuo.mutation.Percentage(); ok {
+		_spec.SetField(readingprogress.FieldPercentage, field.TypeFloat64, value)
+	}
+	if value, ok := rpuo.mutation.AddedPercentage(); ok {
+		_spec.AddField(readingprogress.FieldPercentage, field.TypeFloat64, value)
+	}
+	if value, ok := rpuo.mutation.Device(); ok {
+		_spec.SetField(readingprogress.FieldDevice, field.TypeString, value)
+	}
+	if value, ok := rpuo.mutation.DeviceID(); ok {
+		_spec.SetField(readingprogress.FieldDeviceID, field.TypeString, value)
+	}
+	if value, ok := rpuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(readingprogress.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := rpuo.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(readingprogress.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if rpuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   readingprogress.UserTable,
+			Columns: []string{readingprogress.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := rpuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   readingprogress.UserTable,
+			Columns: []string{readingprogress.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ReadingProgress{config: rpuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, rpuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{readingprogress.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	rpuo.mutation.done = true
+	return _node, nil
+}
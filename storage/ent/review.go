@@ -0,0 +1,265 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Review is the model entity for the Review schema.
+type Review struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Rating holds the value of the "rating" field.
+	Rating int `json:"rating,omitempty"`
+	// Text holds the value of the "text" field.
+	Text string `json:"text,omitempty"`
+	// Hidden holds the value of the "hidden" field.
+	Hidden bool `json:"hidden,omitempty"`
+	// HiddenAt holds the value of the "hidden_at" field.
+	HiddenAt *int64 `json:"hidden_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ReviewQuery when eager-loading is set.
+	Edges            ReviewEdges `json:"edges"`
+	review_book      *int64
+	review_user      *int64
+	review_hidden_by *int64
+	selectValues     sql.SelectValues
+}
+
+// ReviewEdges holds the relations/edges for other nodes in the graph.
+type ReviewEdges struct {
+	// Book holds the value of the book edge.
+	Book *Book `json:"book,omitempty"`
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// HiddenBy holds the value of the hidden_by edge.
+	HiddenBy *User `json:"hidden_by,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [3]bool
+}
+
+// BookOrErr returns the Book value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ReviewEdges) BookOrErr() (*Book, error) {
+	if e.loadedTypes[0] {
+		if e.Book == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: book.Label}
+		}
+		return e.Book, nil
+	}
+	return nil, &NotLoadedError{edge: "book"}
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ReviewEdges) UserOrErr() (*User, error) {
+	if e.loadedTypes[1] {
+		if e.User == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.User, nil
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// HiddenByOrErr returns the HiddenBy value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ReviewEdges) HiddenByOrErr() (*User, error) {
+	if e.loadedTypes[2] {
+		if e.HiddenBy == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.HiddenBy, nil
+	}
+	return nil, &NotLoadedError{edge: "hidden_by"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Review) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case review.FieldHidden:
+			values[i] = new(sql.NullBool)
+		case review.FieldID, review.FieldRating, review.FieldHiddenAt, review.FieldCreatedAt, review.FieldUpdatedAt:
+			values[i] = new(sql.NullInt64)
+		case review.FieldText:
+			values[i] = new(sql.NullString)
+		case review.ForeignKeys[0]: // review_book
+			values[i] = new(sql.NullInt64)
+		case review.ForeignKeys[1]: // review_user
+			values[i] = new(sql.NullInt64)
+		case review.ForeignKeys[2]: // review_hidden_by
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Review fields.
+func (r *Review) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case review.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			r.ID = int64(value.Int64)
+		case review.FieldRating:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rating", values[i])
+			} else if value.Valid {
+				r.Rating = int(value.Int64)
+			}
+		case review.FieldText:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field text", values[i])
+			} else if value.Valid {
+				r.Text = value.String
+			}
+		case review.FieldHidden:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field hidden", values[i])
+			} else if value.Valid {
+				r.Hidden = value.Bool
+			}
+		case review.FieldHiddenAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field hidden_at", values[i])
+			} else if value.Valid {
+				r.HiddenAt = new(int64)
+				*r.HiddenAt = value.Int64
+			}
+		case review.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				r.CreatedAt = value.Int64
+			}
+		case review.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				r.UpdatedAt = value.Int64
+			}
+		case review.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field review_book", value)
+			} else if value.Valid {
+				r.review_book = new(int64)
+				*r.review_book = int64(value.Int64)
+			}
+		case review.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field review_user", value)
+			} else if value.Valid {
+				r.review_user = new(int64)
+				*r.review_user = int64(value.Int64)
+			}
+		case review.ForeignKeys[2]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field review_hidden_by", value)
+			} else if value.Valid {
+				r.review_hidden_by = new(int64)
+				*r.review_hidden_by = int64(value.Int64)
+			}
+		default:
+			r.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Review.
+// This includes values selected through modifiers, order, etc.
+func (r *Review) Value(name string) (ent.Value, error) {
+	return r.selectValues.Get(name)
+}
+
+// QueryBook queries the "book" edge of the Review entity.
+func (r *Review) QueryBook() *BookQuery {
+	return NewReviewClient(r.config).QueryBook(r)
+}
+
+// QueryUser queries the "user" edge of the Review entity.
+func (r *Review) QueryUser() *UserQuery {
+	return NewReviewClient(r.config).QueryUser(r)
+}
+
+// QueryHiddenBy queries the "hidden_by" edge of the Review entity.
+func (r *Review) QueryHiddenBy() *UserQuery {
+	return NewReviewClient(r.config).QueryHiddenBy(r)
+}
+
+// Update returns a builder for updating this Review.
+// Note that you need to call Review.Unwrap() before calling this method if this Review
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (r *Review) Update() *ReviewUpdateOne {
+	return NewReviewClient(r.config).UpdateOne(r)
+}
+
+// Unwrap unwraps the Review entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (r *Review) Unwrap() *Review {
+	_tx, ok := r.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Review is not a transactional entity")
+	}
+	r.config.driver = _tx.drv
+	return r
+}
+
+// String implements the fmt.Stringer.
+func (r *Review) String() string {
+	var builder strings.Builder
+	builder.WriteString("Review(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", r.ID))
+	builder.WriteString("rating=")
+	builder.WriteString(fmt.Sprintf("%v", r.Rating))
+	builder.WriteString(", ")
+	builder.WriteString("text=")
+	builder.WriteString(r.Text)
+	builder.WriteString(", ")
+	builder.WriteString("hidden=")
+	builder.WriteString(fmt.Sprintf("%v", r.Hidden))
+	builder.WriteString(", ")
+	if v := r.HiddenAt; v != nil {
+		builder.WriteString("hidden_at=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", r.CreatedAt))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(fmt.Sprintf("%v", r.UpdatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Reviews is a parsable slice of Review.
+type Reviews []*Review
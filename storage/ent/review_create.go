@@ -0,0 +1,422 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ReviewCreate is the builder for creating a Review entity.
+type ReviewCreate struct {
+	config
+	mutation *ReviewMutation
+	hooks    []Hook
+}
+
+// SetRating sets the "rating" field.
+func (rc *ReviewCreate) SetRating(i int) *ReviewCreate {
+	rc.mutation.SetRating(i)
+	return rc
+}
+
+// SetText sets the "text" field.
+func (rc *ReviewCreate) SetText(s string) *ReviewCreate {
+	rc.mutation.SetText(s)
+	return rc
+}
+
+// SetNillableText sets the "text" field if the given value is not nil.
+func (rc *ReviewCreate) SetNillableText(s *string) *ReviewCreate {
+	if s != nil {
+		rc.SetText(*s)
+	}
+	return rc
+}
+
+// SetHidden sets the "hidden" field.
+func (rc *ReviewCreate) SetHidden(b bool) *ReviewCreate {
+	rc.mutation.SetHidden(b)
+	return rc
+}
+
+// SetNillableHidden sets the "hidden" field if the given value is not nil.
+func (rc *ReviewCreate) SetNillableHidden(b *bool) *ReviewCreate {
+	if b != nil {
+		rc.SetHidden(*b)
+	}
+	return rc
+}
+
+// SetHiddenAt sets the "hidden_at" field.
+func (rc *ReviewCreate) SetHiddenAt(i int64) *ReviewCreate {
+	rc.mutation.SetHiddenAt(i)
+	return rc
+}
+
+// SetNillableHiddenAt sets the "hidden_at" field if the given value is not nil.
+func (rc *ReviewCreate) SetNillableHiddenAt(i *int64) *ReviewCreate {
+	if i != nil {
+		rc.SetHiddenAt(*i)
+	}
+	return rc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (rc *ReviewCreate) SetCreatedAt(i int64) *ReviewCreate {
+	rc.mutation.SetCreatedAt(i)
+	return rc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (rc *ReviewCreate) SetNillableCreatedAt(i *int64) *ReviewCreate {
+	if i != nil {
+		rc.SetCreatedAt(*i)
+	}
+	return rc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (rc *ReviewCreate) SetUpdatedAt(i int64) *ReviewCreate {
+	rc.mutation.SetUpdatedAt(i)
+	return rc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (rc *ReviewCreate) SetNillableUpdatedAt(i *int64) *ReviewCreate {
+	if i != nil {
+		rc.SetUpdatedAt(*i)
+	}
+	return rc
+}
+
+// SetID sets the "id" field.
+func (rc *ReviewCreate) SetID(i int64) *ReviewCreate {
+	rc.mutation.SetID(i)
+	return rc
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (rc *ReviewCreate) SetBookID(id int64) *ReviewCreate {
+	rc.mutation.SetBookID(id)
+	return rc
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (rc *ReviewCreate) SetBook(b *Book) *ReviewCreate {
+	return rc.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (rc *ReviewCreate) SetUserID(id int64) *ReviewCreate {
+	rc.mutation.SetUserID(id)
+	return rc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (rc *ReviewCreate) SetUser(u *User) *ReviewCreate {
+	return rc.SetUserID(u.ID)
+}
+
+// SetHiddenByID sets the "hidden_by" edge to the User entity by ID.
+func (rc *ReviewCreate) SetHiddenByID(id int64) *ReviewCreate {
+	rc.mutation.SetHiddenByID(id)
+	return rc
+}
+
+// SetNillableHiddenByID sets the "hidden_by" edge to the User entity by ID if the given value is not nil.
+func (rc *ReviewCreate) SetNillableHiddenByID(id *int64) *ReviewCreate {
+	if id != nil {
+		rc = rc.SetHiddenByID(*id)
+	}
+	return rc
+}
+
+// SetHiddenBy sets the "hidden_by" edge to the User entity.
+func (rc *ReviewCreate) SetHiddenBy(u *User) *ReviewCreate {
+	return rc.SetHiddenByID(u.ID)
+}
+
+// Mutation returns the ReviewMutation object of the builder.
+func (rc *ReviewCreate) Mutation() *ReviewMutation {
+	return rc.mutation
+}
+
+// Save creates the Review in the database.
+func (rc *ReviewCreate) Save(ctx context.Context) (*Review, error) {
+	rc.defaults()
+	return withHooks(ctx, rc.sqlSave, rc.mutation, rc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rc *ReviewCreate) SaveX(ctx context.Context) *Review {
+	v, err := rc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rc *ReviewCreate) Exec(ctx context.Context) error {
+	_, err := rc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rc *ReviewCreate) ExecX(ctx context.Context) {
+	if err := rc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rc *ReviewCreate) defaults() {
+	if _, ok := rc.mutation.Hidden(); !ok {
+		v := review.DefaultHidden
+		rc.mutation.SetHidden(v)
+	}
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		v := review.DefaultCreatedAt()
+		rc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := rc.mutation.UpdatedAt(); !ok {
+		v := review.DefaultUpdatedAt()
+		rc.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rc *ReviewCreate) check() error {
+	if _, ok := rc.mutation.Rating(); !ok {
+		return &ValidationError{Name: "rating", err: errors.New(`ent: missing required field "Review.rating"`)}
+	}
+	if v, ok := rc.mutation.Rating(); ok {
+		if err := review.RatingValidator(v); err != nil {
+			return &ValidationError{Name: "rating", err: fmt.Errorf(`ent: validator failed for field "Review.rating": %w`, err)}
+		}
+	}
+	if v, ok := rc.mutation.Text(); ok {
+		if err := review.TextValidator(v); err != nil {
+			return &ValidationError{Name: "text", err: fmt.Errorf(`ent: validator failed for field "Review.text": %w`, err)}
+		}
+	}
+	if _, ok := rc.mutation.Hidden(); !ok {
+		return &ValidationError{Name: "hidden", err: errors.New(`ent: missing required field "Review.hidden"`)}
+	}
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Review.created_at"`)}
+	}
+	if _, ok := rc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Review.updated_at"`)}
+	}
+	if _, ok := rc.mutation.BookID(); !ok {
+		return &ValidationError{Name: "book", err: errors.New(`ent: missing required edge "Review.book"`)}
+	}
+	if _, ok := rc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Review.user"`)}
+	}
+	return nil
+}
+
+func (rc *ReviewCreate) sqlSave(ctx context.Context) (*Review, error) {
+	if err := rc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := rc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	rc.mutation.id = &_node.ID
+	rc.mutation.done = true
+	return _node, nil
+}
+
+func (rc *ReviewCreate) createSpec() (*Review, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Review{config: rc.config}
+		_spec = sqlgraph.NewCreateSpec(review.Table, sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64))
+	)
+	if id, ok := rc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := rc.mutation.Rating(); ok {
+		_spec.SetField(review.FieldRating, field.TypeInt, value)
+		_node.Rating = value
+	}
+	if value, ok := rc.mutation.Text(); ok {
+		_spec.SetField(review.FieldText, field.TypeString, value)
+		_node.Text = value
+	}
+	if value, ok := rc.mutation.Hidden(); ok {
+		_spec.SetField(review.FieldHidden, field.TypeBool, value)
+		_node.Hidden = value
+	}
+	if value, ok := rc.mutation.HiddenAt(); ok {
+		_spec.SetField(review.FieldHiddenAt, field.TypeInt64, value)
+		_node.HiddenAt = &value
+	}
+	if value, ok := rc.mutation.CreatedAt(); ok {
+		_spec.SetField(review.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := rc.mutation.UpdatedAt(); ok {
+		_spec.SetField(review.FieldUpdatedAt, field.TypeInt64, value)
+		_node.UpdatedAt = value
+	}
+	if nodes := rc.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.BookTable,
+			Columns: []string{review.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.review_book = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := rc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.UserTable,
+			Columns: []string{review.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.review_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := rc.mutation.HiddenByIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.HiddenByTable,
+			Columns: []string{review.HiddenByColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.review_hidden_by = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ReviewCreateBulk is the builder for creating many Review entities in bulk.
+type ReviewCreateBulk struct {
+	config
+	err      error
+	builders []*ReviewCreate
+}
+
+// Save creates the Review entities in the database.
+func (rcb *ReviewCreateBulk) Save(ctx context.Context) ([]*Review, error) {
+	if rcb.err != nil {
+		return nil, rcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(rcb.builders))
+	nodes := make([]*Review, len(rcb.builders))
+	mutators := make([]Mutator, len(rcb.builders))
+	for i := range rcb.builders {
+		func(i int, root context.Context) {
+			builder := rcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ReviewMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rcb *ReviewCreateBulk) SaveX(ctx context.Context) []*Review {
+	v, err := rcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rcb *ReviewCreateBulk) Exec(ctx context.Context) error {
+	_, err := rcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rcb *ReviewCreateBulk) ExecX(ctx context.Context) {
+	if err := rcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
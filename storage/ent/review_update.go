@@ -0,0 +1,769 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ReviewUpdate is the builder for updating Review entities.
+type ReviewUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ReviewMutation
+}
+
+// Where appends a list predicates to the ReviewUpdate builder.
+func (ru *ReviewUpdate) Where(ps ...predicate.Review) *ReviewUpdate {
+	ru.mutation.Where(ps...)
+	return ru
+}
+
+// SetRating sets the "rating" field.
+func (ru *ReviewUpdate) SetRating(i int) *ReviewUpdate {
+	ru.mutation.ResetRating()
+	ru.mutation.SetRating(i)
+	return ru
+}
+
+// AddRating adds i to the "rating" field.
+func (ru *ReviewUpdate) AddRating(i int) *ReviewUpdate {
+	ru.mutation.AddRating(i)
+	return ru
+}
+
+// SetText sets the "text" field.
+func (ru *ReviewUpdate) SetText(s string) *ReviewUpdate {
+	ru.mutation.SetText(s)
+	return ru
+}
+
+// SetNillableText sets the "text" field if the given value is not nil.
+func (ru *ReviewUpdate) SetNillableText(s *string) *ReviewUpdate {
+	if s != nil {
+		ru.SetText(*s)
+	}
+	return ru
+}
+
+// ClearText clears the value of the "text" field.
+func (ru *ReviewUpdate) ClearText() *ReviewUpdate {
+	ru.mutation.ClearText()
+	return ru
+}
+
+// SetHidden sets the "hidden" field.
+func (ru *ReviewUpdate) SetHidden(b bool) *ReviewUpdate {
+	ru.mutation.SetHidden(b)
+	return ru
+}
+
+// SetNillableHidden sets the "hidden" field if the given value is not nil.
+func (ru *ReviewUpdate) SetNillableHidden(b *bool) *ReviewUpdate {
+	if b != nil {
+		ru.SetHidden(*b)
+	}
+	return ru
+}
+
+// SetHiddenAt sets the "hidden_at" field.
+func (ru *ReviewUpdate) SetHiddenAt(i int64) *ReviewUpdate {
+	ru.mutation.ResetHiddenAt()
+	ru.mutation.SetHiddenAt(i)
+	return ru
+}
+
+// SetNillableHiddenAt sets the "hidden_at" field if the given value is not nil.
+func (ru *ReviewUpdate) SetNillableHiddenAt(i *int64) *ReviewUpdate {
+	if i != nil {
+		ru.SetHiddenAt(*i)
+	}
+	return ru
+}
+
+// AddHiddenAt adds i to the "hidden_at" field.
+func (ru *ReviewUpdate) AddHiddenAt(i int64) *ReviewUpdate {
+	ru.mutation.AddHiddenAt(i)
+	return ru
+}
+
+// ClearHiddenAt clears the value of the "hidden_at" field.
+func (ru *ReviewUpdate) ClearHiddenAt() *ReviewUpdate {
+	ru.mutation.ClearHiddenAt()
+	return ru
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ru *ReviewUpdate) SetUpdatedAt(i int64) *ReviewUpdate {
+	ru.mutation.ResetUpdatedAt()
+	ru.mutation.SetUpdatedAt(i)
+	return ru
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (ru *ReviewUpdate) AddUpdatedAt(i int64) *ReviewUpdate {
+	ru.mutation.AddUpdatedAt(i)
+	return ru
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (ru *ReviewUpdate) SetBookID(id int64) *ReviewUpdate {
+	ru.mutation.SetBookID(id)
+	return ru
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (ru *ReviewUpdate) SetBook(b *Book) *ReviewUpdate {
+	return ru.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (ru *ReviewUpdate) SetUserID(id int64) *ReviewUpdate {
+	ru.mutation.SetUserID(id)
+	return ru
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (ru *ReviewUpdate) SetUser(u *User) *ReviewUpdate {
+	return ru.SetUserID(u.ID)
+}
+
+// SetHiddenByID sets the "hidden_by" edge to the User entity by ID.
+func (ru *ReviewUpdate) SetHiddenByID(id int64) *ReviewUpdate {
+	ru.mutation.SetHiddenByID(id)
+	return ru
+}
+
+// SetNillableHiddenByID sets the "hidden_by" edge to the User entity by ID if the given value is not nil.
+func (ru *ReviewUpdate) SetNillableHiddenByID(id *int64) *ReviewUpdate {
+	if id != nil {
+		ru = ru.SetHiddenByID(*id)
+	}
+	return ru
+}
+
+// SetHiddenBy sets the "hidden_by" edge to the User entity.
+func (ru *ReviewUpdate) SetHiddenBy(u *User) *ReviewUpdate {
+	return ru.SetHiddenByID(u.ID)
+}
+
+// Mutation returns the ReviewMutation object of the builder.
+func (ru *ReviewUpdate) Mutation() *ReviewMutation {
+	return ru.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (ru *ReviewUpdate) ClearBook() *ReviewUpdate {
+	ru.mutation.ClearBook()
+	return ru
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (ru *ReviewUpdate) ClearUser() *ReviewUpdate {
+	ru.mutation.ClearUser()
+	return ru
+}
+
+// ClearHiddenBy clears the "hidden_by" edge to the User entity.
+func (ru *ReviewUpdate) ClearHiddenBy() *ReviewUpdate {
+	ru.mutation.ClearHiddenBy()
+	return ru
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ru *ReviewUpdate) Save(ctx context.Context) (int, error) {
+	ru.defaults()
+	return withHooks(ctx, ru.sqlSave, ru.mutation, ru.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ru *ReviewUpdate) SaveX(ctx context.Context) int {
+	affected, err := ru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ru *ReviewUpdate) Exec(ctx context.Context) error {
+	_, err := ru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ru *ReviewUpdate) ExecX(ctx context.Context) {
+	if err := ru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ru *ReviewUpdate) defaults() {
+	if _, ok := ru.mutation.UpdatedAt(); !ok {
+		v := review.UpdateDefaultUpdatedAt()
+		ru.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ru *ReviewUpdate) check() error {
+	if v, ok := ru.mutation.Rating(); ok {
+		if err := review.RatingValidator(v); err != nil {
+			return &ValidationError{Name: "rating", err: fmt.Errorf(`ent: validator failed for field "Review.rating": %w`, err)}
+		}
+	}
+	if v, ok := ru.mutation.Text(); ok {
+		if err := review.TextValidator(v); err != nil {
+			return &ValidationError{Name: "text", err: fmt.Errorf(`ent: validator failed for field "Review.text": %w`, err)}
+		}
+	}
+	if _, ok := ru.mutation.BookID(); ru.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Review.book"`)
+	}
+	if _, ok := ru.mutation.UserID(); ru.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Review.user"`)
+	}
+	return nil
+}
+
+func (ru *ReviewUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ru.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(review.Table, review.Columns, sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64))
+	if ps := ru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ru.mutation.Rating(); ok {
+		_spec.SetField(review.FieldRating, field.TypeInt, value)
+	}
+	if value, ok := ru.mutation.AddedRating(); ok {
+		_spec.AddField(review.FieldRating, field.TypeInt, value)
+	}
+	if value, ok := ru.mutation.Text(); ok {
+		_spec.SetField(review.FieldText, field.TypeString, value)
+	}
+	if ru.mutation.TextCleared() {
+		_spec.ClearField(review.FieldText, field.TypeString)
+	}
+	if value, ok := ru.mutation.Hidden(); ok {
+		_spec.SetField(review.FieldHidden, field.TypeBool, value)
+	}
+	if value, ok := ru.mutation.HiddenAt(); ok {
+		_spec.SetField(review.FieldHiddenAt, field.TypeInt64, value)
+	}
+	if value, ok := ru.mutation.AddedHiddenAt(); ok {
+		_spec.AddField(review.FieldHiddenAt, field.TypeInt64, value)
+	}
+	if ru.mutation.HiddenAtCleared() {
+		_spec.ClearField(review.FieldHiddenAt, field.TypeInt64)
+	}
+	if value, ok := ru.mutation.UpdatedAt(); ok {
+		_spec.SetField(review.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ru.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(review.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if ru.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.BookTable,
+			Columns: []string{review.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.BookTable,
+			Columns: []string{review.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ru.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.UserTable,
+			Columns: []string{review.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.UserTable,
+			Columns: []string{review.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ru.mutation.HiddenByCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.HiddenByTable,
+			Columns: []string{review.HiddenByColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.HiddenByIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.HiddenByTable,
+			Columns: []string{review.HiddenByColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{review.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ru.mutation.done = true
+	return n, nil
+}
+
+// ReviewUpdateOne is the builder for updating a single Review entity.
+type ReviewUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ReviewMutation
+}
+
+// SetRating sets the "rating" field.
+func (ruo *ReviewUpdateOne) SetRating(i int) *ReviewUpdateOne {
+	ruo.mutation.ResetRating()
+	ruo.mutation.SetRating(i)
+	return ruo
+}
+
+// AddRating adds i to the "rating" field.
+func (ruo *ReviewUpdateOne) AddRating(i int) *ReviewUpdateOne {
+	ruo.mutation.AddRating(i)
+	return ruo
+}
+
+// SetText sets the "text" field.
+func (ruo *ReviewUpdateOne) SetText(s string) *ReviewUpdateOne {
+	ruo.mutation.SetText(s)
+	return ruo
+}
+
+// SetNillableText sets the "text" field if the given value is not nil.
+func (ruo *ReviewUpdateOne) SetNillableText(s *string) *ReviewUpdateOne {
+	if s != nil {
+		ruo.SetText(*s)
+	}
+	return ruo
+}
+
+// ClearText clears the value of the "text" field.
+func (ruo *ReviewUpdateOne) ClearText() *ReviewUpdateOne {
+	ruo.mutation.ClearText()
+	return ruo
+}
+
+// SetHidden sets the "hidden" field.
+func (ruo *ReviewUpdateOne) SetHidden(b bool) *ReviewUpdateOne {
+	ruo.mutation.SetHidden(b)
+	return ruo
+}
+
+// SetNillableHidden sets the "hidden" field if the given value is not nil.
+func (ruo *ReviewUpdateOne) SetNillableHidden(b *bool) *ReviewUpdateOne {
+	if b != nil {
+		ruo.SetHidden(*b)
+	}
+	return ruo
+}
+
+// SetHiddenAt sets the "hidden_at" field.
+func (ruo *ReviewUpdateOne) SetHiddenAt(i int64) *ReviewUpdateOne {
+	ruo.mutation.ResetHiddenAt()
+	ruo.mutation.SetHiddenAt(i)
+	return ruo
+}
+
+// SetNillableHiddenAt sets the "hidden_at" field if the given value is not nil.
+func (ruo *ReviewUpdateOne) SetNillableHiddenAt(i *int64) *ReviewUpdateOne {
+	if i != nil {
+		ruo.SetHiddenAt(*i)
+	}
+	return ruo
+}
+
+// AddHiddenAt adds i to the "hidden_at" field.
+func (ruo *ReviewUpdateOne) AddHiddenAt(i int64) *ReviewUpdateOne {
+	ruo.mutation.AddHiddenAt(i)
+	return ruo
+}
+
+// ClearHiddenAt clears the value of the "hidden_at" field.
+func (ruo *ReviewUpdateOne) ClearHiddenAt() *ReviewUpdateOne {
+	ruo.mutation.ClearHiddenAt()
+	return ruo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ruo *ReviewUpdateOne) SetUpdatedAt(i int64) *ReviewUpdateOne {
+	ruo.mutation.ResetUpdatedAt()
+	ruo.mutation.SetUpdatedAt(i)
+	return ruo
+}
+
+// AddUpdatedAt adds i to the "updated_at" field.
+func (ruo *ReviewUpdateOne) AddUpdatedAt(i int64) *ReviewUpdateOne {
+	ruo.mutation.AddUpdatedAt(i)
+	return ruo
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (ruo *ReviewUpdateOne) SetBookID(id int64) *ReviewUpdateOne {
+	ruo.mutation.SetBookID(id)
+	return ruo
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (ruo *ReviewUpdateOne) SetBook(b *Book) *ReviewUpdateOne {
+	return ruo.SetBookID(b.ID)
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (ruo *ReviewUpdateOne) SetUserID(id int64) *ReviewUpdateOne {
+	ruo.mutation.SetUserID(id)
+	return ruo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (ruo *ReviewUpdateOne) SetUser(u *User) *ReviewUpdateOne {
+	return ruo.SetUserID(u.ID)
+}
+
+// SetHiddenByID sets the "hidden_by" edge to the User entity by ID.
+func (ruo *ReviewUpdateOne) SetHiddenByID(id int64) *ReviewUpdateOne {
+	ruo.mutation.SetHiddenByID(id)
+	return ruo
+}
+
+// SetNillableHiddenByID sets the "hidden_by" edge to the User entity by ID if the given value is not nil.
+func (ruo *ReviewUpdateOne) SetNillableHiddenByID(id *int64) *ReviewUpdateOne {
+	if id != nil {
+		ruo = ruo.SetHiddenByID(*id)
+	}
+	return ruo
+}
+
+// SetHiddenBy sets the "hidden_by" edge to the User entity.
+func (ruo *ReviewUpdateOne) SetHiddenBy(u *User) *ReviewUpdateOne {
+	return ruo.SetHiddenByID(u.ID)
+}
+
+// Mutation returns the ReviewMutation object of the builder.
+func (ruo *ReviewUpdateOne) Mutation() *ReviewMutation {
+	return ruo.mutation
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (ruo *ReviewUpdateOne) ClearBook() *ReviewUpdateOne {
+	ruo.mutation.ClearBook()
+	return ruo
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (ruo *ReviewUpdateOne) ClearUser() *ReviewUpdateOne {
+	ruo.mutation.ClearUser()
+	return ruo
+}
+
+// ClearHiddenBy clears the "hidden_by" edge to the User entity.
+func (ruo *ReviewUpdateOne) ClearHiddenBy() *ReviewUpdateOne {
+	ruo.mutation.ClearHiddenBy()
+	return ruo
+}
+
+// Where appends a list predicates to the ReviewUpdate builder.
+func (ruo *ReviewUpdateOne) Where(ps ...predicate.Review) *ReviewUpdateOne {
+	ruo.mutation.Where(ps...)
+	return ruo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ruo *ReviewUpdateOne) Select(field string, fields ...string) *ReviewUpdateOne {
+	ruo.fields = append([]string{field}, fields...)
+	return ruo
+}
+
+// Save executes the query and returns the updated Review entity.
+func (ruo *ReviewUpdateOne) Save(ctx context.Context) (*Review, error) {
+	ruo.defaults()
+	return withHooks(ctx, ruo.sqlSave, ruo.mutation, ruo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ruo *ReviewUpdateOne) SaveX(ctx context.Context) *Review {
+	node, err := ruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ruo *ReviewUpdateOne) Exec(ctx context.Context) error {
+	_, err := ruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ruo *ReviewUpdateOne) ExecX(ctx context.Context) {
+	if err := ruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ruo *ReviewUpdateOne) defaults() {
+	if _, ok := ruo.mutation.UpdatedAt(); !ok {
+		v := review.UpdateDefaultUpdatedAt()
+		ruo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ruo *ReviewUpdateOne) check() error {
+	if v, ok := ruo.mutation.Rating(); ok {
+		if err := review.RatingValidator(v); err != nil {
+			return &ValidationError{Name: "rating", err: fmt.Errorf(`ent: validator failed for field "Review.rating": %w`, err)}
+		}
+	}
+	if v, ok := ruo.mutation.Text(); ok {
+		if err := review.TextValidator(v); err != nil {
+			return &ValidationError{Name: "text", err: fmt.Errorf(`ent: validator failed for field "Review.text": %w`, err)}
+		}
+	}
+	if _, ok := ruo.mutation.BookID(); ruo.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Review.book"`)
+	}
+	if _, ok := ruo.mutation.UserID(); ruo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Review.user"`)
+	}
+	return nil
+}
+
+func (ruo *ReviewUpdateOne) sqlSave(ctx context.Context) (_node *Review, err error) {
+	if err := ruo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(review.Table, review.Columns, sqlgraph.NewFieldSpec(review.FieldID, field.TypeInt64))
+	id, ok := ruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Review.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, review.FieldID)
+		for _, f := range fields {
+			if !review.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != review.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ruo.mutation.Rating(); ok {
+		_spec.SetField(review.FieldRating, field.TypeInt, value)
+	}
+	if value, ok := ruo.mutation.AddedRating(); ok {
+		_spec.AddField(review.FieldRating, field.TypeInt, value)
+	}
+	if value, ok := ruo.mutation.Text(); ok {
+		_spec.SetField(review.FieldText, field.TypeString, value)
+	}
+	if ruo.mutation.TextCleared() {
+		_spec.ClearField(review.FieldText, field.TypeString)
+	}
+	if value, ok := ruo.mutation.Hidden(); ok {
+		_spec.SetField(review.FieldHidden, field.TypeBool, value)
+	}
+	if value, ok := ruo.mutation.HiddenAt(); ok {
+		_spec.SetField(review.FieldHiddenAt, field.TypeInt64, value)
+	}
+	if value, ok := ruo.mutation.AddedHiddenAt(); ok {
+		_spec.AddField(review.FieldHiddenAt, field.TypeInt64, value)
+	}
+	if ruo.mutation.HiddenAtCleared() {
+		_spec.ClearField(review.FieldHiddenAt, field.TypeInt64)
+	}
+	if value, ok := ruo.mutation.UpdatedAt(); ok {
+		_spec.SetField(review.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if value, ok := ruo.mutation.AddedUpdatedAt(); ok {
+		_spec.AddField(review.FieldUpdatedAt, field.TypeInt64, value)
+	}
+	if ruo.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.BookTable,
+			Columns: []string{review.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.BookTable,
+			Columns: []string{review.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ruo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.UserTable,
+			Columns: []string{review.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.UserTable,
+			Columns: []string{review.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ruo.mutation.HiddenByCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.HiddenByTable,
+			Columns: []string{review.HiddenByColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.HiddenByIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   review.HiddenByTable,
+			Columns: []string{review.HiddenByColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Review{config: ruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{review.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ruo.mutation.done = true
+	return _node, nil
+}
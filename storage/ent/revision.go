@@ -0,0 +1,193 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Revision is the model entity for the Revision schema.
+type Revision struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Entity holds the value of the "entity" field.
+	Entity string `json:"entity,omitempty"`
+	// EntityID holds the value of the "entity_id" field.
+	EntityID int64 `json:"entity_id,omitempty"`
+	// Data holds the value of the "data" field.
+	Data map[string]interface{} `json:"data,omitempty"`
+	// ActorLogin holds the value of the "actor_login" field.
+	ActorLogin string `json:"actor_login,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the RevisionQuery when eager-loading is set.
+	Edges          RevisionEdges `json:"edges"`
+	revision_actor *int64
+	selectValues   sql.SelectValues
+}
+
+// RevisionEdges holds the relations/edges for other nodes in the graph.
+type RevisionEdges struct {
+	// Actor holds the value of the actor edge.
+	Actor *User `json:"actor,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ActorOrErr returns the Actor value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e RevisionEdges) ActorOrErr() (*User, error) {
+	if e.loadedTypes[0] {
+		if e.Actor == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.Actor, nil
+	}
+	return nil, &NotLoadedError{edge: "actor"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Revision) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case revision.FieldData:
+			values[i] = new([]byte)
+		case revision.FieldID, revision.FieldEntityID, revision.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case revision.FieldEntity, revision.FieldActorLogin:
+			values[i] = new(sql.NullString)
+		case revision.ForeignKeys[0]: // revision_actor
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Revision fields.
+func (r *Revision) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case revision.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			r.ID = int64(value.Int64)
+		case revision.FieldEntity:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field entity", values[i])
+			} else if value.Valid {
+				r.Entity = value.String
+			}
+		case revision.FieldEntityID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field entity_id", values[i])
+			} else if value.Valid {
+				r.EntityID = value.Int64
+			}
+		case revision.FieldData:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field data", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &r.Data); err != nil {
+					return fmt.Errorf("unmarshal field data: %w", err)
+				}
+			}
+		case revision.FieldActorLogin:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field actor_login", values[i])
+			} else if value.Valid {
+				r.ActorLogin = value.String
+			}
+		case revision.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				r.CreatedAt = value.Int64
+			}
+		case revision.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field revision_actor", value)
+			} else if value.Valid {
+				r.revision_actor = new(int64)
+				*r.revision_actor = int64(value.Int64)
+			}
+		default:
+			r.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Revision.
+// This includes values selected through modifiers, order, etc.
+func (r *Revision) Value(name string) (ent.Value, error) {
+	return r.selectValues.Get(name)
+}
+
+// QueryActor queries the "actor" edge of the Revision entity.
+func (r *Revision) QueryActor() *UserQuery {
+	return NewRevisionClient(r.config).QueryActor(r)
+}
+
+// Update returns a builder for updating this Revision.
+// Note that you need to call Revision.Unwrap() before calling this method if this Revision
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (r *Revision) Update() *RevisionUpdateOne {
+	return NewRevisionClient(r.config).UpdateOne(r)
+}
+
+// Unwrap unwraps the Revision entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (r *Revision) Unwrap() *Revision {
+	_tx, ok := r.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Revision is not a transactional entity")
+	}
+	r.config.driver = _tx.drv
+	return r
+}
+
+// String implements the fmt.Stringer.
+func (r *Revision) String() string {
+	var builder strings.Builder
+	builder.WriteString("Revision(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", r.ID))
+	builder.WriteString("entity=")
+	builder.WriteString(r.Entity)
+	builder.WriteString(", ")
+	builder.WriteString("entity_id=")
+	builder.WriteString(fmt.Sprintf("%v", r.EntityID))
+	builder.WriteString(", ")
+	builder.WriteString("data=")
+	builder.WriteString(fmt.Sprintf("%v", r.Data))
+	builder.WriteString(", ")
+	builder.WriteString("actor_login=")
+	builder.WriteString(r.ActorLogin)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", r.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Revisions is a parsable slice of Revision.
+type Revisions []*Revision
@@ -0,0 +1,332 @@
+// Code generated by ent, DO NOT EDIT.
+
+package revision
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.Revision {
+	return predicate.Revision(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.Revision {
+	return predicate.Revision(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.Revision {
+	return predicate.Revision(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.Revision {
+	return predicate.Revision(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.Revision {
+	return predicate.Revision(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.Revision {
+	return predicate.Revision(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.Revision {
+	return predicate.Revision(sql.FieldLTE(FieldID, id))
+}
+
+// Entity applies equality check predicate on the "entity" field. It's identical to EntityEQ.
+func Entity(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldEntity, v))
+}
+
+// EntityID applies equality check predicate on the "entity_id" field. It's identical to EntityIDEQ.
+func EntityID(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldEntityID, v))
+}
+
+// ActorLogin applies equality check predicate on the "actor_login" field. It's identical to ActorLoginEQ.
+func ActorLogin(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldActorLogin, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// EntityEQ applies the EQ predicate on the "entity" field.
+func EntityEQ(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldEntity, v))
+}
+
+// EntityNEQ applies the NEQ predicate on the "entity" field.
+func EntityNEQ(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldNEQ(FieldEntity, v))
+}
+
+// EntityIn applies the In predicate on the "entity" field.
+func EntityIn(vs ...string) predicate.Revision {
+	return predicate.Revision(sql.FieldIn(FieldEntity, vs...))
+}
+
+// EntityNotIn applies the NotIn predicate on the "entity" field.
+func EntityNotIn(vs ...string) predicate.Revision {
+	return predicate.Revision(sql.FieldNotIn(FieldEntity, vs...))
+}
+
+// EntityGT applies the GT predicate on the "entity" field.
+func EntityGT(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldGT(FieldEntity, v))
+}
+
+// EntityGTE applies the GTE predicate on the "entity" field.
+func EntityGTE(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldGTE(FieldEntity, v))
+}
+
+// EntityLT applies the LT predicate on the "entity" field.
+func EntityLT(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldLT(FieldEntity, v))
+}
+
+// EntityLTE applies the LTE predicate on the "entity" field.
+func EntityLTE(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldLTE(FieldEntity, v))
+}
+
+// EntityContains applies the Contains predicate on the "entity" field.
+func EntityContains(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldContains(FieldEntity, v))
+}
+
+// EntityHasPrefix applies the HasPrefix predicate on the "entity" field.
+func EntityHasPrefix(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldHasPrefix(FieldEntity, v))
+}
+
+// EntityHasSuffix applies the HasSuffix predicate on the "entity" field.
+func EntityHasSuffix(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldHasSuffix(FieldEntity, v))
+}
+
+// EntityEqualFold applies the EqualFold predicate on the "entity" field.
+func EntityEqualFold(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldEqualFold(FieldEntity, v))
+}
+
+// EntityContainsFold applies the ContainsFold predicate on the "entity" field.
+func EntityContainsFold(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldContainsFold(FieldEntity, v))
+}
+
+// EntityIDEQ applies the EQ predicate on the "entity_id" field.
+func EntityIDEQ(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldEntityID, v))
+}
+
+// EntityIDNEQ applies the NEQ predicate on the "entity_id" field.
+func EntityIDNEQ(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldNEQ(FieldEntityID, v))
+}
+
+// EntityIDIn applies the In predicate on the "entity_id" field.
+func EntityIDIn(vs ...int64) predicate.Revision {
+	return predicate.Revision(sql.FieldIn(FieldEntityID, vs...))
+}
+
+// EntityIDNotIn applies the NotIn predicate on the "entity_id" field.
+func EntityIDNotIn(vs ...int64) predicate.Revision {
+	return predicate.Revision(sql.FieldNotIn(FieldEntityID, vs...))
+}
+
+// EntityIDGT applies the GT predicate on the "entity_id" field.
+func EntityIDGT(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldGT(FieldEntityID, v))
+}
+
+// EntityIDGTE applies the GTE predicate on the "entity_id" field.
+func EntityIDGTE(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldGTE(FieldEntityID, v))
+}
+
+// EntityIDLT applies the LT predicate on the "entity_id" field.
+func EntityIDLT(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldLT(FieldEntityID, v))
+}
+
+// EntityIDLTE applies the LTE predicate on the "entity_id" field.
+func EntityIDLTE(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldLTE(FieldEntityID, v))
+}
+
+// ActorLoginEQ applies the EQ predicate on the "actor_login" field.
+func ActorLoginEQ(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldActorLogin, v))
+}
+
+// ActorLoginNEQ applies the NEQ predicate on the "actor_login" field.
+func ActorLoginNEQ(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldNEQ(FieldActorLogin, v))
+}
+
+// ActorLoginIn applies the In predicate on the "actor_login" field.
+func ActorLoginIn(vs ...string) predicate.Revision {
+	return predicate.Revision(sql.FieldIn(FieldActorLogin, vs...))
+}
+
+// ActorLoginNotIn applies the NotIn predicate on the "actor_login" field.
+func ActorLoginNotIn(vs ...string) predicate.Revision {
+	return predicate.Revision(sql.FieldNotIn(FieldActorLogin, vs...))
+}
+
+// ActorLoginGT applies the GT predicate on the "actor_login" field.
+func ActorLoginGT(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldGT(FieldActorLogin, v))
+}
+
+// ActorLoginGTE applies the GTE predicate on the "actor_login" field.
+func ActorLoginGTE(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldGTE(FieldActorLogin, v))
+}
+
+// ActorLoginLT applies the LT predicate on the "actor_login" field.
+func ActorLoginLT(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldLT(FieldActorLogin, v))
+}
+
+// ActorLoginLTE applies the LTE predicate on the "actor_login" field.
+func ActorLoginLTE(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldLTE(FieldActorLogin, v))
+}
+
+// ActorLoginContains applies the Contains predicate on the "actor_login" field.
+func ActorLoginContains(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldContains(FieldActorLogin, v))
+}
+
+// ActorLoginHasPrefix applies the HasPrefix predicate on the "actor_login" field.
+func ActorLoginHasPrefix(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldHasPrefix(FieldActorLogin, v))
+}
+
+// ActorLoginHasSuffix applies the HasSuffix predicate on the "actor_login" field.
+func ActorLoginHasSuffix(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldHasSuffix(FieldActorLogin, v))
+}
+
+// ActorLoginIsNil applies the IsNil predicate on the "actor_login" field.
+func ActorLoginIsNil() predicate.Revision {
+	return predicate.Revision(sql.FieldIsNull(FieldActorLogin))
+}
+
+// ActorLoginNotNil applies the NotNil predicate on the "actor_login" field.
+func ActorLoginNotNil() predicate.Revision {
+	return predicate.Revision(sql.FieldNotNull(FieldActorLogin))
+}
+
+// ActorLoginEqualFold applies the EqualFold predicate on the "actor_login" field.
+func ActorLoginEqualFold(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldEqualFold(FieldActorLogin, v))
+}
+
+// ActorLoginContainsFold applies the ContainsFold predicate on the "actor_login" field.
+func ActorLoginContainsFold(v string) predicate.Revision {
+	return predicate.Revision(sql.FieldContainsFold(FieldActorLogin, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...int64) predicate.Revision {
+	return predicate.Revision(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...int64) predicate.Revision {
+	return predicate.Revision(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v int64) predicate.Revision {
+	return predicate.Revision(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasActor applies the HasEdge predicate on the "actor" edge.
+func HasActor() predicate.Revision {
+	return predicate.Revision(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, ActorTable, ActorColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasActorWith applies the HasEdge predicate on the "actor" edge with a given conditions (other predicates).
+func HasActorWith(preds ...predicate.User) predicate.Revision {
+	return predicate.Revision(func(s *sql.Selector) {
+		step := newActorStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Revision) predicate.Revision {
+	return predicate.Revision(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Revision) predicate.Revision {
+	return predicate.Revision(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Revision) predicate.Revision {
+	return predicate.Revision(sql.NotPredicates(p))
+}
@@ -0,0 +1,312 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// RevisionCreate is the builder for creating a Revision entity.
+type RevisionCreate struct {
+	config
+	mutation *RevisionMutation
+	hooks    []Hook
+}
+
+// SetEntity sets the "entity" field.
+func (rc *RevisionCreate) SetEntity(s string) *RevisionCreate {
+	rc.mutation.SetEntity(s)
+	return rc
+}
+
+// SetEntityID sets the "entity_id" field.
+func (rc *RevisionCreate) SetEntityID(i int64) *RevisionCreate {
+	rc.mutation.SetEntityID(i)
+	return rc
+}
+
+// SetData sets the "data" field.
+func (rc *RevisionCreate) SetData(m map[string]interface{}) *RevisionCreate {
+	rc.mutation.SetData(m)
+	return rc
+}
+
+// SetActorLogin sets the "actor_login" field.
+func (rc *RevisionCreate) SetActorLogin(s string) *RevisionCreate {
+	rc.mutation.SetActorLogin(s)
+	return rc
+}
+
+// SetNillableActorLogin sets the "actor_login" field if the given value is not nil.
+func (rc *RevisionCreate) SetNillableActorLogin(s *string) *RevisionCreate {
+	if s != nil {
+		rc.SetActorLogin(*s)
+	}
+	return rc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (rc *RevisionCreate) SetCreatedAt(i int64) *RevisionCreate {
+	rc.mutation.SetCreatedAt(i)
+	return rc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (rc *RevisionCreate) SetNillableCreatedAt(i *int64) *RevisionCreate {
+	if i != nil {
+		rc.SetCreatedAt(*i)
+	}
+	return rc
+}
+
+// SetID sets the "id" field.
+func (rc *RevisionCreate) SetID(i int64) *RevisionCreate {
+	rc.mutation.SetID(i)
+	return rc
+}
+
+// SetActorID sets the "actor" edge to the User entity by ID.
+func (rc *RevisionCreate) SetActorID(id int64) *RevisionCreate {
+	rc.mutation.SetActorID(id)
+	return rc
+}
+
+// SetNillableActorID sets the "actor" edge to the User entity by ID if the given value is not nil.
+func (rc *RevisionCreate) SetNillableActorID(id *int64) *RevisionCreate {
+	if id != nil {
+		rc = rc.SetActorID(*id)
+	}
+	return rc
+}
+
+// SetActor sets the "actor" edge to the User entity.
+func (rc *RevisionCreate) SetActor(u *User) *RevisionCreate {
+	return rc.SetActorID(u.ID)
+}
+
+// Mutation returns the RevisionMutation object of the builder.
+func (rc *RevisionCreate) Mutation() *RevisionMutation {
+	return rc.mutation
+}
+
+// Save creates the Revision in the database.
+func (rc *RevisionCreate) Save(ctx context.Context) (*Revision, error) {
+	rc.defaults()
+	return withHooks(ctx, rc.sqlSave, rc.mutation, rc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rc *RevisionCreate) SaveX(ctx context.Context) *Revision {
+	v, err := rc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rc *RevisionCreate) Exec(ctx context.Context) error {
+	_, err := rc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rc *RevisionCreate) ExecX(ctx context.Context) {
+	if err := rc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rc *RevisionCreate) defaults() {
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		v := revision.DefaultCreatedAt()
+		rc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rc *RevisionCreate) check() error {
+	if _, ok := rc.mutation.Entity(); !ok {
+		return &ValidationError{Name: "entity", err: errors.New(`ent: missing required field "Revision.entity"`)}
+	}
+	if v, ok := rc.mutation.Entity(); ok {
+		if err := revision.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "Revision.entity": %w`, err)}
+		}
+	}
+	if _, ok := rc.mutation.EntityID(); !ok {
+		return &ValidationError{Name: "entity_id", err: errors.New(`ent: missing required field "Revision.entity_id"`)}
+	}
+	if _, ok := rc.mutation.Data(); !ok {
+		return &ValidationError{Name: "data", err: errors.New(`ent: missing required field "Revision.data"`)}
+	}
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Revision.created_at"`)}
+	}
+	return nil
+}
+
+func (rc *RevisionCreate) sqlSave(ctx context.Context) (*Revision, error) {
+	if err := rc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := rc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	rc.mutation.id = &_node.ID
+	rc.mutation.done = true
+	return _node, nil
+}
+
+func (rc *RevisionCreate) createSpec() (*Revision, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Revision{config: rc.config}
+		_spec = sqlgraph.NewCreateSpec(revision.Table, sqlgraph.NewFieldSpec(revision.FieldID, field.TypeInt64))
+	)
+	if id, ok := rc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := rc.mutation.Entity(); ok {
+		_spec.SetField(revision.FieldEntity, field.TypeString, value)
+		_node.Entity = value
+	}
+	if value, ok := rc.mutation.EntityID(); ok {
+		_spec.SetField(revision.FieldEntityID, field.TypeInt64, value)
+		_node.EntityID = value
+	}
+	if value, ok := rc.mutation.Data(); ok {
+		_spec.SetField(revision.FieldData, field.TypeJSON, value)
+		_node.Data = value
+	}
+	if value, ok := rc.mutation.ActorLogin(); ok {
+		_spec.SetField(revision.FieldActorLogin, field.TypeString, value)
+		_node.ActorLogin = value
+	}
+	if value, ok := rc.mutation.CreatedAt(); ok {
+		_spec.SetField(revision.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := rc.mutation.ActorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   revision.ActorTable,
+			Columns: []string{revision.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.revision_actor = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// RevisionCreateBulk is the builder for creating many Revision entities in bulk.
+type RevisionCreateBulk struct {
+	config
+	err      error
+	builders []*RevisionCreate
+}
+
+// Save creates the Revision entities in the database.
+func (rcb *RevisionCreateBulk) Save(ctx context.Context) ([]*Revision, error) {
+	if rcb.err != nil {
+		return nil, rcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(rcb.builders))
+	nodes := make([]*Revision, len(rcb.builders))
+	mutators := make([]Mutator, len(rcb.builders))
+	for i := range rcb.builders {
+		func(i int, root context.Context) {
+			builder := rcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*RevisionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rcb *RevisionCreateBulk) SaveX(ctx context.Context) []*Revision {
+	v, err := rcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rcb *RevisionCreateBulk) Exec(ctx context.Context) error {
+	_, err := rcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rcb *RevisionCreateBulk) ExecX(ctx context.Context) {
+	if err := rcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
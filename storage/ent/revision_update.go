@@ -0,0 +1,436 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// RevisionUpdate is the builder for updating Revision entities.
+type RevisionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RevisionMutation
+}
+
+// Where appends a list predicates to the RevisionUpdate builder.
+func (ru *RevisionUpdate) Where(ps ...predicate.Revision) *RevisionUpdate {
+	ru.mutation.Where(ps...)
+	return ru
+}
+
+// SetEntity sets the "entity" field.
+func (ru *RevisionUpdate) SetEntity(s string) *RevisionUpdate {
+	ru.mutation.SetEntity(s)
+	return ru
+}
+
+// SetEntityID sets the "entity_id" field.
+func (ru *RevisionUpdate) SetEntityID(i int64) *RevisionUpdate {
+	ru.mutation.ResetEntityID()
+	ru.mutation.SetEntityID(i)
+	return ru
+}
+
+// AddEntityID adds i to the "entity_id" field.
+func (ru *RevisionUpdate) AddEntityID(i int64) *RevisionUpdate {
+	ru.mutation.AddEntityID(i)
+	return ru
+}
+
+// SetData sets the "data" field.
+func (ru *RevisionUpdate) SetData(m map[string]interface{}) *RevisionUpdate {
+	ru.mutation.SetData(m)
+	return ru
+}
+
+// SetActorLogin sets the "actor_login" field.
+func (ru *RevisionUpdate) SetActorLogin(s string) *RevisionUpdate {
+	ru.mutation.SetActorLogin(s)
+	return ru
+}
+
+// SetNillableActorLogin sets the "actor_login" field if the given value is not nil.
+func (ru *RevisionUpdate) SetNillableActorLogin(s *string) *RevisionUpdate {
+	if s != nil {
+		ru.SetActorLogin(*s)
+	}
+	return ru
+}
+
+// ClearActorLogin clears the value of the "actor_login" field.
+func (ru *RevisionUpdate) ClearActorLogin() *RevisionUpdate {
+	ru.mutation.ClearActorLogin()
+	return ru
+}
+
+// SetActorID sets the "actor" edge to the User entity by ID.
+func (ru *RevisionUpdate) SetActorID(id int64) *RevisionUpdate {
+	ru.mutation.SetActorID(id)
+	return ru
+}
+
+// SetNillableActorID sets the "actor" edge to the User entity by ID if the given value is not nil.
+func (ru *RevisionUpdate) SetNillableActorID(id *int64) *RevisionUpdate {
+	if id != nil {
+		ru = ru.SetActorID(*id)
+	}
+	return ru
+}
+
+// SetActor sets the "actor" edge to the User entity.
+func (ru *RevisionUpdate) SetActor(u *User) *RevisionUpdate {
+	return ru.SetActorID(u.ID)
+}
+
+// Mutation returns the RevisionMutation object of the builder.
+func (ru *RevisionUpdate) Mutation() *RevisionMutation {
+	return ru.mutation
+}
+
+// ClearActor clears the "actor" edge to the User entity.
+func (ru *RevisionUpdate) ClearActor() *RevisionUpdate {
+	ru.mutation.ClearActor()
+	return ru
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ru *RevisionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, ru.sqlSave, ru.mutation, ru.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ru *RevisionUpdate) SaveX(ctx context.Context) int {
+	affected, err := ru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ru *RevisionUpdate) Exec(ctx context.Context) error {
+	_, err := ru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ru *RevisionUpdate) ExecX(ctx context.Context) {
+	if err := ru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ru *RevisionUpdate) check() error {
+	if v, ok := ru.mutation.Entity(); ok {
+		if err := revision.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "Revision.entity": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ru *RevisionUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ru.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(revision.Table, revision.Columns, sqlgraph.NewFieldSpec(revision.FieldID, field.TypeInt64))
+	if ps := ru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ru.mutation.Entity(); ok {
+		_spec.SetField(revision.FieldEntity, field.TypeString, value)
+	}
+	if value, ok := ru.mutation.EntityID(); ok {
+		_spec.SetField(revision.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := ru.mutation.AddedEntityID(); ok {
+		_spec.AddField(revision.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := ru.mutation.Data(); ok {
+		_spec.SetField(revision.FieldData, field.TypeJSON, value)
+	}
+	if value, ok := ru.mutation.ActorLogin(); ok {
+		_spec.SetField(revision.FieldActorLogin, field.TypeString, value)
+	}
+	if ru.mutation.ActorLoginCleared() {
+		_spec.ClearField(revision.FieldActorLogin, field.TypeString)
+	}
+	if ru.mutation.ActorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   revision.ActorTable,
+			Columns: []string{revision.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.ActorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   revision.ActorTable,
+			Columns: []string{revision.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{revision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ru.mutation.done = true
+	return n, nil
+}
+
+// RevisionUpdateOne is the builder for updating a single Revision entity.
+type RevisionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RevisionMutation
+}
+
+// SetEntity sets the "entity" field.
+func (ruo *RevisionUpdateOne) SetEntity(s string) *RevisionUpdateOne {
+	ruo.mutation.SetEntity(s)
+	return ruo
+}
+
+// SetEntityID sets the "entity_id" field.
+func (ruo *RevisionUpdateOne) SetEntityID(i int64) *RevisionUpdateOne {
+	ruo.mutation.ResetEntityID()
+	ruo.mutation.SetEntityID(i)
+	return ruo
+}
+
+// AddEntityID adds i to the "entity_id" field.
+func (ruo *RevisionUpdateOne) AddEntityID(i int64) *RevisionUpdateOne {
+	ruo.mutation.AddEntityID(i)
+	return ruo
+}
+
+// SetData sets the "data" field.
+func (ruo *RevisionUpdateOne) SetData(m map[string]interface{}) *RevisionUpdateOne {
+	ruo.mutation.SetData(m)
+	return ruo
+}
+
+// SetActorLogin sets the "actor_login" field.
+func (ruo *RevisionUpdateOne) SetActorLogin(s string) *RevisionUpdateOne {
+	ruo.mutation.SetActorLogin(s)
+	return ruo
+}
+
+// SetNillableActorLogin sets the "actor_login" field if the given value is not nil.
+func (ruo *RevisionUpdateOne) SetNillableActorLogin(s *string) *RevisionUpdateOne {
+	if s != nil {
+		ruo.SetActorLogin(*s)
+	}
+	return ruo
+}
+
+// ClearActorLogin clears the value of the "actor_login" field.
+func (ruo *RevisionUpdateOne) ClearActorLogin() *RevisionUpdateOne {
+	ruo.mutation.ClearActorLogin()
+	return ruo
+}
+
+// SetActorID sets the "actor" edge to the User entity by ID.
+func (ruo *RevisionUpdateOne) SetActorID(id int64) *RevisionUpdateOne {
+	ruo.mutation.SetActorID(id)
+	return ruo
+}
+
+// SetNillableActorID sets the "actor" edge to the User entity by ID if the given value is not nil.
+func (ruo *RevisionUpdateOne) SetNillableActorID(id *int64) *RevisionUpdateOne {
+	if id != nil {
+		ruo = ruo.SetActorID(*id)
+	}
+	return ruo
+}
+
+// SetActor sets the "actor" edge to the User entity.
+func (ruo *RevisionUpdateOne) SetActor(u *User) *RevisionUpdateOne {
+	return ruo.SetActorID(u.ID)
+}
+
+// Mutation returns the RevisionMutation object of the builder.
+func (ruo *RevisionUpdateOne) Mutation() *RevisionMutation {
+	return ruo.mutation
+}
+
+// ClearActor clears the "actor" edge to the User entity.
+func (ruo *RevisionUpdateOne) ClearActor() *RevisionUpdateOne {
+	ruo.mutation.ClearActor()
+	return ruo
+}
+
+// Where appends a list predicates to the RevisionUpdate builder.
+func (ruo *RevisionUpdateOne) Where(ps ...predicate.Revision) *RevisionUpdateOne {
+	ruo.mutation.Where(ps...)
+	return ruo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ruo *RevisionUpdateOne) Select(field string, fields ...string) *RevisionUpdateOne {
+	ruo.fields = append([]string{field}, fields...)
+	return ruo
+}
+
+// Save executes the query and returns the updated Revision entity.
+func (ruo *RevisionUpdateOne) Save(ctx context.Context) (*Revision, error) {
+	return withHooks(ctx, ruo.sqlSave, ruo.mutation, ruo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ruo *RevisionUpdateOne) SaveX(ctx context.Context) *Revision {
+	node, err := ruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ruo *RevisionUpdateOne) Exec(ctx context.Context) error {
+	_, err := ruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ruo *RevisionUpdateOne) ExecX(ctx context.Context) {
+	if err := ruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ruo *RevisionUpdateOne) check() error {
+	if v, ok := ruo.mutation.Entity(); ok {
+		if err := revision.EntityValidator(v); err != nil {
+			return &ValidationError{Name: "entity", err: fmt.Errorf(`ent: validator failed for field "Revision.entity": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ruo *RevisionUpdateOne) sqlSave(ctx context.Context) (_node *Revision, err error) {
+	if err := ruo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(revision.Table, revision.Columns, sqlgraph.NewFieldSpec(revision.FieldID, field.TypeInt64))
+	id, ok := ruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Revision.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, revision.FieldID)
+		for _, f := range fields {
+			if !revision.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != revision.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ruo.mutation.Entity(); ok {
+		_spec.SetField(revision.FieldEntity, field.TypeString, value)
+	}
+	if value, ok := ruo.mutation.EntityID(); ok {
+		_spec.SetField(revision.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := ruo.mutation.AddedEntityID(); ok {
+		_spec.AddField(revision.FieldEntityID, field.TypeInt64, value)
+	}
+	if value, ok := ruo.mutation.Data(); ok {
+		_spec.SetField(revision.FieldData, field.TypeJSON, value)
+	}
+	if value, ok := ruo.mutation.ActorLogin(); ok {
+		_spec.SetField(revision.FieldActorLogin, field.TypeString, value)
+	}
+	if ruo.mutation.ActorLoginCleared() {
+		_spec.ClearField(revision.FieldActorLogin, field.TypeString)
+	}
+	if ruo.mutation.ActorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   revision.ActorTable,
+			Columns: []string{revision.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.ActorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   revision.ActorTable,
+			Columns: []string{revision.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Revision{config: ruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{revision.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ruo.mutation.done = true
+	return _node, nil
+}
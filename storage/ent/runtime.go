@@ -3,18 +3,259 @@
 package ent
 
 import (
+	"github.com/ninedraft/bibliotheca/storage/ent/apitoken"
+	"github.com/ninedraft/bibliotheca/storage/ent/auditentry"
 	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/bookcopy"
+	"github.com/ninedraft/bibliotheca/storage/ent/checkpoint"
+	"github.com/ninedraft/bibliotheca/storage/ent/delivery"
+	"github.com/ninedraft/bibliotheca/storage/ent/device"
+	"github.com/ninedraft/bibliotheca/storage/ent/document"
+	"github.com/ninedraft/bibliotheca/storage/ent/highlight"
+	"github.com/ninedraft/bibliotheca/storage/ent/hold"
+	"github.com/ninedraft/bibliotheca/storage/ent/loan"
+	"github.com/ninedraft/bibliotheca/storage/ent/outboxmessage"
+	"github.com/ninedraft/bibliotheca/storage/ent/readingprogress"
+	"github.com/ninedraft/bibliotheca/storage/ent/review"
+	"github.com/ninedraft/bibliotheca/storage/ent/revision"
 	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+	"github.com/ninedraft/bibliotheca/storage/ent/session"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
 )
 
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	apitokenFields := schema.APIToken{}.Fields()
+	_ = apitokenFields
+	// apitokenDescName is the schema descriptor for name field.
+	apitokenDescName := apitokenFields[1].Descriptor()
+	// apitoken.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	apitoken.NameValidator = apitokenDescName.Validators[0].(func(string) error)
+	// apitokenDescCreatedAt is the schema descriptor for created_at field.
+	apitokenDescCreatedAt := apitokenFields[4].Descriptor()
+	// apitoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	apitoken.DefaultCreatedAt = apitokenDescCreatedAt.Default.(func() int64)
+	auditentryFields := schema.AuditEntry{}.Fields()
+	_ = auditentryFields
+	// auditentryDescEntity is the schema descriptor for entity field.
+	auditentryDescEntity := auditentryFields[2].Descriptor()
+	// auditentry.EntityValidator is a validator for the "entity" field. It is called by the builders before save.
+	auditentry.EntityValidator = auditentryDescEntity.Validators[0].(func(string) error)
+	// auditentryDescCreatedAt is the schema descriptor for created_at field.
+	auditentryDescCreatedAt := auditentryFields[6].Descriptor()
+	// auditentry.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditentry.DefaultCreatedAt = auditentryDescCreatedAt.Default.(func() int64)
 	bookFields := schema.Book{}.Fields()
 	_ = bookFields
 	// bookDescWrittenAt is the schema descriptor for written_at field.
 	bookDescWrittenAt := bookFields[2].Descriptor()
 	// book.DefaultWrittenAt holds the default value on creation for the written_at field.
-	book.DefaultWrittenAt = bookDescWrittenAt.Default.(func() int64)
+	book.DefaultWrittenAt = bookDescWrittenAt.Default.(int64)
+	bookcopyFields := schema.BookCopy{}.Fields()
+	_ = bookcopyFields
+	// bookcopyDescBarcode is the schema descriptor for barcode field.
+	bookcopyDescBarcode := bookcopyFields[1].Descriptor()
+	// bookcopy.BarcodeValidator is a validator for the "barcode" field. It is called by the builders before save.
+	bookcopy.BarcodeValidator = bookcopyDescBarcode.Validators[0].(func(string) error)
+	// bookcopyDescCreatedAt is the schema descriptor for created_at field.
+	bookcopyDescCreatedAt := bookcopyFields[4].Descriptor()
+	// bookcopy.DefaultCreatedAt holds the default value on creation for the created_at field.
+	bookcopy.DefaultCreatedAt = bookcopyDescCreatedAt.Default.(func() int64)
+	checkpointFields := schema.Checkpoint{}.Fields()
+	_ = checkpointFields
+	// checkpointDescUpdatedAt is the schema descriptor for updated_at field.
+	checkpointDescUpdatedAt := checkpointFields[2].Descriptor()
+	// checkpoint.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	checkpoint.DefaultUpdatedAt = checkpointDescUpdatedAt.Default.(func() int64)
+	// checkpoint.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	checkpoint.UpdateDefaultUpdatedAt = checkpointDescUpdatedAt.UpdateDefault.(func() int64)
+	deliveryFields := schema.Delivery{}.Fields()
+	_ = deliveryFields
+	// deliveryDescCreatedAt is the schema descriptor for created_at field.
+	deliveryDescCreatedAt := deliveryFields[4].Descriptor()
+	// delivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	delivery.DefaultCreatedAt = deliveryDescCreatedAt.Default.(func() int64)
+	deviceFields := schema.Device{}.Fields()
+	_ = deviceFields
+	// deviceDescName is the schema descriptor for name field.
+	deviceDescName := deviceFields[1].Descriptor()
+	// device.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	device.NameValidator = deviceDescName.Validators[0].(func(string) error)
+	// deviceDescEmail is the schema descriptor for email field.
+	deviceDescEmail := deviceFields[2].Descriptor()
+	// device.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	device.EmailValidator = deviceDescEmail.Validators[0].(func(string) error)
+	// deviceDescCreatedAt is the schema descriptor for created_at field.
+	deviceDescCreatedAt := deviceFields[4].Descriptor()
+	// device.DefaultCreatedAt holds the default value on creation for the created_at field.
+	device.DefaultCreatedAt = deviceDescCreatedAt.Default.(func() int64)
+	documentFields := schema.Document{}.Fields()
+	_ = documentFields
+	// documentDescHash is the schema descriptor for hash field.
+	documentDescHash := documentFields[1].Descriptor()
+	// document.HashValidator is a validator for the "hash" field. It is called by the builders before save.
+	document.HashValidator = documentDescHash.Validators[0].(func(string) error)
+	// documentDescCreatedAt is the schema descriptor for created_at field.
+	documentDescCreatedAt := documentFields[2].Descriptor()
+	// document.DefaultCreatedAt holds the default value on creation for the created_at field.
+	document.DefaultCreatedAt = documentDescCreatedAt.Default.(func() int64)
+	highlightFields := schema.Highlight{}.Fields()
+	_ = highlightFields
+	// highlightDescAnchor is the schema descriptor for anchor field.
+	highlightDescAnchor := highlightFields[1].Descriptor()
+	// highlight.AnchorValidator is a validator for the "anchor" field. It is called by the builders before save.
+	highlight.AnchorValidator = highlightDescAnchor.Validators[0].(func(string) error)
+	// highlightDescStart is the schema descriptor for start field.
+	highlightDescStart := highlightFields[2].Descriptor()
+	// highlight.StartValidator is a validator for the "start" field. It is called by the builders before save.
+	highlight.StartValidator = highlightDescStart.Validators[0].(func(int) error)
+	// highlightDescEnd is the schema descriptor for end field.
+	highlightDescEnd := highlightFields[3].Descriptor()
+	// highlight.EndValidator is a validator for the "end" field. It is called by the builders before save.
+	highlight.EndValidator = highlightDescEnd.Validators[0].(func(int) error)
+	// highlightDescText is the schema descriptor for text field.
+	highlightDescText := highlightFields[4].Descriptor()
+	// highlight.TextValidator is a validator for the "text" field. It is called by the builders before save.
+	highlight.TextValidator = highlightDescText.Validators[0].(func(string) error)
+	// highlightDescNote is the schema descriptor for note field.
+	highlightDescNote := highlightFields[6].Descriptor()
+	// highlight.NoteValidator is a validator for the "note" field. It is called by the builders before save.
+	highlight.NoteValidator = highlightDescNote.Validators[0].(func(string) error)
+	// highlightDescCreatedAt is the schema descriptor for created_at field.
+	highlightDescCreatedAt := highlightFields[8].Descriptor()
+	// highlight.DefaultCreatedAt holds the default value on creation for the created_at field.
+	highlight.DefaultCreatedAt = highlightDescCreatedAt.Default.(func() int64)
+	// highlightDescUpdatedAt is the schema descriptor for updated_at field.
+	highlightDescUpdatedAt := highlightFields[9].Descriptor()
+	// highlight.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	highlight.DefaultUpdatedAt = highlightDescUpdatedAt.Default.(func() int64)
+	// highlight.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	highlight.UpdateDefaultUpdatedAt = highlightDescUpdatedAt.UpdateDefault.(func() int64)
+	holdFields := schema.Hold{}.Fields()
+	_ = holdFields
+	// holdDescCreatedAt is the schema descriptor for created_at field.
+	holdDescCreatedAt := holdFields[2].Descriptor()
+	// hold.DefaultCreatedAt holds the default value on creation for the created_at field.
+	hold.DefaultCreatedAt = holdDescCreatedAt.Default.(func() int64)
+	loanFields := schema.Loan{}.Fields()
+	_ = loanFields
+	// loanDescCheckedOutAt is the schema descriptor for checked_out_at field.
+	loanDescCheckedOutAt := loanFields[1].Descriptor()
+	// loan.DefaultCheckedOutAt holds the default value on creation for the checked_out_at field.
+	loan.DefaultCheckedOutAt = loanDescCheckedOutAt.Default.(func() int64)
+	outboxmessageFields := schema.OutboxMessage{}.Fields()
+	_ = outboxmessageFields
+	// outboxmessageDescCreatedAt is the schema descriptor for created_at field.
+	outboxmessageDescCreatedAt := outboxmessageFields[7].Descriptor()
+	// outboxmessage.DefaultCreatedAt holds the default value on creation for the created_at field.
+	outboxmessage.DefaultCreatedAt = outboxmessageDescCreatedAt.Default.(func() int64)
+	// outboxmessageDescAttempts is the schema descriptor for attempts field.
+	outboxmessageDescAttempts := outboxmessageFields[8].Descriptor()
+	// outboxmessage.DefaultAttempts holds the default value on creation for the attempts field.
+	outboxmessage.DefaultAttempts = outboxmessageDescAttempts.Default.(int)
+	// outboxmessageDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	outboxmessageDescNextAttemptAt := outboxmessageFields[9].Descriptor()
+	// outboxmessage.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	outboxmessage.DefaultNextAttemptAt = outboxmessageDescNextAttemptAt.Default.(func() int64)
+	readingprogressFields := schema.ReadingProgress{}.Fields()
+	_ = readingprogressFields
+	// readingprogressDescDocument is the schema descriptor for document field.
+	readingprogressDescDocument := readingprogressFields[1].Descriptor()
+	// readingprogress.DocumentValidator is a validator for the "document" field. It is called by the builders before save.
+	readingprogress.DocumentValidator = readingprogressDescDocument.Validators[0].(func(string) error)
+	// readingprogressDescUpdatedAt is the schema descriptor for updated_at field.
+	readingprogressDescUpdatedAt := readingprogressFields[6].Descriptor()
+	// readingprogress.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	readingprogress.DefaultUpdatedAt = readingprogressDescUpdatedAt.Default.(func() int64)
+	// readingprogress.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	readingprogress.UpdateDefaultUpdatedAt = readingprogressDescUpdatedAt.UpdateDefault.(func() int64)
+	reviewFields := schema.Review{}.Fields()
+	_ = reviewFields
+	// reviewDescRating is the schema descriptor for rating field.
+	reviewDescRating := reviewFields[1].Descriptor()
+	// review.RatingValidator is a validator for the "rating" field. It is called by the builders before save.
+	review.RatingValidator = reviewDescRating.Validators[0].(func(int) error)
+	// reviewDescText is the schema descriptor for text field.
+	reviewDescText := reviewFields[2].Descriptor()
+	// review.TextValidator is a validator for the "text" field. It is called by the builders before save.
+	review.TextValidator = reviewDescText.Validators[0].(func(string) error)
+	// reviewDescHidden is the schema descriptor for hidden field.
+	reviewDescHidden := reviewFields[3].Descriptor()
+	// review.DefaultHidden holds the default value on creation for the hidden field.
+	review.DefaultHidden = reviewDescHidden.Default.(bool)
+	// reviewDescCreatedAt is the schema descriptor for created_at field.
+	reviewDescCreatedAt := reviewFields[5].Descriptor()
+	// review.DefaultCreatedAt holds the default value on creation for the created_at field.
+	review.DefaultCreatedAt = reviewDescCreatedAt.Default.(func() int64)
+	// reviewDescUpdatedAt is the schema descriptor for updated_at field.
+	reviewDescUpdatedAt := reviewFields[6].Descriptor()
+	// review.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	review.DefaultUpdatedAt = reviewDescUpdatedAt.Default.(func() int64)
+	// review.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	review.UpdateDefaultUpdatedAt = reviewDescUpdatedAt.UpdateDefault.(func() int64)
+	revisionFields := schema.Revision{}.Fields()
+	_ = revisionFields
+	// revisionDescEntity is the schema descriptor for entity field.
+	revisionDescEntity := revisionFields[1].Descriptor()
+	// revision.EntityValidator is a validator for the "entity" field. It is called by the builders before save.
+	revision.EntityValidator = revisionDescEntity.Validators[0].(func(string) error)
+	// revisionDescCreatedAt is the schema descriptor for created_at field.
+	revisionDescCreatedAt := revisionFields[5].Descriptor()
+	// revision.DefaultCreatedAt holds the default value on creation for the created_at field.
+	revision.DefaultCreatedAt = revisionDescCreatedAt.Default.(func() int64)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[2].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() int64)
+	shelfFields := schema.Shelf{}.Fields()
+	_ = shelfFields
+	// shelfDescName is the schema descriptor for name field.
+	shelfDescName := shelfFields[1].Descriptor()
+	// shelf.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	shelf.NameValidator = shelfDescName.Validators[0].(func(string) error)
+	// shelfDescCreatedAt is the schema descriptor for created_at field.
+	shelfDescCreatedAt := shelfFields[3].Descriptor()
+	// shelf.DefaultCreatedAt holds the default value on creation for the created_at field.
+	shelf.DefaultCreatedAt = shelfDescCreatedAt.Default.(func() int64)
+	shelfentryFields := schema.ShelfEntry{}.Fields()
+	_ = shelfentryFields
+	// shelfentryDescAddedAt is the schema descriptor for added_at field.
+	shelfentryDescAddedAt := shelfentryFields[1].Descriptor()
+	// shelfentry.DefaultAddedAt holds the default value on creation for the added_at field.
+	shelfentry.DefaultAddedAt = shelfentryDescAddedAt.Default.(func() int64)
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescLogin is the schema descriptor for login field.
+	userDescLogin := userFields[1].Descriptor()
+	// user.LoginValidator is a validator for the "login" field. It is called by the builders before save.
+	user.LoginValidator = userDescLogin.Validators[0].(func(string) error)
+	// userDescNotifyDue is the schema descriptor for notify_due field.
+	userDescNotifyDue := userFields[5].Descriptor()
+	// user.DefaultNotifyDue holds the default value on creation for the notify_due field.
+	user.DefaultNotifyDue = userDescNotifyDue.Default.(bool)
+	// userDescNotifyHolds is the schema descriptor for notify_holds field.
+	userDescNotifyHolds := userFields[6].Descriptor()
+	// user.DefaultNotifyHolds holds the default value on creation for the notify_holds field.
+	user.DefaultNotifyHolds = userDescNotifyHolds.Default.(bool)
+	// userDescNotifyNewBooks is the schema descriptor for notify_new_books field.
+	userDescNotifyNewBooks := userFields[7].Descriptor()
+	// user.DefaultNotifyNewBooks holds the default value on creation for the notify_new_books field.
+	user.DefaultNotifyNewBooks = userDescNotifyNewBooks.Default.(bool)
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userFields[9].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() int64)
+	validationFields := schema.Validation{}.Fields()
+	_ = validationFields
+	// validationDescCreatedAt is the schema descriptor for created_at field.
+	validationDescCreatedAt := validationFields[3].Descriptor()
+	// validation.DefaultCreatedAt holds the default value on creation for the created_at field.
+	validation.DefaultCreatedAt = validationDescCreatedAt.Default.(func() int64)
 }
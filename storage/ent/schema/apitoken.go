@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// APIToken holds the schema definition for the APIToken entity.
+// Tokens let scripts and e-readers act on behalf of the user
+// with a subset of the user's permissions.
+type APIToken struct {
+	ent.Schema
+}
+
+// Fields of the APIToken.
+func (APIToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("name").NotEmpty(),
+		field.String("token_hash").Unique().Sensitive(),
+		// Scopes are names of permissions granted to the token.
+		field.Strings("scopes"),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+		field.Int64("last_used_at").Optional(),
+	}
+}
+
+// Edges of the APIToken.
+func (APIToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
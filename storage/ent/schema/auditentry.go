@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditEntry holds the schema definition for the AuditEntry entity, a change
+// of the catalog recorded by hook.Audit.
+type AuditEntry struct {
+	ent.Schema
+}
+
+// FieldChange is a change of a field or an edge of the entity.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old,omitempty"`
+	New   any    `json:"new,omitempty"`
+	// Added and Removed are ids of edge targets.
+	Added   []int64 `json:"added,omitempty"`
+	Removed []int64 `json:"removed,omitempty"`
+}
+
+// Fields of the AuditEntry.
+func (AuditEntry) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.Enum("op").Values("create", "update", "delete"),
+		// Entity is the type name like Book, EntityID is its id.
+		field.String("entity").NotEmpty(),
+		field.Int64("entity_id"),
+		field.JSON("changes", []FieldChange{}).Optional(),
+		// ActorLogin is kept after the user is deleted,
+		// it's empty for changes made by commands and jobs.
+		field.String("actor_login").Optional(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the AuditEntry.
+func (AuditEntry) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("actor", User.Type).Unique().
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+	}
+}
+
+// Indexes of the AuditEntry.
+func (AuditEntry) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity", "entity_id"),
+		index.Fields("created_at"),
+	}
+}
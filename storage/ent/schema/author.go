@@ -24,5 +24,6 @@ func (Author) Fields() []ent.Field {
 func (Author) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.From("books", Book.Type).Ref("authors"),
+		edge.From("followers", User.Type).Ref("followed_authors"),
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // Book holds the schema definition for the Book entity.
@@ -18,9 +19,15 @@ func (Book) Fields() []ent.Field {
 	return []ent.Field{
 		field.Int64("id").Unique(),
 		field.String("title"),
-		field.Int64("written_at").DefaultFunc(now),
+		// WrittenAt is the publication date, 0 if unknown.
+		field.Int64("written_at").Default(0),
 		field.String("cover_id").Optional(),
 		field.String("file_id").Optional(),
+		// Pages is the page count of formats with fixed pagination, 0 if unknown.
+		field.Int64("pages").Optional(),
+		// DeletedAt is set when the book is moved to the trash,
+		// deleted books are hidden from queries by intercept.SoftDelete.
+		field.Int64("deleted_at").Optional().Nillable(),
 	}
 }
 
@@ -28,6 +35,20 @@ func (Book) Fields() []ent.Field {
 func (Book) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("authors", Author.Type),
+		edge.From("copies", BookCopy.Type).Ref("book"),
+		edge.From("holds", Hold.Type).Ref("book"),
+		edge.From("deliveries", Delivery.Type).Ref("book"),
+		edge.From("shelf_entries", ShelfEntry.Type).Ref("book"),
+		edge.From("reviews", Review.Type).Ref("book"),
+		edge.From("documents", Document.Type).Ref("book"),
+		edge.From("highlights", Highlight.Type).Ref("book"),
+	}
+}
+
+// Indexes of the Book.
+func (Book) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("deleted_at"),
 	}
 }
 
@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// BookCopy holds the schema definition for the BookCopy entity,
+// a paper copy of the book on a shelf of the library.
+type BookCopy struct {
+	ent.Schema
+}
+
+// Fields of the BookCopy.
+func (BookCopy) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("barcode").Unique().NotEmpty(),
+		field.String("location").Optional(),
+		field.Enum("condition").Values("new", "good", "worn", "damaged").Default("good"),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the BookCopy.
+func (BookCopy) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("book", Book.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.From("loans", Loan.Type).Ref("copy"),
+		edge.From("holds", Hold.Type).Ref("copy"),
+	}
+}
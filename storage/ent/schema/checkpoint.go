@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Checkpoint holds progress of an interruptible job, so it can be resumed.
+type Checkpoint struct {
+	ent.Schema
+}
+
+// Fields of the Checkpoint.
+func (Checkpoint) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").Unique(),
+		field.String("value"),
+		field.Int64("updated_at").DefaultFunc(now).UpdateDefault(now),
+	}
+}
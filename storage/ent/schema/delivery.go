@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Delivery holds the schema definition for the Delivery entity, a book file
+// sent to a device. The status follows the outbox message carrying the file,
+// a delivery without the message failed before sending with the error.
+type Delivery struct {
+	ent.Schema
+}
+
+// Fields of the Delivery.
+func (Delivery) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		// To is the device address at the time of sending.
+		field.String("to"),
+		// Format of the sent file, it differs from the stored one
+		// if the file was converted.
+		field.String("format"),
+		field.String("error").Optional(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the Delivery.
+func (Delivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("book", Book.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("device", Device.Type).Unique().
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+		edge.From("message", OutboxMessage.Type).Ref("delivery").Unique(),
+	}
+}
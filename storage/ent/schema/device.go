@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Device holds the schema definition for the Device entity, an e-reader
+// which receives books by email, like a Kindle.
+type Device struct {
+	ent.Schema
+}
+
+// Fields of the Device.
+func (Device) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("name").NotEmpty(),
+		field.String("email").NotEmpty(),
+		// Kind decides which formats the device accepts.
+		field.Enum("kind").Values("kindle", "other").Default("kindle"),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the Device.
+func (Device) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.From("deliveries", Delivery.Type).Ref("device"),
+	}
+}
+
+// Indexes of the Device.
+func (Device) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("email").Edges("user").Unique(),
+	}
+}
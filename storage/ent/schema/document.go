@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Document holds the schema definition for the Document entity, a file
+// of the book as readers have it: the stored file, the download with
+// metadata from the catalog or a converted copy sent to a device.
+// Reading apps identify documents by the hash, see library.PartialMD5.
+type Document struct {
+	ent.Schema
+}
+
+// Fields of the Document.
+func (Document) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("hash").Unique().NotEmpty(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the Document.
+func (Document) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("book", Book.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
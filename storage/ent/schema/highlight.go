@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Highlight holds the schema definition for the Highlight entity, a piece
+// of the book text marked by the user in the web reader with an optional note.
+type Highlight struct {
+	ent.Schema
+}
+
+// Fields of the Highlight.
+func (Highlight) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		// Anchor is the path of the FB2 text block like body[1]/section[2]/p[3],
+		// start and end are offsets in its text in UTF-16 code units.
+		field.String("anchor").NotEmpty(),
+		field.Int("start").NonNegative(),
+		field.Int("end").Positive(),
+		// Text is the highlighted text, so the highlight survives edits of the file.
+		field.Text("text").NotEmpty(),
+		// Chapter is the title of the section with the text.
+		field.String("chapter").Optional(),
+		field.Text("note").Optional().MaxLen(10000),
+		field.Enum("color").Values("yellow", "green", "blue", "pink").Default("yellow"),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+		field.Int64("updated_at").DefaultFunc(now).UpdateDefault(now),
+	}
+}
+
+// Edges of the Highlight.
+func (Highlight) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("book", Book.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the Highlight.
+func (Highlight) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("book", "user"),
+	}
+}
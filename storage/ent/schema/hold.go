@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Hold holds the schema definition for the Hold entity, a place of the user
+// in the queue for a paper copy of the book. A waiting hold becomes ready
+// when a copy is set aside for the user, and the user has until expires_at
+// to pick it up.
+type Hold struct {
+	ent.Schema
+}
+
+// Fields of the Hold.
+func (Hold) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.Enum("status").Values("waiting", "ready", "fulfilled", "expired", "canceled").Default("waiting"),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+		field.Int64("ready_at").Optional().Nillable(),
+		field.Int64("expires_at").Optional().Nillable(),
+	}
+}
+
+// Edges of the Hold.
+func (Hold) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("book", Book.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		// copy is set aside for a ready hold
+		edge.To("copy", BookCopy.Type).Unique().
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+	}
+}
+
+// Indexes of the Hold.
+func (Hold) Indexes() []ent.Index {
+	return []ent.Index{
+		// one place in the queue per user
+		index.Edges("book", "user").Unique().
+			Annotations(entsql.IndexWhere("status IN ('waiting', 'ready')")),
+		// a copy is set aside for one user at a time
+		index.Edges("copy").Unique().
+			Annotations(entsql.IndexWhere("status = 'ready'")),
+	}
+}
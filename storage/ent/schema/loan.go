@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Loan holds the schema definition for the Loan entity.
+// A loan is open until the copy is returned.
+type Loan struct {
+	ent.Schema
+}
+
+// Fields of the Loan.
+func (Loan) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.Int64("checked_out_at").DefaultFunc(now).Immutable(),
+		field.Int64("due_at"),
+		field.Int64("returned_at").Optional().Nillable(),
+		// reminded_at is set when the due date reminder is queued.
+		field.Int64("reminded_at").Optional().Nillable(),
+	}
+}
+
+// Edges of the Loan.
+func (Loan) Edges() []ent.Edge {
+	return []ent.Edge{
+		// copy is cleared when the book is purged from the trash,
+		// the returned loan is kept in the history of the borrower
+		edge.To("copy", BookCopy.Type).Unique().
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+		edge.To("borrower", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the Loan.
+func (Loan) Indexes() []ent.Index {
+	return []ent.Index{
+		// a copy can't be lent twice at the same time
+		index.Edges("copy").Unique().
+			Annotations(entsql.IndexWhere("returned_at IS NULL")),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// OutboxMessage holds the schema definition for the OutboxMessage entity,
+// an email waiting to be sent. Messages are kept after sending.
+type OutboxMessage struct {
+	ent.Schema
+}
+
+// Fields of the OutboxMessage.
+func (OutboxMessage) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("kind"),
+		field.String("to"),
+		field.String("subject"),
+		field.Text("body"),
+		// Attachment is an id of a stored file sent with the message
+		// under attachment_name.
+		field.String("attachment").Optional(),
+		field.String("attachment_name").Optional(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+		field.Int("attempts").Default(0),
+		field.Int64("next_attempt_at").DefaultFunc(now),
+		field.Int64("sent_at").Optional().Nillable(),
+		field.String("last_error").Optional(),
+	}
+}
+
+// Edges of the OutboxMessage.
+func (OutboxMessage) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("delivery", Delivery.Type).Unique().
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+	}
+}
+
+// Indexes of the OutboxMessage.
+func (OutboxMessage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("sent_at", "next_attempt_at"),
+	}
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ReadingProgress holds the schema definition for the ReadingProgress entity,
+// the position of the user in a document pushed by a reading app.
+// Documents unknown to the library are kept too, they may be matched later.
+type ReadingProgress struct {
+	ent.Schema
+}
+
+// Fields of the ReadingProgress.
+func (ReadingProgress) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		// Document is the hash of the document file.
+		field.String("document").NotEmpty(),
+		// Progress is the position in the format of the app.
+		field.String("progress"),
+		field.Float("percentage"),
+		field.String("device"),
+		field.String("device_id"),
+		field.Int64("updated_at").DefaultFunc(now).UpdateDefault(now),
+	}
+}
+
+// Edges of the ReadingProgress.
+func (ReadingProgress) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the ReadingProgress.
+func (ReadingProgress) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("document").Edges("user").Unique(),
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Review holds the schema definition for the Review entity, a star rating
+// of the book by the user with an optional text in Markdown. Librarians
+// hide reviews breaking the rules, hidden ones don't count in the rating.
+type Review struct {
+	ent.Schema
+}
+
+// Fields of the Review.
+func (Review) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.Int("rating").Range(1, 5),
+		field.Text("text").Optional().MaxLen(10000),
+		field.Bool("hidden").Default(false),
+		field.Int64("hidden_at").Optional().Nillable(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+		field.Int64("updated_at").DefaultFunc(now).UpdateDefault(now),
+	}
+}
+
+// Edges of the Review.
+func (Review) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("book", Book.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		// moderator who hid the review
+		edge.To("hidden_by", User.Type).Unique().
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+	}
+}
+
+// Indexes of the Review.
+func (Review) Indexes() []ent.Index {
+	return []ent.Index{
+		// one review per user, editing replaces it
+		index.Edges("book", "user").Unique(),
+	}
+}
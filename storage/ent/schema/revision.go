@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Revision holds the schema definition for the Revision entity, a snapshot
+// of the metadata of a book or an author stored by hook.Revisions.
+type Revision struct {
+	ent.Schema
+}
+
+// Fields of the Revision.
+func (Revision) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		// Entity is the type name like Book, EntityID is its id.
+		field.String("entity").NotEmpty(),
+		field.Int64("entity_id"),
+		// Data holds fields by names, zero values are omitted.
+		// Revisions of books list ids of authors as authors.
+		field.JSON("data", map[string]any{}),
+		// ActorLogin is kept after the user is deleted,
+		// it's empty for changes made by commands and jobs.
+		field.String("actor_login").Optional(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the Revision.
+func (Revision) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("actor", User.Type).Unique().
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+	}
+}
+
+// Indexes of the Revision.
+func (Revision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity", "entity_id"),
+	}
+}
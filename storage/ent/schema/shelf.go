@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Shelf holds the schema definition for the Shelf entity, a personal list
+// of books. Every user has at most one shelf of each reading status,
+// they are created on first use, and any number of custom shelves.
+type Shelf struct {
+	ent.Schema
+}
+
+// Fields of the Shelf.
+func (Shelf) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("name").NotEmpty(),
+		field.Enum("kind").Values("want_to_read", "reading", "finished", "custom").Default("custom"),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the Shelf.
+func (Shelf) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.From("entries", ShelfEntry.Type).Ref("shelf"),
+	}
+}
+
+// Indexes of the Shelf.
+func (Shelf) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name").Edges("user").Unique().
+			Annotations(entsql.IndexWhere("kind = 'custom'")),
+		index.Fields("kind").Edges("user").Unique().
+			Annotations(entsql.IndexWhere("kind <> 'custom'")),
+	}
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ShelfEntry holds the schema definition for the ShelfEntry entity,
+// a book put on a shelf.
+type ShelfEntry struct {
+	ent.Schema
+}
+
+// Fields of the ShelfEntry.
+func (ShelfEntry) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.Int64("added_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the ShelfEntry.
+func (ShelfEntry) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("shelf", Shelf.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+		edge.To("book", Book.Type).Unique().Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the ShelfEntry.
+func (ShelfEntry) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Edges("shelf", "book").Unique(),
+	}
+}
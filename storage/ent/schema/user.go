@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("login").Unique().NotEmpty(),
+		field.String("password_hash").Sensitive(),
+		field.Enum("role").Values("reader", "librarian", "admin").Default("reader"),
+		// Email receives notifications, there are none if empty.
+		field.String("email").Optional(),
+		field.Bool("notify_due").Default(true),
+		field.Bool("notify_holds").Default(true),
+		field.Bool("notify_new_books").Default(true),
+		// SyncKeyHash is the MAC of the key reading apps send to sync progress,
+		// the key is MD5 of a password set apart from the login one.
+		field.String("sync_key_hash").Optional().Sensitive(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("sessions", Session.Type).Ref("user"),
+		edge.From("api_tokens", APIToken.Type).Ref("user"),
+		edge.From("loans", Loan.Type).Ref("borrower"),
+		edge.From("holds", Hold.Type).Ref("user"),
+		edge.To("followed_authors", Author.Type),
+		edge.From("devices", Device.Type).Ref("user"),
+		edge.From("deliveries", Delivery.Type).Ref("user"),
+		edge.From("shelves", Shelf.Type).Ref("user"),
+		edge.From("reviews", Review.Type).Ref("user"),
+		edge.From("reading_progress", ReadingProgress.Type).Ref("user"),
+		edge.From("highlights", Highlight.Type).Ref("user"),
+	}
+}
@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Validation holds the schema definition for the Validation entity,
+// problems found in a stored FB2 file. Files are stored by their content,
+// so the result holds as long as the file is kept.
+type Validation struct {
+	ent.Schema
+}
+
+// FileProblem is a problem found in the file, see bookinfo.Problem.
+type FileProblem struct {
+	Severity string `json:"severity"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+// Fields of the Validation.
+func (Validation) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id").Unique(),
+		field.String("file_id").Unique(),
+		field.JSON("problems", []FileProblem{}).Optional(),
+		field.Int64("created_at").DefaultFunc(now).Immutable(),
+	}
+}
@@ -0,0 +1,182 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// Shelf is the model entity for the Shelf schema.
+type Shelf struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind shelf.Kind `json:"kind,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ShelfQuery when eager-loading is set.
+	Edges        ShelfEdges `json:"edges"`
+	shelf_user   *int64
+	selectValues sql.SelectValues
+}
+
+// ShelfEdges holds the relations/edges for other nodes in the graph.
+type ShelfEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// Entries holds the value of the entries edge.
+	Entries []*ShelfEntry `json:"entries,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ShelfEdges) UserOrErr() (*User, error) {
+	if e.loadedTypes[0] {
+		if e.User == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: user.Label}
+		}
+		return e.User, nil
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// EntriesOrErr returns the Entries value or an error if the edge
+// was not loaded in eager-loading.
+func (e ShelfEdges) EntriesOrErr() ([]*ShelfEntry, error) {
+	if e.loadedTypes[1] {
+		return e.Entries, nil
+	}
+	return nil, &NotLoadedError{edge: "entries"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Shelf) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case shelf.FieldID, shelf.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case shelf.FieldName, shelf.FieldKind:
+			values[i] = new(sql.NullString)
+		case shelf.ForeignKeys[0]: // shelf_user
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Shelf fields.
+func (s *Shelf) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case shelf.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			s.ID = int64(value.Int64)
+		case shelf.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				s.Name = value.String
+			}
+		case shelf.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				s.Kind = shelf.Kind(value.String)
+			}
+		case shelf.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				s.CreatedAt = value.Int64
+			}
+		case shelf.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field shelf_user", value)
+			} else if value.Valid {
+				s.shelf_user = new(int64)
+				*s.shelf_user = int64(value.Int64)
+			}
+		default:
+			s.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Shelf.
+// This includes values selected through modifiers, order, etc.
+func (s *Shelf) Value(name string) (ent.Value, error) {
+	return s.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the Shelf entity.
+func (s *Shelf) QueryUser() *UserQuery {
+	return NewShelfClient(s.config).QueryUser(s)
+}
+
+// QueryEntries queries the "entries" edge of the Shelf entity.
+func (s *Shelf) QueryEntries() *ShelfEntryQuery {
+	return NewShelfClient(s.config).QueryEntries(s)
+}
+
+// Update returns a builder for updating this Shelf.
+// Note that you need to call Shelf.Unwrap() before calling this method if this Shelf
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (s *Shelf) Update() *ShelfUpdateOne {
+	return NewShelfClient(s.config).UpdateOne(s)
+}
+
+// Unwrap unwraps the Shelf entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (s *Shelf) Unwrap() *Shelf {
+	_tx, ok := s.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Shelf is not a transactional entity")
+	}
+	s.config.driver = _tx.drv
+	return s
+}
+
+// String implements the fmt.Stringer.
+func (s *Shelf) String() string {
+	var builder strings.Builder
+	builder.WriteString("Shelf(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", s.ID))
+	builder.WriteString("name=")
+	builder.WriteString(s.Name)
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", s.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", s.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Shelves is a parsable slice of Shelf.
+type Shelves []*Shelf
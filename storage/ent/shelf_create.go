@@ -0,0 +1,325 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ShelfCreate is the builder for creating a Shelf entity.
+type ShelfCreate struct {
+	config
+	mutation *ShelfMutation
+	hooks    []Hook
+}
+
+// SetName sets the "name" field.
+func (sc *ShelfCreate) SetName(s string) *ShelfCreate {
+	sc.mutation.SetName(s)
+	return sc
+}
+
+// SetKind sets the "kind" field.
+func (sc *ShelfCreate) SetKind(s shelf.Kind) *ShelfCreate {
+	sc.mutation.SetKind(s)
+	return sc
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (sc *ShelfCreate) SetNillableKind(s *shelf.Kind) *ShelfCreate {
+	if s != nil {
+		sc.SetKind(*s)
+	}
+	return sc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (sc *ShelfCreate) SetCreatedAt(i int64) *ShelfCreate {
+	sc.mutation.SetCreatedAt(i)
+	return sc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (sc *ShelfCreate) SetNillableCreatedAt(i *int64) *ShelfCreate {
+	if i != nil {
+		sc.SetCreatedAt(*i)
+	}
+	return sc
+}
+
+// SetID sets the "id" field.
+func (sc *ShelfCreate) SetID(i int64) *ShelfCreate {
+	sc.mutation.SetID(i)
+	return sc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (sc *ShelfCreate) SetUserID(id int64) *ShelfCreate {
+	sc.mutation.SetUserID(id)
+	return sc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (sc *ShelfCreate) SetUser(u *User) *ShelfCreate {
+	return sc.SetUserID(u.ID)
+}
+
+// AddEntryIDs adds the "entries" edge to the ShelfEntry entity by IDs.
+func (sc *ShelfCreate) AddEntryIDs(ids ...int64) *ShelfCreate {
+	sc.mutation.AddEntryIDs(ids...)
+	return sc
+}
+
+// AddEntries adds the "entries" edges to the ShelfEntry entity.
+func (sc *ShelfCreate) AddEntries(s ...*ShelfEntry) *ShelfCreate {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return sc.AddEntryIDs(ids...)
+}
+
+// Mutation returns the ShelfMutation object of the builder.
+func (sc *ShelfCreate) Mutation() *ShelfMutation {
+	return sc.mutation
+}
+
+// Save creates the Shelf in the database.
+func (sc *ShelfCreate) Save(ctx context.Context) (*Shelf, error) {
+	sc.defaults()
+	return withHooks(ctx, sc.sqlSave, sc.mutation, sc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sc *ShelfCreate) SaveX(ctx context.Context) *Shelf {
+	v, err := sc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sc *ShelfCreate) Exec(ctx context.Context) error {
+	_, err := sc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sc *ShelfCreate) ExecX(ctx context.Context) {
+	if err := sc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sc *ShelfCreate) defaults() {
+	if _, ok := sc.mutation.Kind(); !ok {
+		v := shelf.DefaultKind
+		sc.mutation.SetKind(v)
+	}
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		v := shelf.DefaultCreatedAt()
+		sc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sc *ShelfCreate) check() error {
+	if _, ok := sc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Shelf.name"`)}
+	}
+	if v, ok := sc.mutation.Name(); ok {
+		if err := shelf.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Shelf.name": %w`, err)}
+		}
+	}
+	if _, ok := sc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "Shelf.kind"`)}
+	}
+	if v, ok := sc.mutation.Kind(); ok {
+		if err := shelf.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Shelf.kind": %w`, err)}
+		}
+	}
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Shelf.created_at"`)}
+	}
+	if _, ok := sc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Shelf.user"`)}
+	}
+	return nil
+}
+
+func (sc *ShelfCreate) sqlSave(ctx context.Context) (*Shelf, error) {
+	if err := sc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	sc.mutation.id = &_node.ID
+	sc.mutation.done = true
+	return _node, nil
+}
+
+func (sc *ShelfCreate) createSpec() (*Shelf, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Shelf{config: sc.config}
+		_spec = sqlgraph.NewCreateSpec(shelf.Table, sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64))
+	)
+	if id, ok := sc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := sc.mutation.Name(); ok {
+		_spec.SetField(shelf.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := sc.mutation.Kind(); ok {
+		_spec.SetField(shelf.FieldKind, field.TypeEnum, value)
+		_node.Kind = value
+	}
+	if value, ok := sc.mutation.CreatedAt(); ok {
+		_spec.SetField(shelf.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	if nodes := sc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelf.UserTable,
+			Columns: []string{shelf.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.shelf_user = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := sc.mutation.EntriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   shelf.EntriesTable,
+			Columns: []string{shelf.EntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ShelfCreateBulk is the builder for creating many Shelf entities in bulk.
+type ShelfCreateBulk struct {
+	config
+	err      error
+	builders []*ShelfCreate
+}
+
+// Save creates the Shelf entities in the database.
+func (scb *ShelfCreateBulk) Save(ctx context.Context) ([]*Shelf, error) {
+	if scb.err != nil {
+		return nil, scb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(scb.builders))
+	nodes := make([]*Shelf, len(scb.builders))
+	mutators := make([]Mutator, len(scb.builders))
+	for i := range scb.builders {
+		func(i int, root context.Context) {
+			builder := scb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ShelfMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, scb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, scb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, scb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (scb *ShelfCreateBulk) SaveX(ctx context.Context) []*Shelf {
+	v, err := scb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (scb *ShelfCreateBulk) Exec(ctx context.Context) error {
+	_, err := scb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scb *ShelfCreateBulk) ExecX(ctx context.Context) {
+	if err := scb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
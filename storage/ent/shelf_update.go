@@ -0,0 +1,525 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// ShelfUpdate is the builder for updating Shelf entities.
+type ShelfUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ShelfMutation
+}
+
+// Where appends a list predicates to the ShelfUpdate builder.
+func (su *ShelfUpdate) Where(ps ...predicate.Shelf) *ShelfUpdate {
+	su.mutation.Where(ps...)
+	return su
+}
+
+// SetName sets the "name" field.
+func (su *ShelfUpdate) SetName(s string) *ShelfUpdate {
+	su.mutation.SetName(s)
+	return su
+}
+
+// SetKind sets the "kind" field.
+func (su *ShelfUpdate) SetKind(s shelf.Kind) *ShelfUpdate {
+	su.mutation.SetKind(s)
+	return su
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (su *ShelfUpdate) SetNillableKind(s *shelf.Kind) *ShelfUpdate {
+	if s != nil {
+		su.SetKind(*s)
+	}
+	return su
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (su *ShelfUpdate) SetUserID(id int64) *ShelfUpdate {
+	su.mutation.SetUserID(id)
+	return su
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (su *ShelfUpdate) SetUser(u *User) *ShelfUpdate {
+	return su.SetUserID(u.ID)
+}
+
+// AddEntryIDs adds the "entries" edge to the ShelfEntry entity by IDs.
+func (su *ShelfUpdate) AddEntryIDs(ids ...int64) *ShelfUpdate {
+	su.mutation.AddEntryIDs(ids...)
+	return su
+}
+
+// AddEntries adds the "entries" edges to the ShelfEntry entity.
+func (su *ShelfUpdate) AddEntries(s ...*ShelfEntry) *ShelfUpdate {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return su.AddEntryIDs(ids...)
+}
+
+// Mutation returns the ShelfMutation object of the builder.
+func (su *ShelfUpdate) Mutation() *ShelfMutation {
+	return su.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (su *ShelfUpdate) ClearUser() *ShelfUpdate {
+	su.mutation.ClearUser()
+	return su
+}
+
+// ClearEntries clears all "entries" edges to the ShelfEntry entity.
+func (su *ShelfUpdate) ClearEntries() *ShelfUpdate {
+	su.mutation.ClearEntries()
+	return su
+}
+
+// RemoveEntryIDs removes the "entries" edge to ShelfEntry entities by IDs.
+func (su *ShelfUpdate) RemoveEntryIDs(ids ...int64) *ShelfUpdate {
+	su.mutation.RemoveEntryIDs(ids...)
+	return su
+}
+
+// RemoveEntries removes "entries" edges to ShelfEntry entities.
+func (su *ShelfUpdate) RemoveEntries(s ...*ShelfEntry) *ShelfUpdate {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return su.RemoveEntryIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (su *ShelfUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, su.sqlSave, su.mutation, su.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (su *ShelfUpdate) SaveX(ctx context.Context) int {
+	affected, err := su.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (su *ShelfUpdate) Exec(ctx context.Context) error {
+	_, err := su.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (su *ShelfUpdate) ExecX(ctx context.Context) {
+	if err := su.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (su *ShelfUpdate) check() error {
+	if v, ok := su.mutation.Name(); ok {
+		if err := shelf.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Shelf.name": %w`, err)}
+		}
+	}
+	if v, ok := su.mutation.Kind(); ok {
+		if err := shelf.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Shelf.kind": %w`, err)}
+		}
+	}
+	if _, ok := su.mutation.UserID(); su.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Shelf.user"`)
+	}
+	return nil
+}
+
+func (su *ShelfUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := su.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(shelf.Table, shelf.Columns, sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64))
+	if ps := su.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := su.mutation.Name(); ok {
+		_spec.SetField(shelf.FieldName, field.TypeString, value)
+	}
+	if value, ok := su.mutation.Kind(); ok {
+		_spec.SetField(shelf.FieldKind, field.TypeEnum, value)
+	}
+	if su.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelf.UserTable,
+			Columns: []string{shelf.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := su.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelf.UserTable,
+			Columns: []string{shelf.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if su.mutation.EntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   shelf.EntriesTable,
+			Columns: []string{shelf.EntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := su.mutation.RemovedEntriesIDs(); len(nodes) > 0 && !su.mutation.EntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   shelf.EntriesTable,
+			Columns: []string{shelf.EntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := su.mutation.EntriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   shelf.EntriesTable,
+			Columns: []string{shelf.EntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, su.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{shelf.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	su.mutation.done = true
+	return n, nil
+}
+
+// ShelfUpdateOne is the builder for updating a single Shelf entity.
+type ShelfUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ShelfMutation
+}
+
+// SetName sets the "name" field.
+func (suo *ShelfUpdateOne) SetName(s string) *ShelfUpdateOne {
+	suo.mutation.SetName(s)
+	return suo
+}
+
+// SetKind sets the "kind" field.
+func (suo *ShelfUpdateOne) SetKind(s shelf.Kind) *ShelfUpdateOne {
+	suo.mutation.SetKind(s)
+	return suo
+}
+
+// SetNillableKind sets the "kind" field if the given value is not nil.
+func (suo *ShelfUpdateOne) SetNillableKind(s *shelf.Kind) *ShelfUpdateOne {
+	if s != nil {
+		suo.SetKind(*s)
+	}
+	return suo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (suo *ShelfUpdateOne) SetUserID(id int64) *ShelfUpdateOne {
+	suo.mutation.SetUserID(id)
+	return suo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (suo *ShelfUpdateOne) SetUser(u *User) *ShelfUpdateOne {
+	return suo.SetUserID(u.ID)
+}
+
+// AddEntryIDs adds the "entries" edge to the ShelfEntry entity by IDs.
+func (suo *ShelfUpdateOne) AddEntryIDs(ids ...int64) *ShelfUpdateOne {
+	suo.mutation.AddEntryIDs(ids...)
+	return suo
+}
+
+// AddEntries adds the "entries" edges to the ShelfEntry entity.
+func (suo *ShelfUpdateOne) AddEntries(s ...*ShelfEntry) *ShelfUpdateOne {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return suo.AddEntryIDs(ids...)
+}
+
+// Mutation returns the ShelfMutation object of the builder.
+func (suo *ShelfUpdateOne) Mutation() *ShelfMutation {
+	return suo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (suo *ShelfUpdateOne) ClearUser() *ShelfUpdateOne {
+	suo.mutation.ClearUser()
+	return suo
+}
+
+// ClearEntries clears all "entries" edges to the ShelfEntry entity.
+func (suo *ShelfUpdateOne) ClearEntries() *ShelfUpdateOne {
+	suo.mutation.ClearEntries()
+	return suo
+}
+
+// RemoveEntryIDs removes the "entries" edge to ShelfEntry entities by IDs.
+func (suo *ShelfUpdateOne) RemoveEntryIDs(ids ...int64) *ShelfUpdateOne {
+	suo.mutation.RemoveEntryIDs(ids...)
+	return suo
+}
+
+// RemoveEntries removes "entries" edges to ShelfEntry entities.
+func (suo *ShelfUpdateOne) RemoveEntries(s ...*ShelfEntry) *ShelfUpdateOne {
+	ids := make([]int64, len(s))
+	for i := range s {
+		ids[i] = s[i].ID
+	}
+	return suo.RemoveEntryIDs(ids...)
+}
+
+// Where appends a list predicates to the ShelfUpdate builder.
+func (suo *ShelfUpdateOne) Where(ps ...predicate.Shelf) *ShelfUpdateOne {
+	suo.mutation.Where(ps...)
+	return suo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (suo *ShelfUpdateOne) Select(field string, fields ...string) *ShelfUpdateOne {
+	suo.fields = append([]string{field}, fields...)
+	return suo
+}
+
+// Save executes the query and returns the updated Shelf entity.
+func (suo *ShelfUpdateOne) Save(ctx context.Context) (*Shelf, error) {
+	return withHooks(ctx, suo.sqlSave, suo.mutation, suo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (suo *ShelfUpdateOne) SaveX(ctx context.Context) *Shelf {
+	node, err := suo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (suo *ShelfUpdateOne) Exec(ctx context.Context) error {
+	_, err := suo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (suo *ShelfUpdateOne) ExecX(ctx context.Context) {
+	if err := suo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (suo *ShelfUpdateOne) check() error {
+	if v, ok := suo.mutation.Name(); ok {
+		if err := shelf.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Shelf.name": %w`, err)}
+		}
+	}
+	if v, ok := suo.mutation.Kind(); ok {
+		if err := shelf.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "Shelf.kind": %w`, err)}
+		}
+	}
+	if _, ok := suo.mutation.UserID(); suo.mutation.UserCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "Shelf.user"`)
+	}
+	return nil
+}
+
+func (suo *ShelfUpdateOne) sqlSave(ctx context.Context) (_node *Shelf, err error) {
+	if err := suo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(shelf.Table, shelf.Columns, sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64))
+	id, ok := suo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Shelf.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := suo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, shelf.FieldID)
+		for _, f := range fields {
+			if !shelf.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != shelf.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := suo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := suo.mutation.Name(); ok {
+		_spec.SetField(shelf.FieldName, field.TypeString, value)
+	}
+	if value, ok := suo.mutation.Kind(); ok {
+		_spec.SetField(shelf.FieldKind, field.TypeEnum, value)
+	}
+	if suo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelf.UserTable,
+			Columns: []string{shelf.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := suo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelf.UserTable,
+			Columns: []string{shelf.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if suo.mutation.EntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   shelf.EntriesTable,
+			Columns: []string{shelf.EntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := suo.mutation.RemovedEntriesIDs(); len(nodes) > 0 && !suo.mutation.EntriesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   shelf.EntriesTable,
+			Columns: []string{shelf.EntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := suo.mutation.EntriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   shelf.EntriesTable,
+			Columns: []string{shelf.EntriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Shelf{config: suo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, suo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{shelf.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	suo.mutation.done = true
+	return _node, nil
+}
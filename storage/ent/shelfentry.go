@@ -0,0 +1,173 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+)
+
+// ShelfEntry is the model entity for the ShelfEntry schema.
+type ShelfEntry struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// AddedAt holds the value of the "added_at" field.
+	AddedAt int64 `json:"added_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the ShelfEntryQuery when eager-loading is set.
+	Edges             ShelfEntryEdges `json:"edges"`
+	shelf_entry_shelf *int64
+	shelf_entry_book  *int64
+	selectValues      sql.SelectValues
+}
+
+// ShelfEntryEdges holds the relations/edges for other nodes in the graph.
+type ShelfEntryEdges struct {
+	// Shelf holds the value of the shelf edge.
+	Shelf *Shelf `json:"shelf,omitempty"`
+	// Book holds the value of the book edge.
+	Book *Book `json:"book,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// ShelfOrErr returns the Shelf value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ShelfEntryEdges) ShelfOrErr() (*Shelf, error) {
+	if e.loadedTypes[0] {
+		if e.Shelf == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: shelf.Label}
+		}
+		return e.Shelf, nil
+	}
+	return nil, &NotLoadedError{edge: "shelf"}
+}
+
+// BookOrErr returns the Book value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e ShelfEntryEdges) BookOrErr() (*Book, error) {
+	if e.loadedTypes[1] {
+		if e.Book == nil {
+			// Edge was loaded but was not found.
+			return nil, &NotFoundError{label: book.Label}
+		}
+		return e.Book, nil
+	}
+	return nil, &NotLoadedError{edge: "book"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ShelfEntry) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case shelfentry.FieldID, shelfentry.FieldAddedAt:
+			values[i] = new(sql.NullInt64)
+		case shelfentry.ForeignKeys[0]: // shelf_entry_shelf
+			values[i] = new(sql.NullInt64)
+		case shelfentry.ForeignKeys[1]: // shelf_entry_book
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ShelfEntry fields.
+func (se *ShelfEntry) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case shelfentry.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			se.ID = int64(value.Int64)
+		case shelfentry.FieldAddedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field added_at", values[i])
+			} else if value.Valid {
+				se.AddedAt = value.Int64
+			}
+		case shelfentry.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field shelf_entry_shelf", value)
+			} else if value.Valid {
+				se.shelf_entry_shelf = new(int64)
+				*se.shelf_entry_shelf = int64(value.Int64)
+			}
+		case shelfentry.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field shelf_entry_book", value)
+			} else if value.Valid {
+				se.shelf_entry_book = new(int64)
+				*se.shelf_entry_book = int64(value.Int64)
+			}
+		default:
+			se.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ShelfEntry.
+// This includes values selected through modifiers, order, etc.
+func (se *ShelfEntry) Value(name string) (ent.Value, error) {
+	return se.selectValues.Get(name)
+}
+
+// QueryShelf queries the "shelf" edge of the ShelfEntry entity.
+func (se *ShelfEntry) QueryShelf() *ShelfQuery {
+	return NewShelfEntryClient(se.config).QueryShelf(se)
+}
+
+// QueryBook queries the "book" edge of the ShelfEntry entity.
+func (se *ShelfEntry) QueryBook() *BookQuery {
+	return NewShelfEntryClient(se.config).QueryBook(se)
+}
+
+// Update returns a builder for updating this ShelfEntry.
+// Note that you need to call ShelfEntry.Unwrap() before calling this method if this ShelfEntry
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (se *ShelfEntry) Update() *ShelfEntryUpdateOne {
+	return NewShelfEntryClient(se.config).UpdateOne(se)
+}
+
+// Unwrap unwraps the ShelfEntry entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (se *ShelfEntry) Unwrap() *ShelfEntry {
+	_tx, ok := se.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ShelfEntry is not a transactional entity")
+	}
+	se.config.driver = _tx.drv
+	return se
+}
+
+// String implements the fmt.Stringer.
+func (se *ShelfEntry) String() string {
+	var builder strings.Builder
+	builder.WriteString("ShelfEntry(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", se.ID))
+	builder.WriteString("added_at=")
+	builder.WriteString(fmt.Sprintf("%v", se.AddedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ShelfEntries is a parsable slice of ShelfEntry.
+type ShelfEntries []*ShelfEntry
@@ -0,0 +1,111 @@
+// Code generated by ent, DO NOT EDIT.
+
+package shelfentry
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the shelfentry type in the database.
+	Label = "shelf_entry"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldAddedAt holds the string denoting the added_at field in the database.
+	FieldAddedAt = "added_at"
+	// EdgeShelf holds the string denoting the shelf edge name in mutations.
+	EdgeShelf = "shelf"
+	// EdgeBook holds the string denoting the book edge name in mutations.
+	EdgeBook = "book"
+	// Table holds the table name of the shelfentry in the database.
+	Table = "shelf_entries"
+	// ShelfTable is the table that holds the shelf relation/edge.
+	ShelfTable = "shelf_entries"
+	// ShelfInverseTable is the table name for the Shelf entity.
+	// It exists in this package in order to avoid circular dependency with the "shelf" package.
+	ShelfInverseTable = "shelves"
+	// ShelfColumn is the table column denoting the shelf relation/edge.
+	ShelfColumn = "shelf_entry_shelf"
+	// BookTable is the table that holds the book relation/edge.
+	BookTable = "shelf_entries"
+	// BookInverseTable is the table name for the Book entity.
+	// It exists in this package in order to avoid circular dependency with the "book" package.
+	BookInverseTable = "books"
+	// BookColumn is the table column denoting the book relation/edge.
+	BookColumn = "shelf_entry_book"
+)
+
+// Columns holds all SQL columns for shelfentry fields.
+var Columns = []string{
+	FieldID,
+	FieldAddedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "shelf_entries"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"shelf_entry_shelf",
+	"shelf_entry_book",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultAddedAt holds the default value on creation for the "added_at" field.
+	DefaultAddedAt func() int64
+)
+
+// OrderOption defines the ordering options for the ShelfEntry queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByAddedAt orders the results by the added_at field.
+func ByAddedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAddedAt, opts...).ToFunc()
+}
+
+// ByShelfField orders the results by shelf field.
+func ByShelfField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newShelfStep(), sql.OrderByField(field, opts...))
+	}
+}
+
+// ByBookField orders the results by book field.
+func ByBookField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newBookStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newShelfStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ShelfInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, false, ShelfTable, ShelfColumn),
+	)
+}
+func newBookStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(BookInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, false, BookTable, BookColumn),
+	)
+}
@@ -0,0 +1,160 @@
+// Code generated by ent, DO NOT EDIT.
+
+package shelfentry
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldLTE(FieldID, id))
+}
+
+// AddedAt applies equality check predicate on the "added_at" field. It's identical to AddedAtEQ.
+func AddedAt(v int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldEQ(FieldAddedAt, v))
+}
+
+// AddedAtEQ applies the EQ predicate on the "added_at" field.
+func AddedAtEQ(v int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldEQ(FieldAddedAt, v))
+}
+
+// AddedAtNEQ applies the NEQ predicate on the "added_at" field.
+func AddedAtNEQ(v int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldNEQ(FieldAddedAt, v))
+}
+
+// AddedAtIn applies the In predicate on the "added_at" field.
+func AddedAtIn(vs ...int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldIn(FieldAddedAt, vs...))
+}
+
+// AddedAtNotIn applies the NotIn predicate on the "added_at" field.
+func AddedAtNotIn(vs ...int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldNotIn(FieldAddedAt, vs...))
+}
+
+// AddedAtGT applies the GT predicate on the "added_at" field.
+func AddedAtGT(v int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldGT(FieldAddedAt, v))
+}
+
+// AddedAtGTE applies the GTE predicate on the "added_at" field.
+func AddedAtGTE(v int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldGTE(FieldAddedAt, v))
+}
+
+// AddedAtLT applies the LT predicate on the "added_at" field.
+func AddedAtLT(v int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldLT(FieldAddedAt, v))
+}
+
+// AddedAtLTE applies the LTE predicate on the "added_at" field.
+func AddedAtLTE(v int64) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.FieldLTE(FieldAddedAt, v))
+}
+
+// HasShelf applies the HasEdge predicate on the "shelf" edge.
+func HasShelf() predicate.ShelfEntry {
+	return predicate.ShelfEntry(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, ShelfTable, ShelfColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasShelfWith applies the HasEdge predicate on the "shelf" edge with a given conditions (other predicates).
+func HasShelfWith(preds ...predicate.Shelf) predicate.ShelfEntry {
+	return predicate.ShelfEntry(func(s *sql.Selector) {
+		step := newShelfStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasBook applies the HasEdge predicate on the "book" edge.
+func HasBook() predicate.ShelfEntry {
+	return predicate.ShelfEntry(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, BookTable, BookColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasBookWith applies the HasEdge predicate on the "book" edge with a given conditions (other predicates).
+func HasBookWith(preds ...predicate.Book) predicate.ShelfEntry {
+	return predicate.ShelfEntry(func(s *sql.Selector) {
+		step := newBookStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ShelfEntry) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ShelfEntry) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ShelfEntry) predicate.ShelfEntry {
+	return predicate.ShelfEntry(sql.NotPredicates(p))
+}
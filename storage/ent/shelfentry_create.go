@@ -0,0 +1,277 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+)
+
+// ShelfEntryCreate is the builder for creating a ShelfEntry entity.
+type ShelfEntryCreate struct {
+	config
+	mutation *ShelfEntryMutation
+	hooks    []Hook
+}
+
+// SetAddedAt sets the "added_at" field.
+func (sec *ShelfEntryCreate) SetAddedAt(i int64) *ShelfEntryCreate {
+	sec.mutation.SetAddedAt(i)
+	return sec
+}
+
+// SetNillableAddedAt sets the "added_at" field if the given value is not nil.
+func (sec *ShelfEntryCreate) SetNillableAddedAt(i *int64) *ShelfEntryCreate {
+	if i != nil {
+		sec.SetAddedAt(*i)
+	}
+	return sec
+}
+
+// SetID sets the "id" field.
+func (sec *ShelfEntryCreate) SetID(i int64) *ShelfEntryCreate {
+	sec.mutation.SetID(i)
+	return sec
+}
+
+// SetShelfID sets the "shelf" edge to the Shelf entity by ID.
+func (sec *ShelfEntryCreate) SetShelfID(id int64) *ShelfEntryCreate {
+	sec.mutation.SetShelfID(id)
+	return sec
+}
+
+// SetShelf sets the "shelf" edge to the Shelf entity.
+func (sec *ShelfEntryCreate) SetShelf(s *Shelf) *ShelfEntryCreate {
+	return sec.SetShelfID(s.ID)
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (sec *ShelfEntryCreate) SetBookID(id int64) *ShelfEntryCreate {
+	sec.mutation.SetBookID(id)
+	return sec
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (sec *ShelfEntryCreate) SetBook(b *Book) *ShelfEntryCreate {
+	return sec.SetBookID(b.ID)
+}
+
+// Mutation returns the ShelfEntryMutation object of the builder.
+func (sec *ShelfEntryCreate) Mutation() *ShelfEntryMutation {
+	return sec.mutation
+}
+
+// Save creates the ShelfEntry in the database.
+func (sec *ShelfEntryCreate) Save(ctx context.Context) (*ShelfEntry, error) {
+	sec.defaults()
+	return withHooks(ctx, sec.sqlSave, sec.mutation, sec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sec *ShelfEntryCreate) SaveX(ctx context.Context) *ShelfEntry {
+	v, err := sec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sec *ShelfEntryCreate) Exec(ctx context.Context) error {
+	_, err := sec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sec *ShelfEntryCreate) ExecX(ctx context.Context) {
+	if err := sec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sec *ShelfEntryCreate) defaults() {
+	if _, ok := sec.mutation.AddedAt(); !ok {
+		v := shelfentry.DefaultAddedAt()
+		sec.mutation.SetAddedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sec *ShelfEntryCreate) check() error {
+	if _, ok := sec.mutation.AddedAt(); !ok {
+		return &ValidationError{Name: "added_at", err: errors.New(`ent: missing required field "ShelfEntry.added_at"`)}
+	}
+	if _, ok := sec.mutation.ShelfID(); !ok {
+		return &ValidationError{Name: "shelf", err: errors.New(`ent: missing required edge "ShelfEntry.shelf"`)}
+	}
+	if _, ok := sec.mutation.BookID(); !ok {
+		return &ValidationError{Name: "book", err: errors.New(`ent: missing required edge "ShelfEntry.book"`)}
+	}
+	return nil
+}
+
+func (sec *ShelfEntryCreate) sqlSave(ctx context.Context) (*ShelfEntry, error) {
+	if err := sec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	sec.mutation.id = &_node.ID
+	sec.mutation.done = true
+	return _node, nil
+}
+
+func (sec *ShelfEntryCreate) createSpec() (*ShelfEntry, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ShelfEntry{config: sec.config}
+		_spec = sqlgraph.NewCreateSpec(shelfentry.Table, sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64))
+	)
+	if id, ok := sec.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := sec.mutation.AddedAt(); ok {
+		_spec.SetField(shelfentry.FieldAddedAt, field.TypeInt64, value)
+		_node.AddedAt = value
+	}
+	if nodes := sec.mutation.ShelfIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.ShelfTable,
+			Columns: []string{shelfentry.ShelfColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.shelf_entry_shelf = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := sec.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.BookTable,
+			Columns: []string{shelfentry.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.shelf_entry_book = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ShelfEntryCreateBulk is the builder for creating many ShelfEntry entities in bulk.
+type ShelfEntryCreateBulk struct {
+	config
+	err      error
+	builders []*ShelfEntryCreate
+}
+
+// Save creates the ShelfEntry entities in the database.
+func (secb *ShelfEntryCreateBulk) Save(ctx context.Context) ([]*ShelfEntry, error) {
+	if secb.err != nil {
+		return nil, secb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(secb.builders))
+	nodes := make([]*ShelfEntry, len(secb.builders))
+	mutators := make([]Mutator, len(secb.builders))
+	for i := range secb.builders {
+		func(i int, root context.Context) {
+			builder := secb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ShelfEntryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, secb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, secb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, secb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (secb *ShelfEntryCreateBulk) SaveX(ctx context.Context) []*ShelfEntry {
+	v, err := secb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (secb *ShelfEntryCreateBulk) Exec(ctx context.Context) error {
+	_, err := secb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (secb *ShelfEntryCreateBulk) ExecX(ctx context.Context) {
+	if err := secb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+)
+
+// ShelfEntryDelete is the builder for deleting a ShelfEntry entity.
+type ShelfEntryDelete struct {
+	config
+	hooks    []Hook
+	mutation *ShelfEntryMutation
+}
+
+// Where appends a list predicates to the ShelfEntryDelete builder.
+func (sed *ShelfEntryDelete) Where(ps ...predicate.ShelfEntry) *ShelfEntryDelete {
+	sed.mutation.Where(ps...)
+	return sed
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (sed *ShelfEntryDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, sed.sqlExec, sed.mutation, sed.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sed *ShelfEntryDelete) ExecX(ctx context.Context) int {
+	n, err := sed.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (sed *ShelfEntryDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(shelfentry.Table, sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64))
+	if ps := sed.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, sed.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	sed.mutation.done = true
+	return affected, err
+}
+
+// ShelfEntryDeleteOne is the builder for deleting a single ShelfEntry entity.
+type ShelfEntryDeleteOne struct {
+	sed *ShelfEntryDelete
+}
+
+// Where appends a list predicates to the ShelfEntryDelete builder.
+func (sedo *ShelfEntryDeleteOne) Where(ps ...predicate.ShelfEntry) *ShelfEntryDeleteOne {
+	sedo.sed.mutation.Where(ps...)
+	return sedo
+}
+
+// Exec executes the deletion query.
+func (sedo *ShelfEntryDeleteOne) Exec(ctx context.Context) error {
+	n, err := sedo.sed.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{shelfentry.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sedo *ShelfEntryDeleteOne) ExecX(ctx context.Context) {
+	if err := sedo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,688 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+)
+
+// ShelfEntryQuery is the builder for querying ShelfEntry entities.
+type ShelfEntryQuery struct {
+	config
+	ctx        *QueryContext
+	order      []shelfentry.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ShelfEntry
+	withShelf  *ShelfQuery
+	withBook   *BookQuery
+	withFKs    bool
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ShelfEntryQuery builder.
+func (seq *ShelfEntryQuery) Where(ps ...predicate.ShelfEntry) *ShelfEntryQuery {
+	seq.predicates = append(seq.predicates, ps...)
+	return seq
+}
+
+// Limit the number of records to be returned by this query.
+func (seq *ShelfEntryQuery) Limit(limit int) *ShelfEntryQuery {
+	seq.ctx.Limit = &limit
+	return seq
+}
+
+// Offset to start from.
+func (seq *ShelfEntryQuery) Offset(offset int) *ShelfEntryQuery {
+	seq.ctx.Offset = &offset
+	return seq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (seq *ShelfEntryQuery) Unique(unique bool) *ShelfEntryQuery {
+	seq.ctx.Unique = &unique
+	return seq
+}
+
+// Order specifies how the records should be ordered.
+func (seq *ShelfEntryQuery) Order(o ...shelfentry.OrderOption) *ShelfEntryQuery {
+	seq.order = append(seq.order, o...)
+	return seq
+}
+
+// QueryShelf chains the current query on the "shelf" edge.
+func (seq *ShelfEntryQuery) QueryShelf() *ShelfQuery {
+	query := (&ShelfClient{config: seq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := seq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := seq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(shelfentry.Table, shelfentry.FieldID, selector),
+			sqlgraph.To(shelf.Table, shelf.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, shelfentry.ShelfTable, shelfentry.ShelfColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(seq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryBook chains the current query on the "book" edge.
+func (seq *ShelfEntryQuery) QueryBook() *BookQuery {
+	query := (&BookClient{config: seq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := seq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := seq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(shelfentry.Table, shelfentry.FieldID, selector),
+			sqlgraph.To(book.Table, book.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, shelfentry.BookTable, shelfentry.BookColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(seq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first ShelfEntry entity from the query.
+// Returns a *NotFoundError when no ShelfEntry was found.
+func (seq *ShelfEntryQuery) First(ctx context.Context) (*ShelfEntry, error) {
+	nodes, err := seq.Limit(1).All(setContextOp(ctx, seq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{shelfentry.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (seq *ShelfEntryQuery) FirstX(ctx context.Context) *ShelfEntry {
+	node, err := seq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ShelfEntry ID from the query.
+// Returns a *NotFoundError when no ShelfEntry ID was found.
+func (seq *ShelfEntryQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = seq.Limit(1).IDs(setContextOp(ctx, seq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{shelfentry.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (seq *ShelfEntryQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := seq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ShelfEntry entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ShelfEntry entity is found.
+// Returns a *NotFoundError when no ShelfEntry entities are found.
+func (seq *ShelfEntryQuery) Only(ctx context.Context) (*ShelfEntry, error) {
+	nodes, err := seq.Limit(2).All(setContextOp(ctx, seq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{shelfentry.Label}
+	default:
+		return nil, &NotSingularError{shelfentry.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (seq *ShelfEntryQuery) OnlyX(ctx context.Context) *ShelfEntry {
+	node, err := seq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ShelfEntry ID in the query.
+// Returns a *NotSingularError when more than one ShelfEntry ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (seq *ShelfEntryQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = seq.Limit(2).IDs(setContextOp(ctx, seq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{shelfentry.Label}
+	default:
+		err = &NotSingularError{shelfentry.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (seq *ShelfEntryQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := seq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ShelfEntries.
+func (seq *ShelfEntryQuery) All(ctx context.Context) ([]*ShelfEntry, error) {
+	ctx = setContextOp(ctx, seq.ctx, "All")
+	if err := seq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ShelfEntry, *ShelfEntryQuery]()
+	return withInterceptors[[]*ShelfEntry](ctx, seq, qr, seq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (seq *ShelfEntryQuery) AllX(ctx context.Context) []*ShelfEntry {
+	nodes, err := seq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ShelfEntry IDs.
+func (seq *ShelfEntryQuery) IDs(ctx context.Context) (ids []int64, err error) {
+	if seq.ctx.Unique == nil && seq.path != nil {
+		seq.Unique(true)
+	}
+	ctx = setContextOp(ctx, seq.ctx, "IDs")
+	if err = seq.Select(shelfentry.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (seq *ShelfEntryQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := seq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (seq *ShelfEntryQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, seq.ctx, "Count")
+	if err := seq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, seq, querierCount[*ShelfEntryQuery](), seq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (seq *ShelfEntryQuery) CountX(ctx context.Context) int {
+	count, err := seq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (seq *ShelfEntryQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, seq.ctx, "Exist")
+	switch _, err := seq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (seq *ShelfEntryQuery) ExistX(ctx context.Context) bool {
+	exist, err := seq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ShelfEntryQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (seq *ShelfEntryQuery) Clone() *ShelfEntryQuery {
+	if seq == nil {
+		return nil
+	}
+	return &ShelfEntryQuery{
+		config:     seq.config,
+		ctx:        seq.ctx.Clone(),
+		order:      append([]shelfentry.OrderOption{}, seq.order...),
+		inters:     append([]Interceptor{}, seq.inters...),
+		predicates: append([]predicate.ShelfEntry{}, seq.predicates...),
+		withShelf:  seq.withShelf.Clone(),
+		withBook:   seq.withBook.Clone(),
+		// clone intermediate query.
+		sql:  seq.sql.Clone(),
+		path: seq.path,
+	}
+}
+
+// WithShelf tells the query-builder to eager-load the nodes that are connected to
+// the "shelf" edge. The optional arguments are used to configure the query builder of the edge.
+func (seq *ShelfEntryQuery) WithShelf(opts ...func(*ShelfQuery)) *ShelfEntryQuery {
+	query := (&ShelfClient{config: seq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	seq.withShelf = query
+	return seq
+}
+
+// WithBook tells the query-builder to eager-load the nodes that are connected to
+// the "book" edge. The optional arguments are used to configure the query builder of the edge.
+func (seq *ShelfEntryQuery) WithBook(opts ...func(*BookQuery)) *ShelfEntryQuery {
+	query := (&BookClient{config: seq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	seq.withBook = query
+	return seq
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		AddedAt int64 `json:"added_at,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ShelfEntry.Query().
+//		GroupBy(shelfentry.FieldAddedAt).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (seq *ShelfEntryQuery) GroupBy(field string, fields ...string) *ShelfEntryGroupBy {
+	seq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ShelfEntryGroupBy{build: seq}
+	grbuild.flds = &seq.ctx.Fields
+	grbuild.label = shelfentry.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		AddedAt int64 `json:"added_at,omitempty"`
+//	}
+//
+//	client.ShelfEntry.Query().
+//		Select(shelfentry.FieldAddedAt).
+//		Scan(ctx, &v)
+func (seq *ShelfEntryQuery) Select(fields ...string) *ShelfEntrySelect {
+	seq.ctx.Fields = append(seq.ctx.Fields, fields...)
+	sbuild := &ShelfEntrySelect{ShelfEntryQuery: seq}
+	sbuild.label = shelfentry.Label
+	sbuild.flds, sbuild.scan = &seq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ShelfEntrySelect configured with the given aggregations.
+func (seq *ShelfEntryQuery) Aggregate(fns ...AggregateFunc) *ShelfEntrySelect {
+	return seq.Select().Aggregate(fns...)
+}
+
+func (seq *ShelfEntryQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range seq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, seq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range seq.ctx.Fields {
+		if !shelfentry.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if seq.path != nil {
+		prev, err := seq.path(ctx)
+		if err != nil {
+			return err
+		}
+		seq.sql = prev
+	}
+	return nil
+}
+
+func (seq *ShelfEntryQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ShelfEntry, error) {
+	var (
+		nodes       = []*ShelfEntry{}
+		withFKs     = seq.withFKs
+		_spec       = seq.querySpec()
+		loadedTypes = [2]bool{
+			seq.withShelf != nil,
+			seq.withBook != nil,
+		}
+	)
+	if seq.withShelf != nil || seq.withBook != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, shelfentry.ForeignKeys...)
+	}
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ShelfEntry).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ShelfEntry{config: seq.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, seq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := seq.withShelf; query != nil {
+		if err := seq.loadShelf(ctx, query, nodes, nil,
+			func(n *ShelfEntry, e *Shelf) { n.Edges.Shelf = e }); err != nil {
+			return nil, err
+		}
+	}
+	if query := seq.withBook; query != nil {
+		if err := seq.loadBook(ctx, query, nodes, nil,
+			func(n *ShelfEntry, e *Book) { n.Edges.Book = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (seq *ShelfEntryQuery) loadShelf(ctx context.Context, query *ShelfQuery, nodes []*ShelfEntry, init func(*ShelfEntry), assign func(*ShelfEntry, *Shelf)) error {
+	ids := make([]int64, 0, len(nodes))
+	nodeids := make(map[int64][]*ShelfEntry)
+	for i := range nodes {
+		if nodes[i].shelf_entry_shelf == nil {
+			continue
+		}
+		fk := *nodes[i].shelf_entry_shelf
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(shelf.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "shelf_entry_shelf" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+func (seq *ShelfEntryQuery) loadBook(ctx context.Context, query *BookQuery, nodes []*ShelfEntry, init func(*ShelfEntry), assign func(*ShelfEntry, *Book)) error {
+	ids := make([]int64, 0, len(nodes))
+	nodeids := make(map[int64][]*ShelfEntry)
+	for i := range nodes {
+		if nodes[i].shelf_entry_book == nil {
+			continue
+		}
+		fk := *nodes[i].shelf_entry_book
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(book.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "shelf_entry_book" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (seq *ShelfEntryQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := seq.querySpec()
+	_spec.Node.Columns = seq.ctx.Fields
+	if len(seq.ctx.Fields) > 0 {
+		_spec.Unique = seq.ctx.Unique != nil && *seq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, seq.driver, _spec)
+}
+
+func (seq *ShelfEntryQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(shelfentry.Table, shelfentry.Columns, sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64))
+	_spec.From = seq.sql
+	if unique := seq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if seq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := seq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, shelfentry.FieldID)
+		for i := range fields {
+			if fields[i] != shelfentry.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := seq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := seq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := seq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := seq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (seq *ShelfEntryQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(seq.driver.Dialect())
+	t1 := builder.Table(shelfentry.Table)
+	columns := seq.ctx.Fields
+	if len(columns) == 0 {
+		columns = shelfentry.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if seq.sql != nil {
+		selector = seq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if seq.ctx.Unique != nil && *seq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range seq.predicates {
+		p(selector)
+	}
+	for _, p := range seq.order {
+		p(selector)
+	}
+	if offset := seq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := seq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ShelfEntryGroupBy is the group-by builder for ShelfEntry entities.
+type ShelfEntryGroupBy struct {
+	selector
+	build *ShelfEntryQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (segb *ShelfEntryGroupBy) Aggregate(fns ...AggregateFunc) *ShelfEntryGroupBy {
+	segb.fns = append(segb.fns, fns...)
+	return segb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (segb *ShelfEntryGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, segb.build.ctx, "GroupBy")
+	if err := segb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ShelfEntryQuery, *ShelfEntryGroupBy](ctx, segb.build, segb, segb.build.inters, v)
+}
+
+func (segb *ShelfEntryGroupBy) sqlScan(ctx context.Context, root *ShelfEntryQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(segb.fns))
+	for _, fn := range segb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*segb.flds)+len(segb.fns))
+		for _, f := range *segb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*segb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := segb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ShelfEntrySelect is the builder for selecting fields of ShelfEntry entities.
+type ShelfEntrySelect struct {
+	*ShelfEntryQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (ses *ShelfEntrySelect) Aggregate(fns ...AggregateFunc) *ShelfEntrySelect {
+	ses.fns = append(ses.fns, fns...)
+	return ses
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ses *ShelfEntrySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ses.ctx, "Select")
+	if err := ses.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ShelfEntryQuery, *ShelfEntrySelect](ctx, ses.ShelfEntryQuery, ses, ses.inters, v)
+}
+
+func (ses *ShelfEntrySelect) sqlScan(ctx context.Context, root *ShelfEntryQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(ses.fns))
+	for _, fn := range ses.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*ses.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ses.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,389 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/book"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelf"
+	"github.com/ninedraft/bibliotheca/storage/ent/shelfentry"
+)
+
+// ShelfEntryUpdate is the builder for updating ShelfEntry entities.
+type ShelfEntryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ShelfEntryMutation
+}
+
+// Where appends a list predicates to the ShelfEntryUpdate builder.
+func (seu *ShelfEntryUpdate) Where(ps ...predicate.ShelfEntry) *ShelfEntryUpdate {
+	seu.mutation.Where(ps...)
+	return seu
+}
+
+// SetShelfID sets the "shelf" edge to the Shelf entity by ID.
+func (seu *ShelfEntryUpdate) SetShelfID(id int64) *ShelfEntryUpdate {
+	seu.mutation.SetShelfID(id)
+	return seu
+}
+
+// SetShelf sets the "shelf" edge to the Shelf entity.
+func (seu *ShelfEntryUpdate) SetShelf(s *Shelf) *ShelfEntryUpdate {
+	return seu.SetShelfID(s.ID)
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (seu *ShelfEntryUpdate) SetBookID(id int64) *ShelfEntryUpdate {
+	seu.mutation.SetBookID(id)
+	return seu
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (seu *ShelfEntryUpdate) SetBook(b *Book) *ShelfEntryUpdate {
+	return seu.SetBookID(b.ID)
+}
+
+// Mutation returns the ShelfEntryMutation object of the builder.
+func (seu *ShelfEntryUpdate) Mutation() *ShelfEntryMutation {
+	return seu.mutation
+}
+
+// ClearShelf clears the "shelf" edge to the Shelf entity.
+func (seu *ShelfEntryUpdate) ClearShelf() *ShelfEntryUpdate {
+	seu.mutation.ClearShelf()
+	return seu
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (seu *ShelfEntryUpdate) ClearBook() *ShelfEntryUpdate {
+	seu.mutation.ClearBook()
+	return seu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (seu *ShelfEntryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, seu.sqlSave, seu.mutation, seu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (seu *ShelfEntryUpdate) SaveX(ctx context.Context) int {
+	affected, err := seu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (seu *ShelfEntryUpdate) Exec(ctx context.Context) error {
+	_, err := seu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (seu *ShelfEntryUpdate) ExecX(ctx context.Context) {
+	if err := seu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (seu *ShelfEntryUpdate) check() error {
+	if _, ok := seu.mutation.ShelfID(); seu.mutation.ShelfCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "ShelfEntry.shelf"`)
+	}
+	if _, ok := seu.mutation.BookID(); seu.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "ShelfEntry.book"`)
+	}
+	return nil
+}
+
+func (seu *ShelfEntryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := seu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(shelfentry.Table, shelfentry.Columns, sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64))
+	if ps := seu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if seu.mutation.ShelfCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.ShelfTable,
+			Columns: []string{shelfentry.ShelfColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := seu.mutation.ShelfIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.ShelfTable,
+			Columns: []string{shelfentry.ShelfColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if seu.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.BookTable,
+			Columns: []string{shelfentry.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := seu.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.BookTable,
+			Columns: []string{shelfentry.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, seu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{shelfentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	seu.mutation.done = true
+	return n, nil
+}
+
+// ShelfEntryUpdateOne is the builder for updating a single ShelfEntry entity.
+type ShelfEntryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ShelfEntryMutation
+}
+
+// SetShelfID sets the "shelf" edge to the Shelf entity by ID.
+func (seuo *ShelfEntryUpdateOne) SetShelfID(id int64) *ShelfEntryUpdateOne {
+	seuo.mutation.SetShelfID(id)
+	return seuo
+}
+
+// SetShelf sets the "shelf" edge to the Shelf entity.
+func (seuo *ShelfEntryUpdateOne) SetShelf(s *Shelf) *ShelfEntryUpdateOne {
+	return seuo.SetShelfID(s.ID)
+}
+
+// SetBookID sets the "book" edge to the Book entity by ID.
+func (seuo *ShelfEntryUpdateOne) SetBookID(id int64) *ShelfEntryUpdateOne {
+	seuo.mutation.SetBookID(id)
+	return seuo
+}
+
+// SetBook sets the "book" edge to the Book entity.
+func (seuo *ShelfEntryUpdateOne) SetBook(b *Book) *ShelfEntryUpdateOne {
+	return seuo.SetBookID(b.ID)
+}
+
+// Mutation returns the ShelfEntryMutation object of the builder.
+func (seuo *ShelfEntryUpdateOne) Mutation() *ShelfEntryMutation {
+	return seuo.mutation
+}
+
+// ClearShelf clears the "shelf" edge to the Shelf entity.
+func (seuo *ShelfEntryUpdateOne) ClearShelf() *ShelfEntryUpdateOne {
+	seuo.mutation.ClearShelf()
+	return seuo
+}
+
+// ClearBook clears the "book" edge to the Book entity.
+func (seuo *ShelfEntryUpdateOne) ClearBook() *ShelfEntryUpdateOne {
+	seuo.mutation.ClearBook()
+	return seuo
+}
+
+// Where appends a list predicates to the ShelfEntryUpdate builder.
+func (seuo *ShelfEntryUpdateOne) Where(ps ...predicate.ShelfEntry) *ShelfEntryUpdateOne {
+	seuo.mutation.Where(ps...)
+	return seuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (seuo *ShelfEntryUpdateOne) Select(field string, fields ...string) *ShelfEntryUpdateOne {
+	seuo.fields = append([]string{field}, fields...)
+	return seuo
+}
+
+// Save executes the query and returns the updated ShelfEntry entity.
+func (seuo *ShelfEntryUpdateOne) Save(ctx context.Context) (*ShelfEntry, error) {
+	return withHooks(ctx, seuo.sqlSave, seuo.mutation, seuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (seuo *ShelfEntryUpdateOne) SaveX(ctx context.Context) *ShelfEntry {
+	node, err := seuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (seuo *ShelfEntryUpdateOne) Exec(ctx context.Context) error {
+	_, err := seuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (seuo *ShelfEntryUpdateOne) ExecX(ctx context.Context) {
+	if err := seuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (seuo *ShelfEntryUpdateOne) check() error {
+	if _, ok := seuo.mutation.ShelfID(); seuo.mutation.ShelfCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "ShelfEntry.shelf"`)
+	}
+	if _, ok := seuo.mutation.BookID(); seuo.mutation.BookCleared() && !ok {
+		return errors.New(`ent: clearing a required unique edge "ShelfEntry.book"`)
+	}
+	return nil
+}
+
+func (seuo *ShelfEntryUpdateOne) sqlSave(ctx context.Context) (_node *ShelfEntry, err error) {
+	if err := seuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(shelfentry.Table, shelfentry.Columns, sqlgraph.NewFieldSpec(shelfentry.FieldID, field.TypeInt64))
+	id, ok := seuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ShelfEntry.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := seuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, shelfentry.FieldID)
+		for _, f := range fields {
+			if !shelfentry.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != shelfentry.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := seuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if seuo.mutation.ShelfCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.ShelfTable,
+			Columns: []string{shelfentry.ShelfColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := seuo.mutation.ShelfIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.ShelfTable,
+			Columns: []string{shelfentry.ShelfColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(shelf.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if seuo.mutation.BookCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.BookTable,
+			Columns: []string{shelfentry.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := seuo.mutation.BookIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   shelfentry.BookTable,
+			Columns: []string{shelfentry.BookColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(book.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &ShelfEntry{config: seuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, seuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{shelfentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	seuo.mutation.done = true
+	return _node, nil
+}
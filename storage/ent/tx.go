@@ -12,10 +12,48 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// APIToken is the client for interacting with the APIToken builders.
+	APIToken *APITokenClient
+	// AuditEntry is the client for interacting with the AuditEntry builders.
+	AuditEntry *AuditEntryClient
 	// Author is the client for interacting with the Author builders.
 	Author *AuthorClient
 	// Book is the client for interacting with the Book builders.
 	Book *BookClient
+	// BookCopy is the client for interacting with the BookCopy builders.
+	BookCopy *BookCopyClient
+	// Checkpoint is the client for interacting with the Checkpoint builders.
+	Checkpoint *CheckpointClient
+	// Delivery is the client for interacting with the Delivery builders.
+	Delivery *DeliveryClient
+	// Device is the client for interacting with the Device builders.
+	Device *DeviceClient
+	// Document is the client for interacting with the Document builders.
+	Document *DocumentClient
+	// Highlight is the client for interacting with the Highlight builders.
+	Highlight *HighlightClient
+	// Hold is the client for interacting with the Hold builders.
+	Hold *HoldClient
+	// Loan is the client for interacting with the Loan builders.
+	Loan *LoanClient
+	// OutboxMessage is the client for interacting with the OutboxMessage builders.
+	OutboxMessage *OutboxMessageClient
+	// ReadingProgress is the client for interacting with the ReadingProgress builders.
+	ReadingProgress *ReadingProgressClient
+	// Review is the client for interacting with the Review builders.
+	Review *ReviewClient
+	// Revision is the client for interacting with the Revision builders.
+	Revision *RevisionClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
+	// Shelf is the client for interacting with the Shelf builders.
+	Shelf *ShelfClient
+	// ShelfEntry is the client for interacting with the ShelfEntry builders.
+	ShelfEntry *ShelfEntryClient
+	// User is the client for interacting with the User builders.
+	User *UserClient
+	// Validation is the client for interacting with the Validation builders.
+	Validation *ValidationClient
 
 	// lazily loaded.
 	client     *Client
@@ -147,8 +185,27 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.APIToken = NewAPITokenClient(tx.config)
+	tx.AuditEntry = NewAuditEntryClient(tx.config)
 	tx.Author = NewAuthorClient(tx.config)
 	tx.Book = NewBookClient(tx.config)
+	tx.BookCopy = NewBookCopyClient(tx.config)
+	tx.Checkpoint = NewCheckpointClient(tx.config)
+	tx.Delivery = NewDeliveryClient(tx.config)
+	tx.Device = NewDeviceClient(tx.config)
+	tx.Document = NewDocumentClient(tx.config)
+	tx.Highlight = NewHighlightClient(tx.config)
+	tx.Hold = NewHoldClient(tx.config)
+	tx.Loan = NewLoanClient(tx.config)
+	tx.OutboxMessage = NewOutboxMessageClient(tx.config)
+	tx.ReadingProgress = NewReadingProgressClient(tx.config)
+	tx.Review = NewReviewClient(tx.config)
+	tx.Revision = NewRevisionClient(tx.config)
+	tx.Session = NewSessionClient(tx.config)
+	tx.Shelf = NewShelfClient(tx.config)
+	tx.ShelfEntry = NewShelfEntryClient(tx.config)
+	tx.User = NewUserClient(tx.config)
+	tx.Validation = NewValidationClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -158,7 +215,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Author.QueryXXX(), the query will be executed
+// applies a query, for example: APIToken.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -0,0 +1,377 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+// User is the model entity for the User schema.
+type User struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// Login holds the value of the "login" field.
+	Login string `json:"login,omitempty"`
+	// PasswordHash holds the value of the "password_hash" field.
+	PasswordHash string `json:"-"`
+	// Role holds the value of the "role" field.
+	Role user.Role `json:"role,omitempty"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email,omitempty"`
+	// NotifyDue holds the value of the "notify_due" field.
+	NotifyDue bool `json:"notify_due,omitempty"`
+	// NotifyHolds holds the value of the "notify_holds" field.
+	NotifyHolds bool `json:"notify_holds,omitempty"`
+	// NotifyNewBooks holds the value of the "notify_new_books" field.
+	NotifyNewBooks bool `json:"notify_new_books,omitempty"`
+	// SyncKeyHash holds the value of the "sync_key_hash" field.
+	SyncKeyHash string `json:"-"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt int64 `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the UserQuery when eager-loading is set.
+	Edges        UserEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// UserEdges holds the relations/edges for other nodes in the graph.
+type UserEdges struct {
+	// Sessions holds the value of the sessions edge.
+	Sessions []*Session `json:"sessions,omitempty"`
+	// APITokens holds the value of the api_tokens edge.
+	APITokens []*APIToken `json:"api_tokens,omitempty"`
+	// Loans holds the value of the loans edge.
+	Loans []*Loan `json:"loans,omitempty"`
+	// Holds holds the value of the holds edge.
+	Holds []*Hold `json:"holds,omitempty"`
+	// FollowedAuthors holds the value of the followed_authors edge.
+	FollowedAuthors []*Author `json:"followed_authors,omitempty"`
+	// Devices holds the value of the devices edge.
+	Devices []*Device `json:"devices,omitempty"`
+	// Deliveries holds the value of the deliveries edge.
+	Deliveries []*Delivery `json:"deliveries,omitempty"`
+	// Shelves holds the value of the shelves edge.
+	Shelves []*Shelf `json:"shelves,omitempty"`
+	// Reviews holds the value of the reviews edge.
+	Reviews []*Review `json:"reviews,omitempty"`
+	// ReadingProgress holds the value of the reading_progress edge.
+	ReadingProgress []*ReadingProgress `json:"reading_progress,omitempty"`
+	// Highlights holds the value of the highlights edge.
+	Highlights []*Highlight `json:"highlights,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [11]bool
+}
+
+// SessionsOrErr returns the Sessions value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) SessionsOrErr() ([]*Session, error) {
+	if e.loadedTypes[0] {
+		return e.Sessions, nil
+	}
+	return nil, &NotLoadedError{edge: "sessions"}
+}
+
+// APITokensOrErr returns the APITokens value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) APITokensOrErr() ([]*APIToken, error) {
+	if e.loadedTypes[1] {
+		return e.APITokens, nil
+	}
+	return nil, &NotLoadedError{edge: "api_tokens"}
+}
+
+// LoansOrErr returns the Loans value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) LoansOrErr() ([]*Loan, error) {
+	if e.loadedTypes[2] {
+		return e.Loans, nil
+	}
+	return nil, &NotLoadedError{edge: "loans"}
+}
+
+// HoldsOrErr returns the Holds value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) HoldsOrErr() ([]*Hold, error) {
+	if e.loadedTypes[3] {
+		return e.Holds, nil
+	}
+	return nil, &NotLoadedError{edge: "holds"}
+}
+
+// FollowedAuthorsOrErr returns the FollowedAuthors value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) FollowedAuthorsOrErr() ([]*Author, error) {
+	if e.loadedTypes[4] {
+		return e.FollowedAuthors, nil
+	}
+	return nil, &NotLoadedError{edge: "followed_authors"}
+}
+
+// DevicesOrErr returns the Devices value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) DevicesOrErr() ([]*Device, error) {
+	if e.loadedTypes[5] {
+		return e.Devices, nil
+	}
+	return nil, &NotLoadedError{edge: "devices"}
+}
+
+// DeliveriesOrErr returns the Deliveries value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) DeliveriesOrErr() ([]*Delivery, error) {
+	if e.loadedTypes[6] {
+		return e.Deliveries, nil
+	}
+	return nil, &NotLoadedError{edge: "deliveries"}
+}
+
+// ShelvesOrErr returns the Shelves value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) ShelvesOrErr() ([]*Shelf, error) {
+	if e.loadedTypes[7] {
+		return e.Shelves, nil
+	}
+	return nil, &NotLoadedError{edge: "shelves"}
+}
+
+// ReviewsOrErr returns the Reviews value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) ReviewsOrErr() ([]*Review, error) {
+	if e.loadedTypes[8] {
+		return e.Reviews, nil
+	}
+	return nil, &NotLoadedError{edge: "reviews"}
+}
+
+// ReadingProgressOrErr returns the ReadingProgress value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) ReadingProgressOrErr() ([]*ReadingProgress, error) {
+	if e.loadedTypes[9] {
+		return e.ReadingProgress, nil
+	}
+	return nil, &NotLoadedError{edge: "reading_progress"}
+}
+
+// HighlightsOrErr returns the Highlights value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) HighlightsOrErr() ([]*Highlight, error) {
+	if e.loadedTypes[10] {
+		return e.Highlights, nil
+	}
+	return nil, &NotLoadedError{edge: "highlights"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*User) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case user.FieldNotifyDue, user.FieldNotifyHolds, user.FieldNotifyNewBooks:
+			values[i] = new(sql.NullBool)
+		case user.FieldID, user.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case user.FieldLogin, user.FieldPasswordHash, user.FieldRole, user.FieldEmail, user.FieldSyncKeyHash:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the User fields.
+func (u *User) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case user.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			u.ID = int64(value.Int64)
+		case user.FieldLogin:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field login", values[i])
+			} else if value.Valid {
+				u.Login = value.String
+			}
+		case user.FieldPasswordHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field password_hash", values[i])
+			} else if value.Valid {
+				u.PasswordHash = value.String
+			}
+		case user.FieldRole:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field role", values[i])
+			} else if value.Valid {
+				u.Role = user.Role(value.String)
+			}
+		case user.FieldEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[i])
+			} else if value.Valid {
+				u.Email = value.String
+			}
+		case user.FieldNotifyDue:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field notify_due", values[i])
+			} else if value.Valid {
+				u.NotifyDue = value.Bool
+			}
+		case user.FieldNotifyHolds:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field notify_holds", values[i])
+			} else if value.Valid {
+				u.NotifyHolds = value.Bool
+			}
+		case user.FieldNotifyNewBooks:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field notify_new_books", values[i])
+			} else if value.Valid {
+				u.NotifyNewBooks = value.Bool
+			}
+		case user.FieldSyncKeyHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field sync_key_hash", values[i])
+			} else if value.Valid {
+				u.SyncKeyHash = value.String
+			}
+		case user.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				u.CreatedAt = value.Int64
+			}
+		default:
+			u.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the User.
+// This includes values selected through modifiers, order, etc.
+func (u *User) Value(name string) (ent.Value, error) {
+	return u.selectValues.Get(name)
+}
+
+// QuerySessions queries the "sessions" edge of the User entity.
+func (u *User) QuerySessions() *SessionQuery {
+	return NewUserClient(u.config).QuerySessions(u)
+}
+
+// QueryAPITokens queries the "api_tokens" edge of the User entity.
+func (u *User) QueryAPITokens() *APITokenQuery {
+	return NewUserClient(u.config).QueryAPITokens(u)
+}
+
+// QueryLoans queries the "loans" edge of the User entity.
+func (u *User) QueryLoans() *LoanQuery {
+	return NewUserClient(u.config).QueryLoans(u)
+}
+
+// QueryHolds queries the "holds" edge of the User entity.
+func (u *User) QueryHolds() *HoldQuery {
+	return NewUserClient(u.config).QueryHolds(u)
+}
+
+// QueryFollowedAuthors queries the "followed_authors" edge of the User entity.
+func (u *User) QueryFollowedAuthors() *AuthorQuery {
+	return NewUserClient(u.config).QueryFollowedAuthors(u)
+}
+
+// QueryDevices queries the "devices" edge of the User entity.
+func (u *User) QueryDevices() *DeviceQuery {
+	return NewUserClient(u.config).QueryDevices(u)
+}
+
+// QueryDeliveries queries the "deliveries" edge of the User entity.
+func (u *User) QueryDeliveries() *DeliveryQuery {
+	return NewUserClient(u.config).QueryDeliveries(u)
+}
+
+// QueryShelves queries the "shelves" edge of the User entity.
+func (u *User) QueryShelves() *ShelfQuery {
+	return NewUserClient(u.config).QueryShelves(u)
+}
+
+// QueryReviews queries the "reviews" edge of the User entity.
+func (u *User) QueryReviews() *ReviewQuery {
+	return NewUserClient(u.config).QueryReviews(u)
+}
+
+// QueryReadingProgress queries the "reading_progress" edge of the User entity.
+func (u *User) QueryReadingProgress() *ReadingProgressQuery {
+	return NewUserClient(u.config).QueryReadingProgress(u)
+}
+
+// QueryHighlights queries the "highlights" edge of the User entity.
+func (u *User) QueryHighlights() *HighlightQuery {
+	return NewUserClient(u.config).QueryHighlights(u)
+}
+
+// Update returns a builder for updating this User.
+// Note that you need to call User.Unwrap() before calling this method if this User
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (u *User) Update() *UserUpdateOne {
+	return NewUserClient(u.config).UpdateOne(u)
+}
+
+// Unwrap unwraps the User entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (u *User) Unwrap() *User {
+	_tx, ok := u.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: User is not a transactional entity")
+	}
+	u.config.driver = _tx.drv
+	return u
+}
+
+// String implements the fmt.Stringer.
+func (u *User) String() string {
+	var builder strings.Builder
+	builder.WriteString("User(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", u.ID))
+	builder.WriteString("login=")
+	builder.WriteString(u.Login)
+	builder.WriteString(", ")
+	builder.WriteString("password_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("role=")
+	builder.WriteString(fmt.Sprintf("%v", u.Role))
+	builder.WriteString(", ")
+	builder.WriteString("email=")
+	builder.WriteString(u.Email)
+	builder.WriteString(", ")
+	builder.WriteString("notify_due=")
+	builder.WriteString(fmt.Sprintf("%v", u.NotifyDue))
+	builder.WriteString(", ")
+	builder.WriteString("notify_holds=")
+	builder.WriteString(fmt.Sprintf("%v", u.NotifyHolds))
+	builder.WriteString(", ")
+	builder.WriteString("notify_new_books=")
+	builder.WriteString(fmt.Sprintf("%v", u.NotifyNewBooks))
+	builder.WriteString(", ")
+	builder.WriteString("sync_key_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", u.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Users is a parsable slice of User.
+type Users []*User
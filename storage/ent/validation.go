@@ -0,0 +1,131 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
+)
+
+// Validation is the model entity for the Validation schema.
+type Validation struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// FileID holds the value of the "file_id" field.
+	FileID string `json:"file_id,omitempty"`
+	// Problems holds the value of the "problems" field.
+	Problems []schema.FileProblem `json:"problems,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    int64 `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Validation) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case validation.FieldProblems:
+			values[i] = new([]byte)
+		case validation.FieldID, validation.FieldCreatedAt:
+			values[i] = new(sql.NullInt64)
+		case validation.FieldFileID:
+			values[i] = new(sql.NullString)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Validation fields.
+func (v *Validation) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case validation.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			v.ID = int64(value.Int64)
+		case validation.FieldFileID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field file_id", values[i])
+			} else if value.Valid {
+				v.FileID = value.String
+			}
+		case validation.FieldProblems:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field problems", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &v.Problems); err != nil {
+					return fmt.Errorf("unmarshal field problems: %w", err)
+				}
+			}
+		case validation.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				v.CreatedAt = value.Int64
+			}
+		default:
+			v.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Validation.
+// This includes values selected through modifiers, order, etc.
+func (v *Validation) Value(name string) (ent.Value, error) {
+	return v.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Validation.
+// Note that you need to call Validation.Unwrap() before calling this method if this Validation
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (v *Validation) Update() *ValidationUpdateOne {
+	return NewValidationClient(v.config).UpdateOne(v)
+}
+
+// Unwrap unwraps the Validation entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (v *Validation) Unwrap() *Validation {
+	_tx, ok := v.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Validation is not a transactional entity")
+	}
+	v.config.driver = _tx.drv
+	return v
+}
+
+// String implements the fmt.Stringer.
+func (v *Validation) String() string {
+	var builder strings.Builder
+	builder.WriteString("Validation(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", v.ID))
+	builder.WriteString("file_id=")
+	builder.WriteString(v.FileID)
+	builder.WriteString(", ")
+	builder.WriteString("problems=")
+	builder.WriteString(fmt.Sprintf("%v", v.Problems))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(fmt.Sprintf("%v", v.CreatedAt))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Validations is a parsable slice of Validation.
+type Validations []*Validation
@@ -0,0 +1,237 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
+)
+
+// ValidationCreate is the builder for creating a Validation entity.
+type ValidationCreate struct {
+	config
+	mutation *ValidationMutation
+	hooks    []Hook
+}
+
+// SetFileID sets the "file_id" field.
+func (vc *ValidationCreate) SetFileID(s string) *ValidationCreate {
+	vc.mutation.SetFileID(s)
+	return vc
+}
+
+// SetProblems sets the "problems" field.
+func (vc *ValidationCreate) SetProblems(sp []schema.FileProblem) *ValidationCreate {
+	vc.mutation.SetProblems(sp)
+	return vc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (vc *ValidationCreate) SetCreatedAt(i int64) *ValidationCreate {
+	vc.mutation.SetCreatedAt(i)
+	return vc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (vc *ValidationCreate) SetNillableCreatedAt(i *int64) *ValidationCreate {
+	if i != nil {
+		vc.SetCreatedAt(*i)
+	}
+	return vc
+}
+
+// SetID sets the "id" field.
+func (vc *ValidationCreate) SetID(i int64) *ValidationCreate {
+	vc.mutation.SetID(i)
+	return vc
+}
+
+// Mutation returns the ValidationMutation object of the builder.
+func (vc *ValidationCreate) Mutation() *ValidationMutation {
+	return vc.mutation
+}
+
+// Save creates the Validation in the database.
+func (vc *ValidationCreate) Save(ctx context.Context) (*Validation, error) {
+	vc.defaults()
+	return withHooks(ctx, vc.sqlSave, vc.mutation, vc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (vc *ValidationCreate) SaveX(ctx context.Context) *Validation {
+	v, err := vc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (vc *ValidationCreate) Exec(ctx context.Context) error {
+	_, err := vc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vc *ValidationCreate) ExecX(ctx context.Context) {
+	if err := vc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (vc *ValidationCreate) defaults() {
+	if _, ok := vc.mutation.CreatedAt(); !ok {
+		v := validation.DefaultCreatedAt()
+		vc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (vc *ValidationCreate) check() error {
+	if _, ok := vc.mutation.FileID(); !ok {
+		return &ValidationError{Name: "file_id", err: errors.New(`ent: missing required field "Validation.file_id"`)}
+	}
+	if _, ok := vc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Validation.created_at"`)}
+	}
+	return nil
+}
+
+func (vc *ValidationCreate) sqlSave(ctx context.Context) (*Validation, error) {
+	if err := vc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := vc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, vc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	vc.mutation.id = &_node.ID
+	vc.mutation.done = true
+	return _node, nil
+}
+
+func (vc *ValidationCreate) createSpec() (*Validation, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Validation{config: vc.config}
+		_spec = sqlgraph.NewCreateSpec(validation.Table, sqlgraph.NewFieldSpec(validation.FieldID, field.TypeInt64))
+	)
+	if id, ok := vc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := vc.mutation.FileID(); ok {
+		_spec.SetField(validation.FieldFileID, field.TypeString, value)
+		_node.FileID = value
+	}
+	if value, ok := vc.mutation.Problems(); ok {
+		_spec.SetField(validation.FieldProblems, field.TypeJSON, value)
+		_node.Problems = value
+	}
+	if value, ok := vc.mutation.CreatedAt(); ok {
+		_spec.SetField(validation.FieldCreatedAt, field.TypeInt64, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// ValidationCreateBulk is the builder for creating many Validation entities in bulk.
+type ValidationCreateBulk struct {
+	config
+	err      error
+	builders []*ValidationCreate
+}
+
+// Save creates the Validation entities in the database.
+func (vcb *ValidationCreateBulk) Save(ctx context.Context) ([]*Validation, error) {
+	if vcb.err != nil {
+		return nil, vcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(vcb.builders))
+	nodes := make([]*Validation, len(vcb.builders))
+	mutators := make([]Mutator, len(vcb.builders))
+	for i := range vcb.builders {
+		func(i int, root context.Context) {
+			builder := vcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ValidationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, vcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, vcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, vcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (vcb *ValidationCreateBulk) SaveX(ctx context.Context) []*Validation {
+	v, err := vcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (vcb *ValidationCreateBulk) Exec(ctx context.Context) error {
+	_, err := vcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vcb *ValidationCreateBulk) ExecX(ctx context.Context) {
+	if err := vcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
+)
+
+// ValidationDelete is the builder for deleting a Validation entity.
+type ValidationDelete struct {
+	config
+	hooks    []Hook
+	mutation *ValidationMutation
+}
+
+// Where appends a list predicates to the ValidationDelete builder.
+func (vd *ValidationDelete) Where(ps ...predicate.Validation) *ValidationDelete {
+	vd.mutation.Where(ps...)
+	return vd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (vd *ValidationDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, vd.sqlExec, vd.mutation, vd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vd *ValidationDelete) ExecX(ctx context.Context) int {
+	n, err := vd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (vd *ValidationDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(validation.Table, sqlgraph.NewFieldSpec(validation.FieldID, field.TypeInt64))
+	if ps := vd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, vd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	vd.mutation.done = true
+	return affected, err
+}
+
+// ValidationDeleteOne is the builder for deleting a single Validation entity.
+type ValidationDeleteOne struct {
+	vd *ValidationDelete
+}
+
+// Where appends a list predicates to the ValidationDelete builder.
+func (vdo *ValidationDeleteOne) Where(ps ...predicate.Validation) *ValidationDeleteOne {
+	vdo.vd.mutation.Where(ps...)
+	return vdo
+}
+
+// Exec executes the deletion query.
+func (vdo *ValidationDeleteOne) Exec(ctx context.Context) error {
+	n, err := vdo.vd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{validation.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vdo *ValidationDeleteOne) ExecX(ctx context.Context) {
+	if err := vdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
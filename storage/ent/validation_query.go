@@ -0,0 +1,526 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
+)
+
+// ValidationQuery is the builder for querying Validation entities.
+type ValidationQuery struct {
+	config
+	ctx        *QueryContext
+	order      []validation.OrderOption
+	inters     []Interceptor
+	predicates []predicate.Validation
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ValidationQuery builder.
+func (vq *ValidationQuery) Where(ps ...predicate.Validation) *ValidationQuery {
+	vq.predicates = append(vq.predicates, ps...)
+	return vq
+}
+
+// Limit the number of records to be returned by this query.
+func (vq *ValidationQuery) Limit(limit int) *ValidationQuery {
+	vq.ctx.Limit = &limit
+	return vq
+}
+
+// Offset to start from.
+func (vq *ValidationQuery) Offset(offset int) *ValidationQuery {
+	vq.ctx.Offset = &offset
+	return vq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (vq *ValidationQuery) Unique(unique bool) *ValidationQuery {
+	vq.ctx.Unique = &unique
+	return vq
+}
+
+// Order specifies how the records should be ordered.
+func (vq *ValidationQuery) Order(o ...validation.OrderOption) *ValidationQuery {
+	vq.order = append(vq.order, o...)
+	return vq
+}
+
+// First returns the first Validation entity from the query.
+// Returns a *NotFoundError when no Validation was found.
+func (vq *ValidationQuery) First(ctx context.Context) (*Validation, error) {
+	nodes, err := vq.Limit(1).All(setContextOp(ctx, vq.ctx, "First"))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{validation.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (vq *ValidationQuery) FirstX(ctx context.Context) *Validation {
+	node, err := vq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first Validation ID from the query.
+// Returns a *NotFoundError when no Validation ID was found.
+func (vq *ValidationQuery) FirstID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = vq.Limit(1).IDs(setContextOp(ctx, vq.ctx, "FirstID")); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{validation.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (vq *ValidationQuery) FirstIDX(ctx context.Context) int64 {
+	id, err := vq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single Validation entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one Validation entity is found.
+// Returns a *NotFoundError when no Validation entities are found.
+func (vq *ValidationQuery) Only(ctx context.Context) (*Validation, error) {
+	nodes, err := vq.Limit(2).All(setContextOp(ctx, vq.ctx, "Only"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{validation.Label}
+	default:
+		return nil, &NotSingularError{validation.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (vq *ValidationQuery) OnlyX(ctx context.Context) *Validation {
+	node, err := vq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only Validation ID in the query.
+// Returns a *NotSingularError when more than one Validation ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (vq *ValidationQuery) OnlyID(ctx context.Context) (id int64, err error) {
+	var ids []int64
+	if ids, err = vq.Limit(2).IDs(setContextOp(ctx, vq.ctx, "OnlyID")); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{validation.Label}
+	default:
+		err = &NotSingularError{validation.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (vq *ValidationQuery) OnlyIDX(ctx context.Context) int64 {
+	id, err := vq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of Validations.
+func (vq *ValidationQuery) All(ctx context.Context) ([]*Validation, error) {
+	ctx = setContextOp(ctx, vq.ctx, "All")
+	if err := vq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*Validation, *ValidationQuery]()
+	return withInterceptors[[]*Validation](ctx, vq, qr, vq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (vq *ValidationQuery) AllX(ctx context.Context) []*Validation {
+	nodes, err := vq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of Validation IDs.
+func (vq *ValidationQuery) IDs(ctx context.Context) (ids []int64, err error) {
+	if vq.ctx.Unique == nil && vq.path != nil {
+		vq.Unique(true)
+	}
+	ctx = setContextOp(ctx, vq.ctx, "IDs")
+	if err = vq.Select(validation.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (vq *ValidationQuery) IDsX(ctx context.Context) []int64 {
+	ids, err := vq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (vq *ValidationQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, vq.ctx, "Count")
+	if err := vq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, vq, querierCount[*ValidationQuery](), vq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (vq *ValidationQuery) CountX(ctx context.Context) int {
+	count, err := vq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (vq *ValidationQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, vq.ctx, "Exist")
+	switch _, err := vq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (vq *ValidationQuery) ExistX(ctx context.Context) bool {
+	exist, err := vq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ValidationQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (vq *ValidationQuery) Clone() *ValidationQuery {
+	if vq == nil {
+		return nil
+	}
+	return &ValidationQuery{
+		config:     vq.config,
+		ctx:        vq.ctx.Clone(),
+		order:      append([]validation.OrderOption{}, vq.order...),
+		inters:     append([]Interceptor{}, vq.inters...),
+		predicates: append([]predicate.Validation{}, vq.predicates...),
+		// clone intermediate query.
+		sql:  vq.sql.Clone(),
+		path: vq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		FileID string `json:"file_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.Validation.Query().
+//		GroupBy(validation.FieldFileID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (vq *ValidationQuery) GroupBy(field string, fields ...string) *ValidationGroupBy {
+	vq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ValidationGroupBy{build: vq}
+	grbuild.flds = &vq.ctx.Fields
+	grbuild.label = validation.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		FileID string `json:"file_id,omitempty"`
+//	}
+//
+//	client.Validation.Query().
+//		Select(validation.FieldFileID).
+//		Scan(ctx, &v)
+func (vq *ValidationQuery) Select(fields ...string) *ValidationSelect {
+	vq.ctx.Fields = append(vq.ctx.Fields, fields...)
+	sbuild := &ValidationSelect{ValidationQuery: vq}
+	sbuild.label = validation.Label
+	sbuild.flds, sbuild.scan = &vq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ValidationSelect configured with the given aggregations.
+func (vq *ValidationQuery) Aggregate(fns ...AggregateFunc) *ValidationSelect {
+	return vq.Select().Aggregate(fns...)
+}
+
+func (vq *ValidationQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range vq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, vq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range vq.ctx.Fields {
+		if !validation.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if vq.path != nil {
+		prev, err := vq.path(ctx)
+		if err != nil {
+			return err
+		}
+		vq.sql = prev
+	}
+	return nil
+}
+
+func (vq *ValidationQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Validation, error) {
+	var (
+		nodes = []*Validation{}
+		_spec = vq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*Validation).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &Validation{config: vq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, vq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (vq *ValidationQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := vq.querySpec()
+	_spec.Node.Columns = vq.ctx.Fields
+	if len(vq.ctx.Fields) > 0 {
+		_spec.Unique = vq.ctx.Unique != nil && *vq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, vq.driver, _spec)
+}
+
+func (vq *ValidationQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(validation.Table, validation.Columns, sqlgraph.NewFieldSpec(validation.FieldID, field.TypeInt64))
+	_spec.From = vq.sql
+	if unique := vq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if vq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := vq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, validation.FieldID)
+		for i := range fields {
+			if fields[i] != validation.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := vq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := vq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := vq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := vq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (vq *ValidationQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(vq.driver.Dialect())
+	t1 := builder.Table(validation.Table)
+	columns := vq.ctx.Fields
+	if len(columns) == 0 {
+		columns = validation.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if vq.sql != nil {
+		selector = vq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if vq.ctx.Unique != nil && *vq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range vq.predicates {
+		p(selector)
+	}
+	for _, p := range vq.order {
+		p(selector)
+	}
+	if offset := vq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := vq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ValidationGroupBy is the group-by builder for Validation entities.
+type ValidationGroupBy struct {
+	selector
+	build *ValidationQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (vgb *ValidationGroupBy) Aggregate(fns ...AggregateFunc) *ValidationGroupBy {
+	vgb.fns = append(vgb.fns, fns...)
+	return vgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (vgb *ValidationGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, vgb.build.ctx, "GroupBy")
+	if err := vgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ValidationQuery, *ValidationGroupBy](ctx, vgb.build, vgb, vgb.build.inters, v)
+}
+
+func (vgb *ValidationGroupBy) sqlScan(ctx context.Context, root *ValidationQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(vgb.fns))
+	for _, fn := range vgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*vgb.flds)+len(vgb.fns))
+		for _, f := range *vgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*vgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := vgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ValidationSelect is the builder for selecting fields of Validation entities.
+type ValidationSelect struct {
+	*ValidationQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (vs *ValidationSelect) Aggregate(fns ...AggregateFunc) *ValidationSelect {
+	vs.fns = append(vs.fns, fns...)
+	return vs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (vs *ValidationSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, vs.ctx, "Select")
+	if err := vs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ValidationQuery, *ValidationSelect](ctx, vs.ValidationQuery, vs, vs.inters, v)
+}
+
+func (vs *ValidationSelect) sqlScan(ctx context.Context, root *ValidationQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(vs.fns))
+	for _, fn := range vs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*vs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := vs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
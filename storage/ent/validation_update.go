@@ -0,0 +1,253 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/ninedraft/bibliotheca/storage/ent/predicate"
+	"github.com/ninedraft/bibliotheca/storage/ent/schema"
+	"github.com/ninedraft/bibliotheca/storage/ent/validation"
+)
+
+// ValidationUpdate is the builder for updating Validation entities.
+type ValidationUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ValidationMutation
+}
+
+// Where appends a list predicates to the ValidationUpdate builder.
+func (vu *ValidationUpdate) Where(ps ...predicate.Validation) *ValidationUpdate {
+	vu.mutation.Where(ps...)
+	return vu
+}
+
+// SetFileID sets the "file_id" field.
+func (vu *ValidationUpdate) SetFileID(s string) *ValidationUpdate {
+	vu.mutation.SetFileID(s)
+	return vu
+}
+
+// SetProblems sets the "problems" field.
+func (vu *ValidationUpdate) SetProblems(sp []schema.FileProblem) *ValidationUpdate {
+	vu.mutation.SetProblems(sp)
+	return vu
+}
+
+// AppendProblems appends sp to the "problems" field.
+func (vu *ValidationUpdate) AppendProblems(sp []schema.FileProblem) *ValidationUpdate {
+	vu.mutation.AppendProblems(sp)
+	return vu
+}
+
+// ClearProblems clears the value of the "problems" field.
+func (vu *ValidationUpdate) ClearProblems() *ValidationUpdate {
+	vu.mutation.ClearProblems()
+	return vu
+}
+
+// Mutation returns the ValidationMutation object of the builder.
+func (vu *ValidationUpdate) Mutation() *ValidationMutation {
+	return vu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (vu *ValidationUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, vu.sqlSave, vu.mutation, vu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (vu *ValidationUpdate) SaveX(ctx context.Context) int {
+	affected, err := vu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (vu *ValidationUpdate) Exec(ctx context.Context) error {
+	_, err := vu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vu *ValidationUpdate) ExecX(ctx context.Context) {
+	if err := vu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (vu *ValidationUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(validation.Table, validation.Columns, sqlgraph.NewFieldSpec(validation.FieldID, field.TypeInt64))
+	if ps := vu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := vu.mutation.FileID(); ok {
+		_spec.SetField(validation.FieldFileID, field.TypeString, value)
+	}
+	if value, ok := vu.mutation.Problems(); ok {
+		_spec.SetField(validation.FieldProblems, field.TypeJSON, value)
+	}
+	if value, ok := vu.mutation.AppendedProblems(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, validation.FieldProblems, value)
+		})
+	}
+	if vu.mutation.ProblemsCleared() {
+		_spec.ClearField(validation.FieldProblems, field.TypeJSON)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, vu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{validation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	vu.mutation.done = true
+	return n, nil
+}
+
+// ValidationUpdateOne is the builder for updating a single Validation entity.
+type ValidationUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ValidationMutation
+}
+
+// SetFileID sets the "file_id" field.
+func (vuo *ValidationUpdateOne) SetFileID(s string) *ValidationUpdateOne {
+	vuo.mutation.SetFileID(s)
+	return vuo
+}
+
+// SetProblems sets the "problems" field.
+func (vuo *ValidationUpdateOne) SetProblems(sp []schema.FileProblem) *ValidationUpdateOne {
+	vuo.mutation.SetProblems(sp)
+	return vuo
+}
+
+// AppendProblems appends sp to the "problems" field.
+func (vuo *ValidationUpdateOne) AppendProblems(sp []schema.FileProblem) *ValidationUpdateOne {
+	vuo.mutation.AppendProblems(sp)
+	return vuo
+}
+
+// ClearProblems clears the value of the "problems" field.
+func (vuo *ValidationUpdateOne) ClearProblems() *ValidationUpdateOne {
+	vuo.mutation.ClearProblems()
+	return vuo
+}
+
+// Mutation returns the ValidationMutation object of the builder.
+func (vuo *ValidationUpdateOne) Mutation() *ValidationMutation {
+	return vuo.mutation
+}
+
+// Where appends a list predicates to the ValidationUpdate builder.
+func (vuo *ValidationUpdateOne) Where(ps ...predicate.Validation) *ValidationUpdateOne {
+	vuo.mutation.Where(ps...)
+	return vuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (vuo *ValidationUpdateOne) Select(field string, fields ...string) *ValidationUpdateOne {
+	vuo.fields = append([]string{field}, fields...)
+	return vuo
+}
+
+// Save executes the query and returns the updated Validation entity.
+func (vuo *ValidationUpdateOne) Save(ctx context.Context) (*Validation, error) {
+	return withHooks(ctx, vuo.sqlSave, vuo.mutation, vuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (vuo *ValidationUpdateOne) SaveX(ctx context.Context) *Validation {
+	node, err := vuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (vuo *ValidationUpdateOne) Exec(ctx context.Context) error {
+	_, err := vuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (vuo *ValidationUpdateOne) ExecX(ctx context.Context) {
+	if err := vuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (vuo *ValidationUpdateOne) sqlSave(ctx context.Context) (_node *Validation, err error) {
+	_spec := sqlgraph.NewUpdateSpec(validation.Table, validation.Columns, sqlgraph.NewFieldSpec(validation.FieldID, field.TypeInt64))
+	id, ok := vuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Validation.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := vuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, validation.FieldID)
+		for _, f := range fields {
+			if !validation.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != validation.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := vuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := vuo.mutation.FileID(); ok {
+		_spec.SetField(validation.FieldFileID, field.TypeString, value)
+	}
+	if value, ok := vuo.mutation.Problems(); ok {
+		_spec.SetField(validation.FieldProblems, field.TypeJSON, value)
+	}
+	if value, ok := vuo.mutation.AppendedProblems(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, validation.FieldProblems, value)
+		})
+	}
+	if vuo.mutation.ProblemsCleared() {
+		_spec.ClearField(validation.FieldProblems, field.TypeJSON)
+	}
+	_node = &Validation{config: vuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, vuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{validation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	vuo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,126 @@
+package files
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrBadID = errors.New("bad file id")
+
+// Store keeps book files and covers in a flat directory.
+// Files are named by SHA-256 of the content followed by the extension,
+// so the same file uploaded twice is stored once.
+type Store struct {
+	Dir string
+}
+
+// Put stores the file and returns its id. Ext is a file extension
+// with the leading dot.
+func (store *Store) Put(ext string, input io.Reader) (string, error) {
+	tmp, errTmp := os.CreateTemp(store.Dir, ".upload-*")
+	if errTmp != nil {
+		return "", fmt.Errorf("files: %w", errTmp)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	hash := sha256.New()
+	_, errCopy := io.Copy(io.MultiWriter(tmp, hash), input)
+	errClose := tmp.Close()
+	if err := errors.Join(errCopy, errClose); err != nil {
+		return "", fmt.Errorf("files: %w", err)
+	}
+
+	id := hex.EncodeToString(hash.Sum(nil)) + strings.ToLower(ext)
+	if errRename := os.Rename(tmp.Name(), filepath.Join(store.Dir, id)); errRename != nil {
+		return "", fmt.Errorf("files: %w", errRename)
+	}
+
+	return id, nil
+}
+
+func (store *Store) Open(id string) (*os.File, error) {
+	if !validID(id) {
+		return nil, ErrBadID
+	}
+	return os.Open(filepath.Join(store.Dir, id))
+}
+
+// Info describes a stored file.
+type Info struct {
+	ID      string
+	Size    int64
+	ModTime time.Time
+}
+
+// List returns stored files, unfinished uploads are skipped.
+func (store *Store) List() ([]Info, error) {
+	entries, errRead := os.ReadDir(store.Dir)
+	if errRead != nil {
+		return nil, fmt.Errorf("files: %w", errRead)
+	}
+
+	list := make([]Info, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !validID(entry.Name()) {
+			continue
+		}
+		info, errInfo := entry.Info()
+		if errors.Is(errInfo, fs.ErrNotExist) {
+			continue
+		}
+		if errInfo != nil {
+			return nil, fmt.Errorf("files: %w", errInfo)
+		}
+		list = append(list, Info{ID: entry.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return list, nil
+}
+
+// Remove deletes the stored file, a missing file is not an error.
+func (store *Store) Remove(id string) error {
+	if !validID(id) {
+		return ErrBadID
+	}
+	err := os.Remove(filepath.Join(store.Dir, id))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("files: %w", err)
+	}
+	return nil
+}
+
+func validID(id string) bool {
+	return id != "" && !strings.HasPrefix(id, ".") && !strings.ContainsAny(id, `/\`)
+}
+
+// Ext returns the file extension of the stored file without the leading dot.
+func Ext(id string) string {
+	return strings.TrimPrefix(filepath.Ext(id), ".")
+}
+
+var contentTypes = map[string]string{
+	"fb2":  "application/x-fictionbook+xml",
+	"epub": "application/epub+zip",
+	"pdf":  "application/pdf",
+	"cbz":  "application/vnd.comicbook+zip",
+	"mobi": "application/x-mobipocket-ebook",
+	"azw3": "application/vnd.amazon.ebook",
+	"jpg":  "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+// ContentType returns the media type of the stored file by its extension.
+func ContentType(id string) string {
+	if contentType, ok := contentTypes[strings.ToLower(Ext(id))]; ok {
+		return contentType
+	}
+	return "application/octet-stream"
+}
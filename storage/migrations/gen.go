@@ -0,0 +1,91 @@
+//go:build ignore
+
+// gen.go writes a new migration file with the difference between
+// storage/ent/schema and the migration directory. Run it from this directory:
+//
+//	go run -mod=mod gen.go <name>
+//	go run -mod=mod gen.go -dialect postgres -dev 'postgres://localhost/dev?sslmode=disable' <name>
+//
+// Existing migrations are applied to the dev database first, it must be empty.
+// After editing migration files by hand update their checksums:
+//
+//	go run -mod=mod gen.go -dialect <dialect> -hash
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	atlas "ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/ninedraft/bibliotheca/storage/database"
+	"github.com/ninedraft/bibliotheca/storage/ent/migrate"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+)
+
+func main() {
+	driver := database.SQLite
+	flag.StringVar(&driver, "dialect", driver, "sqlite or postgres")
+	dev := ""
+	flag.StringVar(&dev, "dev", dev, "DSN of an empty dev database, in-memory for sqlite")
+	hash := false
+	flag.BoolVar(&hash, "hash", hash, "only update atlas.sum")
+	flag.Parse()
+	ctx := context.Background()
+
+	dir, errDir := atlas.NewLocalDir(driver)
+	if errDir != nil {
+		log.Fatalf("migration dir: %v", errDir)
+	}
+
+	if hash {
+		sum, errSum := dir.Checksum()
+		if errSum != nil {
+			log.Fatalf("checksum: %v", errSum)
+		}
+		if errWrite := atlas.WriteSumFile(dir, sum); errWrite != nil {
+			log.Fatalf("checksum: %v", errWrite)
+		}
+		return
+	}
+
+	if flag.NArg() != 1 {
+		log.Fatalln("usage: go run -mod=mod gen.go [-dialect sqlite|postgres] [-dev dsn] <name>")
+	}
+
+	if driver == database.SQLite && dev == "" {
+		dev = "file:dev?mode=memory&_pragma=foreign_keys(1)"
+	}
+	db, entDialect, errOpen := database.Open(driver, dev)
+	if errOpen != nil {
+		log.Fatalf("dev db: %v", errOpen)
+	}
+	// an in-memory database lives as long as its connection
+	db.SetMaxOpenConns(1)
+	defer func() { _ = db.Close() }()
+
+	// ent can't replay migrations with FTS5 tables, so the dev database
+	// is migrated beforehand and inspected
+	devMigrator := &migrations.Migrator{DB: db, Dialect: entDialect}
+	if errUp := devMigrator.Up(ctx, ""); errUp != nil {
+		log.Fatalf("dev db: %v", errUp)
+	}
+
+	migrator, errMigrator := schema.NewMigrate(sql.OpenDB(entDialect, db),
+		schema.WithDir(dir),
+		schema.WithMigrationMode(schema.ModeInspect),
+		schema.WithDialect(entDialect),
+		schema.WithFormatter(atlas.DefaultFormatter),
+		schema.WithDropColumn(true),
+		schema.WithDropIndex(true),
+	)
+	if errMigrator != nil {
+		log.Fatalf("migrator: %v", errMigrator)
+	}
+
+	if errDiff := migrator.NamedDiff(ctx, flag.Arg(0), migrate.Tables...); errDiff != nil {
+		log.Fatalf("diff: %v", errDiff)
+	}
+}
@@ -0,0 +1,206 @@
+// Package migrations applies versioned SQL migrations generated from
+// storage/ent/schema, see gen.go for adding new ones.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/sqlite"
+	"entgo.io/ent/dialect"
+)
+
+// migration files of each dialect are kept in a directory of the same name
+//
+//go:embed sqlite postgres
+var migrationsFS embed.FS
+
+// ErrPending is returned by Check if the database schema is behind the migration directory.
+var ErrPending = errors.New("database has pending migrations")
+
+// Migrator tracks applied migrations of the database in the schema_revisions table.
+type Migrator struct {
+	DB *sql.DB
+	// Dialect is an ent dialect name of the database.
+	Dialect string
+	// Log receives executed files and statements, nil discards them.
+	Log io.Writer
+}
+
+// Status describes applied and pending migrations.
+type Status struct {
+	Applied []*migrate.Revision
+	Pending []migrate.File
+	// Unversioned is set if the database has tables but no migration history,
+	// like ones created before versioned migrations. Such a database
+	// must be migrated with a baseline version first.
+	Unversioned bool
+}
+
+// Status returns applied and pending migrations. It doesn't write to the database,
+// so without the schema_revisions table no migrations are applied.
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	executor, errExecutor := m.executor(ctx)
+	if errExecutor != nil {
+		return nil, errExecutor
+	}
+
+	applied, errRead := executor.revisions.ReadRevisions(ctx)
+	if errRead != nil {
+		return nil, fmt.Errorf("read revisions: %w", errRead)
+	}
+	status := &Status{Applied: applied}
+
+	var notClean *migrate.NotCleanError
+	pending, errPending := executor.Pending(ctx)
+	switch {
+	case errors.As(errPending, &notClean):
+		status.Unversioned = true
+		pending, errPending = executor.dir.Files()
+	case errors.Is(errPending, migrate.ErrNoPendingFiles):
+		errPending = nil
+	}
+	if errPending != nil {
+		return nil, fmt.Errorf("pending migrations: %w", errPending)
+	}
+	status.Pending = pending
+
+	return status, nil
+}
+
+// Check returns ErrPending if there are migrations to apply.
+func (m *Migrator) Check(ctx context.Context) error {
+	status, errStatus := m.Status(ctx)
+	if errStatus != nil {
+		return errStatus
+	}
+	if status.Unversioned {
+		return fmt.Errorf("%w: database has no migration history, apply them with a baseline version", ErrPending)
+	}
+	if len(status.Pending) > 0 {
+		return fmt.Errorf("%w: %d, latest is %s", ErrPending,
+			len(status.Pending), status.Pending[len(status.Pending)-1].Version())
+	}
+	return nil
+}
+
+// Up applies all pending migrations. If baseline is not empty and the database
+// has no revisions yet, migrations up to and including the baseline version
+// are marked as applied without running them.
+func (m *Migrator) Up(ctx context.Context, baseline string) error {
+	executor, errExecutor := m.executor(ctx, migrate.WithBaselineVersion(baseline))
+	if errExecutor != nil {
+		return errExecutor
+	}
+	if errInit := executor.revisions.init(ctx); errInit != nil {
+		return errInit
+	}
+
+	errExecute := executor.ExecuteN(ctx, 0)
+	switch {
+	case errors.Is(errExecute, migrate.ErrNoPendingFiles):
+		if m.Log != nil {
+			_, _ = fmt.Fprintln(m.Log, "no pending migrations")
+		}
+	case errExecute != nil:
+		return fmt.Errorf("migrate: %w", errExecute)
+	}
+	return nil
+}
+
+type executor struct {
+	*migrate.Executor
+	dir       migrate.Dir
+	revisions *revisions
+}
+
+func (m *Migrator) executor(ctx context.Context, options ...migrate.ExecutorOption) (*executor, error) {
+	var (
+		driver    migrate.Driver
+		errDriver error
+		dirName   string
+	)
+	switch m.Dialect {
+	case dialect.SQLite:
+		driver, errDriver = sqlite.Open(m.DB)
+		dirName = "sqlite"
+	case dialect.Postgres:
+		driver, errDriver = postgres.Open(m.DB)
+		dirName = "postgres"
+	default:
+		return nil, fmt.Errorf("unsupported dialect %q", m.Dialect)
+	}
+	if errDriver != nil {
+		return nil, fmt.Errorf("driver: %w", errDriver)
+	}
+
+	dir, errDir := embeddedDir(migrationsFS, dirName)
+	if errDir != nil {
+		return nil, errDir
+	}
+
+	revisions := &revisions{db: m.DB, dialect: m.Dialect}
+
+	var log migrate.Logger = migrate.NopLogger{}
+	if m.Log != nil {
+		log = &logger{w: m.Log}
+	}
+	options = append(options, migrate.WithLogger(log))
+
+	atlasExecutor, errExecutor := migrate.NewExecutor(driver, dir, revisions, options...)
+	if errExecutor != nil {
+		return nil, fmt.Errorf("executor: %w", errExecutor)
+	}
+	return &executor{Executor: atlasExecutor, dir: dir, revisions: revisions}, nil
+}
+
+// embeddedDir copies migration files into memory,
+// atlas can't read them from fs.FS directly.
+func embeddedDir(fsys fs.FS, root string) (migrate.Dir, error) {
+	entries, errRead := fs.ReadDir(fsys, root)
+	if errRead != nil {
+		return nil, fmt.Errorf("migration dir: %w", errRead)
+	}
+
+	dir := &migrate.MemDir{}
+	for _, entry := range entries {
+		data, errFile := fs.ReadFile(fsys, root+"/"+entry.Name())
+		if errFile != nil {
+			return nil, fmt.Errorf("migration dir: %w", errFile)
+		}
+		if errWrite := dir.WriteFile(entry.Name(), data); errWrite != nil {
+			return nil, fmt.Errorf("migration dir: %w", errWrite)
+		}
+	}
+	return dir, nil
+}
+
+type logger struct {
+	w io.Writer
+}
+
+func (l *logger) Log(entry migrate.LogEntry) {
+	switch entry := entry.(type) {
+	case migrate.LogExecution:
+		from := entry.From
+		if from == "" {
+			from = "empty database"
+		}
+		_, _ = fmt.Fprintf(l.w, "migrating from %s to %s, %d files\n", from, entry.To, len(entry.Files))
+	case migrate.LogFile:
+		_, _ = fmt.Fprintf(l.w, "-- %s\n", entry.File.Name())
+	case migrate.LogStmt:
+		_, _ = fmt.Fprintf(l.w, "%s\n", entry.SQL)
+	case migrate.LogError:
+		_, _ = fmt.Fprintf(l.w, "error: %v\n", entry.Error)
+	case migrate.LogDone:
+		_, _ = fmt.Fprintln(l.w, "done")
+	}
+}
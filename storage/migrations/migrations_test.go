@@ -0,0 +1,97 @@
+package migrations_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"github.com/ninedraft/bibliotheca/storage/database/dbtest"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+)
+
+func forEachDriver(t *testing.T, test func(t *testing.T, migrator *migrations.Migrator)) {
+	for _, driver := range dbtest.Drivers {
+		t.Run(driver, func(t *testing.T) {
+			db, dbDialect := dbtest.Open(t, driver)
+			test(t, &migrations.Migrator{DB: db, Dialect: dbDialect})
+		})
+	}
+}
+
+func countTables(t *testing.T, migrator *migrations.Migrator) int {
+	t.Helper()
+	query := `SELECT count(*) FROM sqlite_master WHERE type = 'table'`
+	if migrator.Dialect == dialect.Postgres {
+		query = `SELECT count(*) FROM information_schema.tables WHERE table_schema = current_schema()`
+	}
+	var tables int
+	if err := migrator.DB.QueryRow(query).Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+	return tables
+}
+
+func TestStatusIsReadOnly(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, migrator *migrations.Migrator) {
+		ctx := context.Background()
+
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(status.Applied) != 0 || status.Unversioned || len(status.Pending) == 0 {
+			t.Fatalf("empty database: got %d applied, %d pending, unversioned %v",
+				len(status.Applied), len(status.Pending), status.Unversioned)
+		}
+		if tables := countTables(t, migrator); tables != 0 {
+			t.Errorf("status created %d tables", tables)
+		}
+	})
+}
+
+func TestUp(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, migrator *migrations.Migrator) {
+		ctx := context.Background()
+
+		if err := migrator.Up(ctx, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := migrator.Check(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(status.Applied) == 0 || len(status.Pending) != 0 {
+			t.Errorf("migrated database: got %d applied, %d pending", len(status.Applied), len(status.Pending))
+		}
+
+		// applying again is a no-op
+		if err := migrator.Up(ctx, ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestUnversioned(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, migrator *migrations.Migrator) {
+		ctx := context.Background()
+
+		if _, err := migrator.DB.Exec(`CREATE TABLE legacy (id integer PRIMARY KEY)`); err != nil {
+			t.Fatal(err)
+		}
+
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !status.Unversioned {
+			t.Error("a database with tables and no history is not reported unversioned")
+		}
+		if err := migrator.Check(ctx); err == nil {
+			t.Error("check passed for an unversioned database")
+		}
+	})
+}
@@ -0,0 +1,6 @@
+-- Create "authors" table
+CREATE TABLE "authors" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying NOT NULL, "bio" character varying NULL, PRIMARY KEY ("id"));
+-- Create "books" table
+CREATE TABLE "books" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "title" character varying NOT NULL, "written_at" bigint NOT NULL, "cover_id" character varying NULL, "file_id" character varying NULL, PRIMARY KEY ("id"));
+-- Create "book_authors" table
+CREATE TABLE "book_authors" ("book_id" bigint NOT NULL, "author_id" bigint NOT NULL, PRIMARY KEY ("book_id", "author_id"), CONSTRAINT "book_authors_book_id" FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "book_authors_author_id" FOREIGN KEY ("author_id") REFERENCES "authors" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
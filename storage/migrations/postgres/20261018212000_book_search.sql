@@ -0,0 +1,6 @@
+-- Create "book_search" full-text index of titles and authors
+CREATE TABLE "book_search" ("book_id" bigint NOT NULL, "document" tsvector NOT NULL, PRIMARY KEY ("book_id"), CONSTRAINT "book_search_book_id" FOREIGN KEY ("book_id") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "book_search_document" to table: "book_search"
+CREATE INDEX "book_search_document" ON "book_search" USING gin ("document");
+-- Index existing books
+INSERT INTO "book_search" ("book_id", "document") SELECT "books"."id", setweight(to_tsvector('simple', "books"."title"), 'A') || to_tsvector('simple', COALESCE((SELECT string_agg("authors"."name", ', ') FROM "book_authors" JOIN "authors" ON "authors"."id" = "book_authors"."author_id" WHERE "book_authors"."book_id" = "books"."id"), '')) FROM "books";
@@ -0,0 +1,4 @@
+-- Create "checkpoints" table
+CREATE TABLE "checkpoints" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying NOT NULL, "value" character varying NOT NULL, "updated_at" bigint NOT NULL, PRIMARY KEY ("id"));
+-- Create index "checkpoints_name_key" to table: "checkpoints"
+CREATE UNIQUE INDEX "checkpoints_name_key" ON "checkpoints" ("name");
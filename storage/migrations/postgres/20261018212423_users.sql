@@ -0,0 +1,8 @@
+-- Create "users" table
+CREATE TABLE "users" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "login" character varying NOT NULL, "password_hash" character varying NOT NULL, "created_at" bigint NOT NULL, PRIMARY KEY ("id"));
+-- Create index "users_login_key" to table: "users"
+CREATE UNIQUE INDEX "users_login_key" ON "users" ("login");
+-- Create "sessions" table
+CREATE TABLE "sessions" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "token_hash" character varying NOT NULL, "created_at" bigint NOT NULL, "expires_at" bigint NOT NULL, "session_user" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "sessions_users_user" FOREIGN KEY ("session_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "sessions_token_hash_key" to table: "sessions"
+CREATE UNIQUE INDEX "sessions_token_hash_key" ON "sessions" ("token_hash");
@@ -0,0 +1,4 @@
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "role" character varying NOT NULL DEFAULT 'reader';
+-- Accounts created before roles could change everything
+UPDATE "users" SET "role" = 'admin';
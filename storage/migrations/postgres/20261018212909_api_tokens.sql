@@ -0,0 +1,4 @@
+-- Create "api_tokens" table
+CREATE TABLE "api_tokens" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying NOT NULL, "token_hash" character varying NOT NULL, "scopes" jsonb NOT NULL, "created_at" bigint NOT NULL, "last_used_at" bigint NULL, "api_token_user" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "api_tokens_users_user" FOREIGN KEY ("api_token_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "api_tokens_token_hash_key" to table: "api_tokens"
+CREATE UNIQUE INDEX "api_tokens_token_hash_key" ON "api_tokens" ("token_hash");
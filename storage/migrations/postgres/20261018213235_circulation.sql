@@ -0,0 +1,8 @@
+-- Create "book_copies" table
+CREATE TABLE "book_copies" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "barcode" character varying NOT NULL, "location" character varying NULL, "condition" character varying NOT NULL DEFAULT 'good', "created_at" bigint NOT NULL, "book_copy_book" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "book_copies_books_book" FOREIGN KEY ("book_copy_book") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "book_copies_barcode_key" to table: "book_copies"
+CREATE UNIQUE INDEX "book_copies_barcode_key" ON "book_copies" ("barcode");
+-- Create "loans" table
+CREATE TABLE "loans" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "checked_out_at" bigint NOT NULL, "due_at" bigint NOT NULL, "returned_at" bigint NULL, "loan_copy" bigint NOT NULL, "loan_borrower" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "loans_book_copies_copy" FOREIGN KEY ("loan_copy") REFERENCES "book_copies" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "loans_users_borrower" FOREIGN KEY ("loan_borrower") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "loan_loan_copy" to table: "loans"
+CREATE UNIQUE INDEX "loan_loan_copy" ON "loans" ("loan_copy") WHERE (returned_at IS NULL);
@@ -0,0 +1,6 @@
+-- Create "holds" table
+CREATE TABLE "holds" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "status" character varying NOT NULL DEFAULT 'waiting', "created_at" bigint NOT NULL, "ready_at" bigint NULL, "expires_at" bigint NULL, "hold_book" bigint NOT NULL, "hold_user" bigint NOT NULL, "hold_copy" bigint NULL, PRIMARY KEY ("id"), CONSTRAINT "holds_books_book" FOREIGN KEY ("hold_book") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "holds_users_user" FOREIGN KEY ("hold_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "holds_book_copies_copy" FOREIGN KEY ("hold_copy") REFERENCES "book_copies" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "hold_hold_book_hold_user" to table: "holds"
+CREATE UNIQUE INDEX "hold_hold_book_hold_user" ON "holds" ("hold_book", "hold_user") WHERE (status IN ('waiting', 'ready'));
+-- Create index "hold_hold_copy" to table: "holds"
+CREATE UNIQUE INDEX "hold_hold_copy" ON "holds" ("hold_copy") WHERE (status = 'ready');
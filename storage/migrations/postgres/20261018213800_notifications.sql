@@ -0,0 +1,10 @@
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "email" character varying NULL, ADD COLUMN "notify_due" boolean NOT NULL DEFAULT true, ADD COLUMN "notify_holds" boolean NOT NULL DEFAULT true, ADD COLUMN "notify_new_books" boolean NOT NULL DEFAULT true;
+-- Modify "loans" table
+ALTER TABLE "loans" ADD COLUMN "reminded_at" bigint NULL;
+-- Create "outbox_messages" table
+CREATE TABLE "outbox_messages" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "kind" character varying NOT NULL, "to" character varying NOT NULL, "subject" character varying NOT NULL, "body" text NOT NULL, "created_at" bigint NOT NULL, "attempts" bigint NOT NULL DEFAULT 0, "next_attempt_at" bigint NOT NULL, "sent_at" bigint NULL, "last_error" character varying NULL, PRIMARY KEY ("id"));
+-- Create index "outboxmessage_sent_at_next_attempt_at" to table: "outbox_messages"
+CREATE INDEX "outboxmessage_sent_at_next_attempt_at" ON "outbox_messages" ("sent_at", "next_attempt_at");
+-- Create "user_followed_authors" table
+CREATE TABLE "user_followed_authors" ("user_id" bigint NOT NULL, "author_id" bigint NOT NULL, PRIMARY KEY ("user_id", "author_id"), CONSTRAINT "user_followed_authors_user_id" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "user_followed_authors_author_id" FOREIGN KEY ("author_id") REFERENCES "authors" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
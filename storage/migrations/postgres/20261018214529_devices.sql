@@ -0,0 +1,10 @@
+-- Modify "outbox_messages" table
+ALTER TABLE "outbox_messages" ADD COLUMN "attachment" character varying NULL, ADD COLUMN "attachment_name" character varying NULL;
+-- Create "devices" table
+CREATE TABLE "devices" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "name" character varying NOT NULL, "email" character varying NOT NULL, "kind" character varying NOT NULL DEFAULT 'kindle', "created_at" bigint NOT NULL, "device_user" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "devices_users_user" FOREIGN KEY ("device_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "device_email_device_user" to table: "devices"
+CREATE UNIQUE INDEX "device_email_device_user" ON "devices" ("email", "device_user");
+-- Create "deliveries" table
+CREATE TABLE "deliveries" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "to" character varying NOT NULL, "format" character varying NOT NULL, "error" character varying NULL, "created_at" bigint NOT NULL, "delivery_user" bigint NOT NULL, "delivery_book" bigint NOT NULL, "delivery_device" bigint NULL, "outbox_message_delivery" bigint NULL, PRIMARY KEY ("id"), CONSTRAINT "deliveries_users_user" FOREIGN KEY ("delivery_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "deliveries_books_book" FOREIGN KEY ("delivery_book") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "deliveries_devices_device" FOREIGN KEY ("delivery_device") REFERENCES "devices" ("id") ON UPDATE NO ACTION ON DELETE SET NULL, CONSTRAINT "deliveries_outbox_messages_delivery" FOREIGN KEY ("outbox_message_delivery") REFERENCES "outbox_messages" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "deliveries_outbox_message_delivery_key" to table: "deliveries"
+CREATE UNIQUE INDEX "deliveries_outbox_message_delivery_key" ON "deliveries" ("outbox_message_delivery");
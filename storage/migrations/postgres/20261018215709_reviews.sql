@@ -0,0 +1,4 @@
+-- Create "reviews" table
+CREATE TABLE "reviews" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "rating" bigint NOT NULL, "text" text NULL, "hidden" boolean NOT NULL DEFAULT false, "hidden_at" bigint NULL, "created_at" bigint NOT NULL, "updated_at" bigint NOT NULL, "review_book" bigint NOT NULL, "review_user" bigint NOT NULL, "review_hidden_by" bigint NULL, PRIMARY KEY ("id"), CONSTRAINT "reviews_books_book" FOREIGN KEY ("review_book") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "reviews_users_user" FOREIGN KEY ("review_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "reviews_users_hidden_by" FOREIGN KEY ("review_hidden_by") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "review_review_book_review_user" to table: "reviews"
+CREATE UNIQUE INDEX "review_review_book_review_user" ON "reviews" ("review_book", "review_user");
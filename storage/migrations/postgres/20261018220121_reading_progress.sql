@@ -0,0 +1,10 @@
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "sync_key_hash" character varying NULL;
+-- Create "documents" table
+CREATE TABLE "documents" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "hash" character varying NOT NULL, "created_at" bigint NOT NULL, "document_book" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "documents_books_book" FOREIGN KEY ("document_book") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "documents_hash_key" to table: "documents"
+CREATE UNIQUE INDEX "documents_hash_key" ON "documents" ("hash");
+-- Create "reading_progresses" table
+CREATE TABLE "reading_progresses" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "document" character varying NOT NULL, "progress" character varying NOT NULL, "percentage" double precision NOT NULL, "device" character varying NOT NULL, "device_id" character varying NOT NULL, "updated_at" bigint NOT NULL, "reading_progress_user" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "reading_progresses_users_user" FOREIGN KEY ("reading_progress_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "readingprogress_document_reading_progress_user" to table: "reading_progresses"
+CREATE UNIQUE INDEX "readingprogress_document_reading_progress_user" ON "reading_progresses" ("document", "reading_progress_user");
@@ -0,0 +1,4 @@
+-- Create "highlights" table
+CREATE TABLE "highlights" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "anchor" character varying NOT NULL, "start" bigint NOT NULL, "end" bigint NOT NULL, "text" text NOT NULL, "chapter" character varying NULL, "note" text NULL, "color" character varying NOT NULL DEFAULT 'yellow', "created_at" bigint NOT NULL, "updated_at" bigint NOT NULL, "highlight_book" bigint NOT NULL, "highlight_user" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "highlights_books_book" FOREIGN KEY ("highlight_book") REFERENCES "books" ("id") ON UPDATE NO ACTION ON DELETE CASCADE, CONSTRAINT "highlights_users_user" FOREIGN KEY ("highlight_user") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE CASCADE);
+-- Create index "highlight_highlight_book_highlight_user" to table: "highlights"
+CREATE INDEX "highlight_highlight_book_highlight_user" ON "highlights" ("highlight_book", "highlight_user");
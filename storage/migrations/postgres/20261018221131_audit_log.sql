@@ -0,0 +1,6 @@
+-- Create "audit_entries" table
+CREATE TABLE "audit_entries" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "op" character varying NOT NULL, "entity" character varying NOT NULL, "entity_id" bigint NOT NULL, "changes" jsonb NULL, "actor_login" character varying NULL, "created_at" bigint NOT NULL, "audit_entry_actor" bigint NULL, PRIMARY KEY ("id"), CONSTRAINT "audit_entries_users_actor" FOREIGN KEY ("audit_entry_actor") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "auditentry_entity_entity_id" to table: "audit_entries"
+CREATE INDEX "auditentry_entity_entity_id" ON "audit_entries" ("entity", "entity_id");
+-- Create index "auditentry_created_at" to table: "audit_entries"
+CREATE INDEX "auditentry_created_at" ON "audit_entries" ("created_at");
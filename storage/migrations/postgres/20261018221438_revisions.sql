@@ -0,0 +1,4 @@
+-- Create "revisions" table
+CREATE TABLE "revisions" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "entity" character varying NOT NULL, "entity_id" bigint NOT NULL, "data" jsonb NOT NULL, "actor_login" character varying NULL, "created_at" bigint NOT NULL, "revision_actor" bigint NULL, PRIMARY KEY ("id"), CONSTRAINT "revisions_users_actor" FOREIGN KEY ("revision_actor") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create index "revision_entity_entity_id" to table: "revisions"
+CREATE INDEX "revision_entity_entity_id" ON "revisions" ("entity", "entity_id");
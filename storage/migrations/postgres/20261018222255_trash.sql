@@ -0,0 +1,4 @@
+-- Modify "books" table
+ALTER TABLE "books" ADD COLUMN "deleted_at" bigint NULL;
+-- Create index "book_deleted_at" to table: "books"
+CREATE INDEX "book_deleted_at" ON "books" ("deleted_at");
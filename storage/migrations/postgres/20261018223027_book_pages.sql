@@ -0,0 +1,2 @@
+-- Modify "books" table
+ALTER TABLE "books" ADD COLUMN "pages" bigint NULL;
@@ -0,0 +1,2 @@
+-- Modify "books" table
+ALTER TABLE "books" ALTER COLUMN "written_at" SET DEFAULT 0;
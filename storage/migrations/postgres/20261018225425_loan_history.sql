@@ -0,0 +1,2 @@
+-- Modify "loans" table
+ALTER TABLE "loans" DROP CONSTRAINT "loans_book_copies_copy", ALTER COLUMN "loan_copy" DROP NOT NULL, ADD CONSTRAINT "loans_book_copies_copy" FOREIGN KEY ("loan_copy") REFERENCES "book_copies" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+-- Create "validations" table
+CREATE TABLE "validations" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "file_id" character varying NOT NULL, "problems" jsonb NULL, "created_at" bigint NOT NULL, PRIMARY KEY ("id"));
+-- Create index "validations_file_id_key" to table: "validations"
+CREATE UNIQUE INDEX "validations_file_id_key" ON "validations" ("file_id");
@@ -0,0 +1,183 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+)
+
+const revisionsTable = "schema_revisions"
+
+// revisions stores migration history, it implements migrate.RevisionReadWriter.
+type revisions struct {
+	db      *sql.DB
+	dialect string
+}
+
+var _ migrate.RevisionReadWriter = (*revisions)(nil)
+
+func (r *revisions) init(ctx context.Context) error {
+	_, errCreate := r.exec(ctx, `CREATE TABLE IF NOT EXISTS `+revisionsTable+` (
+		version text NOT NULL PRIMARY KEY,
+		description text NOT NULL,
+		type integer NOT NULL,
+		applied integer NOT NULL,
+		total integer NOT NULL,
+		executed_at bigint NOT NULL,
+		execution_time bigint NOT NULL,
+		error text NOT NULL,
+		error_stmt text NOT NULL,
+		hash text NOT NULL,
+		partial_hashes text NOT NULL,
+		operator_version text NOT NULL
+	)`)
+	if errCreate != nil {
+		return fmt.Errorf("create %s: %w", revisionsTable, errCreate)
+	}
+	return nil
+}
+
+// exists reports whether the revisions table is created,
+// Up creates it before applying migrations.
+func (r *revisions) exists(ctx context.Context) (bool, error) {
+	query := `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?`
+	if r.dialect == dialect.Postgres {
+		query = `SELECT count(*) FROM information_schema.tables
+			WHERE table_schema = current_schema() AND table_name = ?`
+	}
+	var n int
+	if err := r.db.QueryRowContext(ctx, r.bind(query), revisionsTable).Scan(&n); err != nil {
+		return false, fmt.Errorf("find %s: %w", revisionsTable, err)
+	}
+	return n > 0, nil
+}
+
+func (r *revisions) Ident() *migrate.TableIdent {
+	return &migrate.TableIdent{Name: revisionsTable}
+}
+
+const selectRevisions = `SELECT version, description, type, applied, total, executed_at,
+	execution_time, error, error_stmt, hash, partial_hashes, operator_version
+	FROM ` + revisionsTable
+
+func (r *revisions) ReadRevisions(ctx context.Context) ([]*migrate.Revision, error) {
+	exists, errExists := r.exists(ctx)
+	if errExists != nil || !exists {
+		return nil, errExists
+	}
+
+	rows, errQuery := r.db.QueryContext(ctx, r.bind(selectRevisions+` ORDER BY version`))
+	if errQuery != nil {
+		return nil, errQuery
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*migrate.Revision
+	for rows.Next() {
+		revision, errScan := scanRevision(rows)
+		if errScan != nil {
+			return nil, errScan
+		}
+		result = append(result, revision)
+	}
+	return result, rows.Err()
+}
+
+func (r *revisions) ReadRevision(ctx context.Context, version string) (*migrate.Revision, error) {
+	exists, errExists := r.exists(ctx)
+	switch {
+	case errExists != nil:
+		return nil, errExists
+	case !exists:
+		return nil, migrate.ErrRevisionNotExist
+	}
+
+	revision, errScan := scanRevision(r.db.QueryRowContext(ctx, r.bind(selectRevisions+` WHERE version = ?`), version))
+	if errors.Is(errScan, sql.ErrNoRows) {
+		return nil, migrate.ErrRevisionNotExist
+	}
+	return revision, errScan
+}
+
+func (r *revisions) WriteRevision(ctx context.Context, revision *migrate.Revision) error {
+	partialHashes, errHashes := json.Marshal(revision.PartialHashes)
+	if errHashes != nil {
+		return errHashes
+	}
+
+	_, errExec := r.exec(ctx, `INSERT INTO `+revisionsTable+` (version, description, type,
+		applied, total, executed_at, execution_time, error, error_stmt, hash, partial_hashes, operator_version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (version) DO UPDATE SET
+			description = excluded.description,
+			type = excluded.type,
+			applied = excluded.applied,
+			total = excluded.total,
+			executed_at = excluded.executed_at,
+			execution_time = excluded.execution_time,
+			error = excluded.error,
+			error_stmt = excluded.error_stmt,
+			hash = excluded.hash,
+			partial_hashes = excluded.partial_hashes,
+			operator_version = excluded.operator_version`,
+		revision.Version, revision.Description, revision.Type,
+		revision.Applied, revision.Total, revision.ExecutedAt.UnixNano(), revision.ExecutionTime,
+		revision.Error, revision.ErrorStmt, revision.Hash, string(partialHashes), revision.OperatorVersion)
+	return errExec
+}
+
+func (r *revisions) DeleteRevision(ctx context.Context, version string) error {
+	_, errExec := r.exec(ctx, `DELETE FROM `+revisionsTable+` WHERE version = ?`, version)
+	return errExec
+}
+
+func (r *revisions) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return r.db.ExecContext(ctx, r.bind(query), args...)
+}
+
+// bind replaces ? placeholders with $1, $2... for Postgres.
+func (r *revisions) bind(query string) string {
+	if r.dialect != dialect.Postgres {
+		return query
+	}
+	var (
+		result strings.Builder
+		n      int
+	)
+	for _, char := range query {
+		if char == '?' {
+			n++
+			result.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		result.WriteRune(char)
+	}
+	return result.String()
+}
+
+func scanRevision(row interface{ Scan(...any) error }) (*migrate.Revision, error) {
+	var (
+		revision      = &migrate.Revision{}
+		executedAt    int64
+		partialHashes string
+	)
+	errScan := row.Scan(&revision.Version, &revision.Description, &revision.Type,
+		&revision.Applied, &revision.Total, &executedAt, &revision.ExecutionTime,
+		&revision.Error, &revision.ErrorStmt, &revision.Hash, &partialHashes, &revision.OperatorVersion)
+	if errScan != nil {
+		return nil, errScan
+	}
+	revision.ExecutedAt = time.Unix(0, executedAt)
+	if errHashes := json.Unmarshal([]byte(partialHashes), &revision.PartialHashes); errHashes != nil {
+		return nil, fmt.Errorf("revision %s: partial hashes: %w", revision.Version, errHashes)
+	}
+	return revision, nil
+}
@@ -0,0 +1,6 @@
+-- Create "authors" table
+CREATE TABLE `authors` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `bio` text NULL);
+-- Create "books" table
+CREATE TABLE `books` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `title` text NOT NULL, `written_at` integer NOT NULL, `cover_id` text NULL, `file_id` text NULL);
+-- Create "book_authors" table
+CREATE TABLE `book_authors` (`book_id` integer NOT NULL, `author_id` integer NOT NULL, PRIMARY KEY (`book_id`, `author_id`), CONSTRAINT `book_authors_book_id` FOREIGN KEY (`book_id`) REFERENCES `books` (`id`) ON DELETE CASCADE, CONSTRAINT `book_authors_author_id` FOREIGN KEY (`author_id`) REFERENCES `authors` (`id`) ON DELETE CASCADE);
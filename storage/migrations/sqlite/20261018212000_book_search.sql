@@ -0,0 +1,4 @@
+-- Create "book_search" full-text index of titles and authors
+CREATE VIRTUAL TABLE `book_search` USING fts5(`title`, `authors`, tokenize = 'unicode61 remove_diacritics 2');
+-- Index existing books
+INSERT INTO `book_search` (`rowid`, `title`, `authors`) SELECT `books`.`id`, `books`.`title`, COALESCE((SELECT group_concat(`authors`.`name`, ', ') FROM `book_authors` JOIN `authors` ON `authors`.`id` = `book_authors`.`author_id` WHERE `book_authors`.`book_id` = `books`.`id`), '') FROM `books`;
@@ -0,0 +1,4 @@
+-- Create "checkpoints" table
+CREATE TABLE `checkpoints` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `value` text NOT NULL, `updated_at` integer NOT NULL);
+-- Create index "checkpoints_name_key" to table: "checkpoints"
+CREATE UNIQUE INDEX `checkpoints_name_key` ON `checkpoints` (`name`);
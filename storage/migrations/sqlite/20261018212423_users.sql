@@ -0,0 +1,8 @@
+-- Create "sessions" table
+CREATE TABLE `sessions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `token_hash` text NOT NULL, `created_at` integer NOT NULL, `expires_at` integer NOT NULL, `session_user` integer NOT NULL, CONSTRAINT `sessions_users_user` FOREIGN KEY (`session_user`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Create index "sessions_token_hash_key" to table: "sessions"
+CREATE UNIQUE INDEX `sessions_token_hash_key` ON `sessions` (`token_hash`);
+-- Create "users" table
+CREATE TABLE `users` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `login` text NOT NULL, `password_hash` text NOT NULL, `created_at` integer NOT NULL);
+-- Create index "users_login_key" to table: "users"
+CREATE UNIQUE INDEX `users_login_key` ON `users` (`login`);
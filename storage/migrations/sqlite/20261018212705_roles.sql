@@ -0,0 +1,4 @@
+-- Add column "role" to table: "users"
+ALTER TABLE `users` ADD COLUMN `role` text NOT NULL DEFAULT 'reader';
+-- Accounts created before roles could change everything
+UPDATE `users` SET `role` = 'admin';
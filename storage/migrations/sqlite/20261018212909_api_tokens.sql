@@ -0,0 +1,4 @@
+-- Create "api_tokens" table
+CREATE TABLE `api_tokens` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `token_hash` text NOT NULL, `scopes` json NOT NULL, `created_at` integer NOT NULL, `last_used_at` integer NULL, `api_token_user` integer NOT NULL, CONSTRAINT `api_tokens_users_user` FOREIGN KEY (`api_token_user`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Create index "api_tokens_token_hash_key" to table: "api_tokens"
+CREATE UNIQUE INDEX `api_tokens_token_hash_key` ON `api_tokens` (`token_hash`);
@@ -0,0 +1,8 @@
+-- Create "book_copies" table
+CREATE TABLE `book_copies` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `barcode` text NOT NULL, `location` text NULL, `condition` text NOT NULL DEFAULT 'good', `created_at` integer NOT NULL, `book_copy_book` integer NOT NULL, CONSTRAINT `book_copies_books_book` FOREIGN KEY (`book_copy_book`) REFERENCES `books` (`id`) ON DELETE CASCADE);
+-- Create index "book_copies_barcode_key" to table: "book_copies"
+CREATE UNIQUE INDEX `book_copies_barcode_key` ON `book_copies` (`barcode`);
+-- Create "loans" table
+CREATE TABLE `loans` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `checked_out_at` integer NOT NULL, `due_at` integer NOT NULL, `returned_at` integer NULL, `loan_copy` integer NOT NULL, `loan_borrower` integer NOT NULL, CONSTRAINT `loans_book_copies_copy` FOREIGN KEY (`loan_copy`) REFERENCES `book_copies` (`id`) ON DELETE CASCADE, CONSTRAINT `loans_users_borrower` FOREIGN KEY (`loan_borrower`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Create index "loan_loan_copy" to table: "loans"
+CREATE UNIQUE INDEX `loan_loan_copy` ON `loans` (`loan_copy`) WHERE returned_at IS NULL;
@@ -0,0 +1,6 @@
+-- Create "holds" table
+CREATE TABLE `holds` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `status` text NOT NULL DEFAULT 'waiting', `created_at` integer NOT NULL, `ready_at` integer NULL, `expires_at` integer NULL, `hold_book` integer NOT NULL, `hold_user` integer NOT NULL, `hold_copy` integer NULL, CONSTRAINT `holds_books_book` FOREIGN KEY (`hold_book`) REFERENCES `books` (`id`) ON DELETE CASCADE, CONSTRAINT `holds_users_user` FOREIGN KEY (`hold_user`) REFERENCES `users` (`id`) ON DELETE CASCADE, CONSTRAINT `holds_book_copies_copy` FOREIGN KEY (`hold_copy`) REFERENCES `book_copies` (`id`) ON DELETE SET NULL);
+-- Create index "hold_hold_book_hold_user" to table: "holds"
+CREATE UNIQUE INDEX `hold_hold_book_hold_user` ON `holds` (`hold_book`, `hold_user`) WHERE status IN ('waiting', 'ready');
+-- Create index "hold_hold_copy" to table: "holds"
+CREATE UNIQUE INDEX `hold_hold_copy` ON `holds` (`hold_copy`) WHERE status = 'ready';
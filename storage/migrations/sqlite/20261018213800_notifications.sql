@@ -0,0 +1,13 @@
+-- Add columns to table: "users"
+ALTER TABLE `users` ADD COLUMN `email` text NULL;
+ALTER TABLE `users` ADD COLUMN `notify_due` bool NOT NULL DEFAULT true;
+ALTER TABLE `users` ADD COLUMN `notify_holds` bool NOT NULL DEFAULT true;
+ALTER TABLE `users` ADD COLUMN `notify_new_books` bool NOT NULL DEFAULT true;
+-- Add column "reminded_at" to table: "loans"
+ALTER TABLE `loans` ADD COLUMN `reminded_at` integer NULL;
+-- Create "outbox_messages" table
+CREATE TABLE `outbox_messages` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `kind` text NOT NULL, `to` text NOT NULL, `subject` text NOT NULL, `body` text NOT NULL, `created_at` integer NOT NULL, `attempts` integer NOT NULL DEFAULT 0, `next_attempt_at` integer NOT NULL, `sent_at` integer NULL, `last_error` text NULL);
+-- Create index "outboxmessage_sent_at_next_attempt_at" to table: "outbox_messages"
+CREATE INDEX `outboxmessage_sent_at_next_attempt_at` ON `outbox_messages` (`sent_at`, `next_attempt_at`);
+-- Create "user_followed_authors" table
+CREATE TABLE `user_followed_authors` (`user_id` integer NOT NULL, `author_id` integer NOT NULL, PRIMARY KEY (`user_id`, `author_id`), CONSTRAINT `user_followed_authors_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE, CONSTRAINT `user_followed_authors_author_id` FOREIGN KEY (`author_id`) REFERENCES `authors` (`id`) ON DELETE CASCADE);
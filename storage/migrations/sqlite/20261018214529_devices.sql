@@ -0,0 +1,12 @@
+-- Add column "attachment" to table: "outbox_messages"
+ALTER TABLE `outbox_messages` ADD COLUMN `attachment` text NULL;
+-- Add column "attachment_name" to table: "outbox_messages"
+ALTER TABLE `outbox_messages` ADD COLUMN `attachment_name` text NULL;
+-- Create "deliveries" table
+CREATE TABLE `deliveries` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `to` text NOT NULL, `format` text NOT NULL, `error` text NULL, `created_at` integer NOT NULL, `delivery_user` integer NOT NULL, `delivery_book` integer NOT NULL, `delivery_device` integer NULL, `outbox_message_delivery` integer NULL, CONSTRAINT `deliveries_users_user` FOREIGN KEY (`delivery_user`) REFERENCES `users` (`id`) ON DELETE CASCADE, CONSTRAINT `deliveries_books_book` FOREIGN KEY (`delivery_book`) REFERENCES `books` (`id`) ON DELETE CASCADE, CONSTRAINT `deliveries_devices_device` FOREIGN KEY (`delivery_device`) REFERENCES `devices` (`id`) ON DELETE SET NULL, CONSTRAINT `deliveries_outbox_messages_delivery` FOREIGN KEY (`outbox_message_delivery`) REFERENCES `outbox_messages` (`id`) ON DELETE SET NULL);
+-- Create index "deliveries_outbox_message_delivery_key" to table: "deliveries"
+CREATE UNIQUE INDEX `deliveries_outbox_message_delivery_key` ON `deliveries` (`outbox_message_delivery`);
+-- Create "devices" table
+CREATE TABLE `devices` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `email` text NOT NULL, `kind` text NOT NULL DEFAULT 'kindle', `created_at` integer NOT NULL, `device_user` integer NOT NULL, CONSTRAINT `devices_users_user` FOREIGN KEY (`device_user`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Create index "device_email_device_user" to table: "devices"
+CREATE UNIQUE INDEX `device_email_device_user` ON `devices` (`email`, `device_user`);
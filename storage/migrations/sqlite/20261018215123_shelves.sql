@@ -0,0 +1,10 @@
+-- Create "shelves" table
+CREATE TABLE `shelves` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `name` text NOT NULL, `kind` text NOT NULL DEFAULT 'custom', `created_at` integer NOT NULL, `shelf_user` integer NOT NULL, CONSTRAINT `shelves_users_user` FOREIGN KEY (`shelf_user`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Create index "shelf_name_shelf_user" to table: "shelves"
+CREATE UNIQUE INDEX `shelf_name_shelf_user` ON `shelves` (`name`, `shelf_user`) WHERE kind = 'custom';
+-- Create index "shelf_kind_shelf_user" to table: "shelves"
+CREATE UNIQUE INDEX `shelf_kind_shelf_user` ON `shelves` (`kind`, `shelf_user`) WHERE kind <> 'custom';
+-- Create "shelf_entries" table
+CREATE TABLE `shelf_entries` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `added_at` integer NOT NULL, `shelf_entry_shelf` integer NOT NULL, `shelf_entry_book` integer NOT NULL, CONSTRAINT `shelf_entries_shelves_shelf` FOREIGN KEY (`shelf_entry_shelf`) REFERENCES `shelves` (`id`) ON DELETE CASCADE, CONSTRAINT `shelf_entries_books_book` FOREIGN KEY (`shelf_entry_book`) REFERENCES `books` (`id`) ON DELETE CASCADE);
+-- Create index "shelfentry_shelf_entry_shelf_shelf_entry_book" to table: "shelf_entries"
+CREATE UNIQUE INDEX `shelfentry_shelf_entry_shelf_shelf_entry_book` ON `shelf_entries` (`shelf_entry_shelf`, `shelf_entry_book`);
@@ -0,0 +1,4 @@
+-- Create "reviews" table
+CREATE TABLE `reviews` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `rating` integer NOT NULL, `text` text NULL, `hidden` bool NOT NULL DEFAULT false, `hidden_at` integer NULL, `created_at` integer NOT NULL, `updated_at` integer NOT NULL, `review_book` integer NOT NULL, `review_user` integer NOT NULL, `review_hidden_by` integer NULL, CONSTRAINT `reviews_books_book` FOREIGN KEY (`review_book`) REFERENCES `books` (`id`) ON DELETE CASCADE, CONSTRAINT `reviews_users_user` FOREIGN KEY (`review_user`) REFERENCES `users` (`id`) ON DELETE CASCADE, CONSTRAINT `reviews_users_hidden_by` FOREIGN KEY (`review_hidden_by`) REFERENCES `users` (`id`) ON DELETE SET NULL);
+-- Create index "review_review_book_review_user" to table: "reviews"
+CREATE UNIQUE INDEX `review_review_book_review_user` ON `reviews` (`review_book`, `review_user`);
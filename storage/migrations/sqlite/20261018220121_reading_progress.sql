@@ -0,0 +1,10 @@
+-- Add column "sync_key_hash" to table: "users"
+ALTER TABLE `users` ADD COLUMN `sync_key_hash` text NULL;
+-- Create "documents" table
+CREATE TABLE `documents` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `hash` text NOT NULL, `created_at` integer NOT NULL, `document_book` integer NOT NULL, CONSTRAINT `documents_books_book` FOREIGN KEY (`document_book`) REFERENCES `books` (`id`) ON DELETE CASCADE);
+-- Create index "documents_hash_key" to table: "documents"
+CREATE UNIQUE INDEX `documents_hash_key` ON `documents` (`hash`);
+-- Create "reading_progresses" table
+CREATE TABLE `reading_progresses` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `document` text NOT NULL, `progress` text NOT NULL, `percentage` real NOT NULL, `device` text NOT NULL, `device_id` text NOT NULL, `updated_at` integer NOT NULL, `reading_progress_user` integer NOT NULL, CONSTRAINT `reading_progresses_users_user` FOREIGN KEY (`reading_progress_user`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Create index "readingprogress_document_reading_progress_user" to table: "reading_progresses"
+CREATE UNIQUE INDEX `readingprogress_document_reading_progress_user` ON `reading_progresses` (`document`, `reading_progress_user`);
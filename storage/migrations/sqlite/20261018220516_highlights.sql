@@ -0,0 +1,4 @@
+-- Create "highlights" table
+CREATE TABLE `highlights` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `anchor` text NOT NULL, `start` integer NOT NULL, `end` integer NOT NULL, `text` text NOT NULL, `chapter` text NULL, `note` text NULL, `color` text NOT NULL DEFAULT 'yellow', `created_at` integer NOT NULL, `updated_at` integer NOT NULL, `highlight_book` integer NOT NULL, `highlight_user` integer NOT NULL, CONSTRAINT `highlights_books_book` FOREIGN KEY (`highlight_book`) REFERENCES `books` (`id`) ON DELETE CASCADE, CONSTRAINT `highlights_users_user` FOREIGN KEY (`highlight_user`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Create index "highlight_highlight_book_highlight_user" to table: "highlights"
+CREATE INDEX `highlight_highlight_book_highlight_user` ON `highlights` (`highlight_book`, `highlight_user`);
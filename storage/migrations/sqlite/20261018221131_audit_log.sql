@@ -0,0 +1,6 @@
+-- Create "audit_entries" table
+CREATE TABLE `audit_entries` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `op` text NOT NULL, `entity` text NOT NULL, `entity_id` integer NOT NULL, `changes` json NULL, `actor_login` text NULL, `created_at` integer NOT NULL, `audit_entry_actor` integer NULL, CONSTRAINT `audit_entries_users_actor` FOREIGN KEY (`audit_entry_actor`) REFERENCES `users` (`id`) ON DELETE SET NULL);
+-- Create index "auditentry_entity_entity_id" to table: "audit_entries"
+CREATE INDEX `auditentry_entity_entity_id` ON `audit_entries` (`entity`, `entity_id`);
+-- Create index "auditentry_created_at" to table: "audit_entries"
+CREATE INDEX `auditentry_created_at` ON `audit_entries` (`created_at`);
@@ -0,0 +1,4 @@
+-- Create "revisions" table
+CREATE TABLE `revisions` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `entity` text NOT NULL, `entity_id` integer NOT NULL, `data` json NOT NULL, `actor_login` text NULL, `created_at` integer NOT NULL, `revision_actor` integer NULL, CONSTRAINT `revisions_users_actor` FOREIGN KEY (`revision_actor`) REFERENCES `users` (`id`) ON DELETE SET NULL);
+-- Create index "revision_entity_entity_id" to table: "revisions"
+CREATE INDEX `revision_entity_entity_id` ON `revisions` (`entity`, `entity_id`);
@@ -0,0 +1,2 @@
+-- Add column "pages" to table: "books"
+ALTER TABLE `books` ADD COLUMN `pages` integer NULL;
@@ -0,0 +1,14 @@
+-- Disable the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = off;
+-- Create "new_books" table
+CREATE TABLE `new_books` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `title` text NOT NULL, `written_at` integer NOT NULL DEFAULT 0, `cover_id` text NULL, `file_id` text NULL, `pages` integer NULL, `deleted_at` integer NULL);
+-- Copy rows from old table "books" to new temporary table "new_books"
+INSERT INTO `new_books` (`id`, `title`, `written_at`, `cover_id`, `file_id`, `pages`, `deleted_at`) SELECT `id`, `title`, IFNULL(`written_at`, 0) AS `written_at`, `cover_id`, `file_id`, `pages`, `deleted_at` FROM `books`;
+-- Drop "books" table after copying rows
+DROP TABLE `books`;
+-- Rename temporary table "new_books" to "books"
+ALTER TABLE `new_books` RENAME TO `books`;
+-- Create index "book_deleted_at" to table: "books"
+CREATE INDEX `book_deleted_at` ON `books` (`deleted_at`);
+-- Enable back the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = on;
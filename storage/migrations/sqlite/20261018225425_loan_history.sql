@@ -0,0 +1,14 @@
+-- Disable the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = off;
+-- Create "new_loans" table
+CREATE TABLE `new_loans` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `checked_out_at` integer NOT NULL, `due_at` integer NOT NULL, `returned_at` integer NULL, `reminded_at` integer NULL, `loan_copy` integer NULL, `loan_borrower` integer NOT NULL, CONSTRAINT `loans_book_copies_copy` FOREIGN KEY (`loan_copy`) REFERENCES `book_copies` (`id`) ON DELETE SET NULL, CONSTRAINT `loans_users_borrower` FOREIGN KEY (`loan_borrower`) REFERENCES `users` (`id`) ON DELETE CASCADE);
+-- Copy rows from old table "loans" to new temporary table "new_loans"
+INSERT INTO `new_loans` (`id`, `checked_out_at`, `due_at`, `returned_at`, `reminded_at`, `loan_copy`, `loan_borrower`) SELECT `id`, `checked_out_at`, `due_at`, `returned_at`, `reminded_at`, `loan_copy`, `loan_borrower` FROM `loans`;
+-- Drop "loans" table after copying rows
+DROP TABLE `loans`;
+-- Rename temporary table "new_loans" to "loans"
+ALTER TABLE `new_loans` RENAME TO `loans`;
+-- Create index "loan_loan_copy" to table: "loans"
+CREATE UNIQUE INDEX `loan_loan_copy` ON `loans` (`loan_copy`) WHERE returned_at IS NULL;
+-- Enable back the enforcement of foreign-keys constraints
+PRAGMA foreign_keys = on;
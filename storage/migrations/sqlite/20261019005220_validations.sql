@@ -0,0 +1,4 @@
+-- Create "validations" table
+CREATE TABLE `validations` (`id` integer NOT NULL PRIMARY KEY AUTOINCREMENT, `file_id` text NOT NULL, `problems` json NULL, `created_at` integer NOT NULL);
+-- Create index "validations_file_id_key" to table: "validations"
+CREATE UNIQUE INDEX `validations_file_id_key` ON `validations` (`file_id`);
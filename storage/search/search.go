@@ -0,0 +1,113 @@
+// Package search maintains the full-text index of book titles and authors.
+// SQLite uses an FTS5 table, Postgres uses a tsvector column,
+// both are created by migrations.
+package search
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"entgo.io/ent/dialect"
+)
+
+type Index struct {
+	DB *sql.DB
+	// Dialect is an ent dialect name of the database.
+	Dialect string
+}
+
+// Put adds the book to the index or replaces its indexed text.
+func (index *Index) Put(ctx context.Context, bookID int64, title string, authors []string) error {
+	var query string
+	switch index.Dialect {
+	case dialect.Postgres:
+		query = `INSERT INTO book_search (book_id, document)
+			VALUES ($1, setweight(to_tsvector('simple', $2), 'A') || to_tsvector('simple', $3))
+			ON CONFLICT (book_id) DO UPDATE SET document = excluded.document`
+	default:
+		query = `INSERT OR REPLACE INTO book_search (rowid, title, authors) VALUES (?, ?, ?)`
+	}
+	_, errExec := index.DB.ExecContext(ctx, query, bookID, title, strings.Join(authors, ", "))
+	if errExec != nil {
+		return fmt.Errorf("search index: %w", errExec)
+	}
+	return nil
+}
+
+// Delete removes the book from the index.
+func (index *Index) Delete(ctx context.Context, bookID int64) error {
+	query := `DELETE FROM book_search WHERE rowid = ?`
+	if index.Dialect == dialect.Postgres {
+		query = `DELETE FROM book_search WHERE book_id = $1`
+	}
+	if _, errExec := index.DB.ExecContext(ctx, query, bookID); errExec != nil {
+		return fmt.Errorf("search index: %w", errExec)
+	}
+	return nil
+}
+
+// Reset removes all books from the index.
+func (index *Index) Reset(ctx context.Context) error {
+	if _, errExec := index.DB.ExecContext(ctx, `DELETE FROM book_search`); errExec != nil {
+		return fmt.Errorf("search index: %w", errExec)
+	}
+	return nil
+}
+
+// Search returns IDs of books matching all words of the query as prefixes,
+// the best matches go first.
+func (index *Index) Search(ctx context.Context, query string, limit int) ([]int64, error) {
+	words := queryWords(query)
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	var (
+		sqlQuery string
+		match    string
+	)
+	switch index.Dialect {
+	case dialect.Postgres:
+		for i := range words {
+			words[i] += ":*"
+		}
+		match = strings.Join(words, " & ")
+		sqlQuery = `SELECT book_id FROM book_search
+			WHERE document @@ to_tsquery('simple', $1)
+			ORDER BY ts_rank(document, to_tsquery('simple', $1)) DESC
+			LIMIT $2`
+	default:
+		for i := range words {
+			words[i] = `"` + words[i] + `"*`
+		}
+		match = strings.Join(words, " ")
+		sqlQuery = `SELECT rowid FROM book_search WHERE book_search MATCH ? ORDER BY rank LIMIT ?`
+	}
+
+	rows, errQuery := index.DB.QueryContext(ctx, sqlQuery, match, limit)
+	if errQuery != nil {
+		return nil, fmt.Errorf("search: %w", errQuery)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if errScan := rows.Scan(&id); errScan != nil {
+			return nil, fmt.Errorf("search: %w", errScan)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// queryWords splits the query into words of letters and digits,
+// so they are safe to use in FTS5 and tsquery expressions.
+func queryWords(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,86 @@
+package search_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/storage/database/dbtest"
+	"github.com/ninedraft/bibliotheca/storage/migrations"
+	"github.com/ninedraft/bibliotheca/storage/search"
+)
+
+func TestIndex(t *testing.T) {
+	for _, driver := range dbtest.Drivers {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			db, dbDialect := dbtest.Open(t, driver)
+			if err := (&migrations.Migrator{DB: db, Dialect: dbDialect}).Up(ctx, ""); err != nil {
+				t.Fatal(err)
+			}
+			index := &search.Index{DB: db, Dialect: dbDialect}
+
+			books := []struct {
+				id      int64
+				title   string
+				authors []string
+			}{
+				{1, "Мастер и Маргарита", []string{"Михаил Булгаков"}},
+				{2, "The Master of Go", []string{"Yasunari Kawabata"}},
+				{3, "Collected Stories", []string{"Ann Master"}},
+				{4, "War and Peace", []string{"Leo Tolstoy"}},
+			}
+			for _, b := range books {
+				if err := index.Put(ctx, b.id, b.title, b.authors); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// putting again replaces the indexed text
+			if err := index.Put(ctx, 4, "Anna Karenina", []string{"Leo Tolstoy"}); err != nil {
+				t.Fatal(err)
+			}
+
+			tests := []struct {
+				query string
+				want  []int64
+			}{
+				{query: "мастер", want: []int64{1}},
+				{query: "МАСТ", want: []int64{1}},
+				{query: "булгаков маргарита", want: []int64{1}},
+				{query: "master", want: []int64{2, 3}},
+				{query: "kawa", want: []int64{2}},
+				{query: "anna", want: []int64{4}},
+				{query: "war", want: nil},
+				// operators are searched as words
+				{query: `"master" OR *`, want: nil},
+				{query: `master*`, want: []int64{2, 3}},
+				{query: "!!!", want: nil},
+			}
+			for _, tc := range tests {
+				got, err := index.Search(ctx, tc.query, 10)
+				if err != nil {
+					t.Fatalf("%q: %v", tc.query, err)
+				}
+				// databases rank matches differently, so only the matches are compared
+				slices.Sort(got)
+				if !slices.Equal(got, tc.want) {
+					t.Errorf("%q: got %v, want %v", tc.query, got, tc.want)
+				}
+			}
+
+			if err := index.Delete(ctx, 2); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := index.Search(ctx, "master", 10); !slices.Equal(got, []int64{3}) {
+				t.Errorf("after delete: got %v, want [3]", got)
+			}
+
+			if err := index.Reset(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := index.Search(ctx, "master", 10); len(got) != 0 {
+				t.Errorf("after reset: got %v, want none", got)
+			}
+		})
+	}
+}
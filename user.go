@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/internal/config"
+	"github.com/ninedraft/bibliotheca/storage/ent"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+func runUser(args []string) {
+	flags := flag.NewFlagSet("user", flag.ExitOnError)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(flags.Output(), "usage: bibliotheca user [flags] add <login>")
+		_, _ = fmt.Fprintln(flags.Output(), "       bibliotheca user [flags] role <login> <role>")
+		_, _ = fmt.Fprintln(flags.Output(), "\nthe password of a new user is read from stdin")
+		flags.PrintDefaults()
+	}
+	cfgFlags := registerConfigFlags(flags)
+	role := string(user.RoleReader)
+	flags.StringVar(&role, "role", role, "role of a new user: reader, librarian or admin")
+	_ = flags.Parse(args)
+	cfg := cfgFlags.load(flags)
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	var err error
+	switch {
+	case flags.NArg() == 2 && flags.Arg(0) == "add":
+		err = addUser(ctx, cfg, flags.Arg(1), user.Role(role), os.Stdin)
+	case flags.NArg() == 3 && flags.Arg(0) == "role":
+		err = setRole(ctx, cfg, flags.Arg(1), user.Role(flags.Arg(2)))
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// addUser creates a user with the password read from stdin.
+func addUser(ctx context.Context, cfg *config.Config, login string, role user.Role, stdin io.Reader) error {
+	if err := user.RoleValidator(role); err != nil {
+		return err
+	}
+
+	password, errPassword := readPassword(stdin)
+	if errPassword != nil {
+		return errPassword
+	}
+	if len(password) < auth.MinPasswordLen {
+		return fmt.Errorf("password must be at least %d characters long", auth.MinPasswordLen)
+	}
+	hash, errHash := auth.HashPassword(password)
+	if errHash != nil {
+		return errHash
+	}
+
+	st, errStorage := openStorage(ctx, cfg)
+	if errStorage != nil {
+		return errStorage
+	}
+	defer func() { _ = st.Close() }()
+
+	created, errCreate := st.client.User.Create().
+		SetLogin(login).
+		SetPasswordHash(hash).
+		SetRole(role).
+		Save(ctx)
+	if ent.IsConstraintError(errCreate) {
+		return fmt.Errorf("user %q already exists", login)
+	}
+	if errCreate != nil {
+		return fmt.Errorf("db: %w", errCreate)
+	}
+	fmt.Printf("added %s %q #%d\n", created.Role, created.Login, created.ID)
+	return nil
+}
+
+func setRole(ctx context.Context, cfg *config.Config, login string, role user.Role) error {
+	if err := user.RoleValidator(role); err != nil {
+		return err
+	}
+
+	st, errStorage := openStorage(ctx, cfg)
+	if errStorage != nil {
+		return errStorage
+	}
+	defer func() { _ = st.Close() }()
+
+	n, errUpdate := st.client.User.Update().
+		Where(user.Login(login)).
+		SetRole(role).
+		Save(ctx)
+	if errUpdate != nil {
+		return fmt.Errorf("db: %w", errUpdate)
+	}
+	if n == 0 {
+		return fmt.Errorf("user %q not found", login)
+	}
+	fmt.Printf("%q is %s now\n", login, role)
+	return nil
+}
+
+func readPassword(stdin io.Reader) (string, error) {
+	_, _ = fmt.Fprint(os.Stderr, "password: ")
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
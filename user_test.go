@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/bibliotheca/internal/auth"
+	"github.com/ninedraft/bibliotheca/storage/ent/user"
+)
+
+func TestAddUser(t *testing.T) {
+	ctx := context.Background()
+	cfg := testConfig(t)
+
+	if err := addUser(ctx, cfg, "ann", user.RoleLibrarian, strings.NewReader("correct horse\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		login    string
+		role     user.Role
+		password string
+		want     string
+	}{
+		{"existing", "ann", user.RoleReader, "correct horse\n", `user "ann" already exists`},
+		{"short password", "bob", user.RoleReader, "horse\n", "password must be at least"},
+		{"no password", "bob", user.RoleReader, "", "read password"},
+		{"unknown role", "bob", "owner", "correct horse\n", "role"},
+	}
+	for _, tc := range tests {
+		err := addUser(ctx, cfg, tc.login, tc.role, strings.NewReader(tc.password))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got %v, want %q", tc.name, err, tc.want)
+		}
+	}
+
+	if err := setRole(ctx, cfg, "ann", user.RoleAdmin); err != nil {
+		t.Fatal(err)
+	}
+	if err := setRole(ctx, cfg, "bob", user.RoleAdmin); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("missing user: got %v", err)
+	}
+
+	users, err := newStorage(t, cfg).client.User.Query().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].Login != "ann" || users[0].Role != user.RoleAdmin {
+		t.Fatalf("got users %v, want ann the admin", users)
+	}
+	// the line break is not a part of the password
+	if err := auth.CheckPassword(users[0].PasswordHash, "correct horse"); err != nil {
+		t.Errorf("password: %v", err)
+	}
+}